@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// FindFolder 获取未删除的文件夹, 不限所有者
+func (r *UploadRepo) FindFolder(ctx context.Context, folderId int64) (dao.Folder, error) {
+	return r.dao.FindFolder(ctx, folderId)
+}
+
+// FindFile 获取未删除的文件, 不限所有者
+func (r *UploadRepo) FindFile(ctx context.Context, fileId int64) (dao.File, error) {
+	return r.dao.FindFile(ctx, fileId)
+}
+
+// FindFolders 批量获取未删除的文件夹, 不限所有者
+func (r *UploadRepo) FindFolders(ctx context.Context, ids []int64) (map[int64]dao.Folder, error) {
+	return r.dao.FindFolders(ctx, ids)
+}
+
+// FindFiles 批量获取未删除的文件, 不限所有者
+func (r *UploadRepo) FindFiles(ctx context.Context, ids []int64) (map[int64]dao.File, error) {
+	return r.dao.FindFiles(ctx, ids)
+}
+
+// FolderRole 获取用户对文件夹的角色
+func (r *UploadRepo) FolderRole(ctx context.Context, uid int32, folder dao.Folder) (int8, error) {
+	return r.dao.FolderRole(ctx, uid, folder)
+}
+
+// FileRole 获取用户对文件的角色
+func (r *UploadRepo) FileRole(ctx context.Context, uid int32, file dao.File) (int8, error) {
+	return r.dao.FileRole(ctx, uid, file)
+}
+
+// GrantAccess 授予或修改用户对条目的角色
+func (r *UploadRepo) GrantAccess(ctx context.Context, acl *dao.Acl) error {
+	return r.dao.GrantAccess(ctx, acl)
+}
+
+// RevokeAccess 收回用户对条目的授权
+func (r *UploadRepo) RevokeAccess(ctx context.Context, ownerId int32, resourceType int8, resourceId int64, uid int32) (int64, error) {
+	return r.dao.RevokeAccess(ctx, ownerId, resourceType, resourceId, uid)
+}
+
+// ListAccess 获取条目上的直接授权
+func (r *UploadRepo) ListAccess(ctx context.Context, resourceType int8, resourceId int64) ([]dao.Acl, error) {
+	return r.dao.ListAccess(ctx, resourceType, resourceId)
+}
+
+// ListSharedWithMe 分页获取授权给用户的文件夹和文件
+func (r *UploadRepo) ListSharedWithMe(ctx context.Context, uid int32, page, size int) ([]dao.Acl, int64, error) {
+	return r.dao.ListSharedWithMe(ctx, uid, page, size)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// AddAuditLog 写入审计记录
+func (r *UploadRepo) AddAuditLog(ctx context.Context, l *dao.AuditLog) error {
+	return r.dao.AddAuditLog(ctx, l)
+}
+
+// ListAuditLogs 分页获取审计记录
+func (r *UploadRepo) ListAuditLogs(ctx context.Context, f dao.AuditFilter, page, size int) ([]dao.AuditLog, int64, error) {
+	return r.dao.ListAuditLogs(ctx, f, page, size)
+}
+
+// ScanAuditLogs 按写入顺序获取审计记录
+func (r *UploadRepo) ScanAuditLogs(ctx context.Context, f dao.AuditFilter, afterId int64, limit int) ([]dao.AuditLog, error) {
+	return r.dao.ScanAuditLogs(ctx, f, afterId, limit)
+}
+
+// GetAuditSubjects 获取审计记录目标的当前状态
+func (r *UploadRepo) GetAuditSubjects(ctx context.Context, fileIds, folderIds []int64, shareIds []string) (dao.AuditSubjects, error) {
+	return r.dao.GetAuditSubjects(ctx, fileIds, folderIds, shareIds)
+}
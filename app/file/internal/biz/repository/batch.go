@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// Transaction 在同一个事务中执行多个仓储操作
+func (r *UploadRepo) Transaction(ctx context.Context, fn func(r *UploadRepo) error) error {
+	return r.dao.Transaction(ctx, func(d *dao.UploadDao) error {
+		return fn(&UploadRepo{dao: d, cache: r.cache})
+	})
+}
+
+// RenameFile 重命名文件
+func (r *UploadRepo) RenameFile(ctx context.Context, fileId int64, uid int32, name string) error {
+	return r.dao.RenameFile(ctx, fileId, uid, name)
+}
+
+// RenameFolder 重命名文件夹
+func (r *UploadRepo) RenameFolder(ctx context.Context, folderId int64, uid int32, name string) error {
+	return r.dao.RenameFolder(ctx, folderId, uid, name)
+}
+
+// GetDeletedFolder 获取已删除的文件夹
+func (r *UploadRepo) GetDeletedFolder(ctx context.Context, folderId int64, uid int32) (dao.Folder, error) {
+	return r.dao.GetDeletedFolder(ctx, folderId, uid)
+}
+
+// RestoreFile 恢复文件
+func (r *UploadRepo) RestoreFile(ctx context.Context, fileId int64, uid int32, name string) error {
+	return r.dao.RestoreFile(ctx, fileId, uid, name)
+}
+
+// RestoreFolder 恢复文件夹
+func (r *UploadRepo) RestoreFolder(ctx context.Context, folderId int64, uid int32, name string) error {
+	return r.dao.RestoreFolder(ctx, folderId, uid, name)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// GetLatestUserKey 获取用户最新版本的密钥加密密钥
+func (r *UploadRepo) GetLatestUserKey(ctx context.Context, uid int32) (dao.UserKey, error) {
+	return r.dao.GetLatestUserKey(ctx, uid)
+}
+
+// GetUserKey 获取用户指定版本的密钥加密密钥
+func (r *UploadRepo) GetUserKey(ctx context.Context, uid, version int32) (dao.UserKey, error) {
+	return r.dao.GetUserKey(ctx, uid, version)
+}
+
+// CreateUserKey 保存新版本的密钥加密密钥
+func (r *UploadRepo) CreateUserKey(ctx context.Context, key *dao.UserKey) error {
+	return r.dao.CreateUserKey(ctx, key)
+}
+
+// ListUserKeysNotWrappedBy 分批获取不是以指定主密钥包裹的用户密钥
+func (r *UploadRepo) ListUserKeysNotWrappedBy(ctx context.Context, masterKeyId string, afterId int64, limit int) ([]dao.UserKey, error) {
+	return r.dao.ListUserKeysNotWrappedBy(ctx, masterKeyId, afterId, limit)
+}
+
+// UpdateUserKeyWrap 替换用户密钥的包裹结果
+func (r *UploadRepo) UpdateUserKeyWrap(ctx context.Context, id int64, wrapped []byte, masterKeyId string) error {
+	return r.dao.UpdateUserKeyWrap(ctx, id, wrapped, masterKeyId)
+}
+
+// ListKeyOwners 获取拥有密钥加密密钥的用户
+func (r *UploadRepo) ListKeyOwners(ctx context.Context) ([]int32, error) {
+	return r.dao.ListKeyOwners(ctx)
+}
+
+// DeleteUnusedUserKeys 删除不再使用的旧版本用户密钥
+func (r *UploadRepo) DeleteUnusedUserKeys(ctx context.Context, uid, version int32) (int64, error) {
+	return r.dao.DeleteUnusedUserKeys(ctx, uid, version)
+}
+
+// SaveBlobKey 保存对象的数据密钥
+func (r *UploadRepo) SaveBlobKey(ctx context.Context, key *dao.BlobKey) error {
+	return r.dao.SaveBlobKey(ctx, key)
+}
+
+// GetBlobKey 获取对象的数据密钥
+func (r *UploadRepo) GetBlobKey(ctx context.Context, objectKey string) (dao.BlobKey, error) {
+	return r.dao.GetBlobKey(ctx, objectKey)
+}
+
+// ListBlobKeysBelow 分批获取以旧版本用户密钥包裹的数据密钥
+func (r *UploadRepo) ListBlobKeysBelow(ctx context.Context, uid, version int32, afterKey string, limit int) ([]dao.BlobKey, error) {
+	return r.dao.ListBlobKeysBelow(ctx, uid, version, afterKey, limit)
+}
+
+// RewrapBlobKey 替换数据密钥的包裹结果
+func (r *UploadRepo) RewrapBlobKey(ctx context.Context, objectKey string, oldVersion, version int32, wrapped []byte) error {
+	return r.dao.RewrapBlobKey(ctx, objectKey, oldVersion, version, wrapped)
+}
+
+// DeleteBlobKey 删除对象的数据密钥
+func (r *UploadRepo) DeleteBlobKey(ctx context.Context, objectKey string) error {
+	return r.dao.DeleteBlobKey(ctx, objectKey)
+}
@@ -33,6 +33,7 @@ type DownloadTask struct {
 type DownloadedFile struct {
 	FileId     int64  `json:"file_id"`
 	Name       string `json:"name"` // 添加文件名
+	ObjectKey  string `json:"object_key"`
 	Path       string `json:"path"`
 	Size       int64  `json:"size"`
 	Status     string `json:"status"`      // pending/processing/completed/failed
@@ -113,6 +114,11 @@ func (c *FileCache) SavePartETag(ctx context.Context, uploadId string, partNumbe
 	return c.cmd.HSet(ctx, key, strconv.Itoa(partNumber), etag).Err()
 }
 
+func (c *FileCache) DeletePartETags(ctx context.Context, uploadId string) error {
+	key := fmt.Sprintf("upload:parts:%s", uploadId)
+	return c.cmd.Del(ctx, key).Err()
+}
+
 func (c *FileCache) GetPartETags(ctx context.Context, uploadId string) (map[int]string, error) {
 	key := fmt.Sprintf("upload:parts:%s", uploadId)
 	result, err := c.cmd.HGetAll(ctx, key).Result()
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	JobPrefix = "job:" // 异步任务信息
+)
+
+// Job 复制、转存等耗时操作的异步任务
+type Job struct {
+	UserId    int32     `json:"user_id"`
+	Type      string    `json:"type"`
+	Status    string    `json:"status"` // pending/processing/completed/failed
+	Total     int64     `json:"total"`
+	Done      int64     `json:"done"`
+	ResultId  int64     `json:"result_id"`
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SaveJob 保存任务信息
+func (c *FileCache) SaveJob(ctx context.Context, jobId string, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	return c.cmd.Set(ctx, JobPrefix+jobId, data, time.Hour*24).Err()
+}
+
+// GetJob 获取任务信息
+func (c *FileCache) GetJob(ctx context.Context, jobId string) (*Job, error) {
+	data, err := c.cmd.Get(ctx, JobPrefix+jobId).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// GetFolder 获取文件夹信息
+func (r *UploadRepo) GetFolder(ctx context.Context, folderId int64, uid int32) (dao.Folder, error) {
+	return r.dao.GetFolder(ctx, folderId, uid)
+}
+
+// ListSubtree 获取文件夹下的所有子文件夹和文件
+func (r *UploadRepo) ListSubtree(ctx context.Context, folderId int64, uid int32) ([]dao.Folder, []dao.File, error) {
+	return r.dao.ListSubtree(ctx, folderId, uid)
+}
+
+// ListNames 获取文件夹下未删除的文件和文件夹, 用于同名判断
+func (r *UploadRepo) ListNames(ctx context.Context, folderId int64, uid int32) ([]dao.NameEntry, error) {
+	return r.dao.ListNames(ctx, folderId, uid)
+}
+
+// CopyFile 复制文件
+func (r *UploadRepo) CopyFile(ctx context.Context, src dao.File, name string, toFolderId int64, uid int32) (dao.File, error) {
+	return r.dao.CopyFile(ctx, src, name, toFolderId, uid)
+}
+
+// CopyTree 复制文件夹
+func (r *UploadRepo) CopyTree(ctx context.Context, root dao.Folder, name string, toFolderId int64, uid int32,
+	folders []dao.Folder, files []dao.File, progress func(n int)) (dao.Folder, error) {
+	return r.dao.CopyTree(ctx, root, name, toFolderId, uid, folders, files, progress)
+}
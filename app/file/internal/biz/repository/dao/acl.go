@@ -0,0 +1,206 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 授权的对象类型
+const (
+	ResourceFolder int8 = 1
+	ResourceFile   int8 = 2
+)
+
+// 协作者的角色, 数值越大权限越高, 高的角色包含低的角色的全部权限
+const (
+	RoleViewer    int8 = 1 // 浏览、预览和下载
+	RoleCommenter int8 = 2 // 另可修改文件的自定义元数据
+	RoleEditor    int8 = 3 // 另可上传、修改、移动和删除
+	// RoleOwner 所有者, 不写入 ACL
+	RoleOwner int8 = 4
+)
+
+// ErrPermissionDenied 没有访问条目的权限
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Acl 文件夹或文件对其他用户的授权, 文件夹上的授权向下继承到整个子树
+type Acl struct {
+	Id           int64 `gorm:"primaryKey,autoIncrement"`
+	ResourceType int8  `gorm:"not null;uniqueIndex:uk_acl_resource_user"`
+	ResourceId   int64 `gorm:"not null;uniqueIndex:uk_acl_resource_user"`
+	UserId       int32 `gorm:"not null;uniqueIndex:uk_acl_resource_user;index:idx_acl_user"` // 被授权的用户
+	OwnerId      int32 `gorm:"not null;index"`                                               // 条目的所有者
+	Role         int8  `gorm:"not null"`
+	Ctime        int64 `gorm:"index:idx_acl_user"`
+	Utime        int64
+}
+
+// FindFolder 获取未删除的文件夹, 不限所有者, 调用方须自行检查权限
+func (d *UploadDao) FindFolder(ctx context.Context, folderId int64) (Folder, error) {
+	var folder Folder
+	err := d.db.WithContext(ctx).Model(&Folder{}).Where("id = ? AND status = 0", folderId).First(&folder).Error
+
+	return folder, err
+}
+
+// FindFile 获取未删除的文件, 不限所有者, 调用方须自行检查权限
+func (d *UploadDao) FindFile(ctx context.Context, fileId int64) (File, error) {
+	var file File
+	err := d.db.WithContext(ctx).Model(&File{}).Where("id = ? AND status = 0", fileId).First(&file).Error
+
+	return file, err
+}
+
+// FolderRole 获取用户对文件夹的角色, 取文件夹及其上级文件夹上的授权和团队成员身份中最高者, 没有权限时为 0
+func (d *UploadDao) FolderRole(ctx context.Context, uid int32, folder Folder) (int8, error) {
+	if folder.UserId == uid {
+		return RoleOwner, nil
+	}
+
+	db := d.db.WithContext(ctx)
+	ids, err := ancestorIds(db, folder.Id, folder.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	return maxRole(db, uid, folder.UserId, ResourceFolder, folder.Id, ids)
+}
+
+// FileRole 获取用户对文件的角色, 取文件及其所在文件夹和上级文件夹上的授权和团队成员身份中最高者, 没有权限时为 0
+func (d *UploadDao) FileRole(ctx context.Context, uid int32, file File) (int8, error) {
+	if file.UserId == uid {
+		return RoleOwner, nil
+	}
+
+	db := d.db.WithContext(ctx)
+	ids, err := ancestorIds(db, file.FolderId, file.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	return maxRole(db, uid, file.UserId, ResourceFile, file.Id, ids)
+}
+
+// GrantAccess 授予或修改用户对条目的角色
+func (d *UploadDao) GrantAccess(ctx context.Context, acl *Acl) error {
+	now := time.Now().Unix()
+	acl.Ctime, acl.Utime = now, now
+
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "resource_type"}, {Name: "resource_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "utime"}),
+	}).Create(acl).Error
+}
+
+// RevokeAccess 收回用户对条目的授权, 只有所有者可以收回
+func (d *UploadDao) RevokeAccess(ctx context.Context, ownerId int32, resourceType int8, resourceId int64, uid int32) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("resource_type = ? AND resource_id = ? AND user_id = ? AND owner_id = ?", resourceType, resourceId, uid, ownerId).
+		Delete(&Acl{})
+
+	return res.RowsAffected, res.Error
+}
+
+// ListAccess 获取条目上的直接授权, 不含从上级文件夹继承的授权
+func (d *UploadDao) ListAccess(ctx context.Context, resourceType int8, resourceId int64) ([]Acl, error) {
+	var acls []Acl
+	err := d.db.WithContext(ctx).Model(&Acl{}).
+		Where("resource_type = ? AND resource_id = ?", resourceType, resourceId).
+		Order("id").Find(&acls).Error
+
+	return acls, err
+}
+
+// ListSharedWithMe 分页获取直接授权给用户且未删除的文件夹和文件, 按授权时间倒序
+func (d *UploadDao) ListSharedWithMe(ctx context.Context, uid int32, page, size int) ([]Acl, int64, error) {
+	db := d.db.WithContext(ctx)
+	liveFolders := db.Session(&gorm.Session{NewDB: true}).Model(&Folder{}).Select("id").Where("status = 0")
+	liveFiles := db.Session(&gorm.Session{NewDB: true}).Model(&File{}).Select("id").Where("status = 0")
+	query := db.Model(&Acl{}).Where("user_id = ?", uid).
+		Where(db.Session(&gorm.Session{NewDB: true}).
+			Where("resource_type = ? AND resource_id IN (?)", ResourceFolder, liveFolders).
+			Or("resource_type = ? AND resource_id IN (?)", ResourceFile, liveFiles))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var acls []Acl
+	err := query.Order("ctime DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&acls).Error
+
+	return acls, total, err
+}
+
+// FindFolders 批量获取未删除的文件夹, 不限所有者
+func (d *UploadDao) FindFolders(ctx context.Context, ids []int64) (map[int64]Folder, error) {
+	res := make(map[int64]Folder, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var folders []Folder
+	if err := d.db.WithContext(ctx).Model(&Folder{}).Where("id IN ? AND status = 0", ids).Find(&folders).Error; err != nil {
+		return nil, err
+	}
+	for _, f := range folders {
+		res[f.Id] = f
+	}
+
+	return res, nil
+}
+
+// FindFiles 批量获取未删除的文件, 不限所有者
+func (d *UploadDao) FindFiles(ctx context.Context, ids []int64) (map[int64]File, error) {
+	res := make(map[int64]File, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var files []File
+	if err := d.db.WithContext(ctx).Model(&File{}).Where("id IN ? AND status = 0", ids).Find(&files).Error; err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		res[f.Id] = f
+	}
+
+	return res, nil
+}
+
+// maxRole 取用户在条目上的授权与团队空间成员身份对应的角色中较高者
+func maxRole(db *gorm.DB, uid, owner int32, resourceType int8, resourceId int64, folderIds []int64) (int8, error) {
+	role, err := accessRole(db, uid, resourceType, resourceId, folderIds)
+	if err != nil {
+		return 0, err
+	}
+	team, err := spaceRole(db, uid, owner)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(role, team), nil
+}
+
+// accessRole 获取用户在条目本身及 folderIds 上的授权中最高的角色
+func accessRole(db *gorm.DB, uid int32, resourceType int8, resourceId int64, folderIds []int64) (int8, error) {
+	query := db.Model(&Acl{}).Where("user_id = ?", uid)
+	cond := db.Session(&gorm.Session{NewDB: true}).Where("resource_type = ? AND resource_id = ?", resourceType, resourceId)
+	if len(folderIds) > 0 {
+		cond = cond.Or("resource_type = ? AND resource_id IN ?", ResourceFolder, folderIds)
+	}
+
+	var role *int8
+	if err := query.Where(cond).Select("MAX(role)").Scan(&role).Error; err != nil {
+		return 0, err
+	}
+	if role == nil {
+		return 0, nil
+	}
+
+	return *role, nil
+}
@@ -0,0 +1,205 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditLog 一次操作的审计记录, 只追加不修改
+type AuditLog struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	Action    string `gorm:"type:varchar(64);not null;index:idx_audit_action"`
+	Method    string `gorm:"type:varchar(128);not null"`
+	ActorId   int32  `gorm:"not null;index:idx_audit_actor"` // 匿名访问和管理接口为 0
+	OwnerId   int32  `gorm:"not null;index:idx_audit_owner"` // 目标的所有者, 用户可查询针对自己数据的操作
+	DeviceId  string `gorm:"type:varchar(64)"`
+	Ip        string `gorm:"type:varchar(64)"`
+	UserAgent string `gorm:"type:varchar(255)"`
+	Before    string `gorm:"type:text"` // 操作前目标的元数据, JSON
+	After     string `gorm:"type:text"` // 操作后目标的元数据, JSON
+	Code      string `gorm:"type:varchar(32);not null"`
+	Error     string `gorm:"type:varchar(255)"`
+	Ctime     int64  `gorm:"not null;index:idx_audit_actor;index:idx_audit_owner;index:idx_audit_action;index:idx_audit_ctime"`
+
+	Targets []AuditTarget `gorm:"-"`
+}
+
+// AuditTarget 审计记录涉及的对象, 用于按对象查询
+type AuditTarget struct {
+	LogId    int64  `gorm:"primaryKey"`
+	Type     string `gorm:"type:varchar(32);primaryKey;index:idx_audit_target"`
+	TargetId string `gorm:"type:varchar(64);primaryKey;index:idx_audit_target"`
+}
+
+// AuditFilter 审计日志的查询条件, 为零值的条件不生效
+type AuditFilter struct {
+	UserId     int32 // 操作者或目标所有者为该用户
+	ActorId    int32
+	Start      int64 // 包含
+	End        int64 // 不包含
+	Action     string
+	TargetType string
+	TargetId   string
+}
+
+// AuditSubjects 审计记录目标的当前状态, 不限状态和所有者
+type AuditSubjects struct {
+	Files   map[int64]File
+	Folders map[int64]Folder
+	Shares  map[string]ShareLink
+	Metas   map[int64][]FileMeta
+}
+
+// AddAuditLog 写入审计记录及其目标
+func (d *UploadDao) AddAuditLog(ctx context.Context, l *AuditLog) error {
+	if l.Ctime == 0 {
+		l.Ctime = time.Now().Unix()
+	}
+
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(l).Error; err != nil {
+			return err
+		}
+		if len(l.Targets) == 0 {
+			return nil
+		}
+		for i := range l.Targets {
+			l.Targets[i].LogId = l.Id
+		}
+		return tx.Create(&l.Targets).Error
+	})
+}
+
+// ListAuditLogs 分页获取审计记录, 最近的在前
+func (d *UploadDao) ListAuditLogs(ctx context.Context, f AuditFilter, page, size int) ([]AuditLog, int64, error) {
+	query := d.auditQuery(ctx, f)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []AuditLog
+	err := query.Order("ctime DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := d.loadAuditTargets(ctx, logs); err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+// ScanAuditLogs 按写入顺序获取 ID 大于 afterId 的审计记录, 用于导出
+func (d *UploadDao) ScanAuditLogs(ctx context.Context, f AuditFilter, afterId int64, limit int) ([]AuditLog, error) {
+	var logs []AuditLog
+	err := d.auditQuery(ctx, f).Where("id > ?", afterId).Order("id ASC").Limit(limit).Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, d.loadAuditTargets(ctx, logs)
+}
+
+// GetAuditSubjects 获取审计记录目标的当前状态
+func (d *UploadDao) GetAuditSubjects(ctx context.Context, fileIds, folderIds []int64, shareIds []string) (AuditSubjects, error) {
+	res := AuditSubjects{
+		Files:   make(map[int64]File, len(fileIds)),
+		Folders: make(map[int64]Folder, len(folderIds)),
+		Shares:  make(map[string]ShareLink, len(shareIds)),
+	}
+	db := d.db.WithContext(ctx)
+
+	if len(fileIds) > 0 {
+		var files []File
+		if err := db.Model(&File{}).Where("id IN ?", fileIds).Find(&files).Error; err != nil {
+			return res, err
+		}
+		for _, f := range files {
+			res.Files[f.Id] = f
+		}
+	}
+	if len(folderIds) > 0 {
+		var folders []Folder
+		if err := db.Model(&Folder{}).Where("id IN ?", folderIds).Find(&folders).Error; err != nil {
+			return res, err
+		}
+		for _, f := range folders {
+			res.Folders[f.Id] = f
+		}
+	}
+	if len(shareIds) > 0 {
+		var shares []ShareLink
+		if err := db.Model(&ShareLink{}).Where("id IN ?", shareIds).Find(&shares).Error; err != nil {
+			return res, err
+		}
+		for _, sh := range shares {
+			res.Shares[sh.Id] = sh
+		}
+	}
+
+	var err error
+	res.Metas, err = d.GetFilesMeta(ctx, fileIds)
+
+	return res, err
+}
+
+func (d *UploadDao) auditQuery(ctx context.Context, f AuditFilter) *gorm.DB {
+	query := d.db.WithContext(ctx).Model(&AuditLog{})
+	if f.UserId != 0 {
+		query = query.Where("(actor_id = ? OR owner_id = ?)", f.UserId, f.UserId)
+	}
+	if f.ActorId != 0 {
+		query = query.Where("actor_id = ?", f.ActorId)
+	}
+	if f.Start != 0 {
+		query = query.Where("ctime >= ?", f.Start)
+	}
+	if f.End != 0 {
+		query = query.Where("ctime < ?", f.End)
+	}
+	if prefix, ok := strings.CutSuffix(f.Action, "*"); ok {
+		query = query.Where("action LIKE ?", prefix+"%")
+	} else if f.Action != "" {
+		query = query.Where("action = ?", f.Action)
+	}
+	if f.TargetType != "" || f.TargetId != "" {
+		sub := d.db.WithContext(ctx).Model(&AuditTarget{}).Select("log_id")
+		if f.TargetType != "" {
+			sub = sub.Where("type = ?", f.TargetType)
+		}
+		if f.TargetId != "" {
+			sub = sub.Where("target_id = ?", f.TargetId)
+		}
+		query = query.Where("id IN (?)", sub)
+	}
+
+	return query
+}
+
+func (d *UploadDao) loadAuditTargets(ctx context.Context, logs []AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(logs))
+	index := make(map[int64]int, len(logs))
+	for i, l := range logs {
+		ids = append(ids, l.Id)
+		index[l.Id] = i
+	}
+
+	var targets []AuditTarget
+	if err := d.db.WithContext(ctx).Model(&AuditTarget{}).Where("log_id IN ?", ids).Find(&targets).Error; err != nil {
+		return err
+	}
+	for _, t := range targets {
+		i := index[t.LogId]
+		logs[i].Targets = append(logs[i].Targets, t)
+	}
+
+	return nil
+}
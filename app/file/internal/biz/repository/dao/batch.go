@@ -0,0 +1,230 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInsufficientSpace 用户剩余容量不足
+	ErrInsufficientSpace = errors.New("insufficient storage space")
+	// ErrFolderCycle 文件夹不能移动到自身或其子文件夹中
+	ErrFolderCycle = errors.New("cannot move folder into itself or its subfolder")
+)
+
+// Transaction 在同一个事务中执行多个操作, fn 中的 dao 共用该事务
+// 嵌套调用的事务方法会以 savepoint 的形式执行
+func (d *UploadDao) Transaction(ctx context.Context, fn func(d *UploadDao) error) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&UploadDao{db: tx})
+	})
+}
+
+// RenameFile 重命名文件
+func (d *UploadDao) RenameFile(ctx context.Context, fileId int64, uid int32, name string) error {
+	res := d.db.WithContext(ctx).Model(&File{}).
+		Where("id = ? AND user_id = ? AND status = 0", fileId, uid).
+		Updates(map[string]any{"name": NormalizeName(name), "name_key": d.naming.key(name), "utime": time.Now().Unix()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// RenameFolder 重命名文件夹, 同时更新所有子文件夹的路径
+func (d *UploadDao) RenameFolder(ctx context.Context, folderId int64, uid int32, name string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var folder Folder
+		if err := tx.Model(&Folder{}).Where("id = ? AND user_id = ? AND status = 0", folderId, uid).First(&folder).Error; err != nil {
+			return err
+		}
+
+		parentPath, err := folderPath(tx, folder.ParentId, uid)
+		if err != nil {
+			return err
+		}
+		folder.Name = NormalizeName(name)
+		folder.Path = JoinPath(parentPath, folder.Name)
+		folder.Utime = time.Now().Unix()
+		err = tx.Model(&Folder{}).Where("id = ?", folder.Id).
+			Updates(map[string]any{"name": folder.Name, "name_key": d.naming.key(folder.Name), "path": folder.Path, "utime": folder.Utime}).Error
+		if err != nil {
+			return err
+		}
+
+		return rebuildSubtreePaths(tx, folder)
+	})
+}
+
+// GetDeletedFolder 获取已删除的文件夹
+func (d *UploadDao) GetDeletedFolder(ctx context.Context, folderId int64, uid int32) (Folder, error) {
+	var folder Folder
+	err := d.db.WithContext(ctx).Model(&Folder{}).
+		Where("id = ? AND user_id = ? AND status = 1", folderId, uid).
+		First(&folder).Error
+	if err != nil {
+		return Folder{}, err
+	}
+
+	return folder, nil
+}
+
+// RestoreFile 恢复已删除的文件, name 为恢复后的名称
+func (d *UploadDao) RestoreFile(ctx context.Context, fileId int64, uid int32, name string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var file File
+		if err := tx.Model(&File{}).Where("id = ? AND user_id = ? AND status = 1", fileId, uid).First(&file).Error; err != nil {
+			return err
+		}
+
+		if err := ensureCapacity(tx, uid, file.Size); err != nil {
+			return err
+		}
+
+		now := time.Now().Unix()
+		err := tx.Model(&File{}).Where("id = ?", fileId).
+			Updates(map[string]any{"status": 0, "dtime": 0, "name": NormalizeName(name), "name_key": d.naming.key(name), "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		if err := adjustFolderStats(tx, file.FolderId, uid, file.Size, 1, now); err != nil {
+			return err
+		}
+
+		return addCurrentSize(tx, uid, file.Size)
+	})
+}
+
+// RestoreFolder 恢复已删除的文件夹, 以及与它在同一次操作中被删除的子文件夹和文件
+func (d *UploadDao) RestoreFolder(ctx context.Context, folderId int64, uid int32, name string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var folder Folder
+		if err := tx.Model(&Folder{}).Where("id = ? AND user_id = ? AND status = 1", folderId, uid).First(&folder).Error; err != nil {
+			return err
+		}
+
+		folders, files, err := listSubtree(tx, folderId, uid, func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = 1 AND dtime = ?", folder.Dtime)
+		})
+		if err != nil {
+			return err
+		}
+
+		restored := map[string]any{"status": 0, "dtime": 0}
+		var totalSize int64
+		fileIds := make([]int64, 0, len(files))
+		for _, f := range files {
+			fileIds = append(fileIds, f.Id)
+			totalSize += f.Size
+		}
+		if len(fileIds) > 0 {
+			if err := tx.Model(&File{}).Where("id IN ?", fileIds).Updates(restored).Error; err != nil {
+				return err
+			}
+		}
+		folderIds := make([]int64, 0, len(folders))
+		for _, f := range folders {
+			folderIds = append(folderIds, f.Id)
+		}
+		if len(folderIds) > 0 {
+			if err := tx.Model(&Folder{}).Where("id IN ?", folderIds).Updates(restored).Error; err != nil {
+				return err
+			}
+		}
+
+		parentPath, err := folderPath(tx, folder.ParentId, uid)
+		if err != nil {
+			return err
+		}
+		now := time.Now().Unix()
+		folder.Name = NormalizeName(name)
+		folder.Path = JoinPath(parentPath, folder.Name)
+		err = tx.Model(&Folder{}).Where("id = ?", folder.Id).
+			Updates(map[string]any{"status": 0, "dtime": 0, "name": folder.Name, "name_key": d.naming.key(folder.Name), "path": folder.Path, "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		if err := rebuildSubtreePaths(tx, folder); err != nil {
+			return err
+		}
+
+		if err := ensureCapacity(tx, uid, totalSize); err != nil {
+			return err
+		}
+		if err := adjustFolderStats(tx, folder.ParentId, uid, totalSize, int64(len(files)), now); err != nil {
+			return err
+		}
+
+		return addCurrentSize(tx, uid, totalSize)
+	})
+}
+
+// ensureCapacity 检查用户剩余容量能否再容纳 size 字节, 锁住存储记录直到事务结束, 避免并发写入同时通过检查
+func ensureCapacity(tx *gorm.DB, uid int32, size int64) error {
+	var store FileStore
+	err := tx.Model(&FileStore{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", uid).First(&store).Error
+	if err != nil {
+		return err
+	}
+	if store.CurrentSize+store.Reserved+size > store.Capacity {
+		return ErrInsufficientSpace
+	}
+
+	return nil
+}
+
+// folderPath 获取文件夹路径, 0 表示根目录
+func folderPath(tx *gorm.DB, folderId int64, uid int32) (string, error) {
+	if folderId == 0 {
+		return "", nil
+	}
+
+	var parent Folder
+	err := tx.Model(&Folder{}).Where("id = ? AND user_id = ? AND status = 0", folderId, uid).First(&parent).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New("parent folder not found")
+		}
+		return "", err
+	}
+
+	return parent.Path, nil
+}
+
+// rebuildSubtreePaths 根据 root 的新路径逐层重新计算子文件夹路径
+func rebuildSubtreePaths(tx *gorm.DB, root Folder) error {
+	paths := map[int64]string{root.Id: root.Path}
+	level := []int64{root.Id}
+	for len(level) > 0 {
+		var children []Folder
+		err := tx.Model(&Folder{}).
+			Where("parent_id IN ? AND user_id = ? AND status = 0", level, root.UserId).
+			Find(&children).Error
+		if err != nil {
+			return err
+		}
+
+		level = make([]int64, 0, len(children))
+		for _, c := range children {
+			path := JoinPath(paths[c.ParentId], c.Name)
+			if path != c.Path {
+				if err := tx.Model(&Folder{}).Where("id = ?", c.Id).Update("path", path).Error; err != nil {
+					return err
+				}
+			}
+			paths[c.Id] = path
+			level = append(level, c.Id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrUserKeyNotFound 用户的密钥加密密钥不存在
+var ErrUserKeyNotFound = errors.New("user key not found")
+
+// UserKey 用户的密钥加密密钥, 以主密钥包裹保存
+// 轮换时生成新版本, 旧版本在其包裹的数据密钥全部重新包裹后删除
+type UserKey struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	UserId      int32  `gorm:"not null;uniqueIndex:uk_user_version"`
+	Version     int32  `gorm:"not null;uniqueIndex:uk_user_version"`
+	WrappedKey  []byte `gorm:"type:varbinary(128);not null"`
+	MasterKeyId string `gorm:"type:varchar(64);not null;index"`
+	Ctime       int64
+	Utime       int64
+}
+
+// BlobKey 对象的数据密钥, 以写入时所属用户的密钥加密密钥包裹保存
+// 对象内容不随文件转移或复制而改变, 因此沿用原来的密钥; 没有记录的对象为明文
+type BlobKey struct {
+	ObjectKey  string `gorm:"primaryKey;type:varchar(255)"`
+	KeyOwner   int32  `gorm:"not null;index:idx_blob_kek"`
+	KekVersion int32  `gorm:"not null;index:idx_blob_kek"`
+	WrappedKey []byte `gorm:"type:varbinary(128);not null"`
+	PartSize   int64  `gorm:"not null;default:0"` // 分片上传时除最后一个分片外每个分片的明文大小
+	Ctime      int64
+	Utime      int64
+}
+
+// GetLatestUserKey 获取用户最新版本的密钥加密密钥
+func (d *UploadDao) GetLatestUserKey(ctx context.Context, uid int32) (UserKey, error) {
+	var key UserKey
+	err := d.db.WithContext(ctx).Where("user_id = ?", uid).Order("version DESC").First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return UserKey{}, ErrUserKeyNotFound
+	}
+
+	return key, err
+}
+
+// GetUserKey 获取用户指定版本的密钥加密密钥
+func (d *UploadDao) GetUserKey(ctx context.Context, uid, version int32) (UserKey, error) {
+	var key UserKey
+	err := d.db.WithContext(ctx).Where("user_id = ? AND version = ?", uid, version).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return UserKey{}, ErrUserKeyNotFound
+	}
+
+	return key, err
+}
+
+// CreateUserKey 保存新版本的密钥加密密钥, 版本已存在时返回 gorm.ErrDuplicatedKey
+func (d *UploadDao) CreateUserKey(ctx context.Context, key *UserKey) error {
+	now := time.Now().Unix()
+	key.Ctime, key.Utime = now, now
+
+	return d.db.WithContext(ctx).Create(key).Error
+}
+
+// ListUserKeysNotWrappedBy 获取不是以 masterKeyId 包裹的用户密钥, 按 id 分批
+func (d *UploadDao) ListUserKeysNotWrappedBy(ctx context.Context, masterKeyId string, afterId int64, limit int) ([]UserKey, error) {
+	var keys []UserKey
+	err := d.db.WithContext(ctx).Where("master_key_id <> ? AND id > ?", masterKeyId, afterId).
+		Order("id").Limit(limit).Find(&keys).Error
+
+	return keys, err
+}
+
+// UpdateUserKeyWrap 以新的主密钥包裹结果替换用户密钥, 密钥本身不变
+func (d *UploadDao) UpdateUserKeyWrap(ctx context.Context, id int64, wrapped []byte, masterKeyId string) error {
+	return d.db.WithContext(ctx).Model(&UserKey{}).Where("id = ?", id).
+		Updates(map[string]any{"wrapped_key": wrapped, "master_key_id": masterKeyId, "utime": time.Now().Unix()}).Error
+}
+
+// ListKeyOwners 获取拥有密钥加密密钥的用户
+func (d *UploadDao) ListKeyOwners(ctx context.Context) ([]int32, error) {
+	var uids []int32
+	err := d.db.WithContext(ctx).Model(&UserKey{}).Distinct("user_id").Pluck("user_id", &uids).Error
+
+	return uids, err
+}
+
+// DeleteUnusedUserKeys 删除用户低于 version 且已不再包裹任何数据密钥的旧版本
+func (d *UploadDao) DeleteUnusedUserKeys(ctx context.Context, uid, version int32) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("user_id = ? AND version < ?", uid, version).
+		Where("NOT EXISTS (SELECT 1 FROM blob_key WHERE blob_key.key_owner = user_key.user_id AND blob_key.kek_version = user_key.version)").
+		Delete(&UserKey{})
+
+	return res.RowsAffected, res.Error
+}
+
+// SaveBlobKey 保存对象的数据密钥, 对象被重写时替换原来的密钥
+func (d *UploadDao) SaveBlobKey(ctx context.Context, key *BlobKey) error {
+	now := time.Now().Unix()
+	key.Ctime, key.Utime = now, now
+
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "object_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"key_owner", "kek_version", "wrapped_key", "part_size", "utime"}),
+	}).Create(key).Error
+}
+
+// GetBlobKey 获取对象的数据密钥, 对象未加密时返回 gorm.ErrRecordNotFound
+func (d *UploadDao) GetBlobKey(ctx context.Context, objectKey string) (BlobKey, error) {
+	var key BlobKey
+	err := d.db.WithContext(ctx).Where("object_key = ?", objectKey).First(&key).Error
+
+	return key, err
+}
+
+// ListBlobKeysBelow 获取以用户低于 version 的密钥加密密钥包裹的数据密钥, 按 object_key 分批
+func (d *UploadDao) ListBlobKeysBelow(ctx context.Context, uid, version int32, afterKey string, limit int) ([]BlobKey, error) {
+	var keys []BlobKey
+	err := d.db.WithContext(ctx).Where("key_owner = ? AND kek_version < ? AND object_key > ?", uid, version, afterKey).
+		Order("object_key").Limit(limit).Find(&keys).Error
+
+	return keys, err
+}
+
+// RewrapBlobKey 以新版本的密钥加密密钥包裹结果替换数据密钥, 仅当记录仍为 oldVersion 时更新
+// 期间对象被重写时, 新的密钥已使用最新版本, 不会被覆盖
+func (d *UploadDao) RewrapBlobKey(ctx context.Context, objectKey string, oldVersion, version int32, wrapped []byte) error {
+	return d.db.WithContext(ctx).Model(&BlobKey{}).
+		Where("object_key = ? AND kek_version = ?", objectKey, oldVersion).
+		Updates(map[string]any{"kek_version": version, "wrapped_key": wrapped, "utime": time.Now().Unix()}).Error
+}
+
+// DeleteBlobKey 删除对象的数据密钥, 对象以明文重写时调用
+func (d *UploadDao) DeleteBlobKey(ctx context.Context, objectKey string) error {
+	return d.db.WithContext(ctx).Where("object_key = ?", objectKey).Delete(&BlobKey{}).Error
+}
@@ -0,0 +1,192 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// copyBatchSize 复制文件时每批写入的行数
+const copyBatchSize = 100
+
+// CopyFile 复制单个文件, 新文件与源文件共用对象存储中的内容
+func (d *UploadDao) CopyFile(ctx context.Context, src File, name string, toFolderId int64, uid int32) (File, error) {
+	var dst File
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		objectKey := src.ObjectName()
+		src.Name, src.ObjectKey = name, objectKey
+		files, err := d.copyFiles(tx, []File{src}, map[int64]int64{src.FolderId: toFolderId}, uid)
+		if err != nil {
+			return err
+		}
+		dst = files[0]
+
+		if err := adjustFolderStats(tx, toFolderId, uid, src.Size, 1, dst.Utime); err != nil {
+			return err
+		}
+
+		return addCurrentSize(tx, uid, src.Size)
+	})
+	if err != nil {
+		return File{}, err
+	}
+
+	return dst, nil
+}
+
+// CopyTree 复制整个文件夹, root 为源文件夹, folders 和 files 为 ListSubtree 的结果
+// 副本沿用源条目所在的保险库, 调用方需保证不会复制保险库的根文件夹
+// progress 在每批写入后回调已复制的条目数
+func (d *UploadDao) CopyTree(ctx context.Context, root Folder, name string, toFolderId int64, uid int32,
+	folders []Folder, files []File, progress func(n int)) (Folder, error) {
+	var newRoot Folder
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Unix()
+
+		var parentPath string
+		if toFolderId != 0 {
+			var parent Folder
+			if err := tx.Model(&Folder{}).Where("id = ? AND user_id = ?", toFolderId, uid).First(&parent).Error; err != nil {
+				return err
+			}
+			parentPath = parent.Path
+		}
+
+		newRoot = Folder{
+			Name:     name,
+			NameKey:  d.naming.key(name),
+			ParentId: toFolderId,
+			UserId:   uid,
+			Path:     JoinPath(parentPath, name),
+			VaultId:  root.VaultId,
+			Ctime:    now,
+			Utime:    now,
+		}
+		if err := tx.Create(&newRoot).Error; err != nil {
+			return err
+		}
+
+		// 旧文件夹 ID -> 新文件夹
+		mapping := map[int64]Folder{root.Id: newRoot}
+		for _, f := range folders {
+			parent := mapping[f.ParentId]
+			nf := Folder{
+				Name:     f.Name,
+				NameKey:  d.naming.key(f.Name),
+				ParentId: parent.Id,
+				UserId:   uid,
+				Path:     JoinPath(parent.Path, f.Name),
+				VaultId:  f.VaultId,
+				Ctime:    now,
+				Utime:    now,
+			}
+			if err := tx.Create(&nf).Error; err != nil {
+				return err
+			}
+			mapping[f.Id] = nf
+			progress(1)
+		}
+
+		parents := make(map[int64]int64, len(mapping))
+		for oldId, nf := range mapping {
+			parents[oldId] = nf.Id
+		}
+
+		var total int64
+		for i := 0; i < len(files); i += copyBatchSize {
+			end := min(i+copyBatchSize, len(files))
+			if _, err := d.copyFiles(tx, files[i:end], parents, uid); err != nil {
+				return err
+			}
+			for _, f := range files[i:end] {
+				total += f.Size
+			}
+			progress(end - i)
+		}
+
+		stats, err := setCopiedTreeStats(tx, root, folders, files, mapping)
+		if err != nil {
+			return err
+		}
+		newRoot.TotalSize, newRoot.FileCount, newRoot.LastModified = stats.TotalSize, stats.FileCount, stats.LastModified
+		if err := adjustFolderStats(tx, toFolderId, uid, stats.TotalSize, stats.FileCount, now); err != nil {
+			return err
+		}
+
+		return addCurrentSize(tx, uid, total)
+	})
+	if err != nil {
+		return Folder{}, err
+	}
+
+	return newRoot, nil
+}
+
+// copyFiles 批量插入文件副本并复制元数据, parents 为源文件夹 ID 到目标文件夹 ID 的映射
+func (d *UploadDao) copyFiles(tx *gorm.DB, files []File, parents map[int64]int64, uid int32) ([]File, error) {
+	now := time.Now().Unix()
+	rows := make([]File, 0, len(files))
+	for _, f := range files {
+		rows = append(rows, File{
+			Name:      f.Name,
+			NameKey:   d.naming.key(f.Name),
+			Hash:      f.Hash,
+			Sha256:    f.Sha256,
+			Type:      f.Type,
+			Path:      f.Path,
+			Size:      f.Size,
+			UserId:    uid,
+			FolderId:  parents[f.FolderId],
+			Ctime:     now,
+			Utime:     now,
+			Version:   1,
+			ObjectKey: f.ObjectName(),
+			VaultId:   f.VaultId,
+		})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	// 批量复制元数据
+	ids := make(map[int64]int64, len(files))
+	srcIds := make([]int64, 0, len(files))
+	for i, f := range files {
+		ids[f.Id] = rows[i].Id
+		srcIds = append(srcIds, f.Id)
+	}
+	var metas []FileMeta
+	if err := tx.Model(&FileMeta{}).Where("file_id IN ?", srcIds).Find(&metas).Error; err != nil {
+		return nil, err
+	}
+	if len(metas) > 0 {
+		for i := range metas {
+			metas[i].Id = 0
+			metas[i].FileId = ids[metas[i].FileId]
+			metas[i].UserId = uid
+			metas[i].Ctime = now
+			metas[i].Utime = now
+		}
+		if err := tx.Create(&metas).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
+// addCurrentSize 调整用户已用空间, size 为负数时减少, 并检查用量是否跨越阈值
+func addCurrentSize(tx *gorm.DB, uid int32, size int64) error {
+	if size == 0 {
+		return nil
+	}
+
+	err := tx.Model(&FileStore{}).Where("user_id = ?", uid).
+		Update("current_size", gorm.Expr("current_size + ?", size)).Error
+	if err != nil {
+		return err
+	}
+
+	return checkQuotaLevel(tx, uid)
+}
@@ -0,0 +1,76 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FileEvent 待发送的文件变更事件, 在发送前写入
+// 由后台任务分别发送到 Kafka 和创建 webhook 投递, 一边失败不影响另一边, 失败的一边下一轮重试
+type FileEvent struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	UserId    int32  `gorm:"not null"`
+	EventType string `gorm:"type:varchar(64);not null"`
+	Payload   []byte `gorm:"type:blob;not null"`           // JSON 编码的事件
+	Queued    bool   `gorm:"not null;default:false;index"` // 已创建 webhook 投递
+	Sent      bool   `gorm:"not null;default:false"`       // 已发送到 Kafka
+	Ctime     int64  `gorm:"not null;index"`
+}
+
+// AddFileEvent 记录待发送的文件变更事件
+func (d *UploadDao) AddFileEvent(ctx context.Context, e *FileEvent) error {
+	e.Ctime = time.Now().Unix()
+
+	return d.db.WithContext(ctx).Create(e).Error
+}
+
+// ListPendingFileEvents 获取 before 及以前写入、尚未发送完的事件
+func (d *UploadDao) ListPendingFileEvents(ctx context.Context, before int64, limit int) ([]FileEvent, error) {
+	var events []FileEvent
+	err := d.db.WithContext(ctx).Model(&FileEvent{}).
+		Where("(queued = ? OR sent = ?) AND ctime <= ?", false, false, before).
+		Order("id ASC").Limit(limit).
+		Find(&events).Error
+
+	return events, err
+}
+
+// AddEventWebhookDeliveries 为事件创建等待投递的记录, 事件已经创建过投递时返回 false
+func (d *UploadDao) AddEventWebhookDeliveries(ctx context.Context, eventId int64, deliveries []WebhookDelivery) (bool, error) {
+	var queued bool
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&FileEvent{}).Where("id = ? AND queued = ?", eventId, false).Update("queued", true)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		queued = true
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		now := time.Now().Unix()
+		for i := range deliveries {
+			deliveries[i].Status = DeliveryPending
+			deliveries[i].NextRun, deliveries[i].Ctime, deliveries[i].Utime = now, now, now
+		}
+		return tx.Create(&deliveries).Error
+	})
+
+	return queued, err
+}
+
+// MarkFileEventSent 标记事件已发送到 Kafka
+func (d *UploadDao) MarkFileEventSent(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Model(&FileEvent{}).Where("id = ?", id).Update("sent", true).Error
+}
+
+// PurgeFileEvents 删除已发送完的事件, 以及 before 以前写入、已创建投递但一直没能发送到 Kafka 的事件
+func (d *UploadDao) PurgeFileEvents(ctx context.Context, before int64) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("queued = ? AND (sent = ? OR ctime < ?)", true, true, before).
+		Delete(&FileEvent{})
+
+	return res.RowsAffected, res.Error
+}
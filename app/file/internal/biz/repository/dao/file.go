@@ -3,26 +3,58 @@ package dao
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Models 文件服务的全部表, 启动时自动迁移
+func Models() []any {
+	return []any{&File{}, &FileStore{}, &Folder{}, &FileMeta{}, &FileVersion{}, &QuotaReservation{},
+		&StoragePlan{}, &CapacityGrant{}, &QuotaEvent{}, &ShareLink{}, &ShareFile{},
+		&ShareAccess{}, &Acl{},
+		&Space{}, &SpaceMember{}, &SpaceActivity{}, &FileRequest{}, &FileRequestUpload{},
+		&UserKey{}, &BlobKey{}, &UserPublicKey{}, &VaultKeyEnvelope{}, &UploadIntent{},
+		&ScrubFinding{}, &FileCommitEvent{}, &FileProcess{},
+		&QuarantinedFile{},
+		&Webhook{}, &WebhookDelivery{}, &FileEvent{}, &AuditLog{}, &AuditTarget{}}
+}
+
 type File struct {
 	Id             int64  `gorm:"primaryKey"`
 	Name           string `gorm:"type:varchar(255);not null"`
 	Hash           string `gorm:"type:varchar(32);not null"`
+	Sha256         string `gorm:"type:varchar(64);not null;default:'';index"` // 服务端计算的内容 SHA-256
 	Type           string `gorm:"type:varchar(50);not null"`
 	Path           string `gorm:"type:varchar(255);not null"`
 	Size           int64  `gorm:"not null"`
-	UserId         int32  `gorm:"not null"`
-	FolderId       int64  `gorm:"not null"`
+	UserId         int32  `gorm:"not null;uniqueIndex:uk_file_live_name"`
+	FolderId       int64  `gorm:"not null;uniqueIndex:uk_file_live_name"`
 	Ctime          int64  `gorm:"not null"`
 	Utime          int64  `gorm:"not null"`
 	Version        int32  `gorm:"not null;default:1"` // 文件版本号
 	DeviceId       string `gorm:"type:varchar(64)"`   // 设备ID
 	LastModifiedBy string `gorm:"type:varchar(64)"`   // 最后修改者
+	ObjectKey      string `gorm:"type:varchar(255)"`  // 对象存储中的 key, 复制出的文件与源文件共用
+	Status         int    `gorm:"not null;default:0"` // 状态：0-正常 1-已删除 2-已隔离
+	Dtime          int64  // 删除时间, 同一次删除的文件(夹)相同, 用于恢复
+	// NameKey 名称唯一性形式的摘要, 保证同一文件夹下未删除的文件不重名, 删除后置为 NULL
+	NameKey *string `gorm:"type:varchar(64);uniqueIndex:uk_file_live_name"`
+	// VaultId 所在的保险库, 0 表示不在保险库中; 保险库中的名称和内容都是客户端加密的密文
+	VaultId int64 `gorm:"not null;default:0;index"`
+	// ScrubTime 巡检最近一次校验内容的时间
+	ScrubTime int64 `gorm:"not null;default:0;index"`
+
+	Metas []FileMeta `gorm:"-"` // 创建时一并写入的自定义元数据
+}
+
+// ObjectName 返回文件内容在对象存储中的 key, 兼容没有 ObjectKey 的历史数据
+func (f *File) ObjectName() string {
+	if f.ObjectKey != "" {
+		return f.ObjectKey
+	}
+
+	return f.Name
 }
 
 // ShareLink 分享链接表
@@ -30,10 +62,16 @@ type ShareLink struct {
 	Id        string    `gorm:"primaryKey"`       // 分享ID
 	UserId    int32     `gorm:"index:idx_user"`   // 分享者ID
 	FolderId  int64     `gorm:"index:idx_folder"` // 分享的文件夹ID
-	Password  string    // 提取密码
+	Password  string    // 提取密码的 bcrypt 哈希, 为空表示无密码
 	CreatedAt time.Time // 创建时间
 	ExpireAt  time.Time `gorm:"index:idx_expire"` // 过期时间
-	Status    int8      `gorm:"index:idx_status"` // 状态：1-有效 2-已过期 3-已取消
+	Status    int8      `gorm:"index:idx_status"` // 状态：1-有效 2-已过期 3-已取消 4-已禁用(包含被隔离的文件)
+
+	MaxDownloads  int64 `gorm:"not null;default:0"` // 下载次数上限, 0 表示不限
+	MaxSaves      int64 `gorm:"not null;default:0"` // 转存次数上限, 0 表示不限
+	ViewCount     int64 `gorm:"not null;default:0"`
+	DownloadCount int64 `gorm:"not null;default:0"`
+	SaveCount     int64 `gorm:"not null;default:0"`
 }
 
 // ShareFile 分享文件关联表
@@ -45,12 +83,22 @@ type ShareFile struct {
 type Folder struct {
 	Id       int64 `gorm:"primaryKey,autoIncrement"`
 	Name     string
-	ParentId int64  `gorm:"index:uid_pid_status"` // 添加联合索引
-	UserId   int32  `gorm:"index:uid_pid_status"`
+	ParentId int64  `gorm:"index:uid_pid_status;uniqueIndex:uk_folder_live_name"` // 添加联合索引
+	UserId   int32  `gorm:"index:uid_pid_status;uniqueIndex:uk_folder_live_name"`
 	Path     string `gorm:"index"` // 添加索引支持路径搜索
 	Status   int    `gorm:"index:uid_pid_status"`
 	Ctime    int64
 	Utime    int64
+	Dtime    int64 // 删除时间
+	// NameKey 名称唯一性形式的摘要, 保证同一文件夹下未删除的子文件夹不重名, 删除后置为 NULL
+	NameKey *string `gorm:"type:varchar(64);uniqueIndex:uk_folder_live_name"`
+	// VaultId 所在的保险库, 保险库的根文件夹为自身ID, 子文件夹继承, 0 表示不在保险库中
+	VaultId int64 `gorm:"not null;default:0;index"`
+
+	// 子树的聚合统计, 在文件增删改和移动时增量维护
+	TotalSize    int64 `gorm:"not null;default:0"` // 子树中文件的总大小
+	FileCount    int64 `gorm:"not null;default:0"` // 子树中的文件数
+	LastModified int64 `gorm:"not null;default:0"` // 子树中文件的最近变更时间
 }
 
 type FileStore struct {
@@ -58,34 +106,55 @@ type FileStore struct {
 	UserId      int32 `gorm:"unique"`
 	Capacity    int64 `gorm:"default:10737418240"`
 	CurrentSize int64
+	Reserved    int64 `gorm:"not null;default:0"` // 进行中的上传预留的空间
+	PlanId      int64 `gorm:"not null;default:0"` // 存储套餐, 0 表示默认套餐
+	QuotaLevel  int   `gorm:"not null;default:0"` // 用量当前所处的阈值(百分比)
 	Ctime       int64
 	Utime       int64
 }
 
 type UploadDao struct {
-	db *gorm.DB
+	db     *gorm.DB
+	naming Naming
+}
+
+func NewUploadDao(db *gorm.DB, naming Naming) *UploadDao {
+	return &UploadDao{db: db, naming: naming}
 }
 
-func NewUploadDao(db *gorm.DB) *UploadDao {
-	return &UploadDao{db: db}
+// Naming 名称唯一性的规则
+func (d *UploadDao) Naming() Naming {
+	return d.naming
 }
 
 func (d *UploadDao) CreateFile(ctx context.Context, file *File) error {
 	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now().Unix()
+		file.Name = NormalizeName(file.Name)
+		file.NameKey = d.naming.key(file.Name)
 		file.Ctime = now
 		file.Utime = now
-		err := tx.WithContext(ctx).Model(&File{}).Create(file).Error
+		vaultId, err := folderVaultId(tx, file.FolderId)
+		if err != nil {
+			return err
+		}
+		file.VaultId = vaultId
+		err = tx.WithContext(ctx).Model(&File{}).Create(file).Error
 		if err != nil {
 			return err
 		}
 
-		err = tx.WithContext(ctx).Model(&FileStore{}).Where("user_id = ?", file.UserId).Update("current_size", gorm.Expr(fmt.Sprintf("current_size + %d", file.Size))).Error
+		err = upsertFileMeta(tx, file.Id, file.UserId, file.Metas)
 		if err != nil {
 			return err
 		}
 
-		return nil
+		err = adjustFolderStats(tx, file.FolderId, file.UserId, file.Size, 1, now)
+		if err != nil {
+			return err
+		}
+
+		return addCurrentSize(tx, file.UserId, file.Size)
 	})
 	if err != nil {
 		return err
@@ -106,39 +175,48 @@ func (d *UploadDao) UpdateFile(ctx context.Context, file *File) error {
 		sizeDiff := file.Size - oldFile.Size
 
 		// 更新文件记录
+		now := time.Now().Unix()
 		updates := map[string]interface{}{
-			"utime": time.Now().Unix(),
+			"utime": now,
 		}
 
 		if file.Name != "" {
-			updates["name"] = file.Name
+			updates["name"] = NormalizeName(file.Name)
+			updates["name_key"] = d.naming.key(file.Name)
 		}
 		if file.Hash != "" {
 			updates["hash"] = file.Hash
 		}
+		if file.Sha256 != "" {
+			updates["sha256"] = file.Sha256
+		}
 		if file.Size > 0 {
 			updates["size"] = file.Size
 		}
+		if file.ObjectKey != "" {
+			updates["object_key"] = file.ObjectKey
+		}
+		if file.Version > 0 {
+			updates["version"] = file.Version
+		}
+		if file.DeviceId != "" {
+			updates["device_id"] = file.DeviceId
+			updates["last_modified_by"] = file.LastModifiedBy
+		}
 
 		if err := tx.Model(&File{}).Where("id = ?", file.Id).Updates(updates).Error; err != nil {
 			return err
 		}
 
-		// 更新存储空间使用量
-		if sizeDiff != 0 {
-			expr := "current_size + ?"
-			if sizeDiff < 0 {
-				expr = "current_size - ?"
-				sizeDiff = -sizeDiff
-			}
-
-			if err := tx.Model(&FileStore{}).Where("user_id = ?", file.UserId).
-				Update("current_size", gorm.Expr(expr, sizeDiff)).Error; err != nil {
-				return err
-			}
+		if file.Size <= 0 {
+			sizeDiff = 0
+		}
+		if err := adjustFolderStats(tx, oldFile.FolderId, oldFile.UserId, sizeDiff, 0, now); err != nil {
+			return err
 		}
 
-		return nil
+		// 更新存储空间使用量
+		return addCurrentSize(tx, file.UserId, sizeDiff)
 	})
 }
 
@@ -152,9 +230,10 @@ func (d *UploadDao) GetFile(ctx context.Context, fid int64, uid int32) (File, er
 	return file, nil
 }
 
-func (d *UploadDao) QueryByHash(ctx context.Context, hash string) (File, error) {
+func (d *UploadDao) QueryBySha256(ctx context.Context, sum string) (File, error) {
 	var file File
-	err := d.db.WithContext(ctx).Model(&File{}).Where("hash = ?", hash).Find(&file).Error
+	// 保险库中的文件是密文, 不参与秒传; 已删除的文件可能随时被清理, 隔离的文件不能复用
+	err := d.db.WithContext(ctx).Model(&File{}).Where("sha256 = ? AND vault_id = 0 AND status = 0", sum).Find(&file).Error
 	if err != nil {
 		return File{}, err
 	}
@@ -168,7 +247,7 @@ func (d *UploadDao) QueryCapacity(ctx context.Context, uid int32, size int64) (b
 	if err != nil {
 		return false, err
 	}
-	if store.Capacity < size+store.CurrentSize {
+	if store.Capacity < size+store.CurrentSize+store.Reserved {
 		return false, nil
 	}
 
@@ -190,6 +269,8 @@ func (d *UploadDao) CreateFileStore(ctx context.Context, store *FileStore) (int3
 func (d *UploadDao) CreateFolder(ctx context.Context, folder *Folder) error {
 	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now().Unix()
+		folder.Name = NormalizeName(folder.Name)
+		folder.NameKey = d.naming.key(folder.Name)
 		folder.Ctime = now
 		folder.Utime = now
 
@@ -199,10 +280,8 @@ func (d *UploadDao) CreateFolder(ctx context.Context, folder *Folder) error {
 			return err
 		}
 
-		if parent.Path == "" {
-			folder.Path = folder.Name
-		}
-		folder.Path = parent.Path + "/" + folder.Name
+		folder.Path = JoinPath(parent.Path, folder.Name)
+		folder.VaultId = parent.VaultId
 		err = tx.WithContext(ctx).Model(&Folder{}).Create(folder).Error
 
 		return err
@@ -211,144 +290,222 @@ func (d *UploadDao) CreateFolder(ctx context.Context, folder *Folder) error {
 	return err
 }
 
-func (d *UploadDao) MoveFile(ctx context.Context, fileId, toFolderId int64, uid int32) error {
-	return d.db.WithContext(ctx).Model(&File{}).
-		Where("user_id = ? AND id = ?", uid, fileId).
-		Update("folder_id", toFolderId).Error
+// MoveFile 将文件移动到 toFolderId 下并命名为 name, name 为空时保持原名
+func (d *UploadDao) MoveFile(ctx context.Context, fileId, toFolderId int64, uid int32, name string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var file File
+		if err := tx.Model(&File{}).Where("user_id = ? AND id = ? AND status = 0", uid, fileId).First(&file).Error; err != nil {
+			return err
+		}
+		if name != "" {
+			name = NormalizeName(name)
+		} else {
+			name = file.Name
+		}
+		if file.FolderId == toFolderId && file.Name == name {
+			return nil
+		}
+
+		now := time.Now().Unix()
+		err := tx.Model(&File{}).Where("id = ?", fileId).
+			Updates(map[string]any{"folder_id": toFolderId, "name": name, "name_key": d.naming.key(name), "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		if file.FolderId == toFolderId {
+			return nil
+		}
+
+		if err := adjustFolderStats(tx, file.FolderId, uid, -file.Size, -1, now); err != nil {
+			return err
+		}
+		return adjustFolderStats(tx, toFolderId, uid, file.Size, 1, now)
+	})
 }
 
-func (d *UploadDao) MoveFolder(ctx context.Context, folderId, toFolderId int64, uid int32, name string) error {
+// MoveFolder 将文件夹移动到 toFolderId 下并命名为 name, 同一事务中更新它和所有子文件夹的路径
+// name 为空时保持原名, 目标为自身或任意层级的子文件夹时返回 ErrFolderCycle
+func (d *UploadDao) MoveFolder(ctx context.Context, folderId, toFolderId int64, uid int32, name string) (Folder, error) {
 	if folderId == toFolderId {
-		return errors.New("cannot move folder to itself")
+		return Folder{}, ErrFolderCycle
 	}
 
+	var folder Folder
 	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var toFolder Folder
-		if err := tx.WithContext(ctx).Model(&Folder{}).
-			Where("id = ?", toFolderId).
-			First(&toFolder).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("target folder not found")
-			}
+		if err := tx.Model(&Folder{}).Where("id = ? AND user_id = ? AND status = 0", folderId, uid).First(&folder).Error; err != nil {
+			return err
 		}
 
-		// 检查源文件夹是否存在
-		var sourceFolder Folder
-		if err := tx.Model(&Folder{}).
-			Where("id = ? AND user_id = ?", folderId, uid).
-			First(&sourceFolder).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("source folder not found")
-			}
+		if err := checkFolderCycle(tx, folderId, toFolderId, uid); err != nil {
+			return err
+		}
+		parentPath, err := folderPath(tx, toFolderId, uid)
+		if err != nil {
 			return err
 		}
 
-		if toFolder.ParentId == folderId {
-			return errors.New("cannot move folder to its subfolder")
+		// 子树的统计从原祖先转移到新祖先
+		now := time.Now().Unix()
+		if err := adjustFolderStats(tx, folder.ParentId, uid, -folder.TotalSize, -folder.FileCount, now); err != nil {
+			return err
+		}
+		if err := adjustFolderStats(tx, toFolderId, uid, folder.TotalSize, folder.FileCount, now); err != nil {
+			return err
 		}
 
-		err := tx.WithContext(ctx).Model(&File{}).Where("folder_id = ? AND user_id = ?", folderId, uid).
-			Update("folder_id", toFolderId).Error
+		if name != "" {
+			folder.Name = NormalizeName(name)
+		}
+		folder.ParentId = toFolderId
+		folder.Path = JoinPath(parentPath, folder.Name)
+		folder.Utime = now
+		err = tx.Model(&Folder{}).Where("id = ?", folder.Id).Updates(map[string]any{
+			"name":      folder.Name,
+			"name_key":  d.naming.key(folder.Name),
+			"parent_id": folder.ParentId,
+			"path":      folder.Path,
+			"utime":     folder.Utime,
+		}).Error
 		if err != nil {
 			return err
 		}
 
-		// 更新文件夹路径
-		newPath := "/"
-		if toFolderId != 0 {
-			newPath = toFolder.Path + "/" + name
-		} else {
-			newPath = "/" + name
-		}
-
-		// 更新所有子文件夹的路径
-		return tx.Model(&Folder{}).
-			Where("path LIKE ? AND user_id = ?", sourceFolder.Path+"/%", uid).
-			Update("path", gorm.Expr(
-				"CONCAT(?, SUBSTR(path, ?))",
-				newPath,
-				len(sourceFolder.Path)+1,
-			)).Error
-		//path = toFolder.Path + "/" + name
-		//err = tx.WithContext(ctx).Model(&Folder{}).
-		//	Where("user_id = ? AND id = ?", uid, folderId).
-		//	Updates(map[string]any{
-		//		"parent_id": toFolderId,
-		//		"path":      path,
-		//	}).Error
-		//return err
+		return rebuildSubtreePaths(tx, folder)
 	})
+	if err != nil {
+		return Folder{}, err
+	}
 
-	return err
+	return folder, nil
+}
+
+// checkFolderCycle 沿 toFolderId 向上查找祖先, 若经过 folderId 说明目标位于它的子树中
+func checkFolderCycle(tx *gorm.DB, folderId, toFolderId int64, uid int32) error {
+	visited := make(map[int64]struct{})
+	for id := toFolderId; id != 0; {
+		if id == folderId {
+			return ErrFolderCycle
+		}
+		if _, ok := visited[id]; ok {
+			// 已有数据中存在环, 不再继续向上
+			return errors.New("folder tree is corrupted")
+		}
+		visited[id] = struct{}{}
+
+		var parent Folder
+		err := tx.Model(&Folder{}).Select("id", "parent_id").Where("id = ? AND user_id = ?", id, uid).First(&parent).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("target folder not found")
+			}
+			return err
+		}
+		id = parent.ParentId
+	}
+
+	return nil
 }
 
 func (d *UploadDao) DeleteFile(ctx context.Context, fileId int64, uid int32) error {
 	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var file File
-		err := tx.WithContext(ctx).Model(&File{}).Where("id = ? AND user_id = ?", fileId, uid).Find(&file).Error
+		err := tx.WithContext(ctx).Model(&File{}).Where("id = ? AND user_id = ? AND status = 0", fileId, uid).First(&file).Error
 		if err != nil {
 			return err
 		}
 
+		now := time.Now().Unix()
 		err = tx.WithContext(ctx).Model(&File{}).
-			Where("id = ? AND user_id = ?", fileId, uid).Update("status", 1).Error
+			Where("id = ? AND user_id = ?", fileId, uid).
+			Updates(map[string]any{"status": 1, "dtime": now, "name_key": nil}).Error
 		if err != nil {
 			return err
 		}
 
-		err = tx.WithContext(ctx).Model(&FileStore{}).Where("user_id = ?", uid).Update("current_size", gorm.Expr(fmt.Sprintf("current_size - %d", file.Size))).Error
-		return err
+		if err := adjustFolderStats(tx, file.FolderId, uid, -file.Size, -1, now); err != nil {
+			return err
+		}
+
+		return addCurrentSize(tx, uid, -file.Size)
 	})
 
 	return err
 }
 
+// DeleteFolder 删除文件夹及其下所有文件和子文件夹
 func (d *UploadDao) DeleteFolder(ctx context.Context, folderId int64, uid int32) error {
 	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var totalSize struct {
-			Size int64
-		}
-		if err := tx.Model(&File{}).
-			Select("COALESCE(SUM(size), 0) as size").
-			Where("user_id = ? AND folder_id = ? AND status = 0", uid, folderId).
-			Scan(&totalSize).Error; err != nil {
+		var folder Folder
+		if err := tx.Model(&Folder{}).Where("id = ? AND user_id = ? AND status = 0", folderId, uid).First(&folder).Error; err != nil {
 			return err
 		}
 
-		// 批量更新文件状态
-		if err := tx.Model(&File{}).
-			Where("user_id = ? AND folder_id = ?", uid, folderId).
-			Update("status", 1).Error; err != nil {
+		folders, files, err := listSubtree(tx, folderId, uid, liveScope)
+		if err != nil {
 			return err
 		}
 
-		// 更新存储空间
-		if totalSize.Size > 0 {
-			if err := tx.Model(&FileStore{}).
-				Where("user_id = ?", uid).
-				Update("current_size", gorm.Expr("current_size - ?", totalSize.Size)).
-				Error; err != nil {
+		folderIds := []int64{folderId}
+		for _, f := range folders {
+			folderIds = append(folderIds, f.Id)
+		}
+		var totalSize int64
+		fileIds := make([]int64, 0, len(files))
+		for _, f := range files {
+			fileIds = append(fileIds, f.Id)
+			totalSize += f.Size
+		}
+
+		// 同一次删除使用相同的删除时间, 恢复时据此找回
+		now := time.Now().Unix()
+		deleted := map[string]any{"status": 1, "dtime": now}
+		if len(fileIds) > 0 {
+			if err := tx.Model(&File{}).Where("id IN ?", fileIds).Updates(deleted).Error; err != nil {
 				return err
 			}
 		}
+		if err := tx.Model(&Folder{}).Where("id IN ?", folderIds).Updates(deleted).Error; err != nil {
+			return err
+		}
+		// 子树内的条目位于已删除的文件夹中, 不会再与新建条目冲突, 只需释放根文件夹的名称
+		if err := tx.Model(&Folder{}).Where("id = ?", folderId).Update("name_key", nil).Error; err != nil {
+			return err
+		}
 
-		// 更新文件夹状态
-		return tx.Model(&Folder{}).
-			Where("id = ? AND user_id = ?", folderId, uid).
-			Update("status", 1).Error
+		// 被删除的子树不再计入祖先的统计, 子树内部的统计保留用于恢复
+		if err := adjustFolderStats(tx, folder.ParentId, uid, -totalSize, -int64(len(files)), now); err != nil {
+			return err
+		}
+
+		// 更新存储空间
+		return addCurrentSize(tx, uid, -totalSize)
 	})
 
 	return err
 }
 
-func (d *UploadDao) Search(ctx context.Context, uid int32, query string, page, size int32) ([]File, []Folder, error) {
+// Search 在 uid 的网盘中按名称搜索文件和文件夹, root 不是根目录时只搜索 root 的子树
+func (d *UploadDao) Search(ctx context.Context, uid int32, root Folder, query string, filters []MetaFilter, page, size int32) ([]File, []Folder, error) {
 	var files []File
 	var folders []Folder
 	offset := (page - 1) * size
 
-	// 搜索文件
-	err := d.db.WithContext(ctx).
-		Where("user_id = ? AND status = 0 AND name LIKE ?", uid, "%"+query+"%").
+	// 搜索文件, 保险库中的名称是密文, 不参与搜索
+	fileQuery := d.db.WithContext(ctx).
+		Where("user_id = ? AND status = 0 AND vault_id = 0 AND name LIKE ?", uid, "%"+query+"%")
+	folderQuery := d.db.WithContext(ctx).
+		Where("user_id = ? AND status = 0 AND vault_id = 0 AND name LIKE ?", uid, "%"+query+"%")
+	if root.Id != 0 {
+		subtree := d.db.WithContext(ctx).Model(&Folder{}).Select("id").
+			Where("user_id = ? AND status = 0 AND (id = ? OR path LIKE ? ESCAPE '!')", uid, root.Id, likePrefix(root.Path+"/"))
+		fileQuery = fileQuery.Where("folder_id IN (?)", subtree)
+		folderQuery = folderQuery.Where("path LIKE ? ESCAPE '!'", likePrefix(root.Path+"/"))
+	}
+	fileQuery, err := applyMetaFilters(fileQuery, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = fileQuery.
 		Order("ctime DESC").
 		Offset(int(offset)).
 		Limit(int(size)).
@@ -357,9 +514,13 @@ func (d *UploadDao) Search(ctx context.Context, uid int32, query string, page, s
 		return nil, nil, err
 	}
 
+	// 文件夹没有元数据, 带元数据过滤条件时只返回文件
+	if len(filters) > 0 {
+		return files, folders, nil
+	}
+
 	// 搜索文件夹
-	err = d.db.WithContext(ctx).
-		Where("user_id = ? AND status = 0 AND name LIKE ?", uid, "%"+query+"%").
+	err = folderQuery.
 		Order("ctime DESC").
 		Find(&folders).Error
 	if err != nil {
@@ -440,7 +601,7 @@ func (d *UploadDao) GetShareLink(ctx context.Context, shareId string) (ShareLink
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ShareLink{}, errors.New("share link not found or expired")
+			return ShareLink{}, ErrShareNotFound
 		}
 		return ShareLink{}, err
 	}
@@ -458,3 +619,85 @@ func (d *UploadDao) FindFileStoreById(ctx context.Context, uid int32) (FileStore
 
 	return store, nil
 }
+
+// GetFolder 获取文件夹信息
+func (d *UploadDao) GetFolder(ctx context.Context, folderId int64, uid int32) (Folder, error) {
+	var folder Folder
+	err := d.db.WithContext(ctx).Model(&Folder{}).
+		Where("id = ? AND user_id = ? AND status = 0", folderId, uid).
+		First(&folder).Error
+	if err != nil {
+		return Folder{}, err
+	}
+
+	return folder, nil
+}
+
+// ListSubtree 获取文件夹下所有未删除的子文件夹和文件, 子文件夹按深度排序
+func (d *UploadDao) ListSubtree(ctx context.Context, folderId int64, uid int32) ([]Folder, []File, error) {
+	return listSubtree(d.db.WithContext(ctx), folderId, uid, liveScope)
+}
+
+// liveScope 只查询未删除的条目
+func liveScope(db *gorm.DB) *gorm.DB {
+	return db.Where("status = 0")
+}
+
+// listSubtree 按层级遍历文件夹, scope 用于筛选每一层的文件和子文件夹
+func listSubtree(db *gorm.DB, folderId int64, uid int32, scope func(*gorm.DB) *gorm.DB) ([]Folder, []File, error) {
+	var folders []Folder
+	var files []File
+
+	level := []int64{folderId}
+	for len(level) > 0 {
+		var fs []File
+		err := db.Model(&File{}).Scopes(scope).
+			Where("folder_id IN ? AND user_id = ?", level, uid).
+			Find(&fs).Error
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, fs...)
+
+		var children []Folder
+		err = db.Model(&Folder{}).Scopes(scope).
+			Where("parent_id IN ? AND user_id = ?", level, uid).
+			Find(&children).Error
+		if err != nil {
+			return nil, nil, err
+		}
+		folders = append(folders, children...)
+
+		level = make([]int64, 0, len(children))
+		for _, c := range children {
+			level = append(level, c.Id)
+		}
+	}
+
+	return folders, files, nil
+}
+
+// ListNames 获取文件夹下未删除的文件和文件夹
+func (d *UploadDao) ListNames(ctx context.Context, folderId int64, uid int32) ([]NameEntry, error) {
+	var files, folders []NameEntry
+	err := d.db.WithContext(ctx).Model(&File{}).
+		Select("id", "name").
+		Where("folder_id = ? AND user_id = ? AND status = 0", folderId, uid).
+		Scan(&files).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.db.WithContext(ctx).Model(&Folder{}).
+		Select("id", "name").
+		Where("parent_id = ? AND user_id = ? AND status = 0", folderId, uid).
+		Scan(&folders).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range folders {
+		folders[i].IsFolder = true
+	}
+
+	return append(files, folders...), nil
+}
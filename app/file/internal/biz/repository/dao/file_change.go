@@ -63,21 +63,11 @@ func (d *UploadDao) ApplyFileChanges(ctx context.Context, fileID int64, changes
 			return err
 		}
 
-		// 更新用户存储空间使用量
-		sizeDiff := file.Size - originalSize
-		if sizeDiff != 0 {
-			expr := "current_size + ?"
-			if sizeDiff < 0 {
-				expr = "current_size - ?"
-				sizeDiff = -sizeDiff
-			}
-
-			if err := tx.Model(&FileStore{}).Where("user_id = ?", file.UserId).
-				Update("current_size", gorm.Expr(expr, sizeDiff)).Error; err != nil {
-				return err
-			}
+		if err := adjustFolderStats(tx, file.FolderId, file.UserId, file.Size-originalSize, 0, file.Utime); err != nil {
+			return err
 		}
 
-		return nil
+		// 更新用户存储空间使用量
+		return addCurrentSize(tx, file.UserId, file.Size-originalSize)
 	})
 }
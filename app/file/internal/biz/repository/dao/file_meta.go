@@ -0,0 +1,160 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MetaType 自定义元数据的值类型
+type MetaType int8
+
+const (
+	MetaString MetaType = iota + 1
+	MetaNumber
+	MetaDate
+	MetaBool
+)
+
+// FileMeta 文件的自定义元数据, 每个 key 一行
+type FileMeta struct {
+	Id          int64    `gorm:"primaryKey,autoIncrement"`
+	FileId      int64    `gorm:"not null;uniqueIndex:uk_file_key"`
+	UserId      int32    `gorm:"not null;index:idx_user_key"`
+	MetaKey     string   `gorm:"type:varchar(64);not null;uniqueIndex:uk_file_key;index:idx_user_key"`
+	Type        MetaType `gorm:"not null"`
+	StringValue string   `gorm:"type:varchar(1024)"`
+	NumberValue float64  // 数字类型的值
+	DateValue   int64    // 日期类型的值, unix 秒
+	BoolValue   bool     // 布尔类型的值
+	Ctime       int64
+	Utime       int64
+}
+
+// MetaFilter 元数据过滤条件, 用于搜索
+type MetaFilter struct {
+	Key   string
+	Op    string // = != > >= < <=
+	Type  MetaType
+	Value any
+}
+
+// Column 返回该类型的值所在的列
+func (t MetaType) Column() string {
+	switch t {
+	case MetaNumber:
+		return "number_value"
+	case MetaDate:
+		return "date_value"
+	case MetaBool:
+		return "bool_value"
+	default:
+		return "string_value"
+	}
+}
+
+// GetFileMeta 获取文件的全部元数据
+func (d *UploadDao) GetFileMeta(ctx context.Context, fileId int64, uid int32) ([]FileMeta, error) {
+	var metas []FileMeta
+	err := d.db.WithContext(ctx).Model(&FileMeta{}).
+		Where("file_id = ? AND user_id = ?", fileId, uid).
+		Order("meta_key ASC").
+		Find(&metas).Error
+	return metas, err
+}
+
+// GetFilesMeta 批量获取文件元数据, 按文件 ID 分组
+func (d *UploadDao) GetFilesMeta(ctx context.Context, fileIds []int64) (map[int64][]FileMeta, error) {
+	res := make(map[int64][]FileMeta, len(fileIds))
+	if len(fileIds) == 0 {
+		return res, nil
+	}
+
+	var metas []FileMeta
+	err := d.db.WithContext(ctx).Model(&FileMeta{}).
+		Where("file_id IN ?", fileIds).
+		Find(&metas).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range metas {
+		res[m.FileId] = append(res[m.FileId], m)
+	}
+
+	return res, nil
+}
+
+// SetFileMeta 写入元数据, 已存在的 key 会被覆盖
+func (d *UploadDao) SetFileMeta(ctx context.Context, fileId int64, uid int32, metas []FileMeta) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		if err := tx.Model(&File{}).Where("id = ? AND user_id = ?", fileId, uid).Count(&cnt).Error; err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return upsertFileMeta(tx, fileId, uid, metas)
+	})
+}
+
+// DeleteFileMeta 删除指定 key 的元数据
+func (d *UploadDao) DeleteFileMeta(ctx context.Context, fileId int64, uid int32, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return d.db.WithContext(ctx).
+		Where("file_id = ? AND user_id = ? AND meta_key IN ?", fileId, uid, keys).
+		Delete(&FileMeta{}).Error
+}
+
+func upsertFileMeta(tx *gorm.DB, fileId int64, uid int32, metas []FileMeta) error {
+	if len(metas) == 0 {
+		return nil
+	}
+
+	now := time.Now().Unix()
+	rows := make([]FileMeta, 0, len(metas))
+	for _, m := range metas {
+		rows = append(rows, FileMeta{
+			FileId:      fileId,
+			UserId:      uid,
+			MetaKey:     m.MetaKey,
+			Type:        m.Type,
+			StringValue: m.StringValue,
+			NumberValue: m.NumberValue,
+			DateValue:   m.DateValue,
+			BoolValue:   m.BoolValue,
+			Ctime:       now,
+			Utime:       now,
+		})
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "file_id"}, {Name: "meta_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"type", "string_value", "number_value", "date_value", "bool_value", "utime"}),
+	}).Create(&rows).Error
+}
+
+// applyMetaFilters 将元数据过滤条件转换为子查询
+func applyMetaFilters(db *gorm.DB, filters []MetaFilter) (*gorm.DB, error) {
+	for _, f := range filters {
+		switch f.Op {
+		case "=", "!=", ">", ">=", "<", "<=":
+		default:
+			return nil, fmt.Errorf("unsupported meta operator: %s", f.Op)
+		}
+
+		sub := db.Session(&gorm.Session{NewDB: true}).Model(&FileMeta{}).
+			Select("file_id").
+			Where(fmt.Sprintf("meta_key = ? AND type = ? AND %s %s ?", f.Type.Column(), f.Op), f.Key, f.Type, f.Value)
+		db = db.Where("id IN (?)", sub)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,160 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrFileRequestNotFound 文件收集链接不存在、已过期或已关闭
+	ErrFileRequestNotFound = errors.New("file request not found or closed")
+	// ErrFileRequestLimit 文件收集链接的文件数或总大小已达上限
+	ErrFileRequestLimit = errors.New("file request limit reached")
+)
+
+// 文件收集链接的状态
+const (
+	FileRequestOpen   int8 = 1
+	FileRequestClosed int8 = 2
+)
+
+// FileRequest 文件收集链接, 匿名用户只能向目标文件夹上传文件, 看不到其中已有的内容
+// 上传的文件属于目标文件夹的所有者并占用其空间
+type FileRequest struct {
+	Id           string `gorm:"primaryKey;type:varchar(64)"`
+	UserId       int32  `gorm:"not null;index:idx_request_user"` // 创建者
+	FolderId     int64  `gorm:"not null"`                        // 目标文件夹, 0 表示创建者的根目录
+	Title        string `gorm:"type:varchar(255)"`
+	Password     string // 密码的 bcrypt 哈希, 为空表示无密码
+	ExpireAt     int64  // 截止时间, 0 表示不限
+	MaxFileSize  int64  `gorm:"not null;default:0"` // 单个文件大小上限, 0 表示不限
+	MaxFiles     int64  `gorm:"not null;default:0"` // 文件数上限, 0 表示不限
+	MaxTotalSize int64  `gorm:"not null;default:0"` // 总大小上限, 0 表示不限
+	AllowedExts  string `gorm:"type:varchar(255)"`  // 允许的扩展名, 逗号分隔的小写形式, 为空表示不限
+	Status       int8   `gorm:"not null;default:1"`
+	FileCount    int64  `gorm:"not null;default:0"`
+	TotalSize    int64  `gorm:"not null;default:0"`
+	Ctime        int64  `gorm:"index:idx_request_user"`
+	Utime        int64
+}
+
+// FileRequestUpload 通过文件收集链接上传的记录
+type FileRequestUpload struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	RequestId string `gorm:"type:varchar(64);not null;index:idx_request_ctime"`
+	FileId    int64
+	Name      string `gorm:"type:varchar(255)"` // 保存后的文件名
+	Uploader  string `gorm:"type:varchar(64)"`  // 上传者自称的名字
+	Size      int64
+	Ip        string `gorm:"type:varchar(64)"`
+	Ctime     int64  `gorm:"not null;index:idx_request_ctime"`
+}
+
+// CreateFileRequest 创建文件收集链接
+func (d *UploadDao) CreateFileRequest(ctx context.Context, req *FileRequest) error {
+	now := time.Now().Unix()
+	req.Ctime, req.Utime = now, now
+	req.Status = FileRequestOpen
+
+	return d.db.WithContext(ctx).Create(req).Error
+}
+
+// GetFileRequest 获取未关闭且未过期的文件收集链接
+func (d *UploadDao) GetFileRequest(ctx context.Context, id string) (FileRequest, error) {
+	var req FileRequest
+	err := d.db.WithContext(ctx).Model(&FileRequest{}).
+		Where("id = ? AND status = ?", id, FileRequestOpen).
+		Where("expire_at = 0 OR expire_at > ?", time.Now().Unix()).
+		First(&req).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return FileRequest{}, ErrFileRequestNotFound
+	}
+
+	return req, err
+}
+
+// ListFileRequests 按创建时间倒序分页获取用户创建的文件收集链接
+func (d *UploadDao) ListFileRequests(ctx context.Context, uid int32, page, size int) ([]FileRequest, int64, error) {
+	query := d.db.WithContext(ctx).Model(&FileRequest{}).Where("user_id = ?", uid)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reqs []FileRequest
+	err := query.Order("ctime DESC").Offset((page - 1) * size).Limit(size).Find(&reqs).Error
+
+	return reqs, total, err
+}
+
+// CloseFileRequests 关闭用户的文件收集链接, 返回实际关闭的数量
+func (d *UploadDao) CloseFileRequests(ctx context.Context, uid int32, ids []string) (int64, error) {
+	res := d.db.WithContext(ctx).Model(&FileRequest{}).
+		Where("id IN ? AND user_id = ? AND status = ?", ids, uid, FileRequestOpen).
+		Updates(map[string]any{"status": FileRequestClosed, "utime": time.Now().Unix()})
+
+	return res.RowsAffected, res.Error
+}
+
+// AcquireFileRequestSlot 为一次上传占用文件数和总大小的额度, 超过上限时返回 ErrFileRequestLimit
+// 上传失败时须调用 ReleaseFileRequestSlot 归还
+func (d *UploadDao) AcquireFileRequestSlot(ctx context.Context, id string, size int64) error {
+	res := d.db.WithContext(ctx).Model(&FileRequest{}).
+		Where("id = ? AND status = ?", id, FileRequestOpen).
+		Where("max_files = 0 OR file_count < max_files").
+		Where("max_total_size = 0 OR total_size + ? <= max_total_size", size).
+		Updates(map[string]any{
+			"file_count": gorm.Expr("file_count + 1"),
+			"total_size": gorm.Expr("total_size + ?", size),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrFileRequestLimit
+	}
+
+	return nil
+}
+
+// ReleaseFileRequestSlot 归还上传失败时占用的额度
+func (d *UploadDao) ReleaseFileRequestSlot(ctx context.Context, id string, size int64) error {
+	return d.db.WithContext(ctx).Model(&FileRequest{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"file_count": gorm.Expr("file_count - 1"),
+			"total_size": gorm.Expr("total_size - ?", size),
+		}).Error
+}
+
+// RecordFileRequestUpload 记录一次上传
+func (d *UploadDao) RecordFileRequestUpload(ctx context.Context, up *FileRequestUpload) error {
+	up.Ctime = time.Now().Unix()
+	return d.db.WithContext(ctx).Create(up).Error
+}
+
+// ListFileRequestUploads 按时间倒序分页获取文件收集链接的上传记录
+func (d *UploadDao) ListFileRequestUploads(ctx context.Context, id string, page, size int) ([]FileRequestUpload, int64, error) {
+	query := d.db.WithContext(ctx).Model(&FileRequestUpload{}).Where("request_id = ?", id)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var ups []FileRequestUpload
+	err := query.Order("ctime DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&ups).Error
+
+	return ups, total, err
+}
+
+// GetUserFileRequest 获取用户创建的文件收集链接, 不限状态
+func (d *UploadDao) GetUserFileRequest(ctx context.Context, id string, uid int32) (FileRequest, error) {
+	var req FileRequest
+	err := d.db.WithContext(ctx).Model(&FileRequest{}).Where("id = ? AND user_id = ?", id, uid).First(&req).Error
+
+	return req, err
+}
@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxTreeNodes GetFolderTree 单次返回的最大文件夹数
+const maxTreeNodes = 10000
+
+// FolderStats 文件夹子树的聚合统计
+type FolderStats struct {
+	TotalSize    int64
+	FileCount    int64
+	LastModified int64
+}
+
+// GetFolderTree 从 rootId 开始逐层加载子文件夹, depth 为加载的层数, 0 表示不限
+// 返回按层排列的文件夹, 超过 maxTreeNodes 时截断并返回 truncated
+func (d *UploadDao) GetFolderTree(ctx context.Context, rootId int64, uid int32, depth int) ([]Folder, bool, error) {
+	var res []Folder
+	level := []int64{rootId}
+	for i := 0; len(level) > 0 && (depth <= 0 || i < depth); i++ {
+		var children []Folder
+		err := d.db.WithContext(ctx).Model(&Folder{}).
+			Where("parent_id IN ? AND user_id = ? AND status = 0", level, uid).
+			Order("name ASC").
+			Find(&children).Error
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(res)+len(children) > maxTreeNodes {
+			return append(res, children[:maxTreeNodes-len(res)]...), true, nil
+		}
+		res = append(res, children...)
+
+		level = make([]int64, 0, len(children))
+		for _, c := range children {
+			level = append(level, c.Id)
+		}
+	}
+
+	return res, false, nil
+}
+
+// GetRootStats 统计用户全部文件, 作为根目录的聚合数据
+func (d *UploadDao) GetRootStats(ctx context.Context, uid int32) (FolderStats, error) {
+	var stats FolderStats
+	err := d.db.WithContext(ctx).Model(&File{}).
+		Select("COALESCE(SUM(size), 0) AS total_size, COUNT(*) AS file_count, COALESCE(MAX(utime), 0) AS last_modified").
+		Where("user_id = ? AND status = 0", uid).
+		Scan(&stats).Error
+
+	return stats, err
+}
+
+// adjustFolderStats 将文件大小和数量的变化累加到 folderId 及其所有祖先文件夹, mtime 大于 0 时同时刷新最近修改时间
+func adjustFolderStats(tx *gorm.DB, folderId int64, uid int32, size, count, mtime int64) error {
+	if size == 0 && count == 0 && mtime == 0 {
+		return nil
+	}
+
+	ids, err := ancestorIds(tx, folderId, uid)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	updates := map[string]any{
+		"total_size": gorm.Expr("total_size + ?", size),
+		"file_count": gorm.Expr("file_count + ?", count),
+	}
+	if mtime > 0 {
+		updates["last_modified"] = gorm.Expr("CASE WHEN last_modified < ? THEN ? ELSE last_modified END", mtime, mtime)
+	}
+
+	return tx.Model(&Folder{}).Where("id IN ?", ids).Updates(updates).Error
+}
+
+// ancestorIds 返回 folderId 及其所有祖先文件夹的 ID, 不包含根目录
+func ancestorIds(tx *gorm.DB, folderId int64, uid int32) ([]int64, error) {
+	var ids []int64
+	visited := make(map[int64]struct{})
+	for id := folderId; id != 0; {
+		if _, ok := visited[id]; ok {
+			return nil, errors.New("folder tree is corrupted")
+		}
+		visited[id] = struct{}{}
+
+		var f Folder
+		err := tx.Model(&Folder{}).Select("id", "parent_id").Where("id = ? AND user_id = ?", id, uid).First(&f).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return nil, err
+		}
+		ids = append(ids, id)
+		id = f.ParentId
+	}
+
+	return ids, nil
+}
+
+// setCopiedTreeStats 为复制出的子树计算统计, folders 按从上到下的顺序排列, mapping 为源文件夹到新文件夹的映射
+func setCopiedTreeStats(tx *gorm.DB, root Folder, folders []Folder, files []File, mapping map[int64]Folder) (FolderStats, error) {
+	now := time.Now().Unix()
+	stats := make(map[int64]*FolderStats, len(mapping))
+	for oldId := range mapping {
+		stats[oldId] = &FolderStats{LastModified: now}
+	}
+	for _, f := range files {
+		if st, ok := stats[f.FolderId]; ok {
+			st.TotalSize += f.Size
+			st.FileCount++
+		}
+	}
+	// 自底向上累加到父文件夹
+	for i := len(folders) - 1; i >= 0; i-- {
+		st, parent := stats[folders[i].Id], stats[folders[i].ParentId]
+		if parent != nil {
+			parent.TotalSize += st.TotalSize
+			parent.FileCount += st.FileCount
+		}
+	}
+
+	for oldId, nf := range mapping {
+		st := stats[oldId]
+		err := tx.Model(&Folder{}).Where("id = ?", nf.Id).Updates(map[string]any{
+			"total_size":    st.TotalSize,
+			"file_count":    st.FileCount,
+			"last_modified": st.LastModified,
+		}).Error
+		if err != nil {
+			return FolderStats{}, err
+		}
+	}
+
+	return *stats[root.Id], nil
+}
@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+
+	"golang.org/x/text/cases"
+	"gorm.io/gorm"
+)
+
+// backfillBatchSize 回填 name_key 时每批处理的行数
+const backfillBatchSize = 500
+
+// Naming 名称唯一性的规则, 由配置决定, 与 name_key 列中已有的数据须一致
+type Naming struct {
+	// CaseInsensitive 为 true 时, 同一文件夹下仅大小写不同的名称也视为冲突
+	CaseInsensitive bool
+}
+
+// NameEntry 文件夹下的一个条目, 用于同名判断
+type NameEntry struct {
+	Id       int64
+	Name     string
+	IsFolder bool
+}
+
+// Key 返回名称用于唯一性判断的形式: NFC 规范化, 忽略大小写时再做大小写折叠
+func (n Naming) Key(name string) string {
+	name = NormalizeName(name)
+	if n.CaseInsensitive {
+		return cases.Fold().String(name)
+	}
+
+	return name
+}
+
+// column 返回 name_key 列中的值, 即 Key 的 SHA-256 摘要的十六进制形式
+// 摘要只含小写十六进制字符, 在任何数据库和排序规则下比较都与按字节比较一致
+func (n Naming) column(name string) string {
+	sum := sha256.Sum256([]byte(n.Key(name)))
+	return hex.EncodeToString(sum[:])
+}
+
+// key 返回写入 name_key 列的值, 已删除的条目该列为 NULL, 不参与唯一索引
+func (n Naming) key(name string) *string {
+	key := n.column(name)
+	return &key
+}
+
+// BackfillNameKeys 为未删除但缺少 name_key 的历史数据补齐该列
+// 历史数据中已存在的重名条目无法自动处理, 保留为 NULL 并记录日志
+func BackfillNameKeys(db *gorm.DB, naming Naming) error {
+	var files []File
+	err := db.Model(&File{}).Select("id", "name").
+		Where("status = 0 AND name_key IS NULL").
+		FindInBatches(&files, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, f := range files {
+				if err := setNameKey(db, naming, &File{}, f.Id, f.Name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	var folders []Folder
+	return db.Model(&Folder{}).Select("id", "name").
+		Where("status = 0 AND name_key IS NULL").
+		FindInBatches(&folders, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, f := range folders {
+				if err := setNameKey(db, naming, &Folder{}, f.Id, f.Name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+func setNameKey(db *gorm.DB, naming Naming, model any, id int64, name string) error {
+	err := db.Model(model).Where("id = ?", id).Update("name_key", naming.key(name)).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		log.Printf("skip backfilling name key of %T %d: duplicated name %q", model, id, name)
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,204 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 文件夹路径以 / 分隔各级名称, 名称中的 / 和 \ 以 \ 转义, 如名为 a/b 的文件夹路径为 /a\/b
+// 存储的路径均为规范形式: 以 / 开头, 名称经过 NFC 规范化, 根目录为空串
+
+var ErrPathConflict = errors.New("path conflicts with an existing file")
+
+// NormalizeName 对名称做 Unicode NFC 规范化, 保证同一名称只有一种编码形式
+func NormalizeName(name string) string {
+	return norm.NFC.String(name)
+}
+
+// EscapeName 转义名称中的 / 和 \
+func EscapeName(name string) string {
+	if !strings.ContainsAny(name, `/\`) {
+		return name
+	}
+
+	var b strings.Builder
+	for _, r := range name {
+		if r == '/' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// JoinPath 拼接父文件夹路径与名称
+func JoinPath(parent, name string) string {
+	return parent + "/" + EscapeName(name)
+}
+
+// likePrefix 匹配以 prefix 开头的 LIKE 模式, 配合 ESCAPE '!' 使用
+func likePrefix(prefix string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(prefix) + "%"
+}
+
+// BuildPath 由各级名称构造规范路径
+func BuildPath(names []string) string {
+	var path string
+	for _, n := range names {
+		path = JoinPath(path, n)
+	}
+
+	return path
+}
+
+// SplitPath 将路径拆分为各级名称, 忽略空段, 返回的名称已反转义并经过 NFC 规范化
+func SplitPath(path string) ([]string, error) {
+	var (
+		names   []string
+		cur     strings.Builder
+		escaped bool
+	)
+
+	flush := func() error {
+		name := cur.String()
+		cur.Reset()
+		if name == "" {
+			return nil
+		}
+		if name == "." || name == ".." {
+			return fmt.Errorf("invalid path segment: %s", name)
+		}
+		names = append(names, NormalizeName(name))
+		return nil
+	}
+
+	for _, r := range path {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '/':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if escaped {
+		return nil, errors.New("invalid path: trailing escape character")
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// GetFolderByPath 按规范路径获取未删除的文件夹
+func (d *UploadDao) GetFolderByPath(ctx context.Context, uid int32, path string) (Folder, error) {
+	var folder Folder
+	err := d.db.WithContext(ctx).Model(&Folder{}).
+		Where("path = ? AND user_id = ? AND status = 0", path, uid).
+		First(&folder).Error
+	if err != nil {
+		return Folder{}, err
+	}
+
+	return folder, nil
+}
+
+// GetFileByName 按名称获取文件夹下未删除的文件
+func (d *UploadDao) GetFileByName(ctx context.Context, uid int32, folderId int64, name string) (File, error) {
+	var file File
+	err := d.db.WithContext(ctx).Model(&File{}).
+		Where("folder_id = ? AND user_id = ? AND name_key = ? AND status = 0", folderId, uid, d.naming.column(name)).
+		First(&file).Error
+	if err != nil {
+		return File{}, err
+	}
+
+	return file, nil
+}
+
+// EnsureFolderPath 逐级查找 names 对应的文件夹, 不存在的在同一事务中创建, 返回最末级文件夹和新建数量
+// names 为空时返回根目录
+func (d *UploadDao) EnsureFolderPath(ctx context.Context, uid int32, names []string) (Folder, int, error) {
+	var (
+		parent  Folder
+		created int
+	)
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁住用户的存储记录, 串行化同一用户的并发创建, 避免产生重复文件夹
+		var store FileStore
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", uid).Find(&store).Error
+		if err != nil {
+			return err
+		}
+
+		parent, created = Folder{UserId: uid}, 0
+		for _, name := range names {
+			path := JoinPath(parent.Path, name)
+
+			// 按名称的唯一性形式查找, 忽略大小写时 /A 与已有的 /a 视为同一文件夹
+			key := d.naming.column(name)
+			var folder Folder
+			err := tx.Model(&Folder{}).Where("parent_id = ? AND user_id = ? AND name_key = ? AND status = 0", parent.Id, uid, key).
+				First(&folder).Error
+			if err == nil {
+				parent = folder
+				continue
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			var cnt int64
+			err = tx.Model(&File{}).
+				Where("folder_id = ? AND user_id = ? AND name_key = ? AND status = 0", parent.Id, uid, key).
+				Count(&cnt).Error
+			if err != nil {
+				return err
+			}
+			if cnt > 0 {
+				return fmt.Errorf("%w: %s", ErrPathConflict, path)
+			}
+
+			now := time.Now().Unix()
+			folder = Folder{
+				Name:     name,
+				NameKey:  d.naming.key(name),
+				ParentId: parent.Id,
+				UserId:   uid,
+				Path:     path,
+				VaultId:  parent.VaultId,
+				Ctime:    now,
+				Utime:    now,
+			}
+			if err := tx.Create(&folder).Error; err != nil {
+				return err
+			}
+			parent = folder
+			created++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Folder{}, 0, err
+	}
+
+	return parent, created, nil
+}
@@ -0,0 +1,338 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultCapacity 未分配套餐的用户的基础容量, 与 FileStore.Capacity 的默认值一致
+const DefaultCapacity int64 = 10 << 30
+
+// quotaThresholds 用量阈值(百分比), 用量向上跨越时产生配额事件
+var quotaThresholds = []int{80, 95, 100}
+
+// ErrFileTooLarge 文件超过套餐允许的单个文件大小
+var ErrFileTooLarge = errors.New("file exceeds the size limit of the storage plan")
+
+// StoragePlan 存储套餐
+type StoragePlan struct {
+	Id               int64  `gorm:"primaryKey,autoIncrement"`
+	Name             string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Capacity         int64  `gorm:"not null"`
+	MaxFileSize      int64  `gorm:"not null;default:0"` // 单个文件的大小上限, 0 表示不限
+	VersionRetention int32  `gorm:"not null;default:0"` // 每个文件保留的历史版本数, 0 表示不限
+	BandwidthTier    string `gorm:"type:varchar(32)"`   // 带宽等级, 供网关限速使用
+	Ctime            int64
+	Utime            int64
+}
+
+// CapacityGrant 临时额外容量, 过期后从用户容量中扣除
+type CapacityGrant struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	UserId   int32  `gorm:"not null;index"`
+	Size     int64  `gorm:"not null"`
+	Reason   string `gorm:"type:varchar(255)"`
+	ExpireAt int64  `gorm:"not null;index:idx_status_expire"`
+	Status   int    `gorm:"not null;default:0;index:idx_status_expire"` // 状态：0-生效 1-已过期
+	Ctime    int64
+}
+
+// QuotaEvent 用量跨越阈值的事件, 与用量变更在同一事务中写入, 由后台任务投递
+type QuotaEvent struct {
+	Id          int64 `gorm:"primaryKey,autoIncrement"`
+	UserId      int32 `gorm:"not null"`
+	Threshold   int   `gorm:"not null"` // 跨越的阈值(百分比)
+	CurrentSize int64
+	Capacity    int64
+	Ctime       int64
+	Sent        bool `gorm:"not null;default:false;index"`
+}
+
+// defaultPlan 未分配套餐时使用的套餐
+func defaultPlan() StoragePlan {
+	return StoragePlan{Name: "default", Capacity: DefaultCapacity}
+}
+
+// SavePlan 创建或更新套餐, 更新时重新计算使用该套餐的用户的容量
+func (d *UploadDao) SavePlan(ctx context.Context, plan *StoragePlan) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Unix()
+		plan.Utime = now
+		if plan.Id == 0 {
+			plan.Ctime = now
+			return tx.Create(plan).Error
+		}
+
+		res := tx.Model(&StoragePlan{}).Where("id = ?", plan.Id).Updates(map[string]any{
+			"name":              plan.Name,
+			"capacity":          plan.Capacity,
+			"max_file_size":     plan.MaxFileSize,
+			"version_retention": plan.VersionRetention,
+			"bandwidth_tier":    plan.BandwidthTier,
+			"utime":             plan.Utime,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var uids []int32
+		if err := tx.Model(&FileStore{}).Where("plan_id = ?", plan.Id).Pluck("user_id", &uids).Error; err != nil {
+			return err
+		}
+		for _, uid := range uids {
+			if err := refreshCapacity(tx, uid); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListPlans 获取全部套餐
+func (d *UploadDao) ListPlans(ctx context.Context) ([]StoragePlan, error) {
+	var plans []StoragePlan
+	err := d.db.WithContext(ctx).Model(&StoragePlan{}).Order("id ASC").Find(&plans).Error
+
+	return plans, err
+}
+
+// GetUserPlan 获取用户的套餐, 未分配时返回默认套餐
+func (d *UploadDao) GetUserPlan(ctx context.Context, uid int32) (StoragePlan, error) {
+	return userPlan(d.db.WithContext(ctx), uid)
+}
+
+// AssignPlan 为用户分配套餐并重新计算容量
+func (d *UploadDao) AssignPlan(ctx context.Context, uid int32, planId int64) (FileStore, error) {
+	var store FileStore
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&StoragePlan{}).Where("id = ?", planId).First(&StoragePlan{}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Model(&FileStore{}).Where("user_id = ?", uid).
+			Updates(map[string]any{"plan_id": planId, "utime": time.Now().Unix()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := refreshCapacity(tx, uid); err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", uid).First(&store).Error
+	})
+	if err != nil {
+		return FileStore{}, err
+	}
+
+	return store, nil
+}
+
+// GrantCapacity 为用户增加临时容量, 到期后由 ExpireCapacityGrants 收回
+func (d *UploadDao) GrantCapacity(ctx context.Context, grant *CapacityGrant) (FileStore, error) {
+	var store FileStore
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&FileStore{}).Where("user_id = ?", grant.UserId).First(&FileStore{}).Error; err != nil {
+			return err
+		}
+
+		grant.Ctime = time.Now().Unix()
+		if err := tx.Create(grant).Error; err != nil {
+			return err
+		}
+		if err := refreshCapacity(tx, grant.UserId); err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", grant.UserId).First(&store).Error
+	})
+	if err != nil {
+		return FileStore{}, err
+	}
+
+	return store, nil
+}
+
+// ExpireCapacityGrants 将到期的临时容量标记为过期并重新计算相关用户的容量, 返回处理的用户数
+func (d *UploadDao) ExpireCapacityGrants(ctx context.Context) (int, error) {
+	var uids []int32
+	err := d.db.WithContext(ctx).Model(&CapacityGrant{}).
+		Where("status = 0 AND expire_at <= ?", time.Now().Unix()).
+		Distinct("user_id").Pluck("user_id", &uids).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for i, uid := range uids {
+		err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Model(&CapacityGrant{}).
+				Where("user_id = ? AND status = 0 AND expire_at <= ?", uid, time.Now().Unix()).
+				Update("status", 1).Error
+			if err != nil {
+				return err
+			}
+
+			return refreshCapacity(tx, uid)
+		})
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(uids), nil
+}
+
+// ListUsersOverThreshold 按用量比例从高到低分页获取用量达到 percent% 的用户
+func (d *UploadDao) ListUsersOverThreshold(ctx context.Context, percent int, page, size int) ([]FileStore, int64, error) {
+	query := d.db.WithContext(ctx).Model(&FileStore{}).
+		Where("capacity > 0 AND current_size * 100 >= capacity * ?", percent)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var stores []FileStore
+	err := query.Order("current_size * 10000 / capacity DESC").Order("user_id ASC").
+		Offset((page - 1) * size).Limit(size).
+		Find(&stores).Error
+
+	return stores, total, err
+}
+
+// ListPendingQuotaEvents 获取尚未投递的配额事件
+func (d *UploadDao) ListPendingQuotaEvents(ctx context.Context, limit int) ([]QuotaEvent, error) {
+	var events []QuotaEvent
+	err := d.db.WithContext(ctx).Model(&QuotaEvent{}).
+		Where("sent = ?", false).Order("id ASC").Limit(limit).
+		Find(&events).Error
+
+	return events, err
+}
+
+// MarkQuotaEventSent 标记配额事件已投递
+func (d *UploadDao) MarkQuotaEventSent(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Model(&QuotaEvent{}).Where("id = ?", id).Update("sent", true).Error
+}
+
+// userPlan 获取用户的套餐, 未分配或套餐不存在时返回默认套餐
+func userPlan(tx *gorm.DB, uid int32) (StoragePlan, error) {
+	var store FileStore
+	if err := tx.Model(&FileStore{}).Select("plan_id").Where("user_id = ?", uid).First(&store).Error; err != nil {
+		return StoragePlan{}, err
+	}
+	if store.PlanId == 0 {
+		return defaultPlan(), nil
+	}
+
+	var plan StoragePlan
+	err := tx.Model(&StoragePlan{}).Where("id = ?", store.PlanId).First(&plan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return defaultPlan(), nil
+	}
+
+	return plan, err
+}
+
+// refreshCapacity 按套餐容量加上生效中的临时容量重新计算用户容量
+func refreshCapacity(tx *gorm.DB, uid int32) error {
+	plan, err := userPlan(tx, uid)
+	if err != nil {
+		return err
+	}
+
+	var granted int64
+	err = tx.Model(&CapacityGrant{}).Select("COALESCE(SUM(size), 0)").
+		Where("user_id = ? AND status = 0 AND expire_at > ?", uid, time.Now().Unix()).
+		Scan(&granted).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(&FileStore{}).Where("user_id = ?", uid).
+		Updates(map[string]any{"capacity": plan.Capacity + granted, "utime": time.Now().Unix()}).Error
+	if err != nil {
+		return err
+	}
+
+	return checkQuotaLevel(tx, uid)
+}
+
+// checkQuotaLevel 根据当前用量更新用户所处的阈值, 向上跨越阈值时写入配额事件
+// 用量回落后阈值随之下调, 再次跨越时会重新产生事件
+func checkQuotaLevel(tx *gorm.DB, uid int32) error {
+	var store FileStore
+	err := tx.Model(&FileStore{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("current_size", "capacity", "quota_level").
+		Where("user_id = ?", uid).First(&store).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	level := 0
+	for _, t := range quotaThresholds {
+		if store.Capacity > 0 && store.CurrentSize*100 >= store.Capacity*int64(t) {
+			level = t
+		}
+	}
+	if level == store.QuotaLevel {
+		return nil
+	}
+
+	if err := tx.Model(&FileStore{}).Where("user_id = ?", uid).Update("quota_level", level).Error; err != nil {
+		return err
+	}
+	if level < store.QuotaLevel {
+		return nil
+	}
+
+	return tx.Create(&QuotaEvent{
+		UserId:      uid,
+		Threshold:   level,
+		CurrentSize: store.CurrentSize,
+		Capacity:    store.Capacity,
+		Ctime:       time.Now().Unix(),
+	}).Error
+}
+
+// pruneVersions 按套餐的版本保留数删除文件最旧的历史版本, 并扣除其占用的空间
+// 只删除版本记录, 版本内容所在的对象可能被副本共用, 不在此删除
+func pruneVersions(tx *gorm.DB, fileId int64, uid int32) error {
+	plan, err := userPlan(tx, uid)
+	if err != nil || plan.VersionRetention <= 0 {
+		return err
+	}
+
+	var stale []FileVersion
+	err = tx.Model(&FileVersion{}).Where("file_id = ?", fileId).
+		Order("version DESC").Offset(int(plan.VersionRetention)).
+		Find(&stale).Error
+	if err != nil || len(stale) == 0 {
+		return err
+	}
+
+	ids := make([]int64, 0, len(stale))
+	var size int64
+	for _, v := range stale {
+		ids = append(ids, v.Id)
+		size += v.Size
+	}
+	if err := tx.Where("id IN ?", ids).Delete(&FileVersion{}).Error; err != nil {
+		return err
+	}
+
+	return addCurrentSize(tx, uid, -size)
+}
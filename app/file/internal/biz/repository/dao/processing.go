@@ -0,0 +1,221 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 文件处理的状态, 与 file.ProcessingStatus 一致
+const (
+	ProcessPending int8 = 1 // 等待处理
+	ProcessRunning int8 = 2 // 处理中, 租约到期后视为失败重新处理
+	ProcessDone    int8 = 3 // 处理成功
+	ProcessRetry   int8 = 4 // 处理失败, 等待重试
+	ProcessDead    int8 = 5 // 多次失败后放弃, 进入死信列表
+)
+
+// FileCommitEvent 文件内容提交的事件, 与文件记录在同一事务中写入, 由处理流水线消费
+type FileCommitEvent struct {
+	Id      int64 `gorm:"primaryKey,autoIncrement"`
+	FileId  int64 `gorm:"not null"`
+	Version int32 `gorm:"not null"`
+	Ctime   int64 `gorm:"not null"`
+}
+
+// FileProcess 文件在一个处理器上的处理状态和结果, 文件的新版本提交后重置
+type FileProcess struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	FileId    int64  `gorm:"not null;uniqueIndex:uk_file_processor"`
+	Processor string `gorm:"type:varchar(64);not null;uniqueIndex:uk_file_processor;index:idx_processor_status"`
+	Version   int32  `gorm:"not null"` // 处理的文件版本
+	Status    int8   `gorm:"not null;index:idx_status_next;index:idx_processor_status"`
+	Attempts  int32  `gorm:"not null;default:0"`
+	NextRun   int64  `gorm:"not null;default:0;index:idx_status_next"` // 下次处理的时间, 处理中时为租约到期的时间
+	LastError string `gorm:"type:varchar(255)"`
+	Result    []byte `gorm:"type:blob"` // 处理结果, JSON 编码的字符串映射
+	Ctime     int64  `gorm:"not null"`
+	Utime     int64  `gorm:"not null"`
+}
+
+// AddFileCommitEvent 记录文件内容的提交
+func (d *UploadDao) AddFileCommitEvent(ctx context.Context, fileId int64, version int32) error {
+	return d.db.WithContext(ctx).Create(&FileCommitEvent{
+		FileId:  fileId,
+		Version: version,
+		Ctime:   time.Now().Unix(),
+	}).Error
+}
+
+// ListFileCommitEvents 获取尚未消费的提交事件
+func (d *UploadDao) ListFileCommitEvents(ctx context.Context, limit int) ([]FileCommitEvent, error) {
+	var events []FileCommitEvent
+	err := d.db.WithContext(ctx).Model(&FileCommitEvent{}).Order("id ASC").Limit(limit).Find(&events).Error
+
+	return events, err
+}
+
+// EnqueueFileProcesses 消费提交事件, 为文件在各处理器上重置处理状态
+// 同一文件已有更新版本的状态时不回退, 多个实例同时消费同一事件时只有一个生效
+func (d *UploadDao) EnqueueFileProcesses(ctx context.Context, event FileCommitEvent, processors []string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ?", event.Id).Delete(&FileCommitEvent{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+
+		for _, name := range processors {
+			if err := resetFileProcess(tx, event.FileId, name, event.Version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ResetFileProcesses 将文件在各处理器上的状态重置为等待处理, 用于重新处理
+func (d *UploadDao) ResetFileProcesses(ctx context.Context, fileId int64, version int32, processors []string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, name := range processors {
+			if err := resetFileProcess(tx, fileId, name, version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// resetFileProcess 以 pending 状态创建或重置处理状态并清除旧结果, 已有的状态对应更新的版本时不变
+func resetFileProcess(tx *gorm.DB, fileId int64, processor string, version int32) error {
+	now := time.Now().Unix()
+	res := tx.Model(&FileProcess{}).
+		Where("file_id = ? AND processor = ? AND version <= ?", fileId, processor, version).
+		Updates(map[string]any{
+			"version":    version,
+			"status":     ProcessPending,
+			"attempts":   0,
+			"next_run":   now,
+			"last_error": "",
+			"result":     nil,
+			"utime":      now,
+		})
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.Error
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&FileProcess{
+		FileId:    fileId,
+		Processor: processor,
+		Version:   version,
+		Status:    ProcessPending,
+		NextRun:   now,
+		Ctime:     now,
+		Utime:     now,
+	}).Error
+}
+
+// ListDueFileProcesses 获取到期的处理, 包括等待处理、等待重试和租约到期的
+func (d *UploadDao) ListDueFileProcesses(ctx context.Context, now int64, limit int) ([]FileProcess, error) {
+	var procs []FileProcess
+	err := d.db.WithContext(ctx).Model(&FileProcess{}).
+		Where("status IN ? AND next_run <= ?", []int8{ProcessPending, ProcessRunning, ProcessRetry}, now).
+		Order("next_run ASC").Order("id ASC").Limit(limit).
+		Find(&procs).Error
+
+	return procs, err
+}
+
+// ClaimFileProcess 以 lease 为租约认领处理并增加尝试次数, 已被其他实例认领或重置时返回 false
+func (d *UploadDao) ClaimFileProcess(ctx context.Context, proc *FileProcess, lease int64) (bool, error) {
+	now := time.Now().Unix()
+	res := d.db.WithContext(ctx).Model(&FileProcess{}).
+		Where("id = ? AND status = ? AND attempts = ? AND version = ? AND next_run <= ?",
+			proc.Id, proc.Status, proc.Attempts, proc.Version, now).
+		Updates(map[string]any{
+			"status":   ProcessRunning,
+			"attempts": proc.Attempts + 1,
+			"next_run": now + lease,
+			"utime":    now,
+		})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	proc.Status, proc.Attempts, proc.NextRun, proc.Utime = ProcessRunning, proc.Attempts+1, now+lease, now
+
+	return true, nil
+}
+
+// FinishFileProcess 记录认领的处理的结果, status 为 ProcessDone、ProcessRetry 或 ProcessDead
+// 处理期间被重置时不覆盖新的状态
+func (d *UploadDao) FinishFileProcess(ctx context.Context, proc *FileProcess, status int8, result []byte, cause string, nextRun int64) error {
+	if len(cause) > 255 {
+		cause = cause[:255]
+	}
+	updates := map[string]any{
+		"status":     status,
+		"next_run":   nextRun,
+		"last_error": cause,
+		"utime":      time.Now().Unix(),
+	}
+	if status == ProcessDone {
+		updates["result"] = result
+	}
+
+	return d.db.WithContext(ctx).Model(&FileProcess{}).
+		Where("id = ? AND status = ? AND attempts = ? AND version = ?", proc.Id, ProcessRunning, proc.Attempts, proc.Version).
+		Updates(updates).Error
+}
+
+// DeleteFileProcess 删除处理状态, 用于文件已被删除的情况
+func (d *UploadDao) DeleteFileProcess(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Where("id = ?", id).Delete(&FileProcess{}).Error
+}
+
+// ListFileProcesses 获取文件在各处理器上的状态和结果
+func (d *UploadDao) ListFileProcesses(ctx context.Context, fileId int64) ([]FileProcess, error) {
+	var procs []FileProcess
+	err := d.db.WithContext(ctx).Model(&FileProcess{}).
+		Where("file_id = ?", fileId).Order("processor ASC").
+		Find(&procs).Error
+
+	return procs, err
+}
+
+// ListDeadFileProcesses 分页获取死信列表, processor 为空时不限处理器, 最近放弃的在前
+func (d *UploadDao) ListDeadFileProcesses(ctx context.Context, processor string, page, size int) ([]FileProcess, int64, error) {
+	query := d.db.WithContext(ctx).Model(&FileProcess{}).Where("status = ?", ProcessDead)
+	if processor != "" {
+		query = query.Where("processor = ?", processor)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var procs []FileProcess
+	err := query.Order("utime DESC").Order("id DESC").
+		Offset((page - 1) * size).Limit(size).
+		Find(&procs).Error
+
+	return procs, total, err
+}
+
+// RetryDeadFileProcesses 将死信重新置为等待处理, processor 为空时不限处理器, 返回重新排队的数量
+func (d *UploadDao) RetryDeadFileProcesses(ctx context.Context, processor string) (int64, error) {
+	now := time.Now().Unix()
+	query := d.db.WithContext(ctx).Model(&FileProcess{}).Where("status = ?", ProcessDead)
+	if processor != "" {
+		query = query.Where("processor = ?", processor)
+	}
+	res := query.Updates(map[string]any{
+		"status":   ProcessPending,
+		"attempts": 0,
+		"next_run": now,
+		"utime":    now,
+	})
+
+	return res.RowsAffected, res.Error
+}
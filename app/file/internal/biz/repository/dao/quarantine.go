@@ -0,0 +1,163 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QuarantinedFile 扫描发现病毒后隔离的文件及其结论, 管理员释放后删除
+// 隔离的文件状态为 2, 不出现在列表和搜索中, 不能下载和分享, 仍占用空间
+type QuarantinedFile struct {
+	FileId         int64  `gorm:"primaryKey"`
+	UserId         int32  `gorm:"not null;index:idx_quarantine_user"`
+	Name           string `gorm:"type:varchar(255);not null"`
+	Size           int64  `gorm:"not null"`
+	ObjectKey      string `gorm:"type:varchar(255);not null"`
+	Scanner        string `gorm:"type:varchar(64);not null"` // 给出结论的扫描驱动
+	Signature      string `gorm:"type:varchar(255)"`         // 命中的病毒特征
+	DisabledShares int64  `gorm:"not null;default:0"`        // 因此被禁用的分享数
+	Ctime          int64  `gorm:"not null;index:idx_quarantine_user"`
+}
+
+// QuarantineObject 隔离内容为 f 当前版本的文件, 以及共用同一对象的其他文件(复制和转存产生)
+// 同时禁用包含这些文件的有效分享; 内容在扫描期间已被替换的文件不隔离, 返回隔离的文件
+func (d *UploadDao) QuarantineObject(ctx context.Context, f *File, scanner, signature string) ([]QuarantinedFile, error) {
+	var quarantined []QuarantinedFile
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&File{}).Where("status = 0 AND id = ? AND version = ?", f.Id, f.Version)
+		if f.ObjectKey != "" {
+			query = query.Or("status = 0 AND object_key = ?", f.ObjectKey)
+		}
+		var files []File
+		if err := query.Find(&files).Error; err != nil {
+			return err
+		}
+
+		if len(signature) > 255 {
+			signature = signature[:255]
+		}
+		now := time.Now().Unix()
+		for _, file := range files {
+			res := tx.Model(&File{}).Where("id = ? AND status = 0", file.Id).
+				Updates(map[string]any{"status": 2, "name_key": nil})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				continue
+			}
+			if err := adjustFolderStats(tx, file.FolderId, file.UserId, -file.Size, -1, 0); err != nil {
+				return err
+			}
+
+			disabled, err := disableFileShares(tx, file)
+			if err != nil {
+				return err
+			}
+			q := QuarantinedFile{
+				FileId:         file.Id,
+				UserId:         file.UserId,
+				Name:           file.Name,
+				Size:           file.Size,
+				ObjectKey:      file.ObjectName(),
+				Scanner:        scanner,
+				Signature:      signature,
+				DisabledShares: disabled,
+				Ctime:          now,
+			}
+			if err := tx.Save(&q).Error; err != nil {
+				return err
+			}
+			quarantined = append(quarantined, q)
+		}
+		return nil
+	})
+
+	return quarantined, err
+}
+
+// ScanPending 文件内容是否尚未被 processor 扫描通过: 文件或共用同一对象的文件有未入队的提交事件, 或扫描尚未成功
+func (d *UploadDao) ScanPending(ctx context.Context, f File, processor string) (bool, error) {
+	db := d.db.WithContext(ctx)
+	files := db.Model(&File{}).Select("id").Where("id = ?", f.Id)
+	if f.ObjectKey != "" {
+		files = files.Or("object_key = ?", f.ObjectKey)
+	}
+
+	var n int64
+	if err := db.Model(&FileCommitEvent{}).Where("file_id IN (?)", files).Count(&n).Error; err != nil || n > 0 {
+		return n > 0, err
+	}
+	err := db.Model(&FileProcess{}).Where("processor = ? AND status <> ? AND file_id IN (?)", processor, ProcessDone, files).
+		Count(&n).Error
+
+	return n > 0, err
+}
+
+// disableFileShares 将包含文件的有效分享置为已禁用, 包括文件分享和分享了其上级文件夹的文件夹分享
+func disableFileShares(tx *gorm.DB, f File) (int64, error) {
+	folders, err := ancestorIds(tx, f.FolderId, f.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	cond := tx.Where("id IN (?)", tx.Model(&ShareFile{}).Select("share_id").Where("file_id = ?", f.Id))
+	if len(folders) > 0 {
+		cond = cond.Or("folder_id IN ?", folders)
+	}
+	res := tx.Model(&ShareLink{}).Where("status = 1 AND user_id = ?", f.UserId).Where(cond).Update("status", 4)
+
+	return res.RowsAffected, res.Error
+}
+
+// GetQuarantinedFile 获取隔离记录
+func (d *UploadDao) GetQuarantinedFile(ctx context.Context, fileId int64) (QuarantinedFile, error) {
+	var q QuarantinedFile
+	err := d.db.WithContext(ctx).Model(&QuarantinedFile{}).Where("file_id = ?", fileId).First(&q).Error
+
+	return q, err
+}
+
+// ListQuarantinedFiles 分页获取隔离的文件, uid 为 0 时不限用户, 最近隔离的在前
+func (d *UploadDao) ListQuarantinedFiles(ctx context.Context, uid int32, page, size int) ([]QuarantinedFile, int64, error) {
+	query := d.db.WithContext(ctx).Model(&QuarantinedFile{})
+	if uid != 0 {
+		query = query.Where("user_id = ?", uid)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var files []QuarantinedFile
+	err := query.Order("ctime DESC").Order("file_id DESC").
+		Offset((page - 1) * size).Limit(size).
+		Find(&files).Error
+
+	return files, total, err
+}
+
+// ReleaseQuarantinedFile 解除隔离, 文件以 name 恢复到原来的文件夹; 被禁用的分享不自动恢复
+func (d *UploadDao) ReleaseQuarantinedFile(ctx context.Context, fileId int64, name string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var file File
+		if err := tx.Model(&File{}).Where("id = ? AND status = 2", fileId).First(&file).Error; err != nil {
+			return err
+		}
+
+		now := time.Now().Unix()
+		err := tx.Model(&File{}).Where("id = ?", fileId).
+			Updates(map[string]any{"status": 0, "name": NormalizeName(name), "name_key": d.naming.key(name), "utime": now}).Error
+		if err != nil {
+			return err
+		}
+		if err := adjustFolderStats(tx, file.FolderId, file.UserId, file.Size, 1, now); err != nil {
+			return err
+		}
+
+		return tx.Where("file_id = ?", fileId).Delete(&QuarantinedFile{}).Error
+	})
+}
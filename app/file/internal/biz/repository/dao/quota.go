@@ -0,0 +1,305 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QuotaReservation 上传过程中预留的空间, 上传完成时转为已用空间, 中止或过期时释放
+type QuotaReservation struct {
+	Id       string `gorm:"primaryKey;type:varchar(64)"` // 预留 ID, 分片上传时为 upload_id
+	UserId   int32  `gorm:"not null;index"`
+	Size     int64  `gorm:"not null"`
+	Ctime    int64  `gorm:"not null"`
+	ExpireAt int64  `gorm:"not null;index"` // 过期后由对账任务中止分片上传并回收
+
+	// 分片上传的会话, 其他预留为空
+	ObjectKey  string `gorm:"type:varchar(255)"` // 分片写入的对象, 每次上传独立
+	ActorId    int32  // 发起上传的用户, 上传到共享的文件夹时与占用空间的 UserId 不同
+	FolderId   int64
+	Name       string `gorm:"type:varchar(255)"`
+	Hash       string `gorm:"type:varchar(64)"`    // 客户端提供的整个文件的 MD5 或 SHA-256
+	WrappedKey []byte `gorm:"type:varbinary(128)"` // 加密时尚未提交的数据密钥, 以 UserId 的用户密钥包裹
+	KekVersion int32
+	PartSize   int64
+}
+
+// BlobKey 分片上传尚未提交的数据密钥, 未加密的上传返回 nil
+func (r QuotaReservation) BlobKey() *BlobKey {
+	if len(r.WrappedKey) == 0 {
+		return nil
+	}
+
+	return &BlobKey{
+		ObjectKey:  r.ObjectKey,
+		KeyOwner:   r.UserId,
+		KekVersion: r.KekVersion,
+		WrappedKey: r.WrappedKey,
+		PartSize:   r.PartSize,
+	}
+}
+
+// QuotaUsage 用户空间的记录值与按文件重新计算的实际值
+type QuotaUsage struct {
+	UserId           int32
+	Capacity         int64
+	RecordedSize     int64 // FileStore 中记录的已用空间
+	ActualSize       int64 // 未删除文件与全部历史版本的大小之和
+	RecordedReserved int64 // FileStore 中记录的预留空间
+	ActualReserved   int64 // 预留的大小之和, 过期的预留在回收前仍占用空间
+	ExpiredReserved  int   // 已过期尚待回收的预留数
+	FoldersFixed     int   // 修正了统计的文件夹数
+}
+
+// Drift 已用空间的偏差, 正数表示多计
+func (u QuotaUsage) Drift() int64 {
+	return u.RecordedSize - u.ActualSize
+}
+
+// ReservedDrift 预留空间的偏差, 正数表示多计
+func (u QuotaUsage) ReservedDrift() int64 {
+	return u.RecordedReserved - u.ActualReserved
+}
+
+// ReserveQuota 原子地为 r.UserId 预留 r.Size 字节并保存预留, 已用加预留超过容量时返回 ErrInsufficientSpace
+func (d *UploadDao) ReserveQuota(ctx context.Context, r *QuotaReservation, ttl time.Duration) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&FileStore{}).
+			Where("user_id = ? AND current_size + reserved + ? <= capacity", r.UserId, r.Size).
+			Update("reserved", gorm.Expr("reserved + ?", r.Size))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrInsufficientSpace
+		}
+
+		now := time.Now()
+		r.Ctime, r.ExpireAt = now.Unix(), now.Add(ttl).Unix()
+		return tx.Create(r).Error
+	})
+}
+
+// ReleaseQuota 释放用户的预留, 预留不存在(已释放或已被回收)时不做处理
+// 上传完成时应与创建文件在同一事务中调用, 使预留转为已用空间
+func (d *UploadDao) ReleaseQuota(ctx context.Context, id string, uid int32) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var r QuotaReservation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", id, uid).First(&r).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&QuotaReservation{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&FileStore{}).Where("user_id = ?", r.UserId).
+			Update("reserved", gorm.Expr("CASE WHEN reserved > ? THEN reserved - ? ELSE 0 END", r.Size, r.Size)).Error
+	})
+}
+
+// FindQuotaReservation 获取预留, 不存在时返回 gorm.ErrRecordNotFound
+func (d *UploadDao) FindQuotaReservation(ctx context.Context, id string) (QuotaReservation, error) {
+	var r QuotaReservation
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&r).Error
+
+	return r, err
+}
+
+// ListExpiredReservations 获取 before 及之前过期的预留, 按过期时间升序, 最多 limit 个
+func (d *UploadDao) ListExpiredReservations(ctx context.Context, before int64, limit int) ([]QuotaReservation, error) {
+	var rs []QuotaReservation
+	err := d.db.WithContext(ctx).Where("expire_at <= ?", before).
+		Order("expire_at ASC").Limit(limit).Find(&rs).Error
+
+	return rs, err
+}
+
+// ListQuotaUsers 按用户 ID 升序分页获取拥有存储空间的用户
+func (d *UploadDao) ListQuotaUsers(ctx context.Context, afterUid int32, limit int) ([]int32, error) {
+	var uids []int32
+	err := d.db.WithContext(ctx).Model(&FileStore{}).
+		Where("user_id > ?", afterUid).
+		Order("user_id ASC").Limit(limit).
+		Pluck("user_id", &uids).Error
+
+	return uids, err
+}
+
+// ReconcileQuota 由文件和历史版本重新计算用户的已用空间, 由预留重新计算预留空间
+// fix 为 true 时写回计算结果, 并重建文件夹的聚合统计; 过期预留只由 ReleaseQuota 回收, 以便先中止对应的分片上传
+func (d *UploadDao) ReconcileQuota(ctx context.Context, uid int32, fix bool) (QuotaUsage, error) {
+	var usage QuotaUsage
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先锁住存储记录, 之后的统计能看到所有已提交的变更, 并发写入在锁释放后叠加到修正值上
+		var store FileStore
+		query := tx.Model(&FileStore{}).Where("user_id = ?", uid)
+		if fix {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		if err := query.First(&store).Error; err != nil {
+			return err
+		}
+
+		var err error
+		usage, err = computeUsage(tx, uid)
+		if err != nil {
+			return err
+		}
+		usage.Capacity = store.Capacity
+		usage.RecordedSize = store.CurrentSize
+		usage.RecordedReserved = store.Reserved
+		if !fix {
+			return nil
+		}
+
+		now := time.Now().Unix()
+		if usage.Drift() != 0 || usage.ReservedDrift() != 0 {
+			err := tx.Model(&FileStore{}).Where("user_id = ?", uid).Updates(map[string]any{
+				"current_size": usage.ActualSize,
+				"reserved":     usage.ActualReserved,
+				"utime":        now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if usage.FoldersFixed, err = rebuildFolderStats(tx, uid); err != nil {
+			return err
+		}
+
+		return checkQuotaLevel(tx, uid)
+	})
+	if err != nil {
+		return QuotaUsage{}, err
+	}
+
+	return usage, nil
+}
+
+// computeUsage 统计用户实际的已用和预留空间
+func computeUsage(tx *gorm.DB, uid int32) (QuotaUsage, error) {
+	usage := QuotaUsage{UserId: uid}
+
+	var files, versions int64
+	// 隔离的文件仍占用空间
+	err := tx.Model(&File{}).Select("COALESCE(SUM(size), 0)").
+		Where("user_id = ? AND status IN (0, 2)", uid).Scan(&files).Error
+	if err != nil {
+		return usage, err
+	}
+	err = tx.Model(&FileVersion{}).Select("COALESCE(SUM(size), 0)").
+		Where("user_id = ?", uid).Scan(&versions).Error
+	if err != nil {
+		return usage, err
+	}
+	usage.ActualSize = files + versions
+
+	err = tx.Model(&QuotaReservation{}).Select("COALESCE(SUM(size), 0)").
+		Where("user_id = ?", uid).Scan(&usage.ActualReserved).Error
+	if err != nil {
+		return usage, err
+	}
+
+	var expired int64
+	err = tx.Model(&QuotaReservation{}).Where("user_id = ? AND expire_at <= ?", uid, time.Now().Unix()).Count(&expired).Error
+	usage.ExpiredReserved = int(expired)
+
+	return usage, err
+}
+
+// BackfillFolderStats 为历史数据重建文件夹的聚合统计
+// 直接包含未删除文件的文件夹文件数不应为 0, 只处理存在这种文件夹的用户, 中断后重新执行可继续
+func BackfillFolderStats(db *gorm.DB) error {
+	var uids []int32
+	err := db.Model(&File{}).Distinct("file.user_id").
+		Joins("JOIN folder ON folder.id = file.folder_id").
+		Where("file.status = 0 AND folder.status = 0 AND folder.file_count = 0").
+		Pluck("file.user_id", &uids).Error
+	if err != nil {
+		return err
+	}
+
+	for _, uid := range uids {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			_, err := rebuildFolderStats(tx, uid)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rebuildFolderStats 由未删除的文件重新计算用户所有未删除文件夹的大小和文件数, 返回修正的文件夹数
+// 最近修改时间只会被调大, 删除操作刷新的时间无法由现存文件推出
+func rebuildFolderStats(tx *gorm.DB, uid int32) (int, error) {
+	var folders []Folder
+	err := tx.Model(&Folder{}).Select("id", "parent_id", "total_size", "file_count", "last_modified").
+		Where("user_id = ? AND status = 0", uid).Find(&folders).Error
+	if err != nil || len(folders) == 0 {
+		return 0, err
+	}
+
+	var files []File
+	err = tx.Model(&File{}).Select("folder_id", "size", "utime").
+		Where("user_id = ? AND status = 0", uid).Find(&files).Error
+	if err != nil {
+		return 0, err
+	}
+
+	parents := make(map[int64]int64, len(folders))
+	stats := make(map[int64]*FolderStats, len(folders))
+	for _, f := range folders {
+		parents[f.Id] = f.ParentId
+		stats[f.Id] = &FolderStats{}
+	}
+	// 每个文件累加到所在文件夹及其所有祖先
+	for _, f := range files {
+		visited := 0
+		for id := f.FolderId; id != 0 && visited <= len(folders); visited++ {
+			st, ok := stats[id]
+			if !ok {
+				break
+			}
+			st.TotalSize += f.Size
+			st.FileCount++
+			if f.Utime > st.LastModified {
+				st.LastModified = f.Utime
+			}
+			id = parents[id]
+		}
+	}
+
+	fixed := 0
+	for _, f := range folders {
+		st := stats[f.Id]
+		lastModified := max(f.LastModified, st.LastModified)
+		if f.TotalSize == st.TotalSize && f.FileCount == st.FileCount && f.LastModified == lastModified {
+			continue
+		}
+
+		err := tx.Model(&Folder{}).Where("id = ?", f.Id).Updates(map[string]any{
+			"total_size":    st.TotalSize,
+			"file_count":    st.FileCount,
+			"last_modified": lastModified,
+		}).Error
+		if err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+
+	return fixed, nil
+}
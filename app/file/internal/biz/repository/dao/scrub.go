@@ -0,0 +1,118 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 巡检发现的问题, 与 file.ScrubProblem 一致
+const (
+	ScrubCorrupt    int8 = 1 // 内容的哈希与记录的不一致
+	ScrubMissing    int8 = 2 // 对象不存在
+	ScrubUnreadable int8 = 3 // 读取或解密失败
+)
+
+// ScrubFinding 巡检发现的有问题的对象, 每个对象一条, 再次校验通过后删除
+type ScrubFinding struct {
+	ObjectKey string `gorm:"primaryKey;type:varchar(255)"`
+	FileId    int64  `gorm:"not null"`
+	UserId    int32  `gorm:"not null;index:idx_finding_user"`
+	Problem   int8   `gorm:"not null"`
+	Expected  string `gorm:"type:varchar(64)"` // 记录的哈希
+	Actual    string `gorm:"type:varchar(64)"` // 读出的内容的哈希
+	Detail    string `gorm:"type:varchar(255)"`
+	Ctime     int64  `gorm:"not null"` // 首次发现的时间
+	Utime     int64  `gorm:"not null;index:idx_finding_user"`
+}
+
+// ListScrubCandidates 获取 before 之后没有校验过的文件, 最久没有校验的在前
+func (d *UploadDao) ListScrubCandidates(ctx context.Context, before int64, limit int) ([]File, error) {
+	var files []File
+	err := d.db.WithContext(ctx).Model(&File{}).
+		Where("status = 0 AND scrub_time < ?", before).
+		Order("scrub_time ASC").Order("id ASC").Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
+// ListLiveFiles 按 ID 顺序获取 after 之后未删除的文件
+func (d *UploadDao) ListLiveFiles(ctx context.Context, after int64, limit int) ([]File, error) {
+	var files []File
+	err := d.db.WithContext(ctx).Model(&File{}).
+		Where("status = 0 AND id > ?", after).
+		Order("id ASC").Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
+// MarkFileScrubbed 记录文件的校验时间
+// sum 不为空时为没有 SHA-256 的历史文件补全摘要, 没有 hash 的一并补全 MD5, 内容在此期间被替换时不补全
+func (d *UploadDao) MarkFileScrubbed(ctx context.Context, f *File, sum, md5 string, now int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&File{}).Where("id = ?", f.Id).Update("scrub_time", now).Error; err != nil {
+			return err
+		}
+		if sum == "" {
+			return nil
+		}
+
+		return tx.Model(&File{}).
+			Where("id = ? AND sha256 = '' AND object_key = ? AND version = ?", f.Id, f.ObjectKey, f.Version).
+			Updates(map[string]any{
+				"sha256": sum,
+				"hash":   gorm.Expr("CASE WHEN hash = '' THEN ? ELSE hash END", md5),
+			}).Error
+	})
+}
+
+// SaveScrubFinding 记录有问题的对象, 已有记录时更新问题并保留首次发现的时间
+func (d *UploadDao) SaveScrubFinding(ctx context.Context, finding *ScrubFinding) error {
+	now := time.Now().Unix()
+	finding.Ctime, finding.Utime = now, now
+	if len(finding.Detail) > 255 {
+		finding.Detail = finding.Detail[:255]
+	}
+
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "object_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"file_id", "user_id", "problem", "expected", "actual", "detail", "utime"}),
+	}).Create(finding).Error
+}
+
+// DeleteScrubFinding 对象再次校验通过时删除记录
+func (d *UploadDao) DeleteScrubFinding(ctx context.Context, objectKey string) error {
+	return d.db.WithContext(ctx).Where("object_key = ?", objectKey).Delete(&ScrubFinding{}).Error
+}
+
+// ListScrubFindings 分页获取有问题的对象, uid 为 0 时不限用户, 最近确认的在前
+func (d *UploadDao) ListScrubFindings(ctx context.Context, uid int32, page, size int) ([]ScrubFinding, int64, error) {
+	query := d.db.WithContext(ctx).Model(&ScrubFinding{})
+	if uid != 0 {
+		query = query.Where("user_id = ?", uid)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var findings []ScrubFinding
+	err := query.Order("utime DESC").Order("object_key ASC").
+		Offset((page - 1) * size).Limit(size).
+		Find(&findings).Error
+
+	return findings, total, err
+}
+
+// CountScrubFindings 统计有问题的对象数
+func (d *UploadDao) CountScrubFindings(ctx context.Context) (int64, error) {
+	var n int64
+	err := d.db.WithContext(ctx).Model(&ScrubFinding{}).Count(&n).Error
+
+	return n, err
+}
@@ -0,0 +1,351 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrShareNotFound 分享不存在、已过期或已取消
+	ErrShareNotFound = errors.New("share link not found or expired")
+	// ErrNotInShare 访问的条目不属于分享
+	ErrNotInShare = errors.New("item is not part of the share")
+	// ErrShareLimitReached 分享的下载或转存次数已达上限
+	ErrShareLimitReached = errors.New("share access limit reached")
+)
+
+// 分享访问记录的类型
+const (
+	ShareActionView     = "view"
+	ShareActionDownload = "download"
+	ShareActionSave     = "save"
+)
+
+// ShareAccess 分享的访问记录
+type ShareAccess struct {
+	Id      int64  `gorm:"primaryKey,autoIncrement"`
+	ShareId string `gorm:"type:varchar(64);not null;index:idx_share_ctime"`
+	Action  string `gorm:"type:varchar(16);not null"` // view/download/save
+	Ip      string `gorm:"type:varchar(64)"`
+	UserId  int32  // 转存时为转存者ID, 匿名访问为 0
+	Ctime   int64  `gorm:"not null;index:idx_share_ctime"`
+}
+
+// ShareUpdate 分享的可修改项, 为 nil 的字段保持不变
+type ShareUpdate struct {
+	ExpireAt     *time.Time
+	Password     *string // 明文密码, 空串表示取消密码
+	MaxDownloads *int64
+	MaxSaves     *int64
+}
+
+// HashSharePassword 计算提取密码的 bcrypt 哈希, 空密码返回空串
+func HashSharePassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// CheckSharePassword 以恒定时间比较提取密码, 分享没有密码时总是通过
+func CheckSharePassword(hash, password string) bool {
+	if hash == "" {
+		return true
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// BackfillSharePasswords 将历史数据中的明文提取密码替换为哈希
+func BackfillSharePasswords(db *gorm.DB) error {
+	var shares []ShareLink
+	return db.Model(&ShareLink{}).Select("id", "password").
+		Where("password <> ''").
+		FindInBatches(&shares, backfillBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, sh := range shares {
+				if _, err := bcrypt.Cost([]byte(sh.Password)); err == nil {
+					continue
+				}
+				hash, err := HashSharePassword(sh.Password)
+				if err != nil {
+					return err
+				}
+				if err := db.Model(&ShareLink{}).Where("id = ?", sh.Id).Update("password", hash).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// ListShares 按创建时间倒序分页获取用户的分享, status 为 0 时不按状态筛选
+func (d *UploadDao) ListShares(ctx context.Context, uid int32, status int8, page, size int) ([]ShareLink, int64, error) {
+	query := d.db.WithContext(ctx).Model(&ShareLink{}).Where("user_id = ?", uid)
+	if status != 0 {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var shares []ShareLink
+	err := query.Order("created_at DESC").Offset((page - 1) * size).Limit(size).Find(&shares).Error
+
+	return shares, total, err
+}
+
+// CountShareFiles 统计文件分享包含的文件数
+func (d *UploadDao) CountShareFiles(ctx context.Context, shareIds []string) (map[string]int64, error) {
+	var rows []struct {
+		ShareId string
+		Count   int64
+	}
+	err := d.db.WithContext(ctx).Model(&ShareFile{}).
+		Select("share_id, COUNT(*) AS count").
+		Where("share_id IN ?", shareIds).
+		Group("share_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, r := range rows {
+		counts[r.ShareId] = r.Count
+	}
+
+	return counts, nil
+}
+
+// GetUserShare 获取用户的分享, 不限状态
+func (d *UploadDao) GetUserShare(ctx context.Context, uid int32, shareId string) (ShareLink, error) {
+	var share ShareLink
+	err := d.db.WithContext(ctx).Model(&ShareLink{}).Where("id = ? AND user_id = ?", shareId, uid).First(&share).Error
+
+	return share, err
+}
+
+// RevokeShares 取消用户的分享, 返回取消的数量
+func (d *UploadDao) RevokeShares(ctx context.Context, uid int32, shareIds []string) (int64, error) {
+	res := d.db.WithContext(ctx).Model(&ShareLink{}).
+		Where("id IN ? AND user_id = ? AND status <> 3", shareIds, uid).
+		Update("status", 3)
+
+	return res.RowsAffected, res.Error
+}
+
+// UpdateShare 修改用户的分享, 已过期的分享延长有效期后恢复为有效, 已取消和已禁用的分享不能修改
+func (d *UploadDao) UpdateShare(ctx context.Context, uid int32, shareId string, upd ShareUpdate) (ShareLink, error) {
+	var share ShareLink
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&ShareLink{}).Where("id = ? AND user_id = ? AND status IN (1, 2)", shareId, uid).First(&share).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrShareNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]any{}
+		if upd.ExpireAt != nil {
+			updates["expire_at"] = *upd.ExpireAt
+			if upd.ExpireAt.After(time.Now()) {
+				updates["status"] = 1
+			} else {
+				updates["status"] = 2
+			}
+		}
+		if upd.Password != nil {
+			hash, err := HashSharePassword(*upd.Password)
+			if err != nil {
+				return err
+			}
+			updates["password"] = hash
+		}
+		if upd.MaxDownloads != nil {
+			updates["max_downloads"] = *upd.MaxDownloads
+		}
+		if upd.MaxSaves != nil {
+			updates["max_saves"] = *upd.MaxSaves
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&ShareLink{}).Where("id = ?", shareId).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&ShareLink{}).Where("id = ?", shareId).First(&share).Error
+	})
+	if err != nil {
+		return ShareLink{}, err
+	}
+
+	return share, nil
+}
+
+// RecordShareAccess 记录一次分享访问并累加计数, 下载和转存超过上限时返回 ErrShareLimitReached
+func (d *UploadDao) RecordShareAccess(ctx context.Context, shareId, action, ip string, uid int32) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&ShareLink{}).Where("id = ? AND status = 1", shareId)
+		var column string
+		switch action {
+		case ShareActionDownload:
+			column = "download_count"
+			query = query.Where("max_downloads = 0 OR download_count < max_downloads")
+		case ShareActionSave:
+			column = "save_count"
+			query = query.Where("max_saves = 0 OR save_count < max_saves")
+		default:
+			column = "view_count"
+		}
+
+		res := query.Update(column, gorm.Expr(column+" + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// 区分分享已失效与次数已达上限
+			var n int64
+			if err := tx.Model(&ShareLink{}).Where("id = ? AND status = 1", shareId).Count(&n).Error; err != nil {
+				return err
+			}
+			if n == 0 {
+				return ErrShareNotFound
+			}
+			return ErrShareLimitReached
+		}
+
+		return tx.Create(&ShareAccess{
+			ShareId: shareId,
+			Action:  action,
+			Ip:      ip,
+			UserId:  uid,
+			Ctime:   time.Now().Unix(),
+		}).Error
+	})
+}
+
+// ListShareAccess 按时间倒序分页获取分享的访问记录
+func (d *UploadDao) ListShareAccess(ctx context.Context, shareId string, page, size int) ([]ShareAccess, int64, error) {
+	query := d.db.WithContext(ctx).Model(&ShareAccess{}).Where("share_id = ?", shareId)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []ShareAccess
+	err := query.Order("ctime DESC").Order("id DESC").Offset((page - 1) * size).Limit(size).Find(&logs).Error
+
+	return logs, total, err
+}
+
+// ExpireShares 将已到期的有效分享标记为已过期, 返回处理的数量
+func (d *UploadDao) ExpireShares(ctx context.Context) (int64, error) {
+	res := d.db.WithContext(ctx).Model(&ShareLink{}).
+		Where("status = 1 AND expire_at <= ?", time.Now()).
+		Update("status", 2)
+
+	return res.RowsAffected, res.Error
+}
+
+// GetShareFolder 获取分享内未删除的文件夹, 文件夹须为分享的文件夹或其子孙, 否则返回 ErrNotInShare
+// 分享后才在其中创建的保险库不属于分享
+func (d *UploadDao) GetShareFolder(ctx context.Context, share ShareLink, folderId int64) (Folder, error) {
+	if share.FolderId == 0 {
+		return Folder{}, ErrNotInShare
+	}
+
+	db := d.db.WithContext(ctx)
+	var folder Folder
+	err := db.Model(&Folder{}).Scopes(shareScope).Where("id = ? AND user_id = ?", folderId, share.UserId).First(&folder).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Folder{}, ErrNotInShare
+	}
+	if err != nil {
+		return Folder{}, err
+	}
+	if err := checkInShare(db, share, folder.Id); err != nil {
+		return Folder{}, err
+	}
+
+	return folder, nil
+}
+
+// GetShareFile 获取分享内未删除的文件, 文件分享须在分享的文件列表中, 文件夹分享须位于分享的文件夹下, 保险库中的文件不属于分享
+func (d *UploadDao) GetShareFile(ctx context.Context, share ShareLink, fileId int64) (File, error) {
+	db := d.db.WithContext(ctx)
+	var file File
+	err := db.Model(&File{}).Scopes(shareScope).Where("id = ? AND user_id = ?", fileId, share.UserId).First(&file).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return File{}, ErrNotInShare
+	}
+	if err != nil {
+		return File{}, err
+	}
+
+	if share.FolderId == 0 {
+		var n int64
+		err := db.Model(&ShareFile{}).Where("share_id = ? AND file_id = ?", share.Id, fileId).Count(&n).Error
+		if err != nil {
+			return File{}, err
+		}
+		if n == 0 {
+			return File{}, ErrNotInShare
+		}
+		return file, nil
+	}
+	if err := checkInShare(db, share, file.FolderId); err != nil {
+		return File{}, err
+	}
+
+	return file, nil
+}
+
+// ListShareFiles 获取文件分享中仍未删除且不在保险库中的文件
+func (d *UploadDao) ListShareFiles(ctx context.Context, share ShareLink) ([]File, error) {
+	var files []File
+	err := d.db.WithContext(ctx).Model(&File{}).Scopes(shareScope).
+		Where("id IN (?) AND user_id = ?",
+			d.db.Model(&ShareFile{}).Select("file_id").Where("share_id = ?", share.Id), share.UserId).
+		Order("id ASC").
+		Find(&files).Error
+
+	return files, err
+}
+
+// ListShareSubtree 获取分享的文件夹下可访问的子文件夹和文件, 保险库及其中的条目不包括在内, 子文件夹按深度排序
+func (d *UploadDao) ListShareSubtree(ctx context.Context, folderId int64, uid int32) ([]Folder, []File, error) {
+	return listSubtree(d.db.WithContext(ctx), folderId, uid, shareScope)
+}
+
+// shareScope 只查询未删除且不在保险库中的条目, 保险库中的内容只能通过密钥信封共享
+func shareScope(db *gorm.DB) *gorm.DB {
+	return db.Where("status = 0 AND vault_id = 0")
+}
+
+// checkInShare 检查文件夹是否为分享的文件夹或位于其下
+func checkInShare(db *gorm.DB, share ShareLink, folderId int64) error {
+	ids, err := ancestorIds(db, folderId, share.UserId)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(ids, share.FolderId) {
+		return ErrNotInShare
+	}
+
+	return nil
+}
@@ -0,0 +1,379 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 团队成员的角色, 由用户服务维护
+const (
+	TeamRoleMember int8 = 1
+	TeamRoleAdmin  int8 = 2
+	TeamRoleOwner  int8 = 3
+)
+
+// 团队空间动态的类型
+const (
+	ActivityUpload   = "upload"
+	ActivityCreate   = "create_folder"
+	ActivityMove     = "move"
+	ActivityRename   = "rename"
+	ActivityDelete   = "delete"
+	ActivityCopy     = "copy"
+	ActivityUpdate   = "update"
+	ActivityTransfer = "transfer_in"
+	ActivityTransOut = "transfer_out"
+)
+
+// ErrSpaceRoot 团队空间的根文件夹不能删除、移动或重命名
+var ErrSpaceRoot = errors.New("the root folder of a team space cannot be changed")
+
+// Space 团队空间, 空间内的条目和存储记录以 TeamOwnerId(TeamId) 作为所有者, 与个人网盘共用同一套表
+type Space struct {
+	TeamId       int32  `gorm:"primaryKey;autoIncrement:false"`
+	Name         string `gorm:"type:varchar(255);not null"`
+	RootFolderId int64  `gorm:"not null"`
+	Ctime        int64
+}
+
+// SpaceMember 团队空间的成员, 由用户服务在成员变更时同步, 用于权限检查
+type SpaceMember struct {
+	Id     int64 `gorm:"primaryKey,autoIncrement"`
+	TeamId int32 `gorm:"not null;uniqueIndex:uk_space_member"`
+	UserId int32 `gorm:"not null;uniqueIndex:uk_space_member;index"`
+	Role   int8  `gorm:"not null"`
+	Utime  int64
+}
+
+// SpaceActivity 团队空间的动态
+type SpaceActivity struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	TeamId   int32  `gorm:"not null;index:idx_team_ctime"`
+	UserId   int32  // 操作者
+	Action   string `gorm:"type:varchar(32);not null"`
+	ItemType string `gorm:"type:varchar(16)"` // file/folder
+	ItemId   int64
+	Name     string `gorm:"type:varchar(255)"`
+	Ctime    int64  `gorm:"not null;index:idx_team_ctime"`
+}
+
+// TeamOwnerId 团队空间的条目的所有者ID, 为负的团队ID, 不会与用户ID冲突
+func TeamOwnerId(teamId int32) int32 {
+	return -teamId
+}
+
+// SpaceTeamId 所有者为团队空间时返回团队ID, 否则返回 0
+func SpaceTeamId(owner int32) int32 {
+	if owner < 0 {
+		return -owner
+	}
+	return 0
+}
+
+// CreateSpace 为团队创建存储记录和根文件夹, 已创建时返回已有的空间
+func (d *UploadDao) CreateSpace(ctx context.Context, teamId int32, name string) (Space, error) {
+	var space Space
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Space{}).Where("team_id = ?", teamId).First(&space).Error
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		now := time.Now().Unix()
+		owner := TeamOwnerId(teamId)
+		store := &FileStore{UserId: owner, Ctime: now, Utime: now}
+		if err := tx.Create(store).Error; err != nil {
+			return err
+		}
+
+		name = NormalizeName(name)
+		root := &Folder{
+			Name:    name,
+			NameKey: d.naming.key(name),
+			UserId:  owner,
+			Path:    JoinPath("", name),
+			Ctime:   now,
+			Utime:   now,
+		}
+		if err := tx.Create(root).Error; err != nil {
+			return err
+		}
+
+		space = Space{TeamId: teamId, Name: name, RootFolderId: root.Id, Ctime: now}
+		return tx.Create(&space).Error
+	})
+
+	return space, err
+}
+
+// GetSpace 获取团队空间
+func (d *UploadDao) GetSpace(ctx context.Context, teamId int32) (Space, error) {
+	var space Space
+	err := d.db.WithContext(ctx).Model(&Space{}).Where("team_id = ?", teamId).First(&space).Error
+
+	return space, err
+}
+
+// SetSpaceMember 同步团队成员的角色, role 为 0 时移除成员
+func (d *UploadDao) SetSpaceMember(ctx context.Context, teamId, uid int32, role int8) error {
+	db := d.db.WithContext(ctx)
+	if role == 0 {
+		return db.Where("team_id = ? AND user_id = ?", teamId, uid).Delete(&SpaceMember{}).Error
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "team_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "utime"}),
+	}).Create(&SpaceMember{TeamId: teamId, UserId: uid, Role: role, Utime: time.Now().Unix()}).Error
+}
+
+// GetSpaceMemberRole 获取用户在团队中的角色, 不是成员时为 0
+func (d *UploadDao) GetSpaceMemberRole(ctx context.Context, teamId, uid int32) (int8, error) {
+	return spaceMemberRole(d.db.WithContext(ctx), teamId, uid)
+}
+
+// CountSpaceMembers 统计团队中角色为 role 的成员数
+func (d *UploadDao) CountSpaceMembers(ctx context.Context, teamId int32, role int8) (int64, error) {
+	var n int64
+	err := d.db.WithContext(ctx).Model(&SpaceMember{}).Where("team_id = ? AND role = ?", teamId, role).Count(&n).Error
+
+	return n, err
+}
+
+// RecordSpaceActivity 记录团队空间的动态
+func (d *UploadDao) RecordSpaceActivity(ctx context.Context, a *SpaceActivity) error {
+	a.Ctime = time.Now().Unix()
+	return d.db.WithContext(ctx).Create(a).Error
+}
+
+// ListSpaceActivity 按时间倒序分页获取团队空间的动态
+func (d *UploadDao) ListSpaceActivity(ctx context.Context, teamId int32, page, size int) ([]SpaceActivity, int64, error) {
+	query := d.db.WithContext(ctx).Model(&SpaceActivity{}).Where("team_id = ?", teamId)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var activities []SpaceActivity
+	err := query.Order("ctime DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&activities).Error
+
+	return activities, total, err
+}
+
+// TransferFile 将文件转移到另一个所有者的文件夹 toFolderId 下并命名为 name
+// 文件连同历史版本和元数据一起转移, 占用的空间从原所有者转到新所有者, 新所有者空间不足时返回 ErrInsufficientSpace
+func (d *UploadDao) TransferFile(ctx context.Context, fileId int64, from int32, toFolderId int64, to int32, name string) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var file File
+		if err := tx.Model(&File{}).Where("id = ? AND user_id = ? AND status = 0", fileId, from).First(&file).Error; err != nil {
+			return err
+		}
+
+		size, err := transferUsage(tx, []int64{file.Id}, file.Size)
+		if err != nil {
+			return err
+		}
+		if err := ensureCapacity(tx, to, size); err != nil {
+			return err
+		}
+
+		name = NormalizeName(name)
+		now := time.Now().Unix()
+		err = tx.Model(&File{}).Where("id = ?", file.Id).Updates(map[string]any{
+			"user_id":   to,
+			"folder_id": toFolderId,
+			"name":      name,
+			"name_key":  d.naming.key(name),
+			"utime":     now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err := transferFileRows(tx, []int64{file.Id}, to); err != nil {
+			return err
+		}
+		if err := transferAcl(tx, ResourceFile, []int64{file.Id}, to); err != nil {
+			return err
+		}
+
+		if err := adjustFolderStats(tx, file.FolderId, from, -file.Size, -1, now); err != nil {
+			return err
+		}
+		if err := adjustFolderStats(tx, toFolderId, to, file.Size, 1, now); err != nil {
+			return err
+		}
+		if err := addCurrentSize(tx, from, -size); err != nil {
+			return err
+		}
+
+		return addCurrentSize(tx, to, size)
+	})
+}
+
+// TransferFolder 将文件夹连同整个子树转移到另一个所有者的文件夹 toFolderId 下并命名为 name
+// 子树中回收站内的条目一并转移, 占用的空间从原所有者转到新所有者
+func (d *UploadDao) TransferFolder(ctx context.Context, folderId int64, from int32, toFolderId int64, to int32, name string) (Folder, error) {
+	var folder Folder
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Folder{}).Where("id = ? AND user_id = ? AND status = 0", folderId, from).First(&folder).Error; err != nil {
+			return err
+		}
+
+		folders, files, err := listSubtree(tx, folder.Id, from, func(db *gorm.DB) *gorm.DB { return db })
+		if err != nil {
+			return err
+		}
+		folderIds := []int64{folder.Id}
+		for _, f := range folders {
+			folderIds = append(folderIds, f.Id)
+		}
+		var live int64
+		fileIds := make([]int64, 0, len(files))
+		for _, f := range files {
+			fileIds = append(fileIds, f.Id)
+			if f.Status == 0 {
+				live += f.Size
+			}
+		}
+
+		size, err := transferUsage(tx, fileIds, live)
+		if err != nil {
+			return err
+		}
+		if err := ensureCapacity(tx, to, size); err != nil {
+			return err
+		}
+
+		parentPath, err := folderPath(tx, toFolderId, to)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().Unix()
+		if err := adjustFolderStats(tx, folder.ParentId, from, -folder.TotalSize, -folder.FileCount, now); err != nil {
+			return err
+		}
+
+		folder.Name = NormalizeName(name)
+		folder.ParentId = toFolderId
+		folder.UserId = to
+		folder.Path = JoinPath(parentPath, folder.Name)
+		folder.Utime = now
+		err = tx.Model(&Folder{}).Where("id = ?", folder.Id).Updates(map[string]any{
+			"name":      folder.Name,
+			"name_key":  d.naming.key(folder.Name),
+			"parent_id": folder.ParentId,
+			"path":      folder.Path,
+			"utime":     folder.Utime,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&Folder{}).Where("id IN ?", folderIds).Update("user_id", to).Error; err != nil {
+			return err
+		}
+		if len(fileIds) > 0 {
+			if err := tx.Model(&File{}).Where("id IN ?", fileIds).Update("user_id", to).Error; err != nil {
+				return err
+			}
+			if err := transferFileRows(tx, fileIds, to); err != nil {
+				return err
+			}
+			if err := transferAcl(tx, ResourceFile, fileIds, to); err != nil {
+				return err
+			}
+		}
+		if err := transferAcl(tx, ResourceFolder, folderIds, to); err != nil {
+			return err
+		}
+		if err := rebuildSubtreePaths(tx, folder); err != nil {
+			return err
+		}
+
+		if err := adjustFolderStats(tx, toFolderId, to, folder.TotalSize, folder.FileCount, now); err != nil {
+			return err
+		}
+		if err := addCurrentSize(tx, from, -size); err != nil {
+			return err
+		}
+
+		return addCurrentSize(tx, to, size)
+	})
+	if err != nil {
+		return Folder{}, err
+	}
+
+	return folder, nil
+}
+
+// transferUsage 计算转移的文件占用的空间, live 为其中未删除文件的大小, 另加上全部历史版本的大小
+func transferUsage(tx *gorm.DB, fileIds []int64, live int64) (int64, error) {
+	if len(fileIds) == 0 {
+		return live, nil
+	}
+
+	var versions int64
+	err := tx.Model(&FileVersion{}).Select("COALESCE(SUM(size), 0)").
+		Where("file_id IN ?", fileIds).Scan(&versions).Error
+
+	return live + versions, err
+}
+
+// transferFileRows 将文件的元数据和历史版本转给新所有者
+func transferFileRows(tx *gorm.DB, fileIds []int64, to int32) error {
+	if err := tx.Model(&FileMeta{}).Where("file_id IN ?", fileIds).Update("user_id", to).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&FileVersion{}).Where("file_id IN ?", fileIds).Update("user_id", to).Error
+}
+
+// transferAcl 将条目上的授权转给新所有者, 授权给新所有者自己的记录不再需要
+func transferAcl(tx *gorm.DB, resourceType int8, ids []int64, to int32) error {
+	err := tx.Where("resource_type = ? AND resource_id IN ? AND user_id = ?", resourceType, ids, to).Delete(&Acl{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&Acl{}).Where("resource_type = ? AND resource_id IN ?", resourceType, ids).Update("owner_id", to).Error
+}
+
+// spaceMemberRole 获取用户在团队中的角色, 不是成员时为 0
+func spaceMemberRole(db *gorm.DB, teamId, uid int32) (int8, error) {
+	var members []SpaceMember
+	err := db.Model(&SpaceMember{}).Where("team_id = ? AND user_id = ?", teamId, uid).Limit(1).Find(&members).Error
+	if err != nil || len(members) == 0 {
+		return 0, err
+	}
+
+	return members[0].Role, nil
+}
+
+// spaceRole 将团队成员的角色转换为对空间内条目的角色, 所有者和管理员可管理条目的共享, 成员可编辑
+func spaceRole(db *gorm.DB, uid, owner int32) (int8, error) {
+	teamId := SpaceTeamId(owner)
+	if teamId == 0 {
+		return 0, nil
+	}
+
+	role, err := spaceMemberRole(db, teamId, uid)
+	if err != nil {
+		return 0, err
+	}
+	switch role {
+	case TeamRoleOwner, TeamRoleAdmin:
+		return RoleOwner, nil
+	case TeamRoleMember:
+		return RoleEditor, nil
+	default:
+		return 0, nil
+	}
+}
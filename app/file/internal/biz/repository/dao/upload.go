@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrUploadAborted 上传已被放弃, 不能再提交
+var ErrUploadAborted = errors.New("upload has been aborted")
+
+// 上传的状态, 只会按 pending -> stored -> committed 前进, pending 和 stored 可以转为 aborted
+const (
+	UploadPending   int8 = iota // 已登记, 对象尚未写入
+	UploadStored                // 对象已写入, 文件记录尚未提交
+	UploadCommitted             // 文件记录已提交
+	UploadAborted               // 已放弃, 等待删除对象并释放预留
+)
+
+// 上传的操作, 决定修复任务如何重试提交
+const (
+	UploadOpCreate  int8 = iota // 新建文件
+	UploadOpUpdate              // 全量更新已有文件的内容
+	UploadOpRequest             // 文件收集链接的匿名上传
+)
+
+// UploadIntent 上传日志, 对象写入前登记, 与文件记录在同一事务中提交
+// 进程在两者之间退出时, 修复任务根据日志重试提交或删除对象
+type UploadIntent struct {
+	Id            int64  `gorm:"primaryKey,autoIncrement"`
+	ObjectKey     string `gorm:"type:varchar(255);not null;uniqueIndex"`
+	Op            int8   `gorm:"not null"`
+	State         int8   `gorm:"not null;index:idx_state_utime"`
+	UserId        int32  `gorm:"not null"`           // 对象所属的用户, 即占用空间的用户
+	ReservationId string `gorm:"type:varchar(64)"`   // 上传持有的空间预留, 放弃后由修复任务释放
+	RequestId     string `gorm:"type:varchar(64)"`   // 匿名上传占用的文件收集链接, 放弃后由修复任务释放名额
+	Size          int64  `gorm:"not null;default:0"` // 对象的明文大小, 用于归还文件收集链接的额度
+	FileId        int64  `gorm:"not null;default:0"` // 全量更新的文件
+	BaseVersion   int32  `gorm:"not null;default:0"` // 全量更新前文件的版本, 重试时版本已变化则放弃
+	Policy        int32  `gorm:"not null;default:0"` // 新建文件时的同名策略
+	Record        []byte `gorm:"type:blob"`          // 待提交的文件记录, JSON 编码
+	Attempts      int32  `gorm:"not null;default:0"` // 修复失败的次数
+	LastError     string `gorm:"type:varchar(255)"`  // 最近一次修复失败的原因
+	Ctime         int64  `gorm:"not null"`
+	Utime         int64  `gorm:"not null;index:idx_state_utime"`
+}
+
+// CreateUploadIntent 以 pending 状态登记上传
+func (d *UploadDao) CreateUploadIntent(ctx context.Context, intent *UploadIntent) error {
+	now := time.Now().Unix()
+	intent.State = UploadPending
+	intent.Ctime, intent.Utime = now, now
+
+	return d.db.WithContext(ctx).Create(intent).Error
+}
+
+// SetUploadState 将上传从 from 状态转为 to 状态, 上传不处于 from 状态时返回 false
+// 与创建文件记录在同一事务中转为 committed, 保证文件记录可见时对象已经写入
+func (d *UploadDao) SetUploadState(ctx context.Context, id int64, from, to int8) (bool, error) {
+	res := d.db.WithContext(ctx).Model(&UploadIntent{}).
+		Where("id = ? AND state = ?", id, from).
+		Updates(map[string]any{"state": to, "utime": time.Now().Unix()})
+
+	return res.RowsAffected == 1, res.Error
+}
+
+// AbortUploadIntent 将未提交的上传标记为已放弃
+// held 为 false 时上传的请求会自行释放预留和名额, 清除记录避免修复任务重复释放
+func (d *UploadDao) AbortUploadIntent(ctx context.Context, id int64, held bool) (bool, error) {
+	updates := map[string]any{"state": UploadAborted, "utime": time.Now().Unix()}
+	if !held {
+		updates["reservation_id"] = ""
+		updates["request_id"] = ""
+	}
+	res := d.db.WithContext(ctx).Model(&UploadIntent{}).
+		Where("id = ? AND state IN ?", id, []int8{UploadPending, UploadStored}).
+		Updates(updates)
+
+	return res.RowsAffected == 1, res.Error
+}
+
+// ClaimUploadIntent 修复任务认领上传, 以 utime 作为版本, 多个实例同时修复时只有一个成功
+func (d *UploadDao) ClaimUploadIntent(ctx context.Context, intent *UploadIntent) (bool, error) {
+	now := time.Now().Unix()
+	res := d.db.WithContext(ctx).Model(&UploadIntent{}).
+		Where("id = ? AND state = ? AND utime = ?", intent.Id, intent.State, intent.Utime).
+		Update("utime", now)
+	if res.RowsAffected == 1 {
+		intent.Utime = now
+	}
+
+	return res.RowsAffected == 1, res.Error
+}
+
+// RecordUploadFailure 记录一次修复失败
+func (d *UploadDao) RecordUploadFailure(ctx context.Context, id int64, cause string) error {
+	if len(cause) > 255 {
+		cause = cause[:255]
+	}
+
+	return d.db.WithContext(ctx).Model(&UploadIntent{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": cause,
+			"utime":      time.Now().Unix(),
+		}).Error
+}
+
+// ListStaleUploads 获取 before 之前最后一次变化且尚未结束的上传
+func (d *UploadDao) ListStaleUploads(ctx context.Context, before int64, limit int) ([]UploadIntent, error) {
+	var intents []UploadIntent
+	err := d.db.WithContext(ctx).
+		Where("state IN ? AND utime < ?", []int8{UploadPending, UploadStored, UploadAborted}, before).
+		Order("utime ASC").Limit(limit).
+		Find(&intents).Error
+
+	return intents, err
+}
+
+// DeleteUploadIntent 删除上传日志
+func (d *UploadDao) DeleteUploadIntent(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Delete(&UploadIntent{}, "id = ?", id).Error
+}
+
+// PurgeCommittedUploads 删除 before 之前提交的上传日志, 返回删除的数量
+func (d *UploadDao) PurgeCommittedUploads(ctx context.Context, before int64) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("state = ? AND utime < ?", UploadCommitted, before).
+		Delete(&UploadIntent{})
+
+	return res.RowsAffected, res.Error
+}
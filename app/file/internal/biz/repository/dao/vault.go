@@ -0,0 +1,136 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserPublicKey 用户的公钥, 客户端以此包裹保险库密钥, 私钥只保存在客户端
+type UserPublicKey struct {
+	UserId    int32  `gorm:"primaryKey;autoIncrement:false"`
+	PublicKey []byte `gorm:"type:varbinary(1024);not null"`
+	Algorithm string `gorm:"type:varchar(32);not null"`
+	Ctime     int64
+	Utime     int64
+}
+
+// VaultKeyEnvelope 以成员公钥包裹的保险库密钥, 每个成员一份, 服务端无法解开
+type VaultKeyEnvelope struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	VaultId   int64  `gorm:"not null;uniqueIndex:uk_vault_user"`
+	UserId    int32  `gorm:"not null;uniqueIndex:uk_vault_user;index"`
+	Envelope  []byte `gorm:"type:varbinary(2048);not null"`
+	CreatedBy int32  `gorm:"not null"` // 生成信封的用户
+	Ctime     int64
+	Utime     int64
+}
+
+// CreateVault 创建保险库的根文件夹并保存创建者的密钥信封
+// 根文件夹的 VaultId 为自身ID, 之后在其中创建的条目都继承该值
+func (d *UploadDao) CreateVault(ctx context.Context, folder *Folder, creator int32, envelope []byte) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Unix()
+		folder.Name = NormalizeName(folder.Name)
+		folder.NameKey = d.naming.key(folder.Name)
+		folder.Ctime = now
+		folder.Utime = now
+
+		var parent Folder
+		err := tx.Model(&Folder{}).Where("id = ? AND user_id = ?", folder.ParentId, folder.UserId).Find(&parent).Error
+		if err != nil {
+			return err
+		}
+		folder.Path = JoinPath(parent.Path, folder.Name)
+		if err := tx.Create(folder).Error; err != nil {
+			return err
+		}
+
+		folder.VaultId = folder.Id
+		if err := tx.Model(&Folder{}).Where("id = ?", folder.Id).Update("vault_id", folder.Id).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&VaultKeyEnvelope{
+			VaultId:   folder.Id,
+			UserId:    creator,
+			Envelope:  envelope,
+			CreatedBy: creator,
+			Ctime:     now,
+			Utime:     now,
+		}).Error
+	})
+}
+
+// SaveUserPublicKey 保存用户的公钥, 已存在时替换
+// 替换公钥不会影响已有的信封, 客户端需要自行为新公钥重新生成信封
+func (d *UploadDao) SaveUserPublicKey(ctx context.Context, key *UserPublicKey) error {
+	now := time.Now().Unix()
+	key.Ctime, key.Utime = now, now
+
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"public_key", "algorithm", "utime"}),
+	}).Create(key).Error
+}
+
+// GetUserPublicKeys 批量获取用户的公钥, 未设置公钥的用户不在结果中
+func (d *UploadDao) GetUserPublicKeys(ctx context.Context, uids []int32) ([]UserPublicKey, error) {
+	var keys []UserPublicKey
+	err := d.db.WithContext(ctx).Where("user_id IN ?", uids).Find(&keys).Error
+
+	return keys, err
+}
+
+// SaveVaultKeyEnvelope 保存用户的保险库密钥信封, 已存在时替换
+func (d *UploadDao) SaveVaultKeyEnvelope(ctx context.Context, env *VaultKeyEnvelope) error {
+	now := time.Now().Unix()
+	env.Ctime, env.Utime = now, now
+
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "vault_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"envelope", "created_by", "utime"}),
+	}).Create(env).Error
+}
+
+// GetVaultKeyEnvelope 获取用户的保险库密钥信封, 不存在时返回 gorm.ErrRecordNotFound
+func (d *UploadDao) GetVaultKeyEnvelope(ctx context.Context, vaultId int64, uid int32) (VaultKeyEnvelope, error) {
+	var env VaultKeyEnvelope
+	err := d.db.WithContext(ctx).Where("vault_id = ? AND user_id = ?", vaultId, uid).First(&env).Error
+
+	return env, err
+}
+
+// DeleteVaultKeyEnvelope 删除用户的保险库密钥信封, 返回删除的数量
+func (d *UploadDao) DeleteVaultKeyEnvelope(ctx context.Context, vaultId int64, uid int32) (int64, error) {
+	res := d.db.WithContext(ctx).Where("vault_id = ? AND user_id = ?", vaultId, uid).Delete(&VaultKeyEnvelope{})
+
+	return res.RowsAffected, res.Error
+}
+
+// HasVaultUnder 文件夹下是否有未删除的保险库
+func (d *UploadDao) HasVaultUnder(ctx context.Context, folder Folder) (bool, error) {
+	var n int64
+	err := d.db.WithContext(ctx).Model(&Folder{}).
+		Where("user_id = ? AND status = 0 AND vault_id <> 0 AND path LIKE ? ESCAPE '!'", folder.UserId, likePrefix(folder.Path+"/")).
+		Count(&n).Error
+
+	return n > 0, err
+}
+
+// folderVaultId 获取文件夹所在的保险库, 根目录不在任何保险库中
+func folderVaultId(tx *gorm.DB, folderId int64) (int64, error) {
+	if folderId == 0 {
+		return 0, nil
+	}
+
+	var vaultIds []int64
+	err := tx.Model(&Folder{}).Where("id = ?", folderId).Pluck("vault_id", &vaultIds).Error
+	if err != nil || len(vaultIds) == 0 {
+		return 0, err
+	}
+
+	return vaultIds[0], nil
+}
@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FileVersion 文件的历史版本, 覆盖写入时保存被替换的内容
+type FileVersion struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	FileId    int64  `gorm:"not null;uniqueIndex:uk_file_version"`
+	Version   int32  `gorm:"not null;uniqueIndex:uk_file_version"`
+	UserId    int32  `gorm:"not null;index"`
+	Hash      string `gorm:"type:varchar(32);not null"`
+	Sha256    string `gorm:"type:varchar(64);not null;default:''"`
+	Type      string `gorm:"type:varchar(50);not null"`
+	Size      int64  `gorm:"not null"`
+	ObjectKey string `gorm:"type:varchar(255)"`
+	Ctime     int64  `gorm:"not null"` // 被替换的时间
+}
+
+// OverwriteFile 用 src 的内容覆盖文件 fileId, 原内容保存为历史版本, 文件 ID 和名称保持不变
+// 历史版本仍占用空间, 因此已用空间增加 src 的大小, 超出套餐版本保留数的旧版本随后被清理
+// 元数据属于文件而不是版本, 原有的保留, src 的元数据按 key 合并
+func (d *UploadDao) OverwriteFile(ctx context.Context, fileId int64, uid int32, src File) (File, error) {
+	var file File
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&File{}).Where("id = ? AND user_id = ? AND status = 0", fileId, uid).First(&file).Error; err != nil {
+			return err
+		}
+
+		now := time.Now().Unix()
+		err := tx.Create(&FileVersion{
+			FileId:    file.Id,
+			Version:   file.Version,
+			UserId:    uid,
+			Hash:      file.Hash,
+			Sha256:    file.Sha256,
+			Type:      file.Type,
+			Size:      file.Size,
+			ObjectKey: file.ObjectName(),
+			Ctime:     now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		oldSize := file.Size
+		file.Hash, file.Sha256, file.Type, file.Size = src.Hash, src.Sha256, src.Type, src.Size
+		file.ObjectKey = src.ObjectName()
+		file.Version++
+		file.Utime = now
+		err = tx.Model(&File{}).Where("id = ?", file.Id).Updates(map[string]any{
+			"hash":       file.Hash,
+			"sha256":     file.Sha256,
+			"type":       file.Type,
+			"size":       file.Size,
+			"object_key": file.ObjectKey,
+			"version":    file.Version,
+			"utime":      file.Utime,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := upsertFileMeta(tx, file.Id, uid, src.Metas); err != nil {
+			return err
+		}
+		if err := adjustFolderStats(tx, file.FolderId, uid, file.Size-oldSize, 0, now); err != nil {
+			return err
+		}
+
+		if err := addCurrentSize(tx, uid, src.Size); err != nil {
+			return err
+		}
+
+		return pruneVersions(tx, file.Id, uid)
+	})
+	if err != nil {
+		return File{}, err
+	}
+
+	return file, nil
+}
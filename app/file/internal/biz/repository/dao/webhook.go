@@ -0,0 +1,285 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrWebhookNotFound webhook 不存在或不属于该用户
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// webhook 的状态
+const (
+	WebhookActive   int8 = 1 // 启用
+	WebhookDisabled int8 = 2 // 连续投递失败过多被自动禁用, 修改后重新启用
+)
+
+// webhook 投递的状态, 与 file.WebhookDeliveryStatus 一致
+const (
+	DeliveryPending   int8 = 1 // 等待投递
+	DeliveryRunning   int8 = 2 // 投递中, 租约到期后视为失败重新投递
+	DeliverySucceeded int8 = 3 // 对方返回 2xx
+	DeliveryRetry     int8 = 4 // 投递失败, 等待重试
+	DeliveryFailed    int8 = 5 // 多次失败或 webhook 被禁用后放弃, 可手动重新投递
+)
+
+// Webhook 用户订阅的文件变更事件推送
+type Webhook struct {
+	Id         int64  `gorm:"primaryKey,autoIncrement"`
+	UserId     int32  `gorm:"not null;index"`
+	Url        string `gorm:"type:varchar(1024);not null"`
+	Secret     string `gorm:"type:varchar(64);not null"` // 签名密钥, 只在创建和轮换时返回
+	EventTypes string `gorm:"type:varchar(512)"`         // 订阅的事件类型, 逗号分隔, 为空表示全部
+	FolderId   int64  `gorm:"not null;default:0"`        // 只推送该文件夹及其子孙下的事件, 0 表示不限
+	Status     int8   `gorm:"not null"`
+	Failures   int32  `gorm:"not null;default:0"` // 连续失败的投递次数, 投递成功时清零
+	Reason     string `gorm:"type:varchar(255)"`  // 被禁用的原因
+	Ctime      int64  `gorm:"not null"`
+	Utime      int64  `gorm:"not null"`
+}
+
+// WebhookDelivery 一次事件推送及其最近一次尝试的结果
+type WebhookDelivery struct {
+	Id           int64  `gorm:"primaryKey,autoIncrement"`
+	WebhookId    int64  `gorm:"not null;index:idx_delivery_webhook"`
+	UserId       int32  `gorm:"not null"`
+	EventType    string `gorm:"type:varchar(64);not null"`
+	Payload      []byte `gorm:"type:blob;not null"` // JSON 编码的事件, 即请求体
+	Status       int8   `gorm:"not null;index:idx_delivery_status_next"`
+	Attempts     int32  `gorm:"not null;default:0"`
+	NextRun      int64  `gorm:"not null;default:0;index:idx_delivery_status_next"` // 下次投递的时间, 投递中时为租约到期的时间
+	ResponseCode int32  `gorm:"not null;default:0"`                                // 最近一次尝试的 HTTP 状态码, 没有收到响应时为 0
+	LastError    string `gorm:"type:varchar(255)"`
+	Ctime        int64  `gorm:"not null;index:idx_delivery_webhook"`
+	Utime        int64  `gorm:"not null"`
+}
+
+// CreateWebhook 创建 webhook
+func (d *UploadDao) CreateWebhook(ctx context.Context, w *Webhook) error {
+	now := time.Now().Unix()
+	w.Status, w.Ctime, w.Utime = WebhookActive, now, now
+
+	return d.db.WithContext(ctx).Create(w).Error
+}
+
+// GetWebhook 获取用户的 webhook, uid 为 0 时不限用户
+func (d *UploadDao) GetWebhook(ctx context.Context, uid int32, id int64) (Webhook, error) {
+	query := d.db.WithContext(ctx).Model(&Webhook{}).Where("id = ?", id)
+	if uid != 0 {
+		query = query.Where("user_id = ?", uid)
+	}
+	var w Webhook
+	err := query.First(&w).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Webhook{}, ErrWebhookNotFound
+	}
+
+	return w, err
+}
+
+// ListWebhooks 获取用户的全部 webhook
+func (d *UploadDao) ListWebhooks(ctx context.Context, uid int32) ([]Webhook, error) {
+	var hooks []Webhook
+	err := d.db.WithContext(ctx).Model(&Webhook{}).Where("user_id = ?", uid).Order("id ASC").Find(&hooks).Error
+
+	return hooks, err
+}
+
+// ListActiveWebhooks 获取用户启用的 webhook
+func (d *UploadDao) ListActiveWebhooks(ctx context.Context, uid int32) ([]Webhook, error) {
+	var hooks []Webhook
+	err := d.db.WithContext(ctx).Model(&Webhook{}).
+		Where("user_id = ? AND status = ?", uid, WebhookActive).
+		Find(&hooks).Error
+
+	return hooks, err
+}
+
+// CountWebhooks 统计用户的 webhook 数
+func (d *UploadDao) CountWebhooks(ctx context.Context, uid int32) (int64, error) {
+	var n int64
+	err := d.db.WithContext(ctx).Model(&Webhook{}).Where("user_id = ?", uid).Count(&n).Error
+
+	return n, err
+}
+
+// UpdateWebhook 修改 webhook 的地址、订阅和范围, 并重新启用; secret 不为空时同时更换签名密钥
+func (d *UploadDao) UpdateWebhook(ctx context.Context, w *Webhook, secret string) error {
+	updates := map[string]any{
+		"url":         w.Url,
+		"event_types": w.EventTypes,
+		"folder_id":   w.FolderId,
+		"status":      WebhookActive,
+		"failures":    0,
+		"reason":      "",
+		"utime":       time.Now().Unix(),
+	}
+	if secret != "" {
+		updates["secret"] = secret
+	}
+	res := d.db.WithContext(ctx).Model(&Webhook{}).Where("id = ? AND user_id = ?", w.Id, w.UserId).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// DeleteWebhook 删除 webhook 及其投递记录
+func (d *UploadDao) DeleteWebhook(ctx context.Context, uid int32, id int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND user_id = ?", id, uid).Delete(&Webhook{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrWebhookNotFound
+		}
+
+		return tx.Where("webhook_id = ?", id).Delete(&WebhookDelivery{}).Error
+	})
+}
+
+// RecordWebhookSuccess 投递成功, 清零连续失败次数
+func (d *UploadDao) RecordWebhookSuccess(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Model(&Webhook{}).
+		Where("id = ? AND failures > 0", id).
+		Update("failures", 0).Error
+}
+
+// RecordWebhookFailure 累加连续失败次数, 达到 limit 时禁用 webhook 并放弃其尚未完成的投递, 返回是否因此禁用
+func (d *UploadDao) RecordWebhookFailure(ctx context.Context, id int64, limit int32, reason string) (bool, error) {
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+
+	var disabled bool
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Webhook{}).Where("id = ? AND status = ?", id, WebhookActive).
+			Update("failures", gorm.Expr("failures + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().Unix()
+		res := tx.Model(&Webhook{}).Where("id = ? AND status = ? AND failures >= ?", id, WebhookActive, limit).
+			Updates(map[string]any{"status": WebhookDisabled, "reason": reason, "utime": now})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		disabled = true
+
+		return tx.Model(&WebhookDelivery{}).
+			Where("webhook_id = ? AND status IN ?", id, []int8{DeliveryPending, DeliveryRetry}).
+			Updates(map[string]any{"status": DeliveryFailed, "last_error": "webhook disabled", "utime": now}).Error
+	})
+
+	return disabled, err
+}
+
+// ListDueWebhookDeliveries 获取到期的投递, 包括等待投递、等待重试和租约到期的
+func (d *UploadDao) ListDueWebhookDeliveries(ctx context.Context, now int64, limit int) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	err := d.db.WithContext(ctx).Model(&WebhookDelivery{}).
+		Where("status IN ? AND next_run <= ?", []int8{DeliveryPending, DeliveryRunning, DeliveryRetry}, now).
+		Order("next_run ASC").Order("id ASC").Limit(limit).
+		Find(&deliveries).Error
+
+	return deliveries, err
+}
+
+// ClaimWebhookDelivery 以 lease 为租约认领投递并增加尝试次数, 已被其他实例认领或重置时返回 false
+func (d *UploadDao) ClaimWebhookDelivery(ctx context.Context, delivery *WebhookDelivery, lease int64) (bool, error) {
+	now := time.Now().Unix()
+	res := d.db.WithContext(ctx).Model(&WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ? AND next_run <= ?", delivery.Id, delivery.Status, delivery.Attempts, now).
+		Updates(map[string]any{
+			"status":   DeliveryRunning,
+			"attempts": delivery.Attempts + 1,
+			"next_run": now + lease,
+			"utime":    now,
+		})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	delivery.Status, delivery.Attempts, delivery.NextRun, delivery.Utime = DeliveryRunning, delivery.Attempts+1, now+lease, now
+
+	return true, nil
+}
+
+// FinishWebhookDelivery 记录认领的投递的结果, status 为 DeliverySucceeded、DeliveryRetry 或 DeliveryFailed
+func (d *UploadDao) FinishWebhookDelivery(ctx context.Context, delivery *WebhookDelivery, status int8, code int32, cause string, nextRun int64) error {
+	if len(cause) > 255 {
+		cause = cause[:255]
+	}
+
+	return d.db.WithContext(ctx).Model(&WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ?", delivery.Id, DeliveryRunning, delivery.Attempts).
+		Updates(map[string]any{
+			"status":        status,
+			"next_run":      nextRun,
+			"response_code": code,
+			"last_error":    cause,
+			"utime":         time.Now().Unix(),
+		}).Error
+}
+
+// GetWebhookDelivery 获取用户的投递记录
+func (d *UploadDao) GetWebhookDelivery(ctx context.Context, uid int32, id int64) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := d.db.WithContext(ctx).Model(&WebhookDelivery{}).Where("id = ? AND user_id = ?", id, uid).First(&delivery).Error
+
+	return delivery, err
+}
+
+// ListWebhookDeliveries 分页获取 webhook 的投递记录, 最近的在前
+func (d *UploadDao) ListWebhookDeliveries(ctx context.Context, webhookId int64, page, size int) ([]WebhookDelivery, int64, error) {
+	query := d.db.WithContext(ctx).Model(&WebhookDelivery{}).Where("webhook_id = ?", webhookId)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []WebhookDelivery
+	err := query.Order("ctime DESC").Order("id DESC").
+		Offset((page - 1) * size).Limit(size).
+		Find(&deliveries).Error
+
+	return deliveries, total, err
+}
+
+// RedeliverWebhook 将已完成的投递重新置为等待投递, 正在投递或等待投递的不变, 返回是否重置
+func (d *UploadDao) RedeliverWebhook(ctx context.Context, id int64) (bool, error) {
+	now := time.Now().Unix()
+	res := d.db.WithContext(ctx).Model(&WebhookDelivery{}).
+		Where("id = ? AND status IN ?", id, []int8{DeliverySucceeded, DeliveryFailed}).
+		Updates(map[string]any{
+			"status":   DeliveryPending,
+			"attempts": 0,
+			"next_run": now,
+			"utime":    now,
+		})
+
+	return res.RowsAffected > 0, res.Error
+}
+
+// PurgeWebhookDeliveries 删除 before 之前已完成的投递记录, 返回删除的数量
+func (d *UploadDao) PurgeWebhookDeliveries(ctx context.Context, before int64) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("status IN ? AND utime < ?", []int8{DeliverySucceeded, DeliveryFailed}, before).
+		Delete(&WebhookDelivery{})
+
+	return res.RowsAffected, res.Error
+}
+
+// FolderAncestors 返回 folderId 及其所有祖先文件夹的 ID, 不包含根目录
+func (d *UploadDao) FolderAncestors(ctx context.Context, folderId int64, uid int32) ([]int64, error) {
+	return ancestorIds(d.db.WithContext(ctx), folderId, uid)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// AddFileEvent 记录待发送的文件变更事件
+func (r *UploadRepo) AddFileEvent(ctx context.Context, e *dao.FileEvent) error {
+	return r.dao.AddFileEvent(ctx, e)
+}
+
+// ListPendingFileEvents 获取尚未发送完的事件
+func (r *UploadRepo) ListPendingFileEvents(ctx context.Context, before int64, limit int) ([]dao.FileEvent, error) {
+	return r.dao.ListPendingFileEvents(ctx, before, limit)
+}
+
+// AddEventWebhookDeliveries 为事件创建等待投递的记录, 每个事件只创建一次
+func (r *UploadRepo) AddEventWebhookDeliveries(ctx context.Context, eventId int64, deliveries []dao.WebhookDelivery) (bool, error) {
+	return r.dao.AddEventWebhookDeliveries(ctx, eventId, deliveries)
+}
+
+// MarkFileEventSent 标记事件已发送到 Kafka
+func (r *UploadRepo) MarkFileEventSent(ctx context.Context, id int64) error {
+	return r.dao.MarkFileEventSent(ctx, id)
+}
+
+// PurgeFileEvents 删除已发送完或过期的事件
+func (r *UploadRepo) PurgeFileEvents(ctx context.Context, before int64) (int64, error) {
+	return r.dao.PurgeFileEvents(ctx, before)
+}
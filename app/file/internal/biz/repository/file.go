@@ -15,6 +15,11 @@ func NewUploadRepo(dao *dao.UploadDao, cache *cache.FileCache) *UploadRepo {
 	return &UploadRepo{dao: dao, cache: cache}
 }
 
+// Naming 名称唯一性的规则
+func (r *UploadRepo) Naming() dao.Naming {
+	return r.dao.Naming()
+}
+
 // CreateFile 创建文件记录
 func (r *UploadRepo) CreateFile(ctx context.Context, file *dao.File) error {
 	return r.dao.CreateFile(ctx, file)
@@ -30,9 +35,9 @@ func (r *UploadRepo) GetFile(ctx context.Context, fid int64, uid int32) (dao.Fil
 	return r.dao.GetFile(ctx, fid, uid)
 }
 
-// QueryByHash 根据文件 hash 查询文件是否存在
-func (r *UploadRepo) QueryByHash(ctx context.Context, hash string) (dao.File, error) {
-	return r.dao.QueryByHash(ctx, hash)
+// QueryBySha256 根据内容的 SHA-256 查询文件是否存在
+func (r *UploadRepo) QueryBySha256(ctx context.Context, sum string) (dao.File, error) {
+	return r.dao.QueryBySha256(ctx, sum)
 }
 
 // QueryCapacity 查询用户空间容量
@@ -50,13 +55,13 @@ func (r *UploadRepo) CreateFolder(ctx context.Context, folder *dao.Folder) error
 	return r.dao.CreateFolder(ctx, folder)
 }
 
-// MoveFile 移动文件
-func (r *UploadRepo) MoveFile(ctx context.Context, fileId, toFolderId int64, uid int32) error {
-	return r.dao.MoveFile(ctx, fileId, toFolderId, uid)
+// MoveFile 移动文件, name 为移动后的名称
+func (r *UploadRepo) MoveFile(ctx context.Context, fileId, toFolderId int64, uid int32, name string) error {
+	return r.dao.MoveFile(ctx, fileId, toFolderId, uid, name)
 }
 
 // MoveFolder 移动文件夹
-func (r *UploadRepo) MoveFolder(ctx context.Context, folderId, toFolderId int64, uid int32, name string) error {
+func (r *UploadRepo) MoveFolder(ctx context.Context, folderId, toFolderId int64, uid int32, name string) (dao.Folder, error) {
 	return r.dao.MoveFolder(ctx, folderId, toFolderId, uid, name)
 }
 
@@ -70,9 +75,9 @@ func (r *UploadRepo) DeleteFolder(ctx context.Context, folderId int64, uid int32
 	return r.dao.DeleteFolder(ctx, folderId, uid)
 }
 
-// Search 查找文件（文件夹)
-func (r *UploadRepo) Search(ctx context.Context, uid int32, query string, page, size int32) ([]dao.File, []dao.Folder, error) {
-	return r.dao.Search(ctx, uid, query, page, size)
+// Search 查找文件（文件夹), root 不是根目录时只搜索其子树
+func (r *UploadRepo) Search(ctx context.Context, uid int32, root dao.Folder, query string, filters []dao.MetaFilter, page, size int32) ([]dao.File, []dao.Folder, error) {
+	return r.dao.Search(ctx, uid, root, query, filters, page, size)
 }
 
 // ListFolder 展示文件夹及文件
@@ -111,19 +116,19 @@ func (r *UploadRepo) GetPartETags(ctx context.Context, uploadId string) (map[int
 }
 
 func (r *UploadRepo) GetFilesByIds(ctx context.Context, files []int64) ([]*dao.File, error) {
-	return nil, nil
+	return r.dao.GetFilesByIds(ctx, files)
 }
 
 func (r *UploadRepo) CreateShareLink(ctx context.Context, share *dao.ShareLink) error {
-	return nil
+	return r.dao.CreateShareLink(ctx, share)
 }
 
 func (r *UploadRepo) CreateShareFile(ctx context.Context, share *dao.ShareFile) error {
-	return nil
+	return r.dao.CreateShareFile(ctx, share)
 }
 
 func (r *UploadRepo) GetShareLink(ctx context.Context, shareId string) (dao.ShareLink, error) {
-	return dao.ShareLink{}, nil
+	return r.dao.GetShareLink(ctx, shareId)
 }
 
 func (r *UploadRepo) FindFileStoreById(ctx context.Context, uid int32) (dao.FileStore, error) {
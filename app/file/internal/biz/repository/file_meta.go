@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// GetFileMeta 获取文件元数据
+func (r *UploadRepo) GetFileMeta(ctx context.Context, fileId int64, uid int32) ([]dao.FileMeta, error) {
+	return r.dao.GetFileMeta(ctx, fileId, uid)
+}
+
+// GetFilesMeta 批量获取文件元数据
+func (r *UploadRepo) GetFilesMeta(ctx context.Context, fileIds []int64) (map[int64][]dao.FileMeta, error) {
+	return r.dao.GetFilesMeta(ctx, fileIds)
+}
+
+// SetFileMeta 设置文件元数据
+func (r *UploadRepo) SetFileMeta(ctx context.Context, fileId int64, uid int32, metas []dao.FileMeta) error {
+	return r.dao.SetFileMeta(ctx, fileId, uid, metas)
+}
+
+// DeleteFileMeta 删除文件元数据
+func (r *UploadRepo) DeleteFileMeta(ctx context.Context, fileId int64, uid int32, keys []string) error {
+	return r.dao.DeleteFileMeta(ctx, fileId, uid, keys)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// CreateFileRequest 创建文件收集链接
+func (r *UploadRepo) CreateFileRequest(ctx context.Context, req *dao.FileRequest) error {
+	return r.dao.CreateFileRequest(ctx, req)
+}
+
+// GetFileRequest 获取未关闭且未过期的文件收集链接
+func (r *UploadRepo) GetFileRequest(ctx context.Context, id string) (dao.FileRequest, error) {
+	return r.dao.GetFileRequest(ctx, id)
+}
+
+// GetUserFileRequest 获取用户创建的文件收集链接
+func (r *UploadRepo) GetUserFileRequest(ctx context.Context, id string, uid int32) (dao.FileRequest, error) {
+	return r.dao.GetUserFileRequest(ctx, id, uid)
+}
+
+// ListFileRequests 分页获取用户创建的文件收集链接
+func (r *UploadRepo) ListFileRequests(ctx context.Context, uid int32, page, size int) ([]dao.FileRequest, int64, error) {
+	return r.dao.ListFileRequests(ctx, uid, page, size)
+}
+
+// CloseFileRequests 关闭用户的文件收集链接
+func (r *UploadRepo) CloseFileRequests(ctx context.Context, uid int32, ids []string) (int64, error) {
+	return r.dao.CloseFileRequests(ctx, uid, ids)
+}
+
+// AcquireFileRequestSlot 为一次上传占用文件收集链接的额度
+func (r *UploadRepo) AcquireFileRequestSlot(ctx context.Context, id string, size int64) error {
+	return r.dao.AcquireFileRequestSlot(ctx, id, size)
+}
+
+// ReleaseFileRequestSlot 归还上传失败时占用的额度
+func (r *UploadRepo) ReleaseFileRequestSlot(ctx context.Context, id string, size int64) error {
+	return r.dao.ReleaseFileRequestSlot(ctx, id, size)
+}
+
+// RecordFileRequestUpload 记录一次上传
+func (r *UploadRepo) RecordFileRequestUpload(ctx context.Context, up *dao.FileRequestUpload) error {
+	return r.dao.RecordFileRequestUpload(ctx, up)
+}
+
+// ListFileRequestUploads 分页获取文件收集链接的上传记录
+func (r *UploadRepo) ListFileRequestUploads(ctx context.Context, id string, page, size int) ([]dao.FileRequestUpload, int64, error) {
+	return r.dao.ListFileRequestUploads(ctx, id, page, size)
+}
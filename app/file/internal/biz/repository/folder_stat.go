@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// GetFolderTree 获取文件夹树
+func (r *UploadRepo) GetFolderTree(ctx context.Context, rootId int64, uid int32, depth int) ([]dao.Folder, bool, error) {
+	return r.dao.GetFolderTree(ctx, rootId, uid, depth)
+}
+
+// GetRootStats 获取根目录的聚合统计
+func (r *UploadRepo) GetRootStats(ctx context.Context, uid int32) (dao.FolderStats, error) {
+	return r.dao.GetRootStats(ctx, uid)
+}
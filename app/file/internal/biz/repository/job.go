@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/cache"
+)
+
+// SaveJob 保存异步任务
+func (r *UploadRepo) SaveJob(ctx context.Context, jobId string, job *cache.Job) error {
+	return r.cache.SaveJob(ctx, jobId, job)
+}
+
+// GetJob 获取异步任务
+func (r *UploadRepo) GetJob(ctx context.Context, jobId string) (*cache.Job, error) {
+	return r.cache.GetJob(ctx, jobId)
+}
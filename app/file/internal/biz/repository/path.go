@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// GetFolderByPath 按规范路径获取文件夹
+func (r *UploadRepo) GetFolderByPath(ctx context.Context, uid int32, path string) (dao.Folder, error) {
+	return r.dao.GetFolderByPath(ctx, uid, path)
+}
+
+// GetFileByName 按名称获取文件夹下的文件
+func (r *UploadRepo) GetFileByName(ctx context.Context, uid int32, folderId int64, name string) (dao.File, error) {
+	return r.dao.GetFileByName(ctx, uid, folderId, name)
+}
+
+// EnsureFolderPath 创建路径上缺失的文件夹
+func (r *UploadRepo) EnsureFolderPath(ctx context.Context, uid int32, names []string) (dao.Folder, int, error) {
+	return r.dao.EnsureFolderPath(ctx, uid, names)
+}
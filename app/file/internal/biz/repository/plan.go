@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// SavePlan 创建或更新存储套餐
+func (r *UploadRepo) SavePlan(ctx context.Context, plan *dao.StoragePlan) error {
+	return r.dao.SavePlan(ctx, plan)
+}
+
+// ListPlans 获取全部存储套餐
+func (r *UploadRepo) ListPlans(ctx context.Context) ([]dao.StoragePlan, error) {
+	return r.dao.ListPlans(ctx)
+}
+
+// GetUserPlan 获取用户的存储套餐
+func (r *UploadRepo) GetUserPlan(ctx context.Context, uid int32) (dao.StoragePlan, error) {
+	return r.dao.GetUserPlan(ctx, uid)
+}
+
+// AssignPlan 为用户分配存储套餐
+func (r *UploadRepo) AssignPlan(ctx context.Context, uid int32, planId int64) (dao.FileStore, error) {
+	return r.dao.AssignPlan(ctx, uid, planId)
+}
+
+// GrantCapacity 为用户增加临时容量
+func (r *UploadRepo) GrantCapacity(ctx context.Context, grant *dao.CapacityGrant) (dao.FileStore, error) {
+	return r.dao.GrantCapacity(ctx, grant)
+}
+
+// ExpireCapacityGrants 收回到期的临时容量
+func (r *UploadRepo) ExpireCapacityGrants(ctx context.Context) (int, error) {
+	return r.dao.ExpireCapacityGrants(ctx)
+}
+
+// ListUsersOverThreshold 分页获取用量达到 percent% 的用户
+func (r *UploadRepo) ListUsersOverThreshold(ctx context.Context, percent int, page, size int) ([]dao.FileStore, int64, error) {
+	return r.dao.ListUsersOverThreshold(ctx, percent, page, size)
+}
+
+// ListPendingQuotaEvents 获取尚未投递的配额事件
+func (r *UploadRepo) ListPendingQuotaEvents(ctx context.Context, limit int) ([]dao.QuotaEvent, error) {
+	return r.dao.ListPendingQuotaEvents(ctx, limit)
+}
+
+// MarkQuotaEventSent 标记配额事件已投递
+func (r *UploadRepo) MarkQuotaEventSent(ctx context.Context, id int64) error {
+	return r.dao.MarkQuotaEventSent(ctx, id)
+}
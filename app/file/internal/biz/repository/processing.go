@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// AddFileCommitEvent 记录文件内容的提交
+func (r *UploadRepo) AddFileCommitEvent(ctx context.Context, fileId int64, version int32) error {
+	return r.dao.AddFileCommitEvent(ctx, fileId, version)
+}
+
+// ListFileCommitEvents 获取尚未消费的提交事件
+func (r *UploadRepo) ListFileCommitEvents(ctx context.Context, limit int) ([]dao.FileCommitEvent, error) {
+	return r.dao.ListFileCommitEvents(ctx, limit)
+}
+
+// EnqueueFileProcesses 消费提交事件, 为文件在各处理器上重置处理状态
+func (r *UploadRepo) EnqueueFileProcesses(ctx context.Context, event dao.FileCommitEvent, processors []string) error {
+	return r.dao.EnqueueFileProcesses(ctx, event, processors)
+}
+
+// ResetFileProcesses 重新处理文件
+func (r *UploadRepo) ResetFileProcesses(ctx context.Context, fileId int64, version int32, processors []string) error {
+	return r.dao.ResetFileProcesses(ctx, fileId, version, processors)
+}
+
+// ListDueFileProcesses 获取到期的处理
+func (r *UploadRepo) ListDueFileProcesses(ctx context.Context, now int64, limit int) ([]dao.FileProcess, error) {
+	return r.dao.ListDueFileProcesses(ctx, now, limit)
+}
+
+// ClaimFileProcess 认领处理
+func (r *UploadRepo) ClaimFileProcess(ctx context.Context, proc *dao.FileProcess, lease int64) (bool, error) {
+	return r.dao.ClaimFileProcess(ctx, proc, lease)
+}
+
+// FinishFileProcess 记录处理的结果
+func (r *UploadRepo) FinishFileProcess(ctx context.Context, proc *dao.FileProcess, status int8, result []byte, cause string, nextRun int64) error {
+	return r.dao.FinishFileProcess(ctx, proc, status, result, cause, nextRun)
+}
+
+// DeleteFileProcess 删除处理状态
+func (r *UploadRepo) DeleteFileProcess(ctx context.Context, id int64) error {
+	return r.dao.DeleteFileProcess(ctx, id)
+}
+
+// ListFileProcesses 获取文件在各处理器上的状态和结果
+func (r *UploadRepo) ListFileProcesses(ctx context.Context, fileId int64) ([]dao.FileProcess, error) {
+	return r.dao.ListFileProcesses(ctx, fileId)
+}
+
+// ListDeadFileProcesses 分页获取死信列表
+func (r *UploadRepo) ListDeadFileProcesses(ctx context.Context, processor string, page, size int) ([]dao.FileProcess, int64, error) {
+	return r.dao.ListDeadFileProcesses(ctx, processor, page, size)
+}
+
+// RetryDeadFileProcesses 将死信重新置为等待处理
+func (r *UploadRepo) RetryDeadFileProcesses(ctx context.Context, processor string) (int64, error) {
+	return r.dao.RetryDeadFileProcesses(ctx, processor)
+}
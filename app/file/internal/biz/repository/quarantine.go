@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// QuarantineObject 隔离内容为 f 当前版本的文件及共用同一对象的文件, 并禁用包含它们的分享
+func (r *UploadRepo) QuarantineObject(ctx context.Context, f *dao.File, scanner, signature string) ([]dao.QuarantinedFile, error) {
+	return r.dao.QuarantineObject(ctx, f, scanner, signature)
+}
+
+// ScanPending 文件内容是否尚未扫描通过
+func (r *UploadRepo) ScanPending(ctx context.Context, f dao.File, processor string) (bool, error) {
+	return r.dao.ScanPending(ctx, f, processor)
+}
+
+// GetQuarantinedFile 获取隔离记录
+func (r *UploadRepo) GetQuarantinedFile(ctx context.Context, fileId int64) (dao.QuarantinedFile, error) {
+	return r.dao.GetQuarantinedFile(ctx, fileId)
+}
+
+// ListQuarantinedFiles 分页获取隔离的文件
+func (r *UploadRepo) ListQuarantinedFiles(ctx context.Context, uid int32, page, size int) ([]dao.QuarantinedFile, int64, error) {
+	return r.dao.ListQuarantinedFiles(ctx, uid, page, size)
+}
+
+// ReleaseQuarantinedFile 解除隔离
+func (r *UploadRepo) ReleaseQuarantinedFile(ctx context.Context, fileId int64, name string) error {
+	return r.dao.ReleaseQuarantinedFile(ctx, fileId, name)
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// ReserveQuota 为上传预留空间
+func (r *UploadRepo) ReserveQuota(ctx context.Context, res *dao.QuotaReservation, ttl time.Duration) error {
+	return r.dao.ReserveQuota(ctx, res, ttl)
+}
+
+// ReleaseQuota 释放预留的空间
+func (r *UploadRepo) ReleaseQuota(ctx context.Context, id string, uid int32) error {
+	return r.dao.ReleaseQuota(ctx, id, uid)
+}
+
+// FindQuotaReservation 获取预留
+func (r *UploadRepo) FindQuotaReservation(ctx context.Context, id string) (dao.QuotaReservation, error) {
+	return r.dao.FindQuotaReservation(ctx, id)
+}
+
+// ListExpiredReservations 获取已过期的预留
+func (r *UploadRepo) ListExpiredReservations(ctx context.Context, before int64, limit int) ([]dao.QuotaReservation, error) {
+	return r.dao.ListExpiredReservations(ctx, before, limit)
+}
+
+// ListQuotaUsers 分页获取拥有存储空间的用户
+func (r *UploadRepo) ListQuotaUsers(ctx context.Context, afterUid int32, limit int) ([]int32, error) {
+	return r.dao.ListQuotaUsers(ctx, afterUid, limit)
+}
+
+// ReconcileQuota 重新计算用户的空间使用量, fix 为 true 时修正记录
+func (r *UploadRepo) ReconcileQuota(ctx context.Context, uid int32, fix bool) (dao.QuotaUsage, error) {
+	return r.dao.ReconcileQuota(ctx, uid, fix)
+}
+
+// DeletePartETags 删除分片上传记录的分片标签
+func (r *UploadRepo) DeletePartETags(ctx context.Context, uploadId string) error {
+	return r.cache.DeletePartETags(ctx, uploadId)
+}
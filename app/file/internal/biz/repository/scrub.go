@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// ListScrubCandidates 获取 before 之后没有校验过的文件
+func (r *UploadRepo) ListScrubCandidates(ctx context.Context, before int64, limit int) ([]dao.File, error) {
+	return r.dao.ListScrubCandidates(ctx, before, limit)
+}
+
+// ListLiveFiles 按 ID 顺序获取 after 之后未删除的文件
+func (r *UploadRepo) ListLiveFiles(ctx context.Context, after int64, limit int) ([]dao.File, error) {
+	return r.dao.ListLiveFiles(ctx, after, limit)
+}
+
+// MarkFileScrubbed 记录文件的校验时间, 并为历史文件补全摘要
+func (r *UploadRepo) MarkFileScrubbed(ctx context.Context, f *dao.File, sum, md5 string, now int64) error {
+	return r.dao.MarkFileScrubbed(ctx, f, sum, md5, now)
+}
+
+// SaveScrubFinding 记录有问题的对象
+func (r *UploadRepo) SaveScrubFinding(ctx context.Context, finding *dao.ScrubFinding) error {
+	return r.dao.SaveScrubFinding(ctx, finding)
+}
+
+// DeleteScrubFinding 删除对象的问题记录
+func (r *UploadRepo) DeleteScrubFinding(ctx context.Context, objectKey string) error {
+	return r.dao.DeleteScrubFinding(ctx, objectKey)
+}
+
+// ListScrubFindings 分页获取有问题的对象
+func (r *UploadRepo) ListScrubFindings(ctx context.Context, uid int32, page, size int) ([]dao.ScrubFinding, int64, error) {
+	return r.dao.ListScrubFindings(ctx, uid, page, size)
+}
+
+// CountScrubFindings 统计有问题的对象数
+func (r *UploadRepo) CountScrubFindings(ctx context.Context) (int64, error) {
+	return r.dao.CountScrubFindings(ctx)
+}
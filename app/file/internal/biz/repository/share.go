@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// GetShareFolder 获取分享内的文件夹
+func (r *UploadRepo) GetShareFolder(ctx context.Context, share dao.ShareLink, folderId int64) (dao.Folder, error) {
+	return r.dao.GetShareFolder(ctx, share, folderId)
+}
+
+// GetShareFile 获取分享内的文件
+func (r *UploadRepo) GetShareFile(ctx context.Context, share dao.ShareLink, fileId int64) (dao.File, error) {
+	return r.dao.GetShareFile(ctx, share, fileId)
+}
+
+// ListShareFiles 获取文件分享中的文件
+func (r *UploadRepo) ListShareFiles(ctx context.Context, share dao.ShareLink) ([]dao.File, error) {
+	return r.dao.ListShareFiles(ctx, share)
+}
+
+// ListShareSubtree 获取分享的文件夹下可访问的子文件夹和文件
+func (r *UploadRepo) ListShareSubtree(ctx context.Context, folderId int64, uid int32) ([]dao.Folder, []dao.File, error) {
+	return r.dao.ListShareSubtree(ctx, folderId, uid)
+}
+
+// ListShares 分页获取用户的分享
+func (r *UploadRepo) ListShares(ctx context.Context, uid int32, status int8, page, size int) ([]dao.ShareLink, int64, error) {
+	return r.dao.ListShares(ctx, uid, status, page, size)
+}
+
+// CountShareFiles 统计文件分享包含的文件数
+func (r *UploadRepo) CountShareFiles(ctx context.Context, shareIds []string) (map[string]int64, error) {
+	return r.dao.CountShareFiles(ctx, shareIds)
+}
+
+// GetUserShare 获取用户的分享
+func (r *UploadRepo) GetUserShare(ctx context.Context, uid int32, shareId string) (dao.ShareLink, error) {
+	return r.dao.GetUserShare(ctx, uid, shareId)
+}
+
+// RevokeShares 取消用户的分享
+func (r *UploadRepo) RevokeShares(ctx context.Context, uid int32, shareIds []string) (int64, error) {
+	return r.dao.RevokeShares(ctx, uid, shareIds)
+}
+
+// UpdateShare 修改用户的分享
+func (r *UploadRepo) UpdateShare(ctx context.Context, uid int32, shareId string, upd dao.ShareUpdate) (dao.ShareLink, error) {
+	return r.dao.UpdateShare(ctx, uid, shareId, upd)
+}
+
+// RecordShareAccess 记录分享访问
+func (r *UploadRepo) RecordShareAccess(ctx context.Context, shareId, action, ip string, uid int32) error {
+	return r.dao.RecordShareAccess(ctx, shareId, action, ip, uid)
+}
+
+// ListShareAccess 分页获取分享的访问记录
+func (r *UploadRepo) ListShareAccess(ctx context.Context, shareId string, page, size int) ([]dao.ShareAccess, int64, error) {
+	return r.dao.ListShareAccess(ctx, shareId, page, size)
+}
+
+// ExpireShares 将到期的分享标记为已过期
+func (r *UploadRepo) ExpireShares(ctx context.Context) (int64, error) {
+	return r.dao.ExpireShares(ctx)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// CreateSpace 为团队创建存储记录和根文件夹
+func (r *UploadRepo) CreateSpace(ctx context.Context, teamId int32, name string) (dao.Space, error) {
+	return r.dao.CreateSpace(ctx, teamId, name)
+}
+
+// GetSpace 获取团队空间
+func (r *UploadRepo) GetSpace(ctx context.Context, teamId int32) (dao.Space, error) {
+	return r.dao.GetSpace(ctx, teamId)
+}
+
+// SetSpaceMember 同步团队成员的角色, role 为 0 时移除成员
+func (r *UploadRepo) SetSpaceMember(ctx context.Context, teamId, uid int32, role int8) error {
+	return r.dao.SetSpaceMember(ctx, teamId, uid, role)
+}
+
+// CountSpaceMembers 统计团队中角色为 role 的成员数
+func (r *UploadRepo) CountSpaceMembers(ctx context.Context, teamId int32, role int8) (int64, error) {
+	return r.dao.CountSpaceMembers(ctx, teamId, role)
+}
+
+// GetSpaceMemberRole 获取用户在团队中的角色
+func (r *UploadRepo) GetSpaceMemberRole(ctx context.Context, teamId, uid int32) (int8, error) {
+	return r.dao.GetSpaceMemberRole(ctx, teamId, uid)
+}
+
+// RecordSpaceActivity 记录团队空间的动态
+func (r *UploadRepo) RecordSpaceActivity(ctx context.Context, a *dao.SpaceActivity) error {
+	return r.dao.RecordSpaceActivity(ctx, a)
+}
+
+// ListSpaceActivity 分页获取团队空间的动态
+func (r *UploadRepo) ListSpaceActivity(ctx context.Context, teamId int32, page, size int) ([]dao.SpaceActivity, int64, error) {
+	return r.dao.ListSpaceActivity(ctx, teamId, page, size)
+}
+
+// TransferFile 将文件转移到另一个所有者的文件夹下
+func (r *UploadRepo) TransferFile(ctx context.Context, fileId int64, from int32, toFolderId int64, to int32, name string) error {
+	return r.dao.TransferFile(ctx, fileId, from, toFolderId, to, name)
+}
+
+// TransferFolder 将文件夹子树转移到另一个所有者的文件夹下
+func (r *UploadRepo) TransferFolder(ctx context.Context, folderId int64, from int32, toFolderId int64, to int32, name string) (dao.Folder, error) {
+	return r.dao.TransferFolder(ctx, folderId, from, toFolderId, to, name)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// CreateUploadIntent 以 pending 状态登记上传
+func (r *UploadRepo) CreateUploadIntent(ctx context.Context, intent *dao.UploadIntent) error {
+	return r.dao.CreateUploadIntent(ctx, intent)
+}
+
+// SetUploadState 转换上传的状态
+func (r *UploadRepo) SetUploadState(ctx context.Context, id int64, from, to int8) (bool, error) {
+	return r.dao.SetUploadState(ctx, id, from, to)
+}
+
+// AbortUploadIntent 将未提交的上传标记为已放弃
+func (r *UploadRepo) AbortUploadIntent(ctx context.Context, id int64, held bool) (bool, error) {
+	return r.dao.AbortUploadIntent(ctx, id, held)
+}
+
+// ClaimUploadIntent 修复任务认领上传
+func (r *UploadRepo) ClaimUploadIntent(ctx context.Context, intent *dao.UploadIntent) (bool, error) {
+	return r.dao.ClaimUploadIntent(ctx, intent)
+}
+
+// RecordUploadFailure 记录一次修复失败
+func (r *UploadRepo) RecordUploadFailure(ctx context.Context, id int64, cause string) error {
+	return r.dao.RecordUploadFailure(ctx, id, cause)
+}
+
+// ListStaleUploads 获取长时间没有进展的上传
+func (r *UploadRepo) ListStaleUploads(ctx context.Context, before int64, limit int) ([]dao.UploadIntent, error) {
+	return r.dao.ListStaleUploads(ctx, before, limit)
+}
+
+// DeleteUploadIntent 删除上传日志
+func (r *UploadRepo) DeleteUploadIntent(ctx context.Context, id int64) error {
+	return r.dao.DeleteUploadIntent(ctx, id)
+}
+
+// PurgeCommittedUploads 删除已提交的旧上传日志
+func (r *UploadRepo) PurgeCommittedUploads(ctx context.Context, before int64) (int64, error) {
+	return r.dao.PurgeCommittedUploads(ctx, before)
+}
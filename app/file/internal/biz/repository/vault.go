@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// CreateVault 创建保险库的根文件夹并保存创建者的密钥信封
+func (r *UploadRepo) CreateVault(ctx context.Context, folder *dao.Folder, creator int32, envelope []byte) error {
+	return r.dao.CreateVault(ctx, folder, creator, envelope)
+}
+
+// SaveUserPublicKey 保存用户的公钥
+func (r *UploadRepo) SaveUserPublicKey(ctx context.Context, key *dao.UserPublicKey) error {
+	return r.dao.SaveUserPublicKey(ctx, key)
+}
+
+// GetUserPublicKeys 批量获取用户的公钥
+func (r *UploadRepo) GetUserPublicKeys(ctx context.Context, uids []int32) ([]dao.UserPublicKey, error) {
+	return r.dao.GetUserPublicKeys(ctx, uids)
+}
+
+// SaveVaultKeyEnvelope 保存用户的保险库密钥信封
+func (r *UploadRepo) SaveVaultKeyEnvelope(ctx context.Context, env *dao.VaultKeyEnvelope) error {
+	return r.dao.SaveVaultKeyEnvelope(ctx, env)
+}
+
+// HasVaultUnder 文件夹下是否有保险库
+func (r *UploadRepo) HasVaultUnder(ctx context.Context, folder dao.Folder) (bool, error) {
+	return r.dao.HasVaultUnder(ctx, folder)
+}
+
+// GetVaultKeyEnvelope 获取用户的保险库密钥信封
+func (r *UploadRepo) GetVaultKeyEnvelope(ctx context.Context, vaultId int64, uid int32) (dao.VaultKeyEnvelope, error) {
+	return r.dao.GetVaultKeyEnvelope(ctx, vaultId, uid)
+}
+
+// DeleteVaultKeyEnvelope 删除用户的保险库密钥信封
+func (r *UploadRepo) DeleteVaultKeyEnvelope(ctx context.Context, vaultId int64, uid int32) (int64, error) {
+	return r.dao.DeleteVaultKeyEnvelope(ctx, vaultId, uid)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// OverwriteFile 用 src 的内容覆盖文件, 原内容保存为历史版本
+func (r *UploadRepo) OverwriteFile(ctx context.Context, fileId int64, uid int32, src dao.File) (dao.File, error) {
+	return r.dao.OverwriteFile(ctx, fileId, uid, src)
+}
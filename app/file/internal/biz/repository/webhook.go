@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// CreateWebhook 创建 webhook
+func (r *UploadRepo) CreateWebhook(ctx context.Context, w *dao.Webhook) error {
+	return r.dao.CreateWebhook(ctx, w)
+}
+
+// GetWebhook 获取用户的 webhook
+func (r *UploadRepo) GetWebhook(ctx context.Context, uid int32, id int64) (dao.Webhook, error) {
+	return r.dao.GetWebhook(ctx, uid, id)
+}
+
+// ListWebhooks 获取用户的全部 webhook
+func (r *UploadRepo) ListWebhooks(ctx context.Context, uid int32) ([]dao.Webhook, error) {
+	return r.dao.ListWebhooks(ctx, uid)
+}
+
+// ListActiveWebhooks 获取用户启用的 webhook
+func (r *UploadRepo) ListActiveWebhooks(ctx context.Context, uid int32) ([]dao.Webhook, error) {
+	return r.dao.ListActiveWebhooks(ctx, uid)
+}
+
+// CountWebhooks 统计用户的 webhook 数
+func (r *UploadRepo) CountWebhooks(ctx context.Context, uid int32) (int64, error) {
+	return r.dao.CountWebhooks(ctx, uid)
+}
+
+// UpdateWebhook 修改并重新启用 webhook
+func (r *UploadRepo) UpdateWebhook(ctx context.Context, w *dao.Webhook, secret string) error {
+	return r.dao.UpdateWebhook(ctx, w, secret)
+}
+
+// DeleteWebhook 删除 webhook 及其投递记录
+func (r *UploadRepo) DeleteWebhook(ctx context.Context, uid int32, id int64) error {
+	return r.dao.DeleteWebhook(ctx, uid, id)
+}
+
+// RecordWebhookSuccess 清零连续失败次数
+func (r *UploadRepo) RecordWebhookSuccess(ctx context.Context, id int64) error {
+	return r.dao.RecordWebhookSuccess(ctx, id)
+}
+
+// RecordWebhookFailure 累加连续失败次数, 过多时禁用 webhook
+func (r *UploadRepo) RecordWebhookFailure(ctx context.Context, id int64, limit int32, reason string) (bool, error) {
+	return r.dao.RecordWebhookFailure(ctx, id, limit, reason)
+}
+
+// ListDueWebhookDeliveries 获取到期的投递
+func (r *UploadRepo) ListDueWebhookDeliveries(ctx context.Context, now int64, limit int) ([]dao.WebhookDelivery, error) {
+	return r.dao.ListDueWebhookDeliveries(ctx, now, limit)
+}
+
+// ClaimWebhookDelivery 认领投递
+func (r *UploadRepo) ClaimWebhookDelivery(ctx context.Context, delivery *dao.WebhookDelivery, lease int64) (bool, error) {
+	return r.dao.ClaimWebhookDelivery(ctx, delivery, lease)
+}
+
+// FinishWebhookDelivery 记录投递的结果
+func (r *UploadRepo) FinishWebhookDelivery(ctx context.Context, delivery *dao.WebhookDelivery, status int8, code int32, cause string, nextRun int64) error {
+	return r.dao.FinishWebhookDelivery(ctx, delivery, status, code, cause, nextRun)
+}
+
+// GetWebhookDelivery 获取用户的投递记录
+func (r *UploadRepo) GetWebhookDelivery(ctx context.Context, uid int32, id int64) (dao.WebhookDelivery, error) {
+	return r.dao.GetWebhookDelivery(ctx, uid, id)
+}
+
+// ListWebhookDeliveries 分页获取 webhook 的投递记录
+func (r *UploadRepo) ListWebhookDeliveries(ctx context.Context, webhookId int64, page, size int) ([]dao.WebhookDelivery, int64, error) {
+	return r.dao.ListWebhookDeliveries(ctx, webhookId, page, size)
+}
+
+// RedeliverWebhook 重新投递
+func (r *UploadRepo) RedeliverWebhook(ctx context.Context, id int64) (bool, error) {
+	return r.dao.RedeliverWebhook(ctx, id)
+}
+
+// PurgeWebhookDeliveries 删除过期的投递记录
+func (r *UploadRepo) PurgeWebhookDeliveries(ctx context.Context, before int64) (int64, error) {
+	return r.dao.PurgeWebhookDeliveries(ctx, before)
+}
+
+// FolderAncestors 返回文件夹及其所有祖先文件夹的 ID
+func (r *UploadRepo) FolderAncestors(ctx context.Context, folderId int64, uid int32) ([]int64, error) {
+	return r.dao.FolderAncestors(ctx, folderId, uid)
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// ErrCrossOwner 不能在不同用户的个人网盘之间移动条目
+var ErrCrossOwner = errors.New("cannot move items between drives of different owners")
+
+// ShareWithUser 将文件夹或文件共享给其他用户, 已共享时修改其角色
+func (s *FileServer) ShareWithUser(ctx context.Context, req *file.ShareWithUserRequest) (*file.ShareWithUserResponse, error) {
+	role := int8(req.GetRole())
+	if role < dao.RoleViewer || role > dao.RoleEditor {
+		return nil, errors.New("invalid role")
+	}
+	if req.GetTargetUserId() == 0 || req.GetTargetUserId() == req.GetUserId() {
+		return nil, errors.New("invalid collaborator")
+	}
+
+	typ, owner, err := s.aclResource(ctx, req.GetUserId(), req.GetIsFolder(), req.GetResourceId(), dao.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	acl := &dao.Acl{
+		ResourceType: typ,
+		ResourceId:   req.GetResourceId(),
+		UserId:       req.GetTargetUserId(),
+		OwnerId:      owner,
+		Role:         role,
+	}
+	if err := s.repo.GrantAccess(ctx, acl); err != nil {
+		return nil, err
+	}
+
+	return &file.ShareWithUserResponse{Collaborator: toPbCollaborator(*acl)}, nil
+}
+
+// RevokeUserShare 收回共享, 所有者可收回任何协作者, 协作者可以退出共享
+func (s *FileServer) RevokeUserShare(ctx context.Context, req *file.RevokeUserShareRequest) (*file.RevokeUserShareResponse, error) {
+	need := dao.RoleOwner
+	if req.GetTargetUserId() == req.GetUserId() {
+		need = dao.RoleViewer
+	}
+	typ, owner, err := s.aclResource(ctx, req.GetUserId(), req.GetIsFolder(), req.GetResourceId(), need)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.RevokeAccess(ctx, owner, typ, req.GetResourceId(), req.GetTargetUserId()); err != nil {
+		return nil, err
+	}
+
+	return &file.RevokeUserShareResponse{}, nil
+}
+
+// ListCollaborators 获取条目的所有者和直接授权的协作者
+func (s *FileServer) ListCollaborators(ctx context.Context, req *file.ListCollaboratorsRequest) (*file.ListCollaboratorsResponse, error) {
+	var (
+		typ   int8
+		owner int32
+		role  int8
+	)
+	if req.GetIsFolder() {
+		folder, err := s.repo.FindFolder(ctx, req.GetResourceId())
+		if err != nil {
+			return nil, err
+		}
+		if role, err = s.repo.FolderRole(ctx, req.GetUserId(), folder); err != nil {
+			return nil, err
+		}
+		typ, owner = dao.ResourceFolder, folder.UserId
+	} else {
+		f, err := s.repo.FindFile(ctx, req.GetResourceId())
+		if err != nil {
+			return nil, err
+		}
+		if role, err = s.repo.FileRole(ctx, req.GetUserId(), f); err != nil {
+			return nil, err
+		}
+		typ, owner = dao.ResourceFile, f.UserId
+	}
+	if role < dao.RoleViewer {
+		return nil, dao.ErrPermissionDenied
+	}
+
+	acls, err := s.repo.ListAccess(ctx, typ, req.GetResourceId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListCollaboratorsResponse{
+		OwnerId:       owner,
+		MyRole:        file.AclRole(role),
+		Collaborators: make([]*file.Collaborator, 0, len(acls)),
+	}
+	for _, a := range acls {
+		resp.Collaborators = append(resp.Collaborators, toPbCollaborator(a))
+	}
+
+	return resp, nil
+}
+
+// ListSharedWithMe 分页获取直接共享给用户的文件夹和文件
+func (s *FileServer) ListSharedWithMe(ctx context.Context, req *file.ListSharedWithMeRequest) (*file.ListSharedWithMeResponse, error) {
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	acls, total, err := s.repo.ListSharedWithMe(ctx, req.GetUserId(), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var folderIds, fileIds []int64
+	for _, a := range acls {
+		if a.ResourceType == dao.ResourceFolder {
+			folderIds = append(folderIds, a.ResourceId)
+		} else {
+			fileIds = append(fileIds, a.ResourceId)
+		}
+	}
+	folders, err := s.repo.FindFolders(ctx, folderIds)
+	if err != nil {
+		return nil, err
+	}
+	files, err := s.repo.FindFiles(ctx, fileIds)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListSharedWithMeResponse{Total: total, Items: make([]*file.SharedItem, 0, len(acls))}
+	for _, a := range acls {
+		item := &file.SharedItem{OwnerId: a.OwnerId, Role: file.AclRole(a.Role), Ctime: a.Ctime}
+		if a.ResourceType == dao.ResourceFolder {
+			folder, ok := folders[a.ResourceId]
+			if !ok {
+				continue
+			}
+			item.Folder = toPbFolder(folder)
+		} else {
+			f, ok := files[a.ResourceId]
+			if !ok {
+				continue
+			}
+			item.File = toPbFile(f)
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	return resp, nil
+}
+
+// authorizeFile 获取未删除的文件并检查 uid 对其至少具有 role 角色
+// 文件可能属于其他用户, 后续读写应以 f.UserId 进行, 修改占用所有者的空间
+func (s *FileServer) authorizeFile(ctx context.Context, uid int32, fileId int64, role int8) (dao.File, error) {
+	f, err := s.repo.FindFile(ctx, fileId)
+	if err != nil {
+		return dao.File{}, err
+	}
+	got, err := s.repo.FileRole(ctx, uid, f)
+	if err != nil {
+		return dao.File{}, err
+	}
+	if got < role {
+		return dao.File{}, dao.ErrPermissionDenied
+	}
+
+	return f, nil
+}
+
+// authorizeFolder 获取未删除的文件夹并检查 uid 对其至少具有 role 角色
+func (s *FileServer) authorizeFolder(ctx context.Context, uid int32, folderId int64, role int8) (dao.Folder, error) {
+	folder, err := s.repo.FindFolder(ctx, folderId)
+	if err != nil {
+		return dao.Folder{}, err
+	}
+	got, err := s.repo.FolderRole(ctx, uid, folder)
+	if err != nil {
+		return dao.Folder{}, err
+	}
+	if got < role {
+		return dao.Folder{}, dao.ErrPermissionDenied
+	}
+
+	return folder, nil
+}
+
+// folderOwner 检查 uid 对文件夹至少具有 role 角色, 返回文件夹的所有者, 0 表示 uid 自己的根目录
+func (s *FileServer) folderOwner(ctx context.Context, uid int32, folderId int64, role int8) (int32, error) {
+	if folderId == 0 {
+		return uid, nil
+	}
+
+	folder, err := s.authorizeFolder(ctx, uid, folderId, role)
+	if err != nil {
+		return 0, err
+	}
+
+	return folder.UserId, nil
+}
+
+// checkMoveTarget 检查 uid 可以写入目标文件夹, 返回目标文件夹的所有者
+// 所有者不同时条目将被转移到目标网盘, 只允许涉及团队空间的转移; vault 为条目移动后应在的保险库
+func (s *FileServer) checkMoveTarget(ctx context.Context, uid int32, to int64, owner int32, vault int64) (int32, error) {
+	toOwner, err := s.folderOwner(ctx, uid, to, dao.RoleEditor)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.checkVaultTarget(ctx, to, vault); err != nil {
+		return 0, err
+	}
+	if toOwner != owner {
+		if err := s.checkTransfer(ctx, uid, owner, toOwner); err != nil {
+			return 0, err
+		}
+	}
+
+	return toOwner, nil
+}
+
+// aclResource 检查 uid 对要共享的条目至少具有 role 角色, 返回条目类型和所有者
+func (s *FileServer) aclResource(ctx context.Context, uid int32, isFolder bool, id int64, role int8) (int8, int32, error) {
+	if isFolder {
+		folder, err := s.authorizeFolder(ctx, uid, id, role)
+		return dao.ResourceFolder, folder.UserId, err
+	}
+
+	f, err := s.authorizeFile(ctx, uid, id, role)
+	return dao.ResourceFile, f.UserId, err
+}
+
+func toPbCollaborator(a dao.Acl) *file.Collaborator {
+	return &file.Collaborator{
+		UserId: a.UserId,
+		Role:   file.AclRole(a.Role),
+		Ctime:  a.Ctime,
+	}
+}
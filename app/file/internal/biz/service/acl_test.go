@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+const (
+	testViewer   int32 = 2
+	testEditor   int32 = 3
+	testStranger int32 = 4
+)
+
+// aclTree 所有者 testUser 的 /Projects/docs/plan.txt 和 /Private/secret.txt, /Projects 共享给查看者和编辑者
+type aclTree struct {
+	projects, docs, private int64
+}
+
+func newACLTree(t *testing.T, s *FileServer) aclTree {
+	t.Helper()
+	ctx := context.Background()
+	mkdir := func(name string, parent int64) int64 {
+		resp, err := s.CreateFolder(ctx, &file.CreateFolderRequest{Name: name, ParentId: parent, UserId: testUser})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.GetFolder().GetId()
+	}
+	upload := func(name string, folder int64) {
+		req := uploadRequest(name, []byte(name))
+		req.Metadata.FolderId = folder
+		if _, err := s.Upload(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree := aclTree{projects: mkdir("Projects", 0), private: mkdir("Private", 0)}
+	tree.docs = mkdir("docs", tree.projects)
+	upload("plan.txt", tree.docs)
+	upload("secret.txt", tree.private)
+
+	for uid, role := range map[int32]file.AclRole{testViewer: file.AclRole(dao.RoleViewer), testEditor: file.AclRole(dao.RoleEditor)} {
+		_, err := s.ShareWithUser(ctx, &file.ShareWithUserRequest{
+			UserId: testUser, TargetUserId: uid, IsFolder: true, ResourceId: tree.projects, Role: role,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return tree
+}
+
+func TestCollaboratorBrowsesSharedFolder(t *testing.T) {
+	s, _, _ := newUploadTestServer(t)
+	tree := newACLTree(t, s)
+	ctx := context.Background()
+
+	tree2, err := s.GetFolderTree(ctx, &file.GetFolderTreeRequest{UserId: testViewer, RootId: tree.projects})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if children := tree2.GetRoot().GetChildren(); len(children) != 1 || children[0].GetFolder().GetId() != tree.docs {
+		t.Fatalf("tree children %v, want docs", children)
+	}
+
+	resolved, err := s.ResolvePath(ctx, &file.ResolvePathRequest{UserId: testViewer, RootId: tree.projects, Path: "docs/plan.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.GetFile().GetName() != "plan.txt" || resolved.GetFile().GetUserId() != testUser {
+		t.Fatalf("resolved %v, want the owner's plan.txt", resolved)
+	}
+
+	listed, err := s.ListPath(ctx, &file.ListPathRequest{UserId: testViewer, RootId: tree.projects, Path: "/docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed.GetFiles()) != 1 || listed.GetFiles()[0].GetName() != "plan.txt" {
+		t.Fatalf("listed %v, want plan.txt", listed.GetFiles())
+	}
+
+	// 搜索限于共享的子树
+	found, err := s.Search(ctx, &file.SearchRequest{UserId: testViewer, FolderId: tree.projects, Query: ".txt", Page: 1, Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found.GetFiles()) != 1 || found.GetFiles()[0].GetName() != "plan.txt" {
+		t.Fatalf("search found %v, want only plan.txt", found.GetFiles())
+	}
+}
+
+func TestPathAndTreeRequireAccess(t *testing.T) {
+	s, _, _ := newUploadTestServer(t)
+	tree := newACLTree(t, s)
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"tree of an unshared folder": func() error {
+			_, err := s.GetFolderTree(ctx, &file.GetFolderTreeRequest{UserId: testViewer, RootId: tree.private})
+			return err
+		},
+		"resolve by a stranger": func() error {
+			_, err := s.ResolvePath(ctx, &file.ResolvePathRequest{UserId: testStranger, RootId: tree.projects, Path: "docs"})
+			return err
+		},
+		"list an unshared folder": func() error {
+			_, err := s.ListPath(ctx, &file.ListPathRequest{UserId: testViewer, RootId: tree.private})
+			return err
+		},
+		"search by a stranger": func() error {
+			_, err := s.Search(ctx, &file.SearchRequest{UserId: testStranger, FolderId: tree.projects, Page: 1, Size: 10})
+			return err
+		},
+		"delete by a viewer": func() error {
+			_, err := s.DeletePath(ctx, &file.DeletePathRequest{UserId: testViewer, RootId: tree.projects, Path: "docs/plan.txt"})
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, dao.ErrPermissionDenied) {
+			t.Errorf("%s: got %v, want ErrPermissionDenied", name, err)
+		}
+	}
+}
+
+func TestEditorDeletesByPath(t *testing.T) {
+	s, _, _ := newUploadTestServer(t)
+	tree := newACLTree(t, s)
+	ctx := context.Background()
+
+	if _, err := s.DeletePath(ctx, &file.DeletePathRequest{UserId: testEditor, RootId: tree.projects}); err == nil {
+		t.Fatal("deleted the shared root itself")
+	}
+	if _, err := s.DeletePath(ctx, &file.DeletePathRequest{UserId: testEditor, RootId: tree.projects, Path: "docs/plan.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := s.ResolvePath(ctx, &file.ResolvePathRequest{UserId: testUser, Path: "/Projects/docs/plan.txt"})
+	if !errors.Is(err, ErrPathNotFound) {
+		t.Fatalf("got %v, want ErrPathNotFound after the delete", err)
+	}
+}
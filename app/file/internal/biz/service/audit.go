@@ -0,0 +1,621 @@
+package service
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// 网关转发的客户端信息, 与网关的 mws.ClientInfo 一致
+const (
+	auditClientIpKey  = "x-client-ip"
+	auditUserAgentKey = "x-user-agent"
+	auditDeviceIdKey  = "x-device-id"
+)
+
+const (
+	// auditTimeout 写入一条审计记录的超时, 请求结束后仍会写入
+	auditTimeout = 5 * time.Second
+	// maxAuditTargets 一条审计记录最多保存的目标数, 批量操作超出的部分不记录
+	maxAuditTargets = 100
+	// auditExportBatch 导出时每次查询的记录数, auditChunkSize 为每块返回的大小
+	auditExportBatch = 500
+	auditChunkSize   = 64 << 10
+)
+
+// auditMethod 需要审计的方法
+// mutation 为 true 时还记录操作前目标的元数据; admin 为 true 时请求中的 user_id 是目标而不是操作者
+type auditMethod struct {
+	action   string
+	mutation bool
+	admin    bool
+}
+
+// auditMethods 按方法名记录全部修改操作以及下载和分享访问, 其他只读方法不记录
+var auditMethods = map[string]auditMethod{
+	"Upload":                 {action: "file.upload", mutation: true},
+	"UploadChunkStream":      {action: "file.upload", mutation: true},
+	"UploadChunk":            {action: "file.upload", mutation: true},
+	"CreateFileStore":        {action: "store.create", mutation: true},
+	"CreateFolder":           {action: "folder.create", mutation: true},
+	"MoveFolder":             {action: "folder.move", mutation: true},
+	"MoveFile":               {action: "file.move", mutation: true},
+	"DeleteFile":             {action: "file.delete", mutation: true},
+	"DeleteFolder":           {action: "folder.delete", mutation: true},
+	"UpdateFile":             {action: "file.update", mutation: true},
+	"SetFileMeta":            {action: "file.set_meta", mutation: true},
+	"DeleteFileMeta":         {action: "file.delete_meta", mutation: true},
+	"CopyFile":               {action: "file.copy", mutation: true},
+	"CopyFolder":             {action: "folder.copy", mutation: true},
+	"BatchMove":              {action: "file.batch_move", mutation: true},
+	"BatchCopy":              {action: "file.batch_copy", mutation: true},
+	"BatchDelete":            {action: "file.batch_delete", mutation: true},
+	"BatchRestore":           {action: "file.batch_restore", mutation: true},
+	"BatchRename":            {action: "file.batch_rename", mutation: true},
+	"DeletePath":             {action: "path.delete", mutation: true},
+	"EnsureFolderPath":       {action: "path.mkdir", mutation: true},
+	"AbortUpload":            {action: "upload.abort", mutation: true},
+	"CreateShareLink":        {action: "share.create", mutation: true},
+	"SaveToMyDrive":          {action: "share.save", mutation: true},
+	"RevokeShares":           {action: "share.revoke", mutation: true},
+	"UpdateShare":            {action: "share.update", mutation: true},
+	"ShareWithUser":          {action: "acl.grant", mutation: true},
+	"RevokeUserShare":        {action: "acl.revoke", mutation: true},
+	"CreateFileRequest":      {action: "file_request.create", mutation: true},
+	"CloseFileRequests":      {action: "file_request.close", mutation: true},
+	"SubmitFileRequest":      {action: "file_request.upload", mutation: true},
+	"CreateVault":            {action: "vault.create", mutation: true},
+	"SetPublicKey":           {action: "vault.set_public_key", mutation: true},
+	"PutVaultKeyEnvelope":    {action: "vault.put_envelope", mutation: true},
+	"RevokeVaultKeyEnvelope": {action: "vault.revoke_envelope", mutation: true},
+	"CreateWebhook":          {action: "webhook.create", mutation: true},
+	"UpdateWebhook":          {action: "webhook.update", mutation: true},
+	"DeleteWebhook":          {action: "webhook.delete", mutation: true},
+	"RedeliverWebhook":       {action: "webhook.redeliver", mutation: true},
+
+	"Download":          {action: "file.download"},
+	"DownloadStream":    {action: "file.download"},
+	"DownloadTask":      {action: "file.download"},
+	"ResumeDownload":    {action: "file.download"},
+	"Preview":           {action: "file.preview"},
+	"ListShareFolder":   {action: "share.view"},
+	"ListShareFiles":    {action: "share.view"},
+	"GetShareFile":      {action: "share.view"},
+	"PreviewShareFile":  {action: "share.preview"},
+	"DownloadShareFile": {action: "share.download"},
+	"ExportAuditLogs":   {action: "audit.export"},
+
+	"ReconcileQuota":         {action: "quota.reconcile", mutation: true, admin: true},
+	"SavePlan":               {action: "plan.save", mutation: true, admin: true},
+	"AssignPlan":             {action: "plan.assign", mutation: true, admin: true},
+	"GrantCapacity":          {action: "plan.grant", mutation: true, admin: true},
+	"CreateTeamSpace":        {action: "space.create", mutation: true, admin: true},
+	"SetSpaceMember":         {action: "space.set_member", mutation: true, admin: true},
+	"ScrubStorage":           {action: "storage.scrub", mutation: true, admin: true},
+	"ReprocessFile":          {action: "file.reprocess", mutation: true, admin: true},
+	"RetryDeadLetters":       {action: "processing.retry", mutation: true, admin: true},
+	"ReleaseQuarantinedFile": {action: "file.release", mutation: true, admin: true},
+	"DumpAuditLogs":          {action: "audit.export", admin: true},
+}
+
+// AuditUnaryInterceptor 记录需要审计的方法的调用, 写入失败只记录日志, 不影响请求
+func (s *FileServer) AuditUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		m, ok := auditMethods[info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		r := newAuditRecord(ctx, info.FullMethod, m, req)
+		if m.mutation {
+			r.log.Before, _ = s.auditSnapshot(ctx, r.log.Targets)
+		}
+		resp, err := handler(ctx, req)
+		s.finishAudit(ctx, r, resp, err)
+
+		return resp, err
+	}
+}
+
+// AuditStreamInterceptor 流式方法在结束后记录, 以收到的第一条消息为请求, 不记录操作前的元数据
+func (s *FileServer) AuditStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		m, ok := auditMethods[info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]]
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		as := &auditStream{ServerStream: ss, keepResp: m.mutation}
+		err := handler(srv, as)
+		s.finishAudit(ss.Context(), newAuditRecord(ss.Context(), info.FullMethod, m, as.req), as.resp, err)
+
+		return err
+	}
+}
+
+type auditStream struct {
+	grpc.ServerStream
+	req, resp any
+	keepResp  bool
+}
+
+func (a *auditStream) RecvMsg(m any) error {
+	err := a.ServerStream.RecvMsg(m)
+	if err == nil && a.req == nil {
+		a.req = m
+	}
+	return err
+}
+
+func (a *auditStream) SendMsg(m any) error {
+	// 下载的响应是文件内容, 不保留
+	if a.keepResp {
+		a.resp = m
+	}
+	return a.ServerStream.SendMsg(m)
+}
+
+type auditRecord struct {
+	log    dao.AuditLog
+	method auditMethod
+	seen   map[dao.AuditTarget]bool
+}
+
+func newAuditRecord(ctx context.Context, fullMethod string, m auditMethod, req any) *auditRecord {
+	r := &auditRecord{
+		log:    dao.AuditLog{Action: m.action, Method: fullMethod},
+		method: m,
+		seen:   make(map[dao.AuditTarget]bool),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		r.log.Ip = firstValue(md, auditClientIpKey)
+		r.log.UserAgent = firstValue(md, auditUserAgentKey)
+		r.log.DeviceId = firstValue(md, auditDeviceIdKey)
+	}
+	if v, ok := req.(interface{ GetUserId() int32 }); ok && !m.admin {
+		r.log.ActorId = v.GetUserId()
+	}
+	// 上传的操作者在元数据中
+	if v, ok := req.(interface{ GetMetadata() *file.FileMetaData }); ok && !m.admin {
+		r.log.ActorId = v.GetMetadata().GetUserId()
+	}
+	if v, ok := req.(interface{ GetDeviceId() string }); ok && r.log.DeviceId == "" {
+		r.log.DeviceId = v.GetDeviceId()
+	}
+	if v, ok := req.(interface{ GetClientIp() string }); ok && r.log.Ip == "" {
+		r.log.Ip = v.GetClientIp()
+	}
+	// 不经网关的调用, 如管理接口, 以对端地址为 IP
+	if p, ok := peer.FromContext(ctx); ok && r.log.Ip == "" {
+		r.log.Ip = p.Addr.String()
+	}
+	if len(r.log.UserAgent) > 255 {
+		r.log.UserAgent = r.log.UserAgent[:255]
+	}
+	if len(r.log.DeviceId) > 64 {
+		r.log.DeviceId = r.log.DeviceId[:64]
+	}
+	if len(r.log.Ip) > 64 {
+		r.log.Ip = r.log.Ip[:64]
+	}
+	r.addTargets(req, !m.admin)
+
+	return r
+}
+
+// addTargets 收集消息中的目标, skipUser 为 true 时顶层的 user_id 是操作者, 不作为目标
+func (r *auditRecord) addTargets(msg any, skipUser bool) {
+	if m, ok := msg.(proto.Message); ok && m != nil {
+		collectAuditTargets(m.ProtoReflect(), strings.Split(r.method.action, ".")[0], true, skipUser, r.addTarget)
+	}
+}
+
+func (r *auditRecord) addTarget(typ, id string) {
+	t := dao.AuditTarget{Type: typ, TargetId: id}
+	if r.seen[t] || len(r.log.Targets) >= maxAuditTargets || len(id) > 64 {
+		return
+	}
+	// 操作者本身不是目标
+	if typ == "user" && !r.method.admin && id == strconv.Itoa(int(r.log.ActorId)) {
+		return
+	}
+	r.seen[t] = true
+	r.log.Targets = append(r.log.Targets, t)
+}
+
+// collectAuditTargets 从消息中收集审计目标
+// 名为 <type>_id 或 <type>_ids 的字段为对应类型的目标, 名为 id 的字段的类型为所在消息的资源:
+// 顶层为动作的资源, 嵌套的单个消息为字段名, 消息列表沿用外层的资源; 消息中名为 type 的枚举和 is_folder 优先
+// 嵌套的消息只展开一层, 避免响应中的列表产生大量目标
+func collectAuditTargets(m protoreflect.Message, resource string, top, skipUser bool, add func(typ, id string)) {
+	fields := m.Descriptor().Fields()
+	if fd := fields.ByName("type"); fd != nil && fd.Kind() == protoreflect.EnumKind && !top {
+		if ev := fd.Enum().Values().ByNumber(m.Get(fd).Enum()); ev != nil {
+			name := string(ev.Name())
+			resource = strings.ToLower(name[strings.LastIndex(name, "_")+1:])
+		}
+	}
+	itemType := resource
+	if fd := fields.ByName("is_folder"); fd != nil && fd.Kind() == protoreflect.BoolKind {
+		itemType = "file"
+		if m.Get(fd).Bool() {
+			itemType = "folder"
+		}
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		switch {
+		case fd.IsMap():
+		case fd.Kind() == protoreflect.MessageKind:
+			if !top {
+				break
+			}
+			if fd.IsList() {
+				for i := 0; i < v.List().Len(); i++ {
+					collectAuditTargets(v.List().Get(i).Message(), resource, false, skipUser, add)
+				}
+			} else {
+				collectAuditTargets(v.Message(), name, false, skipUser, add)
+			}
+		case name == "device_id", top && skipUser && name == "user_id":
+		case name == "id":
+			addAuditValue(fd, v, resource, add)
+		case name == "resource_id":
+			addAuditValue(fd, v, itemType, add)
+		case strings.HasSuffix(name, "_id"), strings.HasSuffix(name, "_ids"):
+			addAuditValue(fd, v, strings.TrimSuffix(strings.TrimSuffix(name, "s"), "_id"), add)
+		}
+		return true
+	})
+}
+
+func addAuditValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, typ string, add func(typ, id string)) {
+	if fd.IsList() {
+		for i := 0; i < v.List().Len(); i++ {
+			addAuditScalar(fd.Kind(), v.List().Get(i), typ, add)
+		}
+		return
+	}
+	addAuditScalar(fd.Kind(), v, typ, add)
+}
+
+func addAuditScalar(kind protoreflect.Kind, v protoreflect.Value, typ string, add func(typ, id string)) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		if v.Int() != 0 {
+			add(typ, strconv.FormatInt(v.Int(), 10))
+		}
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		if v.Uint() != 0 {
+			add(typ, strconv.FormatUint(v.Uint(), 10))
+		}
+	case protoreflect.StringKind:
+		if v.String() != "" {
+			add(typ, v.String())
+		}
+	}
+}
+
+// finishAudit 补充响应中的目标和操作后的元数据, 写入审计记录
+func (s *FileServer) finishAudit(ctx context.Context, r *auditRecord, resp any, err error) {
+	if err == nil && r.method.mutation {
+		r.addTargets(resp, false)
+	}
+	var owner int32
+	r.log.After, owner = s.auditSnapshot(ctx, r.log.Targets)
+	if owner == 0 {
+		owner = r.log.ActorId
+	}
+	for _, t := range r.log.Targets {
+		if owner != 0 {
+			break
+		}
+		if t.Type == "user" {
+			id, _ := strconv.ParseInt(t.TargetId, 10, 32)
+			owner = int32(id)
+		}
+	}
+	r.log.OwnerId = owner
+
+	st := status.Convert(err)
+	r.log.Code = st.Code().String()
+	if err != nil {
+		r.log.Error = st.Message()
+		if len(r.log.Error) > 255 {
+			r.log.Error = r.log.Error[:255]
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+	defer cancel()
+	if err := s.repo.AddAuditLog(ctx, &r.log); err != nil {
+		log.Printf("failed to write audit log of %s: %v", r.log.Method, err)
+	}
+}
+
+// auditSnapshot 返回文件、文件夹和分享类目标当前的元数据, 以及第一个找到的目标的所有者
+func (s *FileServer) auditSnapshot(ctx context.Context, targets []dao.AuditTarget) (string, int32) {
+	var fileIds, folderIds []int64
+	var shareIds []string
+	for _, t := range targets {
+		id, _ := strconv.ParseInt(t.TargetId, 10, 64)
+		switch t.Type {
+		case "file":
+			fileIds = append(fileIds, id)
+		case "folder":
+			folderIds = append(folderIds, id)
+		case "share":
+			shareIds = append(shareIds, t.TargetId)
+		}
+	}
+	if len(fileIds)+len(folderIds)+len(shareIds) == 0 {
+		return "", 0
+	}
+
+	subjects, err := s.repo.GetAuditSubjects(ctx, fileIds, folderIds, shareIds)
+	if err != nil {
+		log.Printf("failed to load audit subjects: %v", err)
+		return "", 0
+	}
+
+	var owner int32
+	snapshot := make(map[string]any)
+	for _, t := range targets {
+		id, _ := strconv.ParseInt(t.TargetId, 10, 64)
+		key := t.Type + ":" + t.TargetId
+		switch t.Type {
+		case "file":
+			f, ok := subjects.Files[id]
+			if !ok {
+				continue
+			}
+			metas := make(map[string]any, len(subjects.Metas[id]))
+			for _, m := range subjects.Metas[id] {
+				metas[m.MetaKey] = metaValue(m)
+			}
+			snapshot[key] = map[string]any{
+				"name": f.Name, "folder_id": f.FolderId, "size": f.Size, "version": f.Version,
+				"sha256": f.Sha256, "status": f.Status, "metas": metas,
+			}
+			owner = cmp.Or(owner, f.UserId)
+		case "folder":
+			f, ok := subjects.Folders[id]
+			if !ok {
+				continue
+			}
+			snapshot[key] = map[string]any{"name": f.Name, "parent_id": f.ParentId, "status": f.Status}
+			owner = cmp.Or(owner, f.UserId)
+		case "share":
+			sh, ok := subjects.Shares[t.TargetId]
+			if !ok {
+				continue
+			}
+			snapshot[key] = map[string]any{
+				"folder_id": sh.FolderId, "status": sh.Status, "expire_at": sh.ExpireAt.Unix(),
+				"has_password": sh.Password != "", "max_downloads": sh.MaxDownloads, "max_saves": sh.MaxSaves,
+			}
+			owner = cmp.Or(owner, sh.UserId)
+		}
+	}
+	if len(snapshot) == 0 {
+		return "", owner
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", owner
+	}
+
+	return string(data), owner
+}
+
+// ListAuditLogs 分页获取用户执行的或针对其数据的操作
+func (s *FileServer) ListAuditLogs(ctx context.Context, req *file.ListAuditLogsRequest) (*file.ListAuditLogsResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, dao.ErrPermissionDenied
+	}
+	f := toAuditFilter(req.GetFilter())
+	f.UserId = req.GetUserId()
+
+	return s.listAuditLogs(ctx, f, req.GetPage(), req.GetSize())
+}
+
+// ExportAuditLogs 导出用户执行的或针对其数据的操作
+func (s *FileServer) ExportAuditLogs(req *file.ExportAuditLogsRequest, stream file.FileService_ExportAuditLogsServer) error {
+	if req.GetUserId() == 0 {
+		return dao.ErrPermissionDenied
+	}
+	f := toAuditFilter(req.GetFilter())
+	f.UserId = req.GetUserId()
+
+	return s.exportAuditLogs(stream.Context(), f, req.GetFormat(), stream.Send)
+}
+
+// QueryAuditLogs 分页获取全部用户的审计记录
+func (s *FileServer) QueryAuditLogs(ctx context.Context, req *file.QueryAuditLogsRequest) (*file.ListAuditLogsResponse, error) {
+	return s.listAuditLogs(ctx, toAuditFilter(req.GetFilter()), req.GetPage(), req.GetSize())
+}
+
+// DumpAuditLogs 导出全部用户的审计记录
+func (s *FileServer) DumpAuditLogs(req *file.DumpAuditLogsRequest, stream file.FileService_DumpAuditLogsServer) error {
+	return s.exportAuditLogs(stream.Context(), toAuditFilter(req.GetFilter()), req.GetFormat(), stream.Send)
+}
+
+func (s *FileServer) listAuditLogs(ctx context.Context, f dao.AuditFilter, page, size int32) (*file.ListAuditLogsResponse, error) {
+	p, sz := pageParams(page, size)
+	logs, total, err := s.repo.ListAuditLogs(ctx, f, p, sz)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListAuditLogsResponse{Total: total, Logs: make([]*file.AuditLog, 0, len(logs))}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, toPbAuditLog(l))
+	}
+
+	return resp, nil
+}
+
+// exportAuditLogs 按写入顺序分批读取审计记录, 编码后分块发送
+func (s *FileServer) exportAuditLogs(ctx context.Context, f dao.AuditFilter, format file.AuditExportFormat,
+	send func(*file.ExportAuditLogsResponse) error) error {
+	var buf bytes.Buffer
+	w := newAuditWriter(&buf, format)
+	flush := func(all bool) error {
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if buf.Len() == 0 || (!all && buf.Len() < auditChunkSize) {
+			return nil
+		}
+		data := bytes.Clone(buf.Bytes())
+		buf.Reset()
+		return send(&file.ExportAuditLogsResponse{Data: data})
+	}
+
+	var afterId int64
+	for {
+		logs, err := s.repo.ScanAuditLogs(ctx, f, afterId, auditExportBatch)
+		if err != nil {
+			return err
+		}
+		for _, l := range logs {
+			if err := w.Write(toPbAuditLog(l)); err != nil {
+				return err
+			}
+		}
+		if err := flush(false); err != nil {
+			return err
+		}
+		if len(logs) < auditExportBatch {
+			break
+		}
+		afterId = logs[len(logs)-1].Id
+	}
+
+	return flush(true)
+}
+
+// auditWriter 按导出格式编码审计记录
+type auditWriter struct {
+	format file.AuditExportFormat
+	out    io.Writer
+	csv    *csv.Writer
+	header bool
+}
+
+func newAuditWriter(out io.Writer, format file.AuditExportFormat) *auditWriter {
+	return &auditWriter{format: format, out: out, csv: csv.NewWriter(out)}
+}
+
+var auditCSVHeader = []string{"id", "service", "time", "action", "method", "actor_id", "owner_id",
+	"device_id", "ip", "user_agent", "targets", "code", "error", "before", "after"}
+
+func (w *auditWriter) Write(l *file.AuditLog) error {
+	if w.format == file.AuditExportFormat_AUDIT_EXPORT_FORMAT_JSONL {
+		data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(l)
+		if err != nil {
+			return err
+		}
+		_, err = w.out.Write(append(data, '\n'))
+		return err
+	}
+
+	if !w.header {
+		w.header = true
+		if err := w.csv.Write(auditCSVHeader); err != nil {
+			return err
+		}
+	}
+	targets := make([]string, 0, len(l.Targets))
+	for _, t := range l.Targets {
+		targets = append(targets, t.Type+":"+t.Id)
+	}
+
+	return w.csv.Write([]string{
+		strconv.FormatInt(l.Id, 10), l.Service, time.Unix(l.Ctime, 0).Format(time.RFC3339), l.Action, l.Method,
+		strconv.Itoa(int(l.ActorId)), strconv.Itoa(int(l.OwnerId)), l.DeviceId, l.Ip, l.UserAgent,
+		strings.Join(targets, " "), l.Code, l.Error, l.Before, l.After,
+	})
+}
+
+func (w *auditWriter) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func toAuditFilter(f *file.AuditLogFilter) dao.AuditFilter {
+	return dao.AuditFilter{
+		ActorId:    f.GetActorId(),
+		Start:      f.GetStartTime(),
+		End:        f.GetEndTime(),
+		Action:     f.GetAction(),
+		TargetType: f.GetTargetType(),
+		TargetId:   f.GetTargetId(),
+	}
+}
+
+func toPbAuditLog(l dao.AuditLog) *file.AuditLog {
+	targets := make([]*file.AuditTarget, 0, len(l.Targets))
+	for _, t := range l.Targets {
+		targets = append(targets, &file.AuditTarget{Type: t.Type, Id: t.TargetId})
+	}
+
+	return &file.AuditLog{
+		Id:        l.Id,
+		Service:   "file",
+		Action:    l.Action,
+		Method:    l.Method,
+		ActorId:   l.ActorId,
+		OwnerId:   l.OwnerId,
+		DeviceId:  l.DeviceId,
+		Ip:        l.Ip,
+		UserAgent: l.UserAgent,
+		Targets:   targets,
+		Before:    l.Before,
+		After:     l.After,
+		Code:      l.Code,
+		Error:     l.Error,
+		Ctime:     l.Ctime,
+	}
+}
+
+// metaValue 返回自定义元数据的值, 日期为 unix 秒
+func metaValue(m dao.FileMeta) any {
+	switch m.Type {
+	case dao.MetaNumber:
+		return m.NumberValue
+	case dao.MetaDate:
+		return m.DateValue
+	case dao.MetaBool:
+		return m.BoolValue
+	default:
+		return m.StringValue
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
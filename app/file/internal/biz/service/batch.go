@@ -0,0 +1,534 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// maxBatchItems 单次批量操作允许的最大条目数
+const maxBatchItems = 1000
+
+var (
+	errInvalidItem  = errors.New("invalid operation")
+	errBatchAborted = errors.New("aborted because another item failed")
+)
+
+// batchOutcome 单个条目执行成功后的结果
+type batchOutcome struct {
+	name    string
+	newId   int64
+	skipped bool
+	version int32 // 覆盖同名文件时为新的版本号
+}
+
+// batchOp 对单个条目执行的操作, 原子模式下 s 的仓储绑定在整个批次的事务上
+type batchOp func(s *FileServer, ctx context.Context, req *file.BatchOperationRequest, item *file.BatchItem) (batchOutcome, error)
+
+// BatchMove 批量移动文件和文件夹
+func (s *FileServer) BatchMove(ctx context.Context, req *file.BatchOperationRequest) (*file.BatchOperationResponse, error) {
+	if _, err := s.folderOwner(ctx, req.GetUserId(), req.GetToFolderId(), dao.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	return s.runBatch(ctx, "move", req, (*FileServer).batchMove)
+}
+
+// BatchCopy 批量复制文件和文件夹, 文件夹同步复制
+func (s *FileServer) BatchCopy(ctx context.Context, req *file.BatchOperationRequest) (*file.BatchOperationResponse, error) {
+	if _, err := s.folderOwner(ctx, req.GetUserId(), req.GetToFolderId(), dao.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	return s.runBatch(ctx, "copy", req, (*FileServer).batchCopy)
+}
+
+// BatchDelete 批量删除文件和文件夹
+func (s *FileServer) BatchDelete(ctx context.Context, req *file.BatchOperationRequest) (*file.BatchOperationResponse, error) {
+	return s.runBatch(ctx, "delete", req, (*FileServer).batchDelete)
+}
+
+// BatchRestore 批量恢复已删除的文件和文件夹
+func (s *FileServer) BatchRestore(ctx context.Context, req *file.BatchOperationRequest) (*file.BatchOperationResponse, error) {
+	return s.runBatch(ctx, "restore", req, (*FileServer).batchRestore)
+}
+
+// BatchRename 批量重命名文件和文件夹
+func (s *FileServer) BatchRename(ctx context.Context, req *file.BatchOperationRequest) (*file.BatchOperationResponse, error) {
+	return s.runBatch(ctx, "rename", req, (*FileServer).batchRename)
+}
+
+// runBatch 按批量模式执行 op, 并发送一条汇总的变更事件
+func (s *FileServer) runBatch(ctx context.Context, action string, req *file.BatchOperationRequest, op batchOp) (*file.BatchOperationResponse, error) {
+	items := req.GetItems()
+	if len(items) == 0 {
+		return nil, errors.New("no items to process")
+	}
+	if len(items) > maxBatchItems {
+		return nil, fmt.Errorf("too many items, at most %d", maxBatchItems)
+	}
+
+	results := make([]*file.BatchItemResult, len(items))
+	for i, item := range items {
+		results[i] = &file.BatchItemResult{Type: item.GetType(), Id: item.GetId()}
+	}
+
+	if req.GetMode() == file.BatchMode_BATCH_ATOMIC {
+		err := s.repo.Transaction(ctx, func(r *repository.UploadRepo) error {
+			tx := s.withRepo(r)
+			for i, item := range items {
+				if !tx.applyBatchItem(ctx, req, item, op, results[i]) {
+					return errBatchAborted
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			// 整个批次已回滚, 失败条目保留自身的状态, 其余条目标记为已回滚
+			for _, res := range results {
+				if res.Status == file.BatchItemStatus_BATCH_ITEM_OK || res.Status == file.BatchItemStatus_BATCH_ITEM_SKIPPED {
+					res.Status = file.BatchItemStatus_BATCH_ITEM_ABORTED
+					res.Message = errBatchAborted.Error()
+					if !errors.Is(err, errBatchAborted) {
+						res.Message = err.Error()
+					}
+					res.NewId = 0
+				}
+			}
+		}
+	} else {
+		for i, item := range items {
+			// 每个条目单独一个事务, 条目内的多步修改要么全部生效要么全部回滚
+			err := s.repo.Transaction(ctx, func(r *repository.UploadRepo) error {
+				if !s.withRepo(r).applyBatchItem(ctx, req, item, op, results[i]) {
+					return errBatchAborted
+				}
+				return nil
+			})
+			if err != nil && !errors.Is(err, errBatchAborted) {
+				results[i].Status = file.BatchItemStatus_BATCH_ITEM_FAILED
+				results[i].Message = err.Error()
+				results[i].NewId = 0
+			}
+		}
+	}
+
+	resp := &file.BatchOperationResponse{Results: results}
+	eventItems := make([]mws.FileEventItem, 0, len(results))
+	for _, res := range results {
+		switch res.Status {
+		case file.BatchItemStatus_BATCH_ITEM_OK:
+			resp.Succeeded++
+			eventItems = append(eventItems, mws.FileEventItem{
+				Type:  batchItemType(res.Type),
+				Id:    res.Id,
+				NewId: res.NewId,
+				Name:  res.Name,
+			})
+		case file.BatchItemStatus_BATCH_ITEM_SKIPPED:
+		default:
+			resp.Failed++
+		}
+	}
+
+	if len(eventItems) > 0 {
+		s.publishEvent(&mws.FileChangeEvent{
+			EventType: "batch_" + action,
+			FolderId:  req.GetToFolderId(),
+			UserId:    req.GetUserId(),
+			Items:     eventItems,
+		})
+	}
+
+	return resp, nil
+}
+
+// applyBatchItem 执行单个条目并填充结果, 返回该条目是否成功
+func (s *FileServer) applyBatchItem(ctx context.Context, req *file.BatchOperationRequest, item *file.BatchItem, op batchOp, res *file.BatchItemResult) bool {
+	out, err := op(s, ctx, req, item)
+	if err != nil {
+		res.Status = batchStatus(err)
+		res.Message = err.Error()
+		return false
+	}
+
+	res.Name = out.name
+	res.NewId = out.newId
+	if out.skipped {
+		res.Status = file.BatchItemStatus_BATCH_ITEM_SKIPPED
+	}
+
+	return true
+}
+
+// withRepo 返回使用指定仓储的 FileServer 副本, 用于在事务中复用业务逻辑
+func (s *FileServer) withRepo(r *repository.UploadRepo) *FileServer {
+	cp := *s
+	cp.repo = r
+	return &cp
+}
+
+func (s *FileServer) batchMove(ctx context.Context, req *file.BatchOperationRequest, item *file.BatchItem) (batchOutcome, error) {
+	uid, to := req.GetUserId(), req.GetToFolderId()
+
+	if item.GetType() == file.BatchItemType_BATCH_ITEM_FOLDER {
+		folder, err := s.authorizeFolder(ctx, uid, item.GetId(), dao.RoleEditor)
+		if err != nil {
+			return batchOutcome{}, err
+		}
+		if folder.ParentId == to {
+			return batchOutcome{name: folder.Name}, nil
+		}
+		if err := checkSpaceRoot(folder); err != nil {
+			return batchOutcome{}, err
+		}
+		toOwner, err := s.checkMoveTarget(ctx, uid, to, folder.UserId, folderMoveVault(folder))
+		if err != nil {
+			return batchOutcome{}, err
+		}
+		if toOwner != folder.UserId {
+			moved, skip, err := s.transferFolder(ctx, folder, to, toOwner, folder.Name, req.GetConflictPolicy())
+			if err != nil || skip {
+				return batchOutcome{skipped: skip}, err
+			}
+			s.recordTransfer(ctx, folder.UserId, toOwner, uid, true, moved.Id, moved.Name)
+			return batchOutcome{name: moved.Name}, nil
+		}
+
+		name, skip, err := s.resolveInFolder(ctx, req.GetConflictPolicy(), folder.Name, true, to, toOwner, dao.NameEntry{})
+		if err != nil || skip {
+			return batchOutcome{name: name, skipped: skip}, err
+		}
+
+		if _, err := s.repo.MoveFolder(ctx, folder.Id, to, toOwner, name); err != nil {
+			return batchOutcome{}, err
+		}
+		s.recordActivity(ctx, toOwner, uid, dao.ActivityMove, true, folder.Id, name)
+		return batchOutcome{name: name}, nil
+	}
+
+	f, err := s.authorizeFile(ctx, uid, item.GetId(), dao.RoleEditor)
+	if err != nil {
+		return batchOutcome{}, err
+	}
+	if f.FolderId == to {
+		return s.moveFile(ctx, f, to, req.GetConflictPolicy())
+	}
+	toOwner, err := s.checkMoveTarget(ctx, uid, to, f.UserId, f.VaultId)
+	if err != nil {
+		return batchOutcome{}, err
+	}
+
+	if toOwner != f.UserId {
+		out, err := s.transferFile(ctx, f, to, toOwner, req.GetConflictPolicy())
+		if err == nil && !out.skipped {
+			s.recordTransfer(ctx, f.UserId, toOwner, uid, false, f.Id, out.name)
+		}
+		return out, err
+	}
+	out, err := s.moveFile(ctx, f, to, req.GetConflictPolicy())
+	if err == nil && !out.skipped {
+		s.recordActivity(ctx, f.UserId, uid, dao.ActivityMove, false, f.Id, out.name)
+	}
+
+	return out, err
+}
+
+func (s *FileServer) batchCopy(ctx context.Context, req *file.BatchOperationRequest, item *file.BatchItem) (batchOutcome, error) {
+	to := req.GetToFolderId()
+	// 副本属于目标文件夹的所有者
+	uid, err := s.folderOwner(ctx, req.GetUserId(), to, dao.RoleEditor)
+	if err != nil {
+		return batchOutcome{}, err
+	}
+
+	if item.GetType() == file.BatchItemType_BATCH_ITEM_FOLDER {
+		root, err := s.authorizeFolder(ctx, req.GetUserId(), item.GetId(), dao.RoleViewer)
+		if err != nil {
+			return batchOutcome{}, err
+		}
+
+		folders, files, err := s.repo.ListSubtree(ctx, root.Id, root.UserId)
+		if err != nil {
+			return batchOutcome{}, err
+		}
+		if err := checkNotInSubtree(root, folders, to); err != nil {
+			return batchOutcome{}, err
+		}
+		if err := checkVaultCopy(root, folders); err != nil {
+			return batchOutcome{}, err
+		}
+		if err := s.checkVaultTarget(ctx, to, root.VaultId); err != nil {
+			return batchOutcome{}, err
+		}
+
+		name, skip, err := s.resolveInFolder(ctx, req.GetConflictPolicy(), root.Name, true, to, uid, dao.NameEntry{})
+		if err != nil || skip {
+			return batchOutcome{name: name, skipped: skip}, err
+		}
+
+		var totalSize int64
+		for _, f := range files {
+			totalSize += f.Size
+		}
+		if err := s.ensureCapacity(ctx, uid, totalSize); err != nil {
+			return batchOutcome{}, err
+		}
+
+		folder, err := s.repo.CopyTree(ctx, root, name, to, uid, folders, files, func(int) {})
+		if err != nil {
+			return batchOutcome{}, err
+		}
+
+		return batchOutcome{name: folder.Name, newId: folder.Id}, nil
+	}
+
+	src, err := s.authorizeFile(ctx, req.GetUserId(), item.GetId(), dao.RoleViewer)
+	if err != nil {
+		return batchOutcome{}, err
+	}
+
+	return s.copyFile(ctx, src, to, uid, req.GetConflictPolicy())
+}
+
+func (s *FileServer) batchDelete(ctx context.Context, req *file.BatchOperationRequest, item *file.BatchItem) (batchOutcome, error) {
+	uid := req.GetUserId()
+
+	if item.GetType() == file.BatchItemType_BATCH_ITEM_FOLDER {
+		folder, err := s.authorizeFolder(ctx, uid, item.GetId(), dao.RoleEditor)
+		if err != nil {
+			return batchOutcome{}, err
+		}
+		if err := checkSpaceRoot(folder); err != nil {
+			return batchOutcome{}, err
+		}
+
+		if err := s.repo.DeleteFolder(ctx, folder.Id, folder.UserId); err != nil {
+			return batchOutcome{}, err
+		}
+		s.recordActivity(ctx, folder.UserId, uid, dao.ActivityDelete, true, folder.Id, folder.Name)
+		return batchOutcome{name: folder.Name}, nil
+	}
+
+	f, err := s.authorizeFile(ctx, uid, item.GetId(), dao.RoleEditor)
+	if err != nil {
+		return batchOutcome{}, err
+	}
+
+	if err := s.repo.DeleteFile(ctx, f.Id, f.UserId); err != nil {
+		return batchOutcome{}, err
+	}
+	s.recordActivity(ctx, f.UserId, uid, dao.ActivityDelete, false, f.Id, f.Name)
+
+	return batchOutcome{name: f.Name}, nil
+}
+
+func (s *FileServer) batchRestore(ctx context.Context, req *file.BatchOperationRequest, item *file.BatchItem) (batchOutcome, error) {
+	uid := req.GetUserId()
+
+	if item.GetType() == file.BatchItemType_BATCH_ITEM_FOLDER {
+		folder, err := s.repo.GetDeletedFolder(ctx, item.GetId(), uid)
+		if err != nil {
+			return batchOutcome{}, err
+		}
+		if err := s.checkRestoreParent(ctx, folder.ParentId, uid); err != nil {
+			return batchOutcome{}, err
+		}
+
+		name, skip, err := s.resolveInFolder(ctx, req.GetConflictPolicy(), folder.Name, true, folder.ParentId, uid, dao.NameEntry{})
+		if err != nil || skip {
+			return batchOutcome{name: name, skipped: skip}, err
+		}
+
+		return batchOutcome{name: name}, s.repo.RestoreFolder(ctx, folder.Id, uid, name)
+	}
+
+	f, err := s.repo.GetFile(ctx, item.GetId(), uid)
+	if err != nil {
+		return batchOutcome{}, err
+	}
+	if f.Id == 0 || f.Status != 1 {
+		return batchOutcome{}, gorm.ErrRecordNotFound
+	}
+	if err := s.checkRestoreParent(ctx, f.FolderId, uid); err != nil {
+		return batchOutcome{}, err
+	}
+
+	name, skip, err := s.resolveInFolder(ctx, req.GetConflictPolicy(), f.Name, false, f.FolderId, uid, dao.NameEntry{})
+	if err != nil || skip {
+		return batchOutcome{name: name, skipped: skip}, err
+	}
+
+	return batchOutcome{name: name}, s.repo.RestoreFile(ctx, f.Id, uid, name)
+}
+
+func (s *FileServer) batchRename(ctx context.Context, req *file.BatchOperationRequest, item *file.BatchItem) (batchOutcome, error) {
+	uid, newName := req.GetUserId(), item.GetNewName()
+	if err := validateName(newName); err != nil {
+		return batchOutcome{}, err
+	}
+
+	if item.GetType() == file.BatchItemType_BATCH_ITEM_FOLDER {
+		folder, err := s.authorizeFolder(ctx, uid, item.GetId(), dao.RoleEditor)
+		if err != nil {
+			return batchOutcome{}, err
+		}
+		if folder.Name == newName {
+			return batchOutcome{name: newName}, nil
+		}
+		if err := checkSpaceRoot(folder); err != nil {
+			return batchOutcome{}, err
+		}
+		owner := folder.UserId
+
+		self := dao.NameEntry{Id: folder.Id, Name: folder.Name, IsFolder: true}
+		name, skip, err := s.resolveInFolder(ctx, req.GetConflictPolicy(), newName, true, folder.ParentId, owner, self)
+		if err != nil || skip {
+			return batchOutcome{name: name, skipped: skip}, err
+		}
+
+		if err := s.repo.RenameFolder(ctx, folder.Id, owner, name); err != nil {
+			return batchOutcome{}, err
+		}
+		s.recordActivity(ctx, owner, uid, dao.ActivityRename, true, folder.Id, name)
+		return batchOutcome{name: name}, nil
+	}
+
+	f, err := s.authorizeFile(ctx, uid, item.GetId(), dao.RoleEditor)
+	if err != nil {
+		return batchOutcome{}, err
+	}
+	if f.Name == newName {
+		return batchOutcome{name: newName}, nil
+	}
+	self := dao.NameEntry{Id: f.Id, Name: f.Name}
+	name, skip, err := s.resolveInFolder(ctx, req.GetConflictPolicy(), newName, false, f.FolderId, f.UserId, self)
+	if err != nil || skip {
+		return batchOutcome{name: name, skipped: skip}, err
+	}
+
+	if err := s.repo.RenameFile(ctx, f.Id, f.UserId, name); err != nil {
+		return batchOutcome{}, err
+	}
+	s.recordActivity(ctx, f.UserId, uid, dao.ActivityRename, false, f.Id, name)
+
+	return batchOutcome{name: name}, nil
+}
+
+// resolveInFolder 在 folderId 下按同名策略计算名称, 用于不支持覆盖的操作
+// self 为条目自身, 不参与冲突判断, 为零值时表示新条目
+func (s *FileServer) resolveInFolder(ctx context.Context, policy file.NameConflictPolicy, name string, isFolder bool,
+	folderId int64, uid int32, self dao.NameEntry) (string, bool, error) {
+	policy, err := s.vaultPolicy(ctx, policy, folderId)
+	if err != nil {
+		return "", false, err
+	}
+	taken, err := s.listNames(ctx, folderId, uid, self)
+	if err != nil {
+		return "", false, err
+	}
+
+	return resolveName(s.repo.Naming(), policy, name, isFolder, taken)
+}
+
+// resolveFileInFolder 在 folderId 下按同名策略计算新文件的名称, 支持覆盖同名文件
+func (s *FileServer) resolveFileInFolder(ctx context.Context, policy file.NameConflictPolicy, name string, folderId int64, uid int32) (nameResolution, error) {
+	policy, err := s.vaultPolicy(ctx, policy, folderId)
+	if err != nil {
+		return nameResolution{}, err
+	}
+	taken, err := s.listNames(ctx, folderId, uid, dao.NameEntry{})
+	if err != nil {
+		return nameResolution{}, err
+	}
+
+	return resolveEntry(s.repo.Naming(), policy, name, false, taken)
+}
+
+// listNames 获取文件夹下的条目, 排除 self
+func (s *FileServer) listNames(ctx context.Context, folderId int64, uid int32, self dao.NameEntry) ([]dao.NameEntry, error) {
+	taken, err := s.repo.ListNames(ctx, folderId, uid)
+	if err != nil {
+		return nil, err
+	}
+	if self.Id != 0 {
+		for i, t := range taken {
+			if t.Id == self.Id && t.IsFolder == self.IsFolder {
+				taken = append(taken[:i], taken[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return taken, nil
+}
+
+// ensureCapacity 检查用户剩余容量
+func (s *FileServer) ensureCapacity(ctx context.Context, uid int32, size int64) error {
+	enough, err := s.repo.QueryCapacity(ctx, uid, size)
+	if err != nil {
+		return err
+	}
+	if !enough {
+		return dao.ErrInsufficientSpace
+	}
+
+	return nil
+}
+
+// checkRestoreParent 恢复前检查父文件夹未被删除
+func (s *FileServer) checkRestoreParent(ctx context.Context, parentId int64, uid int32) error {
+	err := s.checkTargetFolder(ctx, parentId, uid)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: parent folder has been deleted, restore it first", errInvalidItem)
+	}
+
+	return err
+}
+
+// checkNotInSubtree 检查 to 不是 root 自身或其子文件夹, folders 为 root 的全部子文件夹
+func checkNotInSubtree(root dao.Folder, folders []dao.Folder, to int64) error {
+	if to == root.Id {
+		return fmt.Errorf("%w: target is the folder itself", errInvalidItem)
+	}
+	for _, f := range folders {
+		if f.Id == to {
+			return fmt.Errorf("%w: target is a subfolder of %s", errInvalidItem, root.Name)
+		}
+	}
+
+	return nil
+}
+
+// batchStatus 将错误映射为条目状态
+func batchStatus(err error) file.BatchItemStatus {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return file.BatchItemStatus_BATCH_ITEM_NOT_FOUND
+	case errors.Is(err, ErrNameConflict), errors.Is(err, gorm.ErrDuplicatedKey):
+		return file.BatchItemStatus_BATCH_ITEM_CONFLICT
+	case errors.Is(err, dao.ErrInsufficientSpace):
+		return file.BatchItemStatus_BATCH_ITEM_NO_SPACE
+	case errors.Is(err, errInvalidItem), errors.Is(err, ErrInvalidName), errors.Is(err, dao.ErrFolderCycle),
+		errors.Is(err, ErrCrossOwner), errors.Is(err, dao.ErrSpaceRoot), errors.Is(err, ErrVaultBoundary):
+		return file.BatchItemStatus_BATCH_ITEM_INVALID
+	case errors.Is(err, dao.ErrPermissionDenied):
+		return file.BatchItemStatus_BATCH_ITEM_FORBIDDEN
+	default:
+		return file.BatchItemStatus_BATCH_ITEM_FAILED
+	}
+}
+
+func batchItemType(t file.BatchItemType) string {
+	if t == file.BatchItemType_BATCH_ITEM_FOLDER {
+		return "folder"
+	}
+	return "file"
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// copyAsyncThreshold 子树条目数超过该值时复制转为异步任务
+const copyAsyncThreshold = 200
+
+// CopyFile 复制文件到目标文件夹
+func (s *FileServer) CopyFile(ctx context.Context, req *file.CopyFileRequest) (*file.CopyFileResponse, error) {
+	src, err := s.authorizeFile(ctx, req.GetUserId(), req.GetFileId(), dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	// 副本属于目标文件夹的所有者
+	uid, err := s.folderOwner(ctx, req.GetUserId(), req.GetToFolderId(), dao.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s.copyFile(ctx, src, req.GetToFolderId(), uid, req.GetConflictPolicy())
+	if err != nil {
+		return nil, err
+	}
+	if out.skipped {
+		return &file.CopyFileResponse{Skipped: true}, nil
+	}
+	s.recordActivity(ctx, uid, req.GetUserId(), dao.ActivityCopy, false, out.newId, out.name)
+
+	dst, err := s.repo.GetFile(ctx, out.newId, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.CopyFileResponse{File: &file.File{
+		Id:       int32(dst.Id),
+		Name:     dst.Name,
+		FolderId: dst.FolderId,
+		UserId:   dst.UserId,
+		Size:     dst.Size,
+		Type:     dst.Type,
+		Utime:    time.Unix(dst.Utime, 0).Format(time.DateTime),
+		Version:  dst.Version,
+		VaultId:  dst.VaultId,
+	}}, nil
+}
+
+// CopyFolder 递归复制文件夹到目标文件夹, 子树较大时转为异步任务
+func (s *FileServer) CopyFolder(ctx context.Context, req *file.CopyFolderRequest) (*file.CopyFolderResponse, error) {
+	root, err := s.authorizeFolder(ctx, req.GetUserId(), req.GetFolderId(), dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	// 副本属于目标文件夹的所有者并占用其空间
+	uid, err := s.folderOwner(ctx, req.GetUserId(), req.GetToFolderId(), dao.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	folders, files, err := s.repo.ListSubtree(ctx, root.Id, root.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 不能复制到自身或子文件夹中
+	if req.GetToFolderId() == root.Id {
+		return nil, errors.New("cannot copy folder into itself")
+	}
+	for _, f := range folders {
+		if f.Id == req.GetToFolderId() {
+			return nil, errors.New("cannot copy folder into its subfolder")
+		}
+	}
+	if err := checkVaultCopy(root, folders); err != nil {
+		return nil, err
+	}
+	if err := s.checkVaultTarget(ctx, req.GetToFolderId(), root.VaultId); err != nil {
+		return nil, err
+	}
+
+	name, skip, err := s.resolveInFolder(ctx, req.GetConflictPolicy(), root.Name, true, req.GetToFolderId(), uid, dao.NameEntry{})
+	if err != nil {
+		return nil, err
+	}
+	if skip {
+		return &file.CopyFolderResponse{Skipped: true}, nil
+	}
+
+	// 按逻辑大小检查容量, 副本虽然共用对象但计入配额
+	var totalSize int64
+	for _, f := range files {
+		totalSize += f.Size
+	}
+	enough, err := s.repo.QueryCapacity(ctx, uid, totalSize)
+	if err != nil {
+		return nil, err
+	}
+	if !enough {
+		return nil, errors.New("insufficient storage space")
+	}
+
+	copyTree := func(ctx context.Context, progress func(n int)) (dao.Folder, error) {
+		folder, err := s.repo.CopyTree(ctx, root, name, req.GetToFolderId(), uid, folders, files, progress)
+		if err == nil {
+			s.recordActivity(ctx, uid, req.GetUserId(), dao.ActivityCopy, true, folder.Id, folder.Name)
+		}
+		return folder, err
+	}
+
+	total := len(folders) + len(files)
+	if total > copyAsyncThreshold {
+		jobId, err := s.startJob(ctx, req.GetUserId(), "copy_folder", int64(total), func(ctx context.Context, progress func(n int)) (int64, error) {
+			folder, err := copyTree(ctx, progress)
+			return folder.Id, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &file.CopyFolderResponse{JobId: jobId}, nil
+	}
+
+	folder, err := copyTree(ctx, func(int) {})
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.CopyFolderResponse{Folder: toPbFolder(folder)}, nil
+}
+
+// checkTargetFolder 检查目标文件夹存在且属于当前用户, 0 表示根目录
+func (s *FileServer) checkTargetFolder(ctx context.Context, folderId int64, uid int32) error {
+	if folderId == 0 {
+		return nil
+	}
+
+	_, err := s.repo.GetFolder(ctx, folderId, uid)
+	return err
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/config"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+)
+
+// rotateBatch 轮换密钥时每批重新包裹的密钥数
+const rotateBatch = 500
+
+var (
+	// ErrPartSize 加密的分片上传要求除最后一个分片外, 每个分片大小相同且为加密分块的整数倍
+	ErrPartSize = fmt.Errorf("part size must be the same for all but the last part and a multiple of %d bytes", mws.CryptChunkSize)
+	// ErrInvalidRange 读取范围超出文件大小
+	ErrInvalidRange = errors.New("invalid range")
+)
+
+// KeyManager 管理对象的信封加密
+// 每个对象有独立的数据密钥, 由写入时所属用户的密钥加密密钥包裹, 用户密钥再由主密钥包裹
+// 轮换任一层密钥时只需重新包裹下一层的密钥, 对象本身不需要重写
+type KeyManager struct {
+	repo    *repository.UploadRepo
+	keyring *mws.Keyring
+	keks    sync.Map // kekId -> 解开的用户密钥
+}
+
+type kekId struct {
+	uid     int32
+	version int32
+}
+
+func NewKeyManager(repo *repository.UploadRepo, keyring *mws.Keyring) *KeyManager {
+	return &KeyManager{repo: repo, keyring: keyring}
+}
+
+// Seal 为对象生成新的数据密钥并加密 data, 未开启加密时原样返回
+// 对象被重写时旧的数据密钥随之替换或删除
+func (m *KeyManager) Seal(ctx context.Context, owner int32, objectKey string, data []byte) ([]byte, error) {
+	if !m.keyring.Enabled() {
+		return data, m.repo.DeleteBlobKey(ctx, objectKey)
+	}
+
+	bk, key, err := m.newBlobKey(ctx, owner, objectKey, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.repo.SaveBlobKey(ctx, bk); err != nil {
+		return nil, err
+	}
+
+	return mws.SealChunks(key, data, 0, true)
+}
+
+// BeginMultipart 为分片上传的对象生成数据密钥, firstPart 为第一个分片的大小, 未开启加密时返回 nil
+// 返回的密钥尚未保存, 由调用方保存在上传的会话中, 在上传完成的事务中才写入, 中止的上传不会留下密钥
+// 其余分片按第一个分片的大小确定在对象中的位置, 因此除最后一个分片外须与第一个分片大小相同
+func (m *KeyManager) BeginMultipart(ctx context.Context, owner int32, objectKey string, firstPart int64) (*dao.BlobKey, error) {
+	if !m.keyring.Enabled() {
+		return nil, nil
+	}
+
+	// 只有一个分片时大小不必是分块的整数倍
+	partSize := (max(firstPart, 1) + mws.CryptChunkSize - 1) / mws.CryptChunkSize * mws.CryptChunkSize
+	bk, _, err := m.newBlobKey(ctx, owner, objectKey, partSize)
+	return bk, err
+}
+
+// SealPart 以 BeginMultipart 生成的密钥加密第 partNumber 个分片, bk 为 nil 时原样返回
+// last 为 false 时分片大小须与第一个分片相同
+func (m *KeyManager) SealPart(ctx context.Context, bk *dao.BlobKey, partNumber int32, data []byte, last bool) ([]byte, error) {
+	if bk == nil {
+		return data, nil
+	}
+	key, err := m.unwrapBlobKey(ctx, *bk)
+	if err != nil {
+		return nil, err
+	}
+
+	n := int64(len(data))
+	if n > bk.PartSize || (!last && n != bk.PartSize) {
+		return nil, ErrPartSize
+	}
+
+	return mws.SealChunks(key, data, int64(partNumber-1)*bk.PartSize/mws.CryptChunkSize, last)
+}
+
+// Open 从 store 读取对象从明文偏移 offset 开始的内容, 没有数据密钥的对象为明文
+// 加密的对象只读取 offset 所在块之后的密文
+func (m *KeyManager) Open(ctx context.Context, store mws.BlobStore, objectKey string, offset int64) (io.ReadCloser, error) {
+	_, key, err := m.blobKey(ctx, objectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.open(ctx, store, objectKey, key, offset)
+}
+
+// OpenPending 从头读取数据密钥尚未保存的对象, bk 为 BeginMultipart 的结果, nil 表示明文
+func (m *KeyManager) OpenPending(ctx context.Context, store mws.BlobStore, bk *dao.BlobKey, objectKey string) (io.ReadCloser, error) {
+	var key []byte
+	if bk != nil {
+		var err error
+		if key, err = m.unwrapBlobKey(ctx, *bk); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.open(ctx, store, objectKey, key, 0)
+}
+
+// open 以数据密钥 key 解密读取对象, key 为 nil 时直接读取
+func (m *KeyManager) open(ctx context.Context, store mws.BlobStore, objectKey string, key []byte, offset int64) (io.ReadCloser, error) {
+	if key == nil {
+		return store.Get(ctx, objectKey, offset, 0)
+	}
+
+	src, err := store.Get(ctx, objectKey, mws.CipherOffset(offset), 0)
+	if err != nil {
+		return nil, err
+	}
+	r, err := mws.NewDecryptReader(key, src, offset)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+
+	return readCloser{Reader: r, Closer: src}, nil
+}
+
+// Encrypted 对象是否加密保存
+func (m *KeyManager) Encrypted(ctx context.Context, objectKey string) (bool, error) {
+	_, err := m.repo.GetBlobKey(ctx, objectKey)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// RotateUserKey 为用户生成新版本的密钥加密密钥, 用它重新包裹用户的全部数据密钥, 然后删除不再使用的旧版本
+// 返回重新包裹的数据密钥数量
+func (m *KeyManager) RotateUserKey(ctx context.Context, uid int32) (int64, error) {
+	cur, err := m.repo.GetLatestUserKey(ctx, uid)
+	if err != nil {
+		if errors.Is(err, dao.ErrUserKeyNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	// 新版本创建后, 新写入的对象即使用新版本, 下面只需处理已有的数据密钥
+	version, kek, err := m.newKek(ctx, uid, cur.Version+1)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	var after string
+	for {
+		keys, err := m.repo.ListBlobKeysBelow(ctx, uid, version, after, rotateBatch)
+		if err != nil {
+			return n, err
+		}
+		for _, bk := range keys {
+			after = bk.ObjectKey
+			old, err := m.kek(ctx, uid, bk.KekVersion)
+			if err != nil {
+				return n, fmt.Errorf("object %s: %w", bk.ObjectKey, err)
+			}
+			key, err := mws.UnwrapKey(old, bk.WrappedKey, []byte(bk.ObjectKey))
+			if err != nil {
+				return n, fmt.Errorf("object %s: %w", bk.ObjectKey, err)
+			}
+			wrapped, err := mws.WrapKey(kek, key, []byte(bk.ObjectKey))
+			if err != nil {
+				return n, err
+			}
+			if err := m.repo.RewrapBlobKey(ctx, bk.ObjectKey, bk.KekVersion, version, wrapped); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if len(keys) < rotateBatch {
+			break
+		}
+	}
+
+	if _, err := m.repo.DeleteUnusedUserKeys(ctx, uid, version); err != nil {
+		return n, err
+	}
+
+	return n, nil
+}
+
+// RotateAllUserKeys 轮换所有用户的密钥加密密钥
+func (m *KeyManager) RotateAllUserKeys(ctx context.Context) (int64, error) {
+	uids, err := m.repo.ListKeyOwners(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, uid := range uids {
+		n, err := m.RotateUserKey(ctx, uid)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("user %d: %w", uid, err)
+		}
+	}
+
+	return total, nil
+}
+
+// RotateMasterKey 轮换主密钥并用新主密钥重新包裹所有用户密钥, 返回重新包裹的用户密钥数量
+// 使用本地 KMS 时自动生成新的主密钥; 主密钥来自配置时, 须先在配置中加入新密钥并修改 masterKeyId
+func (m *KeyManager) RotateMasterKey(ctx context.Context) (int64, error) {
+	if m.keyring.Local() {
+		if _, err := m.keyring.RotateLocal(); err != nil {
+			return 0, err
+		}
+	}
+	current := m.keyring.CurrentId()
+
+	var n int64
+	var after int64
+	for {
+		keys, err := m.repo.ListUserKeysNotWrappedBy(ctx, current, after, rotateBatch)
+		if err != nil {
+			return n, err
+		}
+		for _, k := range keys {
+			after = k.Id
+			kek, err := m.keyring.Unwrap(k.MasterKeyId, k.WrappedKey, kekAAD(k.UserId))
+			if err != nil {
+				return n, fmt.Errorf("user %d key v%d: %w", k.UserId, k.Version, err)
+			}
+			id, wrapped, err := m.keyring.Wrap(kek, kekAAD(k.UserId))
+			if err != nil {
+				return n, err
+			}
+			if err := m.repo.UpdateUserKeyWrap(ctx, k.Id, wrapped, id); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if len(keys) < rotateBatch {
+			return n, nil
+		}
+	}
+}
+
+// newBlobKey 生成对象的数据密钥, 返回以 owner 最新的用户密钥包裹的记录和密钥本身, 记录由调用方保存
+func (m *KeyManager) newBlobKey(ctx context.Context, owner int32, objectKey string, partSize int64) (*dao.BlobKey, []byte, error) {
+	version, kek, err := m.currentKek(ctx, owner)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := mws.NewDataKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	// 以 object key 作为附加数据, 包裹后的密钥不能挪用到其他对象
+	wrapped, err := mws.WrapKey(kek, key, []byte(objectKey))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &dao.BlobKey{
+		ObjectKey:  objectKey,
+		KeyOwner:   owner,
+		KekVersion: version,
+		WrappedKey: wrapped,
+		PartSize:   partSize,
+	}, key, nil
+}
+
+// blobKey 获取并解开对象的数据密钥, 对象未加密时返回 nil
+func (m *KeyManager) blobKey(ctx context.Context, objectKey string) (dao.BlobKey, []byte, error) {
+	bk, err := m.repo.GetBlobKey(ctx, objectKey)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dao.BlobKey{}, nil, nil
+		}
+		return dao.BlobKey{}, nil, err
+	}
+
+	key, err := m.unwrapBlobKey(ctx, bk)
+	if err != nil {
+		return dao.BlobKey{}, nil, err
+	}
+
+	return bk, key, nil
+}
+
+// unwrapBlobKey 以包裹时的用户密钥解开数据密钥
+func (m *KeyManager) unwrapBlobKey(ctx context.Context, bk dao.BlobKey) ([]byte, error) {
+	kek, err := m.kek(ctx, bk.KeyOwner, bk.KekVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return mws.UnwrapKey(kek, bk.WrappedKey, []byte(bk.ObjectKey))
+}
+
+// currentKek 获取用户最新版本的密钥加密密钥, 不存在时生成第一个版本
+func (m *KeyManager) currentKek(ctx context.Context, uid int32) (int32, []byte, error) {
+	k, err := m.repo.GetLatestUserKey(ctx, uid)
+	if err != nil {
+		if errors.Is(err, dao.ErrUserKeyNotFound) {
+			return m.newKek(ctx, uid, 1)
+		}
+		return 0, nil, err
+	}
+
+	kek, err := m.unwrapKek(k)
+	return k.Version, kek, err
+}
+
+// newKek 生成用户指定版本的密钥加密密钥, 并发生成同一版本时使用已保存的那个
+func (m *KeyManager) newKek(ctx context.Context, uid, version int32) (int32, []byte, error) {
+	kek, err := mws.NewDataKey()
+	if err != nil {
+		return 0, nil, err
+	}
+	masterId, wrapped, err := m.keyring.Wrap(kek, kekAAD(uid))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	err = m.repo.CreateUserKey(ctx, &dao.UserKey{UserId: uid, Version: version, WrappedKey: wrapped, MasterKeyId: masterId})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		kek, err = m.kek(ctx, uid, version)
+		return version, kek, err
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	m.keks.Store(kekId{uid: uid, version: version}, kek)
+
+	return version, kek, nil
+}
+
+// kek 获取用户指定版本的密钥加密密钥
+func (m *KeyManager) kek(ctx context.Context, uid, version int32) ([]byte, error) {
+	if v, ok := m.keks.Load(kekId{uid: uid, version: version}); ok {
+		return v.([]byte), nil
+	}
+
+	k, err := m.repo.GetUserKey(ctx, uid, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.unwrapKek(k)
+}
+
+func (m *KeyManager) unwrapKek(k dao.UserKey) ([]byte, error) {
+	id := kekId{uid: k.UserId, version: k.Version}
+	if v, ok := m.keks.Load(id); ok {
+		return v.([]byte), nil
+	}
+
+	kek, err := m.keyring.Unwrap(k.MasterKeyId, k.WrappedKey, kekAAD(k.UserId))
+	if err != nil {
+		return nil, err
+	}
+	m.keks.Store(id, kek)
+
+	return kek, nil
+}
+
+// kekAAD 用户密钥的附加数据, 包裹后的密钥不能挪用到其他用户
+func kekAAD(uid int32) []byte {
+	return []byte(fmt.Sprintf("user:%d", uid))
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// openContent 打开文件从明文偏移 offset 开始的 length 字节, length 为 0 表示读到末尾, 尚未扫描通过的文件不能读取
+func (s *FileServer) openContent(ctx context.Context, f dao.File, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 || length < 0 || (offset > 0 && offset >= f.Size) {
+		return nil, ErrInvalidRange
+	}
+	if err := s.checkScanned(ctx, f); err != nil {
+		return nil, err
+	}
+
+	rc, err := s.keys.Open(ctx, s.store, f.ObjectName(), offset)
+	if err != nil {
+		return nil, err
+	}
+	if length > 0 {
+		return readCloser{Reader: io.LimitReader(rc, length), Closer: rc}, nil
+	}
+
+	return rc, nil
+}
+
+// previewURL 返回预览地址, 明文对象使用对象存储的预签名地址
+// 加密的对象从对象存储只能拿到密文, 改为由网关解密后返回的 gatewayPath
+func (s *FileServer) previewURL(ctx context.Context, objectKey, gatewayPath string) (string, error) {
+	encrypted, err := s.keys.Encrypted(ctx, objectKey)
+	if err != nil {
+		return "", err
+	}
+	if encrypted {
+		return strings.TrimSuffix(config.GetConf().Server.BaseURL, "/") + gatewayPath, nil
+	}
+
+	return s.store.Presign(ctx, objectKey, time.Hour)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+)
+
+const (
+	// eventTimeout 发送文件变更事件的超时时间
+	eventTimeout = 5 * time.Second
+	// eventBatch 每轮重试发送的事件数
+	eventBatch = 100
+	// eventRetention 一直没能发送到 Kafka 的事件的保留时间
+	eventRetention = 24 * time.Hour
+)
+
+// publishEvent 记录文件变更事件后异步发送, 发送失败的由 dispatchEvents 重试, 不影响主流程
+func (s *FileServer) publishEvent(event *mws.FileChangeEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventTimeout)
+	defer cancel()
+
+	e, err := s.addEvent(ctx, event)
+	if err != nil {
+		log.Printf("failed to record file event %s: %s", event.EventType, err)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), eventTimeout)
+		defer cancel()
+
+		if err := s.sendEvent(ctx, e, event); err != nil {
+			log.Printf("failed to send file event %s: %s", event.EventType, err)
+		}
+	}()
+}
+
+// addEvent 记录待发送的文件变更事件
+func (s *FileServer) addEvent(ctx context.Context, event *mws.FileChangeEvent) (*dao.FileEvent, error) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	e := &dao.FileEvent{UserId: event.UserId, EventType: event.EventType, Payload: payload}
+
+	return e, s.repo.AddFileEvent(ctx, e)
+}
+
+// sendEvent 为事件创建 webhook 投递并发送到 Kafka, 两者分别记录完成状态, 一边失败不影响另一边
+func (s *FileServer) sendEvent(ctx context.Context, e *dao.FileEvent, event *mws.FileChangeEvent) error {
+	var errs []error
+	if !e.Queued {
+		if err := s.enqueueWebhooks(ctx, e.Id, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if !e.Sent {
+		var err error
+		if s.kafka != nil {
+			err = s.kafka.SendFileEvent(ctx, event)
+		}
+		if err == nil {
+			err = s.repo.MarkFileEventSent(ctx, e.Id)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// dispatchEvents 重试发送 now 以前记录、尚未发送完的事件, 并清理已发送完和过期的事件
+// 刚记录的事件可能仍在 publishEvent 中发送, 等待 eventTimeout 后才重试
+func (s *FileServer) dispatchEvents(ctx context.Context, now time.Time) error {
+	events, err := s.repo.ListPendingFileEvents(ctx, now.Add(-eventTimeout).Unix(), eventBatch)
+	if err != nil {
+		return err
+	}
+	for i := range events {
+		e := &events[i]
+		var event mws.FileChangeEvent
+		if err := json.Unmarshal(e.Payload, &event); err != nil {
+			return err
+		}
+		sendCtx, cancel := context.WithTimeout(ctx, eventTimeout)
+		if err := s.sendEvent(sendCtx, e, &event); err != nil {
+			log.Printf("failed to send file event %d: %v", e.Id, err)
+		}
+		cancel()
+	}
+
+	n, err := s.repo.PurgeFileEvents(ctx, now.Add(-eventRetention).Unix())
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("purged %d file events", n)
+	}
+
+	return nil
+}
@@ -6,8 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"os"
-	"path/filepath"
+	"net/http"
 	"strings"
 	"time"
 
@@ -17,172 +16,340 @@ import (
 	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
 
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
+	"gorm.io/gorm"
 
 	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
 )
 
 type FileServer struct {
 	repo   *repository.UploadRepo
-	minio  *mws.MinioServer
+	store  mws.BlobStore
 	worker DownloadWorker
 	kafka  *mws.KafkaProducer
+	keys   *KeyManager
+	// processors 文件内容提交后由处理流水线执行的处理器
+	processors []Processor
+	// shareSecret 签发分享访问令牌的密钥
+	shareSecret ShareSecret
 	file.UnimplementedFileServiceServer
 }
 
-func NewFileServer(repo *repository.UploadRepo, minio *mws.MinioServer, worker DownloadWorker, kafka *mws.KafkaProducer) *FileServer {
-	return &FileServer{repo: repo, minio: minio, worker: worker, kafka: kafka}
+func NewFileServer(repo *repository.UploadRepo, store mws.BlobStore, worker DownloadWorker, kafka *mws.KafkaProducer,
+	keys *KeyManager, processors []Processor, shareSecret ShareSecret) *FileServer {
+	return &FileServer{repo: repo, store: store, worker: worker, kafka: kafka, keys: keys, processors: processors, shareSecret: shareSecret}
+}
+
+// BlobHandler 对象存储驱动自带的下载服务, 用于响应 local 驱动签发的预签名地址, 其他驱动返回 nil
+func (s *FileServer) BlobHandler() http.Handler {
+	return mws.BlobHandler(s.store)
 }
 
 // Upload 处理小文件上传
 func (s *FileServer) Upload(ctx context.Context, req *file.UploadRequest) (*file.UploadResponse, error) {
 	meta, data := req.GetMetadata(), req.GetData()
 
-	// 秒传
-	existFile, err := s.repo.QueryByHash(ctx, meta.Hash)
-	if err != nil {
+	// 拒绝与客户端提供的哈希不一致的内容
+	sum := hashContent(data)
+	if err := sum.verify(meta.GetHash()); err != nil {
 		return nil, err
 	}
-	if existFile.Id != 0 {
-		return &file.UploadResponse{Id: int32(existFile.Id)}, nil
-	}
 
-	// 查询容量
-	enough, err := s.repo.QueryCapacity(ctx, meta.GetUserId(), meta.GetSize())
+	metas, err := toDaoMetas(meta.GetMetadata())
 	if err != nil {
 		return nil, err
 	}
-	if !enough {
-		return nil, errors.New("you're on lower capacity")
-	}
 
-	// 存到本地
-	err = s.saveFile(meta.Path, data)
+	folderId, err := s.uploadFolderId(ctx, meta)
 	if err != nil {
 		return nil, err
 	}
 
-	// 存 OSS
-	go func() {
-		newCtx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-		_, err := s.minio.PutToBucket(newCtx, s.minio.BucketName, meta.Name, meta.Size, data)
+	// 秒传, 保险库中的内容是客户端加密的密文, 不参与
+	// 秒传直接返回已有的文件, 无法附带元数据, 提供了元数据时照常上传
+	vault, err := s.folderVault(ctx, folderId)
+	if err != nil {
+		return nil, err
+	}
+	if vault == 0 && len(metas) == 0 {
+		existFile, err := s.repo.QueryBySha256(ctx, sum.SHA256())
 		if err != nil {
-			log.Printf("failed to load oss:%s", meta.Name)
+			return nil, err
 		}
-	}()
+		if existFile.Id != 0 {
+			return &file.UploadResponse{Id: int32(existFile.Id), Sha256: existFile.Sha256}, nil
+		}
+	}
+	// 上传到共享的文件夹时, 文件属于文件夹的所有者并占用其空间
+	owner, err := s.folderOwner(ctx, meta.GetUserId(), folderId, dao.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
 
-	// 存数据库
-	f := &dao.File{
-		Name:     meta.GetName(),
-		Hash:     meta.GetHash(),
-		Type:     meta.GetContentType(),
-		Path:     meta.GetPath(),
-		Size:     meta.GetSize(),
-		UserId:   meta.GetUserId(),
-		FolderId: meta.GetFolderId(),
+	if err := s.checkFileSize(ctx, owner, meta.GetSize()); err != nil {
+		return nil, err
+	}
+
+	// 预留空间, 创建文件时转为已用空间, 其余情况下返回前释放
+	reservationId := uuid.New().String()
+	if err := s.reserveQuota(ctx, reservationId, owner, meta.GetSize()); err != nil {
+		return nil, err
+	}
+	defer s.releaseQuota(reservationId, owner)
+
+	// 按同名策略确定最终名称
+	if err := validateName(meta.GetName()); err != nil {
+		return nil, err
+	}
+	res, err := s.resolveFileInFolder(ctx, meta.GetConflictPolicy(), meta.GetName(), folderId, owner)
+	if err != nil {
+		return nil, err
+	}
+	if res.skip {
+		return &file.UploadResponse{Name: res.name, Skipped: true}, nil
 	}
+	// 每次上传使用独立的 key, 覆盖时旧内容作为历史版本保留, 放弃上传时可以安全地删除对象
+	objectKey := fmt.Sprintf("%s_%s", uuid.New().String(), res.name)
 
-	err = s.repo.CreateFile(ctx, f)
+	f := &dao.File{
+		Name:      res.name,
+		Type:      meta.GetContentType(),
+		Path:      meta.GetPath(),
+		Size:      meta.GetSize(),
+		UserId:    owner,
+		FolderId:  folderId,
+		ObjectKey: objectKey,
+		Metas:     metas,
+	}
+	sum.apply(f)
+
+	// 对象写入成功后才在同一事务中创建文件记录并提交上传
+	intent := &dao.UploadIntent{
+		Op:            dao.UploadOpCreate,
+		UserId:        owner,
+		ReservationId: reservationId,
+		Policy:        int32(meta.GetConflictPolicy()),
+	}
+	if err := s.storeObject(ctx, intent, f, data); err != nil {
+		return nil, err
+	}
+	out, err := s.commitCreate(ctx, intent, f, res)
 	if err != nil {
+		s.abortUpload(intent)
 		return nil, err
 	}
+	s.recordActivity(ctx, owner, meta.GetUserId(), dao.ActivityUpload, false, out.newId, out.name)
+	s.publishEvent(&mws.FileChangeEvent{
+		EventType: "upload",
+		FileId:    out.newId,
+		FolderId:  f.FolderId,
+		UserId:    owner,
+		Name:      out.name,
+		Size:      f.Size,
+	})
 
 	return &file.UploadResponse{
-		Id: int32(f.Id),
+		Id:      int32(out.newId),
+		Name:    out.name,
+		Version: out.version,
+		Sha256:  f.Sha256,
 	}, nil
 }
 
 // UploadChunkStream v2 分片上传, 处理流式分片上传
 func (s *FileServer) UploadChunkStream(stream file.FileService_UploadChunkStreamServer) error {
 	var uploadId string
-	var filename string
+	var filename, objectKey string
+	var blobKey *dao.BlobKey
 	var partNumber int32 = 0
-	var userId int32
+	var userId, actorId int32
 	var folderId int64
-	parts := make([]minio.CompletePart, 0)
+	var policy file.NameConflictPolicy
+	parts := make([]mws.BlobPart, 0)
+
+	// 分片按顺序到达, 边接收边计算整个文件的摘要, 完成前与客户端提供的哈希比对
+	sum := newContentHash()
+	var claimed string
+	// 预留按第一个分片声明的大小计算, 收到的内容超出时拒绝
+	var declared, received int64
+
+	// 整个流共用一个预留, 上传完成时转为已用空间, 中途失败时释放
+	reservationId := uuid.New().String()
+	var reserved bool
+	defer func() {
+		if reserved {
+			s.releaseQuota(reservationId, userId)
+		}
+	}()
+
+	// abort 放弃已初始化的分片上传, 丢弃已写入的分片
+	abort := func() {
+		if uploadId == "" {
+			return
+		}
+		if err := s.store.AbortMultipart(context.Background(), objectKey, uploadId); err != nil {
+			log.Printf("failed to abort multipart upload %s: %v", uploadId, err)
+		}
+	}
+
+	// 加密时分片的位置按第一个分片的大小计算, 须等到下一个分片到达才知道当前分片是否为最后一个, 因此延后一个分片上传
+	var pending []byte
+	uploadPending := func(last bool) error {
+		partNumber++
+		data, err := s.keys.SealPart(stream.Context(), blobKey, partNumber, pending, last)
+		if err != nil {
+			return err
+		}
+		etag, err := s.uploadPart(stream.Context(), uploadId, objectKey, partNumber, data)
+		if err != nil {
+			return err
+		}
+
+		// 保存分片信息
+		parts = append(parts, mws.BlobPart{
+			PartNumber: int(partNumber),
+			ETag:       etag,
+		})
+		return nil
+	}
 
 	for {
 		chunk, err := stream.Recv()
 		if err == io.EOF {
+			if err := sum.verify(claimed); err != nil {
+				abort()
+				return err
+			}
+			if pending != nil {
+				if err := uploadPending(true); err != nil {
+					return err
+				}
+			}
+
 			// 完成上传
-			if err := s.completeMultipartUpload(stream.Context(), uploadId, filename, parts, &dao.File{
-				Name:     filename,
-				UserId:   userId,
-				Type:     filepath.Ext(filename)[1:],
-				Path:     filename,
-				FolderId: folderId,
-			}); err != nil {
+			f := &dao.File{
+				Name:      filename,
+				UserId:    userId,
+				Type:      fileType(filename),
+				Path:      filename,
+				FolderId:  folderId,
+				ObjectKey: objectKey,
+			}
+			sum.apply(f)
+			out, err := s.completeMultipartUpload(stream.Context(), uploadId, reservationId, parts, f, blobKey, policy, "")
+			if err != nil {
 				return err
 			}
+			s.recordActivity(stream.Context(), userId, actorId, dao.ActivityUpload, false, out.newId, out.name)
+			s.publishEvent(&mws.FileChangeEvent{
+				EventType: "upload",
+				FileId:    out.newId,
+				FolderId:  f.FolderId,
+				UserId:    userId,
+				Name:      out.name,
+				Size:      f.Size,
+			})
 			return stream.SendAndClose(&file.UploadChunkResponse{
 				UploadId: uploadId,
+				Name:     out.name,
+				Sha256:   f.Sha256,
 			})
 		}
 		if err != nil {
 			return err
 		}
 
+		// 校验分片, 损坏的分片不写入对象存储
+		if err := verifyChecksum(chunk.Data, chunk.Checksum); err != nil {
+			return err
+		}
+		sum.Write(chunk.Data)
+		received += int64(len(chunk.Data))
+		if chunk.Hash != "" {
+			claimed = chunk.Hash
+		}
+
 		// 初始化上传或获取之前保存的信息
-		if partNumber == 0 {
+		if uploadId == "" {
+			if err := validateName(chunk.Filename); err != nil {
+				return err
+			}
 			filename = chunk.Filename
-			userId = chunk.UserId
+			declared = chunk.FileSize
 			folderId = chunk.FolderId
+			policy = chunk.ConflictPolicy
+			actorId = chunk.UserId
 
-			// 检查存储空间
-			enough, err := s.repo.QueryCapacity(stream.Context(), userId, chunk.FileSize)
+			// 上传到共享的文件夹时, 文件属于文件夹的所有者并占用其空间
+			userId, err = s.folderOwner(stream.Context(), chunk.UserId, folderId, dao.RoleEditor)
 			if err != nil {
 				return err
 			}
-			if !enough {
-				return errors.New("insufficient storage space")
+
+			// 检查套餐的单个文件上限并预留存储空间
+			if err := s.checkFileSize(stream.Context(), userId, chunk.FileSize); err != nil {
+				return err
+			}
+			if err := s.reserveQuota(stream.Context(), reservationId, userId, chunk.FileSize); err != nil {
+				return err
 			}
+			reserved = true
 
-			// 初始化分片上传
-			uploadId, err = s.initMultipartUpload(stream.Context(), filename)
+			// 初始化分片上传, 数据密钥在上传完成时才保存
+			objectKey, uploadId, err = s.initMultipartUpload(stream.Context(), filename)
 			if err != nil {
 				return err
 			}
+			if blobKey, err = s.keys.BeginMultipart(stream.Context(), userId, objectKey, int64(len(chunk.Data))); err != nil {
+				return err
+			}
 		}
-
-		// 上传分片
-		partNumber++
-		etag, err := s.uploadPart(stream.Context(), uploadId, filename, partNumber, chunk.Data)
-		if err != nil {
-			return err
+		if received > declared {
+			abort()
+			return ErrSizeExceeded
 		}
 
-		// 保存分片信息
-		parts = append(parts, minio.CompletePart{
-			PartNumber: int(partNumber),
-			ETag:       etag,
-		})
+		// 上传前一个分片
+		if pending != nil {
+			if err := uploadPending(false); err != nil {
+				return err
+			}
+		}
+		pending = chunk.Data
 	}
 }
 
+// maxUploadParts 分片上传的分片数上限, 与对象存储一致
+const maxUploadParts = 10000
+
 // UploadChunk v1 处理分片上传
+// 分片可能分多次请求以任意顺序到达, 无法边接收边计算整个文件的摘要, 完成时按顺序读回整个对象计算
+// 上传的目标文件夹、名称和对象由第一个分片确定并保存在会话中, 之后的分片只需携带 upload_id
 func (s *FileServer) UploadChunk(ctx context.Context, req *file.UploadChunkRequest) (*file.UploadChunkResponse, error) {
-	// 第一个分片需要初始化
-	if req.PartNumber == 1 && req.UploadId == "" {
-		// 检查存储空间
-		enough, err := s.repo.QueryCapacity(ctx, req.UserId, req.FileSize)
-		if err != nil {
-			return nil, err
-		}
-		if !enough {
-			return nil, errors.New("insufficient storage capacity")
-		}
+	if err := verifyChecksum(req.Data, req.Checksum); err != nil {
+		return nil, err
+	}
+	if req.PartNumber < 1 || req.PartNumber > maxUploadParts {
+		return nil, fmt.Errorf("%w: part number must be between 1 and %d", errInvalidItem, maxUploadParts)
+	}
 
-		// 初始化分片上传
-		uploadID, err := s.initMultipartUpload(ctx, req.Filename)
-		if err != nil {
+	// 第一个分片需要初始化, 之后的分片取回第一个分片保存的会话
+	var session dao.QuotaReservation
+	var err error
+	if req.PartNumber == 1 && req.UploadId == "" {
+		if session, err = s.beginUploadChunk(ctx, req); err != nil {
 			return nil, err
 		}
-		req.UploadId = uploadID
+		req.UploadId = session.Id
+	} else if session, err = s.checkUploadOwner(ctx, req.UploadId, req.UserId); err != nil {
+		return nil, err
 	}
 
-	// 上传分片
-	etag, err := s.uploadPart(ctx, req.UploadId, req.Filename, req.PartNumber, req.Data)
+	// 上传分片, 开启加密时分片单独加密
+	data, err := s.keys.SealPart(ctx, session.BlobKey(), req.PartNumber, req.Data, req.IsLast)
+	if err != nil {
+		return nil, err
+	}
+	etag, err := s.uploadPart(ctx, req.UploadId, session.ObjectKey, req.PartNumber, data)
 	if err != nil {
 		return nil, err
 	}
@@ -199,26 +366,59 @@ func (s *FileServer) UploadChunk(ctx context.Context, req *file.UploadChunkReque
 		if err != nil {
 			return nil, err
 		}
+		// 最后一个分片的序号即分片总数, 须在其余分片都上传后发送, 不能有超出总数的分片
+		total := int(req.PartNumber)
+		for partNumber := range etags {
+			if partNumber < 1 || partNumber > total {
+				return nil, fmt.Errorf("%w: part %d is beyond the last part %d", errInvalidItem, partNumber, total)
+			}
+		}
+		if len(etags) != total {
+			return nil, fmt.Errorf("upload %s has %d of %d parts", req.UploadId, len(etags), total)
+		}
 
 		// 构建完成分片上传请求
-		parts := make([]minio.CompletePart, len(etags))
+		parts := make([]mws.BlobPart, total)
 		for partNumber, etag := range etags {
-			parts[partNumber-1] = minio.CompletePart{
+			parts[partNumber-1] = mws.BlobPart{
 				PartNumber: partNumber,
 				ETag:       etag,
 			}
 		}
 
-		// 完成分片上传
-		if err := s.completeMultipartUpload(ctx, req.UploadId, req.Filename, parts, &dao.File{
-			Name:     req.Filename,
-			UserId:   req.UserId,
-			Type:     filepath.Ext(req.Filename)[1:],
-			Path:     req.Filename,
-			FolderId: req.FolderId,
-		}); err != nil {
+		// 完成分片上传, 文件属于会话中记录的文件夹所有者
+		f := &dao.File{
+			Name:      session.Name,
+			UserId:    session.UserId,
+			Type:      fileType(session.Name),
+			Path:      session.Name,
+			FolderId:  session.FolderId,
+			ObjectKey: session.ObjectKey,
+		}
+		// 客户端的哈希可以由第一个或最后一个分片携带
+		claimed := req.Hash
+		if claimed == "" {
+			claimed = session.Hash
+		}
+		out, err := s.completeMultipartUpload(ctx, req.UploadId, req.UploadId, parts, f, session.BlobKey(), req.ConflictPolicy, claimed)
+		if err != nil {
 			return nil, err
 		}
+		s.recordActivity(ctx, f.UserId, req.UserId, dao.ActivityUpload, false, out.newId, out.name)
+		s.publishEvent(&mws.FileChangeEvent{
+			EventType: "upload",
+			FileId:    out.newId,
+			FolderId:  f.FolderId,
+			UserId:    f.UserId,
+			Name:      out.name,
+			Size:      f.Size,
+		})
+
+		return &file.UploadChunkResponse{
+			UploadId: req.UploadId,
+			Etag:     etag,
+			Name:     out.name,
+		}, nil
 	}
 
 	return &file.UploadChunkResponse{
@@ -227,78 +427,176 @@ func (s *FileServer) UploadChunk(ctx context.Context, req *file.UploadChunkReque
 	}, nil
 }
 
-// initMultipartUpload 初始化分片上传
-func (s *FileServer) initMultipartUpload(ctx context.Context, filename string) (string, error) {
-	return s.minio.CreateMultipartUpload(ctx, s.minio.BucketName, filename)
+// beginUploadChunk 以第一个分片开始 v1 分片上传, 返回保存的会话
+// 上传到共享的文件夹时, 文件属于文件夹的所有者并占用其空间
+func (s *FileServer) beginUploadChunk(ctx context.Context, req *file.UploadChunkRequest) (dao.QuotaReservation, error) {
+	if err := validateName(req.Filename); err != nil {
+		return dao.QuotaReservation{}, err
+	}
+	owner, err := s.folderOwner(ctx, req.UserId, req.FolderId, dao.RoleEditor)
+	if err != nil {
+		return dao.QuotaReservation{}, err
+	}
+	if err := s.checkFileSize(ctx, owner, req.FileSize); err != nil {
+		return dao.QuotaReservation{}, err
+	}
+
+	// 初始化分片上传
+	objectKey, uploadId, err := s.initMultipartUpload(ctx, req.Filename)
+	if err != nil {
+		return dao.QuotaReservation{}, err
+	}
+	bk, err := s.keys.BeginMultipart(ctx, owner, objectKey, int64(len(req.Data)))
+	if err != nil {
+		return dao.QuotaReservation{}, err
+	}
+
+	// 以 upload_id 预留存储空间并保存上传的会话, 直到最后一个分片完成或调用 AbortUpload
+	session := dao.QuotaReservation{
+		Id:        uploadId,
+		UserId:    owner,
+		Size:      req.FileSize,
+		ObjectKey: objectKey,
+		ActorId:   req.UserId,
+		FolderId:  req.FolderId,
+		Name:      req.Filename,
+		Hash:      req.Hash,
+	}
+	if bk != nil {
+		session.WrappedKey, session.KekVersion, session.PartSize = bk.WrappedKey, bk.KekVersion, bk.PartSize
+	}
+	if err := s.reserveMultipart(ctx, &session); err != nil {
+		if err := s.store.AbortMultipart(ctx, objectKey, uploadId); err != nil {
+			log.Printf("failed to abort multipart upload %s: %v", uploadId, err)
+		}
+		return dao.QuotaReservation{}, err
+	}
+
+	return session, nil
+}
+
+// initMultipartUpload 为上传生成独立的对象并初始化分片上传, 返回对象的 key 和 upload id
+// 同名文件的上传互不覆盖, 放弃的上传也不会影响已有的对象
+func (s *FileServer) initMultipartUpload(ctx context.Context, filename string) (string, string, error) {
+	objectKey := fmt.Sprintf("%s_%s", uuid.New().String(), filename)
+	uploadId, err := s.store.CreateMultipart(ctx, objectKey)
+
+	return objectKey, uploadId, err
 }
 
 // uploadPart 上传分片
-func (s *FileServer) uploadPart(ctx context.Context, uploadId string, filename string, partNumber int32, data []byte) (string, error) {
-	partInfo, err := s.minio.PutObjectPart(ctx, s.minio.BucketName, filename, uploadId, int(partNumber), data, int64(len(data)))
+func (s *FileServer) uploadPart(ctx context.Context, uploadId string, objectKey string, partNumber int32, data []byte) (string, error) {
+	return s.store.PutPart(ctx, objectKey, uploadId, int(partNumber), data)
+}
+
+// completeMultipartUpload 完成 f.ObjectKey 的分片上传, 按同名策略创建文件记录, 并将预留 reservationId 转为已用空间
+// key 为上传开始时生成的数据密钥, 与文件记录在同一事务中保存
+// 上传时没能计算摘要(f.Sha256 为空)的, 读回合并后的对象计算, 与客户端提供的哈希 claimed 不一致时删除对象并放弃上传
+func (s *FileServer) completeMultipartUpload(ctx context.Context, uploadId, reservationId string, parts []mws.BlobPart, f *dao.File,
+	key *dao.BlobKey, policy file.NameConflictPolicy, claimed string) (batchOutcome, error) {
+	// 完成对象存储的分片上传
+	err := s.store.CompleteMultipart(ctx, f.ObjectKey, uploadId, parts)
 	if err != nil {
-		return "", err
+		return batchOutcome{}, err
 	}
-	return partInfo.ETag, nil
-}
 
-// completeMultipartUpload 完成分片上传
-func (s *FileServer) completeMultipartUpload(ctx context.Context, uploadId string, filename string, parts []minio.CompletePart, file *dao.File) error {
-	// 完成MinIO的分片上传
-	_, err := s.minio.CompleteMultipartUpload(ctx, s.minio.BucketName, filename, uploadId, parts)
+	// 获取文件详细信息
+	stat, err := s.store.Stat(ctx, f.ObjectKey)
 	if err != nil {
-		return err
+		return batchOutcome{}, err
 	}
 
-	// 获取文件信息
-	obj, err := s.minio.GetObject(ctx, s.minio.BucketName, filename)
+	// 更新文件大小, 加密的对象换算为明文大小
+	f.Size = stat.Size
+	if key != nil {
+		f.Size = mws.PlainSize(stat.Size)
+	}
+
+	// 预留和套餐的单个文件上限都按客户端声明的大小检查, 实际内容更大时拒绝, 否则声明较小的大小即可绕过
+	r, err := s.repo.FindQuotaReservation(ctx, reservationId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = dao.ErrUploadAborted
+	}
+	if err == nil && f.Size > r.Size {
+		err = ErrSizeExceeded
+	}
+	if err == nil {
+		err = s.checkFileSize(ctx, f.UserId, f.Size)
+	}
 	if err != nil {
-		return err
+		s.rejectUpload(f, reservationId)
+		return batchOutcome{}, err
 	}
-	defer obj.Close()
 
-	// 获取文件详细信息
-	stat, err := obj.Stat()
+	if f.Sha256 == "" {
+		if err := s.hashObject(ctx, f, key, claimed); err != nil {
+			if errors.Is(err, ErrHashMismatch) || errors.Is(err, ErrUnsupportedHash) {
+				s.rejectUpload(f, reservationId)
+			}
+			return batchOutcome{}, err
+		}
+	}
+
+	// 创建文件记录, 按同名策略跳过时对象不再需要
+	res, err := s.resolveFileInFolder(ctx, policy, f.Name, f.FolderId, f.UserId)
+	if err != nil {
+		return batchOutcome{}, err
+	}
+	if res.skip {
+		if err := s.store.Delete(ctx, f.ObjectKey); err != nil {
+			log.Printf("failed to delete skipped upload %s: %v", f.ObjectKey, err)
+		}
+	}
+
+	return s.commitFile(ctx, reservationId, f, res, key)
+}
+
+// rejectUpload 删除已完成分片上传但被拒绝的对象, 并释放预留
+func (s *FileServer) rejectUpload(f *dao.File, reservationId string) {
+	if err := s.store.Delete(context.Background(), f.ObjectKey); err != nil {
+		log.Printf("failed to delete rejected upload %s: %v", f.ObjectKey, err)
+	}
+	s.releaseQuota(reservationId, f.UserId)
+}
+
+// hashObject 读回对象计算摘要并写入 f, 与客户端提供的哈希 claimed 校验
+func (s *FileServer) hashObject(ctx context.Context, f *dao.File, key *dao.BlobKey, claimed string) error {
+	rc, err := s.keys.OpenPending(ctx, s.store, key, f.ObjectKey)
 	if err != nil {
 		return err
 	}
+	defer rc.Close()
 
-	// 更新文件大小
-	file.Size = stat.Size
+	sum := newContentHash()
+	if _, err := io.Copy(sum, rc); err != nil {
+		return err
+	}
+	if err := sum.verify(claimed); err != nil {
+		return err
+	}
+	sum.apply(f)
 
-	// 创建文件记录
-	return s.repo.CreateFile(ctx, file)
+	return nil
 }
 
 // Download 单个小文件下载
 func (s *FileServer) Download(ctx context.Context, req *file.DownloadRequest) (*file.DownloadResponse, error) {
 	// 获取文件信息
-	fileInfo, err := s.repo.GetFile(ctx, req.FileId, req.UserId)
+	fileInfo, err := s.authorizeFile(ctx, req.UserId, req.FileId, dao.RoleViewer)
 	if err != nil {
 		return nil, err
 	}
 
-	// 优先从本地文件系统读取
-	data, err := os.ReadFile(fileInfo.Path)
+	// 从对象存储读取, 加密的对象透明解密
+	rc, err := s.openContent(ctx, fileInfo, req.GetOffset(), req.GetLength())
 	if err != nil {
-		// 本地文件不存在，从minio获取
-		obj, err := s.minio.GetObject(ctx, s.minio.BucketName, fileInfo.Name)
-		if err != nil {
-			return nil, err
-		}
-		defer obj.Close()
-
-		// 获取对象信息
-		info, err := obj.Stat()
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
+	}
+	defer rc.Close()
 
-		// 读取对象数据
-		data = make([]byte, info.Size)
-		_, err = obj.Read(data)
-		if err != nil && err != io.EOF {
-			return nil, err
-		}
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
 	}
 
 	return &file.DownloadResponse{
@@ -309,25 +607,19 @@ func (s *FileServer) Download(ctx context.Context, req *file.DownloadRequest) (*
 // DownloadStream 大文件流式下载
 func (s *FileServer) DownloadStream(req *file.DownloadRequest, stream file.FileService_DownloadStreamServer) error {
 	// 获取文件信息
-	fileInfo, err := s.repo.GetFile(stream.Context(), req.FileId, req.UserId)
+	fileInfo, err := s.authorizeFile(stream.Context(), req.UserId, req.FileId, dao.RoleViewer)
 	if err != nil {
 		return err
 	}
 
-	// 尝试从本地读取
-	if f, err := os.Open(fileInfo.Path); err == nil {
-		defer f.Close()
-		err = s.streamFile(f, stream)
-	}
-
-	// 从MinIO读取
-	obj, err := s.minio.GetObject(stream.Context(), s.minio.BucketName, fileInfo.Name)
+	// 从对象存储读取, 加密的对象透明解密
+	rc, err := s.openContent(stream.Context(), fileInfo, req.GetOffset(), req.GetLength())
 	if err != nil {
 		return err
 	}
-	defer obj.Close()
+	defer rc.Close()
 
-	return s.streamFile(obj, stream)
+	return s.streamFile(rc, stream)
 }
 
 // DownloadTask 处理下载队列
@@ -339,17 +631,22 @@ func (s *FileServer) DownloadTask(ctx context.Context, req *file.DownloadTaskReq
 	var totalSize int64
 
 	for _, f := range req.Files {
-		fileInfo, err := s.repo.GetFile(ctx, f.FileId, req.UserId)
+		fileInfo, err := s.authorizeFile(ctx, req.UserId, f.FileId, dao.RoleViewer)
 		if err != nil {
 			return nil, err
 		}
+		// 下载任务直接读取对象, 在入队前检查
+		if err := s.checkScanned(ctx, fileInfo); err != nil {
+			return nil, err
+		}
 
 		downloadFiles = append(downloadFiles, &cache.DownloadedFile{
-			FileId: f.FileId,
-			Name:   fileInfo.Name,
-			Path:   f.Path,
-			Size:   fileInfo.Size,
-			Status: "pending",
+			FileId:    f.FileId,
+			Name:      fileInfo.Name,
+			ObjectKey: fileInfo.ObjectName(),
+			Path:      f.Path,
+			Size:      fileInfo.Size,
+			Status:    "pending",
 		})
 		totalSize += fileInfo.Size
 	}
@@ -434,6 +731,7 @@ func (s *FileServer) ResumeDownload(ctx context.Context, req *file.ResumeDownloa
 			remainingFiles = append(remainingFiles, &cache.DownloadedFile{
 				FileId:     f.FileId,
 				Name:       f.Name,
+				ObjectKey:  f.ObjectKey,
 				Path:       f.Path,
 				Size:       f.Size,
 				Status:     "pending",
@@ -466,8 +764,8 @@ func (s *FileServer) ResumeDownload(ctx context.Context, req *file.ResumeDownloa
 
 // UpdateFile 更新文件，包含版本冲突检测和增量更新支持
 func (s *FileServer) UpdateFile(ctx context.Context, req *file.UpdateFileRequest) (*file.UpdateFileResponse, error) {
-	// 获取当前文件信息
-	currentFile, err := s.repo.GetFile(ctx, req.FileId, req.UserId)
+	// 获取当前文件信息, 协作者须具有编辑权限
+	currentFile, err := s.authorizeFile(ctx, req.UserId, req.FileId, dao.RoleEditor)
 	if err != nil {
 		return nil, err
 	}
@@ -522,6 +820,12 @@ func (s *FileServer) UpdateFile(ctx context.Context, req *file.UpdateFileRequest
 		}, nil
 	}
 
+	// 改名与批量重命名一样校验名称, 不能与同一文件夹下的其他条目同名
+	name, err := s.updateName(ctx, currentFile, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	// 根据是否为增量更新采取不同处理策略
 	if req.IsIncremental && len(req.Changes) > 0 {
 		// 增量更新处理
@@ -564,19 +868,29 @@ func (s *FileServer) UpdateFile(ctx context.Context, req *file.UpdateFileRequest
 		}
 
 		// 获取更新后的文件
-		updatedFile, err := s.repo.GetFile(ctx, req.FileId, req.UserId)
+		updatedFile, err := s.repo.GetFile(ctx, req.FileId, currentFile.UserId)
 		if err != nil {
 			return nil, err
 		}
 
 		// 如果有文件名变更
-		if req.Name != "" && req.Name != updatedFile.Name {
-			updatedFile.Name = req.Name
+		if name != updatedFile.Name {
+			updatedFile.Name = name
 			if err := s.repo.UpdateFile(ctx, &updatedFile); err != nil {
 				return nil, err
 			}
 		}
 
+		s.recordActivity(ctx, currentFile.UserId, req.GetUserId(), dao.ActivityUpdate, false, currentFile.Id, currentFile.Name)
+		s.publishEvent(&mws.FileChangeEvent{
+			EventType: "update",
+			FileId:    updatedFile.Id,
+			FolderId:  updatedFile.FolderId,
+			UserId:    updatedFile.UserId,
+			Name:      updatedFile.Name,
+			Size:      updatedFile.Size,
+		})
+
 		// 返回更新后的文件信息
 		return &file.UpdateFileResponse{
 			File: &file.File{
@@ -618,37 +932,65 @@ func (s *FileServer) UpdateFile(ctx context.Context, req *file.UpdateFileRequest
 			}, nil
 		}
 
-		// 更新文件内容
-		err = s.saveFile(currentFile.Path, req.Data)
-		if err != nil {
+		sum := hashContent(req.Data)
+		if err := sum.verify(req.GetHash()); err != nil {
 			return nil, err
 		}
 
+		// 检查套餐的单个文件上限, 只为增加的部分预留空间, 提交时在同一事务中转为已用空间, 其余情况下返回前释放
+		newSize := int64(len(req.Data))
+		if err := s.checkFileSize(ctx, currentFile.UserId, newSize); err != nil {
+			return nil, err
+		}
+		var reservationId string
+		if grow := newSize - currentFile.Size; grow > 0 {
+			reservationId = uuid.New().String()
+			if err := s.reserveQuota(ctx, reservationId, currentFile.UserId, grow); err != nil {
+				return nil, err
+			}
+			defer s.releaseQuota(reservationId, currentFile.UserId)
+		}
+
 		// 更新文件元数据
+		sum.apply(&currentFile)
+		currentFile.Size = newSize
+		baseVersion := currentFile.Version
 		now := time.Now().Unix()
 		currentFile.Version++
 		currentFile.DeviceId = req.DeviceId
 		currentFile.LastModifiedBy = req.DeviceId
 		currentFile.Utime = now
-		if req.Name != "" {
-			currentFile.Name = req.Name
+		currentFile.Name = name
+
+		// 新内容写到新的 key 上, 提交前文件仍指向完整的旧内容; 复制出的文件和历史版本可能共用旧对象
+		currentFile.ObjectKey = fmt.Sprintf("%s_%s", uuid.New().String(), currentFile.Name)
+		intent := &dao.UploadIntent{
+			Op:            dao.UploadOpUpdate,
+			UserId:        currentFile.UserId,
+			ReservationId: reservationId,
+			FileId:        currentFile.Id,
+			BaseVersion:   baseVersion,
+		}
+		if err := s.storeObject(ctx, intent, &currentFile, req.Data); err != nil {
+			return nil, err
 		}
 
-		// 更新数据库记录
-		err = s.repo.UpdateFile(ctx, &currentFile)
+		// 更新数据库记录并提交上传
+		err = s.commitUpdate(ctx, intent, &currentFile)
 		if err != nil {
+			s.abortUpload(intent)
 			return nil, err
 		}
 
-		// 异步更新 MinIO
-		go func() {
-			newCtx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			_, err := s.minio.PutToBucket(newCtx, s.minio.BucketName, currentFile.Name, currentFile.Size, req.Data)
-			if err != nil {
-				log.Printf("failed to update oss:%s", currentFile.Name)
-			}
-		}()
+		s.recordActivity(ctx, currentFile.UserId, req.GetUserId(), dao.ActivityUpdate, false, currentFile.Id, currentFile.Name)
+		s.publishEvent(&mws.FileChangeEvent{
+			EventType: "update",
+			FileId:    currentFile.Id,
+			FolderId:  currentFile.FolderId,
+			UserId:    currentFile.UserId,
+			Name:      currentFile.Name,
+			Size:      currentFile.Size,
+		})
 
 		return &file.UpdateFileResponse{
 			File: &file.File{
@@ -662,6 +1004,7 @@ func (s *FileServer) UpdateFile(ctx context.Context, req *file.UpdateFileRequest
 				Version:        currentFile.Version,
 				DeviceId:       currentFile.DeviceId,
 				LastModifiedBy: currentFile.LastModifiedBy,
+				Sha256:         currentFile.Sha256,
 			},
 			HasConflict:    false,
 			CurrentVersion: int64(currentFile.Version),
@@ -669,6 +1012,21 @@ func (s *FileServer) UpdateFile(ctx context.Context, req *file.UpdateFileRequest
 	}
 }
 
+// updateName 计算 UpdateFile 之后文件的名称, name 为空或未变化时保持原名
+// 新名称与同一文件夹下的其他条目同名时返回 ErrNameConflict
+func (s *FileServer) updateName(ctx context.Context, f dao.File, name string) (string, error) {
+	if name == "" || name == f.Name {
+		return f.Name, nil
+	}
+	if err := validateName(name); err != nil {
+		return "", err
+	}
+
+	self := dao.NameEntry{Id: f.Id, Name: f.Name}
+	name, _, err := s.resolveInFolder(ctx, file.NameConflictPolicy_NAME_CONFLICT_FAIL, name, false, f.FolderId, f.UserId, self)
+	return name, err
+}
+
 // calculateTotalSize 计算总大小
 func calculateTotalSize(files []*cache.DownloadedFile) int64 {
 	var total int64
@@ -680,7 +1038,16 @@ func calculateTotalSize(files []*cache.DownloadedFile) int64 {
 
 // GetFile 获取文件信息
 func (s *FileServer) GetFile(ctx context.Context, req *file.GetFileRequest) (*file.GetFileResponse, error) {
-	fileInfo, err := s.repo.GetFile(ctx, req.FileId, req.UserId)
+	fileInfo, err := s.authorizeFile(ctx, req.UserId, req.FileId, dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	metas, err := s.repo.GetFileMeta(ctx, fileInfo.Id, fileInfo.UserId)
+	if err != nil {
+		return nil, err
+	}
+	procs, err := s.repo.ListFileProcesses(ctx, fileInfo.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -695,7 +1062,11 @@ func (s *FileServer) GetFile(ctx context.Context, req *file.GetFileRequest) (*fi
 			Size:     fileInfo.Size,
 			Type:     fileInfo.Type,
 			Utime:    utime,
+			Metadata: toPbMetas(metas),
+			VaultId:  fileInfo.VaultId,
+			Sha256:   fileInfo.Sha256,
 		},
+		Processing: toPbProcessingResults(procs),
 	}, nil
 }
 
@@ -713,33 +1084,70 @@ func (s *FileServer) CreateFileStore(ctx context.Context, req *file.CreateFileSt
 	return &file.CreateFileStoreResponse{Id: id}, nil
 }
 
-// CreateFolder 创建文件夹
+// CreateFolder 创建文件夹, 同名条目按 conflict_policy 处理, 跳过时返回已存在的同名文件夹
 func (s *FileServer) CreateFolder(ctx context.Context, req *file.CreateFolderRequest) (*file.CreateFolderResponse, error) {
-	folder := &dao.Folder{
-		Name:     req.GetName(),
-		UserId:   req.GetUserId(),
-		ParentId: req.GetParentId(),
+	if err := validateName(req.GetName()); err != nil {
+		return nil, err
+	}
+	// 在共享的文件夹中创建时, 新文件夹属于其所有者
+	uid, err := s.folderOwner(ctx, req.GetUserId(), req.GetParentId(), dao.RoleEditor)
+	if err != nil {
+		return nil, err
 	}
 
-	err := s.repo.CreateFolder(ctx, folder)
+	policy, err := s.vaultPolicy(ctx, req.GetConflictPolicy(), req.GetParentId())
+	if err != nil {
+		return nil, err
+	}
+	taken, err := s.listNames(ctx, req.GetParentId(), uid, dao.NameEntry{})
 	if err != nil {
 		return nil, err
 	}
-	utime := time.Unix(folder.Utime, 0).Format(time.DateTime)
+	res, err := resolveEntry(s.repo.Naming(), policy, req.GetName(), true, taken)
+	if err != nil {
+		return nil, err
+	}
+	if res.skip {
+		resp := &file.CreateFolderResponse{Skipped: true}
+		if existing := findEntry(s.repo.Naming(), taken, res.name); existing != nil && existing.IsFolder {
+			folder, err := s.repo.GetFolder(ctx, existing.Id, uid)
+			if err != nil {
+				return nil, err
+			}
+			resp.Folder = toPbFolder(folder)
+		}
+		return resp, nil
+	}
+
+	folder := &dao.Folder{
+		Name:     res.name,
+		UserId:   uid,
+		ParentId: req.GetParentId(),
+	}
+	if err := s.repo.CreateFolder(ctx, folder); err != nil {
+		return nil, conflictError(err, folder.Name)
+	}
+	s.recordActivity(ctx, uid, req.GetUserId(), dao.ActivityCreate, true, folder.Id, folder.Name)
 
-	return &file.CreateFolderResponse{Folder: &file.Folder{
-		Id:       folder.Id,
-		Name:     folder.Name,
-		UserId:   folder.UserId,
-		ParentId: folder.ParentId,
-		Path:     folder.Path,
-		Utime:    utime,
-	}}, nil
+	return &file.CreateFolderResponse{Folder: toPbFolder(*folder)}, nil
 }
 
 // ListFolder 展示文件夹及文件
 func (s *FileServer) ListFolder(ctx context.Context, req *file.ListFolderRequest) (*file.ListFolderResponse, error) {
-	fs, fds, err := s.repo.ListFolder(ctx, req.GetFolderId(), req.GetUserId())
+	owner, err := s.folderOwner(ctx, req.GetUserId(), req.GetFolderId(), dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	fs, fds, err := s.repo.ListFolder(ctx, req.GetFolderId(), owner)
+	if err != nil {
+		return nil, err
+	}
+
+	fileIds := make([]int64, 0, len(fs))
+	for _, f := range fs {
+		fileIds = append(fileIds, f.Id)
+	}
+	metas, err := s.repo.GetFilesMeta(ctx, fileIds)
 	if err != nil {
 		return nil, err
 	}
@@ -755,20 +1163,14 @@ func (s *FileServer) ListFolder(ctx context.Context, req *file.ListFolderRequest
 			FolderId: f.FolderId,
 			UserId:   f.UserId,
 			Utime:    utime,
+			Metadata: toPbMetas(metas[f.Id]),
+			VaultId:  f.VaultId,
 		})
 	}
 
 	folders := make([]*file.Folder, 0, len(fds))
 	for _, fd := range fds {
-		utime := time.Unix(fd.Utime, 0).Format(time.DateTime)
-		folders = append(folders, &file.Folder{
-			Id:       fd.Id,
-			Name:     fd.Name,
-			ParentId: fd.ParentId,
-			Path:     fd.Path,
-			UserId:   fd.UserId,
-			Utime:    utime,
-		})
+		folders = append(folders, toPbFolder(*fd))
 	}
 
 	return &file.ListFolderResponse{
@@ -777,49 +1179,151 @@ func (s *FileServer) ListFolder(ctx context.Context, req *file.ListFolderRequest
 	}, nil
 }
 
-// MoveFile 移动文件
+// MoveFile 移动文件, 目标文件夹中的同名条目按 conflict_policy 处理
 func (s *FileServer) MoveFile(ctx context.Context, req *file.MoveFileRequest) (*file.MoveFileResponse, error) {
-	err := s.repo.MoveFile(ctx, req.GetFileId(), req.GetToFolderId(), req.GetUserId())
+	f, err := s.authorizeFile(ctx, req.GetUserId(), req.GetFileId(), dao.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	toOwner, err := s.checkMoveTarget(ctx, req.GetUserId(), req.GetToFolderId(), f.UserId, f.VaultId)
+	if err != nil {
+		return nil, err
+	}
+
+	var out batchOutcome
+	if toOwner != f.UserId {
+		out, err = s.transferFile(ctx, f, req.GetToFolderId(), toOwner, req.GetConflictPolicy())
+	} else {
+		out, err = s.moveFile(ctx, f, req.GetToFolderId(), req.GetConflictPolicy())
+	}
 	if err != nil {
 		return nil, err
 	}
+	if !out.skipped {
+		if toOwner != f.UserId {
+			s.recordTransfer(ctx, f.UserId, toOwner, req.GetUserId(), false, f.Id, out.name)
+		} else if f.FolderId != req.GetToFolderId() {
+			s.recordActivity(ctx, f.UserId, req.GetUserId(), dao.ActivityMove, false, f.Id, out.name)
+		}
+	}
 
-	return &file.MoveFileResponse{}, nil
+	return &file.MoveFileResponse{Name: out.name, Skipped: out.skipped}, nil
 }
 
-// MoveFolder 移动文件夹
+// MoveFolder 移动文件夹, 可同时重命名, 目标文件夹中的同名条目按 conflict_policy 处理
 func (s *FileServer) MoveFolder(ctx context.Context, req *file.MoveFolderRequest) (*file.MoveFolderResponse, error) {
-	err := s.repo.MoveFolder(ctx, req.GetFolderId(), req.GetToFolderId(), req.GetUserId(), req.GetFolderName())
+	to := req.GetToFolderId()
+	folder, err := s.authorizeFolder(ctx, req.GetUserId(), req.GetFolderId(), dao.RoleEditor)
 	if err != nil {
 		return nil, err
 	}
+	uid := folder.UserId
+
+	name := folder.Name
+	if req.GetFolderName() != "" {
+		if err := validateName(req.GetFolderName()); err != nil {
+			return nil, err
+		}
+		name = dao.NormalizeName(req.GetFolderName())
+	}
+
+	if folder.ParentId != to || name != folder.Name {
+		if err := checkSpaceRoot(folder); err != nil {
+			return nil, err
+		}
+		toOwner, err := s.checkMoveTarget(ctx, req.GetUserId(), to, uid, folderMoveVault(folder))
+		if err != nil {
+			return nil, err
+		}
+		if toOwner != uid {
+			moved, skip, err := s.transferFolder(ctx, folder, to, toOwner, name, req.GetConflictPolicy())
+			if err != nil || skip {
+				return &file.MoveFolderResponse{Skipped: skip}, err
+			}
+			s.recordTransfer(ctx, uid, toOwner, req.GetUserId(), true, moved.Id, moved.Name)
+			return &file.MoveFolderResponse{Folder: toPbFolder(moved)}, nil
+		}
+
+		// 在原文件夹内改名时, 自身的名称不算冲突
+		var self dao.NameEntry
+		if folder.ParentId == to {
+			self = dao.NameEntry{Id: folder.Id, Name: folder.Name, IsFolder: true}
+		}
+		var skip bool
+		name, skip, err = s.resolveInFolder(ctx, req.GetConflictPolicy(), name, true, to, uid, self)
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			return &file.MoveFolderResponse{Skipped: true}, nil
+		}
+
+		action := dao.ActivityMove
+		if folder.ParentId == to {
+			action = dao.ActivityRename
+		}
+		folder, err = s.repo.MoveFolder(ctx, folder.Id, to, uid, name)
+		if err != nil {
+			return nil, conflictError(err, name)
+		}
+		s.recordActivity(ctx, uid, req.GetUserId(), action, true, folder.Id, folder.Name)
+	}
 
-	return &file.MoveFolderResponse{}, nil
+	return &file.MoveFolderResponse{Folder: toPbFolder(folder)}, nil
 }
 
 // DeleteFile 删除文件
 func (s *FileServer) DeleteFile(ctx context.Context, req *file.DeleteFileRequest) (*file.DeleteFileResponse, error) {
-	err := s.repo.DeleteFile(ctx, req.GetFileId(), req.GetUserId())
+	f, err := s.authorizeFile(ctx, req.GetUserId(), req.GetFileId(), dao.RoleEditor)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.repo.DeleteFile(ctx, f.Id, f.UserId); err != nil {
+		return nil, err
+	}
+	s.recordActivity(ctx, f.UserId, req.GetUserId(), dao.ActivityDelete, false, f.Id, f.Name)
 
 	return &file.DeleteFileResponse{}, nil
 }
 
 // DeleteFolder 删除文件夹
 func (s *FileServer) DeleteFolder(ctx context.Context, req *file.DeleteFolderRequest) (*file.DeleteFolderResponse, error) {
-	err := s.repo.DeleteFolder(ctx, req.GetFolderId(), req.GetUserId())
+	folder, err := s.authorizeFolder(ctx, req.GetUserId(), req.GetFolderId(), dao.RoleEditor)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkSpaceRoot(folder); err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteFolder(ctx, folder.Id, folder.UserId); err != nil {
+		return nil, err
+	}
+	s.recordActivity(ctx, folder.UserId, req.GetUserId(), dao.ActivityDelete, true, folder.Id, folder.Name)
 
 	return &file.DeleteFolderResponse{}, nil
 }
 
-// Search 搜索文件和文件夹
+// Search 搜索文件和文件夹, 指定 folder_id 时搜索用户有查看权限的该文件夹的子树
 func (s *FileServer) Search(ctx context.Context, req *file.SearchRequest) (*file.SearchResponse, error) {
-	fs, fds, err := s.repo.Search(ctx, req.GetUserId(), req.GetQuery(), req.GetPage(), req.GetSize())
+	query, filters, err := parseSearchQuery(req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	root, err := s.pathRoot(ctx, req.GetUserId(), req.GetFolderId(), dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, fds, err := s.repo.Search(ctx, root.UserId, root, query, filters, req.GetPage(), req.GetSize())
+	if err != nil {
+		return nil, err
+	}
+
+	fileIds := make([]int64, 0, len(fs))
+	for _, f := range fs {
+		fileIds = append(fileIds, f.Id)
+	}
+	metas, err := s.repo.GetFilesMeta(ctx, fileIds)
 	if err != nil {
 		return nil, err
 	}
@@ -835,20 +1339,13 @@ func (s *FileServer) Search(ctx context.Context, req *file.SearchRequest) (*file
 			FolderId: f.FolderId,
 			UserId:   f.UserId,
 			Utime:    utime,
+			Metadata: toPbMetas(metas[f.Id]),
 		})
 	}
 
 	folders := make([]*file.Folder, 0, len(fds))
 	for _, fd := range fds {
-		utime := time.Unix(fd.Utime, 0).Format(time.DateTime)
-		folders = append(folders, &file.Folder{
-			Id:       fd.Id,
-			Name:     fd.Name,
-			ParentId: fd.ParentId,
-			Path:     fd.Path,
-			UserId:   fd.UserId,
-			Utime:    utime,
-		})
+		folders = append(folders, toPbFolder(fd))
 	}
 
 	return &file.SearchResponse{Files: files, Folders: folders}, nil
@@ -857,10 +1354,17 @@ func (s *FileServer) Search(ctx context.Context, req *file.SearchRequest) (*file
 // Preview 预览
 func (s *FileServer) Preview(ctx context.Context, req *file.PreviewRequest) (*file.PreviewResponse, error) {
 	// 获取文件信息
-	fileInfo, err := s.repo.GetFile(ctx, req.FileId, req.UserId)
+	fileInfo, err := s.authorizeFile(ctx, req.UserId, req.FileId, dao.RoleViewer)
 	if err != nil {
 		return nil, err
 	}
+	// 保险库中的内容只有客户端能解密
+	if fileInfo.VaultId != 0 {
+		return nil, ErrVaultContent
+	}
+	if err := s.checkScanned(ctx, fileInfo); err != nil {
+		return nil, err
+	}
 
 	// 判断文件类型
 	previewType := s.getPreviewType(fileInfo.Type)
@@ -869,14 +1373,14 @@ func (s *FileServer) Preview(ctx context.Context, req *file.PreviewRequest) (*fi
 	}
 
 	// 生成预览URL
-	presignedURL, err := s.minio.PresignedGetObject(ctx, s.minio.BucketName, fileInfo.Name, time.Hour)
+	previewURL, err := s.previewURL(ctx, fileInfo.ObjectName(), fmt.Sprintf("/api/files/download/%d?inline=1", fileInfo.Id))
 	if err != nil {
 		return nil, err
 	}
 
 	// 设置预览相关的参数
 	return &file.PreviewResponse{
-		PreviewUrl:  presignedURL.String(),
+		PreviewUrl:  previewURL,
 		ContentType: s.getMimeType(fileInfo.Type),
 		Type:        previewType,
 	}, nil
@@ -884,17 +1388,42 @@ func (s *FileServer) Preview(ctx context.Context, req *file.PreviewRequest) (*fi
 
 // CreateShareLink 创建分享链接
 func (s *FileServer) CreateShareLink(ctx context.Context, req *file.CreateShareLinkRequest) (*file.CreateShareLinkResponse, error) {
+	if req.GetMaxDownloads() < 0 || req.GetMaxSaves() < 0 {
+		return nil, errors.New("invalid share limits")
+	}
+	if err := s.checkShareVault(ctx, req.GetFolderId(), req.GetFileIds()); err != nil {
+		return nil, err
+	}
+	// 尚未扫描通过的文件不能分享, 文件夹分享中的此类文件在访问时拦截
+	for _, id := range req.GetFileIds() {
+		f, err := s.repo.FindFile(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkScanned(ctx, f); err != nil {
+			return nil, err
+		}
+	}
+
 	shareId := uuid.New().String()
 	expireAt := time.Now().AddDate(0, 0, int(req.ExpireDays))
 
+	// 提取密码只保存哈希
+	password, err := dao.HashSharePassword(req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
 	// 创建分享记录
 	share := &dao.ShareLink{
-		Id:       shareId,
-		UserId:   req.UserId,
-		FolderId: req.FolderId,
-		Password: req.Password,
-		ExpireAt: expireAt,
-		Status:   1,
+		Id:           shareId,
+		UserId:       req.UserId,
+		FolderId:     req.FolderId,
+		Password:     password,
+		ExpireAt:     expireAt,
+		Status:       1,
+		MaxDownloads: req.GetMaxDownloads(),
+		MaxSaves:     req.GetMaxSaves(),
 	}
 
 	// 如果是分享文件，创建文件关联
@@ -915,117 +1444,26 @@ func (s *FileServer) CreateShareLink(ctx context.Context, req *file.CreateShareL
 		return nil, err
 	}
 
-	// 生成分享链接
-	shareUrl := fmt.Sprintf("%s/share/%s", "", shareId)
-
 	return &file.CreateShareLinkResponse{
 		ShareId:  shareId,
-		ShareUrl: shareUrl,
+		ShareUrl: shareURL(shareId),
 		Password: req.Password,
 		ExpireAt: expireAt.Unix(),
 	}, nil
 }
 
-// SaveToMyDrive 保存到个人网盘
-func (s *FileServer) SaveToMyDrive(ctx context.Context, req *file.SaveToMyDriveRequest) (*file.SaveToMyDriveResponse, error) {
-	// 验证分享是否有效
-	share, err := s.repo.GetShareLink(ctx, req.ShareId)
-	if err != nil {
-		return nil, err
-	}
-
-	// 检查密码
-	if share.Password != "" && share.Password != req.Password {
-		return nil, errors.New("invalid password")
-	}
-
-	// 检查过期时间
-	if share.ExpireAt.Before(time.Now()) {
-		return nil, errors.New("share link expired")
-	}
-
-	// 获取要保存的文件列表
-	var files []*dao.File
-	var folders []*dao.Folder
-	if share.FolderId != 0 {
-		// 如果是文件夹分享，获取文件夹下的所有文件
-		files, folders, err = s.repo.ListFolder(ctx, req.GetToFolderId(), req.GetUserId())
-	} else {
-		// 如果是文件分享，获取选中的文件
-		files, err = s.repo.GetFilesByIds(ctx, req.FileIds)
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	// 检查存储空间
-	var totalSize int64
-	for _, f := range files {
-		totalSize += f.Size
-	}
-	enough, err := s.repo.QueryCapacity(ctx, req.UserId, totalSize)
-	if err != nil || !enough {
-		return nil, errors.New("insufficient storage space")
-	}
-
-	// 复制文件到用户的网盘
-	for _, f := range files {
-		newFile := &dao.File{
-			UserId:   req.UserId,
-			Name:     f.Name,
-			Hash:     f.Hash,
-			Type:     f.Type,
-			Size:     f.Size,
-			FolderId: req.ToFolderId,
-			Path:     f.Path,
-		}
-		if err := s.repo.CreateFile(ctx, newFile); err != nil {
-			return nil, err
-		}
-	}
-
-	for _, fd := range folders {
-		newFolder := &dao.Folder{
-			Id:       fd.Id,
-			Name:     fd.Name,
-			ParentId: fd.ParentId,
-			UserId:   req.GetUserId(),
-			Path:     fd.Path,
-			Status:   0,
-		}
-		if err := s.repo.CreateFolder(ctx, newFolder); err != nil {
-			return nil, err
-		}
-	}
-
-	return &file.SaveToMyDriveResponse{}, nil
-}
-
 // GetUserFileStore 获取用户资源空间信息
 func (s *FileServer) GetUserFileStore(ctx context.Context, req *file.GetUserFileStoreRequest) (*file.GetUserFileStoreResponse, error) {
 	store, err := s.repo.FindFileStoreById(ctx, req.GetUserId())
 	if err != nil {
 		return nil, err
 	}
-
-	return &file.GetUserFileStoreResponse{FileStore: &file.FileStore{
-		UserId:      store.UserId,
-		Capacity:    store.Capacity,
-		CurrentSize: store.CurrentSize,
-	}}, nil
-}
-
-func (s *FileServer) saveFile(path string, data []byte) error {
-	newFile, err := os.Create(path)
+	plan, err := s.repo.GetUserPlan(ctx, req.GetUserId())
 	if err != nil {
-		return err
-	}
-	_, err = newFile.Write(data)
-	newFile.Close()
-	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+
+	return &file.GetUserFileStoreResponse{FileStore: toPbFileStore(store), Plan: toPbPlan(plan)}, nil
 }
 
 func (s *FileServer) streamFile(r io.Reader, stream file.FileService_DownloadStreamServer) error {
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+const (
+	maxMetaKeys     = 64
+	maxMetaValueLen = 1024
+)
+
+var (
+	metaKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+	// meta.client = "acme" / meta.score >= 3 / meta.due < 2026-01-01
+	metaFilterPattern = regexp.MustCompile(`meta\.([A-Za-z0-9_.-]+)\s*(!=|>=|<=|=|>|<)\s*("(?:[^"\\]|\\.)*"|\S+)`)
+)
+
+// GetFileMeta 获取文件自定义元数据
+func (s *FileServer) GetFileMeta(ctx context.Context, req *file.GetFileMetaRequest) (*file.GetFileMetaResponse, error) {
+	f, err := s.authorizeFile(ctx, req.GetUserId(), req.GetFileId(), dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	metas, err := s.repo.GetFileMeta(ctx, f.Id, f.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.GetFileMetaResponse{Metadata: toPbMetas(metas)}, nil
+}
+
+// SetFileMeta 设置文件自定义元数据
+func (s *FileServer) SetFileMeta(ctx context.Context, req *file.SetFileMetaRequest) (*file.SetFileMetaResponse, error) {
+	metas, err := toDaoMetas(req.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := s.authorizeFile(ctx, req.GetUserId(), req.GetFileId(), dao.RoleCommenter)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := s.repo.GetFileMeta(ctx, f.Id, f.UserId)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]struct{}, len(exists)+len(metas))
+	for _, m := range exists {
+		keys[m.MetaKey] = struct{}{}
+	}
+	for _, m := range metas {
+		keys[m.MetaKey] = struct{}{}
+	}
+	if len(keys) > maxMetaKeys {
+		return nil, fmt.Errorf("too many metadata keys, at most %d", maxMetaKeys)
+	}
+
+	if err := s.repo.SetFileMeta(ctx, f.Id, f.UserId, metas); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.GetFileMeta(ctx, f.Id, f.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.SetFileMetaResponse{Metadata: toPbMetas(res)}, nil
+}
+
+// DeleteFileMeta 删除文件自定义元数据
+func (s *FileServer) DeleteFileMeta(ctx context.Context, req *file.DeleteFileMetaRequest) (*file.DeleteFileMetaResponse, error) {
+	f, err := s.authorizeFile(ctx, req.GetUserId(), req.GetFileId(), dao.RoleCommenter)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteFileMeta(ctx, f.Id, f.UserId, req.GetKeys()); err != nil {
+		return nil, err
+	}
+
+	return &file.DeleteFileMetaResponse{}, nil
+}
+
+// toDaoMetas 校验并转换 protobuf 元数据
+func toDaoMetas(metadata map[string]*file.MetaValue) ([]dao.FileMeta, error) {
+	if len(metadata) > maxMetaKeys {
+		return nil, fmt.Errorf("too many metadata keys, at most %d", maxMetaKeys)
+	}
+
+	metas := make([]dao.FileMeta, 0, len(metadata))
+	for k, v := range metadata {
+		if !metaKeyPattern.MatchString(k) {
+			return nil, fmt.Errorf("invalid metadata key: %q", k)
+		}
+
+		m := dao.FileMeta{MetaKey: k}
+		switch val := v.GetValue().(type) {
+		case *file.MetaValue_StringValue:
+			if len(val.StringValue) > maxMetaValueLen {
+				return nil, fmt.Errorf("metadata value of %q is too long", k)
+			}
+			m.Type = dao.MetaString
+			m.StringValue = val.StringValue
+		case *file.MetaValue_NumberValue:
+			m.Type = dao.MetaNumber
+			m.NumberValue = val.NumberValue
+		case *file.MetaValue_DateValue:
+			m.Type = dao.MetaDate
+			m.DateValue = val.DateValue
+		case *file.MetaValue_BoolValue:
+			m.Type = dao.MetaBool
+			m.BoolValue = val.BoolValue
+		default:
+			return nil, fmt.Errorf("metadata value of %q is empty", k)
+		}
+		metas = append(metas, m)
+	}
+
+	return metas, nil
+}
+
+// toPbMetas 将数据库元数据转换为 protobuf 格式
+func toPbMetas(metas []dao.FileMeta) map[string]*file.MetaValue {
+	if len(metas) == 0 {
+		return nil
+	}
+
+	res := make(map[string]*file.MetaValue, len(metas))
+	for _, m := range metas {
+		switch m.Type {
+		case dao.MetaNumber:
+			res[m.MetaKey] = &file.MetaValue{Value: &file.MetaValue_NumberValue{NumberValue: m.NumberValue}}
+		case dao.MetaDate:
+			res[m.MetaKey] = &file.MetaValue{Value: &file.MetaValue_DateValue{DateValue: m.DateValue}}
+		case dao.MetaBool:
+			res[m.MetaKey] = &file.MetaValue{Value: &file.MetaValue_BoolValue{BoolValue: m.BoolValue}}
+		default:
+			res[m.MetaKey] = &file.MetaValue{Value: &file.MetaValue_StringValue{StringValue: m.StringValue}}
+		}
+	}
+
+	return res
+}
+
+// parseSearchQuery 从搜索词中拆出元数据过滤条件, 剩余部分作为文件名关键字
+func parseSearchQuery(query string) (string, []dao.MetaFilter, error) {
+	matches := metaFilterPattern.FindAllStringSubmatch(query, -1)
+	if len(matches) == 0 {
+		return strings.TrimSpace(query), nil, nil
+	}
+
+	filters := make([]dao.MetaFilter, 0, len(matches))
+	for _, m := range matches {
+		f := dao.MetaFilter{Key: m[1], Op: m[2]}
+		raw := m[3]
+
+		switch {
+		case strings.HasPrefix(raw, `"`):
+			str, err := strconv.Unquote(raw)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid meta filter value: %s", raw)
+			}
+			f.Type, f.Value = dao.MetaString, str
+		case raw == "true" || raw == "false":
+			f.Type, f.Value = dao.MetaBool, raw == "true"
+		default:
+			if num, err := strconv.ParseFloat(raw, 64); err == nil {
+				f.Type, f.Value = dao.MetaNumber, num
+			} else if t, ok := parseMetaDate(raw); ok {
+				f.Type, f.Value = dao.MetaDate, t.Unix()
+			} else {
+				f.Type, f.Value = dao.MetaString, raw
+			}
+		}
+
+		if f.Type == dao.MetaBool && f.Op != "=" && f.Op != "!=" {
+			return "", nil, errors.New("bool metadata only supports = and !=")
+		}
+		filters = append(filters, f)
+	}
+
+	// 过滤条件之间允许用 AND 连接
+	words := make([]string, 0)
+	for _, w := range strings.Fields(metaFilterPattern.ReplaceAllString(query, " ")) {
+		if !strings.EqualFold(w, "and") {
+			words = append(words, w)
+		}
+	}
+
+	return strings.Join(words, " "), filters, nil
+}
+
+func parseMetaDate(raw string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
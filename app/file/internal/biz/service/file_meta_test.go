@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+func TestUploadKeepsMetadata(t *testing.T) {
+	s, _, _ := newUploadTestServer(t)
+	ctx := context.Background()
+
+	data := []byte("deliverable")
+	first, err := s.Upload(ctx, uploadRequest("a.txt", data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 相同内容附带元数据时不走秒传
+	req := uploadRequest("b.txt", data)
+	req.Metadata.Metadata = map[string]*file.MetaValue{
+		"client": {Value: &file.MetaValue_StringValue{StringValue: "acme"}},
+	}
+	second, err := s.Upload(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.GetId() == first.GetId() {
+		t.Fatal("upload with metadata was deduplicated onto an existing file")
+	}
+	assertClient(t, s, int64(second.GetId()))
+
+	// 覆盖产生新版本, 元数据随文件保留
+	req = uploadRequest("b.txt", []byte("revised deliverable"))
+	req.Metadata.ConflictPolicy = file.NameConflictPolicy_NAME_CONFLICT_OVERWRITE
+	third, err := s.Upload(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.GetId() != second.GetId() || third.GetVersion() < 2 {
+		t.Fatalf("overwrite returned file %d version %d, want file %d with a new version", third.GetId(), third.GetVersion(), second.GetId())
+	}
+	assertClient(t, s, int64(third.GetId()))
+}
+
+func assertClient(t *testing.T, s *FileServer, fileId int64) {
+	t.Helper()
+	resp, err := s.GetFileMeta(context.Background(), &file.GetFileMetaRequest{FileId: fileId, UserId: testUser})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetMetadata()["client"].GetStringValue(); got != "acme" {
+		t.Fatalf("metadata of file %d: client = %q, want acme", fileId, got)
+	}
+}
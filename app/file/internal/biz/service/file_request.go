@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/config"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// maxUploaderName 上传者名字的最大长度
+const maxUploaderName = 64
+
+var (
+	// ErrExtNotAllowed 文件类型不在文件收集链接允许的范围内
+	ErrExtNotAllowed = errors.New("file type is not allowed by the file request")
+	// ErrInvalidUploader 上传者名字为空或过长
+	ErrInvalidUploader = errors.New("invalid uploader name")
+)
+
+// CreateFileRequest 创建文件收集链接, 需要对目标文件夹具有编辑权限
+func (s *FileServer) CreateFileRequest(ctx context.Context, req *file.CreateFileRequestRequest) (*file.CreateFileRequestResponse, error) {
+	if req.GetMaxFileSize() < 0 || req.GetMaxFiles() < 0 || req.GetMaxTotalSize() < 0 {
+		return nil, errors.New("invalid file request limits")
+	}
+	if utf8.RuneCountInString(req.GetTitle()) > 255 {
+		return nil, errors.New("title too long")
+	}
+	if req.GetExpireAt() != 0 && req.GetExpireAt() <= time.Now().Unix() {
+		return nil, errors.New("deadline must be in the future")
+	}
+	if _, err := s.folderOwner(ctx, req.GetUserId(), req.GetFolderId(), dao.RoleEditor); err != nil {
+		return nil, err
+	}
+	// 匿名上传的是明文, 不能收集到保险库中
+	if err := s.checkVaultTarget(ctx, req.GetFolderId(), 0); err != nil {
+		return nil, err
+	}
+
+	password, err := dao.HashSharePassword(req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	exts := normalizeExts(req.GetAllowedExts())
+	if len(exts) > 255 {
+		return nil, errors.New("too many allowed extensions")
+	}
+
+	fr := &dao.FileRequest{
+		Id:           uuid.New().String(),
+		UserId:       req.GetUserId(),
+		FolderId:     req.GetFolderId(),
+		Title:        strings.TrimSpace(req.GetTitle()),
+		Password:     password,
+		ExpireAt:     req.GetExpireAt(),
+		MaxFileSize:  req.GetMaxFileSize(),
+		MaxFiles:     req.GetMaxFiles(),
+		MaxTotalSize: req.GetMaxTotalSize(),
+		AllowedExts:  exts,
+	}
+	if err := s.repo.CreateFileRequest(ctx, fr); err != nil {
+		return nil, err
+	}
+
+	return &file.CreateFileRequestResponse{Request: toPbFileRequest(*fr)}, nil
+}
+
+// ListFileRequests 按创建时间倒序分页获取用户创建的文件收集链接
+func (s *FileServer) ListFileRequests(ctx context.Context, req *file.ListFileRequestsRequest) (*file.ListFileRequestsResponse, error) {
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	reqs, total, err := s.repo.ListFileRequests(ctx, req.GetUserId(), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListFileRequestsResponse{Total: total, Requests: make([]*file.FileRequestInfo, 0, len(reqs))}
+	for _, fr := range reqs {
+		resp.Requests = append(resp.Requests, toPbFileRequest(fr))
+	}
+
+	return resp, nil
+}
+
+// CloseFileRequests 关闭文件收集链接, 已上传的文件不受影响
+func (s *FileServer) CloseFileRequests(ctx context.Context, req *file.CloseFileRequestsRequest) (*file.CloseFileRequestsResponse, error) {
+	if len(req.GetRequestIds()) == 0 {
+		return &file.CloseFileRequestsResponse{}, nil
+	}
+
+	n, err := s.repo.CloseFileRequests(ctx, req.GetUserId(), req.GetRequestIds())
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.CloseFileRequestsResponse{Closed: n}, nil
+}
+
+// ListFileRequestUploads 分页获取文件收集链接的上传记录, 仅创建者可见
+func (s *FileServer) ListFileRequestUploads(ctx context.Context, req *file.ListFileRequestUploadsRequest) (*file.ListFileRequestUploadsResponse, error) {
+	if _, err := s.repo.GetUserFileRequest(ctx, req.GetRequestId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	ups, total, err := s.repo.ListFileRequestUploads(ctx, req.GetRequestId(), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListFileRequestUploadsResponse{Total: total, Uploads: make([]*file.FileRequestUpload, 0, len(ups))}
+	for _, up := range ups {
+		resp.Uploads = append(resp.Uploads, &file.FileRequestUpload{
+			Id:       up.Id,
+			FileId:   up.FileId,
+			Name:     up.Name,
+			Uploader: up.Uploader,
+			Size:     up.Size,
+			Ip:       up.Ip,
+			Ctime:    up.Ctime,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetPublicFileRequest 获取上传页面展示的信息, 无需登录
+func (s *FileServer) GetPublicFileRequest(ctx context.Context, req *file.GetPublicFileRequestRequest) (*file.GetPublicFileRequestResponse, error) {
+	fr, err := s.repo.GetFileRequest(ctx, req.GetRequestId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.GetPublicFileRequestResponse{Request: &file.PublicFileRequest{
+		Title:        fr.Title,
+		HasPassword:  fr.Password != "",
+		ExpireAt:     fr.ExpireAt,
+		MaxFileSize:  fr.MaxFileSize,
+		MaxFiles:     fr.MaxFiles,
+		MaxTotalSize: fr.MaxTotalSize,
+		AllowedExts:  splitExts(fr.AllowedExts),
+	}}, nil
+}
+
+// SubmitFileRequest 匿名上传文件到文件收集链接的目标文件夹
+// 文件名前加上上传者的名字, 同名时自动重命名, 文件属于目标文件夹的所有者并占用其空间, 上传后通知链接的创建者
+func (s *FileServer) SubmitFileRequest(ctx context.Context, req *file.SubmitFileRequestRequest) (*file.SubmitFileRequestResponse, error) {
+	fr, err := s.repo.GetFileRequest(ctx, req.GetRequestId())
+	if err != nil {
+		return nil, err
+	}
+	if !dao.CheckSharePassword(fr.Password, req.GetPassword()) {
+		return nil, ErrInvalidSharePassword
+	}
+
+	uploader := strings.TrimSpace(strings.ReplaceAll(req.GetUploader(), "/", "_"))
+	if uploader == "" || utf8.RuneCountInString(uploader) > maxUploaderName {
+		return nil, ErrInvalidUploader
+	}
+	if err := validateName(req.GetName()); err != nil {
+		return nil, err
+	}
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(req.GetName())), ".")
+	if fr.AllowedExts != "" && !slices.Contains(splitExts(fr.AllowedExts), ext) {
+		return nil, ErrExtNotAllowed
+	}
+	data := req.GetData()
+	size := int64(len(data))
+	sum := hashContent(data)
+	if err := sum.verify(req.GetHash()); err != nil {
+		return nil, err
+	}
+	if fr.MaxFileSize > 0 && size > fr.MaxFileSize {
+		return nil, dao.ErrFileTooLarge
+	}
+
+	// 目标文件夹可能在创建链接后被删除或转移到其他网盘
+	owner := fr.UserId
+	if fr.FolderId != 0 {
+		folder, err := s.repo.FindFolder(ctx, fr.FolderId)
+		if err != nil {
+			return nil, err
+		}
+		owner = folder.UserId
+	}
+	if err := s.checkFileSize(ctx, owner, size); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AcquireFileRequestSlot(ctx, fr.Id, size); err != nil {
+		return nil, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			if err := s.repo.ReleaseFileRequestSlot(context.Background(), fr.Id, size); err != nil {
+				log.Printf("failed to release slot of file request %s: %v", fr.Id, err)
+			}
+		}
+	}()
+
+	reservationId := uuid.New().String()
+	if err := s.reserveQuota(ctx, reservationId, owner, size); err != nil {
+		return nil, err
+	}
+	defer s.releaseQuota(reservationId, owner)
+
+	name := fmt.Sprintf("%s - %s", uploader, dao.NormalizeName(req.GetName()))
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	res, err := s.resolveFileInFolder(ctx, file.NameConflictPolicy_NAME_CONFLICT_RENAME, name, fr.FolderId, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	// 匿名上传的对象使用独立的 key, 不会覆盖所有者已有的对象
+	objectKey := fmt.Sprintf("%s_%s", uuid.New().String(), res.name)
+	f := &dao.File{
+		Name:           res.name,
+		Type:           ext,
+		Path:           res.name,
+		Size:           size,
+		UserId:         owner,
+		FolderId:       fr.FolderId,
+		ObjectKey:      objectKey,
+		LastModifiedBy: uploader,
+	}
+	sum.apply(f)
+	intent := &dao.UploadIntent{
+		Op:            dao.UploadOpRequest,
+		UserId:        owner,
+		ReservationId: reservationId,
+		RequestId:     fr.Id,
+	}
+	if err := s.storeObject(ctx, intent, f, data); err != nil {
+		return nil, err
+	}
+	out, err := s.commitCreate(ctx, intent, f, res)
+	if err != nil {
+		s.abortUpload(intent)
+		return nil, err
+	}
+	committed = true
+
+	err = s.repo.RecordFileRequestUpload(ctx, &dao.FileRequestUpload{
+		RequestId: fr.Id,
+		FileId:    out.newId,
+		Name:      out.name,
+		Uploader:  uploader,
+		Size:      size,
+		Ip:        req.GetClientIp(),
+	})
+	if err != nil {
+		log.Printf("failed to record upload of file request %s: %v", fr.Id, err)
+	}
+	s.recordActivity(ctx, owner, fr.UserId, dao.ActivityUpload, false, out.newId, out.name)
+	s.publishEvent(&mws.FileChangeEvent{
+		EventType: "file_request_upload",
+		FileId:    out.newId,
+		FolderId:  fr.FolderId,
+		UserId:    fr.UserId,
+		Name:      out.name,
+		Size:      size,
+		FileRequest: &mws.FileRequestEvent{
+			RequestId: fr.Id,
+			Title:     fr.Title,
+			Uploader:  uploader,
+		},
+	})
+
+	return &file.SubmitFileRequestResponse{Name: out.name}, nil
+}
+
+// normalizeExts 将允许的扩展名规范为去掉点号的小写形式, 以逗号连接
+func normalizeExts(exts []string) string {
+	res := make([]string, 0, len(exts))
+	for _, e := range exts {
+		e = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(e)), ".")
+		if e != "" && !strings.Contains(e, ",") && !slices.Contains(res, e) {
+			res = append(res, e)
+		}
+	}
+
+	return strings.Join(res, ",")
+}
+
+func splitExts(exts string) []string {
+	if exts == "" {
+		return nil
+	}
+
+	return strings.Split(exts, ",")
+}
+
+func fileRequestURL(id string) string {
+	return fmt.Sprintf("%s/request/%s", strings.TrimSuffix(config.GetConf().Server.BaseURL, "/"), id)
+}
+
+func toPbFileRequest(fr dao.FileRequest) *file.FileRequestInfo {
+	return &file.FileRequestInfo{
+		RequestId:    fr.Id,
+		Url:          fileRequestURL(fr.Id),
+		FolderId:     fr.FolderId,
+		Title:        fr.Title,
+		HasPassword:  fr.Password != "",
+		ExpireAt:     fr.ExpireAt,
+		MaxFileSize:  fr.MaxFileSize,
+		MaxFiles:     fr.MaxFiles,
+		MaxTotalSize: fr.MaxTotalSize,
+		AllowedExts:  splitExts(fr.AllowedExts),
+		Status:       int32(fr.Status),
+		FileCount:    fr.FileCount,
+		TotalSize:    fr.TotalSize,
+		Ctime:        fr.Ctime,
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// GetFolderTree 获取以 root_id 为根的文件夹树, 每个节点带有子树的聚合统计, 需要对 root_id 有查看权限
+func (s *FileServer) GetFolderTree(ctx context.Context, req *file.GetFolderTreeRequest) (*file.GetFolderTreeResponse, error) {
+	uid := req.GetUserId()
+
+	var root dao.Folder
+	if req.GetRootId() == 0 {
+		stats, err := s.repo.GetRootStats(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		root = dao.Folder{
+			UserId:       uid,
+			TotalSize:    stats.TotalSize,
+			FileCount:    stats.FileCount,
+			LastModified: stats.LastModified,
+		}
+	} else {
+		var err error
+		root, err = s.authorizeFolder(ctx, uid, req.GetRootId(), dao.RoleViewer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	folders, truncated, err := s.repo.GetFolderTree(ctx, root.Id, root.UserId, int(req.GetDepth()))
+	if err != nil {
+		return nil, err
+	}
+
+	// 文件夹按层返回, 父节点总是先于子节点出现
+	rootNode := &file.FolderNode{Folder: toPbFolder(root)}
+	nodes := map[int64]*file.FolderNode{root.Id: rootNode}
+	for _, f := range folders {
+		node := &file.FolderNode{Folder: toPbFolder(f)}
+		nodes[f.Id] = node
+		if parent, ok := nodes[f.ParentId]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	return &file.GetFolderTreeResponse{Root: rootNode, Truncated: truncated}, nil
+}
+
+func toPbFolder(f dao.Folder) *file.Folder {
+	folder := &file.Folder{
+		Id:        f.Id,
+		Name:      f.Name,
+		ParentId:  f.ParentId,
+		UserId:    f.UserId,
+		Path:      f.Path,
+		Utime:     time.Unix(f.Utime, 0).Format(time.DateTime),
+		TotalSize: f.TotalSize,
+		FileCount: f.FileCount,
+		VaultId:   f.VaultId,
+	}
+	if f.LastModified > 0 {
+		folder.LastModified = time.Unix(f.LastModified, 0).Format(time.DateTime)
+	}
+
+	return folder
+}
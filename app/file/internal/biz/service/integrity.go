@@ -0,0 +1,97 @@
+package service
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strings"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+var (
+	// ErrHashMismatch 服务端计算的内容哈希与客户端提供的不一致
+	ErrHashMismatch = errors.New("content hash mismatch")
+	// ErrChecksumMismatch 分片内容的 SHA-256 与客户端提供的不一致
+	ErrChecksumMismatch = errors.New("part checksum mismatch")
+	// ErrUnsupportedHash 客户端提供的哈希既不是十六进制的 MD5 也不是 SHA-256
+	ErrUnsupportedHash = errors.New("hash must be hex encoded MD5 or SHA-256")
+	// ErrSizeExceeded 分片上传的内容超过开始时声明的大小, 预留的空间按声明的大小计算
+	ErrSizeExceeded = errors.New("upload exceeds its declared size")
+)
+
+// contentHash 在写入内容的同时计算 MD5 和 SHA-256
+// MD5 保存在 hash 字段中兼容已有的客户端, SHA-256 用于秒传和巡检
+type contentHash struct {
+	md5    hash.Hash
+	sha256 hash.Hash
+}
+
+func newContentHash() *contentHash {
+	return &contentHash{md5: md5.New(), sha256: sha256.New()}
+}
+
+// hashContent 计算 data 的摘要
+func hashContent(data []byte) *contentHash {
+	h := newContentHash()
+	h.Write(data)
+
+	return h
+}
+
+func (h *contentHash) Write(p []byte) (int, error) {
+	h.md5.Write(p)
+	h.sha256.Write(p)
+
+	return len(p), nil
+}
+
+// MD5 返回十六进制的 MD5
+func (h *contentHash) MD5() string {
+	return hex.EncodeToString(h.md5.Sum(nil))
+}
+
+// SHA256 返回十六进制的 SHA-256
+func (h *contentHash) SHA256() string {
+	return hex.EncodeToString(h.sha256.Sum(nil))
+}
+
+// verify 校验客户端提供的哈希, 按长度区分 MD5 和 SHA-256, 为空时不校验
+func (h *contentHash) verify(claimed string) error {
+	var actual string
+	switch len(claimed) {
+	case 0:
+		return nil
+	case md5.Size * 2:
+		actual = h.MD5()
+	case sha256.Size * 2:
+		actual = h.SHA256()
+	default:
+		return ErrUnsupportedHash
+	}
+	if !strings.EqualFold(claimed, actual) {
+		return ErrHashMismatch
+	}
+
+	return nil
+}
+
+// apply 将摘要写入文件记录
+func (h *contentHash) apply(f *dao.File) {
+	f.Hash, f.Sha256 = h.MD5(), h.SHA256()
+}
+
+// verifyChecksum 校验分片的 SHA-256, 为空时不校验
+func verifyChecksum(data []byte, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	if !strings.EqualFold(checksum, hex.EncodeToString(sum[:])) {
+		return ErrChecksumMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/cache"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// jobFlushInterval 异步任务进度写回缓存的最小间隔
+const jobFlushInterval = 500 * time.Millisecond
+
+// jobFunc 异步任务的执行体, progress 用于上报新完成的条目数, 返回任务产生的文件(夹) ID
+type jobFunc func(ctx context.Context, progress func(n int)) (int64, error)
+
+// GetJob 获取异步任务进度
+func (s *FileServer) GetJob(ctx context.Context, req *file.GetJobRequest) (*file.GetJobResponse, error) {
+	job, err := s.repo.GetJob(ctx, req.GetJobId())
+	if err != nil {
+		return nil, err
+	}
+
+	if job.UserId != req.GetUserId() {
+		return nil, errors.New("permission denied")
+	}
+
+	return &file.GetJobResponse{
+		JobId:    req.GetJobId(),
+		Type:     job.Type,
+		Status:   job.Status,
+		Total:    job.Total,
+		Done:     job.Done,
+		ResultId: job.ResultId,
+		Error:    job.Error,
+	}, nil
+}
+
+// startJob 创建异步任务并在后台执行
+func (s *FileServer) startJob(ctx context.Context, uid int32, typ string, total int64, fn jobFunc) (string, error) {
+	jobId := uuid.New().String()
+	job := &cache.Job{
+		UserId:    uid,
+		Type:      typ,
+		Status:    "pending",
+		Total:     total,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.SaveJob(ctx, jobId, job); err != nil {
+		return "", err
+	}
+
+	go func() {
+		newCtx := context.Background()
+
+		var mu sync.Mutex
+		lastFlush := time.Now()
+		job.Status = "processing"
+		s.saveJob(newCtx, jobId, job)
+
+		progress := func(n int) {
+			mu.Lock()
+			defer mu.Unlock()
+			job.Done += int64(n)
+			if time.Since(lastFlush) >= jobFlushInterval {
+				lastFlush = time.Now()
+				s.saveJob(newCtx, jobId, job)
+			}
+		}
+
+		resultId, err := fn(newCtx, progress)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			job.Status = "failed"
+			job.Error = err.Error()
+		} else {
+			job.Status = "completed"
+			job.Done = job.Total
+			job.ResultId = resultId
+		}
+		s.saveJob(newCtx, jobId, job)
+	}()
+
+	return jobId, nil
+}
+
+func (s *FileServer) saveJob(ctx context.Context, jobId string, job *cache.Job) {
+	if err := s.repo.SaveJob(ctx, jobId, job); err != nil {
+		log.Printf("failed to save job %s: %s", jobId, err)
+	}
+}
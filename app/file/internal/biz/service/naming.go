@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+var (
+	ErrNameConflict = errors.New("name already exists in target folder")
+	ErrInvalidName  = errors.New("invalid name")
+)
+
+// maxNameLength 文件(夹)名称的最大长度
+const maxNameLength = 255
+
+// validateName 检查文件(夹)名称是否合法, 名称中的 / 在路径中会被转义, 因此允许使用
+func validateName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: empty name", ErrInvalidName)
+	case name == "." || name == "..":
+		return fmt.Errorf("%w: %s", ErrInvalidName, name)
+	case len(name) > maxNameLength:
+		return fmt.Errorf("%w: name too long", ErrInvalidName)
+	}
+
+	return nil
+}
+
+// nameResolution 同名处理的结果
+type nameResolution struct {
+	name      string         // 最终名称
+	skip      bool           // 按策略跳过该条目
+	overwrite *dao.NameEntry // 需要被覆盖的同名文件
+}
+
+// resolveEntry 按同名处理策略计算最终名称, isFolder 表示待写入的条目是否为文件夹
+// 名称按 naming.Key 比较, 覆盖策略只适用于文件覆盖文件
+func resolveEntry(naming dao.Naming, policy file.NameConflictPolicy, name string, isFolder bool, taken []dao.NameEntry) (nameResolution, error) {
+	used := make(map[string]*dao.NameEntry, len(taken))
+	for i := range taken {
+		used[naming.Key(taken[i].Name)] = &taken[i]
+	}
+	existing, ok := used[naming.Key(name)]
+	if !ok {
+		return nameResolution{name: name}, nil
+	}
+
+	switch policy {
+	case file.NameConflictPolicy_NAME_CONFLICT_RENAME:
+		return nameResolution{name: availableName(naming, name, used)}, nil
+	case file.NameConflictPolicy_NAME_CONFLICT_SKIP:
+		return nameResolution{name: existing.Name, skip: true}, nil
+	case file.NameConflictPolicy_NAME_CONFLICT_OVERWRITE:
+		if isFolder || existing.IsFolder {
+			return nameResolution{}, fmt.Errorf("%w: %s, only a file can overwrite a file", ErrNameConflict, name)
+		}
+		return nameResolution{name: existing.Name, overwrite: existing}, nil
+	default:
+		return nameResolution{}, fmt.Errorf("%w: %s", ErrNameConflict, name)
+	}
+}
+
+// resolveName 按同名处理策略计算最终名称, 用于不支持覆盖的操作, skip 为 true 表示应跳过该条目
+func resolveName(naming dao.Naming, policy file.NameConflictPolicy, name string, isFolder bool, taken []dao.NameEntry) (final string, skip bool, err error) {
+	res, err := resolveEntry(naming, policy, name, isFolder, taken)
+	if err != nil {
+		return "", false, err
+	}
+	if res.overwrite != nil {
+		return "", false, fmt.Errorf("%w: overwrite is not supported by this operation", errInvalidItem)
+	}
+
+	return res.name, res.skip, nil
+}
+
+// fileType 由扩展名得到文件类型, 没有扩展名时为空
+func fileType(name string) string {
+	return strings.TrimPrefix(filepath.Ext(name), ".")
+}
+
+// availableName 生成 name (1).ext 形式的不重复名称
+func availableName(naming dao.Naming, name string, used map[string]*dao.NameEntry) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if base == "" {
+		// .gitignore 之类的名称整体作为 base
+		base, ext = name, ""
+	}
+
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, i, ext)
+		if _, ok := used[naming.Key(candidate)]; !ok {
+			return candidate
+		}
+	}
+}
+
+// createFile 按 resolveFileInFolder 的结果在 f.FolderId 下创建文件, 覆盖同名文件时 f 的内容成为该文件的新版本
+func (s *FileServer) createFile(ctx context.Context, f *dao.File, res nameResolution) (batchOutcome, error) {
+	if res.skip {
+		return batchOutcome{name: res.name, skipped: true}, nil
+	}
+	if res.overwrite != nil {
+		return s.overwriteFile(ctx, res.overwrite.Id, *f, false)
+	}
+
+	f.Name, f.Version = res.name, 1
+	if err := s.repo.CreateFile(ctx, f); err != nil {
+		return batchOutcome{}, conflictError(err, f.Name)
+	}
+
+	return batchOutcome{name: f.Name, newId: f.Id, version: f.Version}, nil
+}
+
+// moveFile 按同名策略把文件移动到 to, 覆盖同名文件时源文件的内容成为目标文件的新版本, 源文件移入回收站
+func (s *FileServer) moveFile(ctx context.Context, f dao.File, to int64, policy file.NameConflictPolicy) (batchOutcome, error) {
+	if f.FolderId == to {
+		return batchOutcome{name: f.Name}, nil
+	}
+
+	res, err := s.resolveFileInFolder(ctx, policy, f.Name, to, f.UserId)
+	if err != nil || res.skip {
+		return batchOutcome{name: res.name, skipped: res.skip}, err
+	}
+	if res.overwrite != nil {
+		return s.overwriteFile(ctx, res.overwrite.Id, f, true)
+	}
+
+	if err := s.repo.MoveFile(ctx, f.Id, to, f.UserId, res.name); err != nil {
+		return batchOutcome{}, conflictError(err, res.name)
+	}
+
+	return batchOutcome{name: res.name}, nil
+}
+
+// copyFile 按同名策略把文件复制到 uid 的文件夹 to, 覆盖同名文件时源文件的内容成为目标文件的新版本
+// 源文件可以属于其他用户, 副本属于 uid 并占用其空间, 且须与源文件在同一个保险库中
+func (s *FileServer) copyFile(ctx context.Context, src dao.File, to int64, uid int32, policy file.NameConflictPolicy) (batchOutcome, error) {
+	if err := s.checkVaultTarget(ctx, to, src.VaultId); err != nil {
+		return batchOutcome{}, err
+	}
+	res, err := s.resolveFileInFolder(ctx, policy, src.Name, to, uid)
+	if err != nil || res.skip {
+		return batchOutcome{name: res.name, skipped: res.skip}, err
+	}
+	if res.overwrite != nil && res.overwrite.Id == src.Id {
+		// 复制到原文件夹并覆盖自身, 内容不变
+		return batchOutcome{name: src.Name, newId: src.Id, version: src.Version}, nil
+	}
+	if err := s.ensureCapacity(ctx, uid, src.Size); err != nil {
+		return batchOutcome{}, err
+	}
+	if res.overwrite != nil {
+		// 元数据按源文件的所有者读取, 新版本写入目标文件的所有者
+		if src.Metas == nil {
+			metas, err := s.repo.GetFileMeta(ctx, src.Id, src.UserId)
+			if err != nil {
+				return batchOutcome{}, err
+			}
+			src.Metas = metas
+		}
+		src.UserId = uid
+		return s.overwriteFile(ctx, res.overwrite.Id, src, false)
+	}
+
+	dst, err := s.repo.CopyFile(ctx, src, res.name, to, uid)
+	if err != nil {
+		return batchOutcome{}, conflictError(err, res.name)
+	}
+
+	return batchOutcome{name: dst.Name, newId: dst.Id, version: dst.Version}, nil
+}
+
+// overwriteFile 用 src 的内容覆盖文件 targetId, removeSrc 为 true 时同一事务中将源文件移入回收站
+func (s *FileServer) overwriteFile(ctx context.Context, targetId int64, src dao.File, removeSrc bool) (batchOutcome, error) {
+	if src.Id != 0 && src.Metas == nil {
+		metas, err := s.repo.GetFileMeta(ctx, src.Id, src.UserId)
+		if err != nil {
+			return batchOutcome{}, err
+		}
+		src.Metas = metas
+	}
+
+	var dst dao.File
+	err := s.repo.Transaction(ctx, func(r *repository.UploadRepo) error {
+		var err error
+		if dst, err = r.OverwriteFile(ctx, targetId, src.UserId, src); err != nil {
+			return err
+		}
+		if removeSrc {
+			return r.DeleteFile(ctx, src.Id, src.UserId)
+		}
+		return nil
+	})
+	if err != nil {
+		return batchOutcome{}, err
+	}
+
+	return batchOutcome{name: dst.Name, newId: dst.Id, version: dst.Version}, nil
+}
+
+// conflictError 将唯一索引冲突转换为 ErrNameConflict, 并发写入同名条目时由数据库兜底
+func conflictError(err error, name string) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: %s", ErrNameConflict, name)
+	}
+
+	return err
+}
+
+// findEntry 按 naming.Key 查找同名条目
+func findEntry(naming dao.Naming, taken []dao.NameEntry, name string) *dao.NameEntry {
+	key := naming.Key(name)
+	for i := range taken {
+		if naming.Key(taken[i].Name) == key {
+			return &taken[i]
+		}
+	}
+
+	return nil
+}
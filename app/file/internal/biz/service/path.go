@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+var ErrPathNotFound = errors.New("path not found")
+
+// ResolvePath 将路径解析为文件或文件夹, 路径相对于 root_id, 需要对其有查看权限
+func (s *FileServer) ResolvePath(ctx context.Context, req *file.ResolvePathRequest) (*file.ResolvePathResponse, error) {
+	root, err := s.pathRoot(ctx, req.GetUserId(), req.GetRootId(), dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	folder, f, err := s.resolvePath(ctx, root, req.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	if f != nil {
+		metas, err := s.repo.GetFileMeta(ctx, f.Id, f.UserId)
+		if err != nil {
+			return nil, err
+		}
+
+		return &file.ResolvePathResponse{File: &file.File{
+			Id:       int32(f.Id),
+			Name:     f.Name,
+			FolderId: f.FolderId,
+			UserId:   f.UserId,
+			Size:     f.Size,
+			Type:     f.Type,
+			Utime:    time.Unix(f.Utime, 0).Format(time.DateTime),
+			Version:  f.Version,
+			Metadata: toPbMetas(metas),
+			VaultId:  f.VaultId,
+		}}, nil
+	}
+
+	return &file.ResolvePathResponse{Folder: toPbFolder(*folder)}, nil
+}
+
+// ListPath 按路径展示文件夹及文件, 路径相对于 root_id, 需要对其有查看权限
+func (s *FileServer) ListPath(ctx context.Context, req *file.ListPathRequest) (*file.ListFolderResponse, error) {
+	root, err := s.pathRoot(ctx, req.GetUserId(), req.GetRootId(), dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	folder, err := s.resolveFolderPath(ctx, root, req.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ListFolder(ctx, &file.ListFolderRequest{
+		FolderId: folder.Id,
+		UserId:   req.GetUserId(),
+	})
+}
+
+// DeletePath 按路径删除文件或文件夹, 路径相对于 root_id, 需要有编辑权限
+func (s *FileServer) DeletePath(ctx context.Context, req *file.DeletePathRequest) (*file.DeletePathResponse, error) {
+	uid := req.GetUserId()
+	root, err := s.pathRoot(ctx, uid, req.GetRootId(), dao.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	folder, f, err := s.resolvePath(ctx, root, req.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case f != nil:
+		_, err = s.DeleteFile(ctx, &file.DeleteFileRequest{FileId: f.Id, UserId: uid})
+	case folder.Id == root.Id:
+		err = errors.New("cannot delete root folder")
+	default:
+		_, err = s.DeleteFolder(ctx, &file.DeleteFolderRequest{FolderId: folder.Id, UserId: uid})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.DeletePathResponse{}, nil
+}
+
+// EnsureFolderPath 确保路径上的文件夹都存在, 缺失的在同一事务中创建, 类似 mkdir -p
+func (s *FileServer) EnsureFolderPath(ctx context.Context, req *file.EnsureFolderPathRequest) (*file.EnsureFolderPathResponse, error) {
+	folder, created, err := s.ensureFolderPath(ctx, req.GetUserId(), req.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.EnsureFolderPathResponse{
+		Folder:  toPbFolder(folder),
+		Created: int32(created),
+	}, nil
+}
+
+// pathRoot 路径相对的文件夹, rootId 为 0 时是 uid 的根目录, 否则检查 uid 对其至少具有 role 角色
+func (s *FileServer) pathRoot(ctx context.Context, uid int32, rootId int64, role int8) (dao.Folder, error) {
+	if rootId == 0 {
+		return dao.Folder{UserId: uid}, nil
+	}
+
+	return s.authorizeFolder(ctx, uid, rootId, role)
+}
+
+// resolvePath 解析相对于 root 的路径, 返回的文件夹与文件只有一个非空, 空路径解析为 root 自身
+func (s *FileServer) resolvePath(ctx context.Context, root dao.Folder, path string) (*dao.Folder, *dao.File, error) {
+	names, err := dao.SplitPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(names) == 0 {
+		return &root, nil, nil
+	}
+
+	folder, err := s.repo.GetFolderByPath(ctx, root.UserId, root.Path+dao.BuildPath(names))
+	if err == nil {
+		return &folder, nil, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, err
+	}
+
+	// 不是文件夹, 按父文件夹 + 文件名查找文件
+	parentId := root.Id
+	if len(names) > 1 {
+		parent, err := s.repo.GetFolderByPath(ctx, root.UserId, root.Path+dao.BuildPath(names[:len(names)-1]))
+		if err != nil {
+			return nil, nil, pathError(path, err)
+		}
+		parentId = parent.Id
+	}
+
+	f, err := s.repo.GetFileByName(ctx, root.UserId, parentId, names[len(names)-1])
+	if err != nil {
+		return nil, nil, pathError(path, err)
+	}
+
+	return nil, &f, nil
+}
+
+// resolveFolderPath 将相对于 root 的路径解析为文件夹, 空路径解析为 root 自身
+func (s *FileServer) resolveFolderPath(ctx context.Context, root dao.Folder, path string) (dao.Folder, error) {
+	names, err := dao.SplitPath(path)
+	if err != nil {
+		return dao.Folder{}, err
+	}
+	if len(names) == 0 {
+		return root, nil
+	}
+
+	folder, err := s.repo.GetFolderByPath(ctx, root.UserId, root.Path+dao.BuildPath(names))
+	if err != nil {
+		return dao.Folder{}, pathError(path, err)
+	}
+
+	return folder, nil
+}
+
+func (s *FileServer) ensureFolderPath(ctx context.Context, uid int32, path string) (dao.Folder, int, error) {
+	names, err := dao.SplitPath(path)
+	if err != nil {
+		return dao.Folder{}, 0, err
+	}
+	for _, name := range names {
+		if err := validateName(name); err != nil {
+			return dao.Folder{}, 0, err
+		}
+	}
+
+	return s.repo.EnsureFolderPath(ctx, uid, names)
+}
+
+// uploadFolderId 计算上传的目标文件夹, 指定了 folder_path 时按路径解析
+func (s *FileServer) uploadFolderId(ctx context.Context, meta *file.FileMetaData) (int64, error) {
+	if meta.GetFolderPath() == "" {
+		return meta.GetFolderId(), nil
+	}
+
+	if meta.GetCreateParents() {
+		folder, _, err := s.ensureFolderPath(ctx, meta.GetUserId(), meta.GetFolderPath())
+		return folder.Id, err
+	}
+
+	folder, err := s.resolveFolderPath(ctx, dao.Folder{UserId: meta.GetUserId()}, meta.GetFolderPath())
+	return folder.Id, err
+}
+
+func pathError(path string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %s", ErrPathNotFound, path)
+	}
+
+	return err
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+const (
+	// defaultNearQuotaPercent 未指定阈值时列出用量达到 80% 的用户
+	defaultNearQuotaPercent = 80
+	// quotaEventBatch 每轮投递的配额事件数
+	quotaEventBatch = 100
+)
+
+// SavePlan 创建或更新存储套餐
+func (s *FileServer) SavePlan(ctx context.Context, req *file.SavePlanRequest) (*file.SavePlanResponse, error) {
+	p := req.GetPlan()
+	if p.GetName() == "" || p.GetCapacity() <= 0 || p.GetMaxFileSize() < 0 || p.GetVersionRetention() < 0 {
+		return nil, errors.New("invalid storage plan")
+	}
+
+	plan := &dao.StoragePlan{
+		Id:               p.GetId(),
+		Name:             p.GetName(),
+		Capacity:         p.GetCapacity(),
+		MaxFileSize:      p.GetMaxFileSize(),
+		VersionRetention: p.GetVersionRetention(),
+		BandwidthTier:    p.GetBandwidthTier(),
+	}
+	if err := s.repo.SavePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return &file.SavePlanResponse{Plan: toPbPlan(*plan)}, nil
+}
+
+// ListPlans 获取全部存储套餐
+func (s *FileServer) ListPlans(ctx context.Context, req *file.ListPlansRequest) (*file.ListPlansResponse, error) {
+	plans, err := s.repo.ListPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListPlansResponse{Plans: make([]*file.StoragePlan, 0, len(plans))}
+	for _, p := range plans {
+		resp.Plans = append(resp.Plans, toPbPlan(p))
+	}
+
+	return resp, nil
+}
+
+// AssignPlan 为用户分配存储套餐, 容量随之变为套餐容量加上生效中的临时容量
+func (s *FileServer) AssignPlan(ctx context.Context, req *file.AssignPlanRequest) (*file.AssignPlanResponse, error) {
+	store, err := s.repo.AssignPlan(ctx, req.GetUserId(), req.GetPlanId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.AssignPlanResponse{FileStore: toPbFileStore(store)}, nil
+}
+
+// GrantCapacity 为用户增加临时容量, 到期后由后台任务收回
+func (s *FileServer) GrantCapacity(ctx context.Context, req *file.GrantCapacityRequest) (*file.GrantCapacityResponse, error) {
+	if req.GetSize() <= 0 {
+		return nil, errors.New("grant size must be positive")
+	}
+	if req.GetExpireAt() <= time.Now().Unix() {
+		return nil, errors.New("grant must expire in the future")
+	}
+
+	grant := &dao.CapacityGrant{
+		UserId:   req.GetUserId(),
+		Size:     req.GetSize(),
+		Reason:   req.GetReason(),
+		ExpireAt: req.GetExpireAt(),
+	}
+	store, err := s.repo.GrantCapacity(ctx, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.GrantCapacityResponse{GrantId: grant.Id, FileStore: toPbFileStore(store)}, nil
+}
+
+// ListUsersNearQuota 按用量比例从高到低分页列出用量达到阈值的用户
+func (s *FileServer) ListUsersNearQuota(ctx context.Context, req *file.ListUsersNearQuotaRequest) (*file.ListUsersNearQuotaResponse, error) {
+	percent := int(req.GetPercent())
+	if percent <= 0 {
+		percent = defaultNearQuotaPercent
+	}
+	page, size := pageParams(req.GetPage(), req.GetSize())
+
+	stores, total, err := s.repo.ListUsersOverThreshold(ctx, percent, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListUsersNearQuotaResponse{Total: total, FileStores: make([]*file.FileStore, 0, len(stores))}
+	for _, st := range stores {
+		resp.FileStores = append(resp.FileStores, toPbFileStore(st))
+	}
+
+	return resp, nil
+}
+
+// RunQuotaEventDispatcher 按 interval 周期性地收回到期的临时容量, 并投递用量阈值事件, ctx 结束时退出
+func (s *FileServer) RunQuotaEventDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.repo.ExpireCapacityGrants(ctx); err != nil {
+				log.Printf("failed to expire capacity grants: %v", err)
+			}
+			if err := s.dispatchQuotaEvents(ctx); err != nil {
+				log.Printf("failed to dispatch quota events: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatchQuotaEvents 按写入顺序将尚未投递的配额事件转为文件变更事件发送, 失败时停止, 下一轮重试
+func (s *FileServer) dispatchQuotaEvents(ctx context.Context) error {
+	events, err := s.repo.ListPendingQuotaEvents(ctx, quotaEventBatch)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		event := &mws.FileChangeEvent{
+			EventType: "quota_threshold",
+			UserId:    e.UserId,
+			Size:      e.CurrentSize,
+			Timestamp: time.Unix(e.Ctime, 0),
+			Quota: &mws.QuotaEvent{
+				Threshold:   e.Threshold,
+				CurrentSize: e.CurrentSize,
+				Capacity:    e.Capacity,
+			},
+		}
+		fe, err := s.addEvent(ctx, event)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.MarkQuotaEventSent(ctx, e.Id); err != nil {
+			return err
+		}
+		sendCtx, cancel := context.WithTimeout(ctx, eventTimeout)
+		if err := s.sendEvent(sendCtx, fe, event); err != nil {
+			log.Printf("failed to send quota event %d: %v", e.Id, err)
+		}
+		cancel()
+	}
+
+	return nil
+}
+
+// checkFileSize 检查文件大小是否超过用户套餐的单个文件上限
+func (s *FileServer) checkFileSize(ctx context.Context, uid int32, size int64) error {
+	plan, err := s.repo.GetUserPlan(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if plan.MaxFileSize > 0 && size > plan.MaxFileSize {
+		return dao.ErrFileTooLarge
+	}
+
+	return nil
+}
+
+func toPbPlan(p dao.StoragePlan) *file.StoragePlan {
+	return &file.StoragePlan{
+		Id:               p.Id,
+		Name:             p.Name,
+		Capacity:         p.Capacity,
+		MaxFileSize:      p.MaxFileSize,
+		VersionRetention: p.VersionRetention,
+		BandwidthTier:    p.BandwidthTier,
+	}
+}
+
+func toPbFileStore(st dao.FileStore) *file.FileStore {
+	return &file.FileStore{
+		UserId:      st.UserId,
+		Capacity:    st.Capacity,
+		CurrentSize: st.CurrentSize,
+		PlanId:      st.PlanId,
+		Reserved:    st.Reserved,
+	}
+}
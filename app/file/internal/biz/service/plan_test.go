@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+func TestMaxFileSizeChecksRealSize(t *testing.T) {
+	s, store, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	plan := &dao.StoragePlan{Name: "small files", Capacity: 1 << 30, MaxFileSize: 8}
+	if err := db.Create(plan).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&dao.FileStore{}).Where("user_id = ?", testUser).Update("plan_id", plan.Id).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 更新文件时按新内容的大小检查
+	up, err := s.Upload(ctx, uploadRequest("a.txt", []byte("small")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UpdateFile(ctx, &file.UpdateFileRequest{FileId: int64(up.GetId()), UserId: testUser, Data: []byte("larger than the plan allows"), BaseVersion: 1})
+	if !errors.Is(err, dao.ErrFileTooLarge) {
+		t.Fatalf("update: got %v, want ErrFileTooLarge", err)
+	}
+
+	// 分片上传声明的大小在上限内, 完成时按实际大小检查
+	const key = "understated"
+	uploadId, err := store.CreateMultipart(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("larger than the plan allows")
+	etag, err := store.PutPart(ctx, key, uploadId, 1, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.reserveQuota(ctx, uploadId, testUser, int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	f := &dao.File{Name: "b.txt", UserId: testUser, ObjectKey: key}
+	_, err = s.completeMultipartUpload(ctx, uploadId, uploadId, []mws.BlobPart{{PartNumber: 1, ETag: etag}}, f, nil, 0, "")
+	if !errors.Is(err, dao.ErrFileTooLarge) {
+		t.Fatalf("complete: got %v, want ErrFileTooLarge", err)
+	}
+	if n := countRows(t, db, &dao.File{}, "name = ?", "b.txt"); n != 0 {
+		t.Fatal("oversized upload was committed")
+	}
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// ErrUnknownProcessor 没有注册该名称的处理器
+var ErrUnknownProcessor = errors.New("unknown processor")
+
+const (
+	// processEventBatch 流水线每轮消费的提交事件数
+	processEventBatch = 100
+	// processBatch 流水线每轮执行的处理数
+	processBatch = 20
+	// processMaxAttempts 处理的尝试次数上限, 超过后进入死信列表
+	processMaxAttempts = 5
+	// processLease 处理的租约, 处理超时或进程退出时租约到期后重新处理
+	processLease = 10 * time.Minute
+	// processBackoff 第一次重试的间隔, 之后每次加倍, 最长 processMaxBackoff
+	processBackoff    = time.Minute
+	processMaxBackoff = time.Hour
+)
+
+// ProcessingRuns 处理的执行次数, 按处理器和结果区分
+var ProcessingRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "cloudstorage",
+	Subsystem: "file",
+	Name:      "processing_runs_total",
+	Help:      "Number of post-upload processing runs by processor and outcome.",
+}, []string{"processor", "outcome"})
+
+// Processor 文件内容提交后执行的处理, 如生成缩略图、提取文本、病毒扫描和提取元数据
+// 同一版本可能被处理多次, 实现须是幂等的
+type Processor interface {
+	// Name 处理器的唯一名称, 每个文件的处理状态按名称保存
+	Name() string
+	// Accept 是否处理该文件
+	Accept(f *dao.File) bool
+	// Process 处理文件, 返回的结果可通过 GetFile 查询, 返回错误时按退避重试
+	Process(ctx context.Context, in *ProcessInput) (map[string]string, error)
+}
+
+// ProcessInput 处理器的输入
+type ProcessInput struct {
+	File dao.File
+	// Store 对象存储, 处理器可写入派生的对象, 如缩略图
+	Store mws.BlobStore
+
+	open func(ctx context.Context) (io.ReadCloser, error)
+}
+
+// Open 打开文件解密后的内容, 每次调用都从头读取
+func (in *ProcessInput) Open(ctx context.Context) (io.ReadCloser, error) {
+	return in.open(ctx)
+}
+
+// fileCommitted 在提交文件内容的事务中记录提交事件, 由处理流水线消费
+func (s *FileServer) fileCommitted(ctx context.Context, r *repository.UploadRepo, out batchOutcome) error {
+	if len(s.processors) == 0 || out.skipped || out.newId == 0 {
+		return nil
+	}
+
+	return r.AddFileCommitEvent(ctx, out.newId, out.version)
+}
+
+// ReprocessFile 重新处理文件的当前版本
+func (s *FileServer) ReprocessFile(ctx context.Context, req *file.ReprocessFileRequest) (*file.ReprocessFileResponse, error) {
+	f, err := s.repo.FindFile(ctx, req.GetFileId())
+	if err != nil {
+		return nil, err
+	}
+	if f.VaultId != 0 {
+		return nil, ErrVaultContent
+	}
+
+	names := req.GetProcessors()
+	if len(names) == 0 {
+		names = s.acceptingProcessors(&f)
+	}
+	for _, name := range names {
+		if s.processor(name) == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProcessor, name)
+		}
+	}
+	if err := s.repo.ResetFileProcesses(ctx, f.Id, f.Version, names); err != nil {
+		return nil, err
+	}
+
+	procs, err := s.repo.ListFileProcesses(ctx, f.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.ReprocessFileResponse{Results: toPbProcessingResults(procs)}, nil
+}
+
+// ListDeadLetters 分页获取多次处理失败后放弃的文件
+func (s *FileServer) ListDeadLetters(ctx context.Context, req *file.ListDeadLettersRequest) (*file.ListDeadLettersResponse, error) {
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	procs, total, err := s.repo.ListDeadFileProcesses(ctx, req.GetProcessor(), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.ListDeadLettersResponse{Results: toPbProcessingResults(procs), Total: total}, nil
+}
+
+// RetryDeadLetters 重新处理死信列表中的文件
+func (s *FileServer) RetryDeadLetters(ctx context.Context, req *file.RetryDeadLettersRequest) (*file.RetryDeadLettersResponse, error) {
+	n, err := s.repo.RetryDeadFileProcesses(ctx, req.GetProcessor())
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.RetryDeadLettersResponse{Count: n}, nil
+}
+
+// RunProcessingPipeline 按 interval 周期性地消费提交事件并执行到期的处理, ctx 结束时退出
+func (s *FileServer) RunProcessingPipeline(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.dispatchCommitEvents(ctx); err != nil {
+				log.Printf("failed to dispatch file commit events: %v", err)
+			}
+			if err := s.runDueProcesses(ctx, time.Now()); err != nil {
+				log.Printf("failed to run file processing: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatchCommitEvents 为提交的文件在适用的处理器上排队, 保险库中的密文不处理
+func (s *FileServer) dispatchCommitEvents(ctx context.Context) error {
+	events, err := s.repo.ListFileCommitEvents(ctx, processEventBatch)
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		// 文件已被删除时只消费事件
+		var names []string
+		f, err := s.repo.FindFile(ctx, ev.FileId)
+		switch {
+		case err == nil:
+			names = s.acceptingProcessors(&f)
+			ev.Version = max(ev.Version, f.Version)
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		if err := s.repo.EnqueueFileProcesses(ctx, ev, names); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runDueProcesses 认领并执行 now 时到期的处理
+func (s *FileServer) runDueProcesses(ctx context.Context, now time.Time) error {
+	procs, err := s.repo.ListDueFileProcesses(ctx, now.Unix(), processBatch)
+	if err != nil {
+		return err
+	}
+	for i := range procs {
+		proc := &procs[i]
+		// 多个实例同时执行时只有一个能认领
+		ok, err := s.repo.ClaimFileProcess(ctx, proc, int64(processLease/time.Second))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := s.runProcess(ctx, proc); err != nil {
+			log.Printf("failed to record processing of file %d by %s: %v", proc.FileId, proc.Processor, err)
+		}
+	}
+
+	return nil
+}
+
+// runProcess 执行一次认领的处理并记录结果, 失败时按退避重试, 多次失败后进入死信列表
+func (s *FileServer) runProcess(ctx context.Context, proc *dao.FileProcess) error {
+	f, err := s.repo.FindFile(ctx, proc.FileId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.repo.DeleteFileProcess(ctx, proc.Id)
+	}
+	if err != nil {
+		return err
+	}
+
+	var out map[string]string
+	p := s.processor(proc.Processor)
+	switch {
+	case p == nil:
+		err = fmt.Errorf("%w: %s", ErrUnknownProcessor, proc.Processor)
+	case f.VaultId != 0 || !p.Accept(&f):
+		return s.repo.DeleteFileProcess(ctx, proc.Id)
+	case f.Version != proc.Version:
+		// 内容已被替换, 新版本的提交事件会重置处理
+		err = fmt.Errorf("file version changed from %d to %d", proc.Version, f.Version)
+	default:
+		pctx, cancel := context.WithTimeout(ctx, processLease)
+		out, err = p.Process(pctx, &ProcessInput{
+			File:  f,
+			Store: s.store,
+			open: func(ctx context.Context) (io.ReadCloser, error) {
+				return s.keys.Open(ctx, s.store, f.ObjectName(), 0)
+			},
+		})
+		cancel()
+	}
+
+	if err == nil {
+		ProcessingRuns.WithLabelValues(proc.Processor, "done").Inc()
+		result, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+		return s.repo.FinishFileProcess(ctx, proc, dao.ProcessDone, result, "", 0)
+	}
+
+	log.Printf("processing of file %d by %s failed (attempt %d): %v", proc.FileId, proc.Processor, proc.Attempts, err)
+	if proc.Attempts >= processMaxAttempts {
+		ProcessingRuns.WithLabelValues(proc.Processor, "dead").Inc()
+		return s.repo.FinishFileProcess(ctx, proc, dao.ProcessDead, nil, err.Error(), 0)
+	}
+	ProcessingRuns.WithLabelValues(proc.Processor, "retry").Inc()
+	backoff := min(processBackoff<<(proc.Attempts-1), processMaxBackoff)
+
+	return s.repo.FinishFileProcess(ctx, proc, dao.ProcessRetry, nil, err.Error(), time.Now().Add(backoff).Unix())
+}
+
+// acceptingProcessors 返回处理该文件的处理器名称, 保险库中的密文不处理
+func (s *FileServer) acceptingProcessors(f *dao.File) []string {
+	if f.VaultId != 0 {
+		return nil
+	}
+	names := make([]string, 0, len(s.processors))
+	for _, p := range s.processors {
+		if p.Accept(f) {
+			names = append(names, p.Name())
+		}
+	}
+
+	return names
+}
+
+// processor 按名称查找注册的处理器
+func (s *FileServer) processor(name string) Processor {
+	for _, p := range s.processors {
+		if p.Name() == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func toPbProcessingResults(procs []dao.FileProcess) []*file.ProcessingResult {
+	res := make([]*file.ProcessingResult, 0, len(procs))
+	for _, proc := range procs {
+		var outputs map[string]string
+		if len(proc.Result) > 0 {
+			if err := json.Unmarshal(proc.Result, &outputs); err != nil {
+				log.Printf("invalid result of file %d processed by %s: %v", proc.FileId, proc.Processor, err)
+			}
+		}
+		res = append(res, &file.ProcessingResult{
+			FileId:    proc.FileId,
+			Processor: proc.Processor,
+			Version:   proc.Version,
+			Status:    file.ProcessingStatus(proc.Status),
+			Attempts:  proc.Attempts,
+			LastError: proc.LastError,
+			Outputs:   outputs,
+			Utime:     proc.Utime,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+)
+
+// textExtractLimit 提取的文本的最大字节数, 结果保存在数据库中
+const textExtractLimit = 16 << 10
+
+// textTypes 提取文本的文件类型
+var textTypes = map[string]bool{
+	"txt": true, "md": true, "csv": true, "tsv": true, "log": true, "json": true,
+	"xml": true, "yaml": true, "yml": true, "html": true, "htm": true,
+}
+
+// metadataProcessor 识别内容的 MIME 类型, 图片另外提取格式和宽高
+type metadataProcessor struct{}
+
+func NewMetadataProcessor() Processor {
+	return metadataProcessor{}
+}
+
+func (metadataProcessor) Name() string {
+	return "metadata"
+}
+
+func (metadataProcessor) Accept(f *dao.File) bool {
+	return true
+}
+
+func (metadataProcessor) Process(ctx context.Context, in *ProcessInput) (map[string]string, error) {
+	rc, err := in.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	br := bufio.NewReader(rc)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	out := map[string]string{"mime": http.DetectContentType(head)}
+
+	if strings.HasPrefix(out["mime"], "image/") {
+		if cfg, format, err := image.DecodeConfig(br); err == nil {
+			out["format"] = format
+			out["width"] = strconv.Itoa(cfg.Width)
+			out["height"] = strconv.Itoa(cfg.Height)
+		}
+	}
+
+	return out, nil
+}
+
+// textProcessor 提取文本文件开头的内容, 供预览和搜索使用
+type textProcessor struct{}
+
+func NewTextProcessor() Processor {
+	return textProcessor{}
+}
+
+func (textProcessor) Name() string {
+	return "text"
+}
+
+func (textProcessor) Accept(f *dao.File) bool {
+	return textTypes[strings.ToLower(f.Type)]
+}
+
+func (textProcessor) Process(ctx context.Context, in *ProcessInput) (map[string]string, error) {
+	rc, err := in.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, textExtractLimit+1))
+	if err != nil {
+		return nil, err
+	}
+	truncated := len(data) > textExtractLimit
+	if truncated {
+		data = data[:textExtractLimit]
+	}
+
+	// 截断处可能把多字节字符切开, 非法的字节一并去掉
+	return map[string]string{
+		"text":      strings.ToValidUTF8(string(data), ""),
+		"truncated": strconv.FormatBool(truncated),
+	}, nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// QuarantinedFiles 因扫描发现病毒而隔离的文件数
+var QuarantinedFiles = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "cloudstorage",
+	Subsystem: "file",
+	Name:      "quarantined_files_total",
+	Help:      "Number of files quarantined after a malware scan found an infection.",
+})
+
+// ErrScanPending 文件内容尚未完成病毒扫描, 扫描通过前不能下载和分享
+var ErrScanPending = errors.New("file is pending malware scan")
+
+// scanProcessorName 扫描处理器的名称
+const scanProcessorName = "scan"
+
+// scanProcessor 用配置的 Scanner 扫描提交的内容, 发现病毒时隔离文件并禁用包含它的分享
+// 共用同一对象的文件(复制和转存产生)一并隔离, 它们不会产生新的提交事件
+type scanProcessor struct {
+	scanner mws.Scanner
+	repo    *repository.UploadRepo
+}
+
+func NewScanProcessor(scanner mws.Scanner, repo *repository.UploadRepo) Processor {
+	return scanProcessor{scanner: scanner, repo: repo}
+}
+
+func (scanProcessor) Name() string {
+	return scanProcessorName
+}
+
+func (scanProcessor) Accept(f *dao.File) bool {
+	return true
+}
+
+func (p scanProcessor) Process(ctx context.Context, in *ProcessInput) (map[string]string, error) {
+	rc, err := in.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	verdict, err := p.scanner.Scan(ctx, rc)
+	if err != nil {
+		return nil, err
+	}
+	if !verdict.Infected {
+		return map[string]string{"verdict": "clean", "scanner": p.scanner.Name()}, nil
+	}
+
+	quarantined, err := p.repo.QuarantineObject(ctx, &in.File, p.scanner.Name(), verdict.Signature)
+	if err != nil {
+		return nil, err
+	}
+	QuarantinedFiles.Add(float64(len(quarantined)))
+	for _, q := range quarantined {
+		log.Printf("quarantined file %d of user %d: %s found by %s, %d shares disabled",
+			q.FileId, q.UserId, verdict.Signature, p.scanner.Name(), q.DisabledShares)
+	}
+
+	return map[string]string{
+		"verdict":     "infected",
+		"scanner":     p.scanner.Name(),
+		"signature":   verdict.Signature,
+		"quarantined": strconv.Itoa(len(quarantined)),
+	}, nil
+}
+
+// checkScanned 启用了扫描时, 文件内容尚未扫描通过则返回 ErrScanPending, 保险库中的密文不扫描
+func (s *FileServer) checkScanned(ctx context.Context, f dao.File) error {
+	if f.VaultId != 0 || s.processor(scanProcessorName) == nil {
+		return nil
+	}
+	pending, err := s.repo.ScanPending(ctx, f, scanProcessorName)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return ErrScanPending
+	}
+
+	return nil
+}
+
+// ListQuarantinedFiles 分页获取隔离的文件及扫描结论
+func (s *FileServer) ListQuarantinedFiles(ctx context.Context, req *file.ListQuarantinedFilesRequest) (*file.ListQuarantinedFilesResponse, error) {
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	files, total, err := s.repo.ListQuarantinedFiles(ctx, req.GetUserId(), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListQuarantinedFilesResponse{Total: total, Files: make([]*file.QuarantinedFile, 0, len(files))}
+	for _, q := range files {
+		resp.Files = append(resp.Files, &file.QuarantinedFile{
+			FileId:         q.FileId,
+			UserId:         q.UserId,
+			Name:           q.Name,
+			Size:           q.Size,
+			Scanner:        q.Scanner,
+			Signature:      q.Signature,
+			DisabledShares: q.DisabledShares,
+			Ctime:          q.Ctime,
+		})
+	}
+
+	return resp, nil
+}
+
+// ReleaseQuarantinedFile 误报时解除隔离, 文件恢复到原来的文件夹, 重名时自动重命名
+func (s *FileServer) ReleaseQuarantinedFile(ctx context.Context, req *file.ReleaseQuarantinedFileRequest) (*file.ReleaseQuarantinedFileResponse, error) {
+	q, err := s.repo.GetQuarantinedFile(ctx, req.GetFileId())
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.repo.GetFile(ctx, q.FileId, q.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if f.Id == 0 || f.Status != 2 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err := s.checkRestoreParent(ctx, f.FolderId, f.UserId); err != nil {
+		return nil, err
+	}
+
+	name, _, err := s.resolveInFolder(ctx, file.NameConflictPolicy_NAME_CONFLICT_RENAME, f.Name, false, f.FolderId, f.UserId, dao.NameEntry{})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReleaseQuarantinedFile(ctx, f.Id, name); err != nil {
+		return nil, err
+	}
+
+	return &file.ReleaseQuarantinedFileResponse{Name: name}, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// eicar EICAR 标准测试文件的内容
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// newScanTestServer 启用特征扫描, 返回的 process 执行一轮处理流水线
+func newScanTestServer(t *testing.T) (*FileServer, func()) {
+	t.Helper()
+	s, _, _ := newUploadTestServer(t)
+	scanner, err := mws.NewSignatureScanner(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.processors = []Processor{NewScanProcessor(scanner, s.repo)}
+
+	process := func() {
+		t.Helper()
+		ctx := context.Background()
+		if err := s.dispatchCommitEvents(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.runDueProcesses(ctx, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s, process
+}
+
+func TestDownloadWaitsForScan(t *testing.T) {
+	s, process := newScanTestServer(t)
+	ctx := context.Background()
+
+	up, err := s.Upload(ctx, uploadRequest("a.txt", []byte("hello")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &file.DownloadRequest{UserId: testUser, FileId: int64(up.GetId())}
+	if _, err := s.Download(ctx, req); !errors.Is(err, ErrScanPending) {
+		t.Fatalf("download before scan: got %v, want ErrScanPending", err)
+	}
+	if _, err := s.CreateShareLink(ctx, &file.CreateShareLinkRequest{UserId: testUser, FileIds: []int64{int64(up.GetId())}}); !errors.Is(err, ErrScanPending) {
+		t.Fatalf("share before scan: got %v, want ErrScanPending", err)
+	}
+
+	process()
+	resp, err := s.Download(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.GetData()) != "hello" {
+		t.Fatalf("downloaded %q", resp.GetData())
+	}
+}
+
+func TestEicarUploadIsQuarantined(t *testing.T) {
+	s, process := newScanTestServer(t)
+	ctx := context.Background()
+
+	up, err := s.Upload(ctx, uploadRequest("eicar.com", []byte(eicar)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	process()
+
+	q, err := s.repo.GetQuarantinedFile(ctx, int64(up.GetId()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Signature != "Eicar-Test-Signature" {
+		t.Fatalf("quarantined with signature %q", q.Signature)
+	}
+	if _, err := s.Download(ctx, &file.DownloadRequest{UserId: testUser, FileId: int64(up.GetId())}); err == nil {
+		t.Fatal("downloaded a quarantined file")
+	}
+
+	// 隔离的内容不参与秒传, 再次上传得到新的文件
+	again, err := s.Upload(ctx, uploadRequest("eicar.com", []byte(eicar)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.GetId() == up.GetId() {
+		t.Fatal("upload reused the quarantined file")
+	}
+	if f, err := s.repo.GetFile(ctx, int64(again.GetId()), testUser); err != nil || f.Status != 0 {
+		t.Fatalf("new upload %+v, %v", f, err)
+	}
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// reservationTTL 上传预留的有效期, 超时未完成的上传由对账任务回收
+const reservationTTL = 24 * time.Hour
+
+// reconcilePageSize 全量对账时每页处理的用户数
+const reconcilePageSize = 100
+
+// reclaimBatch 回收过期预留时每批处理的预留数
+const reclaimBatch = 100
+
+var (
+	// QuotaDriftBytes 最近一次全量对账发现的已用空间偏差绝对值之和
+	QuotaDriftBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "cloudstorage",
+		Subsystem: "file",
+		Name:      "quota_drift_bytes",
+		Help:      "Sum of absolute differences between recorded and actual storage usage found by the last full reconciliation.",
+	})
+	// QuotaDriftUsers 最近一次全量对账发现存在偏差的用户数
+	QuotaDriftUsers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "cloudstorage",
+		Subsystem: "file",
+		Name:      "quota_drift_users",
+		Help:      "Number of users whose recorded storage usage or reservation drifted, found by the last full reconciliation.",
+	})
+)
+
+// ReconcileQuota 重新计算空间使用量, 单个用户同步执行, 全部用户转为异步任务
+// 无论是否修正, 对账前都先回收过期的预留
+func (s *FileServer) ReconcileQuota(ctx context.Context, req *file.ReconcileQuotaRequest) (*file.ReconcileQuotaResponse, error) {
+	if uid := req.GetUserId(); uid != 0 {
+		if _, err := s.reclaimExpiredReservations(ctx); err != nil {
+			return nil, err
+		}
+		usage, err := s.repo.ReconcileQuota(ctx, uid, req.GetFix())
+		if err != nil {
+			return nil, err
+		}
+
+		return &file.ReconcileQuotaResponse{Usage: toPbQuotaUsage(usage, req.GetFix())}, nil
+	}
+
+	jobId, err := s.startJob(ctx, 0, "reconcile_quota", 0, func(ctx context.Context, progress func(n int)) (int64, error) {
+		return 0, s.reconcileAll(ctx, req.GetFix(), progress)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.ReconcileQuotaResponse{JobId: jobId}, nil
+}
+
+// AbortUpload 中止分片上传, 丢弃已上传的分片并释放预留的空间
+func (s *FileServer) AbortUpload(ctx context.Context, req *file.AbortUploadRequest) (*file.AbortUploadResponse, error) {
+	if req.GetUploadId() == "" {
+		return nil, errors.New("upload id is required")
+	}
+	// 预留已释放或被回收时, 对象存储中的分片已随之清理
+	r, err := s.checkUploadOwner(ctx, req.GetUploadId(), req.GetUserId())
+	if errors.Is(err, dao.ErrUploadAborted) {
+		return &file.AbortUploadResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.AbortMultipart(ctx, r.ObjectKey, req.GetUploadId()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeletePartETags(ctx, req.GetUploadId()); err != nil {
+		log.Printf("failed to delete part etags of upload %s: %v", req.GetUploadId(), err)
+	}
+	if err := s.repo.ReleaseQuota(ctx, req.GetUploadId(), r.UserId); err != nil {
+		return nil, err
+	}
+
+	return &file.AbortUploadResponse{}, nil
+}
+
+// ListUploadParts 获取分片上传已上传的分片, 客户端据此跳过已上传的分片续传
+func (s *FileServer) ListUploadParts(ctx context.Context, req *file.ListUploadPartsRequest) (*file.ListUploadPartsResponse, error) {
+	if _, err := s.checkUploadOwner(ctx, req.GetUploadId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+	etags, err := s.repo.GetPartETags(ctx, req.GetUploadId())
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]int32, 0, len(etags))
+	for partNumber := range etags {
+		parts = append(parts, int32(partNumber))
+	}
+	slices.Sort(parts)
+
+	return &file.ListUploadPartsResponse{PartNumbers: parts}, nil
+}
+
+// RunQuotaReconciler 按 interval 周期性地回收过期的预留并对全部用户对账, 只报告偏差不做修正, ctx 结束时退出
+func (s *FileServer) RunQuotaReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.reconcileAll(ctx, false, func(int) {}); err != nil {
+				log.Printf("quota reconciliation failed: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reconcileAll 回收过期的预留后逐页对全部用户对账并更新偏差指标, 单个用户失败时记录日志并继续
+func (s *FileServer) reconcileAll(ctx context.Context, fix bool, progress func(n int)) error {
+	if n, err := s.reclaimExpiredReservations(ctx); err != nil {
+		log.Printf("failed to reclaim expired reservations: %v", err)
+	} else if n > 0 {
+		log.Printf("reclaimed %d expired reservations", n)
+	}
+
+	var (
+		driftBytes int64
+		driftUsers int
+		after      int32
+	)
+	for {
+		uids, err := s.repo.ListQuotaUsers(ctx, after, reconcilePageSize)
+		if err != nil {
+			return err
+		}
+		if len(uids) == 0 {
+			break
+		}
+
+		for _, uid := range uids {
+			usage, err := s.repo.ReconcileQuota(ctx, uid, fix)
+			if err != nil {
+				log.Printf("failed to reconcile quota of user %d: %v", uid, err)
+				progress(1)
+				continue
+			}
+			if usage.Drift() != 0 || usage.ReservedDrift() != 0 {
+				driftUsers++
+				driftBytes += abs(usage.Drift())
+				log.Printf("quota drift of user %d: recorded %d actual %d, reserved recorded %d actual %d, fixed %v",
+					uid, usage.RecordedSize, usage.ActualSize, usage.RecordedReserved, usage.ActualReserved, fix)
+			}
+			progress(1)
+		}
+		after = uids[len(uids)-1]
+	}
+
+	QuotaDriftBytes.Set(float64(driftBytes))
+	QuotaDriftUsers.Set(float64(driftUsers))
+
+	return nil
+}
+
+// reclaimExpiredReservations 中止过期预留对应的分片上传并释放预留, 返回回收的预留数
+// 中止失败的预留保留到下一轮重试, 先释放预留会让对象存储中的分片失去记录而无法清理
+func (s *FileServer) reclaimExpiredReservations(ctx context.Context) (int, error) {
+	now := time.Now().Unix()
+	reclaimed := 0
+	for {
+		rs, err := s.repo.ListExpiredReservations(ctx, now, reclaimBatch)
+		if err != nil {
+			return reclaimed, err
+		}
+
+		batch := 0
+		for _, r := range rs {
+			if r.ObjectKey != "" {
+				if err := s.store.AbortMultipart(ctx, r.ObjectKey, r.Id); err != nil {
+					log.Printf("failed to abort expired upload %s: %v", r.Id, err)
+					continue
+				}
+				if err := s.repo.DeletePartETags(ctx, r.Id); err != nil {
+					log.Printf("failed to delete part etags of upload %s: %v", r.Id, err)
+				}
+			}
+			if err := s.repo.ReleaseQuota(ctx, r.Id, r.UserId); err != nil {
+				return reclaimed, err
+			}
+			batch++
+		}
+		reclaimed += batch
+		// 整批都中止失败时停止, 避免反复处理同一批
+		if len(rs) < reclaimBatch || batch == 0 {
+			return reclaimed, nil
+		}
+	}
+}
+
+// checkUploadOwner 校验分片上传由用户发起并返回上传的会话, 预留已释放或被回收时上传已结束, 返回 dao.ErrUploadAborted
+func (s *FileServer) checkUploadOwner(ctx context.Context, uploadId string, uid int32) (dao.QuotaReservation, error) {
+	r, err := s.repo.FindQuotaReservation(ctx, uploadId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r, dao.ErrUploadAborted
+	}
+	if err != nil {
+		return r, err
+	}
+	if r.ActorId != uid {
+		return r, dao.ErrPermissionDenied
+	}
+
+	return r, nil
+}
+
+// reserveQuota 为上传预留空间, 空间不足时返回 dao.ErrInsufficientSpace
+func (s *FileServer) reserveQuota(ctx context.Context, id string, uid int32, size int64) error {
+	return s.repo.ReserveQuota(ctx, &dao.QuotaReservation{Id: id, UserId: uid, Size: size}, reservationTTL)
+}
+
+// reserveMultipart 为分片上传预留空间, 同时保存上传的会话, 后续分片和完成上传时按 upload_id 取回
+func (s *FileServer) reserveMultipart(ctx context.Context, r *dao.QuotaReservation) error {
+	return s.repo.ReserveQuota(ctx, r, reservationTTL)
+}
+
+// releaseQuota 释放预留, 用于上传失败后的清理, 已提交的预留不受影响
+func (s *FileServer) releaseQuota(id string, uid int32) {
+	if err := s.repo.ReleaseQuota(context.Background(), id, uid); err != nil {
+		log.Printf("failed to release quota reservation %s: %v", id, err)
+	}
+}
+
+// commitFile 按同名处理结果创建文件, 并在同一事务中保存对象的数据密钥并释放预留, 使预留的空间转为已用空间
+// key 为 nil 表示对象未加密
+func (s *FileServer) commitFile(ctx context.Context, reservationId string, f *dao.File, res nameResolution, key *dao.BlobKey) (batchOutcome, error) {
+	var out batchOutcome
+	err := s.repo.Transaction(ctx, func(r *repository.UploadRepo) error {
+		if key != nil && !res.skip {
+			if err := r.SaveBlobKey(ctx, key); err != nil {
+				return err
+			}
+		}
+		var err error
+		if out, err = s.withRepo(r).createFile(ctx, f, res); err != nil {
+			return err
+		}
+		if err := s.fileCommitted(ctx, r, out); err != nil {
+			return err
+		}
+		return r.ReleaseQuota(ctx, reservationId, f.UserId)
+	})
+	if err != nil {
+		return batchOutcome{}, err
+	}
+
+	return out, nil
+}
+
+func toPbQuotaUsage(u dao.QuotaUsage, fixed bool) *file.QuotaUsage {
+	return &file.QuotaUsage{
+		UserId:              u.UserId,
+		Capacity:            u.Capacity,
+		RecordedSize:        u.RecordedSize,
+		ActualSize:          u.ActualSize,
+		RecordedReserved:    u.RecordedReserved,
+		ActualReserved:      u.ActualReserved,
+		ExpiredReservations: int32(u.ExpiredReserved),
+		FoldersFixed:        int32(u.FoldersFixed),
+		Fixed:               fixed,
+	}
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
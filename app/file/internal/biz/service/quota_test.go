@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/cache"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+func TestReconcileReclaimsExpiredUploads(t *testing.T) {
+	s, store, db := newUploadTestServer(t)
+	ctx := context.Background()
+	// 分片标签在缓存中, 删除失败只记录日志
+	s.repo = repository.NewUploadRepo(dao.NewUploadDao(db, dao.Naming{}),
+		cache.NewFileCache(redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})))
+
+	const key = "expired-upload"
+	uploadId, err := store.CreateMultipart(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.PutPart(ctx, key, uploadId, 1, []byte("part")); err != nil {
+		t.Fatal(err)
+	}
+	r := &dao.QuotaReservation{Id: uploadId, UserId: testUser, ActorId: testUser, Size: 100, ObjectKey: key}
+	if err := s.reserveMultipart(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(r).Update("expire_at", time.Now().Add(-time.Minute).Unix()).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 只报告不修正时同样回收
+	resp, err := s.ReconcileQuota(ctx, &file.ReconcileQuotaRequest{UserId: testUser})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := resp.GetUsage(); u.GetRecordedReserved() != 0 || u.GetActualReserved() != 0 || u.GetExpiredReservations() != 0 {
+		t.Fatalf("usage after reclaim %v, want nothing reserved", u)
+	}
+	if n := countRows(t, db, &dao.QuotaReservation{}, "id = ?", uploadId); n != 0 {
+		t.Fatalf("%d reservations left", n)
+	}
+	if _, err := store.PutPart(ctx, key, uploadId, 2, []byte("part")); err == nil {
+		t.Fatal("multipart upload still open after reclaim")
+	}
+}
+
+func TestBackfillFolderStats(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	outer, err := s.CreateFolder(ctx, &file.CreateFolderRequest{Name: "outer", UserId: testUser})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner, err := s.CreateFolder(ctx, &file.CreateFolderRequest{Name: "inner", ParentId: outer.GetFolder().GetId(), UserId: testUser})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := uploadRequest("a.txt", []byte("hello"))
+	req.Metadata.FolderId = inner.GetFolder().GetId()
+	if _, err := s.Upload(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	// 统计列加入之前的历史数据
+	if err := db.Model(&dao.Folder{}).Where("1 = 1").Updates(map[string]any{"total_size": 0, "file_count": 0}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := dao.BackfillFolderStats(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var folders []dao.Folder
+	if err := db.Find(&folders).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range folders {
+		if f.TotalSize != 5 || f.FileCount != 1 {
+			t.Errorf("folder %s: size %d count %d, want 5 and 1", f.Name, f.TotalSize, f.FileCount)
+		}
+	}
+}
+
+func TestUploadCannotExceedReservation(t *testing.T) {
+	s, store, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	// 流式上传收到超出声明大小的内容时拒绝
+	for _, declared := range []int64{0, 4} {
+		stream := &chunkStream{chunks: []*file.UploadChunkRequest{
+			{Filename: "a.txt", Data: []byte("abcd"), FileSize: declared, UserId: testUser},
+			{Data: []byte("efgh")},
+		}}
+		if err := s.UploadChunkStream(stream); !errors.Is(err, ErrSizeExceeded) {
+			t.Errorf("declared %d: got %v, want ErrSizeExceeded", declared, err)
+		}
+	}
+
+	// 完成分片上传时按实际大小与预留比较
+	const key = "understated"
+	uploadId, err := store.CreateMultipart(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	etag, err := store.PutPart(ctx, key, uploadId, 1, []byte("more than declared"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.reserveQuota(ctx, uploadId, testUser, 1); err != nil {
+		t.Fatal(err)
+	}
+	f := &dao.File{Name: "b.txt", UserId: testUser, ObjectKey: key}
+	_, err = s.completeMultipartUpload(ctx, uploadId, uploadId, []mws.BlobPart{{PartNumber: 1, ETag: etag}}, f, nil, 0, "")
+	if !errors.Is(err, ErrSizeExceeded) {
+		t.Fatalf("got %v, want ErrSizeExceeded", err)
+	}
+
+	if n := countRows(t, db, &dao.File{}, "1 = 1"); n != 0 {
+		t.Fatalf("%d files created", n)
+	}
+	if fs := fileStore(t, db); fs.CurrentSize != 0 || fs.Reserved != 0 {
+		t.Fatalf("store after rejected uploads: %+v", fs)
+	}
+}
+
+func TestUpdateFileReservesGrowth(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	if err := db.Model(&dao.FileStore{}).Where("user_id = ?", testUser).Update("capacity", 10).Error; err != nil {
+		t.Fatal(err)
+	}
+	up, err := s.Upload(ctx, uploadRequest("a.txt", []byte("hello")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	update := func(data string, version int64) error {
+		_, err := s.UpdateFile(ctx, &file.UpdateFileRequest{FileId: int64(up.GetId()), UserId: testUser, Data: []byte(data), BaseVersion: version})
+		return err
+	}
+
+	if err := update("far more than ten bytes", 1); !errors.Is(err, dao.ErrInsufficientSpace) {
+		t.Fatalf("got %v, want ErrInsufficientSpace", err)
+	}
+	if err := update("eight b.", 1); err != nil {
+		t.Fatal(err)
+	}
+	if fs := fileStore(t, db); fs.CurrentSize != 8 || fs.Reserved != 0 {
+		t.Fatalf("store after update: size %d reserved %d, want 8 and 0", fs.CurrentSize, fs.Reserved)
+	}
+}
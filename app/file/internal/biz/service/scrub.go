@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// scrubBatch 巡检每轮校验的文件数, 全量校验时每页的文件数
+const scrubBatch = 50
+
+var (
+	// ScrubFiles 巡检校验过的文件数
+	ScrubFiles = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "cloudstorage",
+		Subsystem: "file",
+		Name:      "scrub_files_total",
+		Help:      "Number of files whose stored content was re-hashed by the scrubber.",
+	})
+	// ScrubBytes 巡检读取的内容字节数
+	ScrubBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "cloudstorage",
+		Subsystem: "file",
+		Name:      "scrub_bytes_total",
+		Help:      "Number of content bytes read and hashed by the scrubber.",
+	})
+	// ScrubProblems 巡检发现的问题数, 按问题类型区分
+	ScrubProblems = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "cloudstorage",
+		Subsystem: "file",
+		Name:      "scrub_problems_total",
+		Help:      "Number of scrub checks that found a corrupt, missing or unreadable object.",
+	}, []string{"problem"})
+	// ScrubFindings 当前有问题的对象数
+	ScrubFindings = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "cloudstorage",
+		Subsystem: "file",
+		Name:      "scrub_findings",
+		Help:      "Number of objects currently flagged by the scrubber.",
+	})
+)
+
+// ScrubStorage 立即校验文件的内容, 单个文件同步执行, 全部文件转为异步任务
+func (s *FileServer) ScrubStorage(ctx context.Context, req *file.ScrubStorageRequest) (*file.ScrubStorageResponse, error) {
+	if fid := req.GetFileId(); fid != 0 {
+		f, err := s.repo.FindFile(ctx, fid)
+		if err != nil {
+			return nil, err
+		}
+		finding, sum, err := s.scrubFile(ctx, &f, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if err := s.refreshScrubFindings(ctx); err != nil {
+			log.Printf("failed to count scrub findings: %v", err)
+		}
+
+		resp := &file.ScrubStorageResponse{Sha256: sum}
+		if finding != nil {
+			resp.Finding = toPbScrubFinding(*finding)
+		}
+		return resp, nil
+	}
+
+	jobId, err := s.startJob(ctx, 0, "scrub_storage", 0, func(ctx context.Context, progress func(n int)) (int64, error) {
+		return 0, s.scrubAll(ctx, progress)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.ScrubStorageResponse{JobId: jobId}, nil
+}
+
+// ListScrubFindings 分页获取巡检发现的有问题的对象
+func (s *FileServer) ListScrubFindings(ctx context.Context, req *file.ListScrubFindingsRequest) (*file.ListScrubFindingsResponse, error) {
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	findings, total, err := s.repo.ListScrubFindings(ctx, req.GetUserId(), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListScrubFindingsResponse{Total: total, Findings: make([]*file.ScrubFinding, 0, len(findings))}
+	for _, fd := range findings {
+		resp.Findings = append(resp.Findings, toPbScrubFinding(fd))
+	}
+
+	return resp, nil
+}
+
+// RunScrubber 按 interval 周期性地校验一批超过 period 没有校验过的文件, ctx 结束时退出
+func (s *FileServer) RunScrubber(ctx context.Context, interval, period time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.scrubDue(ctx, time.Now(), period); err != nil {
+				log.Printf("storage scrub failed: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// scrubDue 校验一批 now 之前 period 内没有校验过的文件, 最久没有校验的优先
+func (s *FileServer) scrubDue(ctx context.Context, now time.Time, period time.Duration) error {
+	files, err := s.repo.ListScrubCandidates(ctx, now.Add(-period).Unix(), scrubBatch)
+	if err != nil {
+		return err
+	}
+	for i := range files {
+		if _, _, err := s.scrubFile(ctx, &files[i], now); err != nil {
+			return err
+		}
+	}
+
+	return s.refreshScrubFindings(ctx)
+}
+
+// scrubAll 按 ID 顺序校验全部文件, 单个文件失败时记录日志并继续
+func (s *FileServer) scrubAll(ctx context.Context, progress func(n int)) error {
+	var after int64
+	for {
+		files, err := s.repo.ListLiveFiles(ctx, after, scrubBatch)
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			break
+		}
+		for i := range files {
+			if _, _, err := s.scrubFile(ctx, &files[i], time.Now()); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.Printf("failed to scrub file %d: %v", files[i].Id, err)
+			}
+			progress(1)
+		}
+		after = files[len(files)-1].Id
+	}
+
+	return s.refreshScrubFindings(ctx)
+}
+
+// scrubFile 重新读取文件的内容并与记录的摘要比对, 返回发现的问题和读出的内容的 SHA-256
+// 加密的对象解密后比对, 没有 SHA-256 的历史文件以 MD5 比对后补全摘要, 两者都没有时直接补全
+// 读取失败可能是暂时的, 同样记为问题, 下次校验通过时清除
+func (s *FileServer) scrubFile(ctx context.Context, f *dao.File, now time.Time) (*dao.ScrubFinding, string, error) {
+	key := f.ObjectName()
+	sum := newContentHash()
+	rc, err := s.keys.Open(ctx, s.store, key, 0)
+	if err == nil {
+		var n int64
+		n, err = io.Copy(sum, rc)
+		rc.Close()
+		ScrubBytes.Add(float64(n))
+	}
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+	ScrubFiles.Inc()
+
+	finding := &dao.ScrubFinding{ObjectKey: key, FileId: f.Id, UserId: f.UserId}
+	var backfill bool
+	switch {
+	case errors.Is(err, mws.ErrBlobNotFound):
+		finding.Problem = dao.ScrubMissing
+	case err != nil:
+		finding.Problem, finding.Detail = dao.ScrubUnreadable, err.Error()
+	case f.Sha256 != "":
+		finding.Expected, finding.Actual = f.Sha256, sum.SHA256()
+	case len(f.Hash) == 32:
+		finding.Expected, finding.Actual = f.Hash, sum.MD5()
+		backfill = true
+	default:
+		backfill = true
+	}
+	if finding.Problem == 0 && finding.Expected != finding.Actual {
+		finding.Problem = dao.ScrubCorrupt
+	}
+
+	if finding.Problem != 0 {
+		log.Printf("scrub found problem %d with object %s of file %d: expected %q actual %q %s",
+			finding.Problem, key, f.Id, finding.Expected, finding.Actual, finding.Detail)
+		ScrubProblems.WithLabelValues(file.ScrubProblem(finding.Problem).String()).Inc()
+		if err := s.repo.SaveScrubFinding(ctx, finding); err != nil {
+			return nil, "", err
+		}
+	} else if err := s.repo.DeleteScrubFinding(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	var sha, md5 string
+	if backfill && finding.Problem == 0 {
+		sha, md5 = sum.SHA256(), sum.MD5()
+	}
+	if err := s.repo.MarkFileScrubbed(ctx, f, sha, md5, now.Unix()); err != nil {
+		return nil, "", err
+	}
+
+	if finding.Problem == 0 {
+		finding = nil
+	}
+	if err != nil {
+		return finding, "", nil
+	}
+
+	return finding, sum.SHA256(), nil
+}
+
+// refreshScrubFindings 更新当前有问题的对象数指标
+func (s *FileServer) refreshScrubFindings(ctx context.Context) error {
+	n, err := s.repo.CountScrubFindings(ctx)
+	if err != nil {
+		return err
+	}
+	ScrubFindings.Set(float64(n))
+
+	return nil
+}
+
+func toPbScrubFinding(fd dao.ScrubFinding) *file.ScrubFinding {
+	return &file.ScrubFinding{
+		ObjectKey: fd.ObjectKey,
+		FileId:    fd.FileId,
+		UserId:    fd.UserId,
+		Problem:   file.ScrubProblem(fd.Problem),
+		Expected:  fd.Expected,
+		Actual:    fd.Actual,
+		Detail:    fd.Detail,
+		Ctime:     fd.Ctime,
+		Utime:     fd.Utime,
+	}
+}
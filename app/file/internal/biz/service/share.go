@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// ErrInvalidSharePassword 提取密码错误
+var ErrInvalidSharePassword = errors.New("invalid password")
+
+// archiveTokenTTL 打包下载令牌的有效期, 覆盖一次较大分享的完整下载
+const archiveTokenTTL = 6 * time.Hour
+
+// archiveScope 打包下载令牌的用途
+const archiveScope = "archive"
+
+// previewTokenTTL 预览令牌的有效期, 与对象存储预签名地址一致
+const previewTokenTTL = time.Hour
+
+// ShareSecret 签发分享访问令牌的服务端密钥, 令牌不能由分享的公开信息推出
+type ShareSecret []byte
+
+// ListShareFolder 匿名浏览分享, folder_id 为 0 时列出分享的根
+// 文件分享的根为分享的文件, 文件夹分享的根为分享的文件夹下的内容
+func (s *FileServer) ListShareFolder(ctx context.Context, req *file.ListShareFolderRequest) (*file.ListShareFolderResponse, error) {
+	share, err := s.openShare(ctx, req.GetShareId(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	// 只有打开分享的根计为一次浏览
+	if req.GetFolderId() == 0 {
+		s.recordShareView(ctx, share.Id, req.GetClientIp())
+	}
+
+	if share.FolderId == 0 {
+		if req.GetFolderId() != 0 {
+			return nil, dao.ErrNotInShare
+		}
+		files, err := s.repo.ListShareFiles(ctx, share)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &file.ListShareFolderResponse{Share: toPbShareInfo(share, dao.Folder{})}
+		for _, f := range files {
+			resp.Files = append(resp.Files, toPbFile(f))
+		}
+		return resp, nil
+	}
+
+	root, err := s.repo.GetShareFolder(ctx, share, share.FolderId)
+	if err != nil {
+		return nil, err
+	}
+	folder := root
+	if req.GetFolderId() != 0 && req.GetFolderId() != root.Id {
+		if folder, err = s.repo.GetShareFolder(ctx, share, req.GetFolderId()); err != nil {
+			return nil, err
+		}
+	}
+
+	fs, fds, err := s.repo.ListFolder(ctx, folder.Id, share.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 分享后才在其中创建的保险库不属于分享
+	resp := &file.ListShareFolderResponse{Share: toPbShareInfo(share, root)}
+	for _, f := range fs {
+		if f.VaultId != 0 {
+			continue
+		}
+		resp.Files = append(resp.Files, toPbFile(*f))
+	}
+	for _, fd := range fds {
+		if fd.VaultId != 0 {
+			continue
+		}
+		pb := toPbFolder(*fd)
+		pb.Path = sharePath(root, fd.Path)
+		resp.Folders = append(resp.Folders, pb)
+	}
+
+	return resp, nil
+}
+
+// ListShareFiles 列出分享内的全部文件及其相对分享根的路径, 供打包下载使用
+func (s *FileServer) ListShareFiles(ctx context.Context, req *file.ListShareFilesRequest) (*file.ListShareFilesResponse, error) {
+	share, err := s.openShare(ctx, req.GetShareId(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	var token string
+	if req.GetDownload() {
+		if err := s.repo.RecordShareAccess(ctx, share.Id, dao.ShareActionDownload, req.GetClientIp(), 0); err != nil {
+			return nil, err
+		}
+		token = s.shareToken(share, archiveScope, time.Now().Add(archiveTokenTTL))
+	}
+
+	// dirs 为文件夹ID到相对路径的映射, used 记录每个目录下已使用的名称
+	var (
+		root    dao.Folder
+		files   []dao.File
+		folders []dao.Folder
+		dirs    = map[int64]string{}
+		used    = map[string]map[string]*dao.NameEntry{}
+	)
+	if share.FolderId == 0 {
+		if files, err = s.repo.ListShareFiles(ctx, share); err != nil {
+			return nil, err
+		}
+	} else {
+		if root, err = s.repo.GetShareFolder(ctx, share, share.FolderId); err != nil {
+			return nil, err
+		}
+		if folders, files, err = s.repo.ListShareSubtree(ctx, root.Id, share.UserId); err != nil {
+			return nil, err
+		}
+		dirs[root.Id] = ""
+	}
+
+	naming := s.repo.Naming()
+	entryPath := func(dir, name string) string {
+		names, ok := used[dir]
+		if !ok {
+			names = map[string]*dao.NameEntry{}
+			used[dir] = names
+		}
+		name = strings.ReplaceAll(name, "/", "_")
+		if _, ok := names[naming.Key(name)]; ok {
+			name = availableName(naming, name, names)
+		}
+		names[naming.Key(name)] = &dao.NameEntry{Name: name}
+
+		return path.Join(dir, name)
+	}
+
+	// 子文件夹按深度排序, 父文件夹的路径总是先于子文件夹确定
+	for _, fd := range folders {
+		parent, ok := dirs[fd.ParentId]
+		if !ok {
+			continue
+		}
+		dirs[fd.Id] = entryPath(parent, fd.Name)
+	}
+
+	resp := &file.ListShareFilesResponse{Share: toPbShareInfo(share, root), ArchiveToken: token}
+	for _, f := range files {
+		dir, ok := dirs[f.FolderId]
+		if share.FolderId != 0 && !ok {
+			continue
+		}
+		resp.Entries = append(resp.Entries, &file.ShareEntry{
+			File: toPbFile(f),
+			Path: entryPath(dir, f.Name),
+		})
+	}
+
+	return resp, nil
+}
+
+// GetShareFile 获取分享内的文件信息
+func (s *FileServer) GetShareFile(ctx context.Context, req *file.ShareFileRequest) (*file.GetFileResponse, error) {
+	var (
+		share dao.ShareLink
+		err   error
+	)
+	if req.GetPreviewToken() != "" {
+		share, err = s.openSharePreview(ctx, req.GetShareId(), req.GetFileId(), req.GetPreviewToken())
+	} else {
+		share, err = s.openShare(ctx, req.GetShareId(), req.GetPassword())
+	}
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.repo.GetShareFile(ctx, share, req.GetFileId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.GetFileResponse{File: toPbFile(f)}, nil
+}
+
+// PreviewShareFile 预览分享内的文件
+func (s *FileServer) PreviewShareFile(ctx context.Context, req *file.ShareFileRequest) (*file.PreviewResponse, error) {
+	share, err := s.openShare(ctx, req.GetShareId(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.repo.GetShareFile(ctx, share, req.GetFileId())
+	if err != nil {
+		return nil, err
+	}
+	if f.VaultId != 0 {
+		return nil, ErrVaultContent
+	}
+	if err := s.checkScanned(ctx, f); err != nil {
+		return nil, err
+	}
+
+	previewType := s.getPreviewType(f.Type)
+	if previewType == file.PreviewType_UNKNOWN {
+		return nil, errors.New("file type not supported for preview")
+	}
+	s.recordShareView(ctx, share.Id, req.GetClientIp())
+
+	// 地址中只带限定于该文件的短期令牌, 不暴露提取密码
+	token := s.shareToken(share, previewScope(f.Id), time.Now().Add(previewTokenTTL))
+	gatewayPath := fmt.Sprintf("/api/share/%s/download/%d?inline=1&token=%s", share.Id, f.Id, url.QueryEscape(token))
+	previewURL, err := s.previewURL(ctx, f.ObjectName(), gatewayPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.PreviewResponse{
+		PreviewUrl:  previewURL,
+		ContentType: s.getMimeType(f.Type),
+		Type:        previewType,
+	}, nil
+}
+
+// DownloadShareFile 流式下载分享内的文件
+func (s *FileServer) DownloadShareFile(req *file.ShareFileRequest, stream file.FileService_DownloadShareFileServer) error {
+	ctx := stream.Context()
+	var (
+		share dao.ShareLink
+		err   error
+	)
+	// 只有凭令牌的访问才不计下载次数, 客户端自行设置的标记不能绕过下载次数限制
+	preview := req.GetPreviewToken() != ""
+	archived := req.GetArchiveToken() != "" && req.GetInArchive()
+	switch {
+	case preview:
+		share, err = s.openSharePreview(ctx, req.GetShareId(), req.GetFileId(), req.GetPreviewToken())
+	case archived:
+		// 打包下载的各个文件凭令牌访问, 避免每个文件都做一次 bcrypt 比较
+		share, err = s.openShareArchive(ctx, req.GetShareId(), req.GetArchiveToken())
+	default:
+		share, err = s.openShare(ctx, req.GetShareId(), req.GetPassword())
+	}
+	if err != nil {
+		return err
+	}
+	f, err := s.repo.GetShareFile(ctx, share, req.GetFileId())
+	if err != nil {
+		return err
+	}
+	switch {
+	case preview:
+		// 加密文件的在线预览凭 PreviewShareFile 签发的令牌经由此处解密, 已计为浏览, 不计下载次数
+		if f.VaultId != 0 {
+			return ErrVaultContent
+		}
+		if s.getPreviewType(f.Type) == file.PreviewType_UNKNOWN {
+			return errors.New("file type not supported for preview")
+		}
+	case !archived:
+		if err := s.repo.RecordShareAccess(ctx, share.Id, dao.ShareActionDownload, req.GetClientIp(), 0); err != nil {
+			return err
+		}
+	}
+
+	rc, err := s.openContent(ctx, f, req.GetOffset(), req.GetLength())
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return s.streamFile(rc, stream)
+}
+
+// openShare 获取有效的分享并校验提取密码, 分享不存在、已过期或已取消时返回 dao.ErrShareNotFound
+func (s *FileServer) openShare(ctx context.Context, shareId, password string) (dao.ShareLink, error) {
+	share, err := s.repo.GetShareLink(ctx, shareId)
+	if err != nil {
+		return dao.ShareLink{}, err
+	}
+	if !dao.CheckSharePassword(share.Password, password) {
+		return dao.ShareLink{}, ErrInvalidSharePassword
+	}
+
+	return share, nil
+}
+
+// openShareArchive 获取有效的分享并校验 ListShareFiles 签发的打包下载令牌
+func (s *FileServer) openShareArchive(ctx context.Context, shareId, token string) (dao.ShareLink, error) {
+	share, err := s.repo.GetShareLink(ctx, shareId)
+	if err != nil {
+		return dao.ShareLink{}, err
+	}
+	if !s.checkShareToken(share, archiveScope, token) {
+		return dao.ShareLink{}, ErrInvalidSharePassword
+	}
+
+	return share, nil
+}
+
+// openSharePreview 获取有效的分享并校验 PreviewShareFile 为该文件签发的预览令牌
+func (s *FileServer) openSharePreview(ctx context.Context, shareId string, fileId int64, token string) (dao.ShareLink, error) {
+	share, err := s.repo.GetShareLink(ctx, shareId)
+	if err != nil {
+		return dao.ShareLink{}, err
+	}
+	if !s.checkShareToken(share, previewScope(fileId), token) {
+		return dao.ShareLink{}, ErrInvalidSharePassword
+	}
+
+	return share, nil
+}
+
+// previewScope 预览令牌的用途, 限定于单个文件
+func previewScope(fileId int64) string {
+	return "preview:" + strconv.FormatInt(fileId, 10)
+}
+
+// shareToken 生成到 exp 为止对 scope 有效的分享访问令牌
+// 以服务端密钥签名分享 ID、提取密码的哈希和 scope, 没有密码的分享也无法伪造, 修改密码后旧令牌随之失效
+func (s *FileServer) shareToken(share dao.ShareLink, scope string, exp time.Time) string {
+	unix := strconv.FormatInt(exp.Unix(), 10)
+	mac := hmac.New(sha256.New, s.shareSecret)
+	for _, field := range []string{share.Id, share.Password, scope, unix} {
+		mac.Write([]byte(field))
+		mac.Write([]byte{0})
+	}
+
+	return unix + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkShareToken 校验 shareToken 签发的令牌未过期且对 scope 有效, 未配置密钥时拒绝全部令牌
+func (s *FileServer) checkShareToken(share dao.ShareLink, scope, token string) bool {
+	if len(s.shareSecret) == 0 {
+		return false
+	}
+	exp, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return false
+	}
+
+	return hmac.Equal([]byte(token), []byte(s.shareToken(share, scope, time.Unix(unix, 0))))
+}
+
+// recordShareView 记录一次浏览, 浏览不受次数限制, 记录失败不影响访问
+func (s *FileServer) recordShareView(ctx context.Context, shareId, ip string) {
+	if err := s.repo.RecordShareAccess(ctx, shareId, dao.ShareActionView, ip, 0); err != nil {
+		log.Printf("failed to record view of share %s: %v", shareId, err)
+	}
+}
+
+// sharePath 将文件夹路径转换为相对分享根的路径, 避免暴露分享者的目录结构
+func sharePath(root dao.Folder, p string) string {
+	rel := strings.TrimPrefix(p, root.Path)
+	if rel == "" {
+		return "/"
+	}
+
+	return rel
+}
+
+func toPbShareInfo(share dao.ShareLink, root dao.Folder) *file.ShareInfo {
+	return &file.ShareInfo{
+		ShareId:  share.Id,
+		OwnerId:  share.UserId,
+		Name:     root.Name,
+		IsFolder: share.FolderId != 0,
+		ExpireAt: share.ExpireAt.Unix(),
+	}
+}
+
+func toPbFile(f dao.File) *file.File {
+	return &file.File{
+		Id:       int32(f.Id),
+		Name:     f.Name,
+		FolderId: f.FolderId,
+		UserId:   f.UserId,
+		Size:     f.Size,
+		Type:     f.Type,
+		Utime:    time.Unix(f.Utime, 0).Format(time.DateTime),
+		Version:  f.Version,
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/config"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// ListShares 分页获取用户创建的分享及其访问统计
+func (s *FileServer) ListShares(ctx context.Context, req *file.ListSharesRequest) (*file.ListSharesResponse, error) {
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	shares, total, err := s.repo.ListShares(ctx, req.GetUserId(), int8(req.GetStatus()), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(shares))
+	for _, sh := range shares {
+		ids = append(ids, sh.Id)
+	}
+	counts, err := s.repo.CountShareFiles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListSharesResponse{Total: total, Shares: make([]*file.ShareSummary, 0, len(shares))}
+	for _, sh := range shares {
+		resp.Shares = append(resp.Shares, toPbShareSummary(sh, counts[sh.Id]))
+	}
+
+	return resp, nil
+}
+
+// RevokeShares 取消分享, 取消后链接立即失效且不能恢复
+func (s *FileServer) RevokeShares(ctx context.Context, req *file.RevokeSharesRequest) (*file.RevokeSharesResponse, error) {
+	if len(req.GetShareIds()) == 0 {
+		return &file.RevokeSharesResponse{}, nil
+	}
+
+	n, err := s.repo.RevokeShares(ctx, req.GetUserId(), req.GetShareIds())
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.RevokeSharesResponse{Revoked: int32(n)}, nil
+}
+
+// UpdateShare 修改分享的有效期、提取密码和次数上限
+func (s *FileServer) UpdateShare(ctx context.Context, req *file.UpdateShareRequest) (*file.UpdateShareResponse, error) {
+	var upd dao.ShareUpdate
+	if req.GetUpdateExpire() {
+		expireAt := time.Unix(req.GetExpireAt(), 0)
+		upd.ExpireAt = &expireAt
+	}
+	if req.GetUpdatePassword() {
+		password := req.GetPassword()
+		upd.Password = &password
+	}
+	if req.GetUpdateLimits() {
+		if req.GetMaxDownloads() < 0 || req.GetMaxSaves() < 0 {
+			return nil, errors.New("invalid share limits")
+		}
+		maxDownloads, maxSaves := req.GetMaxDownloads(), req.GetMaxSaves()
+		upd.MaxDownloads, upd.MaxSaves = &maxDownloads, &maxSaves
+	}
+
+	share, err := s.repo.UpdateShare(ctx, req.GetUserId(), req.GetShareId(), upd)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.CountShareFiles(ctx, []string{share.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.UpdateShareResponse{Share: toPbShareSummary(share, counts[share.Id])}, nil
+}
+
+// GetShareAccessLog 分页获取分享的访问记录, 只有分享者可以查看
+func (s *FileServer) GetShareAccessLog(ctx context.Context, req *file.GetShareAccessLogRequest) (*file.GetShareAccessLogResponse, error) {
+	share, err := s.repo.GetUserShare(ctx, req.GetUserId(), req.GetShareId())
+	if err != nil {
+		return nil, err
+	}
+
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	logs, total, err := s.repo.ListShareAccess(ctx, share.Id, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.GetShareAccessLogResponse{Total: total, Logs: make([]*file.ShareAccessLog, 0, len(logs))}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, &file.ShareAccessLog{
+			Action: l.Action,
+			Ip:     l.Ip,
+			UserId: l.UserId,
+			Ctime:  l.Ctime,
+		})
+	}
+
+	return resp, nil
+}
+
+// RunShareExpirer 按 interval 周期性地将到期的分享标记为已过期, ctx 结束时退出
+func (s *FileServer) RunShareExpirer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.repo.ExpireShares(ctx); err != nil {
+				log.Printf("failed to expire shares: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// shareURL 生成分享链接
+func shareURL(shareId string) string {
+	return fmt.Sprintf("%s/share/%s", strings.TrimSuffix(config.GetConf().Server.BaseURL, "/"), shareId)
+}
+
+// pageParams 规范分页参数, 默认每页 20 条
+func pageParams(page, size int32) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = 20
+	}
+
+	return int(page), int(size)
+}
+
+func toPbShareSummary(sh dao.ShareLink, fileCount int64) *file.ShareSummary {
+	return &file.ShareSummary{
+		ShareId:       sh.Id,
+		ShareUrl:      shareURL(sh.Id),
+		FolderId:      sh.FolderId,
+		FileCount:     fileCount,
+		HasPassword:   sh.Password != "",
+		CreatedAt:     sh.CreatedAt.Unix(),
+		ExpireAt:      sh.ExpireAt.Unix(),
+		Status:        int32(sh.Status),
+		MaxDownloads:  sh.MaxDownloads,
+		MaxSaves:      sh.MaxSaves,
+		ViewCount:     sh.ViewCount,
+		DownloadCount: sh.DownloadCount,
+		SaveCount:     sh.SaveCount,
+	}
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// saveItem 转存的一个顶层条目, folder 非空时为文件夹, folders 和 files 为其子树
+type saveItem struct {
+	file    dao.File
+	folder  *dao.Folder
+	folders []dao.Folder
+	files   []dao.File
+}
+
+// count 条目数, 用于任务进度, 与 CopyFolder 一致不计文件夹本身
+func (it saveItem) count() int {
+	if it.folder == nil {
+		return 1
+	}
+
+	return len(it.folders) + len(it.files)
+}
+
+// size 条目的逻辑大小
+func (it saveItem) size() int64 {
+	if it.folder == nil {
+		return it.file.Size
+	}
+
+	var total int64
+	for _, f := range it.files {
+		total += f.Size
+	}
+	return total
+}
+
+// inVault 条目或其子树中是否有保险库的内容, 分享链接不能创建在保险库上, 但保险库可能在分享后才创建
+func (it saveItem) inVault() bool {
+	if it.folder == nil {
+		return it.file.VaultId != 0
+	}
+	if it.folder.VaultId != 0 {
+		return true
+	}
+	for _, f := range it.folders {
+		if f.VaultId != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SaveToMyDrive 将分享或分享中选择的文件和文件夹转存到个人网盘, 文件夹连同子树一起复制
+// 副本与分享者共用对象存储中的内容, 但计入保存者的配额, 条目较多时转为异步任务
+func (s *FileServer) SaveToMyDrive(ctx context.Context, req *file.SaveToMyDriveRequest) (*file.SaveToMyDriveResponse, error) {
+	uid, to := req.GetUserId(), req.GetToFolderId()
+	share, err := s.openShare(ctx, req.GetShareId(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTargetFolder(ctx, to, uid); err != nil {
+		return nil, err
+	}
+
+	items, err := s.shareItems(ctx, share, req.GetFileIds(), req.GetFolderIds())
+	if err != nil {
+		return nil, err
+	}
+	// 转存的是明文, 不能存入保险库; 保险库中的密文也不能被转存
+	if err := s.checkVaultTarget(ctx, to, 0); err != nil {
+		return nil, err
+	}
+	for _, it := range items {
+		if it.inVault() {
+			return nil, ErrVaultContent
+		}
+	}
+
+	// 按总大小一次性预留空间, 全部写入后释放
+	var (
+		total     int
+		totalSize int64
+	)
+	for _, it := range items {
+		total += it.count()
+		totalSize += it.size()
+	}
+	reservationId := uuid.New().String()
+	if err := s.reserveQuota(ctx, reservationId, uid, totalSize); err != nil {
+		return nil, err
+	}
+	// 空间足够时才计入转存次数, 达到上限时拒绝
+	if err := s.repo.RecordShareAccess(ctx, share.Id, dao.ShareActionSave, req.GetClientIp(), uid); err != nil {
+		s.releaseQuota(reservationId, uid)
+		return nil, err
+	}
+
+	save := func(ctx context.Context, progress func(n int)) (*file.SaveToMyDriveResponse, error) {
+		return s.saveShareItems(ctx, items, to, uid, req.GetConflictPolicy(), progress)
+	}
+
+	if total > copyAsyncThreshold {
+		jobId, err := s.startJob(ctx, uid, "save_share", int64(total), func(ctx context.Context, progress func(n int)) (int64, error) {
+			defer s.releaseQuota(reservationId, uid)
+			_, err := save(ctx, progress)
+			return to, err
+		})
+		if err != nil {
+			s.releaseQuota(reservationId, uid)
+			return nil, err
+		}
+
+		return &file.SaveToMyDriveResponse{JobId: jobId}, nil
+	}
+
+	defer s.releaseQuota(reservationId, uid)
+	return save(ctx, func(int) {})
+}
+
+// shareItems 获取要转存的条目, 未选择任何条目时为整个分享
+// 选择的条目须在分享内, 已包含在其他选择的文件夹中的条目不重复转存
+func (s *FileServer) shareItems(ctx context.Context, share dao.ShareLink, fileIds, folderIds []int64) ([]saveItem, error) {
+	if len(fileIds) == 0 && len(folderIds) == 0 {
+		if share.FolderId != 0 {
+			root, err := s.repo.GetShareFolder(ctx, share, share.FolderId)
+			if err != nil {
+				return nil, err
+			}
+			folders, files, err := s.repo.ListShareSubtree(ctx, root.Id, share.UserId)
+			if err != nil {
+				return nil, err
+			}
+			return []saveItem{{folder: &root, folders: folders, files: files}}, nil
+		}
+
+		files, err := s.repo.ListShareFiles(ctx, share)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]saveItem, 0, len(files))
+		for _, f := range files {
+			items = append(items, saveItem{file: f})
+		}
+		return items, nil
+	}
+
+	// nested 为选择的文件夹的全部子文件夹, selected 为选择的文件夹
+	var (
+		items    []saveItem
+		nested   = map[int64]bool{}
+		selected = map[int64]bool{}
+	)
+	for _, id := range folderIds {
+		if selected[id] {
+			continue
+		}
+		fd, err := s.repo.GetShareFolder(ctx, share, id)
+		if err != nil {
+			return nil, err
+		}
+		folders, files, err := s.repo.ListShareSubtree(ctx, fd.Id, share.UserId)
+		if err != nil {
+			return nil, err
+		}
+		selected[fd.Id] = true
+		for _, sub := range folders {
+			nested[sub.Id] = true
+		}
+		items = append(items, saveItem{folder: &fd, folders: folders, files: files})
+	}
+
+	result := make([]saveItem, 0, len(items)+len(fileIds))
+	for _, it := range items {
+		if !nested[it.folder.Id] {
+			result = append(result, it)
+		}
+	}
+
+	seen := make(map[int64]bool, len(fileIds))
+	for _, id := range fileIds {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		f, err := s.repo.GetShareFile(ctx, share, id)
+		if err != nil {
+			return nil, err
+		}
+		if selected[f.FolderId] || nested[f.FolderId] {
+			continue
+		}
+		result = append(result, saveItem{file: f})
+	}
+
+	return result, nil
+}
+
+// saveShareItems 按同名策略将条目逐个复制到用户的文件夹 to 下
+func (s *FileServer) saveShareItems(ctx context.Context, items []saveItem, to int64, uid int32,
+	policy file.NameConflictPolicy, progress func(n int)) (*file.SaveToMyDriveResponse, error) {
+	// 顶层文件的自定义元数据随文件一起转存, 子树中文件的元数据由 CopyTree 复制
+	var fileIds []int64
+	for _, it := range items {
+		if it.folder == nil {
+			fileIds = append(fileIds, it.file.Id)
+		}
+	}
+	metas, err := s.repo.GetFilesMeta(ctx, fileIds)
+	if err != nil {
+		return nil, err
+	}
+	// 顶层文件的路径按保存者的文件夹重新计算, 不沿用分享者的目录结构
+	var parentPath string
+	if to != 0 {
+		parent, err := s.repo.GetFolder(ctx, to, uid)
+		if err != nil {
+			return nil, err
+		}
+		parentPath = parent.Path
+	}
+
+	resp := &file.SaveToMyDriveResponse{}
+	for _, it := range items {
+		if it.folder != nil {
+			name, skip, err := s.resolveInFolder(ctx, policy, it.folder.Name, true, to, uid, dao.NameEntry{})
+			if err != nil {
+				return nil, err
+			}
+			if skip {
+				resp.Skipped++
+				progress(it.count())
+				continue
+			}
+
+			folder, err := s.repo.CopyTree(ctx, *it.folder, name, to, uid, it.folders, it.files, progress)
+			if err != nil {
+				return nil, conflictError(err, name)
+			}
+			resp.Folders = append(resp.Folders, toPbFolder(folder))
+			continue
+		}
+
+		f := it.file
+		res, err := s.resolveFileInFolder(ctx, policy, f.Name, to, uid)
+		if err != nil {
+			return nil, err
+		}
+		out, err := s.createFile(ctx, &dao.File{
+			UserId:    uid,
+			Hash:      f.Hash,
+			Sha256:    f.Sha256,
+			Type:      f.Type,
+			Size:      f.Size,
+			FolderId:  to,
+			Path:      dao.JoinPath(parentPath, res.name),
+			ObjectKey: f.ObjectName(),
+			Metas:     metas[f.Id],
+		}, res)
+		if err != nil {
+			return nil, err
+		}
+		progress(1)
+		if out.skipped {
+			resp.Skipped++
+			continue
+		}
+
+		saved, err := s.repo.GetFile(ctx, out.newId, uid)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, toPbFile(saved))
+	}
+
+	return resp, nil
+}
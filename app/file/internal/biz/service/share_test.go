@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+func TestShareArchiveToken(t *testing.T) {
+	s, _, _ := newUploadTestServer(t)
+	ctx := context.Background()
+
+	up, err := s.Upload(ctx, uploadRequest("a.txt", []byte("hello")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	password, err := dao.HashSharePassword("pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const shareId = "share"
+	if err := s.repo.CreateShareFile(ctx, &dao.ShareFile{ShareId: shareId, FileId: int64(up.GetId())}); err != nil {
+		t.Fatal(err)
+	}
+	err = s.repo.CreateShareLink(ctx, &dao.ShareLink{
+		Id: shareId, UserId: testUser, Password: password, ExpireAt: time.Now().Add(time.Hour), Status: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.ListShareFiles(ctx, &file.ListShareFilesRequest{ShareId: shareId, Password: "bad", Download: true}); !errors.Is(err, ErrInvalidSharePassword) {
+		t.Fatalf("got %v, want ErrInvalidSharePassword", err)
+	}
+	listed, err := s.ListShareFiles(ctx, &file.ListShareFilesRequest{ShareId: shareId, Password: "pw", Download: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := listed.GetArchiveToken()
+	if _, err := s.openShareArchive(ctx, shareId, token); err != nil {
+		t.Fatalf("issued token rejected: %v", err)
+	}
+
+	share, err := s.repo.GetShareLink(ctx, shareId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, bad := range map[string]string{
+		"tampered": token[:len(token)-1] + "0",
+		"expired":  s.shareToken(share, archiveScope, time.Now().Add(-time.Minute)),
+		"empty":    "",
+		"unsigned": (&FileServer{}).shareToken(share, archiveScope, time.Now().Add(time.Hour)),
+		"scope":    s.shareToken(share, "preview", time.Now().Add(time.Hour)),
+	} {
+		if bad == token {
+			continue
+		}
+		if _, err := s.openShareArchive(ctx, shareId, bad); !errors.Is(err, ErrInvalidSharePassword) {
+			t.Errorf("%s token: got %v, want ErrInvalidSharePassword", name, err)
+		}
+	}
+}
+
+// downloadStream 收集下载流返回的数据
+type downloadStream struct {
+	grpc.ServerStream
+	data []byte
+}
+
+func (d *downloadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (d *downloadStream) Send(resp *file.DownloadStreamResponse) error {
+	d.data = append(d.data, resp.GetData()...)
+	return nil
+}
+
+func TestSharePreviewToken(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	up, err := s.Upload(ctx, uploadRequest("a.txt", []byte("hello")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := s.Upload(ctx, uploadRequest("b.txt", []byte("world")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	password, err := dao.HashSharePassword("secret-pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const shareId = "share"
+	for _, id := range []int32{up.GetId(), other.GetId()} {
+		if err := s.repo.CreateShareFile(ctx, &dao.ShareFile{ShareId: shareId, FileId: int64(id)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = s.repo.CreateShareLink(ctx, &dao.ShareLink{
+		Id: shareId, UserId: testUser, Password: password, ExpireAt: time.Now().Add(time.Hour), Status: 1, MaxDownloads: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileId := int64(up.GetId())
+
+	preview, err := s.PreviewShareFile(ctx, &file.ShareFileRequest{ShareId: shareId, Password: "secret-pw", FileId: fileId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(preview.GetPreviewUrl(), "secret-pw") {
+		t.Fatalf("preview url exposes the password: %s", preview.GetPreviewUrl())
+	}
+
+	share, err := s.repo.GetShareLink(ctx, shareId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	downloads := func() int64 {
+		t.Helper()
+		var link dao.ShareLink
+		if err := db.First(&link, "id = ?", shareId).Error; err != nil {
+			t.Fatal(err)
+		}
+		return link.DownloadCount
+	}
+
+	// 凭预览令牌读取不计下载次数
+	token := s.shareToken(share, previewScope(fileId), time.Now().Add(previewTokenTTL))
+	for i := 0; i < 2; i++ {
+		stream := &downloadStream{}
+		if err := s.DownloadShareFile(&file.ShareFileRequest{ShareId: shareId, FileId: fileId, PreviewToken: token}, stream); err != nil {
+			t.Fatalf("preview download: %v", err)
+		}
+		if string(stream.data) != "hello" {
+			t.Fatalf("preview download returned %q", stream.data)
+		}
+	}
+	if n := downloads(); n != 0 {
+		t.Fatalf("preview downloads counted: %d", n)
+	}
+	if _, err := s.GetShareFile(ctx, &file.ShareFileRequest{ShareId: shareId, FileId: fileId, PreviewToken: token}); err != nil {
+		t.Fatalf("preview token rejected by GetShareFile: %v", err)
+	}
+
+	// 令牌限定于签发时的文件
+	err = s.DownloadShareFile(&file.ShareFileRequest{ShareId: shareId, FileId: int64(other.GetId()), PreviewToken: token}, &downloadStream{})
+	if !errors.Is(err, ErrInvalidSharePassword) {
+		t.Fatalf("token for another file: got %v, want ErrInvalidSharePassword", err)
+	}
+
+	// 没有打包下载令牌时 in_archive 不能绕过下载次数限制
+	req := &file.ShareFileRequest{ShareId: shareId, Password: "secret-pw", FileId: fileId, InArchive: true}
+	if err := s.DownloadShareFile(req, &downloadStream{}); err != nil {
+		t.Fatal(err)
+	}
+	if n := downloads(); n != 1 {
+		t.Fatalf("download count = %d, want 1", n)
+	}
+	if err := s.DownloadShareFile(req, &downloadStream{}); !errors.Is(err, dao.ErrShareLimitReached) {
+		t.Fatalf("got %v, want ErrShareLimitReached", err)
+	}
+}
+
+func TestFolderShareExcludesVaults(t *testing.T) {
+	s, _, _ := newUploadTestServer(t)
+	ctx := context.Background()
+
+	projects, err := s.CreateFolder(ctx, &file.CreateFolderRequest{Name: "Projects", UserId: testUser})
+	if err != nil {
+		t.Fatal(err)
+	}
+	folderId := projects.GetFolder().GetId()
+	req := uploadRequest("plan.txt", []byte("plan"))
+	req.Metadata.FolderId = folderId
+	if _, err := s.Upload(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	vault, err := s.CreateVault(ctx, &file.CreateVaultRequest{UserId: testUser, ParentId: folderId, Name: "vault", KeyEnvelope: []byte("envelope")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vaultId := vault.GetFolder().GetId()
+	req = uploadRequest("secret.bin", []byte("ciphertext"))
+	req.Metadata.FolderId = vaultId
+	if _, err := s.Upload(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.CreateShareLink(ctx, &file.CreateShareLinkRequest{UserId: testUser, FolderId: folderId})
+	if !errors.Is(err, ErrVaultContent) {
+		t.Fatalf("sharing a folder containing a vault: got %v, want ErrVaultContent", err)
+	}
+
+	// 分享在保险库创建之前已存在
+	const shareId = "before-vault"
+	err = s.repo.CreateShareLink(ctx, &dao.ShareLink{Id: shareId, UserId: testUser, FolderId: folderId, ExpireAt: time.Now().Add(time.Hour), Status: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed, err := s.ListShareFolder(ctx, &file.ListShareFolderRequest{ShareId: shareId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed.GetFolders()) != 0 || len(listed.GetFiles()) != 1 {
+		t.Fatalf("share lists %v and %v, want only plan.txt", listed.GetFolders(), listed.GetFiles())
+	}
+	if _, err := s.ListShareFolder(ctx, &file.ListShareFolderRequest{ShareId: shareId, FolderId: vaultId}); !errors.Is(err, dao.ErrNotInShare) {
+		t.Fatalf("browsing the vault: got %v, want ErrNotInShare", err)
+	}
+	all, err := s.ListShareFiles(ctx, &file.ListShareFilesRequest{ShareId: shareId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.GetEntries()) != 1 || all.GetEntries()[0].GetPath() != "plan.txt" {
+		t.Fatalf("archive entries %v, want only plan.txt", all.GetEntries())
+	}
+}
+
+func TestSaveToMyDrive(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	req := uploadRequest("report.txt", []byte("report"))
+	req.Metadata.Path = "/Clients/acme/report.txt"
+	up, err := s.Upload(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const shareId = "save"
+	if err := s.repo.CreateShareFile(ctx, &dao.ShareFile{ShareId: shareId, FileId: int64(up.GetId())}); err != nil {
+		t.Fatal(err)
+	}
+	err = s.repo.CreateShareLink(ctx, &dao.ShareLink{Id: shareId, UserId: testUser, ExpireAt: time.Now().Add(time.Hour), Status: 1, MaxSaves: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 空间不足的转存不计入转存次数
+	if err := db.Create(&dao.FileStore{UserId: testViewer, Capacity: 1}).Error; err != nil {
+		t.Fatal(err)
+	}
+	save := &file.SaveToMyDriveRequest{ShareId: shareId, UserId: testViewer}
+	if _, err := s.SaveToMyDrive(ctx, save); !errors.Is(err, dao.ErrInsufficientSpace) {
+		t.Fatalf("got %v, want ErrInsufficientSpace", err)
+	}
+	if err := db.Model(&dao.FileStore{}).Where("user_id = ?", testViewer).Update("capacity", 1<<30).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	inbox, err := s.CreateFolder(ctx, &file.CreateFolderRequest{Name: "Inbox", UserId: testViewer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	save.ToFolderId = inbox.GetFolder().GetId()
+	saved, err := s.SaveToMyDrive(ctx, save)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.GetFiles()) != 1 {
+		t.Fatalf("saved %v, want report.txt", saved.GetFiles())
+	}
+	// 路径不沿用分享者的目录结构
+	f, err := s.repo.GetFile(ctx, int64(saved.GetFiles()[0].GetId()), testViewer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Path != "/Inbox/report.txt" {
+		t.Fatalf("saved path %q, want /Inbox/report.txt", f.Path)
+	}
+
+	if _, err := s.SaveToMyDrive(ctx, save); !errors.Is(err, dao.ErrShareLimitReached) {
+		t.Fatalf("second save: got %v, want ErrShareLimitReached", err)
+	}
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// CreateTeamSpace 为团队创建空间, 并将创建者设为所有者, 重复调用返回已有的空间
+func (s *FileServer) CreateTeamSpace(ctx context.Context, req *file.CreateTeamSpaceRequest) (*file.CreateTeamSpaceResponse, error) {
+	if req.GetTeamId() <= 0 || req.GetOwnerId() == 0 {
+		return nil, errors.New("invalid team")
+	}
+	if err := validateName(req.GetName()); err != nil {
+		return nil, err
+	}
+
+	space, err := s.repo.CreateSpace(ctx, req.GetTeamId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetSpaceMember(ctx, req.GetTeamId(), req.GetOwnerId(), dao.TeamRoleOwner); err != nil {
+		return nil, err
+	}
+
+	pb, err := s.toPbTeamSpace(ctx, space)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.CreateTeamSpaceResponse{Space: pb}, nil
+}
+
+// SetSpaceMember 同步团队成员的角色, 成员变更由用户服务维护, 发起变更的成员 operator_id 须有权授予该角色
+func (s *FileServer) SetSpaceMember(ctx context.Context, req *file.SetSpaceMemberRequest) (*file.SetSpaceMemberResponse, error) {
+	role := int8(req.GetRole())
+	if role < 0 || role > dao.TeamRoleOwner {
+		return nil, errors.New("invalid role")
+	}
+	if _, err := s.repo.GetSpace(ctx, req.GetTeamId()); err != nil {
+		return nil, err
+	}
+	if err := s.checkSetSpaceMember(ctx, req.GetTeamId(), req.GetOperatorId(), req.GetUserId(), role); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetSpaceMember(ctx, req.GetTeamId(), req.GetUserId(), role); err != nil {
+		return nil, err
+	}
+
+	return &file.SetSpaceMemberResponse{}, nil
+}
+
+// GetTeamSpace 获取团队空间的根文件夹和共享的存储空间, 仅成员可见
+func (s *FileServer) GetTeamSpace(ctx context.Context, req *file.GetTeamSpaceRequest) (*file.GetTeamSpaceResponse, error) {
+	role, err := s.spaceMember(ctx, req.GetTeamId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	space, err := s.repo.GetSpace(ctx, req.GetTeamId())
+	if err != nil {
+		return nil, err
+	}
+
+	pb, err := s.toPbTeamSpace(ctx, space)
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.GetTeamSpaceResponse{Space: pb, Role: int32(role)}, nil
+}
+
+// ListSpaceActivity 按时间倒序分页获取团队空间的动态, 仅成员可见
+func (s *FileServer) ListSpaceActivity(ctx context.Context, req *file.ListSpaceActivityRequest) (*file.ListSpaceActivityResponse, error) {
+	if _, err := s.spaceMember(ctx, req.GetTeamId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	activities, total, err := s.repo.ListSpaceActivity(ctx, req.GetTeamId(), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListSpaceActivityResponse{Total: total, Activities: make([]*file.SpaceActivity, 0, len(activities))}
+	for _, a := range activities {
+		resp.Activities = append(resp.Activities, &file.SpaceActivity{
+			Id:       a.Id,
+			UserId:   a.UserId,
+			Action:   a.Action,
+			ItemType: a.ItemType,
+			ItemId:   a.ItemId,
+			Name:     a.Name,
+			Ctime:    a.Ctime,
+		})
+	}
+
+	return resp, nil
+}
+
+// checkSetSpaceMember 检查 operator 能否将 uid 的角色设为 role, 规则与用户服务的团队成员管理一致:
+// 只有所有者能设置所有者; 其余变更只能作用于角色低于自己的成员, 且只能授予低于自己的角色;
+// 成员可以退出团队, 所有者在团队还有其他所有者时(转让团队)才能降级自己
+func (s *FileServer) checkSetSpaceMember(ctx context.Context, teamId, operator, uid int32, role int8) error {
+	me, err := s.spaceMember(ctx, teamId, operator)
+	if err != nil {
+		return err
+	}
+
+	if uid == operator {
+		if role == me || (role == 0 && me != dao.TeamRoleOwner) {
+			return nil
+		}
+		if me != dao.TeamRoleOwner {
+			return dao.ErrPermissionDenied
+		}
+		owners, err := s.repo.CountSpaceMembers(ctx, teamId, dao.TeamRoleOwner)
+		if err != nil {
+			return err
+		}
+		if owners < 2 {
+			return dao.ErrPermissionDenied
+		}
+		return nil
+	}
+
+	if role == dao.TeamRoleOwner {
+		if me != dao.TeamRoleOwner {
+			return dao.ErrPermissionDenied
+		}
+		return nil
+	}
+	target, err := s.repo.GetSpaceMemberRole(ctx, teamId, uid)
+	if err != nil {
+		return err
+	}
+	if target >= me || role >= me {
+		return dao.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// spaceMember 获取用户在团队中的角色, 不是成员时返回 ErrPermissionDenied
+func (s *FileServer) spaceMember(ctx context.Context, teamId, uid int32) (int8, error) {
+	role, err := s.repo.GetSpaceMemberRole(ctx, teamId, uid)
+	if err != nil {
+		return 0, err
+	}
+	if role == 0 {
+		return 0, dao.ErrPermissionDenied
+	}
+
+	return role, nil
+}
+
+// checkTransfer 检查 uid 能否将 from 的条目转移到 to 的网盘
+// 只允许在个人网盘和团队空间之间或团队空间之间转移, 且 uid 须是源网盘的所有者或源团队的管理员
+func (s *FileServer) checkTransfer(ctx context.Context, uid, from, to int32) error {
+	if dao.SpaceTeamId(from) == 0 && dao.SpaceTeamId(to) == 0 {
+		return ErrCrossOwner
+	}
+	if from == uid {
+		return nil
+	}
+
+	teamId := dao.SpaceTeamId(from)
+	if teamId == 0 {
+		return dao.ErrPermissionDenied
+	}
+	role, err := s.repo.GetSpaceMemberRole(ctx, teamId, uid)
+	if err != nil {
+		return err
+	}
+	if role < dao.TeamRoleAdmin {
+		return dao.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// transferFile 将文件转移到另一个网盘的文件夹 to, 目标文件夹中的同名条目按 policy 处理
+// 覆盖同名文件时源文件的内容成为目标文件的新版本, 源文件移入原网盘的回收站
+func (s *FileServer) transferFile(ctx context.Context, f dao.File, to int64, toOwner int32, policy file.NameConflictPolicy) (batchOutcome, error) {
+	res, err := s.resolveFileInFolder(ctx, policy, f.Name, to, toOwner)
+	if err != nil || res.skip {
+		return batchOutcome{name: res.name, skipped: res.skip}, err
+	}
+
+	if res.overwrite != nil {
+		if err := s.ensureCapacity(ctx, toOwner, f.Size); err != nil {
+			return batchOutcome{}, err
+		}
+		metas, err := s.repo.GetFileMeta(ctx, f.Id, f.UserId)
+		if err != nil {
+			return batchOutcome{}, err
+		}
+
+		src := f
+		src.UserId, src.Metas = toOwner, metas
+		var dst dao.File
+		err = s.repo.Transaction(ctx, func(r *repository.UploadRepo) error {
+			var err error
+			if dst, err = r.OverwriteFile(ctx, res.overwrite.Id, toOwner, src); err != nil {
+				return err
+			}
+			return r.DeleteFile(ctx, f.Id, f.UserId)
+		})
+		if err != nil {
+			return batchOutcome{}, err
+		}
+		return batchOutcome{name: dst.Name, newId: dst.Id, version: dst.Version}, nil
+	}
+
+	if err := s.repo.TransferFile(ctx, f.Id, f.UserId, to, toOwner, res.name); err != nil {
+		return batchOutcome{}, conflictError(err, res.name)
+	}
+
+	return batchOutcome{name: res.name}, nil
+}
+
+// transferFolder 将文件夹子树转移到另一个网盘的文件夹 to 并命名为 name, 同名条目按 policy 处理
+func (s *FileServer) transferFolder(ctx context.Context, folder dao.Folder, to int64, toOwner int32, name string, policy file.NameConflictPolicy) (dao.Folder, bool, error) {
+	name, skip, err := s.resolveInFolder(ctx, policy, name, true, to, toOwner, dao.NameEntry{})
+	if err != nil || skip {
+		return dao.Folder{}, skip, err
+	}
+
+	moved, err := s.repo.TransferFolder(ctx, folder.Id, folder.UserId, to, toOwner, name)
+	if err != nil {
+		return dao.Folder{}, false, conflictError(err, name)
+	}
+
+	return moved, false, nil
+}
+
+// recordTransfer 在转出和转入的团队空间中分别记录动态
+func (s *FileServer) recordTransfer(ctx context.Context, from, to, uid int32, isFolder bool, id int64, name string) {
+	s.recordActivity(ctx, from, uid, dao.ActivityTransOut, isFolder, id, name)
+	s.recordActivity(ctx, to, uid, dao.ActivityTransfer, isFolder, id, name)
+}
+
+// recordActivity 条目属于团队空间时记录一条动态, 记录失败不影响操作本身
+func (s *FileServer) recordActivity(ctx context.Context, owner, uid int32, action string, isFolder bool, id int64, name string) {
+	teamId := dao.SpaceTeamId(owner)
+	if teamId == 0 {
+		return
+	}
+
+	itemType := "file"
+	if isFolder {
+		itemType = "folder"
+	}
+	err := s.repo.RecordSpaceActivity(ctx, &dao.SpaceActivity{
+		TeamId:   teamId,
+		UserId:   uid,
+		Action:   action,
+		ItemType: itemType,
+		ItemId:   id,
+		Name:     name,
+	})
+	if err != nil {
+		log.Printf("failed to record activity of team %d: %v", teamId, err)
+	}
+}
+
+// checkSpaceRoot 团队空间的根文件夹不能删除、移动或重命名
+func checkSpaceRoot(folder dao.Folder) error {
+	if dao.SpaceTeamId(folder.UserId) != 0 && folder.ParentId == 0 {
+		return dao.ErrSpaceRoot
+	}
+
+	return nil
+}
+
+func (s *FileServer) toPbTeamSpace(ctx context.Context, space dao.Space) (*file.TeamSpace, error) {
+	store, err := s.repo.FindFileStoreById(ctx, dao.TeamOwnerId(space.TeamId))
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.TeamSpace{
+		TeamId:       space.TeamId,
+		Name:         space.Name,
+		RootFolderId: space.RootFolderId,
+		FileStore:    toPbFileStore(store),
+		Ctime:        space.Ctime,
+	}, nil
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// 写入路径: 登记上传(pending) -> 写入对象(stored) -> 在创建文件记录的事务中提交(committed)
+// 文件记录可见时对象一定已经写入; 进程在中途退出时, 修复任务重试提交或删除对象并释放预留
+const (
+	// uploadStaleAfter 上传超过该时长没有进展时视为卡住, 由修复任务处理
+	uploadStaleAfter = 10 * time.Minute
+	// uploadStoreTimeout 登记上传到写入对象的期限, 短于 uploadStaleAfter, 修复任务处理的 pending 上传不会仍在写入
+	uploadStoreTimeout = uploadStaleAfter / 2
+	// uploadRepairAttempts 修复任务重试提交的次数上限, 超过后放弃上传
+	uploadRepairAttempts = 5
+	// uploadRepairBatch 修复任务每轮处理的上传数
+	uploadRepairBatch = 100
+	// uploadRetention 已提交的上传日志的保留时长
+	uploadRetention = 24 * time.Hour
+)
+
+// storeObject 登记上传, 加密 data 并写入 f.ObjectKey, 成功后上传处于 stored 状态
+// 失败时放弃上传, 调用方持有的预留和名额由调用方自行释放
+func (s *FileServer) storeObject(ctx context.Context, intent *dao.UploadIntent, f *dao.File, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, uploadStoreTimeout)
+	defer cancel()
+
+	record, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	intent.ObjectKey = f.ObjectKey
+	intent.Size = f.Size
+	intent.Record = record
+	if err := s.repo.CreateUploadIntent(ctx, intent); err != nil {
+		return err
+	}
+
+	sealed, err := s.keys.Seal(ctx, intent.UserId, intent.ObjectKey, data)
+	if err == nil {
+		err = s.store.Put(ctx, intent.ObjectKey, sealed)
+	}
+	if err == nil {
+		var ok bool
+		if ok, err = s.repo.SetUploadState(ctx, intent.Id, dao.UploadPending, dao.UploadStored); err == nil && !ok {
+			err = dao.ErrUploadAborted
+		}
+	}
+	if err != nil {
+		s.abortUpload(intent)
+		return err
+	}
+	intent.State = dao.UploadStored
+
+	return nil
+}
+
+// commitUpload 在一个事务中执行 apply 并将上传转为 committed, 上传已被放弃时返回 ErrUploadAborted
+func (s *FileServer) commitUpload(ctx context.Context, intent *dao.UploadIntent, apply func(r *repository.UploadRepo) error) error {
+	err := s.repo.Transaction(ctx, func(r *repository.UploadRepo) error {
+		if err := apply(r); err != nil {
+			return err
+		}
+		ok, err := r.SetUploadState(ctx, intent.Id, dao.UploadStored, dao.UploadCommitted)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return dao.ErrUploadAborted
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	intent.State = dao.UploadCommitted
+
+	return nil
+}
+
+// commitCreate 提交新建文件的上传, 与 commitFile 一样在同一事务中将预留转为已用空间
+func (s *FileServer) commitCreate(ctx context.Context, intent *dao.UploadIntent, f *dao.File, res nameResolution) (batchOutcome, error) {
+	var out batchOutcome
+	err := s.commitUpload(ctx, intent, func(r *repository.UploadRepo) error {
+		var err error
+		if out, err = s.withRepo(r).createFile(ctx, f, res); err != nil {
+			return err
+		}
+		if err := s.fileCommitted(ctx, r, out); err != nil {
+			return err
+		}
+		return r.ReleaseQuota(ctx, intent.ReservationId, f.UserId)
+	})
+
+	return out, err
+}
+
+// commitUpdate 提交全量更新文件内容的上传, 内容增大时在同一事务中将预留转为已用空间
+func (s *FileServer) commitUpdate(ctx context.Context, intent *dao.UploadIntent, f *dao.File) error {
+	return s.commitUpload(ctx, intent, func(r *repository.UploadRepo) error {
+		if err := r.UpdateFile(ctx, f); err != nil {
+			return err
+		}
+		if err := s.fileCommitted(ctx, r, batchOutcome{newId: f.Id, version: f.Version}); err != nil {
+			return err
+		}
+		if intent.ReservationId == "" {
+			return nil
+		}
+		return r.ReleaseQuota(ctx, intent.ReservationId, f.UserId)
+	})
+}
+
+// abortUpload 请求失败时放弃上传并删除已写入的对象, 预留和名额由请求自行释放
+// 删除失败时上传保持 aborted 状态, 由修复任务重试
+func (s *FileServer) abortUpload(intent *dao.UploadIntent) {
+	// 请求的 ctx 可能已经取消, 清理使用独立的 ctx
+	ctx := context.Background()
+	ok, err := s.repo.AbortUploadIntent(ctx, intent.Id, false)
+	if err != nil || !ok {
+		// 未能标记时上传仍处于 pending 或 stored, 或者已被修复任务接手, 都交给修复任务处理
+		if err != nil {
+			log.Printf("failed to abort upload %s: %v", intent.ObjectKey, err)
+		}
+		return
+	}
+	intent.State = dao.UploadAborted
+	intent.ReservationId, intent.RequestId = "", ""
+
+	if err := s.cleanupUpload(ctx, intent); err != nil {
+		log.Printf("failed to clean up aborted upload %s: %v", intent.ObjectKey, err)
+		if err := s.repo.RecordUploadFailure(ctx, intent.Id, err.Error()); err != nil {
+			log.Printf("failed to record failure of upload %s: %v", intent.ObjectKey, err)
+		}
+	}
+}
+
+// cleanupUpload 删除已放弃的上传写入的对象和数据密钥, 释放仍由上传持有的预留和名额, 最后删除日志
+func (s *FileServer) cleanupUpload(ctx context.Context, intent *dao.UploadIntent) error {
+	if err := s.store.Delete(ctx, intent.ObjectKey); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteBlobKey(ctx, intent.ObjectKey); err != nil {
+		return err
+	}
+	if intent.ReservationId != "" {
+		if err := s.repo.ReleaseQuota(ctx, intent.ReservationId, intent.UserId); err != nil {
+			return err
+		}
+	}
+	if intent.RequestId != "" {
+		if err := s.repo.ReleaseFileRequestSlot(ctx, intent.RequestId, intent.Size); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.DeleteUploadIntent(ctx, intent.Id)
+}
+
+// RunUploadRepairer 按 interval 周期性地修复卡住的上传, ctx 结束时退出
+func (s *FileServer) RunUploadRepairer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.repairUploads(ctx, time.Now()); err != nil {
+				log.Printf("failed to repair uploads: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// repairUploads 处理 now 之前 uploadStaleAfter 没有进展的上传, 并清理过期的上传日志
+func (s *FileServer) repairUploads(ctx context.Context, now time.Time) error {
+	if _, err := s.repo.PurgeCommittedUploads(ctx, now.Add(-uploadRetention).Unix()); err != nil {
+		log.Printf("failed to purge committed uploads: %v", err)
+	}
+
+	intents, err := s.repo.ListStaleUploads(ctx, now.Add(-uploadStaleAfter).Unix(), uploadRepairBatch)
+	if err != nil {
+		return err
+	}
+	for i := range intents {
+		intent := &intents[i]
+		// 多个实例同时修复时只有一个能认领
+		ok, err := s.repo.ClaimUploadIntent(ctx, intent)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		if err := s.repairUpload(ctx, intent); err != nil {
+			log.Printf("failed to repair upload %s: %v", intent.ObjectKey, err)
+			if err := s.repo.RecordUploadFailure(ctx, intent.Id, err.Error()); err != nil {
+				log.Printf("failed to record failure of upload %s: %v", intent.ObjectKey, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// repairUpload 修复一个卡住的上传
+// 对象已写入的上传重试提交, 对象不存在、多次提交失败或无法重试的上传回滚
+func (s *FileServer) repairUpload(ctx context.Context, intent *dao.UploadIntent) error {
+	switch intent.State {
+	case dao.UploadPending:
+		// 进程在写入对象时退出, 对象的写入是原子的, 存在即说明已完整写入
+		_, err := s.store.Stat(ctx, intent.ObjectKey)
+		if errors.Is(err, mws.ErrBlobNotFound) {
+			return s.rollbackUpload(ctx, intent)
+		}
+		if err != nil {
+			return err
+		}
+		ok, err := s.repo.SetUploadState(ctx, intent.Id, dao.UploadPending, dao.UploadStored)
+		if err != nil || !ok {
+			return err
+		}
+		intent.State = dao.UploadStored
+		return s.retryCommit(ctx, intent)
+	case dao.UploadStored:
+		return s.retryCommit(ctx, intent)
+	case dao.UploadAborted:
+		return s.cleanupUpload(ctx, intent)
+	}
+
+	return nil
+}
+
+// retryCommit 按日志中的文件记录重新提交上传
+func (s *FileServer) retryCommit(ctx context.Context, intent *dao.UploadIntent) error {
+	// 匿名上传者已经收到失败, 不再替其提交
+	if intent.Op == dao.UploadOpRequest || intent.Attempts >= uploadRepairAttempts {
+		return s.rollbackUpload(ctx, intent)
+	}
+	var f dao.File
+	if err := json.Unmarshal(intent.Record, &f); err != nil {
+		return s.rollbackUpload(ctx, intent)
+	}
+
+	switch intent.Op {
+	case dao.UploadOpCreate:
+		res, err := s.resolveFileInFolder(ctx, file.NameConflictPolicy(intent.Policy), f.Name, f.FolderId, f.UserId)
+		if err != nil {
+			return err
+		}
+		if res.skip {
+			return s.rollbackUpload(ctx, intent)
+		}
+		out, err := s.commitCreate(ctx, intent, &f, res)
+		if err != nil {
+			return err
+		}
+		s.recordActivity(ctx, f.UserId, f.UserId, dao.ActivityUpload, false, out.newId, out.name)
+		s.publishEvent(&mws.FileChangeEvent{
+			EventType: "upload",
+			FileId:    out.newId,
+			FolderId:  f.FolderId,
+			UserId:    f.UserId,
+			Name:      out.name,
+			Size:      f.Size,
+		})
+		return nil
+	case dao.UploadOpUpdate:
+		// 文件在此期间被删除或更新过时, 提交会覆盖更新的内容, 放弃
+		cur, err := s.repo.FindFile(ctx, intent.FileId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.rollbackUpload(ctx, intent)
+		}
+		if err != nil {
+			return err
+		}
+		if cur.Version != intent.BaseVersion {
+			return s.rollbackUpload(ctx, intent)
+		}
+		return s.commitUpdate(ctx, intent, &f)
+	}
+
+	return s.rollbackUpload(ctx, intent)
+}
+
+// rollbackUpload 修复任务放弃上传, 删除对象并释放上传仍持有的预留和名额
+func (s *FileServer) rollbackUpload(ctx context.Context, intent *dao.UploadIntent) error {
+	ok, err := s.repo.AbortUploadIntent(ctx, intent.Id, true)
+	if err != nil || !ok {
+		return err
+	}
+	intent.State = dao.UploadAborted
+
+	return s.cleanupUpload(ctx, intent)
+}
@@ -0,0 +1,570 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+var errInjected = errors.New("injected blob store failure")
+
+// faultyStore 在本地驱动外包一层, 按需让写入和删除失败, beforePut 在写入前调用, 用于模拟并发的提交
+// putDeadline 记录最近一次写入的期限
+type faultyStore struct {
+	mws.BlobStore
+
+	mu          sync.Mutex
+	failPut     bool
+	failDelete  bool
+	beforePut   func()
+	putDeadline time.Time
+}
+
+func (f *faultyStore) Put(ctx context.Context, key string, data []byte) error {
+	f.mu.Lock()
+	fail, hook := f.failPut, f.beforePut
+	f.putDeadline, _ = ctx.Deadline()
+	f.mu.Unlock()
+	if hook != nil {
+		hook()
+	}
+	if fail {
+		return errInjected
+	}
+
+	return f.BlobStore.Put(ctx, key, data)
+}
+
+func (f *faultyStore) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	fail := f.failDelete
+	f.mu.Unlock()
+	if fail {
+		return errInjected
+	}
+
+	return f.BlobStore.Delete(ctx, key)
+}
+
+func (f *faultyStore) set(fn func(f *faultyStore)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn(f)
+}
+
+// chunkStream 依次返回 chunks 的 v2 分片上传流
+type chunkStream struct {
+	grpc.ServerStream
+	chunks []*file.UploadChunkRequest
+	resp   *file.UploadChunkResponse
+}
+
+func (c *chunkStream) Context() context.Context {
+	return context.Background()
+}
+
+func (c *chunkStream) Recv() (*file.UploadChunkRequest, error) {
+	if len(c.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := c.chunks[0]
+	c.chunks = c.chunks[1:]
+	return chunk, nil
+}
+
+func (c *chunkStream) SendAndClose(resp *file.UploadChunkResponse) error {
+	c.resp = resp
+	return nil
+}
+
+const testUser int32 = 1
+
+func newUploadTestServer(t *testing.T) (*FileServer, *faultyStore, *gorm.DB) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger:         logger.Discard,
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 内存数据库每个连接各自独立, 只保留一个连接
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	err = db.AutoMigrate(dao.Models()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&dao.FileStore{UserId: testUser, Capacity: 1 << 30}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	local, err := mws.NewLocalStore(t.TempDir(), "http://localhost/blob", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &faultyStore{BlobStore: local}
+	repo := repository.NewUploadRepo(dao.NewUploadDao(db, dao.Naming{}), nil)
+	s := &FileServer{
+		repo:  repo,
+		store: store,
+		keys:  NewKeyManager(repo, &mws.Keyring{}),
+
+		shareSecret: ShareSecret("test secret"),
+	}
+
+	return s, store, db
+}
+
+func uploadRequest(name string, data []byte) *file.UploadRequest {
+	sum := sha256.Sum256(data)
+	return &file.UploadRequest{
+		Metadata: &file.FileMetaData{
+			Name:        name,
+			Hash:        hex.EncodeToString(sum[:]),
+			Size:        int64(len(data)),
+			ContentType: "txt",
+			UserId:      testUser,
+		},
+		Data: data,
+	}
+}
+
+// ageUploads 让所有上传看起来已经卡住
+func ageUploads(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	old := time.Now().Add(-2 * uploadStaleAfter).Unix()
+	if err := db.Model(&dao.UploadIntent{}).Where("1 = 1").Update("utime", old).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// createConflict 返回只执行一次的 beforePut, 创建与上传同名的 a.txt
+func createConflict(t *testing.T, s *FileServer, f *faultyStore) func() {
+	return func() {
+		f.set(func(f *faultyStore) { f.beforePut = nil })
+		err := s.repo.CreateFile(context.Background(), &dao.File{Name: "a.txt", Hash: "h0", Type: "txt", UserId: testUser, ObjectKey: "other"})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func countRows(t *testing.T, db *gorm.DB, model any, query string, args ...any) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Model(model).Where(query, args...).Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func fileStore(t *testing.T, db *gorm.DB) dao.FileStore {
+	t.Helper()
+	var fs dao.FileStore
+	if err := db.Where("user_id = ?", testUser).First(&fs).Error; err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func readObject(t *testing.T, s *FileServer, key string) string {
+	t.Helper()
+	rc, err := s.store.Get(context.Background(), key, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestUploadCommitsAfterObjectIsStored(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	resp, err := s.Upload(ctx, uploadRequest("a.txt", []byte("hello")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var f dao.File
+	if err := db.First(&f, resp.GetId()).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got := readObject(t, s, f.ObjectKey); got != "hello" {
+		t.Fatalf("object content = %q", got)
+	}
+	var intent dao.UploadIntent
+	if err := db.Where("object_key = ?", f.ObjectKey).First(&intent).Error; err != nil {
+		t.Fatal(err)
+	}
+	if intent.State != dao.UploadCommitted {
+		t.Fatalf("intent state = %d, want committed", intent.State)
+	}
+	if fs := fileStore(t, db); fs.CurrentSize != 5 || fs.Reserved != 0 {
+		t.Fatalf("file store = %+v", fs)
+	}
+}
+
+func TestUploadStoreHasDeadline(t *testing.T) {
+	s, store, _ := newUploadTestServer(t)
+
+	start := time.Now()
+	if _, err := s.Upload(context.Background(), uploadRequest("a.txt", []byte("hello"))); err != nil {
+		t.Fatal(err)
+	}
+	// 写入结束前修复任务不会把上传当作卡住
+	store.mu.Lock()
+	deadline := store.putDeadline
+	store.mu.Unlock()
+	if deadline.IsZero() || !deadline.Before(start.Add(uploadStaleAfter)) {
+		t.Fatalf("put deadline %v, want one before the upload goes stale at %v", deadline, start.Add(uploadStaleAfter))
+	}
+}
+
+func TestUploadStoreFailureLeavesNoRow(t *testing.T) {
+	s, store, db := newUploadTestServer(t)
+	store.set(func(f *faultyStore) { f.failPut = true })
+
+	_, err := s.Upload(context.Background(), uploadRequest("a.txt", []byte("hello")))
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("err = %v, want injected failure", err)
+	}
+	if n := countRows(t, db, &dao.File{}, "1 = 1"); n != 0 {
+		t.Fatalf("%d file rows after failed upload", n)
+	}
+	if n := countRows(t, db, &dao.UploadIntent{}, "1 = 1"); n != 0 {
+		t.Fatalf("%d intents left after failed upload", n)
+	}
+	if fs := fileStore(t, db); fs.CurrentSize != 0 || fs.Reserved != 0 {
+		t.Fatalf("file store = %+v", fs)
+	}
+}
+
+func TestUploadCommitFailureDeletesObject(t *testing.T) {
+	s, store, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	// 写入对象期间另一个请求抢先创建了同名文件, 提交时名称冲突
+	store.set(func(f *faultyStore) { f.beforePut = createConflict(t, s, f) })
+
+	req := uploadRequest("a.txt", []byte("hello"))
+	req.Metadata.ConflictPolicy = file.NameConflictPolicy_NAME_CONFLICT_FAIL
+	if _, err := s.Upload(ctx, req); err == nil {
+		t.Fatal("upload succeeded despite the name conflict")
+	}
+
+	if n := countRows(t, db, &dao.File{}, "object_key <> ?", "other"); n != 0 {
+		t.Fatalf("%d rows for the failed upload", n)
+	}
+	if n := countRows(t, db, &dao.UploadIntent{}, "1 = 1"); n != 0 {
+		t.Fatalf("%d intents left after failed commit", n)
+	}
+	objects, err := s.store.List(ctx, "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 0 {
+		t.Fatalf("objects left after failed commit: %+v", objects)
+	}
+}
+
+func TestAbortedUploadIsCleanedUpByRepairer(t *testing.T) {
+	s, store, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	// 提交失败且对象存储暂时无法删除, 上传保持 aborted 等待修复
+	store.set(func(f *faultyStore) {
+		f.beforePut = createConflict(t, s, f)
+		f.failDelete = true
+	})
+	req := uploadRequest("a.txt", []byte("hello"))
+	req.Metadata.ConflictPolicy = file.NameConflictPolicy_NAME_CONFLICT_FAIL
+	if _, err := s.Upload(ctx, req); err == nil {
+		t.Fatal("upload succeeded despite the name conflict")
+	}
+	var intent dao.UploadIntent
+	if err := db.First(&intent).Error; err != nil {
+		t.Fatal(err)
+	}
+	if intent.State != dao.UploadAborted || intent.ReservationId != "" {
+		t.Fatalf("intent = %+v, want aborted without reservation", intent)
+	}
+
+	store.set(func(f *faultyStore) { f.failDelete = false })
+	ageUploads(t, db)
+	if err := s.repairUploads(ctx, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, db, &dao.UploadIntent{}, "1 = 1"); n != 0 {
+		t.Fatalf("%d intents left after repair", n)
+	}
+	if _, err := s.store.Stat(ctx, intent.ObjectKey); !errors.Is(err, mws.ErrBlobNotFound) {
+		t.Fatalf("stat after repair: %v", err)
+	}
+}
+
+func TestRepairRetriesStoredUpload(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	// 对象已写入, 进程在提交前退出
+	if err := s.reserveQuota(ctx, "r1", testUser, 5); err != nil {
+		t.Fatal(err)
+	}
+	intent := &dao.UploadIntent{Op: dao.UploadOpCreate, UserId: testUser, ReservationId: "r1"}
+	f := &dao.File{Name: "a.txt", Hash: "h1", Type: "txt", Size: 5, UserId: testUser, ObjectKey: "k1_a.txt"}
+	if err := s.storeObject(ctx, intent, f, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, db, &dao.File{}, "1 = 1"); n != 0 {
+		t.Fatal("file row visible before commit")
+	}
+
+	// 未卡住的上传不受影响
+	if err := s.repairUploads(ctx, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, db, &dao.File{}, "1 = 1"); n != 0 {
+		t.Fatal("repairer committed an upload that is still in progress")
+	}
+
+	ageUploads(t, db)
+	if err := s.repairUploads(ctx, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	var got dao.File
+	if err := db.Where("object_key = ?", "k1_a.txt").First(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "a.txt" || got.Size != 5 {
+		t.Fatalf("committed file = %+v", got)
+	}
+	if n := countRows(t, db, &dao.UploadIntent{}, "state = ?", dao.UploadCommitted); n != 1 {
+		t.Fatal("intent not committed")
+	}
+	if fs := fileStore(t, db); fs.CurrentSize != 5 || fs.Reserved != 0 {
+		t.Fatalf("file store = %+v", fs)
+	}
+}
+
+func TestRepairCommitsPendingUploadWithObject(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	// 对象写入完成, 进程在标记 stored 之前退出
+	f := &dao.File{Name: "a.txt", Hash: "h1", Type: "txt", Size: 5, UserId: testUser, ObjectKey: "k1_a.txt"}
+	intent := &dao.UploadIntent{Op: dao.UploadOpCreate, UserId: testUser, ObjectKey: f.ObjectKey, Record: []byte(`{"Name":"a.txt","Hash":"h1","Type":"txt","Size":5,"UserId":1,"ObjectKey":"k1_a.txt"}`)}
+	if err := s.repo.CreateUploadIntent(ctx, intent); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.store.Put(ctx, f.ObjectKey, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	ageUploads(t, db)
+	if err := s.repairUploads(ctx, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, db, &dao.File{}, "object_key = ?", f.ObjectKey); n != 1 {
+		t.Fatal("pending upload with a stored object was not committed")
+	}
+}
+
+func TestRepairRollsBackPendingUploadWithoutObject(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	// 进程在写入对象时退出, 预留仍然存在
+	if err := s.reserveQuota(ctx, "r1", testUser, 5); err != nil {
+		t.Fatal(err)
+	}
+	intent := &dao.UploadIntent{Op: dao.UploadOpCreate, UserId: testUser, ReservationId: "r1", ObjectKey: "k1_a.txt", Size: 5}
+	if err := s.repo.CreateUploadIntent(ctx, intent); err != nil {
+		t.Fatal(err)
+	}
+
+	ageUploads(t, db)
+	if err := s.repairUploads(ctx, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRows(t, db, &dao.UploadIntent{}, "1 = 1"); n != 0 {
+		t.Fatalf("%d intents left after rollback", n)
+	}
+	if n := countRows(t, db, &dao.File{}, "1 = 1"); n != 0 {
+		t.Fatal("rolled back upload created a file")
+	}
+	if fs := fileStore(t, db); fs.Reserved != 0 {
+		t.Fatalf("reservation not released: %+v", fs)
+	}
+}
+
+func TestRepairGivesUpAfterRepeatedFailures(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	// 同名文件已存在且同名策略为报错, 每次重试提交都会失败
+	if err := s.repo.CreateFile(ctx, &dao.File{Name: "a.txt", Hash: "h0", Type: "txt", UserId: testUser, ObjectKey: "other"}); err != nil {
+		t.Fatal(err)
+	}
+	intent := &dao.UploadIntent{
+		Op:     dao.UploadOpCreate,
+		UserId: testUser,
+		Policy: int32(file.NameConflictPolicy_NAME_CONFLICT_FAIL),
+	}
+	f := &dao.File{Name: "a.txt", Hash: "h1", Type: "txt", Size: 5, UserId: testUser, ObjectKey: "k1_a.txt"}
+	if err := s.storeObject(ctx, intent, f, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i <= uploadRepairAttempts; i++ {
+		ageUploads(t, db)
+		if err := s.repairUploads(ctx, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := countRows(t, db, &dao.UploadIntent{}, "1 = 1"); n != 0 {
+		t.Fatalf("%d intents left after giving up", n)
+	}
+	if _, err := s.store.Stat(ctx, f.ObjectKey); !errors.Is(err, mws.ErrBlobNotFound) {
+		t.Fatalf("stat after giving up: %v", err)
+	}
+	if n := countRows(t, db, &dao.File{}, "hash = ?", "h1"); n != 0 {
+		t.Fatal("upload committed despite the conflict")
+	}
+}
+
+func TestUpdateFileStoreFailureKeepsOldContent(t *testing.T) {
+	s, store, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	resp, err := s.Upload(ctx, uploadRequest("a.txt", []byte("hello")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var before dao.File
+	if err := db.First(&before, resp.GetId()).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	store.set(func(f *faultyStore) { f.failPut = true })
+	_, err = s.UpdateFile(ctx, &file.UpdateFileRequest{
+		FileId:      before.Id,
+		UserId:      testUser,
+		Data:        []byte("world"),
+		BaseVersion: int64(before.Version),
+	})
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("err = %v, want injected failure", err)
+	}
+	var after dao.File
+	if err := db.First(&after, before.Id).Error; err != nil {
+		t.Fatal(err)
+	}
+	if after.ObjectKey != before.ObjectKey || after.Version != before.Version {
+		t.Fatalf("file changed after failed update: %+v", after)
+	}
+	if got := readObject(t, s, after.ObjectKey); got != "hello" {
+		t.Fatalf("object content = %q", got)
+	}
+
+	store.set(func(f *faultyStore) { f.failPut = false })
+	if _, err := s.UpdateFile(ctx, &file.UpdateFileRequest{
+		FileId:      before.Id,
+		UserId:      testUser,
+		Data:        []byte("world"),
+		BaseVersion: int64(before.Version),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.First(&after, before.Id).Error; err != nil {
+		t.Fatal(err)
+	}
+	if after.ObjectKey == before.ObjectKey || after.Version != before.Version+1 {
+		t.Fatalf("file after update = %+v", after)
+	}
+	if got := readObject(t, s, after.ObjectKey); got != "world" {
+		t.Fatalf("object content = %q", got)
+	}
+}
+
+func TestFolderNamesFollowNaming(t *testing.T) {
+	ctx := context.Background()
+	for _, ci := range []bool{false, true} {
+		s, _, db := newUploadTestServer(t)
+		s.repo = repository.NewUploadRepo(dao.NewUploadDao(db, dao.Naming{CaseInsensitive: ci}), nil)
+
+		if _, err := s.CreateFolder(ctx, &file.CreateFolderRequest{Name: "Docs", UserId: testUser}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := s.CreateFolder(ctx, &file.CreateFolderRequest{Name: "docs", UserId: testUser})
+		if ci && !errors.Is(err, ErrNameConflict) {
+			t.Fatalf("case-insensitive: got %v, want ErrNameConflict", err)
+		}
+		// name_key 存名称的摘要, 区分大小写时两者共存
+		if !ci && err != nil {
+			t.Fatalf("case-sensitive: %v", err)
+		}
+	}
+}
+
+func TestUploadNamesAreValidated(t *testing.T) {
+	s, _, _ := newUploadTestServer(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"", "..", strings.Repeat("a", maxNameLength+1)} {
+		stream := &chunkStream{chunks: []*file.UploadChunkRequest{{Filename: name, Data: []byte("data"), FileSize: 4, UserId: testUser}}}
+		if err := s.UploadChunkStream(stream); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("stream upload named %q: got %v, want ErrInvalidName", name, err)
+		}
+	}
+
+	a, err := s.Upload(ctx, uploadRequest("a.txt", []byte("a")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Upload(ctx, uploadRequest("b.txt", []byte("b"))); err != nil {
+		t.Fatal(err)
+	}
+	update := func(name string) error {
+		_, err := s.UpdateFile(ctx, &file.UpdateFileRequest{FileId: int64(a.GetId()), UserId: testUser, Data: []byte("a2"), Name: name, BaseVersion: 1})
+		return err
+	}
+	if err := update(".."); !errors.Is(err, ErrInvalidName) {
+		t.Fatalf("rename to ..: got %v, want ErrInvalidName", err)
+	}
+	if err := update("b.txt"); !errors.Is(err, ErrNameConflict) {
+		t.Fatalf("rename onto b.txt: got %v, want ErrNameConflict", err)
+	}
+	if err := update("c.txt"); err != nil {
+		t.Fatal(err)
+	}
+}
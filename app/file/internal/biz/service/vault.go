@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// 保险库中的文件在客户端加密, 服务端只保存密文、加密后的名称和以成员公钥包裹的保险库密钥
+// 服务端无法读取内容, 因此秒传、搜索和预览对保险库中的条目不可用, 条目也不能移出或移入保险库
+const (
+	maxPublicKeySize  = 1024
+	maxEnvelopeSize   = 2048
+	maxPublicKeyQuery = 100
+)
+
+var (
+	// ErrVaultBoundary 条目不能跨越保险库的边界移动或复制
+	ErrVaultBoundary = errors.New("items cannot be moved or copied across vault boundaries")
+	// ErrVaultContent 服务端无法处理保险库中的密文
+	ErrVaultContent = errors.New("operation is not supported for vault content")
+	// ErrNotVault 文件夹不是保险库的根文件夹
+	ErrNotVault = errors.New("folder is not a vault")
+)
+
+// CreateVault 在 parent_id 下创建保险库, 保险库不能嵌套
+// 名称为客户端加密后的密文, key_envelope 为以创建者公钥包裹的保险库密钥
+func (s *FileServer) CreateVault(ctx context.Context, req *file.CreateVaultRequest) (*file.CreateVaultResponse, error) {
+	if err := validateName(req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := checkEnvelope(req.GetKeyEnvelope()); err != nil {
+		return nil, err
+	}
+	// 在共享的文件夹中创建时, 保险库属于其所有者
+	uid, err := s.folderOwner(ctx, req.GetUserId(), req.GetParentId(), dao.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	vault, err := s.folderVault(ctx, req.GetParentId())
+	if err != nil {
+		return nil, err
+	}
+	if vault != 0 {
+		return nil, fmt.Errorf("%w: vaults cannot be nested", ErrVaultBoundary)
+	}
+
+	name, _, err := s.resolveInFolder(ctx, file.NameConflictPolicy_NAME_CONFLICT_FAIL, req.GetName(), true, req.GetParentId(), uid, dao.NameEntry{})
+	if err != nil {
+		return nil, err
+	}
+	folder := &dao.Folder{
+		Name:     name,
+		UserId:   uid,
+		ParentId: req.GetParentId(),
+	}
+	if err := s.repo.CreateVault(ctx, folder, req.GetUserId(), req.GetKeyEnvelope()); err != nil {
+		return nil, conflictError(err, folder.Name)
+	}
+	s.recordActivity(ctx, uid, req.GetUserId(), dao.ActivityCreate, true, folder.Id, folder.Name)
+
+	return &file.CreateVaultResponse{Folder: toPbFolder(*folder)}, nil
+}
+
+// SetPublicKey 保存用户的公钥, 其他成员以此为用户生成保险库密钥的信封
+func (s *FileServer) SetPublicKey(ctx context.Context, req *file.SetPublicKeyRequest) (*file.SetPublicKeyResponse, error) {
+	if len(req.GetPublicKey()) == 0 || len(req.GetPublicKey()) > maxPublicKeySize {
+		return nil, errors.New("invalid public key")
+	}
+	if req.GetAlgorithm() == "" || len(req.GetAlgorithm()) > 32 {
+		return nil, errors.New("invalid key algorithm")
+	}
+
+	err := s.repo.SaveUserPublicKey(ctx, &dao.UserPublicKey{
+		UserId:    req.GetUserId(),
+		PublicKey: req.GetPublicKey(),
+		Algorithm: req.GetAlgorithm(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.SetPublicKeyResponse{}, nil
+}
+
+// GetPublicKeys 批量获取用户的公钥, 公钥是公开的, 任何用户都可以查询
+func (s *FileServer) GetPublicKeys(ctx context.Context, req *file.GetPublicKeysRequest) (*file.GetPublicKeysResponse, error) {
+	if len(req.GetUserIds()) == 0 {
+		return &file.GetPublicKeysResponse{}, nil
+	}
+	if len(req.GetUserIds()) > maxPublicKeyQuery {
+		return nil, fmt.Errorf("at most %d users per query", maxPublicKeyQuery)
+	}
+
+	keys, err := s.repo.GetUserPublicKeys(ctx, req.GetUserIds())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.GetPublicKeysResponse{Keys: make([]*file.UserPublicKey, 0, len(keys))}
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, &file.UserPublicKey{
+			UserId:    k.UserId,
+			PublicKey: k.PublicKey,
+			Algorithm: k.Algorithm,
+			Utime:     k.Utime,
+		})
+	}
+
+	return resp, nil
+}
+
+// PutVaultKeyEnvelope 为 recipient_id 保存保险库密钥的信封, 需要对保险库具有编辑权限
+// 接收者须已能访问保险库, 通常先通过 ShareWithUser 共享, 再由客户端以其公钥生成信封
+func (s *FileServer) PutVaultKeyEnvelope(ctx context.Context, req *file.PutVaultKeyEnvelopeRequest) (*file.PutVaultKeyEnvelopeResponse, error) {
+	if err := checkEnvelope(req.GetEnvelope()); err != nil {
+		return nil, err
+	}
+	vault, err := s.authorizeVault(ctx, req.GetUserId(), req.GetVaultId(), dao.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.repo.FolderRole(ctx, req.GetRecipientId(), vault)
+	if err != nil {
+		return nil, err
+	}
+	if role < dao.RoleViewer {
+		return nil, fmt.Errorf("%w: recipient has no access to the vault", dao.ErrPermissionDenied)
+	}
+
+	err = s.repo.SaveVaultKeyEnvelope(ctx, &dao.VaultKeyEnvelope{
+		VaultId:   vault.Id,
+		UserId:    req.GetRecipientId(),
+		Envelope:  req.GetEnvelope(),
+		CreatedBy: req.GetUserId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.PutVaultKeyEnvelopeResponse{}, nil
+}
+
+// GetVaultKeyEnvelope 获取当前用户的保险库密钥信封, 失去访问权限后不再返回
+func (s *FileServer) GetVaultKeyEnvelope(ctx context.Context, req *file.GetVaultKeyEnvelopeRequest) (*file.GetVaultKeyEnvelopeResponse, error) {
+	vault, err := s.authorizeVault(ctx, req.GetUserId(), req.GetVaultId(), dao.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := s.repo.GetVaultKeyEnvelope(ctx, vault.Id, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &file.GetVaultKeyEnvelopeResponse{
+		Envelope:  env.Envelope,
+		CreatedBy: env.CreatedBy,
+		Utime:     env.Utime,
+	}, nil
+}
+
+// RevokeVaultKeyEnvelope 删除 recipient_id 的信封, 需要对保险库具有编辑权限, 用户可以删除自己的信封
+func (s *FileServer) RevokeVaultKeyEnvelope(ctx context.Context, req *file.RevokeVaultKeyEnvelopeRequest) (*file.RevokeVaultKeyEnvelopeResponse, error) {
+	role := dao.RoleEditor
+	if req.GetRecipientId() == req.GetUserId() {
+		role = dao.RoleViewer
+	}
+	vault, err := s.authorizeVault(ctx, req.GetUserId(), req.GetVaultId(), role)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.DeleteVaultKeyEnvelope(ctx, vault.Id, req.GetRecipientId()); err != nil {
+		return nil, err
+	}
+
+	return &file.RevokeVaultKeyEnvelopeResponse{}, nil
+}
+
+// authorizeVault 获取保险库的根文件夹并检查 uid 对其至少具有 role 角色
+func (s *FileServer) authorizeVault(ctx context.Context, uid int32, vaultId int64, role int8) (dao.Folder, error) {
+	folder, err := s.authorizeFolder(ctx, uid, vaultId, role)
+	if err != nil {
+		return dao.Folder{}, err
+	}
+	if !isVaultRoot(folder) {
+		return dao.Folder{}, ErrNotVault
+	}
+
+	return folder, nil
+}
+
+// folderVault 获取文件夹所在的保险库, 0 表示根目录或不在保险库中
+func (s *FileServer) folderVault(ctx context.Context, folderId int64) (int64, error) {
+	if folderId == 0 {
+		return 0, nil
+	}
+
+	folder, err := s.repo.FindFolder(ctx, folderId)
+	if err != nil {
+		return 0, err
+	}
+
+	return folder.VaultId, nil
+}
+
+// checkVaultTarget 检查目标文件夹 to 所在的保险库为 vault
+func (s *FileServer) checkVaultTarget(ctx context.Context, to, vault int64) error {
+	toVault, err := s.folderVault(ctx, to)
+	if err != nil {
+		return err
+	}
+	if toVault != vault {
+		return ErrVaultBoundary
+	}
+
+	return nil
+}
+
+// checkShareVault 保险库中的条目不能通过链接分享, 应通过 ShareWithUser 和密钥信封共享给其他用户
+// 包含保险库的文件夹同样不能分享, 分享后才在其中创建的保险库在访问分享时被排除
+func (s *FileServer) checkShareVault(ctx context.Context, folderId int64, fileIds []int64) error {
+	if folderId != 0 {
+		folder, err := s.repo.FindFolder(ctx, folderId)
+		if err != nil {
+			return err
+		}
+		if folder.VaultId != 0 {
+			return ErrVaultContent
+		}
+		hasVault, err := s.repo.HasVaultUnder(ctx, folder)
+		if err != nil {
+			return err
+		}
+		if hasVault {
+			return ErrVaultContent
+		}
+	}
+	for _, id := range fileIds {
+		f, err := s.repo.FindFile(ctx, id)
+		if err != nil {
+			return err
+		}
+		if f.VaultId != 0 {
+			return ErrVaultContent
+		}
+	}
+
+	return nil
+}
+
+// folderMoveVault 文件夹移动后应在的保险库
+// 保险库的根文件夹只能移动到保险库之外, 其他文件夹只能在所在的保险库内移动
+func folderMoveVault(folder dao.Folder) int64 {
+	if isVaultRoot(folder) {
+		return 0
+	}
+
+	return folder.VaultId
+}
+
+// checkVaultCopy 检查文件夹子树可以复制, folders 为 root 的全部子文件夹
+// 复制保险库的根文件夹会产生共用密钥却没有信封的新保险库, 因此不允许复制包含保险库根文件夹的子树
+func checkVaultCopy(root dao.Folder, folders []dao.Folder) error {
+	if isVaultRoot(root) {
+		return fmt.Errorf("%w: a vault cannot be copied", ErrVaultBoundary)
+	}
+	if root.VaultId != 0 {
+		return nil
+	}
+	for _, f := range folders {
+		if f.VaultId != 0 {
+			return fmt.Errorf("%w: folder contains a vault", ErrVaultBoundary)
+		}
+	}
+
+	return nil
+}
+
+// vaultPolicy 保险库中的名称是密文, 自动重命名会破坏密文, 改为报错由客户端处理
+func (s *FileServer) vaultPolicy(ctx context.Context, policy file.NameConflictPolicy, folderId int64) (file.NameConflictPolicy, error) {
+	if policy != file.NameConflictPolicy_NAME_CONFLICT_RENAME {
+		return policy, nil
+	}
+	vault, err := s.folderVault(ctx, folderId)
+	if err != nil || vault == 0 {
+		return policy, err
+	}
+
+	return file.NameConflictPolicy_NAME_CONFLICT_FAIL, nil
+}
+
+func isVaultRoot(folder dao.Folder) bool {
+	return folder.VaultId != 0 && folder.VaultId == folder.Id
+}
+
+func checkEnvelope(envelope []byte) error {
+	if len(envelope) == 0 || len(envelope) > maxEnvelopeSize {
+		return errors.New("invalid key envelope")
+	}
+
+	return nil
+}
@@ -0,0 +1,526 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+var (
+	// ErrInvalidWebhookURL webhook 的地址不是 http 或 https 的绝对地址
+	ErrInvalidWebhookURL = errors.New("webhook url must be an absolute http or https url")
+	// ErrForbiddenWebhookAddress webhook 的地址解析到本机、内网等不允许投递的地址
+	ErrForbiddenWebhookAddress = errors.New("webhook address is not allowed")
+	// ErrTooManyWebhooks 用户的 webhook 数已达上限
+	ErrTooManyWebhooks = errors.New("too many webhooks")
+	// ErrDeliveryInProgress 投递尚未完成, 不能重新投递
+	ErrDeliveryInProgress = errors.New("delivery is still in progress")
+)
+
+const (
+	// maxWebhooks 每个用户的 webhook 数上限
+	maxWebhooks = 20
+	// webhookBatch 每轮投递的数量
+	webhookBatch = 50
+	// webhookWorkers 同时进行的投递数
+	webhookWorkers = 8
+	// webhookTimeout 一次投递的超时, webhookLease 须大于它
+	webhookTimeout = 10 * time.Second
+	webhookLease   = time.Minute
+	// webhookMaxAttempts 一次投递的尝试次数上限, 超过后放弃
+	webhookMaxAttempts = 8
+	// webhookBackoff 第一次重试的间隔, 之后每次加倍, 最长 webhookMaxBackoff
+	webhookBackoff    = 30 * time.Second
+	webhookMaxBackoff = time.Hour
+	// webhookDisableAfter 连续失败这么多次后自动禁用 webhook
+	webhookDisableAfter = 20
+	// webhookRetention 已完成的投递记录的保留时间
+	webhookRetention = 30 * 24 * time.Hour
+)
+
+// webhook 请求头
+const (
+	webhookHeaderEvent     = "X-Webhook-Event"
+	webhookHeaderDelivery  = "X-Webhook-Delivery"
+	webhookHeaderTimestamp = "X-Webhook-Timestamp"
+	webhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookDeliveries webhook 的投递次数, 按结果区分
+var WebhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "cloudstorage",
+	Subsystem: "file",
+	Name:      "webhook_deliveries_total",
+	Help:      "Number of webhook delivery attempts by outcome.",
+}, []string{"outcome"})
+
+// webhookClient 投递使用的 HTTP 客户端, 不跟随重定向, 3xx 视为失败
+// 不使用代理, 每次连接前检查 DNS 解析后的地址, 不能借 webhook 访问内网服务
+var webhookClient = &http.Client{
+	Timeout: webhookTimeout,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: 5 * time.Second, Control: webhookDialControl}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// CreateWebhook 创建 webhook, 签名密钥只在响应中返回这一次
+func (s *FileServer) CreateWebhook(ctx context.Context, req *file.CreateWebhookRequest) (*file.CreateWebhookResponse, error) {
+	w := &dao.Webhook{UserId: req.GetUserId(), FolderId: req.GetFolderId()}
+	if err := s.validateWebhook(ctx, w, req.GetUrl(), req.GetEventTypes()); err != nil {
+		return nil, err
+	}
+	n, err := s.repo.CountWebhooks(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if n >= maxWebhooks {
+		return nil, ErrTooManyWebhooks
+	}
+
+	if w.Secret, err = newWebhookSecret(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateWebhook(ctx, w); err != nil {
+		return nil, err
+	}
+
+	return &file.CreateWebhookResponse{Webhook: toPbWebhook(*w), Secret: w.Secret}, nil
+}
+
+// ListWebhooks 获取用户的全部 webhook
+func (s *FileServer) ListWebhooks(ctx context.Context, req *file.ListWebhooksRequest) (*file.ListWebhooksResponse, error) {
+	hooks, err := s.repo.ListWebhooks(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListWebhooksResponse{Webhooks: make([]*file.Webhook, 0, len(hooks))}
+	for _, w := range hooks {
+		resp.Webhooks = append(resp.Webhooks, toPbWebhook(w))
+	}
+
+	return resp, nil
+}
+
+// UpdateWebhook 修改 webhook 并重新启用, 被自动禁用的 webhook 通过它恢复
+func (s *FileServer) UpdateWebhook(ctx context.Context, req *file.UpdateWebhookRequest) (*file.UpdateWebhookResponse, error) {
+	w, err := s.repo.GetWebhook(ctx, req.GetUserId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w.FolderId = req.GetFolderId()
+	if err := s.validateWebhook(ctx, &w, req.GetUrl(), req.GetEventTypes()); err != nil {
+		return nil, err
+	}
+
+	var secret string
+	if req.GetRotateSecret() {
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.UpdateWebhook(ctx, &w, secret); err != nil {
+		return nil, err
+	}
+	if w, err = s.repo.GetWebhook(ctx, req.GetUserId(), req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &file.UpdateWebhookResponse{Webhook: toPbWebhook(w), Secret: secret}, nil
+}
+
+// DeleteWebhook 删除 webhook 及其投递记录
+func (s *FileServer) DeleteWebhook(ctx context.Context, req *file.DeleteWebhookRequest) (*file.DeleteWebhookResponse, error) {
+	if err := s.repo.DeleteWebhook(ctx, req.GetUserId(), req.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &file.DeleteWebhookResponse{}, nil
+}
+
+// ListWebhookDeliveries 分页获取 webhook 的投递记录
+func (s *FileServer) ListWebhookDeliveries(ctx context.Context, req *file.ListWebhookDeliveriesRequest) (*file.ListWebhookDeliveriesResponse, error) {
+	if _, err := s.repo.GetWebhook(ctx, req.GetUserId(), req.GetWebhookId()); err != nil {
+		return nil, err
+	}
+
+	page, size := pageParams(req.GetPage(), req.GetSize())
+	deliveries, total, err := s.repo.ListWebhookDeliveries(ctx, req.GetWebhookId(), page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListWebhookDeliveriesResponse{Total: total, Deliveries: make([]*file.WebhookDelivery, 0, len(deliveries))}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, toPbWebhookDelivery(d))
+	}
+
+	return resp, nil
+}
+
+// RedeliverWebhook 重新投递已完成的投递, 使用 webhook 当前的地址和密钥
+func (s *FileServer) RedeliverWebhook(ctx context.Context, req *file.RedeliverWebhookRequest) (*file.RedeliverWebhookResponse, error) {
+	d, err := s.repo.GetWebhookDelivery(ctx, req.GetUserId(), req.GetDeliveryId())
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.repo.GetWebhook(ctx, req.GetUserId(), d.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+	if w.Status != dao.WebhookActive {
+		return nil, fmt.Errorf("webhook is disabled: %s", w.Reason)
+	}
+
+	ok, err := s.repo.RedeliverWebhook(ctx, d.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrDeliveryInProgress
+	}
+	if d, err = s.repo.GetWebhookDelivery(ctx, req.GetUserId(), d.Id); err != nil {
+		return nil, err
+	}
+
+	return &file.RedeliverWebhookResponse{Delivery: toPbWebhookDelivery(d)}, nil
+}
+
+// validateWebhook 检查并设置 webhook 的地址、订阅的事件类型和文件夹范围
+func (s *FileServer) validateWebhook(ctx context.Context, w *dao.Webhook, rawURL string, eventTypes []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(rawURL) > 1024 {
+		return ErrInvalidWebhookURL
+	}
+	// 只接受域名, 解析后的地址在投递时检查
+	if _, err := netip.ParseAddr(u.Hostname()); err == nil {
+		return ErrForbiddenWebhookAddress
+	}
+	w.Url = rawURL
+
+	types := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		t = strings.TrimSpace(t)
+		if t == "" || strings.Contains(t, ",") {
+			return fmt.Errorf("invalid event type %q", t)
+		}
+		types = append(types, t)
+	}
+	w.EventTypes = strings.Join(types, ",")
+	if len(w.EventTypes) > 512 {
+		return errors.New("too many event types")
+	}
+
+	if w.FolderId != 0 {
+		folder, err := s.repo.FindFolder(ctx, w.FolderId)
+		if err != nil {
+			return err
+		}
+		if folder.UserId != w.UserId {
+			return dao.ErrPermissionDenied
+		}
+	}
+
+	return nil
+}
+
+// webhookDialControl 在建立连接前拒绝不允许投递的地址, address 是 DNS 解析后的 IP
+func webhookDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !publicAddr(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenWebhookAddress, ip)
+	}
+
+	return nil
+}
+
+// sharedAddressSpace 运营商级 NAT 使用的地址段
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddr ip 是否为公网单播地址
+func publicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+
+	return ip.IsValid() && !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsUnspecified() && !ip.IsMulticast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !sharedAddressSpace.Contains(ip)
+}
+
+// enqueueWebhooks 为记录的事件 eventId 在订阅了它的 webhook 上创建投递, 每个事件只创建一次
+func (s *FileServer) enqueueWebhooks(ctx context.Context, eventId int64, event *mws.FileChangeEvent) error {
+	hooks, err := s.repo.ListActiveWebhooks(ctx, event.UserId)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var ancestors []int64
+	var deliveries []dao.WebhookDelivery
+	for _, w := range hooks {
+		if !matchEventType(w.EventTypes, event.EventType) {
+			continue
+		}
+		if w.FolderId != 0 {
+			// 事件的文件夹及其祖先中包含 webhook 的文件夹时推送, 每个事件只查询一次
+			if ancestors == nil && event.FolderId != 0 {
+				if ancestors, err = s.repo.FolderAncestors(ctx, event.FolderId, event.UserId); err != nil {
+					return err
+				}
+			}
+			if !containsId(ancestors, w.FolderId) {
+				continue
+			}
+		}
+		deliveries = append(deliveries, dao.WebhookDelivery{
+			WebhookId: w.Id,
+			UserId:    w.UserId,
+			EventType: event.EventType,
+			Payload:   payload,
+		})
+	}
+
+	_, err = s.repo.AddEventWebhookDeliveries(ctx, eventId, deliveries)
+
+	return err
+}
+
+// RunWebhookDispatcher 按 interval 周期性地重试发送文件变更事件, 投递到期的 webhook 并清理过期的投递记录, ctx 结束时退出
+func (s *FileServer) RunWebhookDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.dispatchEvents(ctx, time.Now()); err != nil {
+				log.Printf("failed to dispatch file events: %v", err)
+			}
+			if err := s.dispatchWebhooks(ctx, time.Now()); err != nil {
+				log.Printf("failed to dispatch webhooks: %v", err)
+			}
+			if _, err := s.repo.PurgeWebhookDeliveries(ctx, time.Now().Add(-webhookRetention).Unix()); err != nil {
+				log.Printf("failed to purge webhook deliveries: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatchWebhooks 认领并投递 now 时到期的投递, 最多同时进行 webhookWorkers 个
+func (s *FileServer) dispatchWebhooks(ctx context.Context, now time.Time) error {
+	deliveries, err := s.repo.ListDueWebhookDeliveries(ctx, now.Unix(), webhookBatch)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, webhookWorkers)
+	for i := range deliveries {
+		d := &deliveries[i]
+		// 多个实例同时投递时只有一个能认领
+		ok, err := s.repo.ClaimWebhookDelivery(ctx, d, int64(webhookLease/time.Second))
+		if err != nil {
+			wg.Wait()
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			if err := s.deliverWebhook(ctx, d); err != nil {
+				log.Printf("failed to record webhook delivery %d: %v", d.Id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// deliverWebhook 执行一次认领的投递并记录结果, 失败时按退避重试, 连续失败过多时禁用 webhook
+func (s *FileServer) deliverWebhook(ctx context.Context, d *dao.WebhookDelivery) error {
+	w, err := s.repo.GetWebhook(ctx, 0, d.WebhookId)
+	if errors.Is(err, dao.ErrWebhookNotFound) {
+		return s.repo.FinishWebhookDelivery(ctx, d, dao.DeliveryFailed, 0, "webhook deleted", 0)
+	}
+	if err != nil {
+		return err
+	}
+	if w.Status != dao.WebhookActive {
+		return s.repo.FinishWebhookDelivery(ctx, d, dao.DeliveryFailed, 0, "webhook disabled", 0)
+	}
+
+	code, err := postWebhook(ctx, w, d)
+	if err == nil {
+		WebhookDeliveries.WithLabelValues("succeeded").Inc()
+		if err := s.repo.RecordWebhookSuccess(ctx, w.Id); err != nil {
+			return err
+		}
+		return s.repo.FinishWebhookDelivery(ctx, d, dao.DeliverySucceeded, code, "", 0)
+	}
+
+	disabled, rerr := s.repo.RecordWebhookFailure(ctx, w.Id, webhookDisableAfter,
+		fmt.Sprintf("%d consecutive failed deliveries, last: %v", webhookDisableAfter, err))
+	if rerr != nil {
+		return rerr
+	}
+	if disabled {
+		log.Printf("disabled webhook %d of user %d after %d consecutive failures: %v", w.Id, w.UserId, webhookDisableAfter, err)
+	}
+	if disabled || d.Attempts >= webhookMaxAttempts {
+		WebhookDeliveries.WithLabelValues("failed").Inc()
+		return s.repo.FinishWebhookDelivery(ctx, d, dao.DeliveryFailed, code, err.Error(), 0)
+	}
+	WebhookDeliveries.WithLabelValues("retry").Inc()
+	backoff := min(webhookBackoff<<(d.Attempts-1), webhookMaxBackoff)
+
+	return s.repo.FinishWebhookDelivery(ctx, d, dao.DeliveryRetry, code, err.Error(), time.Now().Add(backoff).Unix())
+}
+
+// postWebhook 以签名的 POST 请求投递事件, 返回响应的状态码, 非 2xx 时返回错误
+func postWebhook(ctx context.Context, w dao.Webhook, d *dao.WebhookDelivery) (int32, error) {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookHeaderEvent, d.EventType)
+	req.Header.Set(webhookHeaderDelivery, strconv.FormatInt(d.Id, 10))
+	req.Header.Set(webhookHeaderTimestamp, ts)
+	req.Header.Set(webhookHeaderSignature, "sha256="+signWebhook(w.Secret, ts, d.Payload))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// 读完响应以复用连接, 内容不保存
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return int32(resp.StatusCode), fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return int32(resp.StatusCode), nil
+}
+
+// signWebhook 计算 "<timestamp>.<payload>" 的 HMAC-SHA256, 接收方以相同方式校验, 并可按时间戳拒绝重放
+func signWebhook(secret, ts string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// matchEventType 事件类型是否在订阅中, types 为空表示全部, 以 * 结尾的按前缀匹配
+func matchEventType(types, eventType string) bool {
+	if types == "" {
+		return true
+	}
+	for _, t := range strings.Split(types, ",") {
+		if t == eventType || (strings.HasSuffix(t, "*") && strings.HasPrefix(eventType, strings.TrimSuffix(t, "*"))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsId(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toPbWebhook(w dao.Webhook) *file.Webhook {
+	var types []string
+	if w.EventTypes != "" {
+		types = strings.Split(w.EventTypes, ",")
+	}
+
+	return &file.Webhook{
+		Id:             w.Id,
+		Url:            w.Url,
+		EventTypes:     types,
+		FolderId:       w.FolderId,
+		Enabled:        w.Status == dao.WebhookActive,
+		Failures:       w.Failures,
+		DisabledReason: w.Reason,
+		Ctime:          w.Ctime,
+		Utime:          w.Utime,
+	}
+}
+
+func toPbWebhookDelivery(d dao.WebhookDelivery) *file.WebhookDelivery {
+	return &file.WebhookDelivery{
+		Id:           d.Id,
+		WebhookId:    d.WebhookId,
+		EventType:    d.EventType,
+		Status:       file.WebhookDeliveryStatus(d.Status),
+		Attempts:     d.Attempts,
+		ResponseCode: d.ResponseCode,
+		LastError:    d.LastError,
+		Payload:      string(d.Payload),
+		Ctime:        d.Ctime,
+		Utime:        d.Utime,
+	}
+}
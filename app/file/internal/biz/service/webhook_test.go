@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/mws"
+)
+
+func TestValidateWebhookURL(t *testing.T) {
+	s, _, _ := newUploadTestServer(t)
+	cases := map[string]error{
+		"https://hooks.example.com/drive": nil,
+		"ftp://hooks.example.com/drive":   ErrInvalidWebhookURL,
+		"/relative":                       ErrInvalidWebhookURL,
+		"http://127.0.0.1:8080/hook":      ErrForbiddenWebhookAddress,
+		"http://10.0.0.7/hook":            ErrForbiddenWebhookAddress,
+		"http://[::1]/hook":               ErrForbiddenWebhookAddress,
+		// 公网地址也只接受域名
+		"http://93.184.216.34/hook": ErrForbiddenWebhookAddress,
+	}
+	for rawURL, want := range cases {
+		w := &dao.Webhook{UserId: testUser}
+		if err := s.validateWebhook(context.Background(), w, rawURL, nil); !errors.Is(err, want) {
+			t.Errorf("%s: got %v, want %v", rawURL, err, want)
+		}
+	}
+}
+
+func TestWebhookClientRefusesPrivateAddresses(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }))
+	defer srv.Close()
+
+	// 域名解析到本机时同样在连接前被拒绝
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = postWebhook(context.Background(), dao.Webhook{Url: "http://localhost:" + u.Port(), Secret: "s"}, &dao.WebhookDelivery{Payload: []byte("{}")})
+	if !errors.Is(err, ErrForbiddenWebhookAddress) {
+		t.Fatalf("got %v, want ErrForbiddenWebhookAddress", err)
+	}
+	if called {
+		t.Fatal("request reached the loopback server")
+	}
+}
+
+func TestPostWebhookSignsPayload(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+	// 测试服务器在本机, 换用不检查地址的客户端
+	defer func(c *http.Client) { webhookClient = c }(webhookClient)
+	webhookClient = srv.Client()
+
+	payload := []byte(`{"event_type":"file.created"}`)
+	code, err := postWebhook(context.Background(), dao.Webhook{Url: srv.URL, Secret: "secret"},
+		&dao.WebhookDelivery{Id: 9, EventType: "file.created", Payload: payload})
+	if err != nil || code != http.StatusOK {
+		t.Fatalf("got %d, %v", code, err)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(got.Header.Get(webhookHeaderTimestamp) + "."))
+	mac.Write(body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); got.Header.Get(webhookHeaderSignature) != want {
+		t.Fatalf("signature %q, want %q", got.Header.Get(webhookHeaderSignature), want)
+	}
+	if got.Header.Get(webhookHeaderEvent) != "file.created" || got.Header.Get(webhookHeaderDelivery) != "9" {
+		t.Fatalf("unexpected headers %v", got.Header)
+	}
+}
+
+func TestDispatchEventsMatchesSubscriptions(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+
+	hooks := []*dao.Webhook{
+		{UserId: testUser, Url: "https://a.example.com", EventTypes: ""},
+		{UserId: testUser, Url: "https://b.example.com", EventTypes: "file.*"},
+		{UserId: testUser, Url: "https://c.example.com", EventTypes: "folder.created"},
+		{UserId: testUser + 1, Url: "https://d.example.com"},
+	}
+	for _, w := range hooks {
+		if err := s.repo.CreateWebhook(ctx, w); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := s.addEvent(ctx, &mws.FileChangeEvent{EventType: "file.created", UserId: testUser}); err != nil {
+		t.Fatal(err)
+	}
+	// 重复发送不会重复创建投递
+	for i := 0; i < 2; i++ {
+		if err := s.dispatchEvents(ctx, time.Now().Add(time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var ids []int64
+	if err := db.Model(&dao.WebhookDelivery{}).Order("webhook_id").Pluck("webhook_id", &ids).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != hooks[0].Id || ids[1] != hooks[1].Id {
+		t.Fatalf("deliveries for webhooks %v, want %d and %d", ids, hooks[0].Id, hooks[1].Id)
+	}
+}
+
+func TestDispatchEventsSendsRecordedEvents(t *testing.T) {
+	s, _, db := newUploadTestServer(t)
+	ctx := context.Background()
+	if err := s.repo.CreateWebhook(ctx, &dao.Webhook{UserId: testUser, Url: "https://a.example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 事件先于发送记录, 进程在发送前退出时由 dispatchEvents 补发
+	if _, err := s.addEvent(ctx, &mws.FileChangeEvent{EventType: "upload", UserId: testUser}); err != nil {
+		t.Fatal(err)
+	}
+	var deliveries int64
+	if err := db.Model(&dao.WebhookDelivery{}).Count(&deliveries).Error; err != nil || deliveries != 0 {
+		t.Fatalf("%d deliveries before dispatch: %v", deliveries, err)
+	}
+	if err := s.dispatchEvents(ctx, time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	var pending int64
+	db.Model(&dao.WebhookDelivery{}).Count(&deliveries)
+	db.Model(&dao.FileEvent{}).Count(&pending)
+	if deliveries != 1 || pending != 0 {
+		t.Fatalf("%d deliveries and %d pending events, want 1 and 0", deliveries, pending)
+	}
+}
@@ -19,15 +19,17 @@ type DownloadWorker interface {
 
 type RedisWorker struct {
 	repo      *repository.UploadRepo
-	minio     *mws.MinioServer
+	store     mws.BlobStore
+	keys      *KeyManager
 	stopCh    chan struct{}
 	workerNum int
 }
 
-func NewRedisWorker(repo *repository.UploadRepo, minio *mws.MinioServer) DownloadWorker {
+func NewRedisWorker(repo *repository.UploadRepo, store mws.BlobStore, keys *KeyManager) DownloadWorker {
 	return &RedisWorker{
 		repo:      repo,
-		minio:     minio,
+		store:     store,
+		keys:      keys,
 		stopCh:    make(chan struct{}),
 		workerNum: 3,
 	}
@@ -83,13 +85,24 @@ func (w *RedisWorker) processTask(taskId string) {
 				return
 			}
 
+			// 文件在排队期间被删除或隔离时不再下载
+			if _, err := w.repo.FindFile(ctx, file.FileId); err != nil {
+				file.Status = "failed"
+				return
+			}
+
 			// 获取文件对象
-			obj, err := w.minio.GetObject(ctx, w.minio.BucketName, file.Name)
+			key := file.ObjectKey
+			if key == "" {
+				key = file.Name
+			}
+			// 从偏移处读取，支持断点续传, 加密的对象从偏移处解密
+			r, err := w.keys.Open(ctx, w.store, key, file.Downloaded)
 			if err != nil {
 				file.Status = "failed"
 				return
 			}
-			defer obj.Close()
+			defer r.Close()
 
 			// 创建本地文件
 			filePath := filepath.Join(tmpDir, file.Path)
@@ -102,14 +115,12 @@ func (w *RedisWorker) processTask(taskId string) {
 			}
 			defer f.Close()
 
-			// 设置偏移量，支持断点续传
 			if file.Downloaded > 0 {
-				obj.Seek(file.Downloaded, io.SeekStart)
 				f.Seek(file.Downloaded, io.SeekStart)
 			}
 
 			// 拷贝数据并更新进度
-			written, err := io.Copy(f, obj)
+			written, err := io.Copy(f, r)
 			if err != nil {
 				file.Status = "failed"
 				return
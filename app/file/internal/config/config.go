@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -16,12 +17,21 @@ var (
 )
 
 type Config struct {
-	Env    string
-	Server Server `yaml:"server"`
-	MySQL  MySQL  `yaml:"mysql"`
-	Minio  Minio  `yaml:"minio"`
-	Redis  Redis  `yaml:"redis"`
-	ETCD   ETCD   `yaml:"etcd"`
+	Env     string
+	Server  Server  `yaml:"server"`
+	MySQL   MySQL   `yaml:"mysql"`
+	Minio   Minio   `yaml:"minio"`
+	Redis   Redis   `yaml:"redis"`
+	ETCD    ETCD    `yaml:"etcd"`
+	Storage Storage `yaml:"storage"`
+	Share   Share   `yaml:"share"`
+
+	Processing Processing `yaml:"processing"`
+	Scan       Scan       `yaml:"scan"`
+	Webhook    Webhook    `yaml:"webhook"`
+
+	Encryption Encryption `yaml:"encryption"`
+	Admin      Admin      `yaml:"admin"`
 }
 
 type Server struct {
@@ -48,6 +58,75 @@ type Minio struct {
 	BucketName string `yaml:"bucketName"`
 }
 
+type Storage struct {
+	// CaseInsensitiveNames 同一文件夹下仅大小写不同的名称是否视为同名
+	CaseInsensitiveNames bool `yaml:"caseInsensitiveNames"`
+	// QuotaReconcileInterval 周期性空间对账的间隔, 为 0 时不启动
+	QuotaReconcileInterval time.Duration `yaml:"quotaReconcileInterval"`
+	// QuotaEventInterval 投递用量阈值事件和收回到期临时容量的间隔, 为 0 时使用 30s
+	QuotaEventInterval time.Duration `yaml:"quotaEventInterval"`
+	// UploadRepairInterval 修复卡住的上传的间隔, 为 0 时使用 1m
+	UploadRepairInterval time.Duration `yaml:"uploadRepairInterval"`
+	// ScrubInterval 巡检每轮校验一批文件的间隔, 为 0 时使用 1m
+	ScrubInterval time.Duration `yaml:"scrubInterval"`
+	// ScrubPeriod 每个文件重新校验的周期, 为 0 时使用 720h
+	ScrubPeriod time.Duration `yaml:"scrubPeriod"`
+	// Driver 对象存储驱动, minio 或 local, 为空时使用 minio
+	Driver string `yaml:"driver"`
+	// LocalRoot local 驱动存放对象的目录, 为空时使用 data/blobs
+	LocalRoot string `yaml:"localRoot"`
+	// LocalURL local 驱动预签名地址的前缀, 指向文件服务 HTTP 端口上的 /blob
+	LocalURL string `yaml:"localURL"`
+	// LocalSecret local 驱动预签名地址的签名密钥, 为空时每次启动随机生成
+	LocalSecret string `yaml:"localSecret"`
+}
+
+type Share struct {
+	// ExpireInterval 将到期分享标记为已过期的间隔, 为 0 时使用 1m
+	ExpireInterval time.Duration `yaml:"expireInterval"`
+	// TokenSecret 签发分享访问令牌的密钥, 多个实例须配置相同的值, 为空时每次启动随机生成, 已签发的令牌在重启后失效
+	TokenSecret string `yaml:"tokenSecret"`
+}
+
+type Processing struct {
+	// Interval 处理流水线消费提交事件和执行处理的间隔, 为 0 时使用 10s
+	Interval time.Duration `yaml:"interval"`
+	// Processors 启用的处理器, 为空时启用全部内置处理器
+	Processors []string `yaml:"processors"`
+}
+
+type Webhook struct {
+	// DispatchInterval 投递到期 webhook 的间隔, 为 0 时使用 10s
+	DispatchInterval time.Duration `yaml:"dispatchInterval"`
+}
+
+type Scan struct {
+	// Driver 病毒扫描驱动, clamd 或 signature, 为空时不扫描
+	Driver string `yaml:"driver"`
+	// ClamdAddr clamd 驱动的地址, 如 tcp://127.0.0.1:3310 或 unix:///run/clamav/clamd.ctl
+	ClamdAddr string `yaml:"clamdAddr"`
+	// Timeout 扫描一个文件的超时, 为 0 时不限
+	Timeout time.Duration `yaml:"timeout"`
+	// Signatures signature 驱动在内置的 EICAR 测试特征之外匹配的特征, 名称到十六进制编码的内容
+	Signatures map[string]string `yaml:"signatures"`
+}
+
+type Admin struct {
+	// Token 调用管理接口须在 gRPC 元数据 x-admin-token 中携带的令牌, 为空时拒绝全部管理接口
+	Token string `yaml:"token"`
+}
+
+type Encryption struct {
+	// Enabled 是否加密新写入的对象, 已加密的对象无论是否开启都会透明解密
+	Enabled bool `yaml:"enabled"`
+	// MasterKeyId 当前用于包裹用户密钥的主密钥
+	MasterKeyId string `yaml:"masterKeyId"`
+	// MasterKeys 主密钥 id 到 base64 编码的 32 字节密钥, 轮换后须保留旧密钥直到重新包裹完成
+	MasterKeys map[string]string `yaml:"masterKeys"`
+	// LocalKMSPath 未配置主密钥时, 本地模拟 KMS 的密钥文件, 为空时使用 config/kms.json
+	LocalKMSPath string `yaml:"localKMSPath"`
+}
+
 func GetConf() *Config {
 	once.Do(initConfig)
 
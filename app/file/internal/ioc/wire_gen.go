@@ -7,6 +7,7 @@
 package ioc
 
 import (
+	"crypto/rand"
 	"fmt"
 	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository"
 	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/repository/cache"
@@ -22,38 +23,72 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 	"os"
+	"slices"
 	"time"
 )
 
 // Injectors from wire.go:
 
 func InitServer() *service.FileServer {
-	db := InitDB()
-	uploadDao := dao.NewUploadDao(db)
+	naming := InitNaming()
+	db := InitDB(naming)
+	uploadDao := dao.NewUploadDao(db, naming)
 	cmdable := InitCache()
 	fileCache := cache.NewFileCache(cmdable)
 	uploadRepo := repository.NewUploadRepo(uploadDao, fileCache)
-	client := InitMinio()
-	minioServer := mws.NewMinioServer(client)
-	downloadWorker := service.NewRedisWorker(uploadRepo, minioServer)
+	blobStore := InitBlobStore()
+	keyring := mws.NewKeyring()
+	keyManager := service.NewKeyManager(uploadRepo, keyring)
+	downloadWorker := service.NewRedisWorker(uploadRepo, blobStore, keyManager)
 	kafkaProducer := mws.NewKafkaProducer()
-	fileServer := service.NewFileServer(uploadRepo, minioServer, downloadWorker, kafkaProducer)
+	scanner := InitScanner()
+	v := InitProcessors(scanner, uploadRepo)
+	shareSecret := InitShareSecret()
+	fileServer := service.NewFileServer(uploadRepo, blobStore, downloadWorker, kafkaProducer, keyManager, v, shareSecret)
 	return fileServer
 }
 
+// InitKeyManager 供密钥轮换命令使用, 不初始化对象存储和消息队列
+func InitKeyManager() *service.KeyManager {
+	naming := InitNaming()
+	db := InitDB(naming)
+	uploadDao := dao.NewUploadDao(db, naming)
+	cmdable := InitCache()
+	fileCache := cache.NewFileCache(cmdable)
+	uploadRepo := repository.NewUploadRepo(uploadDao, fileCache)
+	keyring := mws.NewKeyring()
+	keyManager := service.NewKeyManager(uploadRepo, keyring)
+	return keyManager
+}
+
 // wire.go:
 
-func InitDB() *gorm.DB {
+// InitNaming 按配置的 storage.caseInsensitiveNames 决定名称唯一性规则
+func InitNaming() dao.Naming {
+	return dao.Naming{CaseInsensitive: config.GetConf().Storage.CaseInsensitiveNames}
+}
+
+func InitDB(naming dao.Naming) *gorm.DB {
 	dsn := fmt.Sprintf(config.GetConf().MySQL.DSN, os.Getenv("MYSQL_USER"), os.Getenv("MYSQL_PASSWORD"), os.Getenv("MYSQL_HOST"), os.Getenv("MYSQL_PORT"), os.Getenv("MYSQL_DB"))
 
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
 		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+		TranslateError: true,
 	})
 	if err != nil {
 		panic(err)
 	}
 
-	db.AutoMigrate(&dao.File{}, &dao.FileStore{}, &dao.Folder{})
+	db.AutoMigrate(dao.Models()...)
+	if err := dao.BackfillNameKeys(db, naming); err != nil {
+		panic(err)
+	}
+	if err := dao.BackfillFolderStats(db); err != nil {
+		panic(err)
+	}
+	if err := dao.BackfillSharePasswords(db); err != nil {
+		panic(err)
+	}
 
 	return db
 }
@@ -76,6 +111,86 @@ func InitMinio() *minio.Client {
 	return client
 }
 
+// InitBlobStore 按配置的 storage.driver 选择对象存储驱动
+func InitBlobStore() mws.BlobStore {
+	conf := config.GetConf().Storage
+	switch conf.Driver {
+	case "", "minio":
+		return mws.NewMinioStore(InitMinio(), config.GetConf().Minio.BucketName)
+	case "local":
+		root := conf.LocalRoot
+		if root == "" {
+			root = "data/blobs"
+		}
+		store, err := mws.NewLocalStore(root, conf.LocalURL, conf.LocalSecret)
+		if err != nil {
+			panic(err)
+		}
+		return store
+	default:
+		panic(fmt.Sprintf("unknown storage driver %q", conf.Driver))
+	}
+}
+
+// InitScanner 按配置的 scan.driver 选择病毒扫描驱动, 未配置时返回 nil, 不扫描
+func InitScanner() mws.Scanner {
+	conf := config.GetConf().Scan
+	switch conf.Driver {
+	case "":
+		return nil
+	case "clamd":
+		scanner, err := mws.NewClamdScanner(conf.ClamdAddr, conf.Timeout)
+		if err != nil {
+			panic(err)
+		}
+		return scanner
+	case "signature":
+		scanner, err := mws.NewSignatureScanner(conf.Signatures)
+		if err != nil {
+			panic(err)
+		}
+		return scanner
+	default:
+		panic(fmt.Sprintf("unknown scan driver %q", conf.Driver))
+	}
+}
+
+// InitProcessors 按配置的 processing.processors 选择文件提交后执行的处理器
+// 配置了扫描驱动时内置的处理器包括病毒扫描
+func InitProcessors(scanner mws.Scanner, repo *repository.UploadRepo) []service.Processor {
+	builtin := []service.Processor{service.NewMetadataProcessor(), service.NewTextProcessor()}
+	if scanner != nil {
+		builtin = append(builtin, service.NewScanProcessor(scanner, repo))
+	}
+	names := config.GetConf().Processing.Processors
+	if len(names) == 0 {
+		return builtin
+	}
+
+	processors := make([]service.Processor, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(builtin, func(p service.Processor) bool { return p.Name() == name })
+		if i < 0 {
+			panic(fmt.Sprintf("unknown processor %q", name))
+		}
+		processors = append(processors, builtin[i])
+	}
+	return processors
+}
+
+// InitShareSecret 读取签发分享访问令牌的密钥, 未配置时随机生成
+func InitShareSecret() service.ShareSecret {
+	if secret := config.GetConf().Share.TokenSecret; secret != "" {
+		return service.ShareSecret(secret)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return secret
+}
+
 func InitRegistry() *clientv3.Client {
 	cli, err := clientv3.New(clientv3.Config{
 		Endpoints:   []string{config.GetConf().ETCD.Addr},
@@ -0,0 +1,62 @@
+package mws
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrBlobNotFound 对象不存在
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore 对象存储, 文件服务和下载任务的对象读写都经由它进行
+// 驱动在配置的 storage.driver 中选择
+type BlobStore interface {
+	// Put 写入整个对象, 已存在时覆盖
+	Put(ctx context.Context, key string, data []byte) error
+	// PutStream 从 r 写入对象, size 为 -1 表示大小未知
+	PutStream(ctx context.Context, key string, r io.Reader, size int64) error
+	// CreateMultipart 初始化分片上传, 返回 upload id
+	CreateMultipart(ctx context.Context, key string) (string, error)
+	// PutPart 上传分片, 返回分片的 etag
+	PutPart(ctx context.Context, key, uploadId string, partNumber int, data []byte) (string, error)
+	// CompleteMultipart 按 parts 的顺序合并分片
+	CompleteMultipart(ctx context.Context, key, uploadId string, parts []BlobPart) error
+	// AbortMultipart 中止分片上传, 丢弃已上传的分片, 上传不存在时不报错
+	AbortMultipart(ctx context.Context, key, uploadId string) error
+	// Get 读取对象从 offset 开始的 length 字节, length 为 0 表示读到末尾
+	Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	// Stat 获取对象信息, 对象不存在时返回 ErrBlobNotFound
+	Stat(ctx context.Context, key string) (BlobInfo, error)
+	// Delete 删除对象, 对象不存在时不报错
+	Delete(ctx context.Context, key string) error
+	// Presign 生成有效期为 expires 的下载地址
+	Presign(ctx context.Context, key string, expires time.Duration) (string, error)
+	// List 按 key 的顺序列出以 prefix 开头且大于 after 的对象, 最多 limit 个
+	List(ctx context.Context, prefix, after string, limit int) ([]BlobInfo, error)
+}
+
+// BlobPart 分片上传中已上传的分片
+type BlobPart struct {
+	PartNumber int
+	ETag       string
+}
+
+// BlobInfo 对象信息
+type BlobInfo struct {
+	Key     string
+	Size    int64
+	ETag    string
+	ModTime time.Time
+}
+
+// BlobHandler 返回驱动自带的下载服务, 用于校验并响应驱动签发的预签名地址; MinIO 驱动没有, 返回 nil
+func BlobHandler(store BlobStore) http.Handler {
+	if h, ok := store.(http.Handler); ok {
+		return h
+	}
+
+	return nil
+}
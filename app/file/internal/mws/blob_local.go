@@ -0,0 +1,413 @@
+package mws
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 本地驱动的目录结构:
+//
+//	objects/<h[:2]>/<h>       对象内容, h 为 key 的 sha256
+//	objects/<h[:2]>/<h>.json  对象的 key 和 etag
+//	uploads/<uploadId>/       未完成的分片上传, key 文件记录对象 key, 分片以分片号命名
+//
+// 写入都先写临时文件再重命名, 读到的对象总是完整的
+const (
+	localObjectDir = "objects"
+	localUploadDir = "uploads"
+	localMetaExt   = ".json"
+)
+
+// ErrBlobSignature 预签名地址的签名无效或已过期
+var ErrBlobSignature = errors.New("invalid or expired blob signature")
+
+// LocalStore 以本地目录存放对象, 用于开发和单机部署
+// 预签名地址由服务自己签名, 指向 ServeHTTP 提供的下载服务
+type LocalStore struct {
+	root    string
+	baseURL string
+	secret  []byte
+}
+
+// localMeta 对象的元数据
+type localMeta struct {
+	Key  string `json:"key"`
+	ETag string `json:"etag"`
+}
+
+// NewLocalStore 以 root 为根目录创建本地驱动, baseURL 为下载服务对外的地址
+// secret 为空时随机生成, 此时签发的地址在重启后失效
+func NewLocalStore(root, baseURL, secret string) (*LocalStore, error) {
+	for _, dir := range []string{localObjectDir, localUploadDir} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return &LocalStore{root: root, baseURL: strings.TrimRight(baseURL, "/"), secret: key}, nil
+}
+
+// Put 写入对象
+func (l *LocalStore) Put(ctx context.Context, key string, data []byte) error {
+	return l.PutStream(ctx, key, bytes.NewReader(data), int64(len(data)))
+}
+
+// PutStream 从 r 写入对象
+func (l *LocalStore) PutStream(ctx context.Context, key string, r io.Reader, size int64) error {
+	if key == "" {
+		return errors.New("empty blob key")
+	}
+
+	return l.writeObject(key, func(w io.Writer) error {
+		n, err := io.Copy(w, r)
+		if err != nil {
+			return err
+		}
+		if size >= 0 && n != size {
+			return fmt.Errorf("blob size mismatch: expected %d, got %d", size, n)
+		}
+		return nil
+	})
+}
+
+// CreateMultipart 初始化分片上传
+func (l *LocalStore) CreateMultipart(ctx context.Context, key string) (string, error) {
+	if key == "" {
+		return "", errors.New("empty blob key")
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	uploadId := hex.EncodeToString(id)
+
+	dir := filepath.Join(l.root, localUploadDir, uploadId)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "key"), []byte(key), 0o644); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	return uploadId, nil
+}
+
+// PutPart 上传分片, 同一分片号重复上传时覆盖
+func (l *LocalStore) PutPart(ctx context.Context, key, uploadId string, partNumber int, data []byte) (string, error) {
+	dir, err := l.uploadDir(key, uploadId)
+	if err != nil {
+		return "", err
+	}
+	if partNumber < 1 {
+		return "", fmt.Errorf("invalid part number %d", partNumber)
+	}
+
+	if err := writeAtomic(filepath.Join(dir, strconv.Itoa(partNumber)), func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CompleteMultipart 按 parts 的顺序拼接分片, 分片的 etag 须与上传时返回的一致
+func (l *LocalStore) CompleteMultipart(ctx context.Context, key, uploadId string, parts []BlobPart) error {
+	dir, err := l.uploadDir(key, uploadId)
+	if err != nil {
+		return err
+	}
+
+	err = l.writeObject(key, func(w io.Writer) error {
+		for _, p := range parts {
+			data, err := os.ReadFile(filepath.Join(dir, strconv.Itoa(p.PartNumber)))
+			if err != nil {
+				return fmt.Errorf("part %d: %w", p.PartNumber, err)
+			}
+			sum := md5.Sum(data)
+			if strings.Trim(p.ETag, `"`) != hex.EncodeToString(sum[:]) {
+				return fmt.Errorf("part %d: etag mismatch", p.PartNumber)
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// AbortMultipart 中止分片上传, 丢弃已上传的分片
+func (l *LocalStore) AbortMultipart(ctx context.Context, key, uploadId string) error {
+	dir, err := l.uploadDir(key, uploadId)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// Get 范围读取对象
+func (l *LocalStore) Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	f, err := os.Open(l.objectPath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if length <= 0 {
+		return f, nil
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(f, length), f}, nil
+}
+
+// Stat 获取对象信息
+func (l *LocalStore) Stat(ctx context.Context, key string) (BlobInfo, error) {
+	return l.stat(l.objectPath(key))
+}
+
+// Delete 删除对象
+func (l *LocalStore) Delete(ctx context.Context, key string) error {
+	p := l.objectPath(key)
+	for _, name := range []string{p, p + localMetaExt} {
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Presign 生成由服务签名的下载地址, 由 ServeHTTP 校验
+func (l *LocalStore) Presign(ctx context.Context, key string, expires time.Duration) (string, error) {
+	exp := time.Now().Add(expires).Unix()
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(exp, 10))
+	q.Set("signature", l.sign(key, exp))
+
+	return l.baseURL + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + q.Encode(), nil
+}
+
+// List 列出对象, 需要遍历全部元数据, 只适合对象不多的场景
+func (l *LocalStore) List(ctx context.Context, prefix, after string, limit int) ([]BlobInfo, error) {
+	var res []BlobInfo
+	err := filepath.WalkDir(filepath.Join(l.root, localObjectDir), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, localMetaExt) {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		info, err := l.stat(strings.TrimSuffix(p, localMetaExt))
+		if errors.Is(err, ErrBlobNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(info.Key, prefix) && info.Key > after {
+			res = append(res, info)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+// ServeHTTP 校验预签名地址并返回对象内容, 支持 Range 请求
+// 挂载时须去掉路径前缀, 使请求路径为 /<key>
+func (l *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	exp, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > exp ||
+		!hmac.Equal([]byte(l.sign(key, exp)), []byte(r.URL.Query().Get("signature"))) {
+		http.Error(w, ErrBlobSignature.Error(), http.StatusForbidden)
+		return
+	}
+
+	f, err := os.Open(l.objectPath(key))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := l.stat(l.objectPath(key))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("ETag", `"`+info.ETag+`"`)
+	http.ServeContent(w, r, filepath.Base(key), info.ModTime, f)
+}
+
+// writeObject 通过 write 写入对象内容, 同时计算 etag
+func (l *LocalStore) writeObject(key string, write func(w io.Writer) error) error {
+	p := l.objectPath(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	h := md5.New()
+	if err := writeAtomic(p, func(w io.Writer) error {
+		return write(io.MultiWriter(w, h))
+	}); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(localMeta{Key: key, ETag: hex.EncodeToString(h.Sum(nil))})
+	if err != nil {
+		return err
+	}
+	return writeAtomic(p+localMetaExt, func(w io.Writer) error {
+		_, err := w.Write(meta)
+		return err
+	})
+}
+
+// stat 读取对象 p 的信息, 内容或元数据缺失时视为不存在
+func (l *LocalStore) stat(p string) (BlobInfo, error) {
+	fi, err := os.Stat(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return BlobInfo{}, ErrBlobNotFound
+		}
+		return BlobInfo{}, err
+	}
+	raw, err := os.ReadFile(p + localMetaExt)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return BlobInfo{}, ErrBlobNotFound
+		}
+		return BlobInfo{}, err
+	}
+	var meta localMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return BlobInfo{}, err
+	}
+
+	return BlobInfo{Key: meta.Key, Size: fi.Size(), ETag: meta.ETag, ModTime: fi.ModTime()}, nil
+}
+
+// uploadDir 获取分片上传的目录, 并检查其属于对象 key
+func (l *LocalStore) uploadDir(key, uploadId string) (string, error) {
+	if uploadId == "" || strings.ContainsAny(uploadId, `/\.`) {
+		return "", fmt.Errorf("invalid upload id %q", uploadId)
+	}
+	dir := filepath.Join(l.root, localUploadDir, uploadId)
+	owner, err := os.ReadFile(filepath.Join(dir, "key"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("upload %s not found: %w", uploadId, err)
+		}
+		return "", err
+	}
+	if string(owner) != key {
+		return "", fmt.Errorf("upload %s does not belong to %s", uploadId, key)
+	}
+
+	return dir, nil
+}
+
+func (l *LocalStore) objectPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	h := hex.EncodeToString(sum[:])
+
+	return filepath.Join(l.root, localObjectDir, h[:2], h)
+}
+
+func (l *LocalStore) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(key))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeAtomic 先写入同目录下的临时文件再重命名为 p
+func writeAtomic(p string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
@@ -0,0 +1,130 @@
+package mws
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize INSTREAM 每个数据块的大小, 须小于 clamd 的 StreamMaxLength
+const clamdChunkSize = 64 << 10
+
+// ClamdScanner 通过 clamd 的 INSTREAM 命令扫描的驱动
+type ClamdScanner struct {
+	network string
+	addr    string
+	timeout time.Duration
+}
+
+// NewClamdScanner 创建 clamd 驱动, addr 为 tcp://host:port、unix:///path 或 host:port
+// timeout 为一次扫描的超时, 为 0 时不限
+func NewClamdScanner(addr string, timeout time.Duration) (*ClamdScanner, error) {
+	network := "tcp"
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		network, addr = "unix", strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "tcp://"):
+		addr = strings.TrimPrefix(addr, "tcp://")
+	}
+	if addr == "" {
+		return nil, errors.New("clamd address is required")
+	}
+
+	return &ClamdScanner{network: network, addr: addr, timeout: timeout}, nil
+}
+
+func (s *ClamdScanner) Name() string {
+	return "clamd"
+}
+
+// Scan 按 clamd 协议发送 zINSTREAM, 内容分块发送, 每块以 4 字节大端长度开头, 以长度为 0 的块结束
+// clamd 的回复形如 "stream: OK" 或 "stream: <特征> FOUND"
+func (s *ClamdScanner) Scan(ctx context.Context, r io.Reader) (ScanVerdict, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, s.network, s.addr)
+	if err != nil {
+		return ScanVerdict{}, fmt.Errorf("connect clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	// ctx 提前结束时中断阻塞的读写
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return ScanVerdict{}, err
+	}
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, werr := conn.Write(buf[:4+n]); werr != nil {
+				// clamd 超过 StreamMaxLength 时关闭连接, 回复中有原因
+				if reply, rerr := readClamdReply(conn); rerr == nil {
+					return ScanVerdict{}, fmt.Errorf("clamd: %s", reply)
+				}
+				return ScanVerdict{}, werr
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return ScanVerdict{}, err
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return ScanVerdict{}, err
+	}
+
+	reply, err := readClamdReply(conn)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ScanVerdict{}, ctx.Err()
+		}
+		return ScanVerdict{}, err
+	}
+
+	return parseClamdReply(reply)
+}
+
+// readClamdReply 读取以 \0 结尾的回复
+func readClamdReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(err == io.EOF && reply != "") {
+		return "", fmt.Errorf("read clamd reply: %w", err)
+	}
+
+	return strings.TrimRight(reply, "\x00\n"), nil
+}
+
+func parseClamdReply(reply string) (ScanVerdict, error) {
+	result := strings.TrimSpace(reply)
+	if i := strings.Index(result, ": "); i >= 0 {
+		result = result[i+2:]
+	}
+
+	switch {
+	case result == "OK":
+		return ScanVerdict{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return ScanVerdict{Infected: true, Signature: strings.TrimSuffix(result, " FOUND")}, nil
+	default:
+		return ScanVerdict{}, fmt.Errorf("clamd: %s", reply)
+	}
+}
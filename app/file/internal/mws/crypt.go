@@ -0,0 +1,218 @@
+package mws
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// 对象按 CryptChunkSize 分块, 每块单独以 AES-256-GCM 加密, 密文块为 nonce || 密文 || tag
+// 块序号和是否为最后一块作为附加数据, 防止块被调换顺序或在块边界处截断
+// 除最后一块外每块都是完整的, 因此可以按偏移直接定位, 支持范围读取; 空对象也有一个空的最后一块
+const (
+	// CryptChunkSize 明文分块大小
+	CryptChunkSize = 64 << 10
+	// DataKeySize 数据密钥长度
+	DataKeySize = 32
+
+	cryptNonceSize = 12
+	cryptTagSize   = 16
+	cryptOverhead  = cryptNonceSize + cryptTagSize
+	cryptBlockSize = CryptChunkSize + cryptOverhead
+)
+
+// ErrDecrypt 密文被篡改或使用了错误的密钥
+var ErrDecrypt = errors.New("failed to decrypt object")
+
+// NewDataKey 生成随机的数据密钥
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, DataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// CipherSize 明文大小为 n 时的密文大小
+func CipherSize(n int64) int64 {
+	chunks := max((n+CryptChunkSize-1)/CryptChunkSize, 1)
+	return n + chunks*cryptOverhead
+}
+
+// PlainSize 密文大小为 n 时的明文大小
+func PlainSize(n int64) int64 {
+	chunks := (n + cryptBlockSize - 1) / cryptBlockSize
+	return n - chunks*cryptOverhead
+}
+
+// SealChunks 分块加密 data, 第一块的序号为 first, final 表示 data 的最后一块是否为对象的最后一块
+// 分片上传时每个分片单独加密, first 为分片第一块在整个对象中的序号, 只有最后一个分片的 final 为 true
+func SealChunks(key, data []byte, first int64, final bool) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, CipherSize(int64(len(data))))
+	for idx := first; len(data) > 0 || (final && idx == first); idx++ {
+		n := min(len(data), CryptChunkSize)
+		nonce := make([]byte, cryptNonceSize)
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		out = append(out, nonce...)
+		out = aead.Seal(out, nonce, data[:n], chunkAAD(idx, final && n == len(data)))
+		data = data[n:]
+	}
+
+	return out, nil
+}
+
+// WrapKey 以 kek 加密密钥, aad 绑定密钥的用途, 解包时必须一致
+func WrapKey(kek, key, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, cryptNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, key, aad), nil
+}
+
+// UnwrapKey 解开 WrapKey 加密的密钥
+func UnwrapKey(kek, wrapped, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < cryptNonceSize {
+		return nil, ErrDecrypt
+	}
+	key, err := aead.Open(nil, wrapped[:cryptNonceSize], wrapped[cryptNonceSize:], aad)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return key, nil
+}
+
+// decryptReader 从密文的任意明文偏移处开始解密
+type decryptReader struct {
+	aead  cipher.AEAD
+	src   io.Reader
+	idx   int64
+	buf   []byte // 当前块未读出的明文
+	raw   []byte
+	final bool // 已读到最后一块
+	err   error
+}
+
+// CipherOffset 明文偏移 offset 所在块在密文中的起始偏移
+func CipherOffset(offset int64) int64 {
+	return offset / CryptChunkSize * cryptBlockSize
+}
+
+// NewDecryptReader 返回从明文偏移 offset 开始的解密流
+// src 为 SealChunks 生成的密文从 CipherOffset(offset) 开始的部分, 可以由对象存储的范围读取得到
+// 第一块在返回前就会解密, 密钥错误时立即返回 ErrDecrypt
+func NewDecryptReader(key []byte, src io.Reader, offset int64) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := offset / CryptChunkSize
+	r := &decryptReader{aead: aead, src: src, idx: idx, raw: make([]byte, cryptBlockSize)}
+	if err := r.next(); err != nil {
+		if err != io.EOF {
+			return nil, err
+		}
+		r.err = err
+	}
+	skip := int(offset % CryptChunkSize)
+	if skip > len(r.buf) {
+		skip = len(r.buf)
+	}
+	r.buf = r.buf[skip:]
+
+	return r, nil
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if err := r.next(); err != nil {
+			r.err = err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// next 读取并解密下一块, 读到最后一块后返回 io.EOF, 没有读到最后一块密文就结束时返回 ErrDecrypt
+func (r *decryptReader) next() error {
+	if r.final {
+		return io.EOF
+	}
+	n, err := io.ReadFull(r.src, r.raw)
+	if err == io.EOF {
+		// 密文在块边界处被截断
+		return ErrDecrypt
+	}
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	if n < cryptOverhead {
+		return ErrDecrypt
+	}
+
+	// 不完整的块只能是最后一块, 完整的块先按中间的块解密, 失败时再按最后一块解密
+	nonce, sealed := r.raw[:cryptNonceSize], r.raw[cryptNonceSize:n]
+	var plain []byte
+	err = ErrDecrypt
+	if n == cryptBlockSize {
+		plain, err = r.aead.Open(nil, nonce, sealed, chunkAAD(r.idx, false))
+	}
+	if err != nil {
+		if plain, err = r.aead.Open(nil, nonce, sealed, chunkAAD(r.idx, true)); err != nil {
+			return ErrDecrypt
+		}
+		r.final = true
+	}
+	r.idx++
+	r.buf = plain
+	if r.final {
+		return io.EOF
+	}
+
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func chunkAAD(idx int64, final bool) []byte {
+	aad := make([]byte, 9)
+	binary.BigEndian.PutUint64(aad, uint64(idx))
+	if final {
+		aad[8] = 1
+	}
+	return aad
+}
@@ -0,0 +1,161 @@
+package mws
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := NewDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// decryptFrom 按范围读取的方式从明文偏移 offset 处解密 sealed
+func decryptFrom(key, sealed []byte, offset int64) ([]byte, error) {
+	r, err := NewDecryptReader(key, bytes.NewReader(sealed[CipherOffset(offset):]), offset)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestSealChunksRoundTrip(t *testing.T) {
+	key := testKey(t)
+	for _, n := range []int{0, 1, CryptChunkSize - 1, CryptChunkSize, CryptChunkSize + 1, 3 * CryptChunkSize} {
+		plain := randomBytes(t, n)
+		sealed, err := SealChunks(key, plain, 0, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(sealed)) != CipherSize(int64(n)) || PlainSize(int64(len(sealed))) != int64(n) {
+			t.Fatalf("size %d: sealed %d bytes, CipherSize %d", n, len(sealed), CipherSize(int64(n)))
+		}
+
+		offsets := []int64{0}
+		if n > 0 {
+			offsets = append(offsets, int64(n)/2, int64(n)-1)
+		}
+		for _, off := range offsets {
+			got, err := decryptFrom(key, sealed, off)
+			if err != nil {
+				t.Fatalf("size %d offset %d: %v", n, off, err)
+			}
+			if !bytes.Equal(got, plain[off:]) {
+				t.Fatalf("size %d offset %d: plaintext mismatch", n, off)
+			}
+		}
+	}
+}
+
+func TestSealChunksMultipart(t *testing.T) {
+	key := testKey(t)
+	partSize := 2 * CryptChunkSize
+	plain := randomBytes(t, 2*partSize+100)
+
+	var sealed []byte
+	for first := 0; first < len(plain); first += partSize {
+		part := plain[first:min(first+partSize, len(plain))]
+		last := first+partSize >= len(plain)
+		out, err := SealChunks(key, part, int64(first/CryptChunkSize), last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sealed = append(sealed, out...)
+	}
+
+	got, err := decryptFrom(key, sealed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Fatal("plaintext mismatch")
+	}
+}
+
+func TestDecryptDetectsTruncation(t *testing.T) {
+	key := testKey(t)
+	plain := randomBytes(t, 3*CryptChunkSize+10)
+	sealed, err := SealChunks(key, plain, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]int{
+		"at a chunk boundary": 2 * cryptBlockSize,
+		"inside a chunk":      2*cryptBlockSize + 100,
+		"everything":          0,
+	}
+	for name, n := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decryptFrom(key, sealed[:n], 0); !errors.Is(err, ErrDecrypt) {
+				t.Fatalf("got %v, want ErrDecrypt", err)
+			}
+		})
+	}
+
+	// 长度恰好为分块整数倍的对象, 去掉最后一块后同样不能通过
+	even := randomBytes(t, 2*CryptChunkSize)
+	sealed, err = SealChunks(key, even, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decryptFrom(key, sealed[:cryptBlockSize], 0); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("got %v, want ErrDecrypt", err)
+	}
+}
+
+func TestDecryptRejectsUnfinishedMultipart(t *testing.T) {
+	key := testKey(t)
+	// 只有非最后一个分片的对象缺少最后一块
+	sealed, err := SealChunks(key, randomBytes(t, CryptChunkSize), 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decryptFrom(key, sealed, 0); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("got %v, want ErrDecrypt", err)
+	}
+}
+
+func TestDecryptDetectsTampering(t *testing.T) {
+	key := testKey(t)
+	plain := randomBytes(t, 2*CryptChunkSize)
+	sealed, err := SealChunks(key, plain, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("flipped bit", func(t *testing.T) {
+		bad := bytes.Clone(sealed)
+		bad[cryptNonceSize+10] ^= 1
+		if _, err := decryptFrom(key, bad, 0); !errors.Is(err, ErrDecrypt) {
+			t.Fatalf("got %v, want ErrDecrypt", err)
+		}
+	})
+	t.Run("swapped chunks", func(t *testing.T) {
+		bad := append(bytes.Clone(sealed[cryptBlockSize:]), sealed[:cryptBlockSize]...)
+		if _, err := decryptFrom(key, bad, 0); !errors.Is(err, ErrDecrypt) {
+			t.Fatalf("got %v, want ErrDecrypt", err)
+		}
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		if _, err := decryptFrom(testKey(t), sealed, 0); !errors.Is(err, ErrDecrypt) {
+			t.Fatalf("got %v, want ErrDecrypt", err)
+		}
+	})
+}
@@ -18,6 +18,33 @@ type FileChangeEvent struct {
 	Size      int64     `json:"size"`
 	Path      string    `json:"path"`
 	Timestamp time.Time `json:"timestamp"`
+
+	Items []FileEventItem `json:"items,omitempty"` // 批量操作涉及的条目
+	Quota *QuotaEvent     `json:"quota,omitempty"` // 用量跨越阈值时的配额信息
+
+	FileRequest *FileRequestEvent `json:"file_request,omitempty"` // 通过文件收集链接上传时的来源
+}
+
+// FileEventItem 批量操作事件中的单个条目
+type FileEventItem struct {
+	Type  string `json:"type"` // file/folder
+	Id    int64  `json:"id"`
+	NewId int64  `json:"new_id,omitempty"`
+	Name  string `json:"name"`
+}
+
+// QuotaEvent 用量跨越阈值事件的配额信息
+type QuotaEvent struct {
+	Threshold   int   `json:"threshold"` // 跨越的阈值(百分比): 80/95/100
+	CurrentSize int64 `json:"current_size"`
+	Capacity    int64 `json:"capacity"`
+}
+
+// FileRequestEvent 通过文件收集链接上传的来源信息, 用于通知链接的创建者
+type FileRequestEvent struct {
+	RequestId string `json:"request_id"`
+	Title     string `json:"title"`
+	Uploader  string `json:"uploader"`
 }
 
 type KafkaProducer struct {
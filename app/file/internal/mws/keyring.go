@@ -0,0 +1,202 @@
+package mws
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/config"
+)
+
+// defaultKMSPath 本地模拟 KMS 的默认密钥文件
+const defaultKMSPath = "config/kms.json"
+
+// ErrMasterKeyNotFound 包裹用户密钥的主密钥不存在, 通常是轮换后过早删除了旧的主密钥
+var ErrMasterKeyNotFound = errors.New("master key not found")
+
+// Keyring 主密钥, 用于包裹用户的密钥加密密钥
+// 主密钥来自配置; 未配置时使用本地文件模拟 KMS, 开启加密且文件不存在时自动生成
+type Keyring struct {
+	mu      sync.RWMutex
+	enabled bool
+	current string
+	keys    map[string][]byte
+	kmsPath string // 使用本地 KMS 时的密钥文件, 为空表示主密钥来自配置
+}
+
+// localKMS 本地 KMS 密钥文件的内容
+type localKMS struct {
+	Current string            `json:"current"`
+	Keys    map[string]string `json:"keys"`
+}
+
+func NewKeyring() *Keyring {
+	conf := config.GetConf().Encryption
+	k := &Keyring{enabled: conf.Enabled, keys: make(map[string][]byte)}
+
+	if len(conf.MasterKeys) > 0 {
+		for id, v := range conf.MasterKeys {
+			key, err := decodeMasterKey(v)
+			if err != nil {
+				panic(fmt.Errorf("master key %s: %w", id, err))
+			}
+			k.keys[id] = key
+		}
+		k.current = conf.MasterKeyId
+		if _, ok := k.keys[k.current]; !ok {
+			panic(fmt.Errorf("current master key %q is not configured", k.current))
+		}
+		return k
+	}
+
+	k.kmsPath = conf.LocalKMSPath
+	if k.kmsPath == "" {
+		k.kmsPath = defaultKMSPath
+	}
+	if err := k.loadLocal(); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			panic(err)
+		}
+		if k.enabled {
+			if _, err := k.RotateLocal(); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	return k
+}
+
+// Enabled 新写入的对象是否加密, 已加密的对象无论是否开启都能解密
+func (k *Keyring) Enabled() bool {
+	return k.enabled
+}
+
+// Local 主密钥是否由本地 KMS 管理
+func (k *Keyring) Local() bool {
+	return k.kmsPath != ""
+}
+
+// CurrentId 当前主密钥的 id
+func (k *Keyring) CurrentId() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.current
+}
+
+// Wrap 以当前主密钥包裹 key, 返回所用主密钥的 id
+func (k *Keyring) Wrap(key, aad []byte) (string, []byte, error) {
+	k.mu.RLock()
+	id, master := k.current, k.keys[k.current]
+	k.mu.RUnlock()
+	if master == nil {
+		return "", nil, ErrMasterKeyNotFound
+	}
+
+	wrapped, err := WrapKey(master, key, aad)
+	return id, wrapped, err
+}
+
+// Unwrap 以 id 对应的主密钥解开 key
+func (k *Keyring) Unwrap(id string, wrapped, aad []byte) ([]byte, error) {
+	k.mu.RLock()
+	master := k.keys[id]
+	k.mu.RUnlock()
+	if master == nil && k.Local() {
+		// 主密钥可能已被轮换命令在另一个进程中更新
+		k.mu.Lock()
+		if err := k.loadLocal(); err != nil {
+			log.Printf("failed to reload local kms: %v", err)
+		}
+		master = k.keys[id]
+		k.mu.Unlock()
+	}
+	if master == nil {
+		return nil, ErrMasterKeyNotFound
+	}
+
+	return UnwrapKey(master, wrapped, aad)
+}
+
+// RotateLocal 在本地 KMS 中生成新的主密钥并设为当前密钥, 旧密钥保留用于解包
+// 主密钥来自配置时, 需要在配置中新增密钥并修改 masterKeyId
+func (k *Keyring) RotateLocal() (string, error) {
+	if !k.Local() {
+		return "", errors.New("master keys are managed by config")
+	}
+	key, err := NewDataKey()
+	if err != nil {
+		return "", err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	id := fmt.Sprintf("local-%d", time.Now().UnixNano())
+	if _, ok := k.keys[id]; ok {
+		return "", fmt.Errorf("master key %s already exists", id)
+	}
+	kms := localKMS{Current: id, Keys: map[string]string{id: base64.StdEncoding.EncodeToString(key)}}
+	for kid, v := range k.keys {
+		kms.Keys[kid] = base64.StdEncoding.EncodeToString(v)
+	}
+	data, err := json.MarshalIndent(kms, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(k.kmsPath), 0700); err != nil {
+		return "", err
+	}
+	// 先写临时文件再替换, 写入成功后才启用新密钥, 避免用未持久化的主密钥包裹用户密钥
+	tmp := k.kmsPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, k.kmsPath); err != nil {
+		return "", err
+	}
+	k.keys[id] = key
+	k.current = id
+
+	return id, nil
+}
+
+func (k *Keyring) loadLocal() error {
+	data, err := os.ReadFile(k.kmsPath)
+	if err != nil {
+		return err
+	}
+	var kms localKMS
+	if err := json.Unmarshal(data, &kms); err != nil {
+		return fmt.Errorf("parse %s: %w", k.kmsPath, err)
+	}
+	for id, v := range kms.Keys {
+		key, err := decodeMasterKey(v)
+		if err != nil {
+			return fmt.Errorf("master key %s: %w", id, err)
+		}
+		k.keys[id] = key
+	}
+	k.current = kms.Current
+
+	return nil
+}
+
+func decodeMasterKey(v string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != DataKeySize {
+		return nil, fmt.Errorf("master key must be %d bytes", DataKeySize)
+	}
+
+	return key, nil
+}
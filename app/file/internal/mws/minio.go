@@ -3,34 +3,34 @@ package mws
 import (
 	"bytes"
 	"context"
+	"io"
 	"log"
+	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/minio/minio-go/v7"
-
-	"github.com/crazyfrankie/cloudstorage/app/file/internal/config"
 )
 
-type MinioServer struct {
+// MinioStore 以 MinIO 的一个 Bucket 存放对象
+type MinioStore struct {
 	client     *minio.Client
 	core       *minio.Core
 	BucketName string
 }
 
-func NewMinioServer(client *minio.Client) *MinioServer {
-	name := config.GetConf().Minio.BucketName
+func NewMinioStore(client *minio.Client, bucket string) *MinioStore {
 	// 从 client 获取 core
 	core := &minio.Core{Client: client}
-	server := &MinioServer{client: client, BucketName: name, core: core}
+	store := &MinioStore{client: client, BucketName: bucket, core: core}
 
-	server.MakeBucket(context.Background(), name)
+	store.MakeBucket(context.Background(), bucket)
 
-	return server
+	return store
 }
 
 // MakeBucket 创建 Bucket
-func (m *MinioServer) MakeBucket(ctx context.Context, name string) {
+func (m *MinioStore) MakeBucket(ctx context.Context, name string) {
 	exists, err := m.client.BucketExists(ctx, name)
 	if err != nil {
 		return
@@ -45,41 +45,132 @@ func (m *MinioServer) MakeBucket(ctx context.Context, name string) {
 	}
 }
 
-// PutToBucket 放入对象
-func (m *MinioServer) PutToBucket(ctx context.Context, bucketName, filename string, filesize int64, data []byte) (minio.UploadInfo, error) {
-	info, err := m.client.PutObject(ctx, bucketName, filename, bytes.NewReader(data), filesize, minio.PutObjectOptions{})
-	return info, err
+// Put 放入对象
+func (m *MinioStore) Put(ctx context.Context, key string, data []byte) error {
+	return m.PutStream(ctx, key, bytes.NewReader(data), int64(len(data)))
+}
+
+// PutStream 从 r 放入对象
+func (m *MinioStore) PutStream(ctx context.Context, key string, r io.Reader, size int64) error {
+	_, err := m.client.PutObject(ctx, m.BucketName, key, r, size, minio.PutObjectOptions{})
+	return err
+}
+
+// CreateMultipart 初始化分片上传
+func (m *MinioStore) CreateMultipart(ctx context.Context, key string) (string, error) {
+	return m.core.NewMultipartUpload(ctx, m.BucketName, key, minio.PutObjectOptions{})
+}
+
+// PutPart 上传分片
+func (m *MinioStore) PutPart(ctx context.Context, key, uploadId string, partNumber int, data []byte) (string, error) {
+	part, err := m.core.PutObjectPart(ctx, m.BucketName, key, uploadId, partNumber,
+		bytes.NewReader(data), int64(len(data)), minio.PutObjectPartOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return part.ETag, nil
+}
+
+// CompleteMultipart 完成分片上传
+func (m *MinioStore) CompleteMultipart(ctx context.Context, key, uploadId string, parts []BlobPart) error {
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, p := range parts {
+		completeParts = append(completeParts, minio.CompletePart{PartNumber: p.PartNumber, ETag: p.ETag})
+	}
+
+	_, err := m.core.CompleteMultipartUpload(ctx, m.BucketName, key, uploadId, completeParts, minio.PutObjectOptions{})
+	return err
+}
+
+// AbortMultipart 中止分片上传, 丢弃已上传的分片
+func (m *MinioStore) AbortMultipart(ctx context.Context, key, uploadId string) error {
+	err := m.core.AbortMultipartUpload(ctx, m.BucketName, key, uploadId)
+	if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+		return nil
+	}
+
+	return err
+}
+
+// Get 范围读取对象
+func (m *MinioStore) Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	opts := minio.GetObjectOptions{}
+	if offset > 0 || length > 0 {
+		end := int64(0)
+		if length > 0 {
+			end = offset + length - 1
+		}
+		if err := opts.SetRange(offset, end); err != nil {
+			return nil, err
+		}
+	}
+
+	// GetObject 在第一次读取时才发出请求, 先 Stat 以便对象不存在时立即返回 ErrBlobNotFound
+	obj, err := m.client.GetObject(ctx, m.BucketName, key, opts)
+	if err != nil {
+		return nil, toBlobError(err)
+	}
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, toBlobError(err)
+	}
+
+	return obj, nil
 }
 
-// GetObject 获取对象信息
-func (m *MinioServer) GetObject(ctx context.Context, bucketName, filename string) (*minio.Object, error) {
-	info, err := m.client.GetObject(ctx, bucketName, filename, minio.GetObjectOptions{})
-	return info, err
+// Stat 获取对象信息
+func (m *MinioStore) Stat(ctx context.Context, key string) (BlobInfo, error) {
+	info, err := m.client.StatObject(ctx, m.BucketName, key, minio.StatObjectOptions{})
+	if err != nil {
+		return BlobInfo{}, toBlobError(err)
+	}
+
+	return BlobInfo{Key: info.Key, Size: info.Size, ETag: info.ETag, ModTime: info.LastModified}, nil
 }
 
-// PresignedGetObject 获取预览 URL
-func (m *MinioServer) PresignedGetObject(ctx context.Context, bucketName, filename string, expiration time.Duration) (*url.URL, error) {
-	reqParams := make(url.Values)
-	return m.client.PresignedGetObject(ctx, bucketName, filename, expiration, reqParams)
+// Delete 删除对象
+func (m *MinioStore) Delete(ctx context.Context, key string) error {
+	return m.client.RemoveObject(ctx, m.BucketName, key, minio.RemoveObjectOptions{})
 }
 
-// CreateMultipartUpload 初始化分片上传
-func (m *MinioServer) CreateMultipartUpload(ctx context.Context, bucketName, filename string) (string, error) {
-	uploadID, err := m.core.NewMultipartUpload(ctx, bucketName, filename, minio.PutObjectOptions{})
+// Presign 获取预签名的下载 URL
+func (m *MinioStore) Presign(ctx context.Context, key string, expires time.Duration) (string, error) {
+	u, err := m.client.PresignedGetObject(ctx, m.BucketName, key, expires, make(url.Values))
 	if err != nil {
 		return "", err
 	}
-	return uploadID, nil
+
+	return u.String(), nil
 }
 
-// PutObjectPart 上传分片
-func (m *MinioServer) PutObjectPart(ctx context.Context, bucketName, objectName, uploadID string, partNumber int,
-	data []byte, size int64) (minio.ObjectPart, error) {
-	return m.core.PutObjectPart(ctx, bucketName, objectName, uploadID, partNumber, bytes.NewReader(data), size, minio.PutObjectPartOptions{})
+// List 列出对象
+func (m *MinioStore) List(ctx context.Context, prefix, after string, limit int) ([]BlobInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var res []BlobInfo
+	for obj := range m.client.ListObjects(ctx, m.BucketName, minio.ListObjectsOptions{
+		Prefix:     prefix,
+		StartAfter: after,
+		Recursive:  true,
+	}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		res = append(res, BlobInfo{Key: obj.Key, Size: obj.Size, ETag: obj.ETag, ModTime: obj.LastModified})
+		if len(res) >= limit {
+			break
+		}
+	}
+
+	return res, nil
 }
 
-// CompleteMultipartUpload 完成分片上传
-func (m *MinioServer) CompleteMultipartUpload(ctx context.Context, bucketName, objectName, uploadID string,
-	parts []minio.CompletePart) (minio.UploadInfo, error) {
-	return m.core.CompleteMultipartUpload(ctx, bucketName, objectName, uploadID, parts, minio.PutObjectOptions{})
+func toBlobError(err error) error {
+	if resp := minio.ToErrorResponse(err); resp.Code == "NoSuchKey" || resp.StatusCode == http.StatusNotFound {
+		return ErrBlobNotFound
+	}
+
+	return err
 }
@@ -0,0 +1,99 @@
+package mws
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Scanner 病毒扫描, 文件内容提交后由扫描处理器调用
+// 驱动在配置的 scan.driver 中选择
+type Scanner interface {
+	// Name 驱动名称, 与结论一起记录
+	Name() string
+	// Scan 扫描 r 的全部内容, 只有无法完成扫描时才返回错误
+	Scan(ctx context.Context, r io.Reader) (ScanVerdict, error)
+}
+
+// ScanVerdict 扫描的结论
+type ScanVerdict struct {
+	Infected bool
+	// Signature 命中的病毒特征名称, 未感染时为空
+	Signature string
+}
+
+// eicarSignature EICAR 标准测试文件的内容, 用于验证扫描和隔离流程
+const eicarSignature = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// signatureScanBuffer 特征扫描每次读取的字节数
+const signatureScanBuffer = 64 << 10
+
+// SignatureScanner 按特征列表逐字节匹配内容的扫描驱动, 内置 EICAR 测试特征, 用于测试和没有 clamd 的部署
+type SignatureScanner struct {
+	names    []string
+	patterns [][]byte
+	maxLen   int
+}
+
+// NewSignatureScanner 创建特征扫描驱动, signatures 为特征名称到十六进制编码的内容, 在内置的 EICAR 特征之外匹配
+func NewSignatureScanner(signatures map[string]string) (*SignatureScanner, error) {
+	s := &SignatureScanner{}
+	s.add("Eicar-Test-Signature", []byte(eicarSignature))
+
+	names := make([]string, 0, len(signatures))
+	for name := range signatures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pattern, err := hex.DecodeString(signatures[name])
+		if err != nil || len(pattern) == 0 {
+			return nil, fmt.Errorf("invalid signature %q: must be non-empty hex", name)
+		}
+		s.add(name, pattern)
+	}
+
+	return s, nil
+}
+
+func (s *SignatureScanner) add(name string, pattern []byte) {
+	s.names = append(s.names, name)
+	s.patterns = append(s.patterns, pattern)
+	s.maxLen = max(s.maxLen, len(pattern))
+}
+
+func (s *SignatureScanner) Name() string {
+	return "signature"
+}
+
+// Scan 流式匹配, 每次保留上一块末尾 maxLen-1 字节, 跨块的特征也能命中
+func (s *SignatureScanner) Scan(ctx context.Context, r io.Reader) (ScanVerdict, error) {
+	buf := make([]byte, 0, s.maxLen-1+signatureScanBuffer)
+	chunk := make([]byte, signatureScanBuffer)
+	for {
+		if err := ctx.Err(); err != nil {
+			return ScanVerdict{}, err
+		}
+
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		for i, p := range s.patterns {
+			if bytes.Contains(buf, p) {
+				return ScanVerdict{Infected: true, Signature: s.names[i]}, nil
+			}
+		}
+		if keep := s.maxLen - 1; len(buf) > keep {
+			buf = append(buf[:0], buf[len(buf)-keep:]...)
+		}
+
+		if err == io.EOF {
+			return ScanVerdict{}, nil
+		}
+		if err != nil {
+			return ScanVerdict{}, err
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package mws
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSignatureScannerFindsEicar(t *testing.T) {
+	s, err := NewSignatureScanner(map[string]string{"Custom-Test": hex.EncodeToString([]byte("bad-bytes"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 特征跨过两次读取的边界时同样命中
+	padding := strings.Repeat("a", signatureScanBuffer-10)
+	cases := map[string]ScanVerdict{
+		"clean":                            {},
+		eicarSignature:                     {Infected: true, Signature: "Eicar-Test-Signature"},
+		padding + eicarSignature + padding: {Infected: true, Signature: "Eicar-Test-Signature"},
+		"prefix bad-bytes":                 {Infected: true, Signature: "Custom-Test"},
+	}
+	for content, want := range cases {
+		got, err := s.Scan(context.Background(), bytes.NewReader([]byte(content)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("scan of %d bytes: got %+v, want %+v", len(content), got, want)
+		}
+	}
+}
+
+func TestNewSignatureScannerRejectsInvalidHex(t *testing.T) {
+	if _, err := NewSignatureScanner(map[string]string{"bad": "zz"}); err == nil {
+		t.Fatal("accepted a non-hex signature")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"os"
 	"syscall"
 	"time"
 
@@ -13,6 +14,13 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "rotate-keys" {
+		if err := rotateKeys(os.Args[2:]); err != nil {
+			log.Fatalf("failed to rotate keys: %v", err)
+		}
+		return
+	}
+
 	server := rpc.NewServer()
 
 	g := &run.Group{}
@@ -33,6 +41,10 @@ func main() {
 				EnableOpenMetrics: true,
 			},
 		))
+		// local 驱动的预签名地址指向这里, 形如 <storage.localURL>/<key>
+		if server.BlobHandler != nil {
+			mux.Handle("/blob/", http.StripPrefix("/blob", server.BlobHandler))
+		}
 		fileServer.Handler = mux
 		return fileServer.ListenAndServe()
 	}, func(err error) {
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/ioc"
+)
+
+// rotateKeys 轮换加密密钥, 只重新包裹下一层的密钥, 不重写对象
+//
+//	file rotate-keys -user 42   轮换用户 42 的密钥加密密钥
+//	file rotate-keys -all       轮换所有用户的密钥加密密钥
+//	file rotate-keys -master    轮换主密钥, 并用新主密钥重新包裹所有用户密钥
+func rotateKeys(args []string) error {
+	fs := flag.NewFlagSet("rotate-keys", flag.ExitOnError)
+	uid := fs.Int("user", 0, "rotate the key-encryption key of this user")
+	all := fs.Bool("all", false, "rotate the key-encryption keys of all users")
+	master := fs.Bool("master", false, "rotate the master key and re-wrap all user keys")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *uid == 0 && !*all && !*master {
+		fs.Usage()
+		return errors.New("nothing to rotate")
+	}
+
+	ctx := context.Background()
+	keys := ioc.InitKeyManager()
+
+	if *master {
+		n, err := keys.RotateMasterKey(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("re-wrapped %d user keys with the current master key", n)
+	}
+
+	switch {
+	case *all:
+		n, err := keys.RotateAllUserKeys(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("re-wrapped %d data keys", n)
+	case *uid != 0:
+		n, err := keys.RotateUserKey(ctx, int32(*uid))
+		if err != nil {
+			return err
+		}
+		log.Printf("re-wrapped %d data keys of user %d", n, *uid)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// adminTokenKey 管理令牌所在的元数据
+const adminTokenKey = "x-admin-token"
+
+// adminMethods 不经网关暴露、供运维调用的管理接口
+var adminMethods = map[string]bool{
+	file.FileService_ReconcileQuota_FullMethodName:         true,
+	file.FileService_SavePlan_FullMethodName:               true,
+	file.FileService_ListPlans_FullMethodName:              true,
+	file.FileService_AssignPlan_FullMethodName:             true,
+	file.FileService_GrantCapacity_FullMethodName:          true,
+	file.FileService_ListUsersNearQuota_FullMethodName:     true,
+	file.FileService_ScrubStorage_FullMethodName:           true,
+	file.FileService_ListScrubFindings_FullMethodName:      true,
+	file.FileService_ReprocessFile_FullMethodName:          true,
+	file.FileService_ListDeadLetters_FullMethodName:        true,
+	file.FileService_RetryDeadLetters_FullMethodName:       true,
+	file.FileService_ListQuarantinedFiles_FullMethodName:   true,
+	file.FileService_ReleaseQuarantinedFile_FullMethodName: true,
+	file.FileService_QueryAuditLogs_FullMethodName:         true,
+	file.FileService_DumpAuditLogs_FullMethodName:          true,
+}
+
+// checkAdmin 调用管理接口时校验元数据中的管理令牌, token 为空时拒绝全部管理接口
+func checkAdmin(ctx context.Context, token, method string) error {
+	if !adminMethods[method] {
+		return nil
+	}
+	if token == "" {
+		return status.Error(codes.PermissionDenied, "admin api is disabled")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(adminTokenKey) {
+		if subtle.ConstantTimeCompare([]byte(v), []byte(token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "invalid admin token")
+}
+
+// adminUnaryInterceptor 拦截没有携带管理令牌的管理接口调用
+func adminUnaryInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkAdmin(ctx, token, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// adminStreamInterceptor 拦截没有携带管理令牌的管理接口调用
+func adminStreamInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkAdmin(ss.Context(), token, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
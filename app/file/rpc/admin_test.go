@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+func TestCheckAdmin(t *testing.T) {
+	withToken := func(v string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(adminTokenKey, v))
+	}
+	cases := []struct {
+		name   string
+		ctx    context.Context
+		token  string
+		method string
+		want   codes.Code
+	}{
+		{"user api", context.Background(), "secret", file.FileService_ListFolder_FullMethodName, codes.OK},
+		{"valid token", withToken("secret"), "secret", file.FileService_ReconcileQuota_FullMethodName, codes.OK},
+		{"stream api", withToken("secret"), "secret", file.FileService_DumpAuditLogs_FullMethodName, codes.OK},
+		{"missing token", context.Background(), "secret", file.FileService_GrantCapacity_FullMethodName, codes.Unauthenticated},
+		{"wrong token", withToken("guess"), "secret", file.FileService_QueryAuditLogs_FullMethodName, codes.Unauthenticated},
+		{"not configured", withToken(""), "", file.FileService_ScrubStorage_FullMethodName, codes.PermissionDenied},
+	}
+	for _, c := range cases {
+		if got := status.Code(checkAdmin(c.ctx, c.token, c.method)); got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
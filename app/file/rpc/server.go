@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"time"
 
 	"github.com/crazyfrankie/framework-plugin/grpcx/interceptor/circuitbreaker"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	"github.com/prometheus/client_golang/prometheus"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/naming/endpoints"
@@ -23,7 +25,10 @@ import (
 	oteltrace "go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/crazyfrankie/cloudstorage/app/file/internal/biz/service"
 	"github.com/crazyfrankie/cloudstorage/app/file/internal/config"
 	"github.com/crazyfrankie/cloudstorage/app/file/internal/ioc"
 	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
@@ -39,6 +44,8 @@ type Server struct {
 	*grpc.Server
 	Addr   string
 	client *clientv3.Client
+	// BlobHandler 对象存储的下载服务, 使用 local 驱动时挂载到 HTTP 端口, 否则为 nil
+	BlobHandler http.Handler
 }
 
 func NewServer() *Server {
@@ -64,7 +71,59 @@ func NewServer() *Server {
 	}
 
 	// 设置 prometheus
-	FileReg.MustRegister(fileMetrics)
+	FileReg.MustRegister(fileMetrics, service.QuotaDriftBytes, service.QuotaDriftUsers,
+		service.ScrubFiles, service.ScrubBytes, service.ScrubProblems, service.ScrubFindings,
+		service.ProcessingRuns, service.QuarantinedFiles, service.WebhookDeliveries)
+
+	// 周期性空间对账, 偏差通过指标上报
+	if interval := config.GetConf().Storage.QuotaReconcileInterval; interval > 0 {
+		go f.RunQuotaReconciler(context.Background(), interval)
+	}
+
+	// 投递用量阈值事件, 收回到期的临时容量
+	eventInterval := config.GetConf().Storage.QuotaEventInterval
+	if eventInterval <= 0 {
+		eventInterval = 30 * time.Second
+	}
+	go f.RunQuotaEventDispatcher(context.Background(), eventInterval)
+
+	// 将到期的分享标记为已过期
+	shareInterval := config.GetConf().Share.ExpireInterval
+	if shareInterval <= 0 {
+		shareInterval = time.Minute
+	}
+	go f.RunShareExpirer(context.Background(), shareInterval)
+
+	// 重试提交或回滚进程退出时卡住的上传
+	repairInterval := config.GetConf().Storage.UploadRepairInterval
+	if repairInterval <= 0 {
+		repairInterval = time.Minute
+	}
+	go f.RunUploadRepairer(context.Background(), repairInterval)
+
+	// 巡检: 周期性地重新计算已存储内容的哈希, 发现损坏或丢失的对象
+	scrubInterval, scrubPeriod := config.GetConf().Storage.ScrubInterval, config.GetConf().Storage.ScrubPeriod
+	if scrubInterval <= 0 {
+		scrubInterval = time.Minute
+	}
+	if scrubPeriod <= 0 {
+		scrubPeriod = 30 * 24 * time.Hour
+	}
+	go f.RunScrubber(context.Background(), scrubInterval, scrubPeriod)
+
+	// 文件提交后的处理流水线: 提取元数据、文本等
+	processInterval := config.GetConf().Processing.Interval
+	if processInterval <= 0 {
+		processInterval = 10 * time.Second
+	}
+	go f.RunProcessingPipeline(context.Background(), processInterval)
+
+	// 投递文件变更事件到用户订阅的 webhook
+	webhookInterval := config.GetConf().Webhook.DispatchInterval
+	if webhookInterval <= 0 {
+		webhookInterval = 10 * time.Second
+	}
+	go f.RunWebhookDispatcher(context.Background(), webhookInterval)
 
 	// 设置 OpenTelemetry
 	tp := initTracerProvider("cloud-storage/server/file")
@@ -74,16 +133,30 @@ func NewServer() *Server {
 		propagation.Baggage{},
 	))
 
+	// 处理请求时的 panic 只让该请求失败, 不影响整个服务
+	recoverPanic := recovery.WithRecoveryHandlerContext(func(ctx context.Context, p any) error {
+		rpcLogger.Error("recovered from panic", zap.Any("panic", p), zap.Stack("stack"))
+		return status.Error(codes.Internal, "internal error")
+	})
+
 	s := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
+			recovery.UnaryServerInterceptor(recoverPanic),
 			fileMetrics.UnaryServerInterceptor(grpcprom.WithExemplarFromContext(labelsFromContext)),
 			logging.UnaryServerInterceptor(interceptorLogger(rpcLogger), logging.WithFieldsFromContext(logTraceID)),
 			circuitbreaker.NewInterceptorBuilder().Build(),
+			// 管理接口须携带管理令牌
+			adminUnaryInterceptor(config.GetConf().Admin.Token),
+			// 审计修改操作、下载和分享访问
+			f.AuditUnaryInterceptor(),
 		),
 		grpc.ChainStreamInterceptor(
+			recovery.StreamServerInterceptor(recoverPanic),
 			fileMetrics.StreamServerInterceptor(grpcprom.WithExemplarFromContext(labelsFromContext)),
 			logging.StreamServerInterceptor(interceptorLogger(rpcLogger), logging.WithFieldsFromContext(logTraceID)),
+			adminStreamInterceptor(config.GetConf().Admin.Token),
+			f.AuditStreamInterceptor(),
 		),
 		grpc.MaxRecvMsgSize(20*1024*1024),
 		grpc.MaxSendMsgSize(20*1024*1024))
@@ -92,9 +165,10 @@ func NewServer() *Server {
 	fileMetrics.InitializeMetrics(s)
 
 	return &Server{
-		Server: s,
-		Addr:   config.GetConf().Server.Addr,
-		client: client,
+		Server:      s,
+		Addr:        config.GetConf().Server.Addr,
+		client:      client,
+		BlobHandler: f.BlobHandler(),
 	}
 }
 
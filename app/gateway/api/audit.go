@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// ListAuditLogs 我执行的或针对我的数据的文件操作
+func (h *FileHandler) ListAuditLogs() gin.HandlerFunc {
+	return listAuditLogs(h.cli.ListAuditLogs)
+}
+
+// ExportAuditLogs 导出我执行的或针对我的数据的文件操作, format 为 csv 或 jsonl
+func (h *FileHandler) ExportAuditLogs() gin.HandlerFunc {
+	return exportAuditLogs("file", h.cli.ExportAuditLogs)
+}
+
+// ListAuditLogs 我执行的或针对我的登录、资料和团队操作
+func (h *UserHandler) ListAuditLogs() gin.HandlerFunc {
+	return listAuditLogs(h.cli.ListAuditLogs)
+}
+
+// ExportAuditLogs 导出我执行的或针对我的登录、资料和团队操作, format 为 csv 或 jsonl
+func (h *UserHandler) ExportAuditLogs() gin.HandlerFunc {
+	return exportAuditLogs("user", h.cli.ExportAuditLogs)
+}
+
+func listAuditLogs(list func(context.Context, *file.ListAuditLogsRequest, ...grpc.CallOption) (*file.ListAuditLogsResponse, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := list(c.Request.Context(), &file.ListAuditLogsRequest{
+			UserId: claims.UserId,
+			Filter: auditFilter(c),
+			Page:   int32(page),
+			Size:   int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+func exportAuditLogs(service string, export func(context.Context, *file.ExportAuditLogsRequest, ...grpc.CallOption) (grpc.ServerStreamingClient[file.ExportAuditLogsResponse], error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, ext, mime := file.AuditExportFormat_AUDIT_EXPORT_FORMAT_CSV, "csv", "text/csv"
+		if c.Query("format") == "jsonl" {
+			format, ext, mime = file.AuditExportFormat_AUDIT_EXPORT_FORMAT_JSONL, "jsonl", "application/x-ndjson"
+		}
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		stream, err := export(c.Request.Context(), &file.ExportAuditLogsRequest{
+			UserId: claims.UserId,
+			Filter: auditFilter(c),
+			Format: format,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		setHeader(c, "audit-"+service+"."+ext, mime)
+		c.Stream(func(w io.Writer) bool {
+			chunk, err := stream.Recv()
+			if err != nil {
+				return false
+			}
+			_, err = w.Write(chunk.Data)
+			return err == nil
+		})
+	}
+}
+
+// auditFilter 从查询参数中获取审计日志的过滤条件, startTime 和 endTime 为 unix 秒, action 以 * 结尾时按前缀匹配
+func auditFilter(c *gin.Context) *file.AuditLogFilter {
+	start, _ := strconv.ParseInt(c.Query("startTime"), 10, 64)
+	end, _ := strconv.ParseInt(c.Query("endTime"), 10, 64)
+	actorId, _ := strconv.Atoi(c.Query("actorId"))
+
+	return &file.AuditLogFilter{
+		ActorId:    int32(actorId),
+		StartTime:  start,
+		EndTime:    end,
+		Action:     c.Query("action"),
+		TargetType: c.Query("targetType"),
+		TargetId:   c.Query("targetId"),
+	}
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+type batchItem struct {
+	Type    int32  `json:"type"` // 0-文件 1-文件夹
+	Id      int64  `json:"id"`
+	NewName string `json:"newName"` // 仅重命名使用
+}
+
+type batchRequest struct {
+	Items          []batchItem `json:"items"`
+	Atomic         bool        `json:"atomic"` // true 时全部成功或全部回滚
+	ToFolderID     int64       `json:"toFolderID"`
+	ConflictPolicy int32       `json:"conflictPolicy"` // 0-报错 1-自动重命名 2-跳过 3-覆盖为新版本(仅文件)
+}
+
+func (r *batchRequest) toPb(uid int32) *file.BatchOperationRequest {
+	items := make([]*file.BatchItem, 0, len(r.Items))
+	for _, it := range r.Items {
+		items = append(items, &file.BatchItem{
+			Type:    file.BatchItemType(it.Type),
+			Id:      it.Id,
+			NewName: it.NewName,
+		})
+	}
+
+	mode := file.BatchMode_BATCH_BEST_EFFORT
+	if r.Atomic {
+		mode = file.BatchMode_BATCH_ATOMIC
+	}
+
+	return &file.BatchOperationRequest{
+		UserId:         uid,
+		Items:          items,
+		Mode:           mode,
+		ToFolderId:     r.ToFolderID,
+		ConflictPolicy: file.NameConflictPolicy(r.ConflictPolicy),
+	}
+}
+
+// BatchMove 批量移动
+func (h *FileHandler) BatchMove() gin.HandlerFunc {
+	return h.batch(h.cli.BatchMove)
+}
+
+// BatchCopy 批量复制
+func (h *FileHandler) BatchCopy() gin.HandlerFunc {
+	return h.batch(h.cli.BatchCopy)
+}
+
+// BatchDelete 批量删除
+func (h *FileHandler) BatchDelete() gin.HandlerFunc {
+	return h.batch(h.cli.BatchDelete)
+}
+
+// BatchRestore 批量恢复
+func (h *FileHandler) BatchRestore() gin.HandlerFunc {
+	return h.batch(h.cli.BatchRestore)
+}
+
+// BatchRename 批量重命名
+func (h *FileHandler) BatchRename() gin.HandlerFunc {
+	return h.batch(h.cli.BatchRename)
+}
+
+func (h *FileHandler) batch(call func(ctx context.Context, in *file.BatchOperationRequest, opts ...grpc.CallOption) (*file.BatchOperationResponse, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req batchRequest
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := call(c.Request.Context(), req.toPb(claims.UserId))
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
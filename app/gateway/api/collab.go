@@ -0,0 +1,119 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/user"
+)
+
+// ShareWithUser 将文件夹或文件共享给其他用户, 按用户名或手机号邀请
+func (h *FileHandler) ShareWithUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			IsFolder   bool   `json:"isFolder"`
+			ResourceId int64  `json:"resourceId"`
+			Name       string `json:"name"`  // 被邀请者的用户名, 与 phone 二选一
+			Phone      string `json:"phone"` // 被邀请者的手机号
+			Role       int32  `json:"role"`  // 1-查看 2-评论 3-编辑
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		target, err := h.userCli.FindUser(c.Request.Context(), &user.FindUserRequest{Name: req.Name, Phone: req.Phone})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.ShareWithUser(c.Request.Context(), &file.ShareWithUserRequest{
+			UserId:       claims.UserId,
+			IsFolder:     req.IsFolder,
+			ResourceId:   req.ResourceId,
+			TargetUserId: target.GetUser().GetId(),
+			Role:         file.AclRole(req.Role),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// RevokeUserShare 收回共享, userId 为自己时表示退出共享
+func (h *FileHandler) RevokeUserShare() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			IsFolder   bool  `json:"isFolder"`
+			ResourceId int64 `json:"resourceId"`
+			UserId     int32 `json:"userId"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.RevokeUserShare(c.Request.Context(), &file.RevokeUserShareRequest{
+			UserId:       claims.UserId,
+			IsFolder:     req.IsFolder,
+			ResourceId:   req.ResourceId,
+			TargetUserId: req.UserId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListCollaborators 获取文件夹或文件的协作者
+func (h *FileHandler) ListCollaborators() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		isFolder, _ := strconv.ParseBool(c.Query("isFolder"))
+		resourceId, _ := strconv.ParseInt(c.Query("resourceId"), 10, 64)
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListCollaborators(c.Request.Context(), &file.ListCollaboratorsRequest{
+			UserId:     claims.UserId,
+			IsFolder:   isFolder,
+			ResourceId: resourceId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListSharedWithMe 与我共享的文件夹和文件
+func (h *FileHandler) ListSharedWithMe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListSharedWithMe(c.Request.Context(), &file.ListSharedWithMeRequest{
+			UserId: claims.UserId,
+			Page:   int32(page),
+			Size:   int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// CopyFile 复制文件
+func (h *FileHandler) CopyFile() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			FileId         int64 `json:"fileId"`
+			ToFolderID     int64 `json:"toFolderID"`
+			ConflictPolicy int32 `json:"conflictPolicy"` // 0-报错 1-自动重命名 2-跳过 3-覆盖为新版本(仅文件)
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.CopyFile(c.Request.Context(), &file.CopyFileRequest{
+			UserId:         claims.UserId,
+			FileId:         req.FileId,
+			ToFolderId:     req.ToFolderID,
+			ConflictPolicy: file.NameConflictPolicy(req.ConflictPolicy),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// CopyFolder 复制文件夹
+func (h *FileHandler) CopyFolder() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			FolderId       int64 `json:"folderId"`
+			ToFolderID     int64 `json:"toFolderID"`
+			ConflictPolicy int32 `json:"conflictPolicy"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.CopyFolder(c.Request.Context(), &file.CopyFolderRequest{
+			UserId:         claims.UserId,
+			FolderId:       req.FolderId,
+			ToFolderId:     req.ToFolderID,
+			ConflictPolicy: file.NameConflictPolicy(req.ConflictPolicy),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// GetJob 查询异步任务进度
+func (h *FileHandler) GetJob() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.GetJob(c.Request.Context(), &file.GetJobRequest{
+			JobId:  c.Param("jobId"),
+			UserId: claims.UserId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
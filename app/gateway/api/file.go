@@ -18,14 +18,16 @@ import (
 	"github.com/crazyfrankie/cloudstorage/app/gateway/common/util"
 	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
 	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/user"
 )
 
 type FileHandler struct {
-	cli file.FileServiceClient
+	cli     file.FileServiceClient
+	userCli user.UserServiceClient // 邀请协作者时按用户名或手机号查找用户
 }
 
-func NewFileHandler(cli file.FileServiceClient) *FileHandler {
-	return &FileHandler{cli: cli}
+func NewFileHandler(cli file.FileServiceClient, userCli user.UserServiceClient) *FileHandler {
+	return &FileHandler{cli: cli, userCli: userCli}
 }
 
 func (h *FileHandler) RegisterRoute(r *gin.Engine) {
@@ -33,6 +35,9 @@ func (h *FileHandler) RegisterRoute(r *gin.Engine) {
 	{
 		fileGroup.POST("/upload", h.Upload())
 		fileGroup.POST("/upload/chunk", h.UploadChunk())
+		fileGroup.POST("/upload/part", h.UploadPart())
+		fileGroup.GET("/upload/:uploadId/parts", h.ListUploadParts())
+		fileGroup.POST("/upload/abort", h.AbortUpload())
 		fileGroup.POST("/update", h.UpdateFile())
 		fileGroup.GET("/download/:id", h.Download())
 		fileGroup.GET("/preview/:id", h.Preview())
@@ -45,8 +50,68 @@ func (h *FileHandler) RegisterRoute(r *gin.Engine) {
 		fileGroup.POST("/folder/create", h.CreateFolder())
 		fileGroup.POST("/folder/list", h.ListFolder())
 		fileGroup.POST("/folder/move", h.MoveFolder())
+		fileGroup.GET("/folder/tree", h.GetFolderTree())
 		fileGroup.POST("/share", h.CreateShareLink())
+		fileGroup.GET("/share/list", h.ListShares())
+		fileGroup.POST("/share/revoke", h.RevokeShares())
+		fileGroup.POST("/share/update", h.UpdateShare())
+		fileGroup.GET("/share/access/:shareId", h.GetShareAccessLog())
 		fileGroup.POST("/save", h.SaveToMyDrive())
+		fileGroup.GET("/meta/:id", h.GetFileMeta())
+		fileGroup.POST("/meta/set", h.SetFileMeta())
+		fileGroup.POST("/meta/delete", h.DeleteFileMeta())
+		fileGroup.POST("/copy", h.CopyFile())
+		fileGroup.POST("/folder/copy", h.CopyFolder())
+		fileGroup.GET("/job/:jobId", h.GetJob())
+		fileGroup.POST("/batch/move", h.BatchMove())
+		fileGroup.POST("/batch/copy", h.BatchCopy())
+		fileGroup.POST("/batch/delete", h.BatchDelete())
+		fileGroup.POST("/batch/restore", h.BatchRestore())
+		fileGroup.POST("/batch/rename", h.BatchRename())
+		fileGroup.GET("/path", h.ResolvePath())
+		fileGroup.GET("/path/list", h.ListPath())
+		fileGroup.POST("/path/delete", h.DeletePath())
+		fileGroup.POST("/path/mkdir", h.EnsureFolderPath())
+		fileGroup.POST("/collab/share", h.ShareWithUser())
+		fileGroup.POST("/collab/revoke", h.RevokeUserShare())
+		fileGroup.GET("/collab/list", h.ListCollaborators())
+		fileGroup.GET("/shared-with-me", h.ListSharedWithMe())
+		fileGroup.GET("/team/:teamId", h.GetTeamSpace())
+		fileGroup.GET("/team/:teamId/activity", h.ListSpaceActivity())
+		fileGroup.POST("/request", h.CreateFileRequest())
+		fileGroup.GET("/request/list", h.ListFileRequests())
+		fileGroup.POST("/request/close", h.CloseFileRequests())
+		fileGroup.GET("/request/:requestId/uploads", h.ListFileRequestUploads())
+		fileGroup.POST("/vault", h.CreateVault())
+		fileGroup.POST("/vault/public-key", h.SetPublicKey())
+		fileGroup.GET("/vault/public-keys", h.GetPublicKeys())
+		fileGroup.POST("/vault/:vaultId/envelope", h.PutVaultKeyEnvelope())
+		fileGroup.GET("/vault/:vaultId/envelope", h.GetVaultKeyEnvelope())
+		fileGroup.POST("/vault/:vaultId/envelope/revoke", h.RevokeVaultKeyEnvelope())
+		fileGroup.POST("/webhook", h.CreateWebhook())
+		fileGroup.GET("/webhook/list", h.ListWebhooks())
+		fileGroup.POST("/webhook/update", h.UpdateWebhook())
+		fileGroup.POST("/webhook/delete", h.DeleteWebhook())
+		fileGroup.GET("/webhook/:webhookId/deliveries", h.ListWebhookDeliveries())
+		fileGroup.POST("/webhook/redeliver", h.RedeliverWebhook())
+		fileGroup.GET("/audit", h.ListAuditLogs())
+		fileGroup.GET("/audit/export", h.ExportAuditLogs())
+	}
+
+	// 分享的匿名访问, 不需要登录
+	shareGroup := r.Group("/api/share")
+	{
+		shareGroup.GET("/:shareId", h.ListShareFolder())
+		shareGroup.GET("/:shareId/preview/:id", h.PreviewShareFile())
+		shareGroup.GET("/:shareId/download/:id", h.DownloadShareFile())
+		shareGroup.GET("/:shareId/zip", h.DownloadShareZip())
+	}
+
+	// 文件收集链接的匿名上传, 不需要登录
+	requestGroup := r.Group("/api/request")
+	{
+		requestGroup.GET("/:requestId", h.GetPublicFileRequest())
+		requestGroup.POST("/:requestId/upload", h.SubmitFileRequest())
 	}
 }
 
@@ -60,15 +125,13 @@ func (h *FileHandler) Upload() gin.HandlerFunc {
 		}
 		defer f.Close()
 
-		var hash string
-		hash, err = util.FileHash(f)
+		// 客户端可提供自己计算的 MD5 或 SHA-256, 否则由网关计算, 文件服务校验收到的内容
+		hash, err := uploadHash(c, f)
 		if err != nil {
 			response.Error(c, err)
 			return
 		}
 
-		f.Seek(0, 0)
-
 		name := header.Filename        // 文件名
 		path := consts.BasePath + name // 文件本地路径
 		strs := strings.Split(name, ".")
@@ -83,20 +146,37 @@ func (h *FileHandler) Upload() gin.HandlerFunc {
 		claims := c.MustGet("claims")
 		claim, _ := claims.(*mws.Claim)
 
+		// 可按 folderPath 指定目标文件夹, 此时 folder 可省略
+		folderPath := c.PostForm("folderPath")
 		folder, ok := c.GetPostForm("folder")
-		if !ok {
+		if !ok && folderPath == "" {
 			response.Error(c, errors.New("doesn't contain parent id"))
 			return
 		}
 		folderId, _ := strconv.Atoi(folder)
+		createParents, _ := strconv.ParseBool(c.PostForm("createParents"))
+		// 同名处理策略: 0-报错 1-自动重命名 2-跳过 3-覆盖为新版本
+		conflictPolicy, _ := strconv.Atoi(c.PostForm("conflictPolicy"))
+
+		// 可选的自定义元数据, JSON 对象
+		metadata, err := util.ParseMetadata(c.PostForm("metadata"))
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
 		meta := &file.FileMetaData{
-			Name:        name,
-			Path:        path,
-			Hash:        hash,
-			Size:        size,
-			ContentType: typ,
-			UserId:      claim.UserId,
-			FolderId:    int64(folderId),
+			Name:           name,
+			Path:           path,
+			Hash:           hash,
+			Size:           size,
+			ContentType:    typ,
+			UserId:         claim.UserId,
+			FolderId:       int64(folderId),
+			Metadata:       metadata,
+			FolderPath:     folderPath,
+			CreateParents:  createParents,
+			ConflictPolicy: file.NameConflictPolicy(conflictPolicy),
 		}
 
 		var data []byte
@@ -132,8 +212,15 @@ func (h *FileHandler) UploadChunk() gin.HandlerFunc {
 		// 获取其他参数
 		folder := c.PostForm("folder")
 		folderId, _ := strconv.Atoi(folder)
+		conflictPolicy, _ := strconv.Atoi(c.PostForm("conflictPolicy"))
 		claims := c.MustGet("claims").(*mws.Claim)
 
+		hash, err := uploadHash(c, f)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
 		stream, err := h.cli.UploadChunkStream(c.Request.Context())
 		if err != nil {
 			response.Error(c, err)
@@ -144,23 +231,31 @@ func (h *FileHandler) UploadChunk() gin.HandlerFunc {
 		var partNumber int32 = 0
 
 		for {
-			n, err := f.Read(buffer)
+			// 除最后一个外每个分片都须填满, 单次 Read 可能只返回部分数据
+			n, err := io.ReadFull(f, buffer)
 			if n > 0 {
 				partNumber++
-				if err := stream.Send(&file.UploadChunkRequest{
-					Filename:   header.Filename,
-					PartNumber: partNumber,
-					Data:       buffer[:n],
-					FileSize:   header.Size,
-					UserId:     claims.UserId,
-					FolderId:   int64(folderId),
-				}); err != nil {
+				chunk := &file.UploadChunkRequest{
+					Filename:       header.Filename,
+					PartNumber:     partNumber,
+					Data:           buffer[:n],
+					FileSize:       header.Size,
+					UserId:         claims.UserId,
+					FolderId:       int64(folderId),
+					ConflictPolicy: file.NameConflictPolicy(conflictPolicy),
+					Checksum:       util.Checksum(buffer[:n]),
+				}
+				// 整个文件的哈希随第一个分片发送
+				if partNumber == 1 {
+					chunk.Hash = hash
+				}
+				if err := stream.Send(chunk); err != nil {
 					response.Error(c, fmt.Errorf("failed to send chunk: %v", err))
 					return
 				}
 			}
 
-			if err == io.EOF {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
 				break
 			}
 			if err != nil {
@@ -270,6 +365,7 @@ func (h *FileHandler) UpdateFile() gin.HandlerFunc {
 			}
 
 			req.Data = data
+			req.Hash = c.PostForm("hash")
 		}
 
 		resp, err := h.cli.UpdateFile(c.Request.Context(), req)
@@ -314,34 +410,41 @@ func (h *FileHandler) Download() gin.HandlerFunc {
 		size := resp.GetFile().Size
 		// 根据文件扩展名设置正确的 MIME 类型
 		mimeType := getMimeType(resp.GetFile().Type)
+		rng, ok := rangeFromRequest(c, size)
+		if !ok {
+			return
+		}
+		req := &file.DownloadRequest{
+			FileId: int64(fileId),
+			UserId: claims.UserId,
+			Offset: rng.offset,
+			Length: rng.rpcLength(),
+		}
+
 		const sizeThreshold = 10 * 1024 * 1024 // 10MB
-		if resp.GetFile().GetSize() <= sizeThreshold {
+		if rng.length <= sizeThreshold {
 			// 小文件直接下载
-			resp, err := h.cli.Download(c.Request.Context(), &file.DownloadRequest{
-				FileId: int64(fileId),
-				UserId: claims.UserId,
-			})
+			resp, err := h.cli.Download(c.Request.Context(), req)
 			if err != nil {
 				response.Error(c, err)
 				return
 			}
 			setHeader(c, fileName, mimeType)
-			c.Header("Content-Length", strconv.FormatInt(size, 10))
-			c.Data(http.StatusOK, mimeType, resp.GetData())
+			setDisposition(c, fileName)
+			c.Data(rng.setHeader(c), mimeType, resp.GetData())
 			return
 		}
 
 		// 大文件流式下载
-		stream, err := h.cli.DownloadStream(c.Request.Context(), &file.DownloadRequest{
-			FileId: int64(fileId),
-			UserId: claims.UserId,
-		})
+		stream, err := h.cli.DownloadStream(c.Request.Context(), req)
 		if err != nil {
 			response.Error(c, err)
 			return
 		}
 
 		setHeader(c, fileName, mimeType)
+		setDisposition(c, fileName)
+		c.Status(rng.setHeader(c))
 
 		// 使用 Stream 写入响应
 		c.Stream(func(w io.Writer) bool {
@@ -448,8 +551,9 @@ func (h *FileHandler) ResumeDownload() gin.HandlerFunc {
 func (h *FileHandler) CreateFolder() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		type Req struct {
-			Name     string `json:"name"`
-			ParentId int64  `json:"parentId"`
+			Name           string `json:"name"`
+			ParentId       int64  `json:"parentId"`
+			ConflictPolicy int32  `json:"conflictPolicy"` // 0-报错 1-自动重命名 2-跳过
 		}
 		var req Req
 		if err := c.Bind(&req); err != nil {
@@ -458,9 +562,10 @@ func (h *FileHandler) CreateFolder() gin.HandlerFunc {
 
 		claims := c.MustGet("claims").(*mws.Claim)
 		resp, err := h.cli.CreateFolder(c.Request.Context(), &file.CreateFolderRequest{
-			Name:     req.Name,
-			ParentId: req.ParentId,
-			UserId:   claims.UserId,
+			Name:           req.Name,
+			ParentId:       req.ParentId,
+			UserId:         claims.UserId,
+			ConflictPolicy: file.NameConflictPolicy(req.ConflictPolicy),
 		})
 		if err != nil {
 			response.Error(c, err)
@@ -500,8 +605,9 @@ func (h *FileHandler) ListFolder() gin.HandlerFunc {
 func (h *FileHandler) MoveFile() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		type Req struct {
-			FileId     int64 `json:"fileId"`
-			ToFolderID int64 `json:"toFolderID"`
+			FileId         int64 `json:"fileId"`
+			ToFolderID     int64 `json:"toFolderID"`
+			ConflictPolicy int32 `json:"conflictPolicy"` // 0-报错 1-自动重命名 2-跳过 3-覆盖为新版本
 		}
 		var req Req
 		if err := c.Bind(&req); err != nil {
@@ -510,9 +616,10 @@ func (h *FileHandler) MoveFile() gin.HandlerFunc {
 
 		claims := c.MustGet("claims").(*mws.Claim)
 		resp, err := h.cli.MoveFile(c.Request.Context(), &file.MoveFileRequest{
-			UserId:     claims.UserId,
-			FileId:     req.FileId,
-			ToFolderId: req.ToFolderID,
+			UserId:         claims.UserId,
+			FileId:         req.FileId,
+			ToFolderId:     req.ToFolderID,
+			ConflictPolicy: file.NameConflictPolicy(req.ConflictPolicy),
 		})
 		if err != nil {
 			response.Error(c, err)
@@ -527,9 +634,10 @@ func (h *FileHandler) MoveFile() gin.HandlerFunc {
 func (h *FileHandler) MoveFolder() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		type Req struct {
-			FolderId   int64  `json:"folderId"`
-			ToFolderID int64  `json:"toFolderID"`
-			FolderName string `json:"folderName"`
+			FolderId       int64  `json:"folderId"`
+			ToFolderID     int64  `json:"toFolderID"`
+			FolderName     string `json:"folderName"`     // 移动后的名称, 为空时保持原名
+			ConflictPolicy int32  `json:"conflictPolicy"` // 0-报错 1-自动重命名 2-跳过
 		}
 		var req Req
 		if err := c.Bind(&req); err != nil {
@@ -538,10 +646,11 @@ func (h *FileHandler) MoveFolder() gin.HandlerFunc {
 
 		claims := c.MustGet("claims").(*mws.Claim)
 		resp, err := h.cli.MoveFolder(c.Request.Context(), &file.MoveFolderRequest{
-			UserId:     claims.UserId,
-			FolderId:   req.FolderId,
-			ToFolderId: req.ToFolderID,
-			FolderName: req.FolderName,
+			UserId:         claims.UserId,
+			FolderId:       req.FolderId,
+			ToFolderId:     req.ToFolderID,
+			FolderName:     req.FolderName,
+			ConflictPolicy: file.NameConflictPolicy(req.ConflictPolicy),
 		})
 		if err != nil {
 			response.Error(c, err)
@@ -606,9 +715,10 @@ func (h *FileHandler) DeleteFolder() gin.HandlerFunc {
 func (h *FileHandler) SearchFiles() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		type Req struct {
-			Query string `json:"query"`
-			Page  int32  `json:"page"`
-			Size  int32  `json:"size"`
+			Query    string `json:"query"`
+			Page     int32  `json:"page"`
+			Size     int32  `json:"size"`
+			FolderId int64  `json:"folderId"`
 		}
 		var req Req
 		if err := c.Bind(&req); err != nil {
@@ -617,10 +727,11 @@ func (h *FileHandler) SearchFiles() gin.HandlerFunc {
 
 		claims := c.MustGet("claims").(*mws.Claim)
 		resp, err := h.cli.Search(c.Request.Context(), &file.SearchRequest{
-			UserId: claims.UserId,
-			Query:  req.Query,
-			Page:   req.Page,
-			Size:   req.Size,
+			UserId:   claims.UserId,
+			Query:    req.Query,
+			Page:     req.Page,
+			Size:     req.Size,
+			FolderId: req.FolderId,
 		})
 		if err != nil {
 			response.Error(c, err)
@@ -656,10 +767,12 @@ func (h *FileHandler) Preview() gin.HandlerFunc {
 func (h *FileHandler) CreateShareLink() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
-			FileIds    []int64 `json:"fileIds"`
-			FolderId   int64   `json:"folderId"`
-			ExpireDays int32   `json:"expireDays"`
-			Password   string  `json:"password"`
+			FileIds      []int64 `json:"fileIds"`
+			FolderId     int64   `json:"folderId"`
+			ExpireDays   int32   `json:"expireDays"`
+			Password     string  `json:"password"`
+			MaxDownloads int64   `json:"maxDownloads"` // 0 表示不限
+			MaxSaves     int64   `json:"maxSaves"`     // 0 表示不限
 		}
 		if err := c.Bind(&req); err != nil {
 			return
@@ -667,11 +780,13 @@ func (h *FileHandler) CreateShareLink() gin.HandlerFunc {
 
 		claims := c.MustGet("claims").(*mws.Claim)
 		resp, err := h.cli.CreateShareLink(c.Request.Context(), &file.CreateShareLinkRequest{
-			UserId:     claims.UserId,
-			FileIds:    req.FileIds,
-			FolderId:   req.FolderId,
-			ExpireDays: req.ExpireDays,
-			Password:   req.Password,
+			UserId:       claims.UserId,
+			FileIds:      req.FileIds,
+			FolderId:     req.FolderId,
+			ExpireDays:   req.ExpireDays,
+			Password:     req.Password,
+			MaxDownloads: req.MaxDownloads,
+			MaxSaves:     req.MaxSaves,
 		})
 		if err != nil {
 			response.Error(c, err)
@@ -686,10 +801,12 @@ func (h *FileHandler) CreateShareLink() gin.HandlerFunc {
 func (h *FileHandler) SaveToMyDrive() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
-			ShareId    string  `json:"shareId"`
-			Password   string  `json:"password"`
-			ToFolderId int64   `json:"toFolderId"`
-			FileIds    []int64 `json:"fileIds"`
+			ShareId        string  `json:"shareId"`
+			Password       string  `json:"password"`
+			ToFolderId     int64   `json:"toFolderId"`
+			FileIds        []int64 `json:"fileIds"`
+			FolderIds      []int64 `json:"folderIds"`      // 与 fileIds 都为空时保存整个分享
+			ConflictPolicy int32   `json:"conflictPolicy"` // 0-报错 1-自动重命名 2-跳过 3-覆盖为新版本
 		}
 		if err := c.Bind(&req); err != nil {
 			return
@@ -697,11 +814,14 @@ func (h *FileHandler) SaveToMyDrive() gin.HandlerFunc {
 
 		claims := c.MustGet("claims").(*mws.Claim)
 		resp, err := h.cli.SaveToMyDrive(c.Request.Context(), &file.SaveToMyDriveRequest{
-			ShareId:    req.ShareId,
-			Password:   req.Password,
-			UserId:     claims.UserId,
-			ToFolderId: req.ToFolderId,
-			FileIds:    req.FileIds,
+			ShareId:        req.ShareId,
+			Password:       req.Password,
+			UserId:         claims.UserId,
+			ToFolderId:     req.ToFolderId,
+			FileIds:        req.FileIds,
+			FolderIds:      req.FolderIds,
+			ConflictPolicy: file.NameConflictPolicy(req.ConflictPolicy),
+			ClientIp:       c.ClientIP(),
 		})
 		if err != nil {
 			response.Error(c, err)
@@ -741,3 +861,20 @@ func setHeader(c *gin.Context, filename, mimeType string) {
 	c.Header("Content-Type", mimeType)
 	c.Header("Cache-Control", "no-cache")
 }
+
+// uploadHash 获取客户端在 hash 字段中提供的内容哈希, 未提供时由网关计算 SHA-256 后将 f 重置到开头
+func uploadHash(c *gin.Context, f io.ReadSeeker) (string, error) {
+	if hash := c.PostForm("hash"); hash != "" {
+		return hash, nil
+	}
+
+	hash, err := util.FileHash(f)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/util"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// GetFileMeta 获取文件自定义元数据
+func (h *FileHandler) GetFileMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fileId, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.GetFileMeta(c.Request.Context(), &file.GetFileMetaRequest{
+			FileId: fileId,
+			UserId: claims.UserId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// SetFileMeta 设置文件自定义元数据
+func (h *FileHandler) SetFileMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			FileId   int64          `json:"fileId"`
+			Metadata map[string]any `json:"metadata"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		metadata, err := util.ToMetaValues(req.Metadata)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.SetFileMeta(c.Request.Context(), &file.SetFileMetaRequest{
+			FileId:   req.FileId,
+			UserId:   claims.UserId,
+			Metadata: metadata,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// DeleteFileMeta 删除文件自定义元数据
+func (h *FileHandler) DeleteFileMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			FileId int64    `json:"fileId"`
+			Keys   []string `json:"keys"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.DeleteFileMeta(c.Request.Context(), &file.DeleteFileMetaRequest{
+			FileId: req.FileId,
+			UserId: claims.UserId,
+			Keys:   req.Keys,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// CreateFileRequest 创建文件收集链接, 他人可通过链接向指定文件夹上传文件
+func (h *FileHandler) CreateFileRequest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			FolderId     int64    `json:"folderId"`
+			Title        string   `json:"title"`
+			Password     string   `json:"password"`
+			ExpireAt     int64    `json:"expireAt"`
+			MaxFileSize  int64    `json:"maxFileSize"`
+			MaxFiles     int64    `json:"maxFiles"`
+			MaxTotalSize int64    `json:"maxTotalSize"`
+			AllowedExts  []string `json:"allowedExts"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.CreateFileRequest(c.Request.Context(), &file.CreateFileRequestRequest{
+			UserId:       claims.UserId,
+			FolderId:     req.FolderId,
+			Title:        req.Title,
+			Password:     req.Password,
+			ExpireAt:     req.ExpireAt,
+			MaxFileSize:  req.MaxFileSize,
+			MaxFiles:     req.MaxFiles,
+			MaxTotalSize: req.MaxTotalSize,
+			AllowedExts:  req.AllowedExts,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListFileRequests 分页获取创建的文件收集链接
+func (h *FileHandler) ListFileRequests() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListFileRequests(c.Request.Context(), &file.ListFileRequestsRequest{
+			UserId: claims.UserId,
+			Page:   int32(page),
+			Size:   int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// CloseFileRequests 关闭文件收集链接
+func (h *FileHandler) CloseFileRequests() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			RequestIds []string `json:"requestIds"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.CloseFileRequests(c.Request.Context(), &file.CloseFileRequestsRequest{
+			UserId:     claims.UserId,
+			RequestIds: req.RequestIds,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListFileRequestUploads 分页获取文件收集链接的上传记录
+func (h *FileHandler) ListFileRequestUploads() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListFileRequestUploads(c.Request.Context(), &file.ListFileRequestUploadsRequest{
+			UserId:    claims.UserId,
+			RequestId: c.Param("requestId"),
+			Page:      int32(page),
+			Size:      int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// 以下为文件收集链接的匿名接口, 上传者只能上传, 看不到目标文件夹中的内容
+// 密码的读取方式与分享相同
+
+// GetPublicFileRequest 获取上传页面展示的标题和限制
+func (h *FileHandler) GetPublicFileRequest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resp, err := h.cli.GetPublicFileRequest(c.Request.Context(), &file.GetPublicFileRequestRequest{
+			RequestId: c.Param("requestId"),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// SubmitFileRequest 通过文件收集链接上传文件, uploader 为上传者的名字
+func (h *FileHandler) SubmitFileRequest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f, header, err := c.Request.FormFile("file")
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+		defer f.Close()
+
+		hash, err := uploadHash(c, f)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		data, err := io.ReadAll(f)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		resp, err := h.cli.SubmitFileRequest(c.Request.Context(), &file.SubmitFileRequestRequest{
+			RequestId:   c.Param("requestId"),
+			Password:    sharePassword(c),
+			Uploader:    c.PostForm("uploader"),
+			Name:        header.Filename,
+			Size:        header.Size,
+			Hash:        hash,
+			ContentType: header.Header.Get("Content-Type"),
+			Data:        data,
+			ClientIp:    c.ClientIP(),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// GetFolderTree 获取文件夹树, 用于侧边栏展示
+func (h *FileHandler) GetFolderTree() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rootId, _ := strconv.ParseInt(c.Query("rootId"), 10, 64)
+		depth, _ := strconv.Atoi(c.Query("depth"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.GetFolderTree(c.Request.Context(), &file.GetFolderTreeRequest{
+			UserId: claims.UserId,
+			RootId: rootId,
+			Depth:  int32(depth),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
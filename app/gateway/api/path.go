@@ -0,0 +1,101 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// ResolvePath 按路径获取文件或文件夹, 路径中名称里的 / 需转义为 \/, root 为路径相对的文件夹
+func (h *FileHandler) ResolvePath() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := c.MustGet("claims").(*mws.Claim)
+		root, _ := strconv.ParseInt(c.Query("root"), 10, 64)
+
+		resp, err := h.cli.ResolvePath(c.Request.Context(), &file.ResolvePathRequest{
+			UserId: claims.UserId,
+			Path:   c.Query("path"),
+			RootId: root,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListPath 按路径展示文件夹内容, root 为路径相对的文件夹
+func (h *FileHandler) ListPath() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := c.MustGet("claims").(*mws.Claim)
+		root, _ := strconv.ParseInt(c.Query("root"), 10, 64)
+
+		resp, err := h.cli.ListPath(c.Request.Context(), &file.ListPathRequest{
+			UserId: claims.UserId,
+			Path:   c.Query("path"),
+			RootId: root,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// DeletePath 按路径删除文件或文件夹
+func (h *FileHandler) DeletePath() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Path string `json:"path"`
+			Root int64  `json:"root"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.DeletePath(c.Request.Context(), &file.DeletePathRequest{
+			UserId: claims.UserId,
+			Path:   req.Path,
+			RootId: req.Root,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// EnsureFolderPath 创建路径上缺失的文件夹
+func (h *FileHandler) EnsureFolderPath() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Path string `json:"path"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.EnsureFolderPath(c.Request.Context(), &file.EnsureFolderPathRequest{
+			UserId: claims.UserId,
+			Path:   req.Path,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
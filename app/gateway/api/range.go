@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contentRange 下载的范围, 没有 Range 请求头时为整个文件
+type contentRange struct {
+	offset  int64
+	length  int64
+	size    int64
+	partial bool
+}
+
+// parseRange 解析 Range 请求头, 只支持单个范围
+func parseRange(header string, size int64) (contentRange, bool) {
+	r := contentRange{length: size, size: size}
+	if header == "" {
+		return r, true
+	}
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return r, false
+	}
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return r, false
+	}
+
+	if startStr == "" {
+		// bytes=-n 表示最后 n 个字节
+		n, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || n <= 0 || size == 0 {
+			return r, false
+		}
+		r.offset, r.length = size-min(n, size), min(n, size)
+	} else {
+		start, err := strconv.ParseInt(startStr, 10, 64)
+		if err != nil || start < 0 || start >= size {
+			return r, false
+		}
+		end := size - 1
+		if endStr != "" {
+			end, err = strconv.ParseInt(endStr, 10, 64)
+			if err != nil || end < start {
+				return r, false
+			}
+			end = min(end, size-1)
+		}
+		r.offset, r.length = start, end-start+1
+	}
+	r.partial = true
+
+	return r, true
+}
+
+// rangeFromRequest 解析请求的范围, 范围不合法时返回 416 并中止请求
+func rangeFromRequest(c *gin.Context, size int64) (contentRange, bool) {
+	r, ok := parseRange(c.GetHeader("Range"), size)
+	if !ok {
+		c.Header("Content-Range", fmt.Sprintf("bytes */%d", size))
+		c.AbortWithStatus(http.StatusRequestedRangeNotSatisfiable)
+	}
+
+	return r, ok
+}
+
+// rpcLength 传给文件服务的读取长度, 0 表示读到末尾
+func (r contentRange) rpcLength() int64 {
+	if r.partial {
+		return r.length
+	}
+
+	return 0
+}
+
+// setHeader 设置长度和范围相关的响应头, 返回响应的状态码
+func (r contentRange) setHeader(c *gin.Context) int {
+	c.Header("Accept-Ranges", "bytes")
+	c.Header("Content-Length", strconv.FormatInt(r.length, 10))
+	if !r.partial {
+		return http.StatusOK
+	}
+	c.Header("Content-Range", fmt.Sprintf("bytes %d-%d/%d", r.offset, r.offset+r.length-1, r.size))
+
+	return http.StatusPartialContent
+}
+
+// setDisposition 带 inline=1 时让浏览器直接打开文件, 用于加密文件的在线预览
+func setDisposition(c *gin.Context, filename string) {
+	if c.Query("inline") == "1" {
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", url.QueryEscape(filename)))
+	}
+}
@@ -0,0 +1,300 @@
+package api
+
+import (
+	"archive/zip"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// 以下为分享的匿名访问接口, 不需要登录, 凭分享ID和提取密码访问
+// 提取密码优先从 X-Share-Password 请求头读取, 其次为 password 查询参数
+
+// ListShareFolder 浏览分享, folderId 为空时列出分享的根
+func (h *FileHandler) ListShareFolder() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		folderId, _ := strconv.ParseInt(c.Query("folderId"), 10, 64)
+
+		resp, err := h.cli.ListShareFolder(c.Request.Context(), &file.ListShareFolderRequest{
+			ShareId:  c.Param("shareId"),
+			Password: sharePassword(c),
+			FolderId: folderId,
+			ClientIp: c.ClientIP(),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// PreviewShareFile 预览分享内的文件
+func (h *FileHandler) PreviewShareFile() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fileId, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+		resp, err := h.cli.PreviewShareFile(c.Request.Context(), &file.ShareFileRequest{
+			ShareId:  c.Param("shareId"),
+			Password: sharePassword(c),
+			FileId:   fileId,
+			ClientIp: c.ClientIP(),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// DownloadShareFile 下载分享内的单个文件
+func (h *FileHandler) DownloadShareFile() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fileId, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+		req := &file.ShareFileRequest{
+			ShareId:  c.Param("shareId"),
+			Password: sharePassword(c),
+			FileId:   fileId,
+			ClientIp: c.ClientIP(),
+			// PreviewShareFile 返回的预览地址以令牌代替提取密码
+			PreviewToken: c.Query("token"),
+		}
+
+		info, err := h.cli.GetShareFile(c.Request.Context(), req)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+		f := info.GetFile()
+		rng, ok := rangeFromRequest(c, f.GetSize())
+		if !ok {
+			return
+		}
+		req.Offset, req.Length = rng.offset, rng.rpcLength()
+
+		stream, err := h.cli.DownloadShareFile(c.Request.Context(), req)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		setHeader(c, f.GetName(), getMimeType(f.GetType()))
+		setDisposition(c, f.GetName())
+		c.Status(rng.setHeader(c))
+
+		c.Stream(func(w io.Writer) bool {
+			chunk, err := stream.Recv()
+			if err != nil {
+				return false
+			}
+			_, err = w.Write(chunk.Data)
+			return err == nil
+		})
+	}
+}
+
+// DownloadShareZip 将分享的全部文件打包为 ZIP 下载
+func (h *FileHandler) DownloadShareZip() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		shareId, password := c.Param("shareId"), sharePassword(c)
+
+		// 整个压缩包计为一次下载
+		resp, err := h.cli.ListShareFiles(c.Request.Context(), &file.ListShareFilesRequest{
+			ShareId:  shareId,
+			Password: password,
+			ClientIp: c.ClientIP(),
+			Download: true,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		name := resp.GetShare().GetName()
+		if name == "" {
+			name = shareId
+		}
+		setHeader(c, name+".zip", "application/zip")
+		c.Status(http.StatusOK)
+
+		zw := zip.NewWriter(c.Writer)
+		defer zw.Close()
+		for _, e := range resp.GetEntries() {
+			if err := h.writeShareEntry(c, zw, shareId, resp.GetArchiveToken(), e); err != nil {
+				// 响应头已发送, 只能中止, 客户端会得到不完整的压缩包
+				c.Error(err)
+				return
+			}
+		}
+	}
+}
+
+// writeShareEntry 将分享内的一个文件写入压缩包, token 为 ListShareFiles 返回的打包下载令牌
+func (h *FileHandler) writeShareEntry(c *gin.Context, zw *zip.Writer, shareId, token string, e *file.ShareEntry) error {
+	stream, err := h.cli.DownloadShareFile(c.Request.Context(), &file.ShareFileRequest{
+		ShareId:      shareId,
+		ArchiveToken: token,
+		FileId:       int64(e.GetFile().GetId()),
+		ClientIp:     c.ClientIP(),
+		InArchive:    true,
+	})
+	if err != nil {
+		return err
+	}
+
+	modified, _ := time.ParseInLocation(time.DateTime, e.GetFile().GetUtime(), time.Local)
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     e.GetPath(),
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return err
+		}
+	}
+}
+
+// ListShares 获取我创建的分享及访问统计
+func (h *FileHandler) ListShares() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, _ := strconv.Atoi(c.Query("status"))
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListShares(c.Request.Context(), &file.ListSharesRequest{
+			UserId: claims.UserId,
+			Status: int32(status),
+			Page:   int32(page),
+			Size:   int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// RevokeShares 取消分享
+func (h *FileHandler) RevokeShares() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			ShareIds []string `json:"shareIds"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.RevokeShares(c.Request.Context(), &file.RevokeSharesRequest{
+			UserId:   claims.UserId,
+			ShareIds: req.ShareIds,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// UpdateShare 修改分享, 未提供的字段保持不变
+func (h *FileHandler) UpdateShare() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			ShareId      string  `json:"shareId"`
+			ExpireAt     *int64  `json:"expireAt"`     // Unix 秒
+			Password     *string `json:"password"`     // 空串表示取消密码
+			MaxDownloads *int64  `json:"maxDownloads"` // 与 maxSaves 一起修改, 0 表示不限
+			MaxSaves     *int64  `json:"maxSaves"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		in := &file.UpdateShareRequest{
+			UserId:         claims.UserId,
+			ShareId:        req.ShareId,
+			UpdateExpire:   req.ExpireAt != nil,
+			UpdatePassword: req.Password != nil,
+			UpdateLimits:   req.MaxDownloads != nil || req.MaxSaves != nil,
+		}
+		if req.ExpireAt != nil {
+			in.ExpireAt = *req.ExpireAt
+		}
+		if req.Password != nil {
+			in.Password = *req.Password
+		}
+		if req.MaxDownloads != nil {
+			in.MaxDownloads = *req.MaxDownloads
+		}
+		if req.MaxSaves != nil {
+			in.MaxSaves = *req.MaxSaves
+		}
+
+		resp, err := h.cli.UpdateShare(c.Request.Context(), in)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// GetShareAccessLog 获取分享的访问记录
+func (h *FileHandler) GetShareAccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.GetShareAccessLog(c.Request.Context(), &file.GetShareAccessLogRequest{
+			UserId:  claims.UserId,
+			ShareId: c.Param("shareId"),
+			Page:    int32(page),
+			Size:    int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// sharePassword 获取请求中的提取密码
+func sharePassword(c *gin.Context) string {
+	if pwd := c.GetHeader("X-Share-Password"); pwd != "" {
+		return pwd
+	}
+
+	return c.Query("password")
+}
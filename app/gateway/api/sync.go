@@ -26,6 +26,24 @@ type FileChangeEvent struct {
 	Size      int64     `json:"size"`
 	Path      string    `json:"path"`
 	Timestamp time.Time `json:"timestamp"`
+
+	Items []FileEventItem `json:"items,omitempty"` // 批量操作涉及的条目
+	Quota *QuotaEvent     `json:"quota,omitempty"` // 用量跨越阈值时的配额信息
+}
+
+// FileEventItem 批量操作事件中的单个条目
+type FileEventItem struct {
+	Type  string `json:"type"` // file/folder
+	Id    int64  `json:"id"`
+	NewId int64  `json:"new_id,omitempty"`
+	Name  string `json:"name"`
+}
+
+// QuotaEvent 用量跨越阈值事件的配额信息
+type QuotaEvent struct {
+	Threshold   int   `json:"threshold"` // 跨越的阈值(百分比): 80/95/100
+	CurrentSize int64 `json:"current_size"`
+	Capacity    int64 `json:"capacity"`
 }
 
 // ConnectionManager WebSocket 连接管理器
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/user"
+)
+
+// CreateTeam 创建团队及其团队空间
+func (h *UserHandler) CreateTeam() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.CreateTeam(c.Request.Context(), &user.CreateTeamRequest{UserId: claims.UserId, Name: req.Name})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListTeams 我加入的团队
+func (h *UserHandler) ListTeams() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.ListTeams(c.Request.Context(), &user.ListTeamsRequest{UserId: claims.UserId})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListTeamMembers 团队成员
+func (h *UserHandler) ListTeamMembers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamId, _ := strconv.Atoi(c.Param("teamId"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListTeamMembers(c.Request.Context(), &user.ListTeamMembersRequest{
+			UserId: claims.UserId,
+			TeamId: int32(teamId),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// AddTeamMember 按用户名或手机号添加团队成员
+func (h *UserHandler) AddTeamMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Name  string `json:"name"`  // 用户名, 与 phone 二选一
+			Phone string `json:"phone"` // 手机号
+			Role  int32  `json:"role"`  // 1-成员 2-管理员, 默认成员
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		teamId, _ := strconv.Atoi(c.Param("teamId"))
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.AddTeamMember(c.Request.Context(), &user.AddTeamMemberRequest{
+			UserId: claims.UserId,
+			TeamId: int32(teamId),
+			Name:   req.Name,
+			Phone:  req.Phone,
+			Role:   req.Role,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// UpdateTeamMember 修改成员角色, role 为 3 时转让团队
+func (h *UserHandler) UpdateTeamMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			UserId int32 `json:"userId"`
+			Role   int32 `json:"role"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		teamId, _ := strconv.Atoi(c.Param("teamId"))
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.UpdateTeamMember(c.Request.Context(), &user.UpdateTeamMemberRequest{
+			UserId:   claims.UserId,
+			TeamId:   int32(teamId),
+			MemberId: req.UserId,
+			Role:     req.Role,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// RemoveTeamMember 移除成员, userId 为自己时表示退出团队
+func (h *UserHandler) RemoveTeamMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			UserId int32 `json:"userId"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		teamId, _ := strconv.Atoi(c.Param("teamId"))
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.RemoveTeamMember(c.Request.Context(), &user.RemoveTeamMemberRequest{
+			UserId:   claims.UserId,
+			TeamId:   int32(teamId),
+			MemberId: req.UserId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// GetTeamAudit 团队的审计日志
+func (h *UserHandler) GetTeamAudit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamId, _ := strconv.Atoi(c.Param("teamId"))
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.GetTeamAudit(c.Request.Context(), &user.GetTeamAuditRequest{
+			UserId: claims.UserId,
+			TeamId: int32(teamId),
+			Page:   int32(page),
+			Size:   int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// GetTeamSpace 团队空间的根文件夹和共享的存储空间
+func (h *FileHandler) GetTeamSpace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamId, _ := strconv.Atoi(c.Param("teamId"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.GetTeamSpace(c.Request.Context(), &file.GetTeamSpaceRequest{
+			TeamId: int32(teamId),
+			UserId: claims.UserId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListSpaceActivity 团队空间的动态
+func (h *FileHandler) ListSpaceActivity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		teamId, _ := strconv.Atoi(c.Param("teamId"))
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListSpaceActivity(c.Request.Context(), &file.ListSpaceActivityRequest{
+			TeamId: int32(teamId),
+			UserId: claims.UserId,
+			Page:   int32(page),
+			Size:   int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
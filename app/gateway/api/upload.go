@@ -0,0 +1,103 @@
+package api
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/util"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// UploadPart 分片上传的单个分片, 分片可并行上传, 不带 uploadId 的第一个分片开始新的上传
+// 除最后一个分片外大小须与第一个分片相同, 最后一个分片须在其余分片都上传后发送, 带 isLast 时完成上传
+func (h *FileHandler) UploadPart() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f, _, err := c.Request.FormFile("file")
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+		defer f.Close()
+
+		data, err := io.ReadAll(f)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		partNumber, _ := strconv.Atoi(c.PostForm("partNumber"))
+		fileSize, _ := strconv.ParseInt(c.PostForm("fileSize"), 10, 64)
+		folderId, _ := strconv.ParseInt(c.PostForm("folder"), 10, 64)
+		isLast, _ := strconv.ParseBool(c.PostForm("isLast"))
+		conflictPolicy, _ := strconv.Atoi(c.PostForm("conflictPolicy"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.UploadChunk(c.Request.Context(), &file.UploadChunkRequest{
+			Filename:       c.PostForm("filename"),
+			UploadId:       c.PostForm("uploadId"),
+			PartNumber:     int32(partNumber),
+			Data:           data,
+			FileSize:       fileSize,
+			UserId:         claims.UserId,
+			FolderId:       folderId,
+			IsLast:         isLast,
+			ConflictPolicy: file.NameConflictPolicy(conflictPolicy),
+			Checksum:       util.Checksum(data),
+			Hash:           c.PostForm("hash"),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListUploadParts 分片上传已上传的分片, 用于续传
+func (h *FileHandler) ListUploadParts() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListUploadParts(c.Request.Context(), &file.ListUploadPartsRequest{
+			UserId:   claims.UserId,
+			UploadId: c.Param("uploadId"),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// AbortUpload 中止分片上传并释放预留的空间
+func (h *FileHandler) AbortUpload() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			UploadId string `json:"uploadId"`
+			Filename string `json:"filename"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.AbortUpload(c.Request.Context(), &file.AbortUploadRequest{
+			UserId:   claims.UserId,
+			UploadId: req.UploadId,
+			Filename: req.Filename,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
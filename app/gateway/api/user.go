@@ -21,6 +21,15 @@ func (h *UserHandler) RegisterRoute(r *gin.Engine) {
 		userGroup.POST("/send-code", h.SendCode())
 		userGroup.POST("/verify-code", h.VerifyCode())
 		userGroup.GET("/info", mws.Auth(), h.GetUserInfo())
+		userGroup.POST("/team/create", mws.Auth(), h.CreateTeam())
+		userGroup.GET("/team/list", mws.Auth(), h.ListTeams())
+		userGroup.GET("/team/:teamId/members", mws.Auth(), h.ListTeamMembers())
+		userGroup.POST("/team/:teamId/members/add", mws.Auth(), h.AddTeamMember())
+		userGroup.POST("/team/:teamId/members/update", mws.Auth(), h.UpdateTeamMember())
+		userGroup.POST("/team/:teamId/members/remove", mws.Auth(), h.RemoveTeamMember())
+		userGroup.GET("/team/:teamId/audit", mws.Auth(), h.GetTeamAudit())
+		userGroup.GET("/audit", mws.Auth(), h.ListAuditLogs())
+		userGroup.GET("/audit/export", mws.Auth(), h.ExportAuditLogs())
 	}
 }
 
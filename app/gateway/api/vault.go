@@ -0,0 +1,171 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// 保险库的内容和名称在客户端加密, 网关只转发密文; 二进制字段在 JSON 中为 base64 编码
+
+// CreateVault 创建保险库, name 为加密后的名称, keyEnvelope 为以自己公钥包裹的保险库密钥
+func (h *FileHandler) CreateVault() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			ParentId    int64  `json:"parentId"`
+			Name        string `json:"name"`
+			KeyEnvelope []byte `json:"keyEnvelope"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.CreateVault(c.Request.Context(), &file.CreateVaultRequest{
+			UserId:      claims.UserId,
+			ParentId:    req.ParentId,
+			Name:        req.Name,
+			KeyEnvelope: req.KeyEnvelope,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// SetPublicKey 上传自己的公钥
+func (h *FileHandler) SetPublicKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			PublicKey []byte `json:"publicKey"`
+			Algorithm string `json:"algorithm"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.SetPublicKey(c.Request.Context(), &file.SetPublicKeyRequest{
+			UserId:    claims.UserId,
+			PublicKey: req.PublicKey,
+			Algorithm: req.Algorithm,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// GetPublicKeys 批量获取用户的公钥, userIds 以逗号分隔
+func (h *FileHandler) GetPublicKeys() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var uids []int32
+		for _, v := range strings.Split(c.Query("userIds"), ",") {
+			if v = strings.TrimSpace(v); v == "" {
+				continue
+			}
+			uid, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				response.Error(c, err)
+				return
+			}
+			uids = append(uids, int32(uid))
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.GetPublicKeys(c.Request.Context(), &file.GetPublicKeysRequest{
+			UserId:  claims.UserId,
+			UserIds: uids,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// PutVaultKeyEnvelope 为其他成员保存保险库密钥的信封, 成员须已通过共享获得访问权限
+func (h *FileHandler) PutVaultKeyEnvelope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		vaultId, _ := strconv.ParseInt(c.Param("vaultId"), 10, 64)
+		var req struct {
+			UserId   int32  `json:"userId"`
+			Envelope []byte `json:"envelope"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.PutVaultKeyEnvelope(c.Request.Context(), &file.PutVaultKeyEnvelopeRequest{
+			UserId:      claims.UserId,
+			VaultId:     vaultId,
+			RecipientId: req.UserId,
+			Envelope:    req.Envelope,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// GetVaultKeyEnvelope 获取自己的保险库密钥信封
+func (h *FileHandler) GetVaultKeyEnvelope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		vaultId, _ := strconv.ParseInt(c.Param("vaultId"), 10, 64)
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.GetVaultKeyEnvelope(c.Request.Context(), &file.GetVaultKeyEnvelopeRequest{
+			UserId:  claims.UserId,
+			VaultId: vaultId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// RevokeVaultKeyEnvelope 删除成员的信封, userId 为自己时表示删除自己的信封
+func (h *FileHandler) RevokeVaultKeyEnvelope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		vaultId, _ := strconv.ParseInt(c.Param("vaultId"), 10, 64)
+		var req struct {
+			UserId int32 `json:"userId"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.RevokeVaultKeyEnvelope(c.Request.Context(), &file.RevokeVaultKeyEnvelopeRequest{
+			UserId:      claims.UserId,
+			VaultId:     vaultId,
+			RecipientId: req.UserId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
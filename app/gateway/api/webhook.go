@@ -0,0 +1,160 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/crazyfrankie/cloudstorage/app/gateway/common/response"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// CreateWebhook 创建 webhook, eventTypes 为空时订阅全部事件, folderId 不为 0 时只推送该文件夹内的事件
+// 响应中的签名密钥只返回这一次
+func (h *FileHandler) CreateWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Url        string   `json:"url"`
+			EventTypes []string `json:"eventTypes"`
+			FolderId   int64    `json:"folderId"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.CreateWebhook(c.Request.Context(), &file.CreateWebhookRequest{
+			UserId:     claims.UserId,
+			Url:        req.Url,
+			EventTypes: req.EventTypes,
+			FolderId:   req.FolderId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListWebhooks 获取创建的 webhook
+func (h *FileHandler) ListWebhooks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListWebhooks(c.Request.Context(), &file.ListWebhooksRequest{
+			UserId: claims.UserId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// UpdateWebhook 修改 webhook 并重新启用, rotateSecret 为 true 时生成新的签名密钥
+func (h *FileHandler) UpdateWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Id           int64    `json:"id"`
+			Url          string   `json:"url"`
+			EventTypes   []string `json:"eventTypes"`
+			FolderId     int64    `json:"folderId"`
+			RotateSecret bool     `json:"rotateSecret"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.UpdateWebhook(c.Request.Context(), &file.UpdateWebhookRequest{
+			UserId:       claims.UserId,
+			Id:           req.Id,
+			Url:          req.Url,
+			EventTypes:   req.EventTypes,
+			FolderId:     req.FolderId,
+			RotateSecret: req.RotateSecret,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// DeleteWebhook 删除 webhook
+func (h *FileHandler) DeleteWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Id int64 `json:"id"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.DeleteWebhook(c.Request.Context(), &file.DeleteWebhookRequest{
+			UserId: claims.UserId,
+			Id:     req.Id,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// ListWebhookDeliveries 分页获取 webhook 的投递记录
+func (h *FileHandler) ListWebhookDeliveries() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		webhookId, _ := strconv.ParseInt(c.Param("webhookId"), 10, 64)
+		page, _ := strconv.Atoi(c.Query("page"))
+		size, _ := strconv.Atoi(c.Query("size"))
+		claims := c.MustGet("claims").(*mws.Claim)
+
+		resp, err := h.cli.ListWebhookDeliveries(c.Request.Context(), &file.ListWebhookDeliveriesRequest{
+			UserId:    claims.UserId,
+			WebhookId: webhookId,
+			Page:      int32(page),
+			Size:      int32(size),
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
+
+// RedeliverWebhook 重新投递一条已完成的投递
+func (h *FileHandler) RedeliverWebhook() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			DeliveryId int64 `json:"deliveryId"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return
+		}
+
+		claims := c.MustGet("claims").(*mws.Claim)
+		resp, err := h.cli.RedeliverWebhook(c.Request.Context(), &file.RedeliverWebhookRequest{
+			UserId:     claims.UserId,
+			DeliveryId: req.DeliveryId,
+		})
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+
+		response.Success(c, resp)
+	}
+}
@@ -14,9 +14,10 @@ var (
 	FileNameExists = gerrors.NewBizError(10000, "file name conflict")
 )
 
+// 以下大小以字节计, << 20 换算为 MB
 const (
 	// SmallFileSizeLimit 小文件阈值：50MB
-	SmallFileSizeLimit = 50 >> 10 >> 10
+	SmallFileSizeLimit = 50 << 20
 	// ChunkSize 分块大小：5MB
-	ChunkSize = 5 >> 10 >> 10
+	ChunkSize = 5 << 20
 )
@@ -1,18 +1,26 @@
 package util
 
 import (
-	"crypto/md5"
-	"fmt"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 )
 
+// FileHash 计算文件内容的 SHA-256, 文件服务以此校验收到的内容
 func FileHash(file io.Reader) (string, error) {
-	hash := md5.New() // 使用 MD5 哈希算法
+	hash := sha256.New()
 	_, err := io.Copy(hash, file)
 	if err != nil {
 		return "", err
 	}
 
 	// 返回哈希值的十六进制字符串
-	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Checksum 计算分片的 SHA-256
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
 }
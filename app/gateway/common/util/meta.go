@@ -0,0 +1,61 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+)
+
+// ParseMetadata 将 JSON 对象解析为文件元数据
+// 字符串、数字、布尔按原类型保存; 形如 {"date": "2026-01-02"} 的对象保存为日期
+func ParseMetadata(raw string) (map[string]*file.MetaValue, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil, fmt.Errorf("invalid metadata format: %v", err)
+	}
+
+	return ToMetaValues(values)
+}
+
+// ToMetaValues 将已解码的 JSON 值转换为文件元数据
+func ToMetaValues(values map[string]any) (map[string]*file.MetaValue, error) {
+	res := make(map[string]*file.MetaValue, len(values))
+	for k, v := range values {
+		switch val := v.(type) {
+		case string:
+			res[k] = &file.MetaValue{Value: &file.MetaValue_StringValue{StringValue: val}}
+		case float64:
+			res[k] = &file.MetaValue{Value: &file.MetaValue_NumberValue{NumberValue: val}}
+		case bool:
+			res[k] = &file.MetaValue{Value: &file.MetaValue_BoolValue{BoolValue: val}}
+		case map[string]any:
+			date, ok := val["date"].(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported metadata value of %q", k)
+			}
+			t, err := parseDate(date)
+			if err != nil {
+				return nil, fmt.Errorf("invalid date of %q: %v", k, err)
+			}
+			res[k] = &file.MetaValue{Value: &file.MetaValue_DateValue{DateValue: t.Unix()}}
+		default:
+			return nil, fmt.Errorf("unsupported metadata value of %q", k)
+		}
+	}
+
+	return res, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	return time.ParseInLocation(time.DateOnly, s, time.Local)
+}
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	"github.com/crazyfrankie/cloudstorage/app/gateway/api"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
 )
 
 func InitRegistry() *clientv3.Client {
@@ -45,6 +46,7 @@ func InitMws() []gin.HandlerFunc {
 			MaxAge:           12 * time.Hour,
 		}),
 		otelgin.Middleware("cloudstorage/gateway"),
+		mws.ClientInfo(),
 	}
 }
 
@@ -8,6 +8,7 @@ package ioc
 
 import (
 	"github.com/crazyfrankie/cloudstorage/app/gateway/api"
+	"github.com/crazyfrankie/cloudstorage/app/gateway/mws"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"go.etcd.io/etcd/client/v3"
@@ -25,7 +26,7 @@ func InitServer() *gin.Engine {
 	userServiceClient := InitUserClient(client)
 	userHandler := api.NewUserHandler(userServiceClient)
 	fileServiceClient := InitFileClient(client)
-	fileHandler := api.NewFileHandler(fileServiceClient)
+	fileHandler := api.NewFileHandler(fileServiceClient, userServiceClient)
 	connectionManager := api.NewConnectionManager()
 	syncHandler := api.NewSyncHandler(connectionManager)
 	engine := InitGin(v, userHandler, fileHandler, syncHandler)
@@ -57,7 +58,7 @@ func InitMws() []gin.HandlerFunc {
 		ExposeHeaders:    []string{"Content-Length", "x-jwt-token"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
-	}), otelgin.Middleware("cloudstorage/gateway"),
+	}), otelgin.Middleware("cloudstorage/gateway"), mws.ClientInfo(),
 	}
 }
 
@@ -0,0 +1,20 @@
+package mws
+
+import (
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientInfo 将客户端的 IP、User-Agent 和设备 ID 放入 gRPC 元数据, 供下游服务审计
+func ClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := metadata.AppendToOutgoingContext(c.Request.Context(),
+			"x-client-ip", c.ClientIP(),
+			"x-user-agent", c.Request.UserAgent(),
+			"x-device-id", c.GetHeader("X-Device-Id"),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/user/internal/biz/repository/dao"
+)
+
+func (r *UserRepo) AddAuditLog(ctx context.Context, l *dao.AuditLog) error {
+	return r.dao.AddAuditLog(ctx, l)
+}
+
+func (r *UserRepo) ListAuditLogs(ctx context.Context, f dao.AuditFilter, page, size int) ([]dao.AuditLog, int64, error) {
+	return r.dao.ListAuditLogs(ctx, f, page, size)
+}
+
+func (r *UserRepo) ScanAuditLogs(ctx context.Context, f dao.AuditFilter, afterId int64, limit int) ([]dao.AuditLog, error) {
+	return r.dao.ScanAuditLogs(ctx, f, afterId, limit)
+}
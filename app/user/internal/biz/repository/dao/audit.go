@@ -0,0 +1,154 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditLog 一次操作的审计记录, 只追加不修改
+type AuditLog struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	Action    string `gorm:"type:varchar(64);not null;index:idx_audit_action"`
+	Method    string `gorm:"type:varchar(128);not null"`
+	ActorId   int    `gorm:"not null;index:idx_audit_actor"` // 未登录和管理接口为 0
+	OwnerId   int    `gorm:"not null;index:idx_audit_owner"` // 目标用户, 用户可查询针对自己的操作
+	DeviceId  string `gorm:"type:varchar(64)"`
+	Ip        string `gorm:"type:varchar(64)"`
+	UserAgent string `gorm:"type:varchar(255)"`
+	Before    string `gorm:"type:text"` // 操作前目标的元数据, JSON
+	After     string `gorm:"type:text"` // 操作后目标的元数据, JSON
+	Code      string `gorm:"type:varchar(32);not null"`
+	Error     string `gorm:"type:varchar(255)"`
+	Ctime     int64  `gorm:"not null;index:idx_audit_actor;index:idx_audit_owner;index:idx_audit_action;index:idx_audit_ctime"`
+
+	Targets []AuditTarget `gorm:"-"`
+}
+
+// AuditTarget 审计记录涉及的对象, 用于按对象查询
+type AuditTarget struct {
+	LogId    int64  `gorm:"primaryKey"`
+	Type     string `gorm:"type:varchar(32);primaryKey;index:idx_audit_target"`
+	TargetId string `gorm:"type:varchar(64);primaryKey;index:idx_audit_target"`
+}
+
+// AuditFilter 审计日志的查询条件, 为零值的条件不生效
+type AuditFilter struct {
+	UserId     int // 操作者或目标用户为该用户
+	ActorId    int
+	Start      int64 // 包含
+	End        int64 // 不包含
+	Action     string
+	TargetType string
+	TargetId   string
+}
+
+// AddAuditLog 写入审计记录及其目标
+func (u *UserDao) AddAuditLog(ctx context.Context, l *AuditLog) error {
+	if l.Ctime == 0 {
+		l.Ctime = time.Now().Unix()
+	}
+
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(l).Error; err != nil {
+			return err
+		}
+		if len(l.Targets) == 0 {
+			return nil
+		}
+		for i := range l.Targets {
+			l.Targets[i].LogId = l.Id
+		}
+		return tx.Create(&l.Targets).Error
+	})
+}
+
+// ListAuditLogs 分页获取审计记录, 最近的在前
+func (u *UserDao) ListAuditLogs(ctx context.Context, f AuditFilter, page, size int) ([]AuditLog, int64, error) {
+	query := u.auditQuery(ctx, f)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []AuditLog
+	err := query.Order("ctime DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := u.loadAuditTargets(ctx, logs); err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+// ScanAuditLogs 按写入顺序获取 ID 大于 afterId 的审计记录, 用于导出
+func (u *UserDao) ScanAuditLogs(ctx context.Context, f AuditFilter, afterId int64, limit int) ([]AuditLog, error) {
+	var logs []AuditLog
+	err := u.auditQuery(ctx, f).Where("id > ?", afterId).Order("id ASC").Limit(limit).Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, u.loadAuditTargets(ctx, logs)
+}
+
+func (u *UserDao) auditQuery(ctx context.Context, f AuditFilter) *gorm.DB {
+	query := u.db.WithContext(ctx).Model(&AuditLog{})
+	if f.UserId != 0 {
+		query = query.Where("(actor_id = ? OR owner_id = ?)", f.UserId, f.UserId)
+	}
+	if f.ActorId != 0 {
+		query = query.Where("actor_id = ?", f.ActorId)
+	}
+	if f.Start != 0 {
+		query = query.Where("ctime >= ?", f.Start)
+	}
+	if f.End != 0 {
+		query = query.Where("ctime < ?", f.End)
+	}
+	if prefix, ok := strings.CutSuffix(f.Action, "*"); ok {
+		query = query.Where("action LIKE ?", prefix+"%")
+	} else if f.Action != "" {
+		query = query.Where("action = ?", f.Action)
+	}
+	if f.TargetType != "" || f.TargetId != "" {
+		sub := u.db.WithContext(ctx).Model(&AuditTarget{}).Select("log_id")
+		if f.TargetType != "" {
+			sub = sub.Where("type = ?", f.TargetType)
+		}
+		if f.TargetId != "" {
+			sub = sub.Where("target_id = ?", f.TargetId)
+		}
+		query = query.Where("id IN (?)", sub)
+	}
+
+	return query
+}
+
+func (u *UserDao) loadAuditTargets(ctx context.Context, logs []AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(logs))
+	index := make(map[int64]int, len(logs))
+	for i, l := range logs {
+		ids = append(ids, l.Id)
+		index[l.Id] = i
+	}
+
+	var targets []AuditTarget
+	if err := u.db.WithContext(ctx).Model(&AuditTarget{}).Where("log_id IN ?", ids).Find(&targets).Error; err != nil {
+		return err
+	}
+	for _, t := range targets {
+		i := index[t.LogId]
+		logs[i].Targets = append(logs[i].Targets, t)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 团队成员的角色, 数值越大权限越高
+const (
+	TeamRoleMember int8 = 1 // 读写团队空间中的文件
+	TeamRoleAdmin  int8 = 2 // 另可管理成员
+	TeamRoleOwner  int8 = 3 // 另可任命管理员和转让团队, 每个团队只有一个
+)
+
+// 团队审计日志的操作类型
+const (
+	AuditCreateTeam    = "create_team"
+	AuditAddMember     = "add_member"
+	AuditUpdateRole    = "update_role"
+	AuditRemoveMember  = "remove_member"
+	AuditLeaveTeam     = "leave_team"
+	AuditTransferOwner = "transfer_owner"
+)
+
+// Team 团队, 团队空间的文件由文件服务保存, RootFolderId 为其根文件夹
+type Team struct {
+	Id           int    `gorm:"primaryKey,autoIncrement"`
+	Name         string `gorm:"type:varchar(255);not null"`
+	OwnerId      int    `gorm:"not null;index"`
+	RootFolderId int64
+	Ctime        int64
+	Utime        int64
+}
+
+// TeamMember 团队成员
+type TeamMember struct {
+	Id     int  `gorm:"primaryKey,autoIncrement"`
+	TeamId int  `gorm:"not null;uniqueIndex:uk_team_user"`
+	UserId int  `gorm:"not null;uniqueIndex:uk_team_user;index"`
+	Role   int8 `gorm:"not null"`
+	Ctime  int64
+}
+
+// TeamAudit 团队成员变更的审计日志
+type TeamAudit struct {
+	Id           int64  `gorm:"primaryKey,autoIncrement"`
+	TeamId       int    `gorm:"not null;index:idx_team_ctime"`
+	ActorId      int    `gorm:"not null"`
+	Action       string `gorm:"type:varchar(32);not null"`
+	TargetUserId int
+	Detail       string `gorm:"type:varchar(255)"`
+	Ctime        int64  `gorm:"not null;index:idx_team_ctime"`
+}
+
+// Transaction 在同一个事务中执行多个操作, fn 中的 dao 共用该事务
+func (u *UserDao) Transaction(ctx context.Context, fn func(d *UserDao) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&UserDao{db: tx})
+	})
+}
+
+// FindByIds 批量获取用户
+func (u *UserDao) FindByIds(ctx context.Context, ids []int) (map[int]User, error) {
+	res := make(map[int]User, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var users []User
+	if err := u.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		res[user.Id] = user
+	}
+
+	return res, nil
+}
+
+// CreateTeam 创建团队并将创建者设为所有者
+func (u *UserDao) CreateTeam(ctx context.Context, team *Team) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Unix()
+		team.Ctime, team.Utime = now, now
+		if err := tx.Create(team).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&TeamMember{TeamId: team.Id, UserId: team.OwnerId, Role: TeamRoleOwner, Ctime: now}).Error
+	})
+}
+
+// SetTeamRootFolder 记录团队空间的根文件夹
+func (u *UserDao) SetTeamRootFolder(ctx context.Context, teamId int, rootFolderId int64) error {
+	return u.db.WithContext(ctx).Model(&Team{}).Where("id = ?", teamId).
+		Updates(map[string]any{"root_folder_id": rootFolderId, "utime": time.Now().Unix()}).Error
+}
+
+// SetTeamOwner 修改团队的所有者
+func (u *UserDao) SetTeamOwner(ctx context.Context, teamId, ownerId int) error {
+	return u.db.WithContext(ctx).Model(&Team{}).Where("id = ?", teamId).
+		Updates(map[string]any{"owner_id": ownerId, "utime": time.Now().Unix()}).Error
+}
+
+// FindTeams 批量获取团队
+func (u *UserDao) FindTeams(ctx context.Context, ids []int) (map[int]Team, error) {
+	res := make(map[int]Team, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var teams []Team
+	if err := u.db.WithContext(ctx).Where("id IN ?", ids).Find(&teams).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range teams {
+		res[t.Id] = t
+	}
+
+	return res, nil
+}
+
+// ListMemberships 获取用户加入的所有团队的成员记录, 按加入时间排序
+func (u *UserDao) ListMemberships(ctx context.Context, uid int) ([]TeamMember, error) {
+	var members []TeamMember
+	err := u.db.WithContext(ctx).Where("user_id = ?", uid).Order("ctime, id").Find(&members).Error
+
+	return members, err
+}
+
+// FindMember 获取团队成员, 不是成员时 Id 为 0
+func (u *UserDao) FindMember(ctx context.Context, teamId, uid int) (TeamMember, error) {
+	var member TeamMember
+	err := u.db.WithContext(ctx).Where("team_id = ? AND user_id = ?", teamId, uid).Find(&member).Error
+
+	return member, err
+}
+
+// ListMembers 获取团队的所有成员, 按角色从高到低排序
+func (u *UserDao) ListMembers(ctx context.Context, teamId int) ([]TeamMember, error) {
+	var members []TeamMember
+	err := u.db.WithContext(ctx).Where("team_id = ?", teamId).Order("role DESC, ctime, id").Find(&members).Error
+
+	return members, err
+}
+
+// AddMember 添加团队成员, 已是成员时返回唯一索引冲突
+func (u *UserDao) AddMember(ctx context.Context, member *TeamMember) error {
+	member.Ctime = time.Now().Unix()
+	return u.db.WithContext(ctx).Create(member).Error
+}
+
+// UpdateMemberRole 修改成员的角色
+func (u *UserDao) UpdateMemberRole(ctx context.Context, teamId, uid int, role int8) error {
+	return u.db.WithContext(ctx).Model(&TeamMember{}).Where("team_id = ? AND user_id = ?", teamId, uid).
+		Update("role", role).Error
+}
+
+// RemoveMember 移除团队成员
+func (u *UserDao) RemoveMember(ctx context.Context, teamId, uid int) error {
+	return u.db.WithContext(ctx).Where("team_id = ? AND user_id = ?", teamId, uid).Delete(&TeamMember{}).Error
+}
+
+// AddAudit 记录团队审计日志
+func (u *UserDao) AddAudit(ctx context.Context, audit *TeamAudit) error {
+	audit.Ctime = time.Now().Unix()
+	return u.db.WithContext(ctx).Create(audit).Error
+}
+
+// ListAudit 按时间倒序分页获取团队审计日志
+func (u *UserDao) ListAudit(ctx context.Context, teamId, page, size int) ([]TeamAudit, int64, error) {
+	query := u.db.WithContext(ctx).Model(&TeamAudit{}).Where("team_id = ?", teamId)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var audits []TeamAudit
+	err := query.Order("ctime DESC, id DESC").Offset((page - 1) * size).Limit(size).Find(&audits).Error
+
+	return audits, total, err
+}
@@ -41,6 +41,16 @@ func (u *UserDao) FindByPhone(ctx context.Context, phone string) (User, error) {
 	return user, nil
 }
 
+func (u *UserDao) FindByName(ctx context.Context, name string) (User, error) {
+	var user User
+	err := u.db.WithContext(ctx).Where("name = ?", name).Find(&user).Error
+	if err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
 func (u *UserDao) FindById(ctx context.Context, id int) (User, error) {
 	var user User
 	err := u.db.WithContext(ctx).Where("id = ?", id).Find(&user).Error
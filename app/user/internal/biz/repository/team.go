@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/crazyfrankie/cloudstorage/app/user/internal/biz/repository/dao"
+)
+
+// Transaction 在同一个事务中执行多个仓储操作
+func (r *UserRepo) Transaction(ctx context.Context, fn func(r *UserRepo) error) error {
+	return r.dao.Transaction(ctx, func(d *dao.UserDao) error {
+		return fn(&UserRepo{dao: d})
+	})
+}
+
+func (r *UserRepo) FindByIds(ctx context.Context, ids []int) (map[int]dao.User, error) {
+	return r.dao.FindByIds(ctx, ids)
+}
+
+func (r *UserRepo) CreateTeam(ctx context.Context, team *dao.Team) error {
+	return r.dao.CreateTeam(ctx, team)
+}
+
+func (r *UserRepo) SetTeamRootFolder(ctx context.Context, teamId int, rootFolderId int64) error {
+	return r.dao.SetTeamRootFolder(ctx, teamId, rootFolderId)
+}
+
+func (r *UserRepo) SetTeamOwner(ctx context.Context, teamId, ownerId int) error {
+	return r.dao.SetTeamOwner(ctx, teamId, ownerId)
+}
+
+func (r *UserRepo) FindTeams(ctx context.Context, ids []int) (map[int]dao.Team, error) {
+	return r.dao.FindTeams(ctx, ids)
+}
+
+func (r *UserRepo) ListMemberships(ctx context.Context, uid int) ([]dao.TeamMember, error) {
+	return r.dao.ListMemberships(ctx, uid)
+}
+
+func (r *UserRepo) FindMember(ctx context.Context, teamId, uid int) (dao.TeamMember, error) {
+	return r.dao.FindMember(ctx, teamId, uid)
+}
+
+func (r *UserRepo) ListMembers(ctx context.Context, teamId int) ([]dao.TeamMember, error) {
+	return r.dao.ListMembers(ctx, teamId)
+}
+
+func (r *UserRepo) AddMember(ctx context.Context, member *dao.TeamMember) error {
+	return r.dao.AddMember(ctx, member)
+}
+
+func (r *UserRepo) UpdateMemberRole(ctx context.Context, teamId, uid int, role int8) error {
+	return r.dao.UpdateMemberRole(ctx, teamId, uid, role)
+}
+
+func (r *UserRepo) RemoveMember(ctx context.Context, teamId, uid int) error {
+	return r.dao.RemoveMember(ctx, teamId, uid)
+}
+
+func (r *UserRepo) AddAudit(ctx context.Context, audit *dao.TeamAudit) error {
+	return r.dao.AddAudit(ctx, audit)
+}
+
+func (r *UserRepo) ListAudit(ctx context.Context, teamId, page, size int) ([]dao.TeamAudit, int64, error) {
+	return r.dao.ListAudit(ctx, teamId, page, size)
+}
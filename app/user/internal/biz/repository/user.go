@@ -22,6 +22,10 @@ func (r *UserRepo) FindByPhone(ctx context.Context, phone string) (dao.User, err
 	return r.dao.FindByPhone(ctx, phone)
 }
 
+func (r *UserRepo) FindByName(ctx context.Context, name string) (dao.User, error) {
+	return r.dao.FindByName(ctx, name)
+}
+
 func (r *UserRepo) FindById(ctx context.Context, id int) (dao.User, error) {
 	return r.dao.FindById(ctx, id)
 }
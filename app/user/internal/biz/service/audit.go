@@ -0,0 +1,447 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/crazyfrankie/cloudstorage/app/user/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/user"
+)
+
+// 网关转发的客户端信息, 与网关的 mws.ClientInfo 一致
+const (
+	auditClientIpKey  = "x-client-ip"
+	auditUserAgentKey = "x-user-agent"
+	auditDeviceIdKey  = "x-device-id"
+)
+
+const (
+	// auditTimeout 写入一条审计记录的超时, 请求结束后仍会写入
+	auditTimeout = 5 * time.Second
+	// auditExportBatch 导出时每次查询的记录数, auditChunkSize 为每块返回的大小
+	auditExportBatch = 500
+	auditChunkSize   = 64 << 10
+)
+
+// auditMethod 需要审计的方法, mutation 为 true 时还记录操作前目标的元数据
+type auditMethod struct {
+	action   string
+	mutation bool
+	admin    bool
+}
+
+// auditMethods 按方法名记录登录、资料和团队的修改以及审计日志的导出, 其他只读方法不记录
+var auditMethods = map[string]auditMethod{
+	"SendCode":         {action: "user.send_code"},
+	"VerifyCode":       {action: "user.login", mutation: true},
+	"UpdateInfo":       {action: "user.update_info", mutation: true},
+	"CreateTeam":       {action: "team.create", mutation: true},
+	"AddTeamMember":    {action: "team.add_member", mutation: true},
+	"UpdateTeamMember": {action: "team.update_member", mutation: true},
+	"RemoveTeamMember": {action: "team.remove_member", mutation: true},
+	"ExportAuditLogs":  {action: "audit.export"},
+	"DumpAuditLogs":    {action: "audit.export", admin: true},
+}
+
+// AuditUnaryInterceptor 记录需要审计的方法的调用, 写入失败只记录日志, 不影响请求
+func (s *UserServer) AuditUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		m, ok := auditMethods[info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		l := newAuditLog(ctx, info.FullMethod, m, req)
+		if m.mutation {
+			l.Before = s.auditSnapshot(ctx, l.Targets)
+		}
+		resp, err := handler(ctx, req)
+		s.finishAudit(ctx, l, m, req, resp, err)
+
+		return resp, err
+	}
+}
+
+// AuditStreamInterceptor 流式方法在结束后记录, 以收到的第一条消息为请求
+func (s *UserServer) AuditStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		m, ok := auditMethods[info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]]
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		as := &auditStream{ServerStream: ss}
+		err := handler(srv, as)
+		s.finishAudit(ss.Context(), newAuditLog(ss.Context(), info.FullMethod, m, as.req), m, as.req, nil, err)
+
+		return err
+	}
+}
+
+type auditStream struct {
+	grpc.ServerStream
+	req any
+}
+
+func (a *auditStream) RecvMsg(m any) error {
+	err := a.ServerStream.RecvMsg(m)
+	if err == nil && a.req == nil {
+		a.req = m
+	}
+	return err
+}
+
+func newAuditLog(ctx context.Context, fullMethod string, m auditMethod, req any) *dao.AuditLog {
+	l := &dao.AuditLog{Action: m.action, Method: fullMethod}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		l.Ip = firstValue(md, auditClientIpKey)
+		l.UserAgent = firstValue(md, auditUserAgentKey)
+		l.DeviceId = firstValue(md, auditDeviceIdKey)
+	}
+	if v, ok := req.(interface{ GetUserId() int32 }); ok && !m.admin {
+		l.ActorId = int(v.GetUserId())
+	}
+	// 不经网关的调用, 如管理接口, 以对端地址为 IP
+	if p, ok := peer.FromContext(ctx); ok && l.Ip == "" {
+		l.Ip = p.Addr.String()
+	}
+	if len(l.UserAgent) > 255 {
+		l.UserAgent = l.UserAgent[:255]
+	}
+	if len(l.DeviceId) > 64 {
+		l.DeviceId = l.DeviceId[:64]
+	}
+	if len(l.Ip) > 64 {
+		l.Ip = l.Ip[:64]
+	}
+
+	switch r := req.(type) {
+	case *user.UpdateInfoRequest:
+		addAuditTarget(l, "user", int(r.GetUserId()))
+	case *user.AddTeamMemberRequest:
+		addAuditTarget(l, "team", int(r.GetTeamId()))
+	case *user.UpdateTeamMemberRequest:
+		addAuditTarget(l, "team", int(r.GetTeamId()))
+		addAuditTarget(l, "user", int(r.GetMemberId()))
+	case *user.RemoveTeamMemberRequest:
+		addAuditTarget(l, "team", int(r.GetTeamId()))
+		addAuditTarget(l, "user", int(r.GetMemberId()))
+	}
+
+	return l
+}
+
+func addAuditTarget(l *dao.AuditLog, typ string, id int) {
+	if id == 0 {
+		return
+	}
+	t := dao.AuditTarget{Type: typ, TargetId: strconv.Itoa(id)}
+	for _, e := range l.Targets {
+		if e == t {
+			return
+		}
+	}
+	l.Targets = append(l.Targets, t)
+}
+
+// finishAudit 补充响应中的目标和操作后的元数据, 写入审计记录
+func (s *UserServer) finishAudit(ctx context.Context, l *dao.AuditLog, m auditMethod, req, resp any, err error) {
+	if err == nil {
+		switch r := resp.(type) {
+		case *user.VerifyCodeResponse:
+			// 登录前没有操作者, 成功后按手机号找到
+			if u, err := s.repo.FindByPhone(ctx, req.(*user.VerifyCodeRequest).GetPhone()); err == nil {
+				l.ActorId = u.Id
+				addAuditTarget(l, "user", u.Id)
+			}
+		case *user.CreateTeamResponse:
+			addAuditTarget(l, "team", int(r.GetTeam().GetId()))
+		case *user.AddTeamMemberResponse:
+			addAuditTarget(l, "user", int(r.GetMember().GetUserId()))
+		}
+	}
+	if m.mutation {
+		l.After = s.auditSnapshot(ctx, l.Targets)
+	}
+	l.OwnerId = l.ActorId
+	for _, t := range l.Targets {
+		if t.Type == "user" {
+			l.OwnerId, _ = strconv.Atoi(t.TargetId)
+			break
+		}
+	}
+
+	st := status.Convert(err)
+	l.Code = st.Code().String()
+	if err != nil {
+		l.Error = st.Message()
+		if len(l.Error) > 255 {
+			l.Error = l.Error[:255]
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+	defer cancel()
+	if err := s.repo.AddAuditLog(ctx, l); err != nil {
+		log.Printf("failed to write audit log of %s: %v", l.Method, err)
+	}
+}
+
+// auditSnapshot 返回用户和团队类目标当前的元数据, 同时有团队和用户时还包括用户在团队中的角色
+func (s *UserServer) auditSnapshot(ctx context.Context, targets []dao.AuditTarget) string {
+	var uids, teamIds []int
+	for _, t := range targets {
+		id, _ := strconv.Atoi(t.TargetId)
+		switch t.Type {
+		case "user":
+			uids = append(uids, id)
+		case "team":
+			teamIds = append(teamIds, id)
+		}
+	}
+	if len(uids)+len(teamIds) == 0 {
+		return ""
+	}
+
+	users, err := s.repo.FindByIds(ctx, uids)
+	if err != nil {
+		log.Printf("failed to load audit users: %v", err)
+		return ""
+	}
+	teams, err := s.repo.FindTeams(ctx, teamIds)
+	if err != nil {
+		log.Printf("failed to load audit teams: %v", err)
+		return ""
+	}
+
+	snapshot := make(map[string]any)
+	for _, uid := range uids {
+		if u, ok := users[uid]; ok {
+			snapshot["user:"+strconv.Itoa(uid)] = map[string]any{"name": u.Name, "avatar": u.Avatar}
+		}
+	}
+	for _, teamId := range teamIds {
+		t, ok := teams[teamId]
+		if !ok {
+			continue
+		}
+		snapshot["team:"+strconv.Itoa(teamId)] = map[string]any{
+			"name": t.Name, "owner_id": t.OwnerId, "root_folder_id": t.RootFolderId,
+		}
+		for _, uid := range uids {
+			member, err := s.repo.FindMember(ctx, teamId, uid)
+			if err != nil || member.Id == 0 {
+				continue
+			}
+			snapshot["member:"+strconv.Itoa(teamId)+":"+strconv.Itoa(uid)] = map[string]any{"role": member.Role}
+		}
+	}
+	if len(snapshot) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// ListAuditLogs 分页获取用户执行的或针对其本人的操作
+func (s *UserServer) ListAuditLogs(ctx context.Context, req *file.ListAuditLogsRequest) (*file.ListAuditLogsResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, errors.New("user id is required")
+	}
+	f := toAuditFilter(req.GetFilter())
+	f.UserId = int(req.GetUserId())
+
+	return s.listAuditLogs(ctx, f, req.GetPage(), req.GetSize())
+}
+
+// ExportAuditLogs 导出用户执行的或针对其本人的操作
+func (s *UserServer) ExportAuditLogs(req *file.ExportAuditLogsRequest, stream user.UserService_ExportAuditLogsServer) error {
+	if req.GetUserId() == 0 {
+		return errors.New("user id is required")
+	}
+	f := toAuditFilter(req.GetFilter())
+	f.UserId = int(req.GetUserId())
+
+	return s.exportAuditLogs(stream.Context(), f, req.GetFormat(), stream.Send)
+}
+
+// QueryAuditLogs 分页获取全部用户的审计记录
+func (s *UserServer) QueryAuditLogs(ctx context.Context, req *file.QueryAuditLogsRequest) (*file.ListAuditLogsResponse, error) {
+	return s.listAuditLogs(ctx, toAuditFilter(req.GetFilter()), req.GetPage(), req.GetSize())
+}
+
+// DumpAuditLogs 导出全部用户的审计记录
+func (s *UserServer) DumpAuditLogs(req *file.DumpAuditLogsRequest, stream user.UserService_DumpAuditLogsServer) error {
+	return s.exportAuditLogs(stream.Context(), toAuditFilter(req.GetFilter()), req.GetFormat(), stream.Send)
+}
+
+func (s *UserServer) listAuditLogs(ctx context.Context, f dao.AuditFilter, page, size int32) (*file.ListAuditLogsResponse, error) {
+	p, sz := int(page), int(size)
+	if p <= 0 {
+		p = 1
+	}
+	if sz <= 0 {
+		sz = 20
+	}
+	logs, total, err := s.repo.ListAuditLogs(ctx, f, p, sz)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &file.ListAuditLogsResponse{Total: total, Logs: make([]*file.AuditLog, 0, len(logs))}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, toPbAuditLog(l))
+	}
+
+	return resp, nil
+}
+
+// exportAuditLogs 按写入顺序分批读取审计记录, 编码后分块发送
+func (s *UserServer) exportAuditLogs(ctx context.Context, f dao.AuditFilter, format file.AuditExportFormat,
+	send func(*file.ExportAuditLogsResponse) error) error {
+	var buf bytes.Buffer
+	w := newAuditWriter(&buf, format)
+	flush := func(all bool) error {
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if buf.Len() == 0 || (!all && buf.Len() < auditChunkSize) {
+			return nil
+		}
+		data := bytes.Clone(buf.Bytes())
+		buf.Reset()
+		return send(&file.ExportAuditLogsResponse{Data: data})
+	}
+
+	var afterId int64
+	for {
+		logs, err := s.repo.ScanAuditLogs(ctx, f, afterId, auditExportBatch)
+		if err != nil {
+			return err
+		}
+		for _, l := range logs {
+			if err := w.Write(toPbAuditLog(l)); err != nil {
+				return err
+			}
+		}
+		if err := flush(false); err != nil {
+			return err
+		}
+		if len(logs) < auditExportBatch {
+			break
+		}
+		afterId = logs[len(logs)-1].Id
+	}
+
+	return flush(true)
+}
+
+// auditWriter 按导出格式编码审计记录, 与文件服务的导出格式一致
+type auditWriter struct {
+	format file.AuditExportFormat
+	out    io.Writer
+	csv    *csv.Writer
+	header bool
+}
+
+func newAuditWriter(out io.Writer, format file.AuditExportFormat) *auditWriter {
+	return &auditWriter{format: format, out: out, csv: csv.NewWriter(out)}
+}
+
+var auditCSVHeader = []string{"id", "service", "time", "action", "method", "actor_id", "owner_id",
+	"device_id", "ip", "user_agent", "targets", "code", "error", "before", "after"}
+
+func (w *auditWriter) Write(l *file.AuditLog) error {
+	if w.format == file.AuditExportFormat_AUDIT_EXPORT_FORMAT_JSONL {
+		data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(l)
+		if err != nil {
+			return err
+		}
+		_, err = w.out.Write(append(data, '\n'))
+		return err
+	}
+
+	if !w.header {
+		w.header = true
+		if err := w.csv.Write(auditCSVHeader); err != nil {
+			return err
+		}
+	}
+	targets := make([]string, 0, len(l.Targets))
+	for _, t := range l.Targets {
+		targets = append(targets, t.Type+":"+t.Id)
+	}
+
+	return w.csv.Write([]string{
+		strconv.FormatInt(l.Id, 10), l.Service, time.Unix(l.Ctime, 0).Format(time.RFC3339), l.Action, l.Method,
+		strconv.Itoa(int(l.ActorId)), strconv.Itoa(int(l.OwnerId)), l.DeviceId, l.Ip, l.UserAgent,
+		strings.Join(targets, " "), l.Code, l.Error, l.Before, l.After,
+	})
+}
+
+func (w *auditWriter) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func toAuditFilter(f *file.AuditLogFilter) dao.AuditFilter {
+	return dao.AuditFilter{
+		ActorId:    int(f.GetActorId()),
+		Start:      f.GetStartTime(),
+		End:        f.GetEndTime(),
+		Action:     f.GetAction(),
+		TargetType: f.GetTargetType(),
+		TargetId:   f.GetTargetId(),
+	}
+}
+
+func toPbAuditLog(l dao.AuditLog) *file.AuditLog {
+	targets := make([]*file.AuditTarget, 0, len(l.Targets))
+	for _, t := range l.Targets {
+		targets = append(targets, &file.AuditTarget{Type: t.Type, Id: t.TargetId})
+	}
+
+	return &file.AuditLog{
+		Id:        l.Id,
+		Service:   "user",
+		Action:    l.Action,
+		Method:    l.Method,
+		ActorId:   int32(l.ActorId),
+		OwnerId:   int32(l.OwnerId),
+		DeviceId:  l.DeviceId,
+		Ip:        l.Ip,
+		UserAgent: l.UserAgent,
+		Targets:   targets,
+		Before:    l.Before,
+		After:     l.After,
+		Code:      l.Code,
+		Error:     l.Error,
+		Ctime:     l.Ctime,
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/crazyfrankie/cloudstorage/app/user/internal/biz/repository"
+	"github.com/crazyfrankie/cloudstorage/app/user/internal/biz/repository/dao"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/file"
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/user"
+)
+
+var (
+	ErrNotTeamMember     = errors.New("not a member of the team")
+	ErrTeamPermission    = errors.New("insufficient team role")
+	ErrAlreadyTeamMember = errors.New("user is already a member of the team")
+)
+
+// CreateTeam 创建团队及其团队空间, 创建者成为所有者, 创建团队空间失败时团队一并回滚
+func (s *UserServer) CreateTeam(ctx context.Context, req *user.CreateTeamRequest) (*user.CreateTeamResponse, error) {
+	name := strings.TrimSpace(req.GetName())
+	if name == "" || utf8.RuneCountInString(name) > 255 {
+		return nil, errors.New("invalid team name")
+	}
+
+	uid := int(req.GetUserId())
+	team := &dao.Team{Name: name, OwnerId: uid}
+	err := s.repo.Transaction(ctx, func(r *repository.UserRepo) error {
+		if err := r.CreateTeam(ctx, team); err != nil {
+			return err
+		}
+
+		resp, err := s.file.CreateTeamSpace(ctx, &file.CreateTeamSpaceRequest{
+			TeamId:  int32(team.Id),
+			Name:    name,
+			OwnerId: req.GetUserId(),
+		})
+		if err != nil {
+			return err
+		}
+		team.RootFolderId = resp.GetSpace().GetRootFolderId()
+		if err := r.SetTeamRootFolder(ctx, team.Id, team.RootFolderId); err != nil {
+			return err
+		}
+
+		return r.AddAudit(ctx, &dao.TeamAudit{TeamId: team.Id, ActorId: uid, Action: dao.AuditCreateTeam, Detail: name})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.CreateTeamResponse{Team: toPbTeam(*team, dao.TeamRoleOwner)}, nil
+}
+
+// ListTeams 获取用户加入的团队
+func (s *UserServer) ListTeams(ctx context.Context, req *user.ListTeamsRequest) (*user.ListTeamsResponse, error) {
+	members, err := s.repo.ListMemberships(ctx, int(req.GetUserId()))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.TeamId)
+	}
+	teams, err := s.repo.FindTeams(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &user.ListTeamsResponse{Teams: make([]*user.Team, 0, len(members))}
+	for _, m := range members {
+		if t, ok := teams[m.TeamId]; ok {
+			resp.Teams = append(resp.Teams, toPbTeam(t, m.Role))
+		}
+	}
+
+	return resp, nil
+}
+
+// ListTeamMembers 获取团队的成员, 仅成员可见
+func (s *UserServer) ListTeamMembers(ctx context.Context, req *user.ListTeamMembersRequest) (*user.ListTeamMembersResponse, error) {
+	teamId := int(req.GetTeamId())
+	if _, err := s.teamMember(ctx, teamId, int(req.GetUserId())); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.ListMembers(ctx, teamId)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.UserId)
+	}
+	users, err := s.repo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &user.ListTeamMembersResponse{Members: make([]*user.TeamMember, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, toPbTeamMember(m, users[m.UserId].Name))
+	}
+
+	return resp, nil
+}
+
+// AddTeamMember 按用户名或手机号添加成员, 只能授予低于自己的角色
+func (s *UserServer) AddTeamMember(ctx context.Context, req *user.AddTeamMemberRequest) (*user.AddTeamMemberResponse, error) {
+	teamId, uid := int(req.GetTeamId()), int(req.GetUserId())
+	role := int8(req.GetRole())
+	if role == 0 {
+		role = dao.TeamRoleMember
+	}
+
+	me, err := s.teamMember(ctx, teamId, uid)
+	if err != nil {
+		return nil, err
+	}
+	if me.Role < dao.TeamRoleAdmin || role < dao.TeamRoleMember || role >= me.Role {
+		return nil, ErrTeamPermission
+	}
+
+	found, err := s.FindUser(ctx, &user.FindUserRequest{Name: req.GetName(), Phone: req.GetPhone()})
+	if err != nil {
+		return nil, err
+	}
+	target := found.GetUser()
+	existing, err := s.repo.FindMember(ctx, teamId, int(target.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	if existing.Id != 0 {
+		return nil, ErrAlreadyTeamMember
+	}
+
+	member := &dao.TeamMember{TeamId: teamId, UserId: int(target.GetId()), Role: role}
+	err = s.repo.Transaction(ctx, func(r *repository.UserRepo) error {
+		if err := r.AddMember(ctx, member); err != nil {
+			return err
+		}
+		if err := s.syncSpaceMember(ctx, teamId, uid, member.UserId, role); err != nil {
+			return err
+		}
+
+		return r.AddAudit(ctx, &dao.TeamAudit{
+			TeamId:       teamId,
+			ActorId:      uid,
+			Action:       dao.AuditAddMember,
+			TargetUserId: member.UserId,
+			Detail:       fmt.Sprintf("role %d", role),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.AddTeamMemberResponse{Member: toPbTeamMember(*member, target.GetName())}, nil
+}
+
+// UpdateTeamMember 修改成员的角色, 所有者将其他成员设为所有者时转让团队, 原所有者成为管理员
+func (s *UserServer) UpdateTeamMember(ctx context.Context, req *user.UpdateTeamMemberRequest) (*user.UpdateTeamMemberResponse, error) {
+	teamId, uid, targetId := int(req.GetTeamId()), int(req.GetUserId()), int(req.GetMemberId())
+	role := int8(req.GetRole())
+	if role < dao.TeamRoleMember || role > dao.TeamRoleOwner {
+		return nil, errors.New("invalid role")
+	}
+	if targetId == uid {
+		return nil, errors.New("cannot change your own role")
+	}
+
+	me, err := s.teamMember(ctx, teamId, uid)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.teamMember(ctx, teamId, targetId)
+	if err != nil {
+		return nil, err
+	}
+	if target.Role == role {
+		return &user.UpdateTeamMemberResponse{}, nil
+	}
+
+	if role == dao.TeamRoleOwner {
+		if me.Role != dao.TeamRoleOwner {
+			return nil, ErrTeamPermission
+		}
+		err = s.repo.Transaction(ctx, func(r *repository.UserRepo) error {
+			if err := r.UpdateMemberRole(ctx, teamId, targetId, dao.TeamRoleOwner); err != nil {
+				return err
+			}
+			if err := r.UpdateMemberRole(ctx, teamId, uid, dao.TeamRoleAdmin); err != nil {
+				return err
+			}
+			if err := r.SetTeamOwner(ctx, teamId, targetId); err != nil {
+				return err
+			}
+			if err := s.syncSpaceMember(ctx, teamId, uid, targetId, dao.TeamRoleOwner); err != nil {
+				return err
+			}
+			if err := s.syncSpaceMember(ctx, teamId, uid, uid, dao.TeamRoleAdmin); err != nil {
+				return err
+			}
+
+			return r.AddAudit(ctx, &dao.TeamAudit{TeamId: teamId, ActorId: uid, Action: dao.AuditTransferOwner, TargetUserId: targetId})
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &user.UpdateTeamMemberResponse{}, nil
+	}
+
+	if me.Role <= target.Role || me.Role <= role {
+		return nil, ErrTeamPermission
+	}
+	err = s.repo.Transaction(ctx, func(r *repository.UserRepo) error {
+		if err := r.UpdateMemberRole(ctx, teamId, targetId, role); err != nil {
+			return err
+		}
+		if err := s.syncSpaceMember(ctx, teamId, uid, targetId, role); err != nil {
+			return err
+		}
+
+		return r.AddAudit(ctx, &dao.TeamAudit{
+			TeamId:       teamId,
+			ActorId:      uid,
+			Action:       dao.AuditUpdateRole,
+			TargetUserId: targetId,
+			Detail:       fmt.Sprintf("role %d -> %d", target.Role, role),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.UpdateTeamMemberResponse{}, nil
+}
+
+// RemoveTeamMember 移除角色低于自己的成员, 或退出团队, 所有者须先转让团队才能退出
+func (s *UserServer) RemoveTeamMember(ctx context.Context, req *user.RemoveTeamMemberRequest) (*user.RemoveTeamMemberResponse, error) {
+	teamId, uid, targetId := int(req.GetTeamId()), int(req.GetUserId()), int(req.GetMemberId())
+
+	me, err := s.teamMember(ctx, teamId, uid)
+	if err != nil {
+		return nil, err
+	}
+	action := dao.AuditRemoveMember
+	if targetId == uid {
+		if me.Role == dao.TeamRoleOwner {
+			return nil, errors.New("the owner must transfer the team before leaving")
+		}
+		action = dao.AuditLeaveTeam
+	} else {
+		target, err := s.teamMember(ctx, teamId, targetId)
+		if err != nil {
+			return nil, err
+		}
+		if me.Role <= target.Role {
+			return nil, ErrTeamPermission
+		}
+	}
+
+	err = s.repo.Transaction(ctx, func(r *repository.UserRepo) error {
+		if err := r.RemoveMember(ctx, teamId, targetId); err != nil {
+			return err
+		}
+		if err := s.syncSpaceMember(ctx, teamId, uid, targetId, 0); err != nil {
+			return err
+		}
+
+		return r.AddAudit(ctx, &dao.TeamAudit{TeamId: teamId, ActorId: uid, Action: action, TargetUserId: targetId})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.RemoveTeamMemberResponse{}, nil
+}
+
+// GetTeamAudit 按时间倒序分页获取团队的审计日志, 需要管理员以上角色
+func (s *UserServer) GetTeamAudit(ctx context.Context, req *user.GetTeamAuditRequest) (*user.GetTeamAuditResponse, error) {
+	teamId := int(req.GetTeamId())
+	me, err := s.teamMember(ctx, teamId, int(req.GetUserId()))
+	if err != nil {
+		return nil, err
+	}
+	if me.Role < dao.TeamRoleAdmin {
+		return nil, ErrTeamPermission
+	}
+
+	page, size := int(req.GetPage()), int(req.GetSize())
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = 20
+	}
+	audits, total, err := s.repo.ListAudit(ctx, teamId, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &user.GetTeamAuditResponse{Total: total, Logs: make([]*user.TeamAudit, 0, len(audits))}
+	for _, a := range audits {
+		resp.Logs = append(resp.Logs, &user.TeamAudit{
+			Id:           a.Id,
+			ActorId:      int32(a.ActorId),
+			Action:       a.Action,
+			TargetUserId: int32(a.TargetUserId),
+			Detail:       a.Detail,
+			Ctime:        a.Ctime,
+		})
+	}
+
+	return resp, nil
+}
+
+// teamMember 获取用户在团队中的成员记录, 不是成员时返回 ErrNotTeamMember
+func (s *UserServer) teamMember(ctx context.Context, teamId, uid int) (dao.TeamMember, error) {
+	member, err := s.repo.FindMember(ctx, teamId, uid)
+	if err != nil {
+		return dao.TeamMember{}, err
+	}
+	if member.Id == 0 {
+		return dao.TeamMember{}, ErrNotTeamMember
+	}
+
+	return member, nil
+}
+
+// syncSpaceMember 将成员 uid 的角色同步到文件服务的团队空间, operator 为发起变更的成员, 失败时调用方回滚本地的成员变更
+func (s *UserServer) syncSpaceMember(ctx context.Context, teamId, operator, uid int, role int8) error {
+	_, err := s.file.SetSpaceMember(ctx, &file.SetSpaceMemberRequest{
+		TeamId:     int32(teamId),
+		UserId:     int32(uid),
+		Role:       int32(role),
+		OperatorId: int32(operator),
+	})
+
+	return err
+}
+
+func toPbTeam(t dao.Team, role int8) *user.Team {
+	return &user.Team{
+		Id:           int32(t.Id),
+		Name:         t.Name,
+		OwnerId:      int32(t.OwnerId),
+		RootFolderId: t.RootFolderId,
+		Role:         int32(role),
+		Ctime:        t.Ctime,
+	}
+}
+
+func toPbTeamMember(m dao.TeamMember, name string) *user.TeamMember {
+	return &user.TeamMember{
+		UserId: int32(m.UserId),
+		Name:   name,
+		Role:   int32(m.Role),
+		Ctime:  m.Ctime,
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/url"
 	"sync"
@@ -152,3 +153,27 @@ func (s *UserServer) UpdateInfo(ctx context.Context, req *user.UpdateInfoRequest
 
 	return &user.UpdateInfoResponse{}, nil
 }
+
+// FindUser 按用户名或手机号查找用户, 用于邀请协作者
+func (s *UserServer) FindUser(ctx context.Context, req *user.FindUserRequest) (*user.FindUserResponse, error) {
+	var (
+		u   dao.User
+		err error
+	)
+	switch {
+	case req.GetName() != "":
+		u, err = s.repo.FindByName(ctx, req.GetName())
+	case req.GetPhone() != "":
+		u, err = s.repo.FindByPhone(ctx, req.GetPhone())
+	default:
+		return nil, errors.New("name or phone is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if u.Id == 0 {
+		return nil, errors.New("user not found")
+	}
+
+	return &user.FindUserResponse{User: &user.User{Id: int32(u.Id), Name: u.Name}}, nil
+}
@@ -22,6 +22,7 @@ type Config struct {
 	JWT    JWT    `yaml:"jwt"`
 	ETCD   ETCD   `yaml:"etcd"`
 	Minio  Minio  `yaml:"minio"`
+	Admin  Admin  `yaml:"admin"`
 }
 
 type Server struct {
@@ -48,6 +49,11 @@ type Minio struct {
 	DefaultName string `yaml:"defaultName"`
 }
 
+type Admin struct {
+	// Token 调用管理接口须在 gRPC 元数据 x-admin-token 中携带的令牌, 为空时拒绝全部管理接口
+	Token string `yaml:"token"`
+}
+
 func GetConf() *Config {
 	once.Do(initConfig)
 	return config
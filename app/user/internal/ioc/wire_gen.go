@@ -53,7 +53,7 @@ func InitDB() *gorm.DB {
 		panic(err)
 	}
 
-	db.AutoMigrate(&dao.User{})
+	db.AutoMigrate(&dao.User{}, &dao.Team{}, &dao.TeamMember{}, &dao.TeamAudit{}, &dao.AuditLog{}, &dao.AuditTarget{})
 
 	return db
 }
@@ -0,0 +1,55 @@
+package mws
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/crazyfrankie/cloudstorage/rpc_gen/user"
+)
+
+func TestCheckAdmin(t *testing.T) {
+	withToken := func(v string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(adminTokenKey, v))
+	}
+	cases := []struct {
+		name   string
+		ctx    context.Context
+		token  string
+		method string
+		want   codes.Code
+	}{
+		{"user api", context.Background(), "secret", user.UserService_ListAuditLogs_FullMethodName, codes.OK},
+		{"valid token", withToken("secret"), "secret", user.UserService_QueryAuditLogs_FullMethodName, codes.OK},
+		{"stream api", withToken("secret"), "secret", user.UserService_DumpAuditLogs_FullMethodName, codes.OK},
+		{"missing token", context.Background(), "secret", user.UserService_QueryAuditLogs_FullMethodName, codes.Unauthenticated},
+		{"missing stream token", context.Background(), "secret", user.UserService_DumpAuditLogs_FullMethodName, codes.Unauthenticated},
+		{"wrong token", withToken("guess"), "secret", user.UserService_QueryAuditLogs_FullMethodName, codes.Unauthenticated},
+		{"not configured", withToken(""), "", user.UserService_DumpAuditLogs_FullMethodName, codes.PermissionDenied},
+	}
+	for _, c := range cases {
+		if got := status.Code(checkAdmin(c.ctx, c.token, c.method)); got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestAdminUnaryInterceptorRejectsMissingToken(t *testing.T) {
+	intercept := AdminUnaryInterceptor("secret")
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: user.UserService_QueryAuditLogs_FullMethodName}
+	if _, err := intercept(context.Background(), nil, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("got %v, want Unauthenticated", err)
+	}
+	if called {
+		t.Fatal("handler ran without an admin token")
+	}
+}
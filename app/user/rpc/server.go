@@ -26,6 +26,7 @@ import (
 
 	"github.com/crazyfrankie/cloudstorage/app/user/internal/config"
 	"github.com/crazyfrankie/cloudstorage/app/user/internal/ioc"
+	"github.com/crazyfrankie/cloudstorage/app/user/internal/mws"
 	"github.com/crazyfrankie/cloudstorage/rpc_gen/user"
 )
 
@@ -75,10 +76,16 @@ func NewServer() *Server {
 			userMetrics.UnaryServerInterceptor(grpcprom.WithExemplarFromContext(labelsFromContext)),
 			logging.UnaryServerInterceptor(interceptorLogger(logger), logging.WithFieldsFromContext(logTraceID)),
 			circuitbreaker.NewInterceptorBuilder().Build(),
+			// 管理接口须携带管理令牌
+			mws.AdminUnaryInterceptor(config.GetConf().Admin.Token),
+			// 审计登录、资料和团队的修改
+			u.AuditUnaryInterceptor(),
 		),
 		grpc.ChainStreamInterceptor(
 			userMetrics.StreamServerInterceptor(grpcprom.WithExemplarFromContext(labelsFromContext)),
 			logging.StreamServerInterceptor(interceptorLogger(logger), logging.WithFieldsFromContext(logTraceID)),
+			mws.AdminStreamInterceptor(config.GetConf().Admin.Token),
+			u.AuditStreamInterceptor(),
 		))
 	user.RegisterUserServiceServer(s, u)
 	userMetrics.InitializeMetrics(s)
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// cookieName 网关保存登录凭证的 cookie
+const cookieName = "cloudstorage"
+
+// ErrNotLoggedIn 未登录或登录已过期
+var ErrNotLoggedIn = errors.New("not logged in, run: cs login <phone>")
+
+// APIError 网关返回的业务错误
+type APIError struct {
+	Code    int32
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// envelope 网关统一的响应格式
+type envelope struct {
+	Code    int32           `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Client 网关的 HTTP 客户端
+type Client struct {
+	server string
+	cookie string
+	http   *http.Client
+}
+
+func NewClient(server, cookie string) *Client {
+	return &Client{server: strings.TrimRight(server, "/"), cookie: cookie, http: &http.Client{}}
+}
+
+// Get 发送 GET 请求, 将响应的 data 解码到 out
+func (c *Client) Get(ctx context.Context, path string, query url.Values, out any) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.do(req, out)
+}
+
+// Post 以 JSON 发送 POST 请求, 将响应的 data 解码到 out
+func (c *Client) Post(ctx context.Context, path string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.do(req, out)
+}
+
+// PostCookies 以 JSON 发送 POST 请求, 返回响应设置的 cookie
+func (c *Client) PostCookies(ctx context.Context, path string, body any) ([]*http.Cookie, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := decode(resp, nil); err != nil {
+		return nil, err
+	}
+
+	return resp.Cookies(), nil
+}
+
+// PostFile 以表单上传 data, 文件字段为 file
+func (c *Client) PostFile(ctx context.Context, path string, fields map[string]string, filename string, data io.Reader, out any) error {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			return err
+		}
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return c.do(req, out)
+}
+
+// Download 下载文件 offset 之后的内容, 服务端不支持范围请求时 partial 为 false, 内容从头开始
+func (c *Client) Download(ctx context.Context, path string, offset int64) (body io.ReadCloser, partial bool, err error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	// 出错时网关仍以 200 返回 JSON, 以下载才有的响应头区分
+	if resp.Header.Get("Content-Description") != "File Transfer" {
+		defer resp.Body.Close()
+		if err := decode(resp, nil); err != nil {
+			return nil, false, err
+		}
+		return nil, false, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return resp.Body, resp.StatusCode == http.StatusPartialContent, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.cookie != "" {
+		req.AddCookie(&http.Cookie{Name: cookieName, Value: c.cookie})
+	}
+
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request, out any) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return decode(resp, out)
+}
+
+// decode 校验响应并将 data 解码到 out, out 为 *json.RawMessage 时保留原始内容
+func decode(resp *http.Response, out any) error {
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrNotLoggedIn
+	}
+
+	var env envelope
+	// 网关出错时可能连续写入两个 JSON 对象, 只取第一个
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("%s: %w", resp.Status, err)
+	}
+	if env.Code != 0 {
+		return &APIError{Code: env.Code, Message: env.Message}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	if out == nil || len(env.Data) == 0 || string(env.Data) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(env.Data, out)
+}
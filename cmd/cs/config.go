@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// defaultServer 网关的默认地址
+const defaultServer = "http://localhost:9091"
+
+// Config 保存在配置目录中的登录信息
+type Config struct {
+	Server string `json:"server"`
+	Cookie string `json:"cookie"` // 网关下发的 cloudstorage cookie
+}
+
+// configDir 配置目录, 可用 CS_CONFIG_DIR 指定, 默认为用户配置目录下的 cs
+func configDir() (string, error) {
+	if dir := os.Getenv("CS_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "cs"), nil
+}
+
+// loadConfig 读取配置, 配置不存在时返回空配置
+func loadConfig() (*Config, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// saveConfig 写入配置, 文件中有登录凭证, 只允许当前用户读写
+func saveConfig(cfg *Config) error {
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+
+	return writeState(filepath.Join(dir, "config.json"), cfg)
+}
+
+// readState 读取 JSON 状态文件, 文件不存在时返回 false
+func readState(path string, v any) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(data, v)
+}
+
+// writeState 先写临时文件再改名, 中途退出不会留下损坏的状态文件
+func writeState(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// 批量操作的条目类型, 与 file.BatchItemType 一致
+const (
+	batchFile   = 0
+	batchFolder = 1
+)
+
+// runLs 列出文件夹内容, 默认为根目录
+func runLs(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("ls", "[path]")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	path := "/"
+	if fs.NArg() > 0 {
+		path = fs.Arg(0)
+	}
+
+	var l Listing
+	if err := a.client.Get(ctx, "/api/files/path/list", url.Values{"path": {path}}, &l); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return a.output(l, func(w io.Writer) { printListing(w, l) })
+}
+
+// runMkdir 创建文件夹及路径上缺失的父文件夹
+func runMkdir(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("mkdir", "<path>...")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	folders := make([]Folder, 0, fs.NArg())
+	for _, path := range fs.Args() {
+		f, err := a.resolveFolder(ctx, path, true)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		folders = append(folders, f)
+	}
+
+	return a.output(folders, func(w io.Writer) {
+		for _, f := range folders {
+			fmt.Fprintf(w, "%d\t%s\n", f.Id, f.Path)
+		}
+	})
+}
+
+// runMv 目标为已有文件夹时将全部源移入其中, 否则将唯一的源移到目标的父文件夹并改名
+func runMv(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("mv", "[-policy error|rename|skip|overwrite] <path>... <dest>")
+	policy := fs.String("policy", "error", "what to do when the name is taken")
+	if err := parseFlags(fs, args, 2); err != nil {
+		return err
+	}
+	conflict, err := parsePolicy(*policy)
+	if err != nil {
+		return err
+	}
+	srcs, dest := fs.Args()[:fs.NArg()-1], fs.Arg(fs.NArg()-1)
+
+	items := make([]batchItem, 0, len(srcs))
+	for _, src := range srcs {
+		e, err := a.resolve(ctx, src)
+		if err != nil {
+			return err
+		}
+		items = append(items, e.batchItem())
+	}
+
+	target, err := a.resolve(ctx, dest)
+	var apiErr *APIError
+	switch {
+	case err == nil && target.Folder != nil:
+		res, err := a.batch(ctx, "/api/files/batch/move", batchRequest{Items: items, ToFolderId: target.Folder.Id, ConflictPolicy: conflict})
+		if err != nil {
+			return err
+		}
+		return a.output(res, func(w io.Writer) { printBatch(w, res) })
+	case err == nil:
+		return fmt.Errorf("%s: already exists", dest)
+	case !errors.As(err, &apiErr):
+		return err
+	}
+
+	// 目标不存在, 移动并改名
+	if len(items) != 1 {
+		return fmt.Errorf("%s: not a folder", dest)
+	}
+	parent, name, err := splitPath(dest)
+	if err != nil {
+		return err
+	}
+	folder, err := a.resolveFolder(ctx, parent, false)
+	if err != nil {
+		return err
+	}
+	res, err := a.batch(ctx, "/api/files/batch/move", batchRequest{Items: items, ToFolderId: folder.Id, ConflictPolicy: conflict})
+	if err != nil {
+		return err
+	}
+	if res.Results[0].Name != name {
+		items[0].NewName = name
+		if res, err = a.batch(ctx, "/api/files/batch/rename", batchRequest{Items: items, ConflictPolicy: conflict}); err != nil {
+			return err
+		}
+	}
+
+	return a.output(res, func(w io.Writer) { printBatch(w, res) })
+}
+
+// runRm 将文件或文件夹移入回收站
+func runRm(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("rm", "<path>...")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	for _, path := range fs.Args() {
+		if err := a.client.Post(ctx, "/api/files/path/delete", map[string]string{"path": path}, nil); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return a.output(fs.Args(), func(w io.Writer) {
+		for _, path := range fs.Args() {
+			fmt.Fprintf(w, "removed %s\n", path)
+		}
+	})
+}
+
+// runShare 为一个文件夹或多个文件创建分享链接
+func runShare(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("share", "[-days n] [-password p] [-max-downloads n] [-max-saves n] <path>...")
+	days := fs.Int("days", 7, "days until the link expires")
+	password := fs.String("password", "", "extraction password")
+	maxDownloads := fs.Int64("max-downloads", 0, "download limit, 0 for unlimited")
+	maxSaves := fs.Int64("max-saves", 0, "save limit, 0 for unlimited")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	req := struct {
+		FileIds      []int64 `json:"fileIds"`
+		FolderId     int64   `json:"folderId"`
+		ExpireDays   int     `json:"expireDays"`
+		Password     string  `json:"password"`
+		MaxDownloads int64   `json:"maxDownloads"`
+		MaxSaves     int64   `json:"maxSaves"`
+	}{ExpireDays: *days, Password: *password, MaxDownloads: *maxDownloads, MaxSaves: *maxSaves}
+	for _, path := range fs.Args() {
+		e, err := a.resolve(ctx, path)
+		if err != nil {
+			return err
+		}
+		if e.Folder != nil {
+			if fs.NArg() > 1 {
+				return fmt.Errorf("%s: a folder must be shared on its own", path)
+			}
+			req.FolderId = e.Folder.Id
+			continue
+		}
+		req.FileIds = append(req.FileIds, e.File.Id)
+	}
+
+	var resp struct {
+		ShareId  string `json:"share_id"`
+		ShareUrl string `json:"share_url"`
+		Password string `json:"password"`
+		ExpireAt int64  `json:"expire_at"`
+	}
+	if err := a.client.Post(ctx, "/api/files/share", req, &resp); err != nil {
+		return err
+	}
+
+	return a.output(resp, func(w io.Writer) {
+		fmt.Fprintf(w, "url\t%s\n", resp.ShareUrl)
+		if resp.Password != "" {
+			fmt.Fprintf(w, "password\t%s\n", resp.Password)
+		}
+		fmt.Fprintf(w, "expires\t%s\n", formatUnix(resp.ExpireAt))
+	})
+}
+
+// runSearch 按名称搜索文件和文件夹
+func runSearch(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("search", "[-page n] [-size n] <query>")
+	page := fs.Int("page", 1, "page number")
+	size := fs.Int("size", 50, "results per page")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	var l Listing
+	req := map[string]any{"query": strings.Join(fs.Args(), " "), "page": *page, "size": *size}
+	if err := a.client.Post(ctx, "/api/files/search", req, &l); err != nil {
+		return err
+	}
+
+	return a.output(l, func(w io.Writer) { printListing(w, l) })
+}
+
+// runQuota 显示存储空间的使用情况
+func runQuota(ctx context.Context, a *app, args []string) error {
+	if err := parseFlags(newFlags("quota", ""), args, 0); err != nil {
+		return err
+	}
+
+	var info struct {
+		FileStore struct {
+			Capacity    int64 `json:"capacity"`
+			CurrentSize int64 `json:"current_size"`
+			Reserved    int64 `json:"reserved"`
+			PlanId      int64 `json:"plan_id"`
+		} `json:"file_store"`
+	}
+	if err := a.client.Get(ctx, "/api/user/info", nil, &info); err != nil {
+		return err
+	}
+	q := info.FileStore
+
+	return a.output(q, func(w io.Writer) {
+		fmt.Fprintf(w, "used\t%s\n", formatSize(q.CurrentSize))
+		fmt.Fprintf(w, "reserved\t%s\n", formatSize(q.Reserved))
+		fmt.Fprintf(w, "free\t%s\n", formatSize(max(q.Capacity-q.CurrentSize-q.Reserved, 0)))
+		fmt.Fprintf(w, "capacity\t%s\n", formatSize(q.Capacity))
+	})
+}
+
+type batchItem struct {
+	Type    int32  `json:"type"`
+	Id      int64  `json:"id"`
+	NewName string `json:"newName,omitempty"`
+}
+
+type batchRequest struct {
+	Items          []batchItem `json:"items"`
+	Atomic         bool        `json:"atomic"`
+	ToFolderId     int64       `json:"toFolderID"`
+	ConflictPolicy int32       `json:"conflictPolicy"`
+}
+
+// batchResult 批量操作的结果, status 与 file.BatchItemStatus 一致, 0 为成功
+type batchResult struct {
+	Results []struct {
+		Type    int32  `json:"type"`
+		Id      int64  `json:"id"`
+		Status  int32  `json:"status"`
+		Message string `json:"message"`
+		Name    string `json:"name"`
+	} `json:"results"`
+	Succeeded int32 `json:"succeeded"`
+	Failed    int32 `json:"failed"`
+}
+
+// batch 以全部成功或全部回滚的方式执行批量操作, 有条目失败时返回第一个失败的原因
+func (a *app) batch(ctx context.Context, path string, req batchRequest) (batchResult, error) {
+	req.Atomic = true
+	var res batchResult
+	if err := a.client.Post(ctx, path, req, &res); err != nil {
+		return res, err
+	}
+	for _, r := range res.Results {
+		if r.Status != 0 {
+			return res, errors.New(r.Message)
+		}
+	}
+	if len(res.Results) != len(req.Items) {
+		return res, fmt.Errorf("%d of %d items processed", len(res.Results), len(req.Items))
+	}
+
+	return res, nil
+}
+
+func (e Entry) batchItem() batchItem {
+	if e.Folder != nil {
+		return batchItem{Type: batchFolder, Id: e.Folder.Id}
+	}
+	return batchItem{Type: batchFile, Id: e.File.Id}
+}
+
+// parsePolicy 同名处理策略, 与 file.NameConflictPolicy 一致
+func parsePolicy(s string) (int32, error) {
+	switch s {
+	case "error":
+		return 0, nil
+	case "rename":
+		return 1, nil
+	case "skip":
+		return 2, nil
+	case "overwrite":
+		return 3, nil
+	}
+	return 0, fmt.Errorf("unknown conflict policy %q", s)
+}
+
+func printListing(w io.Writer, l Listing) {
+	for _, f := range l.Folders {
+		fmt.Fprintf(w, "%d\t-\t%s\t%s/\n", f.Id, f.Utime, f.Name)
+	}
+	for _, f := range l.Files {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", f.Id, formatSize(f.Size), f.Utime, f.Name)
+	}
+}
+
+func printBatch(w io.Writer, res batchResult) {
+	for _, r := range res.Results {
+		fmt.Fprintf(w, "%d\t%s\n", r.Id, r.Name)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// downloadAttempts 下载中断后自动续传的次数上限
+const downloadAttempts = 3
+
+// downloadState 未完成的下载, 与 .cspart 文件放在一起, 远程文件变化后不再续传
+type downloadState struct {
+	FileId  int64  `json:"fileId"`
+	Version int32  `json:"version"`
+	Size    int64  `json:"size"`
+	Sha256  string `json:"sha256"`
+}
+
+// getResult 下载的结果
+type getResult struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	Resumed int64  `json:"resumed,omitempty"` // 续传时已下载的字节数
+}
+
+// runGet 下载文件, 中断后再次执行同一命令从已下载的位置续传
+func runGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("get", "<path> [local]")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+	remote := fs.Arg(0)
+
+	e, err := a.resolve(ctx, remote)
+	if err != nil {
+		return err
+	}
+	if e.File == nil {
+		return fmt.Errorf("%s: is a folder", remote)
+	}
+	f := e.File
+
+	local := f.Name
+	if fs.NArg() > 1 {
+		local = fs.Arg(1)
+		if info, err := os.Stat(local); err == nil && info.IsDir() {
+			local = filepath.Join(local, f.Name)
+		}
+	}
+
+	res, err := a.download(ctx, f, local)
+	if err != nil {
+		return err
+	}
+
+	return a.output(res, func(w io.Writer) {
+		fmt.Fprintf(w, "downloaded %s (%s)\n", res.Path, formatSize(res.Size))
+	})
+}
+
+// download 先写入 <local>.cspart, 完成后改名为 local
+func (a *app) download(ctx context.Context, f *File, local string) (getResult, error) {
+	partPath := local + ".cspart"
+	statePath := partPath + ".json"
+	want := downloadState{FileId: f.Id, Version: f.Version, Size: f.Size, Sha256: f.Sha256}
+
+	var offset int64
+	var prev downloadState
+	ok, err := readState(statePath, &prev)
+	if err != nil {
+		return getResult{}, err
+	}
+	if ok && prev == want {
+		if info, err := os.Stat(partPath); err == nil && info.Size() <= f.Size {
+			offset = info.Size()
+		}
+	} else if err := writeState(statePath, want); err != nil {
+		return getResult{}, err
+	}
+	res := getResult{Path: local, Size: f.Size, Resumed: offset}
+
+	out, err := os.OpenFile(partPath, os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return res, err
+	}
+	defer out.Close()
+	if err := out.Truncate(offset); err != nil {
+		return res, err
+	}
+
+	path := "/api/files/download/" + strconv.FormatInt(f.Id, 10)
+	for attempt := 0; offset < f.Size || attempt == 0; attempt++ {
+		if attempt > 0 {
+			if attempt >= downloadAttempts {
+				return res, fmt.Errorf("download interrupted at %d of %d bytes: %w", offset, f.Size, err)
+			}
+			select {
+			case <-time.After(time.Duration(attempt) * time.Second):
+			case <-ctx.Done():
+				return res, ctx.Err()
+			}
+		}
+		offset, err = a.fetch(ctx, path, out, offset)
+		if err == nil {
+			if offset >= f.Size {
+				break
+			}
+			err = io.ErrUnexpectedEOF
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) || errors.Is(err, ErrNotLoggedIn) || ctx.Err() != nil {
+			return res, err
+		}
+	}
+
+	if offset != f.Size {
+		return res, fmt.Errorf("downloaded %d bytes, expected %d", offset, f.Size)
+	}
+	if err := out.Close(); err != nil {
+		return res, err
+	}
+	// 续传的内容可能跨越了服务端的修改, 与文件的 SHA-256 比对, 不一致时丢弃重新下载
+	if err := verifySha256(partPath, f.Sha256); err != nil {
+		os.Remove(partPath)
+		os.Remove(statePath)
+		return res, fmt.Errorf("%s: %w, run the command again to download it from the start", local, err)
+	}
+	if err := os.Rename(partPath, local); err != nil {
+		return res, err
+	}
+
+	return res, os.Remove(statePath)
+}
+
+// fetch 从 offset 处下载并写入 out, 返回已写入的总字节数
+// 服务端忽略范围请求时从头写入
+func (a *app) fetch(ctx context.Context, path string, out *os.File, offset int64) (int64, error) {
+	body, partial, err := a.client.Download(ctx, path, offset)
+	if err != nil {
+		return offset, err
+	}
+	defer body.Close()
+
+	if offset > 0 && !partial {
+		offset = 0
+		if err := out.Truncate(0); err != nil {
+			return 0, err
+		}
+	}
+	n, err := io.Copy(io.NewOffsetWriter(out, offset), body)
+
+	return offset + n, err
+}
+
+// verifySha256 校验文件内容的 SHA-256, 服务端还没有计算摘要时 want 为空, 不做校验
+func verifySha256(path, want string) error {
+	if want == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, want) {
+		return fmt.Errorf("sha256 mismatch: got %s, want %s", got, want)
+	}
+
+	return nil
+}
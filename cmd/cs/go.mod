@@ -0,0 +1,3 @@
+module github.com/crazyfrankie/cloudstorage/cmd/cs
+
+go 1.23.6
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// runLogin 按短信验证码登录, 未注册的手机号会自动注册, 登录凭证保存在配置目录中
+func runLogin(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("login", "[-code code] <phone>")
+	code := fs.String("code", "", "verification code, prompted for when empty")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+	phone := fs.Arg(0)
+
+	var sent struct {
+		Biz string `json:"biz"`
+	}
+	if err := a.client.Post(ctx, "/api/user/send-code", map[string]string{"phone": phone}, &sent); err != nil {
+		return err
+	}
+
+	if *code == "" {
+		fmt.Fprintf(a.stderr, "code sent to %s, enter it: ", phone)
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		*code = strings.TrimSpace(line)
+	}
+
+	cookie, err := a.verifyCode(ctx, phone, *code, sent.Biz)
+	if err != nil {
+		return err
+	}
+	a.cfg.Cookie = cookie
+	if err := saveConfig(a.cfg); err != nil {
+		return err
+	}
+
+	return a.output(map[string]string{"phone": phone, "biz": sent.Biz, "server": a.cfg.Server}, func(w io.Writer) {
+		fmt.Fprintf(w, "logged in to %s as %s\n", a.cfg.Server, phone)
+	})
+}
+
+// verifyCode 校验验证码, 返回网关下发的登录 cookie
+func (a *app) verifyCode(ctx context.Context, phone, code, biz string) (string, error) {
+	cookies, err := a.client.PostCookies(ctx, "/api/user/verify-code", map[string]string{
+		"phone": phone,
+		"code":  code,
+		"biz":   biz,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, ck := range cookies {
+		if ck.Name == cookieName && ck.Value != "" {
+			return ck.Value, nil
+		}
+	}
+
+	return "", errors.New("gateway did not return a login cookie")
+}
+
+// runLogout 删除保存的登录凭证
+func runLogout(ctx context.Context, a *app, args []string) error {
+	if err := parseFlags(newFlags("logout", ""), args, 0); err != nil {
+		return err
+	}
+	a.cfg.Cookie = ""
+
+	return saveConfig(a.cfg)
+}
@@ -0,0 +1,169 @@
+// cs 是网盘的命令行客户端, 通过网关的 HTTP 接口操作文件
+//
+//	cs [-server URL] [-json] <command> [arguments]
+//
+// 远程路径以 / 分隔, 名称中的 / 和 \ 需以 \ 转义; -json 时输出网关返回的 JSON, 便于脚本处理
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+)
+
+// command 一个子命令
+type command struct {
+	name  string
+	short string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = []command{
+	{"login", "log in with an SMS code", runLogin},
+	{"logout", "forget the stored login", runLogout},
+	{"ls", "list a folder", runLs},
+	{"mkdir", "create folders and missing parents", runMkdir},
+	{"put", "upload a file, resuming an interrupted chunked upload", runPut},
+	{"get", "download a file, resuming a partial download", runGet},
+	{"mv", "move into a folder, or move and rename one item", runMv},
+	{"rm", "move files or folders to the recycle bin", runRm},
+	{"share", "create a share link", runShare},
+	{"search", "search files and folders by name", runSearch},
+	{"quota", "show storage usage", runQuota},
+}
+
+// app 子命令共用的状态
+type app struct {
+	cfg    *Config
+	client *Client
+	json   bool
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	fs := flag.NewFlagSet("cs", flag.ContinueOnError)
+	server := fs.String("server", "", "gateway address, defaults to the stored one or $CS_SERVER")
+	jsonOut := fs.Bool("json", false, "print JSON for scripts")
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		usage(fs)
+		return 2
+	}
+
+	cmd, ok := findCommand(fs.Arg(0))
+	if !ok {
+		fmt.Fprintf(os.Stderr, "cs: unknown command %q\n", fs.Arg(0))
+		usage(fs)
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cs: failed to load config: %v\n", err)
+		return 1
+	}
+	if *server == "" {
+		*server = os.Getenv("CS_SERVER")
+	}
+	if *server == "" {
+		*server = cfg.Server
+	}
+	if *server == "" {
+		*server = defaultServer
+	}
+	cfg.Server = *server
+
+	a := &app{
+		cfg:    cfg,
+		client: NewClient(cfg.Server, cfg.Cookie),
+		json:   *jsonOut,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+
+	// 中断时取消请求, 未完成的上传和下载可再次执行同一命令续传
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := cmd.run(ctx, a, fs.Args()[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(os.Stderr, "cs %s: %v\n", cmd.name, err)
+		return 1
+	}
+
+	return 0
+}
+
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "usage: cs [-server URL] [-json] <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.name, c.short)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "flags:")
+	fs.PrintDefaults()
+}
+
+// newFlags 子命令的参数, synopsis 为用法中命令名之后的部分
+func newFlags(name, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet("cs "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: cs %s %s\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags 解析子命令的参数, 位置参数少于 min 时打印用法并返回 flag.ErrHelp
+func parseFlags(fs *flag.FlagSet, args []string, min int) error {
+	if err := fs.Parse(args); err != nil {
+		return flag.ErrHelp
+	}
+	if fs.NArg() < min {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	return nil
+}
+
+// output 按输出模式打印 data, 非 JSON 模式下调用 human 以表格打印
+func (a *app) output(data any, human func(w io.Writer)) error {
+	if a.json {
+		return json.NewEncoder(a.stdout).Encode(data)
+	}
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	human(tw)
+
+	return tw.Flush()
+}
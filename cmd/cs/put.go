@@ -0,0 +1,358 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// smallUploadLimit 不超过该大小的文件一次上传, 与网关的 consts.SmallFileSizeLimit 一致
+	smallUploadLimit = 50 << 20
+	// minPartSize 对象存储要求除最后一个分片外的分片不小于 5MB
+	minPartSize = 5 << 20
+	// partAttempts 单个分片失败后的重试次数上限
+	partAttempts = 3
+)
+
+// uploadState 未完成的分片上传, 再次上传同一文件时据此续传
+type uploadState struct {
+	UploadId string `json:"uploadId"`
+	Filename string `json:"filename"`
+	FolderId int64  `json:"folderId"`
+	Size     int64  `json:"size"`
+	ModTime  int64  `json:"modTime"`
+	PartSize int64  `json:"partSize"`
+}
+
+// putResult 上传的结果
+type putResult struct {
+	Name     string `json:"name"`
+	FolderId int64  `json:"folderId"`
+	Size     int64  `json:"size"`
+	Parts    int    `json:"parts,omitempty"`
+	Resumed  int    `json:"resumed,omitempty"` // 续传时跳过的已上传分片数
+	Sha256   string `json:"sha256,omitempty"`
+	Skipped  bool   `json:"skipped,omitempty"`
+}
+
+// runPut 上传文件, 小文件一次上传, 大文件分片并行上传, 中断后再次执行同一命令续传
+func runPut(ctx context.Context, a *app, args []string) error {
+	fs := newFlags("put", "[-parallel n] [-part-size bytes] [-chunked] [-policy p] [-p] <local> [folder]")
+	parallel := fs.Int("parallel", 4, "parts uploaded at the same time")
+	partSize := fs.Int64("part-size", 8<<20, "part size of chunked uploads, at least 5MB")
+	policy := fs.String("policy", "error", "what to do when the name is taken: error, rename, skip or overwrite")
+	parents := fs.Bool("p", false, "create the folder and missing parents")
+	chunked := fs.Bool("chunked", false, "use a chunked upload regardless of the size")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+	conflict, err := parsePolicy(*policy)
+	if err != nil {
+		return err
+	}
+	if *partSize < minPartSize {
+		return fmt.Errorf("part size must be at least %d bytes", minPartSize)
+	}
+	if *parallel < 1 {
+		*parallel = 1
+	}
+
+	local := fs.Arg(0)
+	remote := "/"
+	if fs.NArg() > 1 {
+		remote = fs.Arg(1)
+	}
+
+	f, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", local)
+	}
+
+	folder, err := a.resolveFolder(ctx, remote, *parents)
+	if err != nil {
+		return err
+	}
+
+	var res putResult
+	if info.Size() <= smallUploadLimit && !*chunked {
+		res, err = a.putSmall(ctx, f, info, folder.Id, conflict)
+	} else {
+		u := &uploader{a: a, f: f, info: info, folderId: folder.Id, partSize: *partSize, parallel: *parallel, policy: conflict}
+		res, err = u.run(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	return a.output(res, func(w io.Writer) {
+		if res.Skipped {
+			fmt.Fprintf(w, "skipped %s, the name is taken\n", joinPath(remote, info.Name()))
+			return
+		}
+		fmt.Fprintf(w, "uploaded %s (%s)\n", joinPath(remote, res.Name), formatSize(res.Size))
+	})
+}
+
+// putSmall 一次上传整个文件, 附带文件的 SHA-256 由服务端校验
+func (a *app) putSmall(ctx context.Context, f *os.File, info os.FileInfo, folderId int64, policy int32) (putResult, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return putResult{}, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return putResult{}, err
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	var resp struct {
+		Id      int64  `json:"id"`
+		Name    string `json:"name"`
+		Skipped bool   `json:"skipped"`
+		Sha256  string `json:"sha256"`
+	}
+	err := a.client.PostFile(ctx, "/api/files/upload", map[string]string{
+		"folder":         strconv.FormatInt(folderId, 10),
+		"conflictPolicy": strconv.Itoa(int(policy)),
+		"hash":           sum,
+	}, info.Name(), f, &resp)
+	if err != nil {
+		return putResult{}, err
+	}
+
+	return putResult{Name: resp.Name, FolderId: folderId, Size: info.Size(), Sha256: resp.Sha256, Skipped: resp.Skipped}, nil
+}
+
+// uploader 一次分片上传
+// 第一个分片开始上传并确定分片大小, 中间的分片并行上传, 最后一个分片在其余分片都完成后发送并完成上传
+type uploader struct {
+	a        *app
+	f        *os.File
+	info     os.FileInfo
+	folderId int64
+	partSize int64
+	parallel int
+	policy   int32
+
+	state  uploadState
+	sha256 string
+}
+
+func (u *uploader) run(ctx context.Context) (putResult, error) {
+	statePath, err := u.stateFile()
+	if err != nil {
+		return putResult{}, err
+	}
+
+	// 上一次上传未完成时续传, 已上传的分片不再发送
+	done := make(map[int32]bool)
+	ok, err := readState(statePath, &u.state)
+	if err != nil {
+		return putResult{}, err
+	}
+	if ok {
+		var parts struct {
+			PartNumbers []int32 `json:"part_numbers"`
+		}
+		err := u.a.client.Get(ctx, "/api/files/upload/"+u.state.UploadId+"/parts", nil, &parts)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			// 上传已过期或被中止, 重新开始
+			fmt.Fprintf(u.a.stderr, "previous upload of %s is gone, starting over\n", u.info.Name())
+			ok = false
+		} else if err != nil {
+			return putResult{}, err
+		}
+		for _, p := range parts.PartNumbers {
+			done[p] = true
+		}
+	}
+	if !ok {
+		u.state = uploadState{
+			Filename: u.info.Name(),
+			FolderId: u.folderId,
+			Size:     u.info.Size(),
+			ModTime:  u.info.ModTime().UnixNano(),
+			PartSize: u.partSize,
+		}
+		done = make(map[int32]bool)
+	}
+
+	total := int32((u.state.Size + u.state.PartSize - 1) / u.state.PartSize)
+	if total == 0 {
+		total = 1
+	}
+	res := putResult{Name: u.state.Filename, FolderId: u.state.FolderId, Size: u.state.Size, Parts: int(total), Resumed: len(done)}
+
+	// 最后一个分片附带整个文件的 SHA-256, 由服务端在完成上传时校验
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(u.f, 0, u.state.Size)); err != nil {
+		return res, err
+	}
+	u.sha256 = hex.EncodeToString(h.Sum(nil))
+
+	if u.state.UploadId == "" {
+		resp, err := u.uploadPart(ctx, 1, total == 1)
+		if err != nil {
+			return res, err
+		}
+		u.state.UploadId = resp.UploadId
+		if total == 1 {
+			res.Name, res.Sha256 = resp.Name, resp.Sha256
+			return res, nil
+		}
+		if err := writeState(statePath, u.state); err != nil {
+			return res, err
+		}
+		done[1] = true
+	}
+
+	if err := u.uploadMiddle(ctx, total, done); err != nil {
+		return res, err
+	}
+
+	resp, err := u.uploadPart(ctx, total, true)
+	if err != nil {
+		return res, err
+	}
+	res.Name, res.Sha256 = resp.Name, resp.Sha256
+	if err := os.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// uploadMiddle 并行上传第一个和最后一个分片之间未上传的分片, 任一分片失败时停止发送新的分片
+func (u *uploader) uploadMiddle(ctx context.Context, total int32, done map[int32]bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	parts := make(chan int32)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for i := 0; i < u.parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range parts {
+				if _, err := u.uploadPart(ctx, p, false); err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("part %d: %w", p, err)
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	for p := int32(2); p < total; p++ {
+		if done[p] {
+			continue
+		}
+		select {
+		case parts <- p:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(parts)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+type partResponse struct {
+	UploadId string `json:"upload_id"`
+	Name     string `json:"name"`
+	Sha256   string `json:"sha256"`
+}
+
+// uploadPart 上传一个分片, 失败时按退避重试
+func (u *uploader) uploadPart(ctx context.Context, part int32, last bool) (partResponse, error) {
+	offset := int64(part-1) * u.state.PartSize
+	size := min(u.state.PartSize, u.state.Size-offset)
+	data := make([]byte, size)
+	if _, err := u.f.ReadAt(data, offset); err != nil && !errors.Is(err, io.EOF) {
+		return partResponse{}, err
+	}
+
+	fields := map[string]string{
+		"filename":       u.state.Filename,
+		"uploadId":       u.state.UploadId,
+		"partNumber":     strconv.Itoa(int(part)),
+		"fileSize":       strconv.FormatInt(u.state.Size, 10),
+		"folder":         strconv.FormatInt(u.state.FolderId, 10),
+		"isLast":         strconv.FormatBool(last),
+		"conflictPolicy": strconv.Itoa(int(u.policy)),
+	}
+	if last {
+		fields["hash"] = u.sha256
+	}
+
+	var (
+		resp partResponse
+		err  error
+	)
+	for attempt := 0; attempt < partAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * time.Second):
+			case <-ctx.Done():
+				return resp, ctx.Err()
+			}
+		}
+		if err = u.a.client.PostFile(ctx, "/api/files/upload/part", fields, u.state.Filename, bytes.NewReader(data), &resp); err == nil {
+			return resp, nil
+		}
+		// 业务错误重试也不会成功
+		var apiErr *APIError
+		if errors.As(err, &apiErr) || errors.Is(err, ErrNotLoggedIn) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	return resp, err
+}
+
+// stateFile 续传状态的路径, 由本地文件的绝对路径、大小和修改时间以及目标文件夹确定, 文件变化后不会续传
+func (u *uploader) stateFile() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(u.f.Name())
+	if err != nil {
+		return "", err
+	}
+	key := fmt.Sprintf("%s|%s|%d|%d|%d|%d", u.a.cfg.Server, abs, u.info.Size(), u.info.ModTime().UnixNano(), u.folderId, u.partSize)
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(dir, "uploads", hex.EncodeToString(sum[:16])+".json"), nil
+}
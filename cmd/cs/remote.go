@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// File 网关返回的文件
+type File struct {
+	Id       int64  `json:"id"`
+	Name     string `json:"name"`
+	FolderId int64  `json:"folder_id"`
+	Size     int64  `json:"size"`
+	Type     string `json:"type"`
+	Utime    string `json:"utime"`
+	Version  int32  `json:"version"`
+	Sha256   string `json:"sha256"`
+}
+
+// Folder 网关返回的文件夹, 根目录的 ID 为 0
+type Folder struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	ParentId  int64  `json:"parent_id"`
+	Path      string `json:"path"`
+	Utime     string `json:"utime"`
+	TotalSize int64  `json:"total_size"`
+	FileCount int64  `json:"file_count"`
+}
+
+// Entry 按路径解析的结果, File 和 Folder 只有一个非空
+type Entry struct {
+	File   *File   `json:"file"`
+	Folder *Folder `json:"folder"`
+}
+
+// Listing 文件夹内容或搜索结果
+type Listing struct {
+	Folders []Folder `json:"folders"`
+	Files   []File   `json:"files"`
+}
+
+// resolve 按路径获取文件或文件夹
+func (a *app) resolve(ctx context.Context, path string) (Entry, error) {
+	var e Entry
+	if err := a.client.Get(ctx, "/api/files/path", url.Values{"path": {path}}, &e); err != nil {
+		return Entry{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if e.File == nil && e.Folder == nil {
+		// 根目录的各字段均为零值, 网关返回空的文件夹
+		e.Folder = &Folder{}
+	}
+
+	return e, nil
+}
+
+// resolveFolder 按路径获取文件夹, create 为 true 时创建缺失的文件夹
+func (a *app) resolveFolder(ctx context.Context, path string, create bool) (Folder, error) {
+	if create {
+		var resp struct {
+			Folder Folder `json:"folder"`
+		}
+		err := a.client.Post(ctx, "/api/files/path/mkdir", map[string]string{"path": path}, &resp)
+		return resp.Folder, err
+	}
+
+	e, err := a.resolve(ctx, path)
+	if err != nil {
+		return Folder{}, err
+	}
+	if e.Folder == nil {
+		return Folder{}, fmt.Errorf("%s: not a folder", path)
+	}
+
+	return *e.Folder, nil
+}
+
+// splitPath 将远程路径拆分为父路径和未转义的名称, 名称中转义的 / 不作为分隔符
+func splitPath(path string) (parent, name string, err error) {
+	path = strings.TrimRight(path, "/")
+	sep := -1
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			i++
+		case '/':
+			sep = i
+		}
+	}
+	parent, name = "/", path[sep+1:]
+	if sep > 0 {
+		parent = path[:sep]
+	}
+	if name == "" {
+		return "", "", errors.New("path has no name")
+	}
+
+	return parent, unescapeName(name), nil
+}
+
+// escapeName 转义名称中的 / 和 \, 用于拼接远程路径
+func escapeName(name string) string {
+	return strings.NewReplacer(`\`, `\\`, `/`, `\/`).Replace(name)
+}
+
+func unescapeName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+1 < len(name) {
+			i++
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
+
+// joinPath 在远程文件夹路径后拼接名称
+func joinPath(dir, name string) string {
+	return strings.TrimRight(dir, "/") + "/" + escapeName(name)
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGateway 模拟网关的分片上传和下载接口
+type fakeGateway struct {
+	mu       sync.Mutex
+	content  []byte // 下载接口返回的内容
+	sha256   string // 按路径解析时返回的摘要
+	parts    map[int][]byte
+	failOnce map[int]bool // 第一次上传这些分片时返回 500
+	uploaded []byte       // 完成上传后合并的内容
+	hash     string       // 最后一个分片附带的哈希
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ok := func(v any) { json.NewEncoder(w).Encode(map[string]any{"code": 0, "data": v}) }
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	switch {
+	case r.URL.Path == "/api/files/path" && r.URL.Query().Get("path") == "/remote":
+		ok(map[string]any{"file": map[string]any{"id": 7, "name": "remote", "size": len(g.content), "sha256": g.sha256}})
+	case r.URL.Path == "/api/files/path":
+		ok(map[string]any{})
+	case r.URL.Path == "/api/files/upload/u1/parts":
+		var numbers []int
+		for n := range g.parts {
+			numbers = append(numbers, n)
+		}
+		ok(map[string]any{"part_numbers": numbers})
+	case r.URL.Path == "/api/files/upload/part":
+		if err := r.ParseMultipartForm(64 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		n, _ := strconv.Atoi(r.FormValue("partNumber"))
+		if g.failOnce[n] {
+			delete(g.failOnce, n)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f, _, _ := r.FormFile("file")
+		data, _ := io.ReadAll(f)
+		g.parts[n] = data
+		if r.FormValue("isLast") == "true" {
+			g.hash = r.FormValue("hash")
+			for i := 1; i <= n; i++ {
+				g.uploaded = append(g.uploaded, g.parts[i]...)
+			}
+		}
+		ok(map[string]any{"upload_id": "u1", "name": r.FormValue("filename")})
+	case r.URL.Path == "/api/files/download/7":
+		w.Header().Set("Content-Description", "File Transfer")
+		var offset int
+		fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &offset)
+		if offset > 0 {
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.Write(g.content[offset:])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestApp(t *testing.T, g *fakeGateway) *app {
+	t.Helper()
+	srv := httptest.NewServer(g)
+	t.Cleanup(srv.Close)
+	t.Setenv("CS_CONFIG_DIR", t.TempDir())
+
+	return &app{cfg: &Config{Server: srv.URL}, client: NewClient(srv.URL, ""), stdout: io.Discard, stderr: io.Discard}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPutChunkedRetriesAndResumes(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1_300_000)
+	g := &fakeGateway{parts: make(map[int][]byte), failOnce: map[int]bool{2: true}}
+	a := newTestApp(t, g)
+	local := filepath.Join(t.TempDir(), "big")
+	if err := os.WriteFile(local, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 上一次上传已完成前两个分片
+	u := &uploader{a: a, info: mustStat(t, local), partSize: minPartSize}
+	u.f, _ = os.Open(local)
+	defer u.f.Close()
+	statePath, err := u.stateFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := uploadState{UploadId: "u1", Filename: "big", Size: int64(len(content)), PartSize: minPartSize}
+	if err := writeState(statePath, state); err != nil {
+		t.Fatal(err)
+	}
+	g.parts[1] = content[:minPartSize]
+
+	if err := runPut(context.Background(), a, []string{"-chunked", "-part-size", strconv.Itoa(minPartSize), local}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(g.uploaded, content) {
+		t.Fatalf("uploaded %d bytes, want the %d byte file", len(g.uploaded), len(content))
+	}
+	if g.hash != sha256Hex(content) {
+		t.Fatalf("last part carried hash %q, want the file's SHA-256", g.hash)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Fatalf("resume state left behind: %v", err)
+	}
+}
+
+func TestGetResumesFromPartialFile(t *testing.T) {
+	content := bytes.Repeat([]byte("abcdefghij"), 100_000)
+	a := newTestApp(t, &fakeGateway{content: content, sha256: sha256Hex(content)})
+	out := filepath.Join(t.TempDir(), "out")
+
+	// 上一次下载中断在第 1000 个字节
+	if err := os.WriteFile(out+".cspart", content[:1000], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeState(out+".cspart.json", downloadState{FileId: 7, Size: int64(len(content)), Sha256: sha256Hex(content)}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := a.download(context.Background(), &File{Id: 7, Name: "remote", Size: int64(len(content)), Sha256: sha256Hex(content)}, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Resumed != 1000 {
+		t.Fatalf("resumed from %d, want 1000", res.Resumed)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatal("downloaded content differs")
+	}
+}
+
+func TestGetRejectsSplicedContent(t *testing.T) {
+	content := bytes.Repeat([]byte("abcdefghij"), 100_000)
+	a := newTestApp(t, &fakeGateway{content: content, sha256: sha256Hex(content)})
+	out := filepath.Join(t.TempDir(), "out")
+
+	// 已下载的部分来自服务端修改前的内容
+	stale := bytes.Repeat([]byte("z"), 1000)
+	if err := os.WriteFile(out+".cspart", stale, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeState(out+".cspart.json", downloadState{FileId: 7, Size: int64(len(content)), Sha256: sha256Hex(content)}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := a.download(context.Background(), &File{Id: 7, Name: "remote", Size: int64(len(content)), Sha256: sha256Hex(content)}, out)
+	if err == nil || !strings.Contains(err.Error(), "sha256 mismatch") {
+		t.Fatalf("got %v, want a sha256 mismatch", err)
+	}
+	for _, p := range []string{out, out + ".cspart", out + ".cspart.json"} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("%s should not exist: %v", p, err)
+		}
+	}
+}
+
+func mustStat(t *testing.T, path string) os.FileInfo {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
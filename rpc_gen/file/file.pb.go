@@ -125,34 +125,9928 @@ func (ChangeOperation) EnumDescriptor() ([]byte, []int) {
 	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{1}
 }
 
+// 目标文件夹存在同名文件(夹)时的处理策略
+type NameConflictPolicy int32
+
+const (
+	NameConflictPolicy_NAME_CONFLICT_FAIL      NameConflictPolicy = 0 // 报错
+	NameConflictPolicy_NAME_CONFLICT_RENAME    NameConflictPolicy = 1 // 自动重命名为 name (1).ext
+	NameConflictPolicy_NAME_CONFLICT_SKIP      NameConflictPolicy = 2 // 跳过
+	NameConflictPolicy_NAME_CONFLICT_OVERWRITE NameConflictPolicy = 3 // 覆盖同名文件, 旧内容保留为历史版本, 仅适用于文件
+)
+
+// Enum value maps for NameConflictPolicy.
+var (
+	NameConflictPolicy_name = map[int32]string{
+		0: "NAME_CONFLICT_FAIL",
+		1: "NAME_CONFLICT_RENAME",
+		2: "NAME_CONFLICT_SKIP",
+		3: "NAME_CONFLICT_OVERWRITE",
+	}
+	NameConflictPolicy_value = map[string]int32{
+		"NAME_CONFLICT_FAIL":      0,
+		"NAME_CONFLICT_RENAME":    1,
+		"NAME_CONFLICT_SKIP":      2,
+		"NAME_CONFLICT_OVERWRITE": 3,
+	}
+)
+
+func (x NameConflictPolicy) Enum() *NameConflictPolicy {
+	p := new(NameConflictPolicy)
+	*p = x
+	return p
+}
+
+func (x NameConflictPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NameConflictPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[2].Descriptor()
+}
+
+func (NameConflictPolicy) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[2]
+}
+
+func (x NameConflictPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NameConflictPolicy.Descriptor instead.
+func (NameConflictPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{2}
+}
+
+type BatchMode int32
+
+const (
+	BatchMode_BATCH_BEST_EFFORT BatchMode = 0 // 逐条执行, 失败的条目不影响其它条目
+	BatchMode_BATCH_ATOMIC      BatchMode = 1 // 全部成功或全部回滚
+)
+
+// Enum value maps for BatchMode.
+var (
+	BatchMode_name = map[int32]string{
+		0: "BATCH_BEST_EFFORT",
+		1: "BATCH_ATOMIC",
+	}
+	BatchMode_value = map[string]int32{
+		"BATCH_BEST_EFFORT": 0,
+		"BATCH_ATOMIC":      1,
+	}
+)
+
+func (x BatchMode) Enum() *BatchMode {
+	p := new(BatchMode)
+	*p = x
+	return p
+}
+
+func (x BatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[3].Descriptor()
+}
+
+func (BatchMode) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[3]
+}
+
+func (x BatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchMode.Descriptor instead.
+func (BatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{3}
+}
+
+type BatchItemType int32
+
+const (
+	BatchItemType_BATCH_ITEM_FILE   BatchItemType = 0
+	BatchItemType_BATCH_ITEM_FOLDER BatchItemType = 1
+)
+
+// Enum value maps for BatchItemType.
+var (
+	BatchItemType_name = map[int32]string{
+		0: "BATCH_ITEM_FILE",
+		1: "BATCH_ITEM_FOLDER",
+	}
+	BatchItemType_value = map[string]int32{
+		"BATCH_ITEM_FILE":   0,
+		"BATCH_ITEM_FOLDER": 1,
+	}
+)
+
+func (x BatchItemType) Enum() *BatchItemType {
+	p := new(BatchItemType)
+	*p = x
+	return p
+}
+
+func (x BatchItemType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchItemType) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[4].Descriptor()
+}
+
+func (BatchItemType) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[4]
+}
+
+func (x BatchItemType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchItemType.Descriptor instead.
+func (BatchItemType) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{4}
+}
+
+type BatchItemStatus int32
+
+const (
+	BatchItemStatus_BATCH_ITEM_OK        BatchItemStatus = 0
+	BatchItemStatus_BATCH_ITEM_NOT_FOUND BatchItemStatus = 1
+	BatchItemStatus_BATCH_ITEM_CONFLICT  BatchItemStatus = 2 // 目标文件夹存在同名条目
+	BatchItemStatus_BATCH_ITEM_INVALID   BatchItemStatus = 3 // 非法操作, 如移动到自身子文件夹
+	BatchItemStatus_BATCH_ITEM_NO_SPACE  BatchItemStatus = 4 // 容量不足
+	BatchItemStatus_BATCH_ITEM_FAILED    BatchItemStatus = 5 // 其它错误
+	BatchItemStatus_BATCH_ITEM_SKIPPED   BatchItemStatus = 6 // 按同名策略跳过
+	BatchItemStatus_BATCH_ITEM_ABORTED   BatchItemStatus = 7 // 原子模式下因其它条目失败而回滚
+	BatchItemStatus_BATCH_ITEM_FORBIDDEN BatchItemStatus = 8 // 没有操作该条目的权限
+)
+
+// Enum value maps for BatchItemStatus.
+var (
+	BatchItemStatus_name = map[int32]string{
+		0: "BATCH_ITEM_OK",
+		1: "BATCH_ITEM_NOT_FOUND",
+		2: "BATCH_ITEM_CONFLICT",
+		3: "BATCH_ITEM_INVALID",
+		4: "BATCH_ITEM_NO_SPACE",
+		5: "BATCH_ITEM_FAILED",
+		6: "BATCH_ITEM_SKIPPED",
+		7: "BATCH_ITEM_ABORTED",
+		8: "BATCH_ITEM_FORBIDDEN",
+	}
+	BatchItemStatus_value = map[string]int32{
+		"BATCH_ITEM_OK":        0,
+		"BATCH_ITEM_NOT_FOUND": 1,
+		"BATCH_ITEM_CONFLICT":  2,
+		"BATCH_ITEM_INVALID":   3,
+		"BATCH_ITEM_NO_SPACE":  4,
+		"BATCH_ITEM_FAILED":    5,
+		"BATCH_ITEM_SKIPPED":   6,
+		"BATCH_ITEM_ABORTED":   7,
+		"BATCH_ITEM_FORBIDDEN": 8,
+	}
+)
+
+func (x BatchItemStatus) Enum() *BatchItemStatus {
+	p := new(BatchItemStatus)
+	*p = x
+	return p
+}
+
+func (x BatchItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[5].Descriptor()
+}
+
+func (BatchItemStatus) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[5]
+}
+
+func (x BatchItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchItemStatus.Descriptor instead.
+func (BatchItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{5}
+}
+
+// 协作者的角色, 高的角色包含低的角色的全部权限
+type AclRole int32
+
+const (
+	AclRole_ACL_ROLE_NONE      AclRole = 0
+	AclRole_ACL_ROLE_VIEWER    AclRole = 1 // 浏览、预览和下载
+	AclRole_ACL_ROLE_COMMENTER AclRole = 2 // 另可修改文件的自定义元数据
+	AclRole_ACL_ROLE_EDITOR    AclRole = 3 // 另可上传、修改、移动和删除, 占用所有者的空间
+	AclRole_ACL_ROLE_OWNER     AclRole = 4 // 所有者
+)
+
+// Enum value maps for AclRole.
+var (
+	AclRole_name = map[int32]string{
+		0: "ACL_ROLE_NONE",
+		1: "ACL_ROLE_VIEWER",
+		2: "ACL_ROLE_COMMENTER",
+		3: "ACL_ROLE_EDITOR",
+		4: "ACL_ROLE_OWNER",
+	}
+	AclRole_value = map[string]int32{
+		"ACL_ROLE_NONE":      0,
+		"ACL_ROLE_VIEWER":    1,
+		"ACL_ROLE_COMMENTER": 2,
+		"ACL_ROLE_EDITOR":    3,
+		"ACL_ROLE_OWNER":     4,
+	}
+)
+
+func (x AclRole) Enum() *AclRole {
+	p := new(AclRole)
+	*p = x
+	return p
+}
+
+func (x AclRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AclRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[6].Descriptor()
+}
+
+func (AclRole) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[6]
+}
+
+func (x AclRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AclRole.Descriptor instead.
+func (AclRole) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{6}
+}
+
+// 巡检发现的问题
+type ScrubProblem int32
+
+const (
+	ScrubProblem_SCRUB_PROBLEM_NONE       ScrubProblem = 0
+	ScrubProblem_SCRUB_PROBLEM_CORRUPT    ScrubProblem = 1 // 内容的哈希与记录的不一致
+	ScrubProblem_SCRUB_PROBLEM_MISSING    ScrubProblem = 2 // 对象不存在
+	ScrubProblem_SCRUB_PROBLEM_UNREADABLE ScrubProblem = 3 // 读取或解密失败
+)
+
+// Enum value maps for ScrubProblem.
+var (
+	ScrubProblem_name = map[int32]string{
+		0: "SCRUB_PROBLEM_NONE",
+		1: "SCRUB_PROBLEM_CORRUPT",
+		2: "SCRUB_PROBLEM_MISSING",
+		3: "SCRUB_PROBLEM_UNREADABLE",
+	}
+	ScrubProblem_value = map[string]int32{
+		"SCRUB_PROBLEM_NONE":       0,
+		"SCRUB_PROBLEM_CORRUPT":    1,
+		"SCRUB_PROBLEM_MISSING":    2,
+		"SCRUB_PROBLEM_UNREADABLE": 3,
+	}
+)
+
+func (x ScrubProblem) Enum() *ScrubProblem {
+	p := new(ScrubProblem)
+	*p = x
+	return p
+}
+
+func (x ScrubProblem) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScrubProblem) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[7].Descriptor()
+}
+
+func (ScrubProblem) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[7]
+}
+
+func (x ScrubProblem) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScrubProblem.Descriptor instead.
+func (ScrubProblem) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{7}
+}
+
+// 文件处理的状态
+type ProcessingStatus int32
+
+const (
+	ProcessingStatus_PROCESSING_STATUS_UNKNOWN ProcessingStatus = 0
+	ProcessingStatus_PROCESSING_STATUS_PENDING ProcessingStatus = 1
+	ProcessingStatus_PROCESSING_STATUS_RUNNING ProcessingStatus = 2
+	ProcessingStatus_PROCESSING_STATUS_DONE    ProcessingStatus = 3
+	ProcessingStatus_PROCESSING_STATUS_RETRY   ProcessingStatus = 4 // 失败后等待重试
+	ProcessingStatus_PROCESSING_STATUS_DEAD    ProcessingStatus = 5 // 多次失败后放弃, 进入死信列表
+)
+
+// Enum value maps for ProcessingStatus.
+var (
+	ProcessingStatus_name = map[int32]string{
+		0: "PROCESSING_STATUS_UNKNOWN",
+		1: "PROCESSING_STATUS_PENDING",
+		2: "PROCESSING_STATUS_RUNNING",
+		3: "PROCESSING_STATUS_DONE",
+		4: "PROCESSING_STATUS_RETRY",
+		5: "PROCESSING_STATUS_DEAD",
+	}
+	ProcessingStatus_value = map[string]int32{
+		"PROCESSING_STATUS_UNKNOWN": 0,
+		"PROCESSING_STATUS_PENDING": 1,
+		"PROCESSING_STATUS_RUNNING": 2,
+		"PROCESSING_STATUS_DONE":    3,
+		"PROCESSING_STATUS_RETRY":   4,
+		"PROCESSING_STATUS_DEAD":    5,
+	}
+)
+
+func (x ProcessingStatus) Enum() *ProcessingStatus {
+	p := new(ProcessingStatus)
+	*p = x
+	return p
+}
+
+func (x ProcessingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProcessingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[8].Descriptor()
+}
+
+func (ProcessingStatus) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[8]
+}
+
+func (x ProcessingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProcessingStatus.Descriptor instead.
+func (ProcessingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{8}
+}
+
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNKNOWN   WebhookDeliveryStatus = 0
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING   WebhookDeliveryStatus = 1 // 等待投递
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_RUNNING   WebhookDeliveryStatus = 2 // 投递中
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED WebhookDeliveryStatus = 3 // 对方返回 2xx
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_RETRY     WebhookDeliveryStatus = 4 // 失败, 等待重试
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED    WebhookDeliveryStatus = 5 // 多次失败或 webhook 被禁用后放弃
+)
+
+// Enum value maps for WebhookDeliveryStatus.
+var (
+	WebhookDeliveryStatus_name = map[int32]string{
+		0: "WEBHOOK_DELIVERY_STATUS_UNKNOWN",
+		1: "WEBHOOK_DELIVERY_STATUS_PENDING",
+		2: "WEBHOOK_DELIVERY_STATUS_RUNNING",
+		3: "WEBHOOK_DELIVERY_STATUS_SUCCEEDED",
+		4: "WEBHOOK_DELIVERY_STATUS_RETRY",
+		5: "WEBHOOK_DELIVERY_STATUS_FAILED",
+	}
+	WebhookDeliveryStatus_value = map[string]int32{
+		"WEBHOOK_DELIVERY_STATUS_UNKNOWN":   0,
+		"WEBHOOK_DELIVERY_STATUS_PENDING":   1,
+		"WEBHOOK_DELIVERY_STATUS_RUNNING":   2,
+		"WEBHOOK_DELIVERY_STATUS_SUCCEEDED": 3,
+		"WEBHOOK_DELIVERY_STATUS_RETRY":     4,
+		"WEBHOOK_DELIVERY_STATUS_FAILED":    5,
+	}
+)
+
+func (x WebhookDeliveryStatus) Enum() *WebhookDeliveryStatus {
+	p := new(WebhookDeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[9].Descriptor()
+}
+
+func (WebhookDeliveryStatus) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[9]
+}
+
+func (x WebhookDeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDeliveryStatus.Descriptor instead.
+func (WebhookDeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{9}
+}
+
+type AuditExportFormat int32
+
+const (
+	AuditExportFormat_AUDIT_EXPORT_FORMAT_CSV   AuditExportFormat = 0
+	AuditExportFormat_AUDIT_EXPORT_FORMAT_JSONL AuditExportFormat = 1 // 每行一个 AuditLog 的 JSON
+)
+
+// Enum value maps for AuditExportFormat.
+var (
+	AuditExportFormat_name = map[int32]string{
+		0: "AUDIT_EXPORT_FORMAT_CSV",
+		1: "AUDIT_EXPORT_FORMAT_JSONL",
+	}
+	AuditExportFormat_value = map[string]int32{
+		"AUDIT_EXPORT_FORMAT_CSV":   0,
+		"AUDIT_EXPORT_FORMAT_JSONL": 1,
+	}
+)
+
+func (x AuditExportFormat) Enum() *AuditExportFormat {
+	p := new(AuditExportFormat)
+	*p = x
+	return p
+}
+
+func (x AuditExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_cloudstorage_file_proto_enumTypes[10].Descriptor()
+}
+
+func (AuditExportFormat) Type() protoreflect.EnumType {
+	return &file_idl_cloudstorage_file_proto_enumTypes[10]
+}
+
+func (x AuditExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditExportFormat.Descriptor instead.
+func (AuditExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{10}
+}
+
 type FileMetaData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size           int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Hash           string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"` // 客户端计算的内容哈希, 十六进制的 MD5 或 SHA-256, 与服务端计算的不一致时拒绝上传
+	Path           string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	ContentType    string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	UserId         int32                  `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId       int64                  `protobuf:"varint,7,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Metadata       map[string]*MetaValue  `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 上传时附带的自定义元数据
+	FolderPath     string                 `protobuf:"bytes,9,opt,name=folder_path,json=folderPath,proto3" json:"folder_path,omitempty"`                                                     // 按路径指定目标文件夹, 优先于 folder_id
+	CreateParents  bool                   `protobuf:"varint,10,opt,name=create_parents,json=createParents,proto3" json:"create_parents,omitempty"`                                          // folder_path 不存在时自动创建
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,11,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FileMetaData) Reset() {
+	*x = FileMetaData{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileMetaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileMetaData) ProtoMessage() {}
+
+func (x *FileMetaData) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileMetaData.ProtoReflect.Descriptor instead.
+func (*FileMetaData) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FileMetaData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileMetaData) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileMetaData) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *FileMetaData) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileMetaData) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileMetaData) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *FileMetaData) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *FileMetaData) GetMetadata() map[string]*MetaValue {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *FileMetaData) GetFolderPath() string {
+	if x != nil {
+		return x.FolderPath
+	}
+	return ""
+}
+
+func (x *FileMetaData) GetCreateParents() bool {
+	if x != nil {
+		return x.CreateParents
+	}
+	return false
+}
+
+func (x *FileMetaData) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+// 自定义元数据值, 支持字符串、数字、日期(unix 秒)和布尔
+type MetaValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*MetaValue_StringValue
+	//	*MetaValue_NumberValue
+	//	*MetaValue_DateValue
+	//	*MetaValue_BoolValue
+	Value         isMetaValue_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetaValue) Reset() {
+	*x = MetaValue{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetaValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetaValue) ProtoMessage() {}
+
+func (x *MetaValue) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetaValue.ProtoReflect.Descriptor instead.
+func (*MetaValue) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MetaValue) GetValue() isMetaValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *MetaValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*MetaValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *MetaValue) GetNumberValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*MetaValue_NumberValue); ok {
+			return x.NumberValue
+		}
+	}
+	return 0
+}
+
+func (x *MetaValue) GetDateValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*MetaValue_DateValue); ok {
+			return x.DateValue
+		}
+	}
+	return 0
+}
+
+func (x *MetaValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*MetaValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+type isMetaValue_Value interface {
+	isMetaValue_Value()
+}
+
+type MetaValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type MetaValue_NumberValue struct {
+	NumberValue float64 `protobuf:"fixed64,2,opt,name=number_value,json=numberValue,proto3,oneof"`
+}
+
+type MetaValue_DateValue struct {
+	DateValue int64 `protobuf:"varint,3,opt,name=date_value,json=dateValue,proto3,oneof"`
+}
+
+type MetaValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,4,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+func (*MetaValue_StringValue) isMetaValue_Value() {}
+
+func (*MetaValue_NumberValue) isMetaValue_Value() {}
+
+func (*MetaValue_DateValue) isMetaValue_Value() {}
+
+func (*MetaValue_BoolValue) isMetaValue_Value() {}
+
+type File struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	FolderId       int64                  `protobuf:"varint,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	UserId         int32                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Size           int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	Type           string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	Utime          string                 `protobuf:"bytes,8,opt,name=utime,proto3" json:"utime,omitempty"`
+	Version        int32                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`                                                                             // 文件版本号
+	DeviceId       string                 `protobuf:"bytes,10,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                                                           // 设备ID
+	LastModifiedBy string                 `protobuf:"bytes,11,opt,name=last_modified_by,json=lastModifiedBy,proto3" json:"last_modified_by,omitempty"`                                       // 最后修改者
+	Metadata       map[string]*MetaValue  `protobuf:"bytes,12,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 自定义元数据
+	VaultId        int64                  `protobuf:"varint,13,opt,name=vault_id,json=vaultId,proto3" json:"vault_id,omitempty"`                                                             // 所在的保险库, 0 表示不在保险库中, 名称和内容均为客户端加密的密文
+	Sha256         string                 `protobuf:"bytes,14,opt,name=sha256,proto3" json:"sha256,omitempty"`                                                                               // 服务端计算的内容 SHA-256, 十六进制, 可用于校验下载的内容
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *File) Reset() {
+	*x = File{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*File) ProtoMessage() {}
+
+func (x *File) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use File.ProtoReflect.Descriptor instead.
+func (*File) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *File) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *File) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *File) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *File) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *File) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *File) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *File) GetUtime() string {
+	if x != nil {
+		return x.Utime
+	}
+	return ""
+}
+
+func (x *File) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *File) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *File) GetLastModifiedBy() string {
+	if x != nil {
+		return x.LastModifiedBy
+	}
+	return ""
+}
+
+func (x *File) GetMetadata() map[string]*MetaValue {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *File) GetVaultId() int64 {
+	if x != nil {
+		return x.VaultId
+	}
+	return 0
+}
+
+func (x *File) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type Folder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      int64                  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Path          string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	Utime         string                 `protobuf:"bytes,6,opt,name=utime,proto3" json:"utime,omitempty"`
+	TotalSize     int64                  `protobuf:"varint,7,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`         // 子树中文件的总大小
+	FileCount     int64                  `protobuf:"varint,8,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`         // 子树中的文件数
+	LastModified  string                 `protobuf:"bytes,9,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"` // 子树中文件的最近变更时间
+	VaultId       int64                  `protobuf:"varint,10,opt,name=vault_id,json=vaultId,proto3" json:"vault_id,omitempty"`              // 所在的保险库, 保险库根文件夹为自身ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Folder) Reset() {
+	*x = Folder{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Folder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Folder) ProtoMessage() {}
+
+func (x *Folder) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Folder.ProtoReflect.Descriptor instead.
+func (*Folder) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Folder) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Folder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Folder) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Folder) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Folder) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Folder) GetUtime() string {
+	if x != nil {
+		return x.Utime
+	}
+	return ""
+}
+
+func (x *Folder) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *Folder) GetFileCount() int64 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *Folder) GetLastModified() string {
+	if x != nil {
+		return x.LastModified
+	}
+	return ""
+}
+
+func (x *Folder) GetVaultId() int64 {
+	if x != nil {
+		return x.VaultId
+	}
+	return 0
+}
+
+type FolderNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	Children      []*FolderNode          `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FolderNode) Reset() {
+	*x = FolderNode{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FolderNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FolderNode) ProtoMessage() {}
+
+func (x *FolderNode) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FolderNode.ProtoReflect.Descriptor instead.
+func (*FolderNode) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FolderNode) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+func (x *FolderNode) GetChildren() []*FolderNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type FileStore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Capacity      int64                  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	CurrentSize   int64                  `protobuf:"varint,3,opt,name=current_size,json=currentSize,proto3" json:"current_size,omitempty"`
+	PlanId        int64                  `protobuf:"varint,4,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"` // 0 表示默认套餐
+	Reserved      int64                  `protobuf:"varint,5,opt,name=reserved,proto3" json:"reserved,omitempty"`           // 进行中的上传预留的空间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileStore) Reset() {
+	*x = FileStore{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileStore) ProtoMessage() {}
+
+func (x *FileStore) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileStore.ProtoReflect.Descriptor instead.
+func (*FileStore) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FileStore) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *FileStore) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *FileStore) GetCurrentSize() int64 {
+	if x != nil {
+		return x.CurrentSize
+	}
+	return 0
+}
+
+func (x *FileStore) GetPlanId() int64 {
+	if x != nil {
+		return x.PlanId
+	}
+	return 0
+}
+
+func (x *FileStore) GetReserved() int64 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+// 存储套餐
+type StoragePlan struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity         int64                  `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	MaxFileSize      int64                  `protobuf:"varint,4,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`              // 单个文件的大小上限, 0 表示不限
+	VersionRetention int32                  `protobuf:"varint,5,opt,name=version_retention,json=versionRetention,proto3" json:"version_retention,omitempty"` // 每个文件保留的历史版本数, 0 表示不限
+	BandwidthTier    string                 `protobuf:"bytes,6,opt,name=bandwidth_tier,json=bandwidthTier,proto3" json:"bandwidth_tier,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StoragePlan) Reset() {
+	*x = StoragePlan{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoragePlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoragePlan) ProtoMessage() {}
+
+func (x *StoragePlan) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoragePlan.ProtoReflect.Descriptor instead.
+func (*StoragePlan) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StoragePlan) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StoragePlan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StoragePlan) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *StoragePlan) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *StoragePlan) GetVersionRetention() int32 {
+	if x != nil {
+		return x.VersionRetention
+	}
+	return 0
+}
+
+func (x *StoragePlan) GetBandwidthTier() string {
+	if x != nil {
+		return x.BandwidthTier
+	}
+	return ""
+}
+
+type UploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *FileMetaData          `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UploadRequest) GetMetadata() *FileMetaData {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`        // 最终名称
+	Skipped       bool                   `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"` // 按同名策略跳过
+	Version       int32                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"` // 覆盖已有文件时为新的版本号
+	Sha256        string                 `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`    // 服务端计算的内容 SHA-256
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadResponse) Reset() {
+	*x = UploadResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadResponse) ProtoMessage() {}
+
+func (x *UploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
+func (*UploadResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UploadResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UploadResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *UploadResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UploadResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type CreateFileStoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFileStoreRequest) Reset() {
+	*x = CreateFileStoreRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFileStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFileStoreRequest) ProtoMessage() {}
+
+func (x *CreateFileStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFileStoreRequest.ProtoReflect.Descriptor instead.
+func (*CreateFileStoreRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateFileStoreRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CreateFileStoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFileStoreResponse) Reset() {
+	*x = CreateFileStoreResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFileStoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFileStoreResponse) ProtoMessage() {}
+
+func (x *CreateFileStoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFileStoreResponse.ProtoReflect.Descriptor instead.
+func (*CreateFileStoreResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateFileStoreResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateFolderRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId       int64                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	UserId         int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,4,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateFolderRequest) Reset() {
+	*x = CreateFolderRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFolderRequest) ProtoMessage() {}
+
+func (x *CreateFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFolderRequest.ProtoReflect.Descriptor instead.
+func (*CreateFolderRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateFolderRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateFolderRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateFolderRequest) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+type CreateFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	Skipped       bool                   `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // 按同名策略跳过, folder 为已存在的同名文件夹
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFolderResponse) Reset() {
+	*x = CreateFolderResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFolderResponse) ProtoMessage() {}
+
+func (x *CreateFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFolderResponse.ProtoReflect.Descriptor instead.
+func (*CreateFolderResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateFolderResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+func (x *CreateFolderResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type ListFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FolderId      int64                  `protobuf:"varint,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFolderRequest) Reset() {
+	*x = ListFolderRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFolderRequest) ProtoMessage() {}
+
+func (x *ListFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFolderRequest.ProtoReflect.Descriptor instead.
+func (*ListFolderRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListFolderRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *ListFolderRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []*Folder              `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	Files         []*File                `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFolderResponse) Reset() {
+	*x = ListFolderResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFolderResponse) ProtoMessage() {}
+
+func (x *ListFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFolderResponse.ProtoReflect.Descriptor instead.
+func (*ListFolderResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListFolderResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+func (x *ListFolderResponse) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type GetFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileRequest) Reset() {
+	*x = GetFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileRequest) ProtoMessage() {}
+
+func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
+func (*GetFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *GetFileRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Processing    []*ProcessingResult    `protobuf:"bytes,2,rep,name=processing,proto3" json:"processing,omitempty"` // 文件提交后各处理器的状态和结果
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileResponse) Reset() {
+	*x = GetFileResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileResponse) ProtoMessage() {}
+
+func (x *GetFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileResponse.ProtoReflect.Descriptor instead.
+func (*GetFileResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetFileResponse) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *GetFileResponse) GetProcessing() []*ProcessingResult {
+	if x != nil {
+		return x.Processing
+	}
+	return nil
+}
+
+type DownloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"` // 范围读取的起始位置
+	Length        int64                  `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"` // 范围读取的长度, 0 表示读到末尾
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadRequest) Reset() {
+	*x = DownloadRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadRequest) ProtoMessage() {}
+
+func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadRequest.ProtoReflect.Descriptor instead.
+func (*DownloadRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DownloadRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *DownloadRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DownloadRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type DownloadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadResponse) Reset() {
+	*x = DownloadResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadResponse) ProtoMessage() {}
+
+func (x *DownloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadResponse.ProtoReflect.Descriptor instead.
+func (*DownloadResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DownloadResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DownloadStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadStreamResponse) Reset() {
+	*x = DownloadStreamResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadStreamResponse) ProtoMessage() {}
+
+func (x *DownloadStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadStreamResponse.ProtoReflect.Descriptor instead.
+func (*DownloadStreamResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DownloadStreamResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type MoveFolderRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId       int64                  `protobuf:"varint,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	ToFolderId     int64                  `protobuf:"varint,3,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"`
+	FolderName     string                 `protobuf:"bytes,4,opt,name=folder_name,json=folderName,proto3" json:"folder_name,omitempty"` // 移动后的名称, 为空时保持原名
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,5,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MoveFolderRequest) Reset() {
+	*x = MoveFolderRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFolderRequest) ProtoMessage() {}
+
+func (x *MoveFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFolderRequest.ProtoReflect.Descriptor instead.
+func (*MoveFolderRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MoveFolderRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MoveFolderRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *MoveFolderRequest) GetToFolderId() int64 {
+	if x != nil {
+		return x.ToFolderId
+	}
+	return 0
+}
+
+func (x *MoveFolderRequest) GetFolderName() string {
+	if x != nil {
+		return x.FolderName
+	}
+	return ""
+}
+
+func (x *MoveFolderRequest) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+type MoveFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	Skipped       bool                   `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // 按同名策略跳过
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFolderResponse) Reset() {
+	*x = MoveFolderResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFolderResponse) ProtoMessage() {}
+
+func (x *MoveFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFolderResponse.ProtoReflect.Descriptor instead.
+func (*MoveFolderResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MoveFolderResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+func (x *MoveFolderResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type MoveFileRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId         int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ToFolderId     int64                  `protobuf:"varint,4,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"`
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,5,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MoveFileRequest) Reset() {
+	*x = MoveFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFileRequest) ProtoMessage() {}
+
+func (x *MoveFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFileRequest.ProtoReflect.Descriptor instead.
+func (*MoveFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MoveFileRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MoveFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *MoveFileRequest) GetToFolderId() int64 {
+	if x != nil {
+		return x.ToFolderId
+	}
+	return 0
+}
+
+func (x *MoveFileRequest) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+type MoveFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`        // 最终名称
+	Skipped       bool                   `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // 按同名策略跳过
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFileResponse) Reset() {
+	*x = MoveFileResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFileResponse) ProtoMessage() {}
+
+func (x *MoveFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFileResponse.ProtoReflect.Descriptor instead.
+func (*MoveFileResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MoveFileResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MoveFileResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type DeleteFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileRequest) Reset() {
+	*x = DeleteFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileRequest) ProtoMessage() {}
+
+func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *DeleteFileRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileResponse) Reset() {
+	*x = DeleteFileResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResponse) ProtoMessage() {}
+
+func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{25}
+}
+
+type DeleteFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FolderId      int64                  `protobuf:"varint,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderRequest) Reset() {
+	*x = DeleteFolderRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderRequest) ProtoMessage() {}
+
+func (x *DeleteFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFolderRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteFolderRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *DeleteFolderRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderResponse) Reset() {
+	*x = DeleteFolderResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderResponse) ProtoMessage() {}
+
+func (x *DeleteFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFolderResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{27}
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	FolderId      int64                  `protobuf:"varint,5,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"` // 只搜索该文件夹的子树, 可以是共享给用户或团队空间中的文件夹, 0 表示用户自己的网盘
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SearchRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*File                `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Folders       []*Folder              `protobuf:"bytes,2,rep,name=folders,proto3" json:"folders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SearchResponse) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+type PreviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRequest) Reset() {
+	*x = PreviewRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRequest) ProtoMessage() {}
+
+func (x *PreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRequest.ProtoReflect.Descriptor instead.
+func (*PreviewRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PreviewRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *PreviewRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type PreviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PreviewUrl    string                 `protobuf:"bytes,1,opt,name=preview_url,json=previewUrl,proto3" json:"preview_url,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Type          PreviewType            `protobuf:"varint,3,opt,name=type,proto3,enum=file.PreviewType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewResponse) Reset() {
+	*x = PreviewResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewResponse) ProtoMessage() {}
+
+func (x *PreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewResponse.ProtoReflect.Descriptor instead.
+func (*PreviewResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PreviewResponse) GetPreviewUrl() string {
+	if x != nil {
+		return x.PreviewUrl
+	}
+	return ""
+}
+
+func (x *PreviewResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *PreviewResponse) GetType() PreviewType {
+	if x != nil {
+		return x.Type
+	}
+	return PreviewType_UNKNOWN
+}
+
+type PartInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartInfo) Reset() {
+	*x = PartInfo{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartInfo) ProtoMessage() {}
+
+func (x *PartInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartInfo.ProtoReflect.Descriptor instead.
+func (*PartInfo) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PartInfo) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *PartInfo) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type DownloadTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Files         []*FileDownloadInfo    `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	FolderName    string                 `protobuf:"bytes,3,opt,name=folder_name,json=folderName,proto3" json:"folder_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadTaskRequest) Reset() {
+	*x = DownloadTaskRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadTaskRequest) ProtoMessage() {}
+
+func (x *DownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*DownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DownloadTaskRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DownloadTaskRequest) GetFiles() []*FileDownloadInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *DownloadTaskRequest) GetFolderName() string {
+	if x != nil {
+		return x.FolderName
+	}
+	return ""
+}
+
+type FileDownloadInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	OrderNum      int32                  `protobuf:"varint,2,opt,name=order_num,json=orderNum,proto3" json:"order_num,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDownloadInfo) Reset() {
+	*x = FileDownloadInfo{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDownloadInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDownloadInfo) ProtoMessage() {}
+
+func (x *FileDownloadInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDownloadInfo.ProtoReflect.Descriptor instead.
+func (*FileDownloadInfo) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FileDownloadInfo) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *FileDownloadInfo) GetOrderNum() int32 {
+	if x != nil {
+		return x.OrderNum
+	}
+	return 0
+}
+
+func (x *FileDownloadInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type DownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadTaskResponse) Reset() {
+	*x = DownloadTaskResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadTaskResponse) ProtoMessage() {}
+
+func (x *DownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*DownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DownloadTaskResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type GetDownloadTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadTaskRequest) Reset() {
+	*x = GetDownloadTaskRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadTaskRequest) ProtoMessage() {}
+
+func (x *GetDownloadTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetDownloadTaskRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetDownloadTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GetDownloadTaskRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetDownloadTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // pending processing completed failed
+	FolderName    string                 `protobuf:"bytes,3,opt,name=folder_name,json=folderName,proto3" json:"folder_name,omitempty"`
+	TotalSize     int64                  `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	Progress      int64                  `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	Files         []*FileProgress        `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadTaskResponse) Reset() {
+	*x = GetDownloadTaskResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadTaskResponse) ProtoMessage() {}
+
+func (x *GetDownloadTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetDownloadTaskResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetDownloadTaskResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GetDownloadTaskResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetDownloadTaskResponse) GetFolderName() string {
+	if x != nil {
+		return x.FolderName
+	}
+	return ""
+}
+
+func (x *GetDownloadTaskResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *GetDownloadTaskResponse) GetProgress() int64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *GetDownloadTaskResponse) GetFiles() []*FileProgress {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type FileProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`          // pending processing completed failed
+	Downloaded    int64                  `protobuf:"varint,6,opt,name=downloaded,proto3" json:"downloaded,omitempty"` // 已下载大小
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileProgress) Reset() {
+	*x = FileProgress{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileProgress) ProtoMessage() {}
+
+func (x *FileProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileProgress.ProtoReflect.Descriptor instead.
+func (*FileProgress) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *FileProgress) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *FileProgress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileProgress) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileProgress) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileProgress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FileProgress) GetDownloaded() int64 {
+	if x != nil {
+		return x.Downloaded
+	}
+	return 0
+}
+
+type ResumeDownloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`            // 原任务ID
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`           // 用户ID
+	FileIds       []int64                `protobuf:"varint,3,rep,packed,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"` // 需要继续下载的文件ID列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeDownloadRequest) Reset() {
+	*x = ResumeDownloadRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeDownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeDownloadRequest) ProtoMessage() {}
+
+func (x *ResumeDownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeDownloadRequest.ProtoReflect.Descriptor instead.
+func (*ResumeDownloadRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ResumeDownloadRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ResumeDownloadRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ResumeDownloadRequest) GetFileIds() []int64 {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+type ResumeDownloadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewTaskId     string                 `protobuf:"bytes,1,opt,name=new_task_id,json=newTaskId,proto3" json:"new_task_id,omitempty"` // 新的任务ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeDownloadResponse) Reset() {
+	*x = ResumeDownloadResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeDownloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeDownloadResponse) ProtoMessage() {}
+
+func (x *ResumeDownloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeDownloadResponse.ProtoReflect.Descriptor instead.
+func (*ResumeDownloadResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ResumeDownloadResponse) GetNewTaskId() string {
+	if x != nil {
+		return x.NewTaskId
+	}
+	return ""
+}
+
+type UploadChunkRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Filename       string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	UploadId       string                 `protobuf:"bytes,2,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	PartNumber     int32                  `protobuf:"varint,3,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Data           []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	FileSize       int64                  `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	UserId         int32                  `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId       int64                  `protobuf:"varint,7,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	IsLast         bool                   `protobuf:"varint,8,opt,name=is_last,json=isLast,proto3" json:"is_last,omitempty"` // 是否是最后一个分片
+	Parts          []*PartInfo            `protobuf:"bytes,9,rep,name=parts,proto3" json:"parts,omitempty"`                  // 如果是最后一个分片，提供所有分片信息
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,10,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	Checksum       string                 `protobuf:"bytes,11,opt,name=checksum,proto3" json:"checksum,omitempty"` // 本分片内容的 SHA-256, 十六进制, 不一致时拒绝该分片
+	Hash           string                 `protobuf:"bytes,12,opt,name=hash,proto3" json:"hash,omitempty"`         // 整个文件的 MD5 或 SHA-256, 由第一个或最后一个分片携带, 完成上传时校验
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UploadChunkRequest) Reset() {
+	*x = UploadChunkRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunkRequest) ProtoMessage() {}
+
+func (x *UploadChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunkRequest.ProtoReflect.Descriptor instead.
+func (*UploadChunkRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UploadChunkRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadChunkRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadChunkRequest) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadChunkRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadChunkRequest) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *UploadChunkRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UploadChunkRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *UploadChunkRequest) GetIsLast() bool {
+	if x != nil {
+		return x.IsLast
+	}
+	return false
+}
+
+func (x *UploadChunkRequest) GetParts() []*PartInfo {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *UploadChunkRequest) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+func (x *UploadChunkRequest) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *UploadChunkRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type UploadChunkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"` // 如果是第一个分片，返回新的upload_id
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`                         // 分片的ETag
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                         // 上传完成后文件的最终名称
+	Sha256        string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`                     // 服务端计算的整个文件的 SHA-256
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadChunkResponse) Reset() {
+	*x = UploadChunkResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunkResponse) ProtoMessage() {}
+
+func (x *UploadChunkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunkResponse.ProtoReflect.Descriptor instead.
+func (*UploadChunkResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UploadChunkResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadChunkResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UploadChunkResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadChunkResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type CreateShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                   // 分享者ID
+	FileIds       []int64                `protobuf:"varint,2,rep,packed,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`         // 要分享的文件ID列表
+	FolderId      int64                  `protobuf:"varint,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`             // 要分享的文件夹ID，与file_ids二选一
+	ExpireDays    int32                  `protobuf:"varint,4,opt,name=expire_days,json=expireDays,proto3" json:"expire_days,omitempty"`       // 链接有效期(天)
+	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`                              // 可选的提取密码
+	MaxDownloads  int64                  `protobuf:"varint,6,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"` // 下载次数上限, 0 表示不限
+	MaxSaves      int64                  `protobuf:"varint,7,opt,name=max_saves,json=maxSaves,proto3" json:"max_saves,omitempty"`             // 转存次数上限, 0 表示不限
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateShareLinkRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) GetFileIds() []int64 {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+func (x *CreateShareLinkRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) GetExpireDays() int32 {
+	if x != nil {
+		return x.ExpireDays
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetMaxDownloads() int64 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) GetMaxSaves() int64 {
+	if x != nil {
+		return x.MaxSaves
+	}
+	return 0
+}
+
+type CreateShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`     // 分享ID
+	ShareUrl      string                 `protobuf:"bytes,2,opt,name=share_url,json=shareUrl,proto3" json:"share_url,omitempty"`  // 分享链接
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`                  // 提取密码
+	ExpireAt      int64                  `protobuf:"varint,4,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // 过期时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkResponse) Reset() {
+	*x = CreateShareLinkResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkResponse) ProtoMessage() {}
+
+func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CreateShareLinkResponse) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *CreateShareLinkResponse) GetShareUrl() string {
+	if x != nil {
+		return x.ShareUrl
+	}
+	return ""
+}
+
+func (x *CreateShareLinkResponse) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShareLinkResponse) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+type SaveToMyDriveRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShareId        string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`             // 分享ID
+	Password       string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`                          // 提取密码
+	UserId         int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`               // 保存者的用户ID
+	ToFolderId     int64                  `protobuf:"varint,4,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"` // 保存到的目标文件夹ID
+	FileIds        []int64                `protobuf:"varint,5,rep,packed,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`     // 选择保存的文件ID列表
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,6,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	ClientIp       string                 `protobuf:"bytes,7,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`            // 记录访问日志使用
+	FolderIds      []int64                `protobuf:"varint,8,rep,packed,name=folder_ids,json=folderIds,proto3" json:"folder_ids,omitempty"` // 选择保存的文件夹ID列表, 与 file_ids 都为空时保存整个分享
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SaveToMyDriveRequest) Reset() {
+	*x = SaveToMyDriveRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveToMyDriveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveToMyDriveRequest) ProtoMessage() {}
+
+func (x *SaveToMyDriveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveToMyDriveRequest.ProtoReflect.Descriptor instead.
+func (*SaveToMyDriveRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SaveToMyDriveRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *SaveToMyDriveRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *SaveToMyDriveRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SaveToMyDriveRequest) GetToFolderId() int64 {
+	if x != nil {
+		return x.ToFolderId
+	}
+	return 0
+}
+
+func (x *SaveToMyDriveRequest) GetFileIds() []int64 {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+func (x *SaveToMyDriveRequest) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+func (x *SaveToMyDriveRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *SaveToMyDriveRequest) GetFolderIds() []int64 {
+	if x != nil {
+		return x.FolderIds
+	}
+	return nil
+}
+
+type SaveToMyDriveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*File                `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`              // 保存后的文件, 名称为最终名称
+	Folders       []*Folder              `protobuf:"bytes,2,rep,name=folders,proto3" json:"folders,omitempty"`          // 保存后的文件夹
+	Skipped       int32                  `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`         // 按同名策略跳过的条目数
+	JobId         string                 `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // 条目较多时转为异步任务, 通过任务接口查询进度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveToMyDriveResponse) Reset() {
+	*x = SaveToMyDriveResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveToMyDriveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveToMyDriveResponse) ProtoMessage() {}
+
+func (x *SaveToMyDriveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveToMyDriveResponse.ProtoReflect.Descriptor instead.
+func (*SaveToMyDriveResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SaveToMyDriveResponse) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *SaveToMyDriveResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+func (x *SaveToMyDriveResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *SaveToMyDriveResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// 分享的基本信息, 匿名访问时返回
+type ShareInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	OwnerId       int32                  `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`    // 分享者ID
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                          // 文件夹分享时为文件夹名称
+	IsFolder      bool                   `protobuf:"varint,4,opt,name=is_folder,json=isFolder,proto3" json:"is_folder,omitempty"` // 是否为文件夹分享
+	ExpireAt      int64                  `protobuf:"varint,5,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareInfo) Reset() {
+	*x = ShareInfo{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareInfo) ProtoMessage() {}
+
+func (x *ShareInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareInfo.ProtoReflect.Descriptor instead.
+func (*ShareInfo) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ShareInfo) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *ShareInfo) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *ShareInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShareInfo) GetIsFolder() bool {
+	if x != nil {
+		return x.IsFolder
+	}
+	return false
+}
+
+func (x *ShareInfo) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+// 匿名浏览分享, 凭分享ID和提取密码访问
+type ListShareFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	FolderId      int64                  `protobuf:"varint,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"` // 0 表示分享的根, 否则须为分享内的文件夹
+	ClientIp      string                 `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`  // 记录访问日志使用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareFolderRequest) Reset() {
+	*x = ListShareFolderRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareFolderRequest) ProtoMessage() {}
+
+func (x *ListShareFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareFolderRequest.ProtoReflect.Descriptor instead.
+func (*ListShareFolderRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListShareFolderRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *ListShareFolderRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ListShareFolderRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *ListShareFolderRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+type ListShareFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *ShareInfo             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	Files         []*File                `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	Folders       []*Folder              `protobuf:"bytes,3,rep,name=folders,proto3" json:"folders,omitempty"` // path 为相对分享根的路径
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareFolderResponse) Reset() {
+	*x = ListShareFolderResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareFolderResponse) ProtoMessage() {}
+
+func (x *ListShareFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareFolderResponse.ProtoReflect.Descriptor instead.
+func (*ListShareFolderResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListShareFolderResponse) GetShare() *ShareInfo {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *ListShareFolderResponse) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListShareFolderResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+type ListShareFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,3,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	Download      bool                   `protobuf:"varint,4,opt,name=download,proto3" json:"download,omitempty"` // 用于打包下载时计为一次下载
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareFilesRequest) Reset() {
+	*x = ListShareFilesRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareFilesRequest) ProtoMessage() {}
+
+func (x *ListShareFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListShareFilesRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListShareFilesRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *ListShareFilesRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ListShareFilesRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *ListShareFilesRequest) GetDownload() bool {
+	if x != nil {
+		return x.Download
+	}
+	return false
+}
+
+// 分享内的文件及其相对分享根的路径, 用于打包下载
+type ShareEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` // 如 docs/a.txt, 名称中的 / 替换为 _
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareEntry) Reset() {
+	*x = ShareEntry{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareEntry) ProtoMessage() {}
+
+func (x *ShareEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareEntry.ProtoReflect.Descriptor instead.
+func (*ShareEntry) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ShareEntry) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *ShareEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListShareFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *ShareInfo             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	Entries       []*ShareEntry          `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	ArchiveToken  string                 `protobuf:"bytes,3,opt,name=archive_token,json=archiveToken,proto3" json:"archive_token,omitempty"` // download 为 true 时返回, 打包下载各文件时代替提取密码
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareFilesResponse) Reset() {
+	*x = ListShareFilesResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareFilesResponse) ProtoMessage() {}
+
+func (x *ListShareFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListShareFilesResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListShareFilesResponse) GetShare() *ShareInfo {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *ListShareFilesResponse) GetEntries() []*ShareEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListShareFilesResponse) GetArchiveToken() string {
+	if x != nil {
+		return x.ArchiveToken
+	}
+	return ""
+}
+
+// 访问分享内的单个文件
+type ShareFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	FileId        int64                  `protobuf:"varint,3,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	InArchive     bool                   `protobuf:"varint,5,opt,name=in_archive,json=inArchive,proto3" json:"in_archive,omitempty"`          // 打包下载中的文件, 已在 ListShareFiles 中计数
+	Offset        int64                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`                                 // 范围读取的起始位置
+	Length        int64                  `protobuf:"varint,7,opt,name=length,proto3" json:"length,omitempty"`                                 // 范围读取的长度, 0 表示读到末尾
+	ArchiveToken  string                 `protobuf:"bytes,9,opt,name=archive_token,json=archiveToken,proto3" json:"archive_token,omitempty"`  // ListShareFiles 返回的令牌, 设置时代替提取密码, 仅用于 in_archive
+	PreviewToken  string                 `protobuf:"bytes,10,opt,name=preview_token,json=previewToken,proto3" json:"preview_token,omitempty"` // PreviewShareFile 返回的地址中的令牌, 设置时代替提取密码, 只记为浏览, 仅支持可预览的类型
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareFileRequest) Reset() {
+	*x = ShareFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareFileRequest) ProtoMessage() {}
+
+func (x *ShareFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareFileRequest.ProtoReflect.Descriptor instead.
+func (*ShareFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ShareFileRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *ShareFileRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ShareFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *ShareFileRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *ShareFileRequest) GetInArchive() bool {
+	if x != nil {
+		return x.InArchive
+	}
+	return false
+}
+
+func (x *ShareFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ShareFileRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *ShareFileRequest) GetArchiveToken() string {
+	if x != nil {
+		return x.ArchiveToken
+	}
+	return ""
+}
+
+func (x *ShareFileRequest) GetPreviewToken() string {
+	if x != nil {
+		return x.PreviewToken
+	}
+	return ""
+}
+
+// 分享的管理信息
+type ShareSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	ShareUrl      string                 `protobuf:"bytes,2,opt,name=share_url,json=shareUrl,proto3" json:"share_url,omitempty"`
+	FolderId      int64                  `protobuf:"varint,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`    // 文件夹分享的文件夹ID
+	FileCount     int64                  `protobuf:"varint,4,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"` // 文件分享包含的文件数
+	HasPassword   bool                   `protobuf:"varint,5,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpireAt      int64                  `protobuf:"varint,7,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Status        int32                  `protobuf:"varint,8,opt,name=status,proto3" json:"status,omitempty"`                                 // 1-有效 2-已过期 3-已取消 4-已禁用(包含被隔离的文件)
+	MaxDownloads  int64                  `protobuf:"varint,9,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"` // 0 表示不限
+	MaxSaves      int64                  `protobuf:"varint,10,opt,name=max_saves,json=maxSaves,proto3" json:"max_saves,omitempty"`            // 0 表示不限
+	ViewCount     int64                  `protobuf:"varint,11,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	DownloadCount int64                  `protobuf:"varint,12,opt,name=download_count,json=downloadCount,proto3" json:"download_count,omitempty"`
+	SaveCount     int64                  `protobuf:"varint,13,opt,name=save_count,json=saveCount,proto3" json:"save_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareSummary) Reset() {
+	*x = ShareSummary{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareSummary) ProtoMessage() {}
+
+func (x *ShareSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareSummary.ProtoReflect.Descriptor instead.
+func (*ShareSummary) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ShareSummary) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *ShareSummary) GetShareUrl() string {
+	if x != nil {
+		return x.ShareUrl
+	}
+	return ""
+}
+
+func (x *ShareSummary) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetFileCount() int64 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *ShareSummary) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetMaxDownloads() int64 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetMaxSaves() int64 {
+	if x != nil {
+		return x.MaxSaves
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetViewCount() int64 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetDownloadCount() int64 {
+	if x != nil {
+		return x.DownloadCount
+	}
+	return 0
+}
+
+func (x *ShareSummary) GetSaveCount() int64 {
+	if x != nil {
+		return x.SaveCount
+	}
+	return 0
+}
+
+type ListSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"` // 0 表示全部
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharesRequest) Reset() {
+	*x = ListSharesRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharesRequest) ProtoMessage() {}
+
+func (x *ListSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListSharesRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListSharesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListSharesRequest) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListSharesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSharesRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*ShareSummary        `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharesResponse) Reset() {
+	*x = ListSharesResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharesResponse) ProtoMessage() {}
+
+func (x *ListSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListSharesResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListSharesResponse) GetShares() []*ShareSummary {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+func (x *ListSharesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type RevokeSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShareIds      []string               `protobuf:"bytes,2,rep,name=share_ids,json=shareIds,proto3" json:"share_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSharesRequest) Reset() {
+	*x = RevokeSharesRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSharesRequest) ProtoMessage() {}
+
+func (x *RevokeSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSharesRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSharesRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RevokeSharesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RevokeSharesRequest) GetShareIds() []string {
+	if x != nil {
+		return x.ShareIds
+	}
+	return nil
+}
+
+type RevokeSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revoked       int32                  `protobuf:"varint,1,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSharesResponse) Reset() {
+	*x = RevokeSharesResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSharesResponse) ProtoMessage() {}
+
+func (x *RevokeSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSharesResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSharesResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RevokeSharesResponse) GetRevoked() int32 {
+	if x != nil {
+		return x.Revoked
+	}
+	return 0
+}
+
+// 修改分享, 只修改对应 update_* 为 true 的项
+type UpdateShareRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShareId        string                 `protobuf:"bytes,2,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	UpdateExpire   bool                   `protobuf:"varint,3,opt,name=update_expire,json=updateExpire,proto3" json:"update_expire,omitempty"`
+	ExpireAt       int64                  `protobuf:"varint,4,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // Unix 秒
+	UpdatePassword bool                   `protobuf:"varint,5,opt,name=update_password,json=updatePassword,proto3" json:"update_password,omitempty"`
+	Password       string                 `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"` // 空串表示取消密码
+	UpdateLimits   bool                   `protobuf:"varint,7,opt,name=update_limits,json=updateLimits,proto3" json:"update_limits,omitempty"`
+	MaxDownloads   int64                  `protobuf:"varint,8,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`
+	MaxSaves       int64                  `protobuf:"varint,9,opt,name=max_saves,json=maxSaves,proto3" json:"max_saves,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateShareRequest) Reset() {
+	*x = UpdateShareRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShareRequest) ProtoMessage() {}
+
+func (x *UpdateShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShareRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShareRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *UpdateShareRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateShareRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *UpdateShareRequest) GetUpdateExpire() bool {
+	if x != nil {
+		return x.UpdateExpire
+	}
+	return false
+}
+
+func (x *UpdateShareRequest) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *UpdateShareRequest) GetUpdatePassword() bool {
+	if x != nil {
+		return x.UpdatePassword
+	}
+	return false
+}
+
+func (x *UpdateShareRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *UpdateShareRequest) GetUpdateLimits() bool {
+	if x != nil {
+		return x.UpdateLimits
+	}
+	return false
+}
+
+func (x *UpdateShareRequest) GetMaxDownloads() int64 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+func (x *UpdateShareRequest) GetMaxSaves() int64 {
+	if x != nil {
+		return x.MaxSaves
+	}
+	return 0
+}
+
+type UpdateShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *ShareSummary          `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShareResponse) Reset() {
+	*x = UpdateShareResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShareResponse) ProtoMessage() {}
+
+func (x *UpdateShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShareResponse.ProtoReflect.Descriptor instead.
+func (*UpdateShareResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *UpdateShareResponse) GetShare() *ShareSummary {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type ShareAccessLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"` // view/download/save
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 转存者ID, 匿名访问为 0
+	Ctime         int64                  `protobuf:"varint,4,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareAccessLog) Reset() {
+	*x = ShareAccessLog{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareAccessLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareAccessLog) ProtoMessage() {}
+
+func (x *ShareAccessLog) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareAccessLog.ProtoReflect.Descriptor instead.
+func (*ShareAccessLog) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ShareAccessLog) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ShareAccessLog) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *ShareAccessLog) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ShareAccessLog) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+type GetShareAccessLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShareId       string                 `protobuf:"bytes,2,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShareAccessLogRequest) Reset() {
+	*x = GetShareAccessLogRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShareAccessLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShareAccessLogRequest) ProtoMessage() {}
+
+func (x *GetShareAccessLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShareAccessLogRequest.ProtoReflect.Descriptor instead.
+func (*GetShareAccessLogRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetShareAccessLogRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetShareAccessLogRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *GetShareAccessLogRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetShareAccessLogRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetShareAccessLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*ShareAccessLog      `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShareAccessLogResponse) Reset() {
+	*x = GetShareAccessLogResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShareAccessLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShareAccessLogResponse) ProtoMessage() {}
+
+func (x *GetShareAccessLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShareAccessLogResponse.ProtoReflect.Descriptor instead.
+func (*GetShareAccessLogResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetShareAccessLogResponse) GetLogs() []*ShareAccessLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *GetShareAccessLogResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetUserFileStoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserFileStoreRequest) Reset() {
+	*x = GetUserFileStoreRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserFileStoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserFileStoreRequest) ProtoMessage() {}
+
+func (x *GetUserFileStoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserFileStoreRequest.ProtoReflect.Descriptor instead.
+func (*GetUserFileStoreRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetUserFileStoreRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetUserFileStoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileStore     *FileStore             `protobuf:"bytes,1,opt,name=file_store,json=fileStore,proto3" json:"file_store,omitempty"`
+	Plan          *StoragePlan           `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserFileStoreResponse) Reset() {
+	*x = GetUserFileStoreResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserFileStoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserFileStoreResponse) ProtoMessage() {}
+
+func (x *GetUserFileStoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserFileStoreResponse.ProtoReflect.Descriptor instead.
+func (*GetUserFileStoreResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetUserFileStoreResponse) GetFileStore() *FileStore {
+	if x != nil {
+		return x.FileStore
+	}
+	return nil
+}
+
+func (x *GetUserFileStoreResponse) GetPlan() *StoragePlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+type UpdateFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"` // 完整文件内容（用于全量更新，与changes字段二选一）
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,5,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                 // 设备ID
+	BaseVersion   int64                  `protobuf:"varint,6,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`       // 基础版本号，客户端基于哪个版本进行的修改
+	Changes       []*FileChange          `protobuf:"bytes,7,rep,name=changes,proto3" json:"changes,omitempty"`                                   // 文件变更列表（用于增量更新，与data字段二选一）
+	IsIncremental bool                   `protobuf:"varint,8,opt,name=is_incremental,json=isIncremental,proto3" json:"is_incremental,omitempty"` // 是否是增量更新
+	Hash          string                 `protobuf:"bytes,9,opt,name=hash,proto3" json:"hash,omitempty"`                                         // 全量更新时新内容的 MD5 或 SHA-256, 可选
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFileRequest) Reset() {
+	*x = UpdateFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFileRequest) ProtoMessage() {}
+
+func (x *UpdateFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *UpdateFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *UpdateFileRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateFileRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UpdateFileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateFileRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *UpdateFileRequest) GetBaseVersion() int64 {
+	if x != nil {
+		return x.BaseVersion
+	}
+	return 0
+}
+
+func (x *UpdateFileRequest) GetChanges() []*FileChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *UpdateFileRequest) GetIsIncremental() bool {
+	if x != nil {
+		return x.IsIncremental
+	}
+	return false
+}
+
+func (x *UpdateFileRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+// 文件变更记录
+type FileChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     ChangeOperation        `protobuf:"varint,1,opt,name=operation,proto3,enum=file.ChangeOperation" json:"operation,omitempty"` // 操作类型
+	Position      int64                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`                             // 变更的起始位置（字节偏移量）
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`                                 // 原内容长度（用于Delete和Update操作）
+	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`                                // 新内容（用于Insert和Update操作）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChange) Reset() {
+	*x = FileChange{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChange) ProtoMessage() {}
+
+func (x *FileChange) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChange.ProtoReflect.Descriptor instead.
+func (*FileChange) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *FileChange) GetOperation() ChangeOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return ChangeOperation_INSERT
+}
+
+func (x *FileChange) GetPosition() int64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *FileChange) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *FileChange) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// 不修改 UpdateFileResponse
+type UpdateFileResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	File            *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	HasConflict     bool                   `protobuf:"varint,2,opt,name=has_conflict,json=hasConflict,proto3" json:"has_conflict,omitempty"`            // 是否存在冲突
+	ConflictMessage string                 `protobuf:"bytes,3,opt,name=conflict_message,json=conflictMessage,proto3" json:"conflict_message,omitempty"` // 冲突信息
+	CurrentVersion  int64                  `protobuf:"varint,4,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`   // 当前最新版本号
+	NeededChanges   []*FileChange          `protobuf:"bytes,5,rep,name=needed_changes,json=neededChanges,proto3" json:"needed_changes,omitempty"`       // 客户端需要应用的变更（冲突时提供）
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateFileResponse) Reset() {
+	*x = UpdateFileResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFileResponse) ProtoMessage() {}
+
+func (x *UpdateFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFileResponse.ProtoReflect.Descriptor instead.
+func (*UpdateFileResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *UpdateFileResponse) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *UpdateFileResponse) GetHasConflict() bool {
+	if x != nil {
+		return x.HasConflict
+	}
+	return false
+}
+
+func (x *UpdateFileResponse) GetConflictMessage() string {
+	if x != nil {
+		return x.ConflictMessage
+	}
+	return ""
+}
+
+func (x *UpdateFileResponse) GetCurrentVersion() int64 {
+	if x != nil {
+		return x.CurrentVersion
+	}
+	return 0
+}
+
+func (x *UpdateFileResponse) GetNeededChanges() []*FileChange {
+	if x != nil {
+		return x.NeededChanges
+	}
+	return nil
+}
+
+type GetFileMetaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileMetaRequest) Reset() {
+	*x = GetFileMetaRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileMetaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileMetaRequest) ProtoMessage() {}
+
+func (x *GetFileMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileMetaRequest.ProtoReflect.Descriptor instead.
+func (*GetFileMetaRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetFileMetaRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *GetFileMetaRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetFileMetaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      map[string]*MetaValue  `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileMetaResponse) Reset() {
+	*x = GetFileMetaResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileMetaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileMetaResponse) ProtoMessage() {}
+
+func (x *GetFileMetaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileMetaResponse.ProtoReflect.Descriptor instead.
+func (*GetFileMetaResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetFileMetaResponse) GetMetadata() map[string]*MetaValue {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type SetFileMetaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Metadata      map[string]*MetaValue  `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 存在的 key 覆盖, 不存在的新增
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFileMetaRequest) Reset() {
+	*x = SetFileMetaRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFileMetaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFileMetaRequest) ProtoMessage() {}
+
+func (x *SetFileMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFileMetaRequest.ProtoReflect.Descriptor instead.
+func (*SetFileMetaRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *SetFileMetaRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *SetFileMetaRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetFileMetaRequest) GetMetadata() map[string]*MetaValue {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type SetFileMetaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      map[string]*MetaValue  `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFileMetaResponse) Reset() {
+	*x = SetFileMetaResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFileMetaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFileMetaResponse) ProtoMessage() {}
+
+func (x *SetFileMetaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFileMetaResponse.ProtoReflect.Descriptor instead.
+func (*SetFileMetaResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SetFileMetaResponse) GetMetadata() map[string]*MetaValue {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DeleteFileMetaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Keys          []string               `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileMetaRequest) Reset() {
+	*x = DeleteFileMetaRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileMetaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileMetaRequest) ProtoMessage() {}
+
+func (x *DeleteFileMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileMetaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileMetaRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *DeleteFileMetaRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *DeleteFileMetaRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeleteFileMetaRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type DeleteFileMetaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileMetaResponse) Reset() {
+	*x = DeleteFileMetaResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileMetaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileMetaResponse) ProtoMessage() {}
+
+func (x *DeleteFileMetaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileMetaResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFileMetaResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{74}
+}
+
+type CopyFileRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId         int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ToFolderId     int64                  `protobuf:"varint,3,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"`
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,4,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CopyFileRequest) Reset() {
+	*x = CopyFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFileRequest) ProtoMessage() {}
+
+func (x *CopyFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFileRequest.ProtoReflect.Descriptor instead.
+func (*CopyFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CopyFileRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CopyFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *CopyFileRequest) GetToFolderId() int64 {
+	if x != nil {
+		return x.ToFolderId
+	}
+	return 0
+}
+
+func (x *CopyFileRequest) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+type CopyFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Skipped       bool                   `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // 因同名被跳过
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyFileResponse) Reset() {
+	*x = CopyFileResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFileResponse) ProtoMessage() {}
+
+func (x *CopyFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFileResponse.ProtoReflect.Descriptor instead.
+func (*CopyFileResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *CopyFileResponse) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *CopyFileResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type CopyFolderRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId       int64                  `protobuf:"varint,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	ToFolderId     int64                  `protobuf:"varint,3,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"`
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,4,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CopyFolderRequest) Reset() {
+	*x = CopyFolderRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFolderRequest) ProtoMessage() {}
+
+func (x *CopyFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFolderRequest.ProtoReflect.Descriptor instead.
+func (*CopyFolderRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CopyFolderRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CopyFolderRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *CopyFolderRequest) GetToFolderId() int64 {
+	if x != nil {
+		return x.ToFolderId
+	}
+	return 0
+}
+
+func (x *CopyFolderRequest) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+type CopyFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`            // 同步完成时返回新文件夹
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // 目录较大时转为异步任务, 通过 GetJob 查询进度
+	Skipped       bool                   `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CopyFolderResponse) Reset() {
+	*x = CopyFolderResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CopyFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFolderResponse) ProtoMessage() {}
+
+func (x *CopyFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFolderResponse.ProtoReflect.Descriptor instead.
+func (*CopyFolderResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *CopyFolderResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+func (x *CopyFolderResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *CopyFolderResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetJobRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                      // pending processing completed failed
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                       // 需要处理的条目数
+	Done          int64                  `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`                         // 已处理的条目数
+	ResultId      int64                  `protobuf:"varint,6,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"` // 任务产生的文件(夹) ID
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *GetJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetJobResponse) GetDone() int64 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *GetJobResponse) GetResultId() int64 {
+	if x != nil {
+		return x.ResultId
+	}
+	return 0
+}
+
+func (x *GetJobResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          BatchItemType          `protobuf:"varint,1,opt,name=type,proto3,enum=file.BatchItemType" json:"type,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	NewName       string                 `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"` // 仅重命名使用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItem.ProtoReflect.Descriptor instead.
+func (*BatchItem) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *BatchItem) GetType() BatchItemType {
+	if x != nil {
+		return x.Type
+	}
+	return BatchItemType_BATCH_ITEM_FILE
+}
+
+func (x *BatchItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BatchItem) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+type BatchItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          BatchItemType          `protobuf:"varint,1,opt,name=type,proto3,enum=file.BatchItemType" json:"type,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Status        BatchItemStatus        `protobuf:"varint,3,opt,name=status,proto3,enum=file.BatchItemStatus" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`                 // 最终名称
+	NewId         int64                  `protobuf:"varint,6,opt,name=new_id,json=newId,proto3" json:"new_id,omitempty"` // 复制产生的新 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemResult) ProtoMessage() {}
+
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *BatchItemResult) GetType() BatchItemType {
+	if x != nil {
+		return x.Type
+	}
+	return BatchItemType_BATCH_ITEM_FILE
+}
+
+func (x *BatchItemResult) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetStatus() BatchItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchItemStatus_BATCH_ITEM_OK
+}
+
+func (x *BatchItemResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetNewId() int64 {
+	if x != nil {
+		return x.NewId
+	}
+	return 0
+}
+
+// 批量移动、复制、删除、恢复、重命名共用的请求
+type BatchOperationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items          []*BatchItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Mode           BatchMode              `protobuf:"varint,3,opt,name=mode,proto3,enum=file.BatchMode" json:"mode,omitempty"`
+	ToFolderId     int64                  `protobuf:"varint,4,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"` // 移动、复制的目标文件夹
+	ConflictPolicy NameConflictPolicy     `protobuf:"varint,5,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=file.NameConflictPolicy" json:"conflict_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchOperationRequest) Reset() {
+	*x = BatchOperationRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOperationRequest) ProtoMessage() {}
+
+func (x *BatchOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOperationRequest.ProtoReflect.Descriptor instead.
+func (*BatchOperationRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *BatchOperationRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *BatchOperationRequest) GetItems() []*BatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchOperationRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_BEST_EFFORT
+}
+
+func (x *BatchOperationRequest) GetToFolderId() int64 {
+	if x != nil {
+		return x.ToFolderId
+	}
+	return 0
+}
+
+func (x *BatchOperationRequest) GetConflictPolicy() NameConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return NameConflictPolicy_NAME_CONFLICT_FAIL
+}
+
+type BatchOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchItemResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Succeeded     int32                  `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchOperationResponse) Reset() {
+	*x = BatchOperationResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOperationResponse) ProtoMessage() {}
+
+func (x *BatchOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOperationResponse.ProtoReflect.Descriptor instead.
+func (*BatchOperationResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *BatchOperationResponse) GetResults() []*BatchItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchOperationResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BatchOperationResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// 路径以 / 分隔, 名称中的 / 和 \ 需以 \ 转义, 如 /a\/b/c.txt
+type ResolvePathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	RootId        int64                  `protobuf:"varint,3,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"` // 路径相对的文件夹, 可以是共享给用户或团队空间中的文件夹, 0 表示用户的根目录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvePathRequest) Reset() {
+	*x = ResolvePathRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePathRequest) ProtoMessage() {}
+
+func (x *ResolvePathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePathRequest.ProtoReflect.Descriptor instead.
+func (*ResolvePathRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ResolvePathRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ResolvePathRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ResolvePathRequest) GetRootId() int64 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+// file 与 folder 只会设置其一, 根目录返回 id 为 0 的 folder
+type ResolvePathResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Folder        *Folder                `protobuf:"bytes,2,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvePathResponse) Reset() {
+	*x = ResolvePathResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePathResponse) ProtoMessage() {}
+
+func (x *ResolvePathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePathResponse.ProtoReflect.Descriptor instead.
+func (*ResolvePathResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ResolvePathResponse) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *ResolvePathResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type ListPathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	RootId        int64                  `protobuf:"varint,3,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"` // 路径相对的文件夹, 可以是共享给用户或团队空间中的文件夹, 0 表示用户的根目录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPathRequest) Reset() {
+	*x = ListPathRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPathRequest) ProtoMessage() {}
+
+func (x *ListPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPathRequest.ProtoReflect.Descriptor instead.
+func (*ListPathRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListPathRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListPathRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ListPathRequest) GetRootId() int64 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+type DeletePathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	RootId        int64                  `protobuf:"varint,3,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"` // 路径相对的文件夹, 可以是共享给用户或团队空间中的文件夹, 0 表示用户的根目录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePathRequest) Reset() {
+	*x = DeletePathRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePathRequest) ProtoMessage() {}
+
+func (x *DeletePathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePathRequest.ProtoReflect.Descriptor instead.
+func (*DeletePathRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *DeletePathRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeletePathRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DeletePathRequest) GetRootId() int64 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+type DeletePathResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePathResponse) Reset() {
+	*x = DeletePathResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePathResponse) ProtoMessage() {}
+
+func (x *DeletePathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePathResponse.ProtoReflect.Descriptor instead.
+func (*DeletePathResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{89}
+}
+
+type EnsureFolderPathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnsureFolderPathRequest) Reset() {
+	*x = EnsureFolderPathRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnsureFolderPathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnsureFolderPathRequest) ProtoMessage() {}
+
+func (x *EnsureFolderPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnsureFolderPathRequest.ProtoReflect.Descriptor instead.
+func (*EnsureFolderPathRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *EnsureFolderPathRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *EnsureFolderPathRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type EnsureFolderPathResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"` // 新创建的文件夹数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnsureFolderPathResponse) Reset() {
+	*x = EnsureFolderPathResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnsureFolderPathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnsureFolderPathResponse) ProtoMessage() {}
+
+func (x *EnsureFolderPathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnsureFolderPathResponse.ProtoReflect.Descriptor instead.
+func (*EnsureFolderPathResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *EnsureFolderPathResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+func (x *EnsureFolderPathResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+type GetFolderTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RootId        int64                  `protobuf:"varint,2,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"` // 0 表示根目录
+	Depth         int32                  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`                 // 加载的层数, 0 表示不限
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFolderTreeRequest) Reset() {
+	*x = GetFolderTreeRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFolderTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFolderTreeRequest) ProtoMessage() {}
+
+func (x *GetFolderTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFolderTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetFolderTreeRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetFolderTreeRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetFolderTreeRequest) GetRootId() int64 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+func (x *GetFolderTreeRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type GetFolderTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Root          *FolderNode            `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Truncated     bool                   `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"` // 文件夹过多时只返回部分
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFolderTreeResponse) Reset() {
+	*x = GetFolderTreeResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFolderTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFolderTreeResponse) ProtoMessage() {}
+
+func (x *GetFolderTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFolderTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetFolderTreeResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetFolderTreeResponse) GetRoot() *FolderNode {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *GetFolderTreeResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// 中止分片上传, 丢弃已上传的分片并释放预留的空间
+type AbortUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UploadId      string                 `protobuf:"bytes,2,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadRequest) Reset() {
+	*x = AbortUploadRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadRequest) ProtoMessage() {}
+
+func (x *AbortUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadRequest.ProtoReflect.Descriptor instead.
+func (*AbortUploadRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *AbortUploadRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AbortUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *AbortUploadRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type AbortUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadResponse) Reset() {
+	*x = AbortUploadResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadResponse) ProtoMessage() {}
+
+func (x *AbortUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadResponse.ProtoReflect.Descriptor instead.
+func (*AbortUploadResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{95}
+}
+
+// 获取分片上传已上传的分片, 用于续传
+type ListUploadPartsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UploadId      string                 `protobuf:"bytes,2,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUploadPartsRequest) Reset() {
+	*x = ListUploadPartsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUploadPartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUploadPartsRequest) ProtoMessage() {}
+
+func (x *ListUploadPartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUploadPartsRequest.ProtoReflect.Descriptor instead.
+func (*ListUploadPartsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ListUploadPartsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListUploadPartsRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type ListUploadPartsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumbers   []int32                `protobuf:"varint,1,rep,packed,name=part_numbers,json=partNumbers,proto3" json:"part_numbers,omitempty"` // 升序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUploadPartsResponse) Reset() {
+	*x = ListUploadPartsResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUploadPartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUploadPartsResponse) ProtoMessage() {}
+
+func (x *ListUploadPartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUploadPartsResponse.ProtoReflect.Descriptor instead.
+func (*ListUploadPartsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *ListUploadPartsResponse) GetPartNumbers() []int32 {
+	if x != nil {
+		return x.PartNumbers
+	}
+	return nil
+}
+
+// 重新计算用户的空间使用量, user_id 为 0 时处理全部用户
+type ReconcileQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Fix           bool                   `protobuf:"varint,2,opt,name=fix,proto3" json:"fix,omitempty"` // 是否修正记录值, 否则只报告偏差
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileQuotaRequest) Reset() {
+	*x = ReconcileQuotaRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileQuotaRequest) ProtoMessage() {}
+
+func (x *ReconcileQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileQuotaRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ReconcileQuotaRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReconcileQuotaRequest) GetFix() bool {
+	if x != nil {
+		return x.Fix
+	}
+	return false
+}
+
+type QuotaUsage struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	UserId              int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Capacity            int64                  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	RecordedSize        int64                  `protobuf:"varint,3,opt,name=recorded_size,json=recordedSize,proto3" json:"recorded_size,omitempty"`                      // 记录的已用空间
+	ActualSize          int64                  `protobuf:"varint,4,opt,name=actual_size,json=actualSize,proto3" json:"actual_size,omitempty"`                            // 由文件和历史版本计算出的已用空间
+	RecordedReserved    int64                  `protobuf:"varint,5,opt,name=recorded_reserved,json=recordedReserved,proto3" json:"recorded_reserved,omitempty"`          // 记录的预留空间
+	ActualReserved      int64                  `protobuf:"varint,6,opt,name=actual_reserved,json=actualReserved,proto3" json:"actual_reserved,omitempty"`                // 预留之和, 含已过期尚未回收的预留
+	ExpiredReservations int32                  `protobuf:"varint,7,opt,name=expired_reservations,json=expiredReservations,proto3" json:"expired_reservations,omitempty"` // 已过期尚待回收的预留数
+	FoldersFixed        int32                  `protobuf:"varint,8,opt,name=folders_fixed,json=foldersFixed,proto3" json:"folders_fixed,omitempty"`                      // 修正了统计的文件夹数
+	Fixed               bool                   `protobuf:"varint,9,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *QuotaUsage) Reset() {
+	*x = QuotaUsage{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotaUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaUsage) ProtoMessage() {}
+
+func (x *QuotaUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaUsage.ProtoReflect.Descriptor instead.
+func (*QuotaUsage) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *QuotaUsage) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetRecordedSize() int64 {
+	if x != nil {
+		return x.RecordedSize
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetActualSize() int64 {
+	if x != nil {
+		return x.ActualSize
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetRecordedReserved() int64 {
+	if x != nil {
+		return x.RecordedReserved
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetActualReserved() int64 {
+	if x != nil {
+		return x.ActualReserved
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetExpiredReservations() int32 {
+	if x != nil {
+		return x.ExpiredReservations
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetFoldersFixed() int32 {
+	if x != nil {
+		return x.FoldersFixed
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetFixed() bool {
+	if x != nil {
+		return x.Fixed
+	}
+	return false
+}
+
+type ReconcileQuotaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         *QuotaUsage            `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"`              // 单个用户时同步返回
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // 全部用户时转为异步任务, 通过 GetJob 查询进度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileQuotaResponse) Reset() {
+	*x = ReconcileQuotaResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileQuotaResponse) ProtoMessage() {}
+
+func (x *ReconcileQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileQuotaResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ReconcileQuotaResponse) GetUsage() *QuotaUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *ReconcileQuotaResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// 创建或更新存储套餐, id 为 0 时创建
+type SavePlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plan          *StoragePlan           `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavePlanRequest) Reset() {
+	*x = SavePlanRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePlanRequest) ProtoMessage() {}
+
+func (x *SavePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePlanRequest.ProtoReflect.Descriptor instead.
+func (*SavePlanRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *SavePlanRequest) GetPlan() *StoragePlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+type SavePlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plan          *StoragePlan           `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavePlanResponse) Reset() {
+	*x = SavePlanResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavePlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavePlanResponse) ProtoMessage() {}
+
+func (x *SavePlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavePlanResponse.ProtoReflect.Descriptor instead.
+func (*SavePlanResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *SavePlanResponse) GetPlan() *StoragePlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+type ListPlansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlansRequest) Reset() {
+	*x = ListPlansRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlansRequest) ProtoMessage() {}
+
+func (x *ListPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlansRequest.ProtoReflect.Descriptor instead.
+func (*ListPlansRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{103}
+}
+
+type ListPlansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plans         []*StoragePlan         `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlansResponse) Reset() {
+	*x = ListPlansResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlansResponse) ProtoMessage() {}
+
+func (x *ListPlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlansResponse.ProtoReflect.Descriptor instead.
+func (*ListPlansResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *ListPlansResponse) GetPlans() []*StoragePlan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+type AssignPlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId        int64                  `protobuf:"varint,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignPlanRequest) Reset() {
+	*x = AssignPlanRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignPlanRequest) ProtoMessage() {}
+
+func (x *AssignPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignPlanRequest.ProtoReflect.Descriptor instead.
+func (*AssignPlanRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *AssignPlanRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AssignPlanRequest) GetPlanId() int64 {
+	if x != nil {
+		return x.PlanId
+	}
+	return 0
+}
+
+type AssignPlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileStore     *FileStore             `protobuf:"bytes,1,opt,name=file_store,json=fileStore,proto3" json:"file_store,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignPlanResponse) Reset() {
+	*x = AssignPlanResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignPlanResponse) ProtoMessage() {}
+
+func (x *AssignPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignPlanResponse.ProtoReflect.Descriptor instead.
+func (*AssignPlanResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *AssignPlanResponse) GetFileStore() *FileStore {
+	if x != nil {
+		return x.FileStore
+	}
+	return nil
+}
+
+// 为用户增加临时容量, 到期后自动收回
+type GrantCapacityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ExpireAt      int64                  `protobuf:"varint,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // 到期时间, Unix 秒
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantCapacityRequest) Reset() {
+	*x = GrantCapacityRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantCapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantCapacityRequest) ProtoMessage() {}
+
+func (x *GrantCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantCapacityRequest.ProtoReflect.Descriptor instead.
+func (*GrantCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *GrantCapacityRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GrantCapacityRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GrantCapacityRequest) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *GrantCapacityRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GrantCapacityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GrantId       int64                  `protobuf:"varint,1,opt,name=grant_id,json=grantId,proto3" json:"grant_id,omitempty"`
+	FileStore     *FileStore             `protobuf:"bytes,2,opt,name=file_store,json=fileStore,proto3" json:"file_store,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantCapacityResponse) Reset() {
+	*x = GrantCapacityResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantCapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantCapacityResponse) ProtoMessage() {}
+
+func (x *GrantCapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantCapacityResponse.ProtoReflect.Descriptor instead.
+func (*GrantCapacityResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *GrantCapacityResponse) GetGrantId() int64 {
+	if x != nil {
+		return x.GrantId
+	}
+	return 0
+}
+
+func (x *GrantCapacityResponse) GetFileStore() *FileStore {
+	if x != nil {
+		return x.FileStore
+	}
+	return nil
+}
+
+// 按用量比例从高到低列出用量达到阈值的用户
+type ListUsersNearQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percent       int32                  `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"` // 用量阈值(百分比), 0 时默认为 80
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersNearQuotaRequest) Reset() {
+	*x = ListUsersNearQuotaRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersNearQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersNearQuotaRequest) ProtoMessage() {}
+
+func (x *ListUsersNearQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersNearQuotaRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersNearQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *ListUsersNearQuotaRequest) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *ListUsersNearQuotaRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersNearQuotaRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListUsersNearQuotaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileStores    []*FileStore           `protobuf:"bytes,1,rep,name=file_stores,json=fileStores,proto3" json:"file_stores,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersNearQuotaResponse) Reset() {
+	*x = ListUsersNearQuotaResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersNearQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersNearQuotaResponse) ProtoMessage() {}
+
+func (x *ListUsersNearQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersNearQuotaResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersNearQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *ListUsersNearQuotaResponse) GetFileStores() []*FileStore {
+	if x != nil {
+		return x.FileStores
+	}
+	return nil
+}
+
+func (x *ListUsersNearQuotaResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type Collaborator struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          AclRole                `protobuf:"varint,2,opt,name=role,proto3,enum=file.AclRole" json:"role,omitempty"`
+	Ctime         int64                  `protobuf:"varint,3,opt,name=ctime,proto3" json:"ctime,omitempty"` // 授权时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collaborator) Reset() {
+	*x = Collaborator{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collaborator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collaborator) ProtoMessage() {}
+
+func (x *Collaborator) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collaborator.ProtoReflect.Descriptor instead.
+func (*Collaborator) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *Collaborator) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Collaborator) GetRole() AclRole {
+	if x != nil {
+		return x.Role
+	}
+	return AclRole_ACL_ROLE_NONE
+}
+
+func (x *Collaborator) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+// 将文件夹或文件共享给其他用户, 已共享时修改角色, 只有所有者可以操作
+// 文件夹上的授权向下继承到整个子树
+type ShareWithUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 所有者ID
+	IsFolder      bool                   `protobuf:"varint,2,opt,name=is_folder,json=isFolder,proto3" json:"is_folder,omitempty"`
+	ResourceId    int64                  `protobuf:"varint,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	TargetUserId  int32                  `protobuf:"varint,4,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"` // 被共享的用户, 网关按用户名或手机号查找
+	Role          AclRole                `protobuf:"varint,5,opt,name=role,proto3,enum=file.AclRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareWithUserRequest) Reset() {
+	*x = ShareWithUserRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareWithUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareWithUserRequest) ProtoMessage() {}
+
+func (x *ShareWithUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareWithUserRequest.ProtoReflect.Descriptor instead.
+func (*ShareWithUserRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ShareWithUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ShareWithUserRequest) GetIsFolder() bool {
+	if x != nil {
+		return x.IsFolder
+	}
+	return false
+}
+
+func (x *ShareWithUserRequest) GetResourceId() int64 {
+	if x != nil {
+		return x.ResourceId
+	}
+	return 0
+}
+
+func (x *ShareWithUserRequest) GetTargetUserId() int32 {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return 0
+}
+
+func (x *ShareWithUserRequest) GetRole() AclRole {
+	if x != nil {
+		return x.Role
+	}
+	return AclRole_ACL_ROLE_NONE
+}
+
+type ShareWithUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collaborator  *Collaborator          `protobuf:"bytes,1,opt,name=collaborator,proto3" json:"collaborator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareWithUserResponse) Reset() {
+	*x = ShareWithUserResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareWithUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareWithUserResponse) ProtoMessage() {}
+
+func (x *ShareWithUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareWithUserResponse.ProtoReflect.Descriptor instead.
+func (*ShareWithUserResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *ShareWithUserResponse) GetCollaborator() *Collaborator {
+	if x != nil {
+		return x.Collaborator
+	}
+	return nil
+}
+
+// 收回共享, 所有者可收回任何协作者, 协作者可退出共享
+type RevokeUserShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsFolder      bool                   `protobuf:"varint,2,opt,name=is_folder,json=isFolder,proto3" json:"is_folder,omitempty"`
+	ResourceId    int64                  `protobuf:"varint,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	TargetUserId  int32                  `protobuf:"varint,4,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeUserShareRequest) Reset() {
+	*x = RevokeUserShareRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUserShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUserShareRequest) ProtoMessage() {}
+
+func (x *RevokeUserShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUserShareRequest.ProtoReflect.Descriptor instead.
+func (*RevokeUserShareRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *RevokeUserShareRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RevokeUserShareRequest) GetIsFolder() bool {
+	if x != nil {
+		return x.IsFolder
+	}
+	return false
+}
+
+func (x *RevokeUserShareRequest) GetResourceId() int64 {
+	if x != nil {
+		return x.ResourceId
+	}
+	return 0
+}
+
+func (x *RevokeUserShareRequest) GetTargetUserId() int32 {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return 0
+}
+
+type RevokeUserShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeUserShareResponse) Reset() {
+	*x = RevokeUserShareResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeUserShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeUserShareResponse) ProtoMessage() {}
+
+func (x *RevokeUserShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeUserShareResponse.ProtoReflect.Descriptor instead.
+func (*RevokeUserShareResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{115}
+}
+
+// 获取条目的所有者和直接授权的协作者, 有浏览权限即可查看
+type ListCollaboratorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsFolder      bool                   `protobuf:"varint,2,opt,name=is_folder,json=isFolder,proto3" json:"is_folder,omitempty"`
+	ResourceId    int64                  `protobuf:"varint,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollaboratorsRequest) Reset() {
+	*x = ListCollaboratorsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollaboratorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollaboratorsRequest) ProtoMessage() {}
+
+func (x *ListCollaboratorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollaboratorsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollaboratorsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListCollaboratorsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListCollaboratorsRequest) GetIsFolder() bool {
+	if x != nil {
+		return x.IsFolder
+	}
+	return false
+}
+
+func (x *ListCollaboratorsRequest) GetResourceId() int64 {
+	if x != nil {
+		return x.ResourceId
+	}
+	return 0
+}
+
+type ListCollaboratorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int32                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Collaborators []*Collaborator        `protobuf:"bytes,2,rep,name=collaborators,proto3" json:"collaborators,omitempty"`
+	MyRole        AclRole                `protobuf:"varint,3,opt,name=my_role,json=myRole,proto3,enum=file.AclRole" json:"my_role,omitempty"` // 调用者的角色, 含继承的授权
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollaboratorsResponse) Reset() {
+	*x = ListCollaboratorsResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollaboratorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollaboratorsResponse) ProtoMessage() {}
+
+func (x *ListCollaboratorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollaboratorsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollaboratorsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ListCollaboratorsResponse) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *ListCollaboratorsResponse) GetCollaborators() []*Collaborator {
+	if x != nil {
+		return x.Collaborators
+	}
+	return nil
+}
+
+func (x *ListCollaboratorsResponse) GetMyRole() AclRole {
+	if x != nil {
+		return x.MyRole
+	}
+	return AclRole_ACL_ROLE_NONE
+}
+
+type SharedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"` // 与 file 二选一
+	File          *File                  `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	OwnerId       int32                  `protobuf:"varint,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Role          AclRole                `protobuf:"varint,4,opt,name=role,proto3,enum=file.AclRole" json:"role,omitempty"`
+	Ctime         int64                  `protobuf:"varint,5,opt,name=ctime,proto3" json:"ctime,omitempty"` // 授权时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedItem) Reset() {
+	*x = SharedItem{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedItem) ProtoMessage() {}
+
+func (x *SharedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedItem.ProtoReflect.Descriptor instead.
+func (*SharedItem) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *SharedItem) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+func (x *SharedItem) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *SharedItem) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *SharedItem) GetRole() AclRole {
+	if x != nil {
+		return x.Role
+	}
+	return AclRole_ACL_ROLE_NONE
+}
+
+func (x *SharedItem) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+// 与我共享, 只列出直接共享给我的条目, 其下的内容通过 ListFolder 浏览
+type ListSharedWithMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeRequest) Reset() {
+	*x = ListSharedWithMeRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeRequest) ProtoMessage() {}
+
+func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeRequest.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *ListSharedWithMeRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListSharedWithMeRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSharedWithMeRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListSharedWithMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SharedItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeResponse) Reset() {
+	*x = ListSharedWithMeResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeResponse) ProtoMessage() {}
+
+func (x *ListSharedWithMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeResponse.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *ListSharedWithMeResponse) GetItems() []*SharedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListSharedWithMeResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 团队空间, 空间内条目的 user_id 为负的团队ID
+type TeamSpace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        int32                  `protobuf:"varint,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RootFolderId  int64                  `protobuf:"varint,3,opt,name=root_folder_id,json=rootFolderId,proto3" json:"root_folder_id,omitempty"`
+	FileStore     *FileStore             `protobuf:"bytes,4,opt,name=file_store,json=fileStore,proto3" json:"file_store,omitempty"`
+	Ctime         int64                  `protobuf:"varint,5,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamSpace) Reset() {
+	*x = TeamSpace{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamSpace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamSpace) ProtoMessage() {}
+
+func (x *TeamSpace) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamSpace.ProtoReflect.Descriptor instead.
+func (*TeamSpace) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *TeamSpace) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *TeamSpace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TeamSpace) GetRootFolderId() int64 {
+	if x != nil {
+		return x.RootFolderId
+	}
+	return 0
+}
+
+func (x *TeamSpace) GetFileStore() *FileStore {
+	if x != nil {
+		return x.FileStore
+	}
+	return nil
+}
+
+func (x *TeamSpace) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+type SpaceActivity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 操作者
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	ItemType      string                 `protobuf:"bytes,4,opt,name=item_type,json=itemType,proto3" json:"item_type,omitempty"` // file/folder
+	ItemId        int64                  `protobuf:"varint,5,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Name          string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	Ctime         int64                  `protobuf:"varint,7,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpaceActivity) Reset() {
+	*x = SpaceActivity{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceActivity) ProtoMessage() {}
+
+func (x *SpaceActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceActivity.ProtoReflect.Descriptor instead.
+func (*SpaceActivity) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *SpaceActivity) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SpaceActivity) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SpaceActivity) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *SpaceActivity) GetItemType() string {
+	if x != nil {
+		return x.ItemType
+	}
+	return ""
+}
+
+func (x *SpaceActivity) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *SpaceActivity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SpaceActivity) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+// 由用户服务在创建团队时调用
+type CreateTeamSpaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        int32                  `protobuf:"varint,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OwnerId       int32                  `protobuf:"varint,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamSpaceRequest) Reset() {
+	*x = CreateTeamSpaceRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamSpaceRequest) ProtoMessage() {}
+
+func (x *CreateTeamSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamSpaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *CreateTeamSpaceRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *CreateTeamSpaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTeamSpaceRequest) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+type CreateTeamSpaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Space         *TeamSpace             `protobuf:"bytes,1,opt,name=space,proto3" json:"space,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamSpaceResponse) Reset() {
+	*x = CreateTeamSpaceResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamSpaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamSpaceResponse) ProtoMessage() {}
+
+func (x *CreateTeamSpaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamSpaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateTeamSpaceResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *CreateTeamSpaceResponse) GetSpace() *TeamSpace {
+	if x != nil {
+		return x.Space
+	}
+	return nil
+}
+
+// 由用户服务在成员变更时调用, role 为 0 表示移除
+type SetSpaceMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        int32                  `protobuf:"varint,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          int32                  `protobuf:"varint,3,opt,name=role,proto3" json:"role,omitempty"`                               // 1-成员 2-管理员 3-所有者
+	OperatorId    int32                  `protobuf:"varint,4,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"` // 发起变更的成员, 须有权授予该角色
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSpaceMemberRequest) Reset() {
+	*x = SetSpaceMemberRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSpaceMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSpaceMemberRequest) ProtoMessage() {}
+
+func (x *SetSpaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSpaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*SetSpaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *SetSpaceMemberRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *SetSpaceMemberRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetSpaceMemberRequest) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+func (x *SetSpaceMemberRequest) GetOperatorId() int32 {
+	if x != nil {
+		return x.OperatorId
+	}
+	return 0
+}
+
+type SetSpaceMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSpaceMemberResponse) Reset() {
+	*x = SetSpaceMemberResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSpaceMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSpaceMemberResponse) ProtoMessage() {}
+
+func (x *SetSpaceMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSpaceMemberResponse.ProtoReflect.Descriptor instead.
+func (*SetSpaceMemberResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{126}
+}
+
+type GetTeamSpaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        int32                  `protobuf:"varint,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamSpaceRequest) Reset() {
+	*x = GetTeamSpaceRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamSpaceRequest) ProtoMessage() {}
+
+func (x *GetTeamSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamSpaceRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *GetTeamSpaceRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *GetTeamSpaceRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetTeamSpaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Space         *TeamSpace             `protobuf:"bytes,1,opt,name=space,proto3" json:"space,omitempty"`
+	Role          int32                  `protobuf:"varint,2,opt,name=role,proto3" json:"role,omitempty"` // 当前用户在团队中的角色
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamSpaceResponse) Reset() {
+	*x = GetTeamSpaceResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamSpaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamSpaceResponse) ProtoMessage() {}
+
+func (x *GetTeamSpaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamSpaceResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamSpaceResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *GetTeamSpaceResponse) GetSpace() *TeamSpace {
+	if x != nil {
+		return x.Space
+	}
+	return nil
+}
+
+func (x *GetTeamSpaceResponse) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+type ListSpaceActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        int32                  `protobuf:"varint,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSpaceActivityRequest) Reset() {
+	*x = ListSpaceActivityRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSpaceActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpaceActivityRequest) ProtoMessage() {}
+
+func (x *ListSpaceActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpaceActivityRequest.ProtoReflect.Descriptor instead.
+func (*ListSpaceActivityRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *ListSpaceActivityRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *ListSpaceActivityRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListSpaceActivityRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSpaceActivityRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListSpaceActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activities    []*SpaceActivity       `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSpaceActivityResponse) Reset() {
+	*x = ListSpaceActivityResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSpaceActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpaceActivityResponse) ProtoMessage() {}
+
+func (x *ListSpaceActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpaceActivityResponse.ProtoReflect.Descriptor instead.
+func (*ListSpaceActivityResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *ListSpaceActivityResponse) GetActivities() []*SpaceActivity {
+	if x != nil {
+		return x.Activities
+	}
+	return nil
+}
+
+func (x *ListSpaceActivityResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 文件收集链接, 匿名用户只能向目标文件夹上传, 看不到其中的内容
+type FileRequestInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	FolderId      int64                  `protobuf:"varint,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	HasPassword   bool                   `protobuf:"varint,5,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	ExpireAt      int64                  `protobuf:"varint,6,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`               // Unix 秒, 0 表示不限
+	MaxFileSize   int64                  `protobuf:"varint,7,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`    // 0 表示不限
+	MaxFiles      int64                  `protobuf:"varint,8,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`               // 0 表示不限
+	MaxTotalSize  int64                  `protobuf:"varint,9,opt,name=max_total_size,json=maxTotalSize,proto3" json:"max_total_size,omitempty"` // 0 表示不限
+	AllowedExts   []string               `protobuf:"bytes,10,rep,name=allowed_exts,json=allowedExts,proto3" json:"allowed_exts,omitempty"`      // 为空表示不限
+	Status        int32                  `protobuf:"varint,11,opt,name=status,proto3" json:"status,omitempty"`                                  // 1-收集中 2-已关闭
+	FileCount     int64                  `protobuf:"varint,12,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	TotalSize     int64                  `protobuf:"varint,13,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	Ctime         int64                  `protobuf:"varint,14,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileRequestInfo) Reset() {
+	*x = FileRequestInfo{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileRequestInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRequestInfo) ProtoMessage() {}
+
+func (x *FileRequestInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRequestInfo.ProtoReflect.Descriptor instead.
+func (*FileRequestInfo) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *FileRequestInfo) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *FileRequestInfo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *FileRequestInfo) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *FileRequestInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *FileRequestInfo) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *FileRequestInfo) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *FileRequestInfo) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *FileRequestInfo) GetMaxFiles() int64 {
+	if x != nil {
+		return x.MaxFiles
+	}
+	return 0
+}
+
+func (x *FileRequestInfo) GetMaxTotalSize() int64 {
+	if x != nil {
+		return x.MaxTotalSize
+	}
+	return 0
+}
+
+func (x *FileRequestInfo) GetAllowedExts() []string {
+	if x != nil {
+		return x.AllowedExts
+	}
+	return nil
+}
+
+func (x *FileRequestInfo) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *FileRequestInfo) GetFileCount() int64 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *FileRequestInfo) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *FileRequestInfo) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+// 上传页面可见的信息, 不包含所有者和目标文件夹
+type PublicFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	HasPassword   bool                   `protobuf:"varint,2,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	ExpireAt      int64                  `protobuf:"varint,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	MaxFileSize   int64                  `protobuf:"varint,4,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	MaxFiles      int64                  `protobuf:"varint,5,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxTotalSize  int64                  `protobuf:"varint,6,opt,name=max_total_size,json=maxTotalSize,proto3" json:"max_total_size,omitempty"`
+	AllowedExts   []string               `protobuf:"bytes,7,rep,name=allowed_exts,json=allowedExts,proto3" json:"allowed_exts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicFileRequest) Reset() {
+	*x = PublicFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicFileRequest) ProtoMessage() {}
+
+func (x *PublicFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicFileRequest.ProtoReflect.Descriptor instead.
+func (*PublicFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *PublicFileRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PublicFileRequest) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *PublicFileRequest) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *PublicFileRequest) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *PublicFileRequest) GetMaxFiles() int64 {
+	if x != nil {
+		return x.MaxFiles
+	}
+	return 0
+}
+
+func (x *PublicFileRequest) GetMaxTotalSize() int64 {
+	if x != nil {
+		return x.MaxTotalSize
+	}
+	return 0
+}
+
+func (x *PublicFileRequest) GetAllowedExts() []string {
+	if x != nil {
+		return x.AllowedExts
+	}
+	return nil
+}
+
+type FileRequestUpload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Uploader      string                 `protobuf:"bytes,4,opt,name=uploader,proto3" json:"uploader,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Ip            string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	Ctime         int64                  `protobuf:"varint,7,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileRequestUpload) Reset() {
+	*x = FileRequestUpload{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileRequestUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRequestUpload) ProtoMessage() {}
+
+func (x *FileRequestUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRequestUpload.ProtoReflect.Descriptor instead.
+func (*FileRequestUpload) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *FileRequestUpload) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FileRequestUpload) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *FileRequestUpload) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileRequestUpload) GetUploader() string {
+	if x != nil {
+		return x.Uploader
+	}
+	return ""
+}
+
+func (x *FileRequestUpload) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileRequestUpload) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *FileRequestUpload) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+type CreateFileRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId      int64                  `protobuf:"varint,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	ExpireAt      int64                  `protobuf:"varint,5,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	MaxFileSize   int64                  `protobuf:"varint,6,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	MaxFiles      int64                  `protobuf:"varint,7,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxTotalSize  int64                  `protobuf:"varint,8,opt,name=max_total_size,json=maxTotalSize,proto3" json:"max_total_size,omitempty"`
+	AllowedExts   []string               `protobuf:"bytes,9,rep,name=allowed_exts,json=allowedExts,proto3" json:"allowed_exts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFileRequestRequest) Reset() {
+	*x = CreateFileRequestRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFileRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFileRequestRequest) ProtoMessage() {}
+
+func (x *CreateFileRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFileRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreateFileRequestRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *CreateFileRequestRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateFileRequestRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *CreateFileRequestRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateFileRequestRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateFileRequestRequest) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+func (x *CreateFileRequestRequest) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *CreateFileRequestRequest) GetMaxFiles() int64 {
+	if x != nil {
+		return x.MaxFiles
+	}
+	return 0
+}
+
+func (x *CreateFileRequestRequest) GetMaxTotalSize() int64 {
+	if x != nil {
+		return x.MaxTotalSize
+	}
+	return 0
+}
+
+func (x *CreateFileRequestRequest) GetAllowedExts() []string {
+	if x != nil {
+		return x.AllowedExts
+	}
+	return nil
+}
+
+type CreateFileRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *FileRequestInfo       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFileRequestResponse) Reset() {
+	*x = CreateFileRequestResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFileRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFileRequestResponse) ProtoMessage() {}
+
+func (x *CreateFileRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFileRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreateFileRequestResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *CreateFileRequestResponse) GetRequest() *FileRequestInfo {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type ListFileRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileRequestsRequest) Reset() {
+	*x = ListFileRequestsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileRequestsRequest) ProtoMessage() {}
+
+func (x *ListFileRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListFileRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *ListFileRequestsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListFileRequestsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFileRequestsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListFileRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*FileRequestInfo     `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileRequestsResponse) Reset() {
+	*x = ListFileRequestsResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileRequestsResponse) ProtoMessage() {}
+
+func (x *ListFileRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListFileRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *ListFileRequestsResponse) GetRequests() []*FileRequestInfo {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *ListFileRequestsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CloseFileRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestIds    []string               `protobuf:"bytes,2,rep,name=request_ids,json=requestIds,proto3" json:"request_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseFileRequestsRequest) Reset() {
+	*x = CloseFileRequestsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseFileRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseFileRequestsRequest) ProtoMessage() {}
+
+func (x *CloseFileRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseFileRequestsRequest.ProtoReflect.Descriptor instead.
+func (*CloseFileRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *CloseFileRequestsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CloseFileRequestsRequest) GetRequestIds() []string {
+	if x != nil {
+		return x.RequestIds
+	}
+	return nil
+}
+
+type CloseFileRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Closed        int64                  `protobuf:"varint,1,opt,name=closed,proto3" json:"closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseFileRequestsResponse) Reset() {
+	*x = CloseFileRequestsResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseFileRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseFileRequestsResponse) ProtoMessage() {}
+
+func (x *CloseFileRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseFileRequestsResponse.ProtoReflect.Descriptor instead.
+func (*CloseFileRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *CloseFileRequestsResponse) GetClosed() int64 {
+	if x != nil {
+		return x.Closed
+	}
+	return 0
+}
+
+type ListFileRequestUploadsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileRequestUploadsRequest) Reset() {
+	*x = ListFileRequestUploadsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileRequestUploadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileRequestUploadsRequest) ProtoMessage() {}
+
+func (x *ListFileRequestUploadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileRequestUploadsRequest.ProtoReflect.Descriptor instead.
+func (*ListFileRequestUploadsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *ListFileRequestUploadsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListFileRequestUploadsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ListFileRequestUploadsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFileRequestUploadsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListFileRequestUploadsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uploads       []*FileRequestUpload   `protobuf:"bytes,1,rep,name=uploads,proto3" json:"uploads,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileRequestUploadsResponse) Reset() {
+	*x = ListFileRequestUploadsResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileRequestUploadsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileRequestUploadsResponse) ProtoMessage() {}
+
+func (x *ListFileRequestUploadsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileRequestUploadsResponse.ProtoReflect.Descriptor instead.
+func (*ListFileRequestUploadsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *ListFileRequestUploadsResponse) GetUploads() []*FileRequestUpload {
+	if x != nil {
+		return x.Uploads
+	}
+	return nil
+}
+
+func (x *ListFileRequestUploadsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetPublicFileRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicFileRequestRequest) Reset() {
+	*x = GetPublicFileRequestRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicFileRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicFileRequestRequest) ProtoMessage() {}
+
+func (x *GetPublicFileRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicFileRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicFileRequestRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *GetPublicFileRequestRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type GetPublicFileRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *PublicFileRequest     `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicFileRequestResponse) Reset() {
+	*x = GetPublicFileRequestResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicFileRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicFileRequestResponse) ProtoMessage() {}
+
+func (x *GetPublicFileRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicFileRequestResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicFileRequestResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *GetPublicFileRequestResponse) GetRequest() *PublicFileRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// 匿名上传, 文件名前加上上传者的名字
+type SubmitFileRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Uploader      string                 `protobuf:"bytes,3,opt,name=uploader,proto3" json:"uploader,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Hash          string                 `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash,omitempty"`
+	ContentType   string                 `protobuf:"bytes,7,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,9,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitFileRequestRequest) Reset() {
+	*x = SubmitFileRequestRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitFileRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitFileRequestRequest) ProtoMessage() {}
+
+func (x *SubmitFileRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitFileRequestRequest.ProtoReflect.Descriptor instead.
+func (*SubmitFileRequestRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *SubmitFileRequestRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SubmitFileRequestRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *SubmitFileRequestRequest) GetUploader() string {
+	if x != nil {
+		return x.Uploader
+	}
+	return ""
+}
+
+func (x *SubmitFileRequestRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubmitFileRequestRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SubmitFileRequestRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *SubmitFileRequestRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *SubmitFileRequestRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SubmitFileRequestRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+type SubmitFileRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // 保存后的文件名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitFileRequestResponse) Reset() {
+	*x = SubmitFileRequestResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitFileRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitFileRequestResponse) ProtoMessage() {}
+
+func (x *SubmitFileRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitFileRequestResponse.ProtoReflect.Descriptor instead.
+func (*SubmitFileRequestResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{145}
+}
+
+func (x *SubmitFileRequestResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 保险库: 文件在客户端加密, 服务端只保存密文、加密后的名称和包裹后的密钥
+// 保险库密钥以各成员的公钥包裹为信封保存, 向其他用户共享保险库时由客户端为其生成信封
+type CreateVaultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ParentId      int64                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`         // 父文件夹, 不能在保险库中
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                  // 加密后的名称
+	KeyEnvelope   []byte                 `protobuf:"bytes,4,opt,name=key_envelope,json=keyEnvelope,proto3" json:"key_envelope,omitempty"` // 以创建者公钥包裹的保险库密钥
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVaultRequest) Reset() {
+	*x = CreateVaultRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVaultRequest) ProtoMessage() {}
+
+func (x *CreateVaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVaultRequest.ProtoReflect.Descriptor instead.
+func (*CreateVaultRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *CreateVaultRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateVaultRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateVaultRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateVaultRequest) GetKeyEnvelope() []byte {
+	if x != nil {
+		return x.KeyEnvelope
+	}
+	return nil
+}
+
+type CreateVaultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVaultResponse) Reset() {
+	*x = CreateVaultResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVaultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVaultResponse) ProtoMessage() {}
+
+func (x *CreateVaultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[147]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVaultResponse.ProtoReflect.Descriptor instead.
+func (*CreateVaultResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *CreateVaultResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type SetPublicKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	Hash          string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
-	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
-	ContentType   string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	UserId        int32                  `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	FolderId      int64                  `protobuf:"varint,7,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"` // 客户端使用的密钥算法, 如 X25519
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileMetaData) Reset() {
-	*x = FileMetaData{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[0]
+func (x *SetPublicKeyRequest) Reset() {
+	*x = SetPublicKeyRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[148]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileMetaData) String() string {
+func (x *SetPublicKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileMetaData) ProtoMessage() {}
+func (*SetPublicKeyRequest) ProtoMessage() {}
 
-func (x *FileMetaData) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[0]
+func (x *SetPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[148]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -163,91 +10057,93 @@ func (x *FileMetaData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileMetaData.ProtoReflect.Descriptor instead.
-func (*FileMetaData) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use SetPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*SetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{148}
 }
 
-func (x *FileMetaData) GetName() string {
+func (x *SetPublicKeyRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Name
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *FileMetaData) GetSize() int64 {
+func (x *SetPublicKeyRequest) GetPublicKey() []byte {
 	if x != nil {
-		return x.Size
+		return x.PublicKey
 	}
-	return 0
+	return nil
 }
 
-func (x *FileMetaData) GetHash() string {
+func (x *SetPublicKeyRequest) GetAlgorithm() string {
 	if x != nil {
-		return x.Hash
+		return x.Algorithm
 	}
 	return ""
 }
 
-func (x *FileMetaData) GetPath() string {
-	if x != nil {
-		return x.Path
-	}
-	return ""
+type SetPublicKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileMetaData) GetContentType() string {
-	if x != nil {
-		return x.ContentType
-	}
-	return ""
+func (x *SetPublicKeyResponse) Reset() {
+	*x = SetPublicKeyResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[149]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileMetaData) GetUserId() int32 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
+func (x *SetPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *FileMetaData) GetFolderId() int64 {
+func (*SetPublicKeyResponse) ProtoMessage() {}
+
+func (x *SetPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[149]
 	if x != nil {
-		return x.FolderId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type File struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	FolderId       int64                  `protobuf:"varint,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
-	UserId         int32                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Size           int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
-	Type           string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
-	Utime          string                 `protobuf:"bytes,8,opt,name=utime,proto3" json:"utime,omitempty"`
-	Version        int32                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`                                       // 文件版本号
-	DeviceId       string                 `protobuf:"bytes,10,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                     // 设备ID
-	LastModifiedBy string                 `protobuf:"bytes,11,opt,name=last_modified_by,json=lastModifiedBy,proto3" json:"last_modified_by,omitempty"` // 最后修改者
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// Deprecated: Use SetPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*SetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{149}
 }
 
-func (x *File) Reset() {
-	*x = File{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[1]
+type UserPublicKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Utime         int64                  `protobuf:"varint,4,opt,name=utime,proto3" json:"utime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserPublicKey) Reset() {
+	*x = UserPublicKey{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *File) String() string {
+func (x *UserPublicKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*File) ProtoMessage() {}
+func (*UserPublicKey) ProtoMessage() {}
 
-func (x *File) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[1]
+func (x *UserPublicKey) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -258,108 +10154,114 @@ func (x *File) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use File.ProtoReflect.Descriptor instead.
-func (*File) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use UserPublicKey.ProtoReflect.Descriptor instead.
+func (*UserPublicKey) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{150}
 }
 
-func (x *File) GetId() int32 {
+func (x *UserPublicKey) GetUserId() int32 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *File) GetName() string {
+func (x *UserPublicKey) GetPublicKey() []byte {
 	if x != nil {
-		return x.Name
+		return x.PublicKey
 	}
-	return ""
+	return nil
 }
 
-func (x *File) GetFolderId() int64 {
+func (x *UserPublicKey) GetAlgorithm() string {
 	if x != nil {
-		return x.FolderId
+		return x.Algorithm
 	}
-	return 0
+	return ""
 }
 
-func (x *File) GetUserId() int32 {
+func (x *UserPublicKey) GetUtime() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Utime
 	}
 	return 0
 }
 
-func (x *File) GetSize() int64 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
+type GetPublicKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserIds       []int32                `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *File) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
+func (x *GetPublicKeysRequest) Reset() {
+	*x = GetPublicKeysRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[151]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *File) GetUtime() string {
-	if x != nil {
-		return x.Utime
-	}
-	return ""
+func (x *GetPublicKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *File) GetVersion() int32 {
+func (*GetPublicKeysRequest) ProtoMessage() {}
+
+func (x *GetPublicKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[151]
 	if x != nil {
-		return x.Version
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *File) GetDeviceId() string {
+// Deprecated: Use GetPublicKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicKeysRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{151}
+}
+
+func (x *GetPublicKeysRequest) GetUserId() int32 {
 	if x != nil {
-		return x.DeviceId
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *File) GetLastModifiedBy() string {
+func (x *GetPublicKeysRequest) GetUserIds() []int32 {
 	if x != nil {
-		return x.LastModifiedBy
+		return x.UserIds
 	}
-	return ""
+	return nil
 }
 
-type Folder struct {
+// 未设置公钥的用户不在结果中
+type GetPublicKeysResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ParentId      int64                  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Path          string                 `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
-	Utime         string                 `protobuf:"bytes,6,opt,name=utime,proto3" json:"utime,omitempty"`
+	Keys          []*UserPublicKey       `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Folder) Reset() {
-	*x = Folder{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[2]
+func (x *GetPublicKeysResponse) Reset() {
+	*x = GetPublicKeysResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[152]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Folder) String() string {
+func (x *GetPublicKeysResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Folder) ProtoMessage() {}
+func (*GetPublicKeysResponse) ProtoMessage() {}
 
-func (x *Folder) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[2]
+func (x *GetPublicKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[152]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,77 +10272,108 @@ func (x *Folder) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Folder.ProtoReflect.Descriptor instead.
-func (*Folder) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetPublicKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicKeysResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{152}
 }
 
-func (x *Folder) GetId() int64 {
+func (x *GetPublicKeysResponse) GetKeys() []*UserPublicKey {
 	if x != nil {
-		return x.Id
+		return x.Keys
 	}
-	return 0
+	return nil
 }
 
-func (x *Folder) GetName() string {
+// 为 recipient_id 保存保险库密钥的信封, 需要对保险库有编辑权限, 已存在时替换
+type PutVaultKeyEnvelopeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VaultId       int64                  `protobuf:"varint,2,opt,name=vault_id,json=vaultId,proto3" json:"vault_id,omitempty"`
+	RecipientId   int32                  `protobuf:"varint,3,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	Envelope      []byte                 `protobuf:"bytes,4,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutVaultKeyEnvelopeRequest) Reset() {
+	*x = PutVaultKeyEnvelopeRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[153]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutVaultKeyEnvelopeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutVaultKeyEnvelopeRequest) ProtoMessage() {}
+
+func (x *PutVaultKeyEnvelopeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[153]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Folder) GetParentId() int64 {
+// Deprecated: Use PutVaultKeyEnvelopeRequest.ProtoReflect.Descriptor instead.
+func (*PutVaultKeyEnvelopeRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{153}
+}
+
+func (x *PutVaultKeyEnvelopeRequest) GetUserId() int32 {
 	if x != nil {
-		return x.ParentId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *Folder) GetUserId() int32 {
+func (x *PutVaultKeyEnvelopeRequest) GetVaultId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.VaultId
 	}
 	return 0
 }
 
-func (x *Folder) GetPath() string {
+func (x *PutVaultKeyEnvelopeRequest) GetRecipientId() int32 {
 	if x != nil {
-		return x.Path
+		return x.RecipientId
 	}
-	return ""
+	return 0
 }
 
-func (x *Folder) GetUtime() string {
+func (x *PutVaultKeyEnvelopeRequest) GetEnvelope() []byte {
 	if x != nil {
-		return x.Utime
+		return x.Envelope
 	}
-	return ""
+	return nil
 }
 
-type FileStore struct {
+type PutVaultKeyEnvelopeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Capacity      int64                  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
-	CurrentSize   int64                  `protobuf:"varint,3,opt,name=current_size,json=currentSize,proto3" json:"current_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileStore) Reset() {
-	*x = FileStore{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[3]
+func (x *PutVaultKeyEnvelopeResponse) Reset() {
+	*x = PutVaultKeyEnvelopeResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[154]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileStore) String() string {
+func (x *PutVaultKeyEnvelopeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileStore) ProtoMessage() {}
+func (*PutVaultKeyEnvelopeResponse) ProtoMessage() {}
 
-func (x *FileStore) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[3]
+func (x *PutVaultKeyEnvelopeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[154]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,55 +10384,34 @@ func (x *FileStore) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileStore.ProtoReflect.Descriptor instead.
-func (*FileStore) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *FileStore) GetUserId() int32 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
-}
-
-func (x *FileStore) GetCapacity() int64 {
-	if x != nil {
-		return x.Capacity
-	}
-	return 0
-}
-
-func (x *FileStore) GetCurrentSize() int64 {
-	if x != nil {
-		return x.CurrentSize
-	}
-	return 0
+// Deprecated: Use PutVaultKeyEnvelopeResponse.ProtoReflect.Descriptor instead.
+func (*PutVaultKeyEnvelopeResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{154}
 }
 
-type UploadRequest struct {
+type GetVaultKeyEnvelopeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *FileMetaData          `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VaultId       int64                  `protobuf:"varint,2,opt,name=vault_id,json=vaultId,proto3" json:"vault_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadRequest) Reset() {
-	*x = UploadRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[4]
+func (x *GetVaultKeyEnvelopeRequest) Reset() {
+	*x = GetVaultKeyEnvelopeRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[155]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadRequest) String() string {
+func (x *GetVaultKeyEnvelopeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadRequest) ProtoMessage() {}
+func (*GetVaultKeyEnvelopeRequest) ProtoMessage() {}
 
-func (x *UploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[4]
+func (x *GetVaultKeyEnvelopeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[155]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,47 +10422,49 @@ func (x *UploadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
-func (*UploadRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetVaultKeyEnvelopeRequest.ProtoReflect.Descriptor instead.
+func (*GetVaultKeyEnvelopeRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{155}
 }
 
-func (x *UploadRequest) GetMetadata() *FileMetaData {
+func (x *GetVaultKeyEnvelopeRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Metadata
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-func (x *UploadRequest) GetData() []byte {
+func (x *GetVaultKeyEnvelopeRequest) GetVaultId() int64 {
 	if x != nil {
-		return x.Data
+		return x.VaultId
 	}
-	return nil
+	return 0
 }
 
-type UploadResponse struct {
+type GetVaultKeyEnvelopeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Envelope      []byte                 `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	CreatedBy     int32                  `protobuf:"varint,2,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // 生成信封的用户, 客户端据此校验信封来源
+	Utime         int64                  `protobuf:"varint,3,opt,name=utime,proto3" json:"utime,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadResponse) Reset() {
-	*x = UploadResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[5]
+func (x *GetVaultKeyEnvelopeResponse) Reset() {
+	*x = GetVaultKeyEnvelopeResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[156]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadResponse) String() string {
+func (x *GetVaultKeyEnvelopeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadResponse) ProtoMessage() {}
+func (*GetVaultKeyEnvelopeResponse) ProtoMessage() {}
 
-func (x *UploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[5]
+func (x *GetVaultKeyEnvelopeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[156]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -561,40 +10475,58 @@ func (x *UploadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
-func (*UploadResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetVaultKeyEnvelopeResponse.ProtoReflect.Descriptor instead.
+func (*GetVaultKeyEnvelopeResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{156}
 }
 
-func (x *UploadResponse) GetId() int32 {
+func (x *GetVaultKeyEnvelopeResponse) GetEnvelope() []byte {
 	if x != nil {
-		return x.Id
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *GetVaultKeyEnvelopeResponse) GetCreatedBy() int32 {
+	if x != nil {
+		return x.CreatedBy
 	}
 	return 0
 }
 
-type CreateFileStoreRequest struct {
+func (x *GetVaultKeyEnvelopeResponse) GetUtime() int64 {
+	if x != nil {
+		return x.Utime
+	}
+	return 0
+}
+
+// 删除 recipient_id 的信封, 需要对保险库有编辑权限, 用户可删除自己的信封
+// 信封删除后仍需客户端轮换保险库密钥, 才能防止对方解密之后的新内容
+type RevokeVaultKeyEnvelopeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VaultId       int64                  `protobuf:"varint,2,opt,name=vault_id,json=vaultId,proto3" json:"vault_id,omitempty"`
+	RecipientId   int32                  `protobuf:"varint,3,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateFileStoreRequest) Reset() {
-	*x = CreateFileStoreRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[6]
+func (x *RevokeVaultKeyEnvelopeRequest) Reset() {
+	*x = RevokeVaultKeyEnvelopeRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[157]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateFileStoreRequest) String() string {
+func (x *RevokeVaultKeyEnvelopeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateFileStoreRequest) ProtoMessage() {}
+func (*RevokeVaultKeyEnvelopeRequest) ProtoMessage() {}
 
-func (x *CreateFileStoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[6]
+func (x *RevokeVaultKeyEnvelopeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[157]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,40 +10537,53 @@ func (x *CreateFileStoreRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateFileStoreRequest.ProtoReflect.Descriptor instead.
-func (*CreateFileStoreRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RevokeVaultKeyEnvelopeRequest.ProtoReflect.Descriptor instead.
+func (*RevokeVaultKeyEnvelopeRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{157}
 }
 
-func (x *CreateFileStoreRequest) GetUserId() int32 {
+func (x *RevokeVaultKeyEnvelopeRequest) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-type CreateFileStoreResponse struct {
+func (x *RevokeVaultKeyEnvelopeRequest) GetVaultId() int64 {
+	if x != nil {
+		return x.VaultId
+	}
+	return 0
+}
+
+func (x *RevokeVaultKeyEnvelopeRequest) GetRecipientId() int32 {
+	if x != nil {
+		return x.RecipientId
+	}
+	return 0
+}
+
+type RevokeVaultKeyEnvelopeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateFileStoreResponse) Reset() {
-	*x = CreateFileStoreResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[7]
+func (x *RevokeVaultKeyEnvelopeResponse) Reset() {
+	*x = RevokeVaultKeyEnvelopeResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[158]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateFileStoreResponse) String() string {
+func (x *RevokeVaultKeyEnvelopeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateFileStoreResponse) ProtoMessage() {}
+func (*RevokeVaultKeyEnvelopeResponse) ProtoMessage() {}
 
-func (x *CreateFileStoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[7]
+func (x *RevokeVaultKeyEnvelopeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[158]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,42 +10594,41 @@ func (x *CreateFileStoreResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateFileStoreResponse.ProtoReflect.Descriptor instead.
-func (*CreateFileStoreResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *CreateFileStoreResponse) GetId() int32 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
+// Deprecated: Use RevokeVaultKeyEnvelopeResponse.ProtoReflect.Descriptor instead.
+func (*RevokeVaultKeyEnvelopeResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{158}
 }
 
-type CreateFolderRequest struct {
+type ScrubFinding struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	ParentId      int64                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ObjectKey     string                 `protobuf:"bytes,1,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"` // 发现问题时校验的文件, 复制出的文件可能共用同一对象
 	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Problem       ScrubProblem           `protobuf:"varint,4,opt,name=problem,proto3,enum=file.ScrubProblem" json:"problem,omitempty"`
+	Expected      string                 `protobuf:"bytes,5,opt,name=expected,proto3" json:"expected,omitempty"` // 记录的哈希
+	Actual        string                 `protobuf:"bytes,6,opt,name=actual,proto3" json:"actual,omitempty"`     // 读出的内容的哈希
+	Detail        string                 `protobuf:"bytes,7,opt,name=detail,proto3" json:"detail,omitempty"`
+	Ctime         int64                  `protobuf:"varint,8,opt,name=ctime,proto3" json:"ctime,omitempty"` // 首次发现的时间
+	Utime         int64                  `protobuf:"varint,9,opt,name=utime,proto3" json:"utime,omitempty"` // 最近一次确认的时间
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateFolderRequest) Reset() {
-	*x = CreateFolderRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[8]
+func (x *ScrubFinding) Reset() {
+	*x = ScrubFinding{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[159]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateFolderRequest) String() string {
+func (x *ScrubFinding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateFolderRequest) ProtoMessage() {}
+func (*ScrubFinding) ProtoMessage() {}
 
-func (x *CreateFolderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[8]
+func (x *ScrubFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[159]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,54 +10639,97 @@ func (x *CreateFolderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateFolderRequest.ProtoReflect.Descriptor instead.
-func (*CreateFolderRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ScrubFinding.ProtoReflect.Descriptor instead.
+func (*ScrubFinding) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{159}
 }
 
-func (x *CreateFolderRequest) GetName() string {
+func (x *ScrubFinding) GetObjectKey() string {
 	if x != nil {
-		return x.Name
+		return x.ObjectKey
 	}
 	return ""
 }
 
-func (x *CreateFolderRequest) GetParentId() int64 {
+func (x *ScrubFinding) GetFileId() int64 {
 	if x != nil {
-		return x.ParentId
+		return x.FileId
 	}
 	return 0
 }
 
-func (x *CreateFolderRequest) GetUserId() int32 {
+func (x *ScrubFinding) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-type CreateFolderResponse struct {
+func (x *ScrubFinding) GetProblem() ScrubProblem {
+	if x != nil {
+		return x.Problem
+	}
+	return ScrubProblem_SCRUB_PROBLEM_NONE
+}
+
+func (x *ScrubFinding) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *ScrubFinding) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+func (x *ScrubFinding) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *ScrubFinding) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+func (x *ScrubFinding) GetUtime() int64 {
+	if x != nil {
+		return x.Utime
+	}
+	return 0
+}
+
+// 立即校验文件, file_id 为 0 时校验全部文件并转为异步任务
+type ScrubStorageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateFolderResponse) Reset() {
-	*x = CreateFolderResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[9]
+func (x *ScrubStorageRequest) Reset() {
+	*x = ScrubStorageRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[160]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateFolderResponse) String() string {
+func (x *ScrubStorageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateFolderResponse) ProtoMessage() {}
+func (*ScrubStorageRequest) ProtoMessage() {}
 
-func (x *CreateFolderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[9]
+func (x *ScrubStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[160]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -753,41 +10740,42 @@ func (x *CreateFolderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateFolderResponse.ProtoReflect.Descriptor instead.
-func (*CreateFolderResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ScrubStorageRequest.ProtoReflect.Descriptor instead.
+func (*ScrubStorageRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{160}
 }
 
-func (x *CreateFolderResponse) GetFolder() *Folder {
+func (x *ScrubStorageRequest) GetFileId() int64 {
 	if x != nil {
-		return x.Folder
+		return x.FileId
 	}
-	return nil
+	return 0
 }
 
-type ListFolderRequest struct {
+type ScrubStorageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FolderId      int64                  `protobuf:"varint,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Finding       *ScrubFinding          `protobuf:"bytes,1,opt,name=finding,proto3" json:"finding,omitempty"`          // 单个文件时同步返回, 为空表示校验通过
+	Sha256        string                 `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`            // 单个文件时读出的内容的 SHA-256
+	JobId         string                 `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // 全部文件时通过 GetJob 查询进度
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFolderRequest) Reset() {
-	*x = ListFolderRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[10]
+func (x *ScrubStorageResponse) Reset() {
+	*x = ScrubStorageResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[161]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFolderRequest) String() string {
+func (x *ScrubStorageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFolderRequest) ProtoMessage() {}
+func (*ScrubStorageResponse) ProtoMessage() {}
 
-func (x *ListFolderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[10]
+func (x *ScrubStorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[161]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,48 +10786,56 @@ func (x *ListFolderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFolderRequest.ProtoReflect.Descriptor instead.
-func (*ListFolderRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ScrubStorageResponse.ProtoReflect.Descriptor instead.
+func (*ScrubStorageResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{161}
 }
 
-func (x *ListFolderRequest) GetFolderId() int64 {
+func (x *ScrubStorageResponse) GetFinding() *ScrubFinding {
 	if x != nil {
-		return x.FolderId
+		return x.Finding
 	}
-	return 0
+	return nil
 }
 
-func (x *ListFolderRequest) GetUserId() int32 {
+func (x *ScrubStorageResponse) GetSha256() string {
 	if x != nil {
-		return x.UserId
+		return x.Sha256
 	}
-	return 0
+	return ""
 }
 
-type ListFolderResponse struct {
+func (x *ScrubStorageResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type ListScrubFindingsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Folders       []*Folder              `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
-	Files         []*File                `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 0 表示全部用户
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFolderResponse) Reset() {
-	*x = ListFolderResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[11]
+func (x *ListScrubFindingsRequest) Reset() {
+	*x = ListScrubFindingsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[162]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFolderResponse) String() string {
+func (x *ListScrubFindingsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFolderResponse) ProtoMessage() {}
+func (*ListScrubFindingsRequest) ProtoMessage() {}
 
-func (x *ListFolderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[11]
+func (x *ListScrubFindingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[162]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -850,48 +10846,55 @@ func (x *ListFolderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFolderResponse.ProtoReflect.Descriptor instead.
-func (*ListFolderResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListScrubFindingsRequest.ProtoReflect.Descriptor instead.
+func (*ListScrubFindingsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{162}
 }
 
-func (x *ListFolderResponse) GetFolders() []*Folder {
+func (x *ListScrubFindingsRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Folders
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-func (x *ListFolderResponse) GetFiles() []*File {
+func (x *ListScrubFindingsRequest) GetPage() int32 {
 	if x != nil {
-		return x.Files
+		return x.Page
 	}
-	return nil
+	return 0
 }
 
-type GetFileRequest struct {
+func (x *ListScrubFindingsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListScrubFindingsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Findings      []*ScrubFinding        `protobuf:"bytes,1,rep,name=findings,proto3" json:"findings,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFileRequest) Reset() {
-	*x = GetFileRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[12]
+func (x *ListScrubFindingsResponse) Reset() {
+	*x = ListScrubFindingsResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[163]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFileRequest) String() string {
+func (x *ListScrubFindingsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFileRequest) ProtoMessage() {}
+func (*ListScrubFindingsResponse) ProtoMessage() {}
 
-func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[12]
+func (x *ListScrubFindingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[163]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,47 +10905,55 @@ func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
-func (*GetFileRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListScrubFindingsResponse.ProtoReflect.Descriptor instead.
+func (*ListScrubFindingsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{163}
 }
 
-func (x *GetFileRequest) GetFileId() int64 {
+func (x *ListScrubFindingsResponse) GetFindings() []*ScrubFinding {
 	if x != nil {
-		return x.FileId
+		return x.Findings
 	}
-	return 0
+	return nil
 }
 
-func (x *GetFileRequest) GetUserId() int32 {
+func (x *ListScrubFindingsResponse) GetTotal() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Total
 	}
 	return 0
 }
 
-type GetFileResponse struct {
+// 文件在一个处理器上的处理状态和结果
+type ProcessingResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	File          *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Processor     string                 `protobuf:"bytes,2,opt,name=processor,proto3" json:"processor,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"` // 处理的文件版本
+	Status        ProcessingStatus       `protobuf:"varint,4,opt,name=status,proto3,enum=file.ProcessingStatus" json:"status,omitempty"`
+	Attempts      int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Outputs       map[string]string      `protobuf:"bytes,7,rep,name=outputs,proto3" json:"outputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 处理结果, 如缩略图的 key、提取的文本、扫描结论
+	Utime         int64                  `protobuf:"varint,8,opt,name=utime,proto3" json:"utime,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFileResponse) Reset() {
-	*x = GetFileResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[13]
+func (x *ProcessingResult) Reset() {
+	*x = ProcessingResult{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[164]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFileResponse) String() string {
+func (x *ProcessingResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFileResponse) ProtoMessage() {}
+func (*ProcessingResult) ProtoMessage() {}
 
-func (x *GetFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[13]
+func (x *ProcessingResult) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[164]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,44 +10961,94 @@ func (x *GetFileResponse) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessingResult.ProtoReflect.Descriptor instead.
+func (*ProcessingResult) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{164}
+}
+
+func (x *ProcessingResult) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *ProcessingResult) GetProcessor() string {
+	if x != nil {
+		return x.Processor
+	}
+	return ""
+}
+
+func (x *ProcessingResult) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProcessingResult) GetStatus() ProcessingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProcessingStatus_PROCESSING_STATUS_UNKNOWN
+}
+
+func (x *ProcessingResult) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *ProcessingResult) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
 }
 
-// Deprecated: Use GetFileResponse.ProtoReflect.Descriptor instead.
-func (*GetFileResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{13}
+func (x *ProcessingResult) GetOutputs() map[string]string {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
 }
 
-func (x *GetFileResponse) GetFile() *File {
+func (x *ProcessingResult) GetUtime() int64 {
 	if x != nil {
-		return x.File
+		return x.Utime
 	}
-	return nil
+	return 0
 }
 
-type DownloadRequest struct {
+// 重新处理文件的当前版本, processors 为空时使用全部适用的处理器
+type ReprocessFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Processors    []string               `protobuf:"bytes,2,rep,name=processors,proto3" json:"processors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadRequest) Reset() {
-	*x = DownloadRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[14]
+func (x *ReprocessFileRequest) Reset() {
+	*x = ReprocessFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[165]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadRequest) String() string {
+func (x *ReprocessFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadRequest) ProtoMessage() {}
+func (*ReprocessFileRequest) ProtoMessage() {}
 
-func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[14]
+func (x *ReprocessFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[165]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -998,47 +11059,47 @@ func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadRequest.ProtoReflect.Descriptor instead.
-func (*DownloadRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ReprocessFileRequest.ProtoReflect.Descriptor instead.
+func (*ReprocessFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{165}
 }
 
-func (x *DownloadRequest) GetFileId() int64 {
+func (x *ReprocessFileRequest) GetFileId() int64 {
 	if x != nil {
 		return x.FileId
 	}
 	return 0
 }
 
-func (x *DownloadRequest) GetUserId() int32 {
+func (x *ReprocessFileRequest) GetProcessors() []string {
 	if x != nil {
-		return x.UserId
+		return x.Processors
 	}
-	return 0
+	return nil
 }
 
-type DownloadResponse struct {
+type ReprocessFileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Results       []*ProcessingResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadResponse) Reset() {
-	*x = DownloadResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[15]
+func (x *ReprocessFileResponse) Reset() {
+	*x = ReprocessFileResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[166]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadResponse) String() string {
+func (x *ReprocessFileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadResponse) ProtoMessage() {}
+func (*ReprocessFileResponse) ProtoMessage() {}
 
-func (x *DownloadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[15]
+func (x *ReprocessFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[166]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1049,40 +11110,42 @@ func (x *DownloadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadResponse.ProtoReflect.Descriptor instead.
-func (*DownloadResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ReprocessFileResponse.ProtoReflect.Descriptor instead.
+func (*ReprocessFileResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{166}
 }
 
-func (x *DownloadResponse) GetData() []byte {
+func (x *ReprocessFileResponse) GetResults() []*ProcessingResult {
 	if x != nil {
-		return x.Data
+		return x.Results
 	}
 	return nil
 }
 
-type DownloadStreamResponse struct {
+type ListDeadLettersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Processor     string                 `protobuf:"bytes,1,opt,name=processor,proto3" json:"processor,omitempty"` // 为空表示全部处理器
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadStreamResponse) Reset() {
-	*x = DownloadStreamResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[16]
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[167]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadStreamResponse) String() string {
+func (x *ListDeadLettersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadStreamResponse) ProtoMessage() {}
+func (*ListDeadLettersRequest) ProtoMessage() {}
 
-func (x *DownloadStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[16]
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[167]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1093,43 +11156,55 @@ func (x *DownloadStreamResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadStreamResponse.ProtoReflect.Descriptor instead.
-func (*DownloadStreamResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{167}
 }
 
-func (x *DownloadStreamResponse) GetData() []byte {
+func (x *ListDeadLettersRequest) GetProcessor() string {
 	if x != nil {
-		return x.Data
+		return x.Processor
 	}
-	return nil
+	return ""
 }
 
-type MoveFolderRequest struct {
+func (x *ListDeadLettersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeadLettersRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	FolderId      int64                  `protobuf:"varint,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
-	ToFolderId    int64                  `protobuf:"varint,3,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"`
-	FolderName    string                 `protobuf:"bytes,4,opt,name=folder_name,json=folderName,proto3" json:"folder_name,omitempty"`
+	Results       []*ProcessingResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MoveFolderRequest) Reset() {
-	*x = MoveFolderRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[17]
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[168]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MoveFolderRequest) String() string {
+func (x *ListDeadLettersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveFolderRequest) ProtoMessage() {}
+func (*ListDeadLettersResponse) ProtoMessage() {}
 
-func (x *MoveFolderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[17]
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[168]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1140,60 +11215,92 @@ func (x *MoveFolderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveFolderRequest.ProtoReflect.Descriptor instead.
-func (*MoveFolderRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{168}
 }
 
-func (x *MoveFolderRequest) GetUserId() int32 {
+func (x *ListDeadLettersResponse) GetResults() []*ProcessingResult {
 	if x != nil {
-		return x.UserId
+		return x.Results
 	}
-	return 0
+	return nil
 }
 
-func (x *MoveFolderRequest) GetFolderId() int64 {
+func (x *ListDeadLettersResponse) GetTotal() int64 {
 	if x != nil {
-		return x.FolderId
+		return x.Total
 	}
 	return 0
 }
 
-func (x *MoveFolderRequest) GetToFolderId() int64 {
+// 重新处理死信列表中的文件
+type RetryDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Processor     string                 `protobuf:"bytes,1,opt,name=processor,proto3" json:"processor,omitempty"` // 为空表示全部处理器
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryDeadLettersRequest) Reset() {
+	*x = RetryDeadLettersRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[169]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryDeadLettersRequest) ProtoMessage() {}
+
+func (x *RetryDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[169]
 	if x != nil {
-		return x.ToFolderId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *MoveFolderRequest) GetFolderName() string {
+// Deprecated: Use RetryDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*RetryDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{169}
+}
+
+func (x *RetryDeadLettersRequest) GetProcessor() string {
 	if x != nil {
-		return x.FolderName
+		return x.Processor
 	}
 	return ""
 }
 
-type MoveFolderResponse struct {
+type RetryDeadLettersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MoveFolderResponse) Reset() {
-	*x = MoveFolderResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[18]
+func (x *RetryDeadLettersResponse) Reset() {
+	*x = RetryDeadLettersResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[170]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MoveFolderResponse) String() string {
+func (x *RetryDeadLettersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveFolderResponse) ProtoMessage() {}
+func (*RetryDeadLettersResponse) ProtoMessage() {}
 
-func (x *MoveFolderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[18]
+func (x *RetryDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[170]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1204,35 +11311,48 @@ func (x *MoveFolderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveFolderResponse.ProtoReflect.Descriptor instead.
-func (*MoveFolderResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use RetryDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*RetryDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{170}
 }
 
-type MoveFileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	ToFolderId    int64                  `protobuf:"varint,4,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RetryDeadLettersResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
 }
 
-func (x *MoveFileRequest) Reset() {
-	*x = MoveFileRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[19]
+// 扫描发现病毒后隔离的文件
+type QuarantinedFile struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FileId         int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId         int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Size           int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Scanner        string                 `protobuf:"bytes,5,opt,name=scanner,proto3" json:"scanner,omitempty"`                                      // 给出结论的扫描驱动
+	Signature      string                 `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`                                  // 命中的病毒特征
+	DisabledShares int64                  `protobuf:"varint,7,opt,name=disabled_shares,json=disabledShares,proto3" json:"disabled_shares,omitempty"` // 因此被禁用的分享数
+	Ctime          int64                  `protobuf:"varint,8,opt,name=ctime,proto3" json:"ctime,omitempty"`                                         // 隔离的时间
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QuarantinedFile) Reset() {
+	*x = QuarantinedFile{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[171]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MoveFileRequest) String() string {
+func (x *QuarantinedFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveFileRequest) ProtoMessage() {}
+func (*QuarantinedFile) ProtoMessage() {}
 
-func (x *MoveFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[19]
+func (x *QuarantinedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[171]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1243,91 +11363,91 @@ func (x *MoveFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveFileRequest.ProtoReflect.Descriptor instead.
-func (*MoveFileRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use QuarantinedFile.ProtoReflect.Descriptor instead.
+func (*QuarantinedFile) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{171}
 }
 
-func (x *MoveFileRequest) GetUserId() int32 {
+func (x *QuarantinedFile) GetFileId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.FileId
 	}
 	return 0
 }
 
-func (x *MoveFileRequest) GetFileId() int64 {
+func (x *QuarantinedFile) GetUserId() int32 {
 	if x != nil {
-		return x.FileId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *MoveFileRequest) GetToFolderId() int64 {
+func (x *QuarantinedFile) GetName() string {
 	if x != nil {
-		return x.ToFolderId
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-type MoveFileResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *QuarantinedFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
 }
 
-func (x *MoveFileResponse) Reset() {
-	*x = MoveFileResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[20]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *QuarantinedFile) GetScanner() string {
+	if x != nil {
+		return x.Scanner
+	}
+	return ""
 }
 
-func (x *MoveFileResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *QuarantinedFile) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
 }
 
-func (*MoveFileResponse) ProtoMessage() {}
-
-func (x *MoveFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[20]
+func (x *QuarantinedFile) GetDisabledShares() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.DisabledShares
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use MoveFileResponse.ProtoReflect.Descriptor instead.
-func (*MoveFileResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{20}
+func (x *QuarantinedFile) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
 }
 
-type DeleteFileRequest struct {
+type ListQuarantinedFilesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 0 表示全部用户
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFileRequest) Reset() {
-	*x = DeleteFileRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[21]
+func (x *ListQuarantinedFilesRequest) Reset() {
+	*x = ListQuarantinedFilesRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[172]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFileRequest) String() string {
+func (x *ListQuarantinedFilesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFileRequest) ProtoMessage() {}
+func (*ListQuarantinedFilesRequest) ProtoMessage() {}
 
-func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[21]
+func (x *ListQuarantinedFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[172]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1338,46 +11458,55 @@ func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
-func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ListQuarantinedFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListQuarantinedFilesRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{172}
 }
 
-func (x *DeleteFileRequest) GetFileId() int64 {
+func (x *ListQuarantinedFilesRequest) GetUserId() int32 {
 	if x != nil {
-		return x.FileId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *DeleteFileRequest) GetUserId() int32 {
+func (x *ListQuarantinedFilesRequest) GetPage() int32 {
 	if x != nil {
-		return x.UserId
+		return x.Page
 	}
 	return 0
 }
 
-type DeleteFileResponse struct {
+func (x *ListQuarantinedFilesRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListQuarantinedFilesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*QuarantinedFile     `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFileResponse) Reset() {
-	*x = DeleteFileResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[22]
+func (x *ListQuarantinedFilesResponse) Reset() {
+	*x = ListQuarantinedFilesResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[173]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFileResponse) String() string {
+func (x *ListQuarantinedFilesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFileResponse) ProtoMessage() {}
+func (*ListQuarantinedFilesResponse) ProtoMessage() {}
 
-func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[22]
+func (x *ListQuarantinedFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[173]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1388,34 +11517,48 @@ func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
-func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ListQuarantinedFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListQuarantinedFilesResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{173}
 }
 
-type DeleteFolderRequest struct {
+func (x *ListQuarantinedFilesResponse) GetFiles() []*QuarantinedFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListQuarantinedFilesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 误报时解除隔离, 文件恢复到原来的文件夹, 重名时自动重命名; 被禁用的分享不自动恢复
+type ReleaseQuarantinedFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FolderId      int64                  `protobuf:"varint,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFolderRequest) Reset() {
-	*x = DeleteFolderRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[23]
+func (x *ReleaseQuarantinedFileRequest) Reset() {
+	*x = ReleaseQuarantinedFileRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[174]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFolderRequest) String() string {
+func (x *ReleaseQuarantinedFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFolderRequest) ProtoMessage() {}
+func (*ReleaseQuarantinedFileRequest) ProtoMessage() {}
 
-func (x *DeleteFolderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[23]
+func (x *ReleaseQuarantinedFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[174]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1426,46 +11569,40 @@ func (x *DeleteFolderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFolderRequest.ProtoReflect.Descriptor instead.
-func (*DeleteFolderRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *DeleteFolderRequest) GetFolderId() int64 {
-	if x != nil {
-		return x.FolderId
-	}
-	return 0
+// Deprecated: Use ReleaseQuarantinedFileRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseQuarantinedFileRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{174}
 }
 
-func (x *DeleteFolderRequest) GetUserId() int32 {
+func (x *ReleaseQuarantinedFileRequest) GetFileId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.FileId
 	}
 	return 0
 }
 
-type DeleteFolderResponse struct {
+type ReleaseQuarantinedFileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // 恢复后的名称
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFolderResponse) Reset() {
-	*x = DeleteFolderResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[24]
+func (x *ReleaseQuarantinedFileResponse) Reset() {
+	*x = ReleaseQuarantinedFileResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[175]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFolderResponse) String() string {
+func (x *ReleaseQuarantinedFileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFolderResponse) ProtoMessage() {}
+func (*ReleaseQuarantinedFileResponse) ProtoMessage() {}
 
-func (x *DeleteFolderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[24]
+func (x *ReleaseQuarantinedFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[175]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1476,36 +11613,50 @@ func (x *DeleteFolderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFolderResponse.ProtoReflect.Descriptor instead.
-func (*DeleteFolderResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ReleaseQuarantinedFileResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseQuarantinedFileResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{175}
 }
 
-type SearchRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ReleaseQuarantinedFileResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *SearchRequest) Reset() {
-	*x = SearchRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[25]
+// 推送文件变更事件的 webhook, 请求体为 file-changes 中的事件 JSON
+// 请求头 X-Webhook-Signature 为 sha256=<hex>, 即以密钥对 "<X-Webhook-Timestamp>.<请求体>" 计算的 HMAC-SHA256
+type Webhook struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url            string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes     []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // 订阅的事件类型, 为空表示全部, 以 * 结尾时按前缀匹配
+	FolderId       int64                  `protobuf:"varint,4,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`      // 只推送该文件夹及其子孙下的事件, 0 表示不限
+	Enabled        bool                   `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Failures       int32                  `protobuf:"varint,6,opt,name=failures,proto3" json:"failures,omitempty"`                                  // 连续失败的投递次数
+	DisabledReason string                 `protobuf:"bytes,7,opt,name=disabled_reason,json=disabledReason,proto3" json:"disabled_reason,omitempty"` // 被自动禁用的原因
+	Ctime          int64                  `protobuf:"varint,8,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	Utime          int64                  `protobuf:"varint,9,opt,name=utime,proto3" json:"utime,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[176]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchRequest) String() string {
+func (x *Webhook) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest) ProtoMessage() {}
+func (*Webhook) ProtoMessage() {}
 
-func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[25]
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[176]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1516,114 +11667,99 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
-func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{176}
 }
 
-func (x *SearchRequest) GetUserId() int32 {
+func (x *Webhook) GetId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *SearchRequest) GetQuery() string {
+func (x *Webhook) GetUrl() string {
 	if x != nil {
-		return x.Query
+		return x.Url
 	}
 	return ""
 }
 
-func (x *SearchRequest) GetPage() int32 {
+func (x *Webhook) GetEventTypes() []string {
 	if x != nil {
-		return x.Page
+		return x.EventTypes
 	}
-	return 0
+	return nil
 }
 
-func (x *SearchRequest) GetSize() int32 {
+func (x *Webhook) GetFolderId() int64 {
 	if x != nil {
-		return x.Size
+		return x.FolderId
 	}
 	return 0
 }
 
-type SearchResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Files         []*File                `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
-	Folders       []*Folder              `protobuf:"bytes,2,rep,name=folders,proto3" json:"folders,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *SearchResponse) Reset() {
-	*x = SearchResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[26]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *SearchResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Webhook) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
 }
 
-func (*SearchResponse) ProtoMessage() {}
-
-func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[26]
+func (x *Webhook) GetFailures() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Failures
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
-func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{26}
+func (x *Webhook) GetDisabledReason() string {
+	if x != nil {
+		return x.DisabledReason
+	}
+	return ""
 }
 
-func (x *SearchResponse) GetFiles() []*File {
+func (x *Webhook) GetCtime() int64 {
 	if x != nil {
-		return x.Files
+		return x.Ctime
 	}
-	return nil
+	return 0
 }
 
-func (x *SearchResponse) GetFolders() []*Folder {
+func (x *Webhook) GetUtime() int64 {
 	if x != nil {
-		return x.Folders
+		return x.Utime
 	}
-	return nil
+	return 0
 }
 
-type PreviewRequest struct {
+type CreateWebhookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	FolderId      int64                  `protobuf:"varint,4,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PreviewRequest) Reset() {
-	*x = PreviewRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[27]
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[177]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PreviewRequest) String() string {
+func (x *CreateWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PreviewRequest) ProtoMessage() {}
+func (*CreateWebhookRequest) ProtoMessage() {}
 
-func (x *PreviewRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[27]
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[177]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1634,49 +11770,62 @@ func (x *PreviewRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PreviewRequest.ProtoReflect.Descriptor instead.
-func (*PreviewRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{177}
 }
 
-func (x *PreviewRequest) GetFileId() int64 {
+func (x *CreateWebhookRequest) GetUserId() int32 {
 	if x != nil {
-		return x.FileId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *PreviewRequest) GetUserId() int32 {
+func (x *CreateWebhookRequest) GetUrl() string {
 	if x != nil {
-		return x.UserId
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
 	}
 	return 0
 }
 
-type PreviewResponse struct {
+type CreateWebhookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PreviewUrl    string                 `protobuf:"bytes,1,opt,name=preview_url,json=previewUrl,proto3" json:"preview_url,omitempty"`
-	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	Type          PreviewType            `protobuf:"varint,3,opt,name=type,proto3,enum=file.PreviewType" json:"type,omitempty"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // 签名密钥, 只返回这一次
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PreviewResponse) Reset() {
-	*x = PreviewResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[28]
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[178]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PreviewResponse) String() string {
+func (x *CreateWebhookResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PreviewResponse) ProtoMessage() {}
+func (*CreateWebhookResponse) ProtoMessage() {}
 
-func (x *PreviewResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[28]
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[178]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1687,55 +11836,47 @@ func (x *PreviewResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PreviewResponse.ProtoReflect.Descriptor instead.
-func (*PreviewResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{178}
 }
 
-func (x *PreviewResponse) GetPreviewUrl() string {
+func (x *CreateWebhookResponse) GetWebhook() *Webhook {
 	if x != nil {
-		return x.PreviewUrl
+		return x.Webhook
 	}
-	return ""
+	return nil
 }
 
-func (x *PreviewResponse) GetContentType() string {
+func (x *CreateWebhookResponse) GetSecret() string {
 	if x != nil {
-		return x.ContentType
+		return x.Secret
 	}
 	return ""
 }
 
-func (x *PreviewResponse) GetType() PreviewType {
-	if x != nil {
-		return x.Type
-	}
-	return PreviewType_UNKNOWN
-}
-
-type PartInfo struct {
+type ListWebhooksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
-	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PartInfo) Reset() {
-	*x = PartInfo{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[29]
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[179]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PartInfo) String() string {
+func (x *ListWebhooksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PartInfo) ProtoMessage() {}
+func (*ListWebhooksRequest) ProtoMessage() {}
 
-func (x *PartInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[29]
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[179]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1746,49 +11887,40 @@ func (x *PartInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PartInfo.ProtoReflect.Descriptor instead.
-func (*PartInfo) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{179}
 }
 
-func (x *PartInfo) GetPartNumber() int32 {
+func (x *ListWebhooksRequest) GetUserId() int32 {
 	if x != nil {
-		return x.PartNumber
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *PartInfo) GetEtag() string {
-	if x != nil {
-		return x.Etag
-	}
-	return ""
-}
-
-type DownloadTaskRequest struct {
+type ListWebhooksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Files         []*FileDownloadInfo    `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
-	FolderName    string                 `protobuf:"bytes,3,opt,name=folder_name,json=folderName,proto3" json:"folder_name,omitempty"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadTaskRequest) Reset() {
-	*x = DownloadTaskRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[30]
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[180]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadTaskRequest) String() string {
+func (x *ListWebhooksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadTaskRequest) ProtoMessage() {}
+func (*ListWebhooksResponse) ProtoMessage() {}
 
-func (x *DownloadTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[30]
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[180]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1799,56 +11931,46 @@ func (x *DownloadTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadTaskRequest.ProtoReflect.Descriptor instead.
-func (*DownloadTaskRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{30}
-}
-
-func (x *DownloadTaskRequest) GetUserId() int32 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{180}
 }
 
-func (x *DownloadTaskRequest) GetFiles() []*FileDownloadInfo {
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
 	if x != nil {
-		return x.Files
+		return x.Webhooks
 	}
 	return nil
 }
 
-func (x *DownloadTaskRequest) GetFolderName() string {
-	if x != nil {
-		return x.FolderName
-	}
-	return ""
-}
-
-type FileDownloadInfo struct {
+// 修改 webhook 并重新启用
+type UpdateWebhookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	OrderNum      int32                  `protobuf:"varint,2,opt,name=order_num,json=orderNum,proto3" json:"order_num,omitempty"`
-	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	FolderId      int64                  `protobuf:"varint,5,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	RotateSecret  bool                   `protobuf:"varint,6,opt,name=rotate_secret,json=rotateSecret,proto3" json:"rotate_secret,omitempty"` // 同时更换签名密钥
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileDownloadInfo) Reset() {
-	*x = FileDownloadInfo{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[31]
+func (x *UpdateWebhookRequest) Reset() {
+	*x = UpdateWebhookRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[181]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileDownloadInfo) String() string {
+func (x *UpdateWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileDownloadInfo) ProtoMessage() {}
+func (*UpdateWebhookRequest) ProtoMessage() {}
 
-func (x *FileDownloadInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[31]
+func (x *UpdateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[181]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1859,54 +11981,76 @@ func (x *FileDownloadInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileDownloadInfo.ProtoReflect.Descriptor instead.
-func (*FileDownloadInfo) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use UpdateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{181}
 }
 
-func (x *FileDownloadInfo) GetFileId() int64 {
+func (x *UpdateWebhookRequest) GetUserId() int32 {
 	if x != nil {
-		return x.FileId
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateWebhookRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
 	}
 	return 0
 }
 
-func (x *FileDownloadInfo) GetOrderNum() int32 {
+func (x *UpdateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UpdateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *UpdateWebhookRequest) GetFolderId() int64 {
 	if x != nil {
-		return x.OrderNum
+		return x.FolderId
 	}
 	return 0
 }
 
-func (x *FileDownloadInfo) GetPath() string {
+func (x *UpdateWebhookRequest) GetRotateSecret() bool {
 	if x != nil {
-		return x.Path
+		return x.RotateSecret
 	}
-	return ""
+	return false
 }
 
-type DownloadTaskResponse struct {
+type UpdateWebhookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // 更换后的签名密钥, 未更换时为空
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadTaskResponse) Reset() {
-	*x = DownloadTaskResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[32]
+func (x *UpdateWebhookResponse) Reset() {
+	*x = UpdateWebhookResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[182]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadTaskResponse) String() string {
+func (x *UpdateWebhookResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadTaskResponse) ProtoMessage() {}
+func (*UpdateWebhookResponse) ProtoMessage() {}
 
-func (x *DownloadTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[32]
+func (x *UpdateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[182]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1917,41 +12061,48 @@ func (x *DownloadTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadTaskResponse.ProtoReflect.Descriptor instead.
-func (*DownloadTaskResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use UpdateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{182}
 }
 
-func (x *DownloadTaskResponse) GetTaskId() string {
+func (x *UpdateWebhookResponse) GetWebhook() *Webhook {
 	if x != nil {
-		return x.TaskId
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *UpdateWebhookResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
 	}
 	return ""
 }
 
-type GetDownloadTaskRequest struct {
+type DeleteWebhookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDownloadTaskRequest) Reset() {
-	*x = GetDownloadTaskRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[33]
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[183]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDownloadTaskRequest) String() string {
+func (x *DeleteWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDownloadTaskRequest) ProtoMessage() {}
+func (*DeleteWebhookRequest) ProtoMessage() {}
 
-func (x *GetDownloadTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[33]
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[183]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1962,52 +12113,46 @@ func (x *GetDownloadTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDownloadTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetDownloadTaskRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{183}
 }
 
-func (x *GetDownloadTaskRequest) GetTaskId() string {
+func (x *DeleteWebhookRequest) GetUserId() int32 {
 	if x != nil {
-		return x.TaskId
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *GetDownloadTaskRequest) GetUserId() int32 {
+func (x *DeleteWebhookRequest) GetId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return 0
 }
 
-type GetDownloadTaskResponse struct {
+type DeleteWebhookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // pending processing completed failed
-	FolderName    string                 `protobuf:"bytes,3,opt,name=folder_name,json=folderName,proto3" json:"folder_name,omitempty"`
-	TotalSize     int64                  `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
-	Progress      int64                  `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
-	Files         []*FileProgress        `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDownloadTaskResponse) Reset() {
-	*x = GetDownloadTaskResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[34]
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[184]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDownloadTaskResponse) String() string {
+func (x *DeleteWebhookResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDownloadTaskResponse) ProtoMessage() {}
+func (*DeleteWebhookResponse) ProtoMessage() {}
 
-func (x *GetDownloadTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[34]
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[184]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2018,80 +12163,42 @@ func (x *GetDownloadTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDownloadTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetDownloadTaskResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{34}
-}
-
-func (x *GetDownloadTaskResponse) GetTaskId() string {
-	if x != nil {
-		return x.TaskId
-	}
-	return ""
-}
-
-func (x *GetDownloadTaskResponse) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
-
-func (x *GetDownloadTaskResponse) GetFolderName() string {
-	if x != nil {
-		return x.FolderName
-	}
-	return ""
-}
-
-func (x *GetDownloadTaskResponse) GetTotalSize() int64 {
-	if x != nil {
-		return x.TotalSize
-	}
-	return 0
-}
-
-func (x *GetDownloadTaskResponse) GetProgress() int64 {
-	if x != nil {
-		return x.Progress
-	}
-	return 0
-}
-
-func (x *GetDownloadTaskResponse) GetFiles() []*FileProgress {
-	if x != nil {
-		return x.Files
-	}
-	return nil
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{184}
 }
 
-type FileProgress struct {
+type WebhookDelivery struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
-	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`          // pending processing completed failed
-	Downloaded    int64                  `protobuf:"varint,6,opt,name=downloaded,proto3" json:"downloaded,omitempty"` // 已下载大小
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId     int64                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Status        WebhookDeliveryStatus  `protobuf:"varint,4,opt,name=status,proto3,enum=file.WebhookDeliveryStatus" json:"status,omitempty"`
+	Attempts      int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	ResponseCode  int32                  `protobuf:"varint,6,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"` // 最近一次尝试的 HTTP 状态码, 没有收到响应时为 0
+	LastError     string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Payload       string                 `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	Ctime         int64                  `protobuf:"varint,9,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	Utime         int64                  `protobuf:"varint,10,opt,name=utime,proto3" json:"utime,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileProgress) Reset() {
-	*x = FileProgress{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[35]
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[185]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileProgress) String() string {
+func (x *WebhookDelivery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileProgress) ProtoMessage() {}
+func (*WebhookDelivery) ProtoMessage() {}
 
-func (x *FileProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[35]
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[185]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2102,135 +12209,106 @@ func (x *FileProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileProgress.ProtoReflect.Descriptor instead.
-func (*FileProgress) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{185}
 }
 
-func (x *FileProgress) GetFileId() int64 {
+func (x *WebhookDelivery) GetId() int64 {
 	if x != nil {
-		return x.FileId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *FileProgress) GetName() string {
+func (x *WebhookDelivery) GetWebhookId() int64 {
 	if x != nil {
-		return x.Name
+		return x.WebhookId
 	}
-	return ""
+	return 0
 }
 
-func (x *FileProgress) GetPath() string {
+func (x *WebhookDelivery) GetEventType() string {
 	if x != nil {
-		return x.Path
+		return x.EventType
 	}
 	return ""
 }
 
-func (x *FileProgress) GetSize() int64 {
+func (x *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
 	if x != nil {
-		return x.Size
+		return x.Status
 	}
-	return 0
+	return WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNKNOWN
 }
 
-func (x *FileProgress) GetStatus() string {
+func (x *WebhookDelivery) GetAttempts() int32 {
 	if x != nil {
-		return x.Status
+		return x.Attempts
 	}
-	return ""
+	return 0
 }
 
-func (x *FileProgress) GetDownloaded() int64 {
+func (x *WebhookDelivery) GetResponseCode() int32 {
 	if x != nil {
-		return x.Downloaded
+		return x.ResponseCode
 	}
 	return 0
 }
 
-type ResumeDownloadRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`            // 原任务ID
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`           // 用户ID
-	FileIds       []int64                `protobuf:"varint,3,rep,packed,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"` // 需要继续下载的文件ID列表
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ResumeDownloadRequest) Reset() {
-	*x = ResumeDownloadRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[36]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ResumeDownloadRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ResumeDownloadRequest) ProtoMessage() {}
-
-func (x *ResumeDownloadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[36]
+func (x *WebhookDelivery) GetLastError() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.LastError
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ResumeDownloadRequest.ProtoReflect.Descriptor instead.
-func (*ResumeDownloadRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{36}
+	return ""
 }
 
-func (x *ResumeDownloadRequest) GetTaskId() string {
+func (x *WebhookDelivery) GetPayload() string {
 	if x != nil {
-		return x.TaskId
+		return x.Payload
 	}
 	return ""
 }
 
-func (x *ResumeDownloadRequest) GetUserId() int32 {
+func (x *WebhookDelivery) GetCtime() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Ctime
 	}
 	return 0
 }
 
-func (x *ResumeDownloadRequest) GetFileIds() []int64 {
+func (x *WebhookDelivery) GetUtime() int64 {
 	if x != nil {
-		return x.FileIds
+		return x.Utime
 	}
-	return nil
+	return 0
 }
 
-type ResumeDownloadResponse struct {
+type ListWebhookDeliveriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NewTaskId     string                 `protobuf:"bytes,1,opt,name=new_task_id,json=newTaskId,proto3" json:"new_task_id,omitempty"` // 新的任务ID
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WebhookId     int64                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResumeDownloadResponse) Reset() {
-	*x = ResumeDownloadResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[37]
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[186]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResumeDownloadResponse) String() string {
+func (x *ListWebhookDeliveriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResumeDownloadResponse) ProtoMessage() {}
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
 
-func (x *ResumeDownloadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[37]
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[186]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2241,48 +12319,62 @@ func (x *ResumeDownloadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResumeDownloadResponse.ProtoReflect.Descriptor instead.
-func (*ResumeDownloadResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{186}
 }
 
-func (x *ResumeDownloadResponse) GetNewTaskId() string {
+func (x *ListWebhookDeliveriesRequest) GetUserId() int32 {
 	if x != nil {
-		return x.NewTaskId
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-type UploadChunkRequest struct {
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	UploadId      string                 `protobuf:"bytes,2,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
-	PartNumber    int32                  `protobuf:"varint,3,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
-	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
-	FileSize      int64                  `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
-	UserId        int32                  `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	FolderId      int64                  `protobuf:"varint,7,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
-	IsLast        bool                   `protobuf:"varint,8,opt,name=is_last,json=isLast,proto3" json:"is_last,omitempty"` // 是否是最后一个分片
-	Parts         []*PartInfo            `protobuf:"bytes,9,rep,name=parts,proto3" json:"parts,omitempty"`                  // 如果是最后一个分片，提供所有分片信息
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadChunkRequest) Reset() {
-	*x = UploadChunkRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[38]
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[187]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadChunkRequest) String() string {
+func (x *ListWebhookDeliveriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadChunkRequest) ProtoMessage() {}
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
 
-func (x *UploadChunkRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[38]
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[187]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2293,97 +12385,146 @@ func (x *UploadChunkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadChunkRequest.ProtoReflect.Descriptor instead.
-func (*UploadChunkRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{187}
 }
 
-func (x *UploadChunkRequest) GetFilename() string {
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
 	if x != nil {
-		return x.Filename
+		return x.Deliveries
 	}
-	return ""
+	return nil
 }
 
-func (x *UploadChunkRequest) GetUploadId() string {
+func (x *ListWebhookDeliveriesResponse) GetTotal() int64 {
 	if x != nil {
-		return x.UploadId
+		return x.Total
 	}
-	return ""
+	return 0
+}
+
+// 重新投递已完成的投递
+type RedeliverWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeliveryId    int64                  `protobuf:"varint,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookRequest) Reset() {
+	*x = RedeliverWebhookRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[188]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadChunkRequest) GetPartNumber() int32 {
-	if x != nil {
-		return x.PartNumber
-	}
-	return 0
+func (x *RedeliverWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *UploadChunkRequest) GetData() []byte {
+func (*RedeliverWebhookRequest) ProtoMessage() {}
+
+func (x *RedeliverWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[188]
 	if x != nil {
-		return x.Data
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UploadChunkRequest) GetFileSize() int64 {
-	if x != nil {
-		return x.FileSize
-	}
-	return 0
+// Deprecated: Use RedeliverWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{188}
 }
 
-func (x *UploadChunkRequest) GetUserId() int32 {
+func (x *RedeliverWebhookRequest) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *UploadChunkRequest) GetFolderId() int64 {
+func (x *RedeliverWebhookRequest) GetDeliveryId() int64 {
 	if x != nil {
-		return x.FolderId
+		return x.DeliveryId
 	}
 	return 0
 }
 
-func (x *UploadChunkRequest) GetIsLast() bool {
+type RedeliverWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivery      *WebhookDelivery       `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookResponse) Reset() {
+	*x = RedeliverWebhookResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[189]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookResponse) ProtoMessage() {}
+
+func (x *RedeliverWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[189]
 	if x != nil {
-		return x.IsLast
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *UploadChunkRequest) GetParts() []*PartInfo {
+// Deprecated: Use RedeliverWebhookResponse.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{189}
+}
+
+func (x *RedeliverWebhookResponse) GetDelivery() *WebhookDelivery {
 	if x != nil {
-		return x.Parts
+		return x.Delivery
 	}
 	return nil
 }
 
-type UploadChunkResponse struct {
+// AuditTarget 审计日志涉及的对象, type 取自请求中的字段名, 如 file、folder、share
+type AuditTarget struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"` // 如果是第一个分片，返回新的upload_id
-	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`                         // 分片的ETag
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadChunkResponse) Reset() {
-	*x = UploadChunkResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[39]
+func (x *AuditTarget) Reset() {
+	*x = AuditTarget{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[190]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadChunkResponse) String() string {
+func (x *AuditTarget) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadChunkResponse) ProtoMessage() {}
+func (*AuditTarget) ProtoMessage() {}
 
-func (x *UploadChunkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[39]
+func (x *AuditTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[190]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2394,51 +12535,63 @@ func (x *UploadChunkResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadChunkResponse.ProtoReflect.Descriptor instead.
-func (*UploadChunkResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use AuditTarget.ProtoReflect.Descriptor instead.
+func (*AuditTarget) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{190}
 }
 
-func (x *UploadChunkResponse) GetUploadId() string {
+func (x *AuditTarget) GetType() string {
 	if x != nil {
-		return x.UploadId
+		return x.Type
 	}
 	return ""
 }
 
-func (x *UploadChunkResponse) GetEtag() string {
+func (x *AuditTarget) GetId() string {
 	if x != nil {
-		return x.Etag
+		return x.Id
 	}
 	return ""
 }
 
-type CreateShareLinkRequest struct {
+// AuditLog 一次操作的审计记录, 只追加不修改
+// before 和 after 为操作前后目标的元数据(JSON 对象, 以 "<type>:<id>" 为键), 只读访问只记录 after
+type AuditLog struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // 分享者ID
-	FileIds       []int64                `protobuf:"varint,2,rep,packed,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`   // 要分享的文件ID列表
-	FolderId      int64                  `protobuf:"varint,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`       // 要分享的文件夹ID，与file_ids二选一
-	ExpireDays    int32                  `protobuf:"varint,4,opt,name=expire_days,json=expireDays,proto3" json:"expire_days,omitempty"` // 链接有效期(天)
-	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`                        // 可选的提取密码
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Service       string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`                 // 记录的服务, file 或 user
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`                   // 如 file.delete、share.download、user.update_info
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`                   // gRPC 方法全名
+	ActorId       int32                  `protobuf:"varint,5,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // 操作者, 匿名访问时为 0
+	OwnerId       int32                  `protobuf:"varint,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"` // 目标的所有者
+	DeviceId      string                 `protobuf:"bytes,7,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Ip            string                 `protobuf:"bytes,8,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,9,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Targets       []*AuditTarget         `protobuf:"bytes,10,rep,name=targets,proto3" json:"targets,omitempty"`
+	Before        string                 `protobuf:"bytes,11,opt,name=before,proto3" json:"before,omitempty"`
+	After         string                 `protobuf:"bytes,12,opt,name=after,proto3" json:"after,omitempty"`
+	Code          string                 `protobuf:"bytes,13,opt,name=code,proto3" json:"code,omitempty"` // gRPC 状态码, 成功时为 OK
+	Error         string                 `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
+	Ctime         int64                  `protobuf:"varint,15,opt,name=ctime,proto3" json:"ctime,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateShareLinkRequest) Reset() {
-	*x = CreateShareLinkRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[40]
+func (x *AuditLog) Reset() {
+	*x = AuditLog{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[191]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateShareLinkRequest) String() string {
+func (x *AuditLog) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateShareLinkRequest) ProtoMessage() {}
+func (*AuditLog) ProtoMessage() {}
 
-func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[40]
+func (x *AuditLog) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[191]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2449,140 +12602,144 @@ func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
-func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use AuditLog.ProtoReflect.Descriptor instead.
+func (*AuditLog) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{191}
 }
 
-func (x *CreateShareLinkRequest) GetUserId() int32 {
+func (x *AuditLog) GetId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *CreateShareLinkRequest) GetFileIds() []int64 {
+func (x *AuditLog) GetService() string {
 	if x != nil {
-		return x.FileIds
+		return x.Service
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateShareLinkRequest) GetFolderId() int64 {
+func (x *AuditLog) GetAction() string {
 	if x != nil {
-		return x.FolderId
+		return x.Action
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateShareLinkRequest) GetExpireDays() int32 {
+func (x *AuditLog) GetMethod() string {
 	if x != nil {
-		return x.ExpireDays
+		return x.Method
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateShareLinkRequest) GetPassword() string {
+func (x *AuditLog) GetActorId() int32 {
 	if x != nil {
-		return x.Password
+		return x.ActorId
 	}
-	return ""
+	return 0
 }
 
-type CreateShareLinkResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`     // 分享ID
-	ShareUrl      string                 `protobuf:"bytes,2,opt,name=share_url,json=shareUrl,proto3" json:"share_url,omitempty"`  // 分享链接
-	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`                  // 提取密码
-	ExpireAt      int64                  `protobuf:"varint,4,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // 过期时间
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AuditLog) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
 }
 
-func (x *CreateShareLinkResponse) Reset() {
-	*x = CreateShareLinkResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[41]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AuditLog) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
 }
 
-func (x *CreateShareLinkResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AuditLog) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
 }
 
-func (*CreateShareLinkResponse) ProtoMessage() {}
+func (x *AuditLog) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
 
-func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[41]
+func (x *AuditLog) GetTargets() []*AuditTarget {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Targets
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use CreateShareLinkResponse.ProtoReflect.Descriptor instead.
-func (*CreateShareLinkResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{41}
+func (x *AuditLog) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
 }
 
-func (x *CreateShareLinkResponse) GetShareId() string {
+func (x *AuditLog) GetAfter() string {
 	if x != nil {
-		return x.ShareId
+		return x.After
 	}
 	return ""
 }
 
-func (x *CreateShareLinkResponse) GetShareUrl() string {
+func (x *AuditLog) GetCode() string {
 	if x != nil {
-		return x.ShareUrl
+		return x.Code
 	}
 	return ""
 }
 
-func (x *CreateShareLinkResponse) GetPassword() string {
+func (x *AuditLog) GetError() string {
 	if x != nil {
-		return x.Password
+		return x.Error
 	}
 	return ""
 }
 
-func (x *CreateShareLinkResponse) GetExpireAt() int64 {
+func (x *AuditLog) GetCtime() int64 {
 	if x != nil {
-		return x.ExpireAt
+		return x.Ctime
 	}
 	return 0
 }
 
-type SaveToMyDriveRequest struct {
+// AuditLogFilter 审计日志的查询条件, 为零值的条件不生效
+type AuditLogFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`             // 分享ID
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`                          // 提取密码
-	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`               // 保存者的用户ID
-	ToFolderId    int64                  `protobuf:"varint,4,opt,name=to_folder_id,json=toFolderId,proto3" json:"to_folder_id,omitempty"` // 保存到的目标文件夹ID
-	FileIds       []int64                `protobuf:"varint,5,rep,packed,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`     // 选择保存的文件ID列表
+	ActorId       int32                  `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`       // 仅管理接口使用
+	StartTime     int64                  `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 包含, unix 秒
+	EndTime       int64                  `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // 不包含, unix 秒
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                         // 以 * 结尾时按前缀匹配, 如 share.*
+	TargetType    string                 `protobuf:"bytes,5,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetId      string                 `protobuf:"bytes,6,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SaveToMyDriveRequest) Reset() {
-	*x = SaveToMyDriveRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[42]
+func (x *AuditLogFilter) Reset() {
+	*x = AuditLogFilter{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[192]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveToMyDriveRequest) String() string {
+func (x *AuditLogFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveToMyDriveRequest) ProtoMessage() {}
+func (*AuditLogFilter) ProtoMessage() {}
 
-func (x *SaveToMyDriveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[42]
+func (x *AuditLogFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[192]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2593,67 +12750,79 @@ func (x *SaveToMyDriveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveToMyDriveRequest.ProtoReflect.Descriptor instead.
-func (*SaveToMyDriveRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use AuditLogFilter.ProtoReflect.Descriptor instead.
+func (*AuditLogFilter) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{192}
 }
 
-func (x *SaveToMyDriveRequest) GetShareId() string {
+func (x *AuditLogFilter) GetActorId() int32 {
 	if x != nil {
-		return x.ShareId
+		return x.ActorId
 	}
-	return ""
+	return 0
 }
 
-func (x *SaveToMyDriveRequest) GetPassword() string {
+func (x *AuditLogFilter) GetStartTime() int64 {
 	if x != nil {
-		return x.Password
+		return x.StartTime
 	}
-	return ""
+	return 0
 }
 
-func (x *SaveToMyDriveRequest) GetUserId() int32 {
+func (x *AuditLogFilter) GetEndTime() int64 {
 	if x != nil {
-		return x.UserId
+		return x.EndTime
 	}
 	return 0
 }
 
-func (x *SaveToMyDriveRequest) GetToFolderId() int64 {
+func (x *AuditLogFilter) GetAction() string {
 	if x != nil {
-		return x.ToFolderId
+		return x.Action
 	}
-	return 0
+	return ""
 }
 
-func (x *SaveToMyDriveRequest) GetFileIds() []int64 {
+func (x *AuditLogFilter) GetTargetType() string {
 	if x != nil {
-		return x.FileIds
+		return x.TargetType
 	}
-	return nil
+	return ""
 }
 
-type SaveToMyDriveResponse struct {
+func (x *AuditLogFilter) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+// 用户查询自己执行的或针对自己数据的操作
+type ListAuditLogsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filter        *AuditLogFilter        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SaveToMyDriveResponse) Reset() {
-	*x = SaveToMyDriveResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[43]
+func (x *ListAuditLogsRequest) Reset() {
+	*x = ListAuditLogsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[193]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveToMyDriveResponse) String() string {
+func (x *ListAuditLogsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveToMyDriveResponse) ProtoMessage() {}
+func (*ListAuditLogsRequest) ProtoMessage() {}
 
-func (x *SaveToMyDriveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[43]
+func (x *ListAuditLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[193]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2664,77 +12833,62 @@ func (x *SaveToMyDriveResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveToMyDriveResponse.ProtoReflect.Descriptor instead.
-func (*SaveToMyDriveResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{43}
-}
-
-type GetUserFileStoreRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetUserFileStoreRequest) Reset() {
-	*x = GetUserFileStoreRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[44]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+// Deprecated: Use ListAuditLogsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{193}
 }
 
-func (x *GetUserFileStoreRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ListAuditLogsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
 }
 
-func (*GetUserFileStoreRequest) ProtoMessage() {}
-
-func (x *GetUserFileStoreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[44]
+func (x *ListAuditLogsRequest) GetFilter() *AuditLogFilter {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Filter
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetUserFileStoreRequest.ProtoReflect.Descriptor instead.
-func (*GetUserFileStoreRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{44}
+func (x *ListAuditLogsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
 }
 
-func (x *GetUserFileStoreRequest) GetUserId() int32 {
+func (x *ListAuditLogsRequest) GetSize() int32 {
 	if x != nil {
-		return x.UserId
+		return x.Size
 	}
 	return 0
 }
 
-type GetUserFileStoreResponse struct {
+type ListAuditLogsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileStore     *FileStore             `protobuf:"bytes,1,opt,name=file_store,json=fileStore,proto3" json:"file_store,omitempty"`
+	Logs          []*AuditLog            `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserFileStoreResponse) Reset() {
-	*x = GetUserFileStoreResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[45]
+func (x *ListAuditLogsResponse) Reset() {
+	*x = ListAuditLogsResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[194]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserFileStoreResponse) String() string {
+func (x *ListAuditLogsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserFileStoreResponse) ProtoMessage() {}
+func (*ListAuditLogsResponse) ProtoMessage() {}
 
-func (x *GetUserFileStoreResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[45]
+func (x *ListAuditLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[194]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2744,48 +12898,50 @@ func (x *GetUserFileStoreResponse) ProtoReflect() protoreflect.Message {
 	}
 	return mi.MessageOf(x)
 }
-
-// Deprecated: Use GetUserFileStoreResponse.ProtoReflect.Descriptor instead.
-func (*GetUserFileStoreResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{45}
+
+// Deprecated: Use ListAuditLogsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditLogsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{194}
 }
 
-func (x *GetUserFileStoreResponse) GetFileStore() *FileStore {
+func (x *ListAuditLogsResponse) GetLogs() []*AuditLog {
 	if x != nil {
-		return x.FileStore
+		return x.Logs
 	}
 	return nil
 }
 
-type UpdateFileRequest struct {
+func (x *ListAuditLogsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ExportAuditLogsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"` // 完整文件内容（用于全量更新，与changes字段二选一）
-	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	DeviceId      string                 `protobuf:"bytes,5,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`                 // 设备ID
-	BaseVersion   int64                  `protobuf:"varint,6,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`       // 基础版本号，客户端基于哪个版本进行的修改
-	Changes       []*FileChange          `protobuf:"bytes,7,rep,name=changes,proto3" json:"changes,omitempty"`                                   // 文件变更列表（用于增量更新，与data字段二选一）
-	IsIncremental bool                   `protobuf:"varint,8,opt,name=is_incremental,json=isIncremental,proto3" json:"is_incremental,omitempty"` // 是否是增量更新
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filter        *AuditLogFilter        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Format        AuditExportFormat      `protobuf:"varint,3,opt,name=format,proto3,enum=file.AuditExportFormat" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateFileRequest) Reset() {
-	*x = UpdateFileRequest{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[46]
+func (x *ExportAuditLogsRequest) Reset() {
+	*x = ExportAuditLogsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[195]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateFileRequest) String() string {
+func (x *ExportAuditLogsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateFileRequest) ProtoMessage() {}
+func (*ExportAuditLogsRequest) ProtoMessage() {}
 
-func (x *UpdateFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[46]
+func (x *ExportAuditLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[195]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2796,93 +12952,101 @@ func (x *UpdateFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateFileRequest.ProtoReflect.Descriptor instead.
-func (*UpdateFileRequest) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{46}
-}
-
-func (x *UpdateFileRequest) GetFileId() int64 {
-	if x != nil {
-		return x.FileId
-	}
-	return 0
+// Deprecated: Use ExportAuditLogsRequest.ProtoReflect.Descriptor instead.
+func (*ExportAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{195}
 }
 
-func (x *UpdateFileRequest) GetUserId() int32 {
+func (x *ExportAuditLogsRequest) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *UpdateFileRequest) GetData() []byte {
+func (x *ExportAuditLogsRequest) GetFilter() *AuditLogFilter {
 	if x != nil {
-		return x.Data
+		return x.Filter
 	}
 	return nil
 }
 
-func (x *UpdateFileRequest) GetName() string {
+func (x *ExportAuditLogsRequest) GetFormat() AuditExportFormat {
 	if x != nil {
-		return x.Name
+		return x.Format
 	}
-	return ""
+	return AuditExportFormat_AUDIT_EXPORT_FORMAT_CSV
 }
 
-func (x *UpdateFileRequest) GetDeviceId() string {
-	if x != nil {
-		return x.DeviceId
-	}
-	return ""
+// 导出的内容分块返回, 按时间升序
+type ExportAuditLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateFileRequest) GetBaseVersion() int64 {
-	if x != nil {
-		return x.BaseVersion
-	}
-	return 0
+func (x *ExportAuditLogsResponse) Reset() {
+	*x = ExportAuditLogsResponse{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[196]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateFileRequest) GetChanges() []*FileChange {
+func (x *ExportAuditLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAuditLogsResponse) ProtoMessage() {}
+
+func (x *ExportAuditLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[196]
 	if x != nil {
-		return x.Changes
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateFileRequest) GetIsIncremental() bool {
+// Deprecated: Use ExportAuditLogsResponse.ProtoReflect.Descriptor instead.
+func (*ExportAuditLogsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{196}
+}
+
+func (x *ExportAuditLogsResponse) GetData() []byte {
 	if x != nil {
-		return x.IsIncremental
+		return x.Data
 	}
-	return false
+	return nil
 }
 
-// 文件变更记录
-type FileChange struct {
+type QueryAuditLogsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Operation     ChangeOperation        `protobuf:"varint,1,opt,name=operation,proto3,enum=file.ChangeOperation" json:"operation,omitempty"` // 操作类型
-	Position      int64                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`                             // 变更的起始位置（字节偏移量）
-	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`                                 // 原内容长度（用于Delete和Update操作）
-	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`                                // 新内容（用于Insert和Update操作）
+	Filter        *AuditLogFilter        `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileChange) Reset() {
-	*x = FileChange{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[47]
+func (x *QueryAuditLogsRequest) Reset() {
+	*x = QueryAuditLogsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[197]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileChange) String() string {
+func (x *QueryAuditLogsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileChange) ProtoMessage() {}
+func (*QueryAuditLogsRequest) ProtoMessage() {}
 
-func (x *FileChange) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[47]
+func (x *QueryAuditLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[197]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2893,66 +13057,55 @@ func (x *FileChange) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileChange.ProtoReflect.Descriptor instead.
-func (*FileChange) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use QueryAuditLogsRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{197}
 }
 
-func (x *FileChange) GetOperation() ChangeOperation {
+func (x *QueryAuditLogsRequest) GetFilter() *AuditLogFilter {
 	if x != nil {
-		return x.Operation
+		return x.Filter
 	}
-	return ChangeOperation_INSERT
+	return nil
 }
 
-func (x *FileChange) GetPosition() int64 {
+func (x *QueryAuditLogsRequest) GetPage() int32 {
 	if x != nil {
-		return x.Position
+		return x.Page
 	}
 	return 0
 }
 
-func (x *FileChange) GetLength() int64 {
+func (x *QueryAuditLogsRequest) GetSize() int32 {
 	if x != nil {
-		return x.Length
+		return x.Size
 	}
 	return 0
 }
 
-func (x *FileChange) GetContent() []byte {
-	if x != nil {
-		return x.Content
-	}
-	return nil
-}
-
-// 不修改 UpdateFileResponse
-type UpdateFileResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	File            *File                  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	HasConflict     bool                   `protobuf:"varint,2,opt,name=has_conflict,json=hasConflict,proto3" json:"has_conflict,omitempty"`            // 是否存在冲突
-	ConflictMessage string                 `protobuf:"bytes,3,opt,name=conflict_message,json=conflictMessage,proto3" json:"conflict_message,omitempty"` // 冲突信息
-	CurrentVersion  int64                  `protobuf:"varint,4,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`   // 当前最新版本号
-	NeededChanges   []*FileChange          `protobuf:"bytes,5,rep,name=needed_changes,json=neededChanges,proto3" json:"needed_changes,omitempty"`       // 客户端需要应用的变更（冲突时提供）
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type DumpAuditLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *AuditLogFilter        `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Format        AuditExportFormat      `protobuf:"varint,2,opt,name=format,proto3,enum=file.AuditExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateFileResponse) Reset() {
-	*x = UpdateFileResponse{}
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[48]
+func (x *DumpAuditLogsRequest) Reset() {
+	*x = DumpAuditLogsRequest{}
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[198]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateFileResponse) String() string {
+func (x *DumpAuditLogsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateFileResponse) ProtoMessage() {}
+func (*DumpAuditLogsRequest) ProtoMessage() {}
 
-func (x *UpdateFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_cloudstorage_file_proto_msgTypes[48]
+func (x *DumpAuditLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_file_proto_msgTypes[198]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2963,51 +13116,30 @@ func (x *UpdateFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateFileResponse.ProtoReflect.Descriptor instead.
-func (*UpdateFileResponse) Descriptor() ([]byte, []int) {
-	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use DumpAuditLogsRequest.ProtoReflect.Descriptor instead.
+func (*DumpAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_file_proto_rawDescGZIP(), []int{198}
 }
 
-func (x *UpdateFileResponse) GetFile() *File {
+func (x *DumpAuditLogsRequest) GetFilter() *AuditLogFilter {
 	if x != nil {
-		return x.File
+		return x.Filter
 	}
 	return nil
 }
 
-func (x *UpdateFileResponse) GetHasConflict() bool {
-	if x != nil {
-		return x.HasConflict
-	}
-	return false
-}
-
-func (x *UpdateFileResponse) GetConflictMessage() string {
-	if x != nil {
-		return x.ConflictMessage
-	}
-	return ""
-}
-
-func (x *UpdateFileResponse) GetCurrentVersion() int64 {
-	if x != nil {
-		return x.CurrentVersion
-	}
-	return 0
-}
-
-func (x *UpdateFileResponse) GetNeededChanges() []*FileChange {
+func (x *DumpAuditLogsRequest) GetFormat() AuditExportFormat {
 	if x != nil {
-		return x.NeededChanges
+		return x.Format
 	}
-	return nil
+	return AuditExportFormat_AUDIT_EXPORT_FORMAT_CSV
 }
 
 var File_idl_cloudstorage_file_proto protoreflect.FileDescriptor
 
 const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\n" +
-	"\x1bidl/cloudstorage/file.proto\x12\x04file\"\xb7\x01\n" +
+	"\x1bidl/cloudstorage/file.proto\x12\x04file\"\xce\x03\n" +
 	"\fFileMetaData\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
@@ -3015,7 +13147,24 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\x04path\x18\x04 \x01(\tR\x04path\x12!\n" +
 	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x17\n" +
 	"\auser_id\x18\x06 \x01(\x05R\x06userId\x12\x1b\n" +
-	"\tfolder_id\x18\a \x01(\x03R\bfolderId\"\xff\x01\n" +
+	"\tfolder_id\x18\a \x01(\x03R\bfolderId\x12<\n" +
+	"\bmetadata\x18\b \x03(\v2 .file.FileMetaData.MetadataEntryR\bmetadata\x12\x1f\n" +
+	"\vfolder_path\x18\t \x01(\tR\n" +
+	"folderPath\x12%\n" +
+	"\x0ecreate_parents\x18\n" +
+	" \x01(\bR\rcreateParents\x12A\n" +
+	"\x0fconflict_policy\x18\v \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\x1aL\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.file.MetaValueR\x05value:\x028\x01\"\xa0\x01\n" +
+	"\tMetaValue\x12#\n" +
+	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12#\n" +
+	"\fnumber_value\x18\x02 \x01(\x01H\x00R\vnumberValue\x12\x1f\n" +
+	"\n" +
+	"date_value\x18\x03 \x01(\x03H\x00R\tdateValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x04 \x01(\bH\x00R\tboolValueB\a\n" +
+	"\x05value\"\xb6\x03\n" +
 	"\x04File\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
@@ -3027,33 +13176,65 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\aversion\x18\t \x01(\x05R\aversion\x12\x1b\n" +
 	"\tdevice_id\x18\n" +
 	" \x01(\tR\bdeviceId\x12(\n" +
-	"\x10last_modified_by\x18\v \x01(\tR\x0elastModifiedBy\"\x8c\x01\n" +
+	"\x10last_modified_by\x18\v \x01(\tR\x0elastModifiedBy\x124\n" +
+	"\bmetadata\x18\f \x03(\v2\x18.file.File.MetadataEntryR\bmetadata\x12\x19\n" +
+	"\bvault_id\x18\r \x01(\x03R\avaultId\x12\x16\n" +
+	"\x06sha256\x18\x0e \x01(\tR\x06sha256\x1aL\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.file.MetaValueR\x05value:\x028\x01\"\x8a\x02\n" +
 	"\x06Folder\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
 	"\tparent_id\x18\x03 \x01(\x03R\bparentId\x12\x17\n" +
 	"\auser_id\x18\x04 \x01(\x05R\x06userId\x12\x12\n" +
 	"\x04path\x18\x05 \x01(\tR\x04path\x12\x14\n" +
-	"\x05utime\x18\x06 \x01(\tR\x05utime\"c\n" +
+	"\x05utime\x18\x06 \x01(\tR\x05utime\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\a \x01(\x03R\ttotalSize\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\b \x01(\x03R\tfileCount\x12#\n" +
+	"\rlast_modified\x18\t \x01(\tR\flastModified\x12\x19\n" +
+	"\bvault_id\x18\n" +
+	" \x01(\x03R\avaultId\"`\n" +
+	"\n" +
+	"FolderNode\x12$\n" +
+	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\x12,\n" +
+	"\bchildren\x18\x02 \x03(\v2\x10.file.FolderNodeR\bchildren\"\x98\x01\n" +
 	"\tFileStore\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1a\n" +
 	"\bcapacity\x18\x02 \x01(\x03R\bcapacity\x12!\n" +
-	"\fcurrent_size\x18\x03 \x01(\x03R\vcurrentSize\"S\n" +
+	"\fcurrent_size\x18\x03 \x01(\x03R\vcurrentSize\x12\x17\n" +
+	"\aplan_id\x18\x04 \x01(\x03R\x06planId\x12\x1a\n" +
+	"\breserved\x18\x05 \x01(\x03R\breserved\"\xc5\x01\n" +
+	"\vStoragePlan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x03R\bcapacity\x12\"\n" +
+	"\rmax_file_size\x18\x04 \x01(\x03R\vmaxFileSize\x12+\n" +
+	"\x11version_retention\x18\x05 \x01(\x05R\x10versionRetention\x12%\n" +
+	"\x0ebandwidth_tier\x18\x06 \x01(\tR\rbandwidthTier\"S\n" +
 	"\rUploadRequest\x12.\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x12.file.FileMetaDataR\bmetadata\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\fR\x04data\" \n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x80\x01\n" +
 	"\x0eUploadResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"1\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\bR\askipped\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\"1\n" +
 	"\x16CreateFileStoreRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\")\n" +
 	"\x17CreateFileStoreResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"_\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xa2\x01\n" +
 	"\x13CreateFolderRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
 	"\tparent_id\x18\x02 \x01(\x03R\bparentId\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\x05R\x06userId\"<\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12A\n" +
+	"\x0fconflict_policy\x18\x04 \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\"V\n" +
 	"\x14CreateFolderResponse\x12$\n" +
-	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\"I\n" +
+	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\bR\askipped\"I\n" +
 	"\x11ListFolderRequest\x12\x1b\n" +
 	"\tfolder_id\x18\x01 \x01(\x03R\bfolderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\"^\n" +
@@ -3063,31 +13244,42 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	".file.FileR\x05files\"B\n" +
 	"\x0eGetFileRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x05R\x06userId\"1\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"i\n" +
 	"\x0fGetFileResponse\x12\x1e\n" +
 	"\x04file\x18\x01 \x01(\v2\n" +
-	".file.FileR\x04file\"C\n" +
+	".file.FileR\x04file\x126\n" +
+	"\n" +
+	"processing\x18\x02 \x03(\v2\x16.file.ProcessingResultR\n" +
+	"processing\"s\n" +
 	"\x0fDownloadRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x05R\x06userId\"&\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x03R\x06length\"&\n" +
 	"\x10DownloadResponse\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\",\n" +
 	"\x16DownloadStreamResponse\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"\x8c\x01\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xcf\x01\n" +
 	"\x11MoveFolderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
 	"\tfolder_id\x18\x02 \x01(\x03R\bfolderId\x12 \n" +
 	"\fto_folder_id\x18\x03 \x01(\x03R\n" +
 	"toFolderId\x12\x1f\n" +
 	"\vfolder_name\x18\x04 \x01(\tR\n" +
-	"folderName\"\x14\n" +
-	"\x12MoveFolderResponse\"e\n" +
+	"folderName\x12A\n" +
+	"\x0fconflict_policy\x18\x05 \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\"T\n" +
+	"\x12MoveFolderResponse\x12$\n" +
+	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\bR\askipped\"\xa8\x01\n" +
 	"\x0fMoveFileRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
 	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12 \n" +
 	"\fto_folder_id\x18\x04 \x01(\x03R\n" +
-	"toFolderId\"\x12\n" +
-	"\x10MoveFileResponse\"E\n" +
+	"toFolderId\x12A\n" +
+	"\x0fconflict_policy\x18\x05 \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\"@\n" +
+	"\x10MoveFileResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\bR\askipped\"E\n" +
 	"\x11DeleteFileRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\x14\n" +
@@ -3095,12 +13287,13 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\x13DeleteFolderRequest\x12\x1b\n" +
 	"\tfolder_id\x18\x01 \x01(\x03R\bfolderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\x16\n" +
-	"\x14DeleteFolderResponse\"f\n" +
+	"\x14DeleteFolderResponse\"\x83\x01\n" +
 	"\rSearchRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
-	"\x04size\x18\x04 \x01(\x05R\x04size\"Z\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\x12\x1b\n" +
+	"\tfolder_id\x18\x05 \x01(\x03R\bfolderId\"Z\n" +
 	"\x0eSearchResponse\x12 \n" +
 	"\x05files\x18\x01 \x03(\v2\n" +
 	".file.FileR\x05files\x12&\n" +
@@ -3154,7 +13347,7 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x19\n" +
 	"\bfile_ids\x18\x03 \x03(\x03R\afileIds\"8\n" +
 	"\x16ResumeDownloadResponse\x12\x1e\n" +
-	"\vnew_task_id\x18\x01 \x01(\tR\tnewTaskId\"\x94\x02\n" +
+	"\vnew_task_id\x18\x01 \x01(\tR\tnewTaskId\"\x87\x03\n" +
 	"\x12UploadChunkRequest\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1b\n" +
 	"\tupload_id\x18\x02 \x01(\tR\buploadId\x12\x1f\n" +
@@ -3165,35 +13358,152 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\auser_id\x18\x06 \x01(\x05R\x06userId\x12\x1b\n" +
 	"\tfolder_id\x18\a \x01(\x03R\bfolderId\x12\x17\n" +
 	"\ais_last\x18\b \x01(\bR\x06isLast\x12$\n" +
-	"\x05parts\x18\t \x03(\v2\x0e.file.PartInfoR\x05parts\"F\n" +
+	"\x05parts\x18\t \x03(\v2\x0e.file.PartInfoR\x05parts\x12A\n" +
+	"\x0fconflict_policy\x18\n" +
+	" \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\x12\x1a\n" +
+	"\bchecksum\x18\v \x01(\tR\bchecksum\x12\x12\n" +
+	"\x04hash\x18\f \x01(\tR\x04hash\"r\n" +
 	"\x13UploadChunkResponse\x12\x1b\n" +
 	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x12\n" +
-	"\x04etag\x18\x02 \x01(\tR\x04etag\"\xa6\x01\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\"\xe8\x01\n" +
 	"\x16CreateShareLinkRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
 	"\bfile_ids\x18\x02 \x03(\x03R\afileIds\x12\x1b\n" +
 	"\tfolder_id\x18\x03 \x01(\x03R\bfolderId\x12\x1f\n" +
 	"\vexpire_days\x18\x04 \x01(\x05R\n" +
 	"expireDays\x12\x1a\n" +
-	"\bpassword\x18\x05 \x01(\tR\bpassword\"\x8a\x01\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12#\n" +
+	"\rmax_downloads\x18\x06 \x01(\x03R\fmaxDownloads\x12\x1b\n" +
+	"\tmax_saves\x18\a \x01(\x03R\bmaxSaves\"\x8a\x01\n" +
 	"\x17CreateShareLinkResponse\x12\x19\n" +
 	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x1b\n" +
 	"\tshare_url\x18\x02 \x01(\tR\bshareUrl\x12\x1a\n" +
 	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1b\n" +
-	"\texpire_at\x18\x04 \x01(\x03R\bexpireAt\"\xa3\x01\n" +
+	"\texpire_at\x18\x04 \x01(\x03R\bexpireAt\"\xa2\x02\n" +
 	"\x14SaveToMyDriveRequest\x12\x19\n" +
 	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x17\n" +
 	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12 \n" +
 	"\fto_folder_id\x18\x04 \x01(\x03R\n" +
 	"toFolderId\x12\x19\n" +
-	"\bfile_ids\x18\x05 \x03(\x03R\afileIds\"\x17\n" +
-	"\x15SaveToMyDriveResponse\"2\n" +
+	"\bfile_ids\x18\x05 \x03(\x03R\afileIds\x12A\n" +
+	"\x0fconflict_policy\x18\x06 \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\x12\x1b\n" +
+	"\tclient_ip\x18\a \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"folder_ids\x18\b \x03(\x03R\tfolderIds\"\x92\x01\n" +
+	"\x15SaveToMyDriveResponse\x12 \n" +
+	"\x05files\x18\x01 \x03(\v2\n" +
+	".file.FileR\x05files\x12&\n" +
+	"\afolders\x18\x02 \x03(\v2\f.file.FolderR\afolders\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\x05R\askipped\x12\x15\n" +
+	"\x06job_id\x18\x04 \x01(\tR\x05jobId\"\x8f\x01\n" +
+	"\tShareInfo\x12\x19\n" +
+	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\x05R\aownerId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\tis_folder\x18\x04 \x01(\bR\bisFolder\x12\x1b\n" +
+	"\texpire_at\x18\x05 \x01(\x03R\bexpireAt\"\x89\x01\n" +
+	"\x16ListShareFolderRequest\x12\x19\n" +
+	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tfolder_id\x18\x03 \x01(\x03R\bfolderId\x12\x1b\n" +
+	"\tclient_ip\x18\x04 \x01(\tR\bclientIp\"\x8a\x01\n" +
+	"\x17ListShareFolderResponse\x12%\n" +
+	"\x05share\x18\x01 \x01(\v2\x0f.file.ShareInfoR\x05share\x12 \n" +
+	"\x05files\x18\x02 \x03(\v2\n" +
+	".file.FileR\x05files\x12&\n" +
+	"\afolders\x18\x03 \x03(\v2\f.file.FolderR\afolders\"\x87\x01\n" +
+	"\x15ListShareFilesRequest\x12\x19\n" +
+	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tclient_ip\x18\x03 \x01(\tR\bclientIp\x12\x1a\n" +
+	"\bdownload\x18\x04 \x01(\bR\bdownload\"@\n" +
+	"\n" +
+	"ShareEntry\x12\x1e\n" +
+	"\x04file\x18\x01 \x01(\v2\n" +
+	".file.FileR\x04file\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"\x90\x01\n" +
+	"\x16ListShareFilesResponse\x12%\n" +
+	"\x05share\x18\x01 \x01(\v2\x0f.file.ShareInfoR\x05share\x12*\n" +
+	"\aentries\x18\x02 \x03(\v2\x10.file.ShareEntryR\aentries\x12#\n" +
+	"\rarchive_token\x18\x03 \x01(\tR\farchiveToken\"\x9e\x02\n" +
+	"\x10ShareFileRequest\x12\x19\n" +
+	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x17\n" +
+	"\afile_id\x18\x03 \x01(\x03R\x06fileId\x12\x1b\n" +
+	"\tclient_ip\x18\x04 \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"in_archive\x18\x05 \x01(\bR\tinArchive\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\a \x01(\x03R\x06length\x12#\n" +
+	"\rarchive_token\x18\t \x01(\tR\farchiveToken\x12#\n" +
+	"\rpreview_token\x18\n" +
+	" \x01(\tR\fpreviewTokenJ\x04\b\b\x10\t\"\xa0\x03\n" +
+	"\fShareSummary\x12\x19\n" +
+	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x1b\n" +
+	"\tshare_url\x18\x02 \x01(\tR\bshareUrl\x12\x1b\n" +
+	"\tfolder_id\x18\x03 \x01(\x03R\bfolderId\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x04 \x01(\x03R\tfileCount\x12!\n" +
+	"\fhas_password\x18\x05 \x01(\bR\vhasPassword\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1b\n" +
+	"\texpire_at\x18\a \x01(\x03R\bexpireAt\x12\x16\n" +
+	"\x06status\x18\b \x01(\x05R\x06status\x12#\n" +
+	"\rmax_downloads\x18\t \x01(\x03R\fmaxDownloads\x12\x1b\n" +
+	"\tmax_saves\x18\n" +
+	" \x01(\x03R\bmaxSaves\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\v \x01(\x03R\tviewCount\x12%\n" +
+	"\x0edownload_count\x18\f \x01(\x03R\rdownloadCount\x12\x1d\n" +
+	"\n" +
+	"save_count\x18\r \x01(\x03R\tsaveCount\"l\n" +
+	"\x11ListSharesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\"V\n" +
+	"\x12ListSharesResponse\x12*\n" +
+	"\x06shares\x18\x01 \x03(\v2\x12.file.ShareSummaryR\x06shares\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"K\n" +
+	"\x13RevokeSharesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tshare_ids\x18\x02 \x03(\tR\bshareIds\"0\n" +
+	"\x14RevokeSharesResponse\x12\x18\n" +
+	"\arevoked\x18\x01 \x01(\x05R\arevoked\"\xb6\x02\n" +
+	"\x12UpdateShareRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bshare_id\x18\x02 \x01(\tR\ashareId\x12#\n" +
+	"\rupdate_expire\x18\x03 \x01(\bR\fupdateExpire\x12\x1b\n" +
+	"\texpire_at\x18\x04 \x01(\x03R\bexpireAt\x12'\n" +
+	"\x0fupdate_password\x18\x05 \x01(\bR\x0eupdatePassword\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\x12#\n" +
+	"\rupdate_limits\x18\a \x01(\bR\fupdateLimits\x12#\n" +
+	"\rmax_downloads\x18\b \x01(\x03R\fmaxDownloads\x12\x1b\n" +
+	"\tmax_saves\x18\t \x01(\x03R\bmaxSaves\"?\n" +
+	"\x13UpdateShareResponse\x12(\n" +
+	"\x05share\x18\x01 \x01(\v2\x12.file.ShareSummaryR\x05share\"g\n" +
+	"\x0eShareAccessLog\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05ctime\x18\x04 \x01(\x03R\x05ctime\"v\n" +
+	"\x18GetShareAccessLogRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bshare_id\x18\x02 \x01(\tR\ashareId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\"[\n" +
+	"\x19GetShareAccessLogResponse\x12(\n" +
+	"\x04logs\x18\x01 \x03(\v2\x14.file.ShareAccessLogR\x04logs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"2\n" +
 	"\x17GetUserFileStoreRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x05R\x06userId\"J\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"q\n" +
 	"\x18GetUserFileStoreResponse\x12.\n" +
 	"\n" +
-	"file_store\x18\x01 \x01(\v2\x0f.file.FileStoreR\tfileStore\"\x80\x02\n" +
+	"file_store\x18\x01 \x01(\v2\x0f.file.FileStoreR\tfileStore\x12%\n" +
+	"\x04plan\x18\x02 \x01(\v2\x11.file.StoragePlanR\x04plan\"\x94\x02\n" +
 	"\x11UpdateFileRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
@@ -3202,7 +13512,8 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\tdevice_id\x18\x05 \x01(\tR\bdeviceId\x12!\n" +
 	"\fbase_version\x18\x06 \x01(\x03R\vbaseVersion\x12*\n" +
 	"\achanges\x18\a \x03(\v2\x10.file.FileChangeR\achanges\x12%\n" +
-	"\x0eis_incremental\x18\b \x01(\bR\risIncremental\"\x8f\x01\n" +
+	"\x0eis_incremental\x18\b \x01(\bR\risIncremental\x12\x12\n" +
+	"\x04hash\x18\t \x01(\tR\x04hash\"\x8f\x01\n" +
 	"\n" +
 	"FileChange\x123\n" +
 	"\toperation\x18\x01 \x01(\x0e2\x15.file.ChangeOperationR\toperation\x12\x1a\n" +
@@ -3215,7 +13526,588 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\fhas_conflict\x18\x02 \x01(\bR\vhasConflict\x12)\n" +
 	"\x10conflict_message\x18\x03 \x01(\tR\x0fconflictMessage\x12'\n" +
 	"\x0fcurrent_version\x18\x04 \x01(\x03R\x0ecurrentVersion\x127\n" +
-	"\x0eneeded_changes\x18\x05 \x03(\v2\x10.file.FileChangeR\rneededChanges*F\n" +
+	"\x0eneeded_changes\x18\x05 \x03(\v2\x10.file.FileChangeR\rneededChanges\"F\n" +
+	"\x12GetFileMetaRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\xa8\x01\n" +
+	"\x13GetFileMetaResponse\x12C\n" +
+	"\bmetadata\x18\x01 \x03(\v2'.file.GetFileMetaResponse.MetadataEntryR\bmetadata\x1aL\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.file.MetaValueR\x05value:\x028\x01\"\xd8\x01\n" +
+	"\x12SetFileMetaRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12B\n" +
+	"\bmetadata\x18\x03 \x03(\v2&.file.SetFileMetaRequest.MetadataEntryR\bmetadata\x1aL\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.file.MetaValueR\x05value:\x028\x01\"\xa8\x01\n" +
+	"\x13SetFileMetaResponse\x12C\n" +
+	"\bmetadata\x18\x01 \x03(\v2'.file.SetFileMetaResponse.MetadataEntryR\bmetadata\x1aL\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.file.MetaValueR\x05value:\x028\x01\"]\n" +
+	"\x15DeleteFileMetaRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keys\"\x18\n" +
+	"\x16DeleteFileMetaResponse\"\xa8\x01\n" +
+	"\x0fCopyFileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12 \n" +
+	"\fto_folder_id\x18\x03 \x01(\x03R\n" +
+	"toFolderId\x12A\n" +
+	"\x0fconflict_policy\x18\x04 \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\"L\n" +
+	"\x10CopyFileResponse\x12\x1e\n" +
+	"\x04file\x18\x01 \x01(\v2\n" +
+	".file.FileR\x04file\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\bR\askipped\"\xae\x01\n" +
+	"\x11CopyFolderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\x03R\bfolderId\x12 \n" +
+	"\fto_folder_id\x18\x03 \x01(\x03R\n" +
+	"toFolderId\x12A\n" +
+	"\x0fconflict_policy\x18\x04 \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\"k\n" +
+	"\x12CopyFolderResponse\x12$\n" +
+	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\bR\askipped\"?\n" +
+	"\rGetJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\xb0\x01\n" +
+	"\x0eGetJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\x03R\x04done\x12\x1b\n" +
+	"\tresult_id\x18\x06 \x01(\x03R\bresultId\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"_\n" +
+	"\tBatchItem\x12'\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x13.file.BatchItemTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x19\n" +
+	"\bnew_name\x18\x03 \x01(\tR\anewName\"\xbe\x01\n" +
+	"\x0fBatchItemResult\x12'\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x13.file.BatchItemTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12-\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x15.file.BatchItemStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x15\n" +
+	"\x06new_id\x18\x06 \x01(\x03R\x05newId\"\xe1\x01\n" +
+	"\x15BatchOperationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12%\n" +
+	"\x05items\x18\x02 \x03(\v2\x0f.file.BatchItemR\x05items\x12#\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x0f.file.BatchModeR\x04mode\x12 \n" +
+	"\fto_folder_id\x18\x04 \x01(\x03R\n" +
+	"toFolderId\x12A\n" +
+	"\x0fconflict_policy\x18\x05 \x01(\x0e2\x18.file.NameConflictPolicyR\x0econflictPolicy\"\x7f\n" +
+	"\x16BatchOperationResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.file.BatchItemResultR\aresults\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"Z\n" +
+	"\x12ResolvePathRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x17\n" +
+	"\aroot_id\x18\x03 \x01(\x03R\x06rootId\"[\n" +
+	"\x13ResolvePathResponse\x12\x1e\n" +
+	"\x04file\x18\x01 \x01(\v2\n" +
+	".file.FileR\x04file\x12$\n" +
+	"\x06folder\x18\x02 \x01(\v2\f.file.FolderR\x06folder\"W\n" +
+	"\x0fListPathRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x17\n" +
+	"\aroot_id\x18\x03 \x01(\x03R\x06rootId\"Y\n" +
+	"\x11DeletePathRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x17\n" +
+	"\aroot_id\x18\x03 \x01(\x03R\x06rootId\"\x14\n" +
+	"\x12DeletePathResponse\"F\n" +
+	"\x17EnsureFolderPathRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"Z\n" +
+	"\x18EnsureFolderPathResponse\x12$\n" +
+	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\"^\n" +
+	"\x14GetFolderTreeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\aroot_id\x18\x02 \x01(\x03R\x06rootId\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\x05R\x05depth\"[\n" +
+	"\x15GetFolderTreeResponse\x12$\n" +
+	"\x04root\x18\x01 \x01(\v2\x10.file.FolderNodeR\x04root\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\"f\n" +
+	"\x12AbortUploadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tupload_id\x18\x02 \x01(\tR\buploadId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\"\x15\n" +
+	"\x13AbortUploadResponse\"N\n" +
+	"\x16ListUploadPartsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tupload_id\x18\x02 \x01(\tR\buploadId\"<\n" +
+	"\x17ListUploadPartsResponse\x12!\n" +
+	"\fpart_numbers\x18\x01 \x03(\x05R\vpartNumbers\"B\n" +
+	"\x15ReconcileQuotaRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x10\n" +
+	"\x03fix\x18\x02 \x01(\bR\x03fix\"\xcb\x02\n" +
+	"\n" +
+	"QuotaUsage\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\bcapacity\x18\x02 \x01(\x03R\bcapacity\x12#\n" +
+	"\rrecorded_size\x18\x03 \x01(\x03R\frecordedSize\x12\x1f\n" +
+	"\vactual_size\x18\x04 \x01(\x03R\n" +
+	"actualSize\x12+\n" +
+	"\x11recorded_reserved\x18\x05 \x01(\x03R\x10recordedReserved\x12'\n" +
+	"\x0factual_reserved\x18\x06 \x01(\x03R\x0eactualReserved\x121\n" +
+	"\x14expired_reservations\x18\a \x01(\x05R\x13expiredReservations\x12#\n" +
+	"\rfolders_fixed\x18\b \x01(\x05R\ffoldersFixed\x12\x14\n" +
+	"\x05fixed\x18\t \x01(\bR\x05fixed\"W\n" +
+	"\x16ReconcileQuotaResponse\x12&\n" +
+	"\x05usage\x18\x01 \x01(\v2\x10.file.QuotaUsageR\x05usage\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"8\n" +
+	"\x0fSavePlanRequest\x12%\n" +
+	"\x04plan\x18\x01 \x01(\v2\x11.file.StoragePlanR\x04plan\"9\n" +
+	"\x10SavePlanResponse\x12%\n" +
+	"\x04plan\x18\x01 \x01(\v2\x11.file.StoragePlanR\x04plan\"\x12\n" +
+	"\x10ListPlansRequest\"<\n" +
+	"\x11ListPlansResponse\x12'\n" +
+	"\x05plans\x18\x01 \x03(\v2\x11.file.StoragePlanR\x05plans\"E\n" +
+	"\x11AssignPlanRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\x03R\x06planId\"D\n" +
+	"\x12AssignPlanResponse\x12.\n" +
+	"\n" +
+	"file_store\x18\x01 \x01(\v2\x0f.file.FileStoreR\tfileStore\"x\n" +
+	"\x14GrantCapacityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x1b\n" +
+	"\texpire_at\x18\x03 \x01(\x03R\bexpireAt\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"b\n" +
+	"\x15GrantCapacityResponse\x12\x19\n" +
+	"\bgrant_id\x18\x01 \x01(\x03R\agrantId\x12.\n" +
+	"\n" +
+	"file_store\x18\x02 \x01(\v2\x0f.file.FileStoreR\tfileStore\"]\n" +
+	"\x19ListUsersNearQuotaRequest\x12\x18\n" +
+	"\apercent\x18\x01 \x01(\x05R\apercent\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"d\n" +
+	"\x1aListUsersNearQuotaResponse\x120\n" +
+	"\vfile_stores\x18\x01 \x03(\v2\x0f.file.FileStoreR\n" +
+	"fileStores\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"`\n" +
+	"\fCollaborator\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12!\n" +
+	"\x04role\x18\x02 \x01(\x0e2\r.file.AclRoleR\x04role\x12\x14\n" +
+	"\x05ctime\x18\x03 \x01(\x03R\x05ctime\"\xb6\x01\n" +
+	"\x14ShareWithUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tis_folder\x18\x02 \x01(\bR\bisFolder\x12\x1f\n" +
+	"\vresource_id\x18\x03 \x01(\x03R\n" +
+	"resourceId\x12$\n" +
+	"\x0etarget_user_id\x18\x04 \x01(\x05R\ftargetUserId\x12!\n" +
+	"\x04role\x18\x05 \x01(\x0e2\r.file.AclRoleR\x04role\"O\n" +
+	"\x15ShareWithUserResponse\x126\n" +
+	"\fcollaborator\x18\x01 \x01(\v2\x12.file.CollaboratorR\fcollaborator\"\x95\x01\n" +
+	"\x16RevokeUserShareRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tis_folder\x18\x02 \x01(\bR\bisFolder\x12\x1f\n" +
+	"\vresource_id\x18\x03 \x01(\x03R\n" +
+	"resourceId\x12$\n" +
+	"\x0etarget_user_id\x18\x04 \x01(\x05R\ftargetUserId\"\x19\n" +
+	"\x17RevokeUserShareResponse\"q\n" +
+	"\x18ListCollaboratorsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tis_folder\x18\x02 \x01(\bR\bisFolder\x12\x1f\n" +
+	"\vresource_id\x18\x03 \x01(\x03R\n" +
+	"resourceId\"\x98\x01\n" +
+	"\x19ListCollaboratorsResponse\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x05R\aownerId\x128\n" +
+	"\rcollaborators\x18\x02 \x03(\v2\x12.file.CollaboratorR\rcollaborators\x12&\n" +
+	"\amy_role\x18\x03 \x01(\x0e2\r.file.AclRoleR\x06myRole\"\xa6\x01\n" +
+	"\n" +
+	"SharedItem\x12$\n" +
+	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\x12\x1e\n" +
+	"\x04file\x18\x02 \x01(\v2\n" +
+	".file.FileR\x04file\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\x05R\aownerId\x12!\n" +
+	"\x04role\x18\x04 \x01(\x0e2\r.file.AclRoleR\x04role\x12\x14\n" +
+	"\x05ctime\x18\x05 \x01(\x03R\x05ctime\"Z\n" +
+	"\x17ListSharedWithMeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"X\n" +
+	"\x18ListSharedWithMeResponse\x12&\n" +
+	"\x05items\x18\x01 \x03(\v2\x10.file.SharedItemR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xa4\x01\n" +
+	"\tTeamSpace\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\x05R\x06teamId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12$\n" +
+	"\x0eroot_folder_id\x18\x03 \x01(\x03R\frootFolderId\x12.\n" +
+	"\n" +
+	"file_store\x18\x04 \x01(\v2\x0f.file.FileStoreR\tfileStore\x12\x14\n" +
+	"\x05ctime\x18\x05 \x01(\x03R\x05ctime\"\xb0\x01\n" +
+	"\rSpaceActivity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1b\n" +
+	"\titem_type\x18\x04 \x01(\tR\bitemType\x12\x17\n" +
+	"\aitem_id\x18\x05 \x01(\x03R\x06itemId\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x14\n" +
+	"\x05ctime\x18\a \x01(\x03R\x05ctime\"`\n" +
+	"\x16CreateTeamSpaceRequest\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\x05R\x06teamId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\x05R\aownerId\"@\n" +
+	"\x17CreateTeamSpaceResponse\x12%\n" +
+	"\x05space\x18\x01 \x01(\v2\x0f.file.TeamSpaceR\x05space\"~\n" +
+	"\x15SetSpaceMemberRequest\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\x05R\x06teamId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\x05R\x04role\x12\x1f\n" +
+	"\voperator_id\x18\x04 \x01(\x05R\n" +
+	"operatorId\"\x18\n" +
+	"\x16SetSpaceMemberResponse\"G\n" +
+	"\x13GetTeamSpaceRequest\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\x05R\x06teamId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"Q\n" +
+	"\x14GetTeamSpaceResponse\x12%\n" +
+	"\x05space\x18\x01 \x01(\v2\x0f.file.TeamSpaceR\x05space\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\x05R\x04role\"t\n" +
+	"\x18ListSpaceActivityRequest\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\x05R\x06teamId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\"f\n" +
+	"\x19ListSpaceActivityResponse\x123\n" +
+	"\n" +
+	"activities\x18\x01 \x03(\v2\x13.file.SpaceActivityR\n" +
+	"activities\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xab\x03\n" +
+	"\x0fFileRequestInfo\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1b\n" +
+	"\tfolder_id\x18\x03 \x01(\x03R\bfolderId\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12!\n" +
+	"\fhas_password\x18\x05 \x01(\bR\vhasPassword\x12\x1b\n" +
+	"\texpire_at\x18\x06 \x01(\x03R\bexpireAt\x12\"\n" +
+	"\rmax_file_size\x18\a \x01(\x03R\vmaxFileSize\x12\x1b\n" +
+	"\tmax_files\x18\b \x01(\x03R\bmaxFiles\x12$\n" +
+	"\x0emax_total_size\x18\t \x01(\x03R\fmaxTotalSize\x12!\n" +
+	"\fallowed_exts\x18\n" +
+	" \x03(\tR\vallowedExts\x12\x16\n" +
+	"\x06status\x18\v \x01(\x05R\x06status\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\f \x01(\x03R\tfileCount\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\r \x01(\x03R\ttotalSize\x12\x14\n" +
+	"\x05ctime\x18\x0e \x01(\x03R\x05ctime\"\xf3\x01\n" +
+	"\x11PublicFileRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12!\n" +
+	"\fhas_password\x18\x02 \x01(\bR\vhasPassword\x12\x1b\n" +
+	"\texpire_at\x18\x03 \x01(\x03R\bexpireAt\x12\"\n" +
+	"\rmax_file_size\x18\x04 \x01(\x03R\vmaxFileSize\x12\x1b\n" +
+	"\tmax_files\x18\x05 \x01(\x03R\bmaxFiles\x12$\n" +
+	"\x0emax_total_size\x18\x06 \x01(\x03R\fmaxTotalSize\x12!\n" +
+	"\fallowed_exts\x18\a \x03(\tR\vallowedExts\"\xa6\x01\n" +
+	"\x11FileRequestUpload\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\buploader\x18\x04 \x01(\tR\buploader\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\x12\x14\n" +
+	"\x05ctime\x18\a \x01(\x03R\x05ctime\"\xa9\x02\n" +
+	"\x18CreateFileRequestRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\x03R\bfolderId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12\x1b\n" +
+	"\texpire_at\x18\x05 \x01(\x03R\bexpireAt\x12\"\n" +
+	"\rmax_file_size\x18\x06 \x01(\x03R\vmaxFileSize\x12\x1b\n" +
+	"\tmax_files\x18\a \x01(\x03R\bmaxFiles\x12$\n" +
+	"\x0emax_total_size\x18\b \x01(\x03R\fmaxTotalSize\x12!\n" +
+	"\fallowed_exts\x18\t \x03(\tR\vallowedExts\"L\n" +
+	"\x19CreateFileRequestResponse\x12/\n" +
+	"\arequest\x18\x01 \x01(\v2\x15.file.FileRequestInfoR\arequest\"Z\n" +
+	"\x17ListFileRequestsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"c\n" +
+	"\x18ListFileRequestsResponse\x121\n" +
+	"\brequests\x18\x01 \x03(\v2\x15.file.FileRequestInfoR\brequests\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"T\n" +
+	"\x18CloseFileRequestsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\vrequest_ids\x18\x02 \x03(\tR\n" +
+	"requestIds\"3\n" +
+	"\x19CloseFileRequestsResponse\x12\x16\n" +
+	"\x06closed\x18\x01 \x01(\x03R\x06closed\"\x7f\n" +
+	"\x1dListFileRequestUploadsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\"i\n" +
+	"\x1eListFileRequestUploadsResponse\x121\n" +
+	"\auploads\x18\x01 \x03(\v2\x17.file.FileRequestUploadR\auploads\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"<\n" +
+	"\x1bGetPublicFileRequestRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\"Q\n" +
+	"\x1cGetPublicFileRequestResponse\x121\n" +
+	"\arequest\x18\x01 \x01(\v2\x17.file.PublicFileRequestR\arequest\"\x81\x02\n" +
+	"\x18SubmitFileRequestRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1a\n" +
+	"\buploader\x18\x03 \x01(\tR\buploader\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04hash\x18\x06 \x01(\tR\x04hash\x12!\n" +
+	"\fcontent_type\x18\a \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\b \x01(\fR\x04data\x12\x1b\n" +
+	"\tclient_ip\x18\t \x01(\tR\bclientIp\"/\n" +
+	"\x19SubmitFileRequestResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x81\x01\n" +
+	"\x12CreateVaultRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x03R\bparentId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12!\n" +
+	"\fkey_envelope\x18\x04 \x01(\fR\vkeyEnvelope\";\n" +
+	"\x13CreateVaultResponse\x12$\n" +
+	"\x06folder\x18\x01 \x01(\v2\f.file.FolderR\x06folder\"k\n" +
+	"\x13SetPublicKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\talgorithm\x18\x03 \x01(\tR\talgorithm\"\x16\n" +
+	"\x14SetPublicKeyResponse\"{\n" +
+	"\rUserPublicKey\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\talgorithm\x18\x03 \x01(\tR\talgorithm\x12\x14\n" +
+	"\x05utime\x18\x04 \x01(\x03R\x05utime\"J\n" +
+	"\x14GetPublicKeysRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\x05R\auserIds\"@\n" +
+	"\x15GetPublicKeysResponse\x12'\n" +
+	"\x04keys\x18\x01 \x03(\v2\x13.file.UserPublicKeyR\x04keys\"\x8f\x01\n" +
+	"\x1aPutVaultKeyEnvelopeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bvault_id\x18\x02 \x01(\x03R\avaultId\x12!\n" +
+	"\frecipient_id\x18\x03 \x01(\x05R\vrecipientId\x12\x1a\n" +
+	"\benvelope\x18\x04 \x01(\fR\benvelope\"\x1d\n" +
+	"\x1bPutVaultKeyEnvelopeResponse\"P\n" +
+	"\x1aGetVaultKeyEnvelopeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bvault_id\x18\x02 \x01(\x03R\avaultId\"n\n" +
+	"\x1bGetVaultKeyEnvelopeResponse\x12\x1a\n" +
+	"\benvelope\x18\x01 \x01(\fR\benvelope\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x02 \x01(\x05R\tcreatedBy\x12\x14\n" +
+	"\x05utime\x18\x03 \x01(\x03R\x05utime\"v\n" +
+	"\x1dRevokeVaultKeyEnvelopeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bvault_id\x18\x02 \x01(\x03R\avaultId\x12!\n" +
+	"\frecipient_id\x18\x03 \x01(\x05R\vrecipientId\" \n" +
+	"\x1eRevokeVaultKeyEnvelopeResponse\"\x85\x02\n" +
+	"\fScrubFinding\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x01 \x01(\tR\tobjectKey\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12,\n" +
+	"\aproblem\x18\x04 \x01(\x0e2\x12.file.ScrubProblemR\aproblem\x12\x1a\n" +
+	"\bexpected\x18\x05 \x01(\tR\bexpected\x12\x16\n" +
+	"\x06actual\x18\x06 \x01(\tR\x06actual\x12\x16\n" +
+	"\x06detail\x18\a \x01(\tR\x06detail\x12\x14\n" +
+	"\x05ctime\x18\b \x01(\x03R\x05ctime\x12\x14\n" +
+	"\x05utime\x18\t \x01(\x03R\x05utime\".\n" +
+	"\x13ScrubStorageRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\"s\n" +
+	"\x14ScrubStorageResponse\x12,\n" +
+	"\afinding\x18\x01 \x01(\v2\x12.file.ScrubFindingR\afinding\x12\x16\n" +
+	"\x06sha256\x18\x02 \x01(\tR\x06sha256\x12\x15\n" +
+	"\x06job_id\x18\x03 \x01(\tR\x05jobId\"[\n" +
+	"\x18ListScrubFindingsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"a\n" +
+	"\x19ListScrubFindingsResponse\x12.\n" +
+	"\bfindings\x18\x01 \x03(\v2\x12.file.ScrubFindingR\bfindings\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xdf\x02\n" +
+	"\x10ProcessingResult\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x1c\n" +
+	"\tprocessor\x18\x02 \x01(\tR\tprocessor\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12.\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x16.file.ProcessingStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12=\n" +
+	"\aoutputs\x18\a \x03(\v2#.file.ProcessingResult.OutputsEntryR\aoutputs\x12\x14\n" +
+	"\x05utime\x18\b \x01(\x03R\x05utime\x1a:\n" +
+	"\fOutputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"O\n" +
+	"\x14ReprocessFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x1e\n" +
+	"\n" +
+	"processors\x18\x02 \x03(\tR\n" +
+	"processors\"I\n" +
+	"\x15ReprocessFileResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.file.ProcessingResultR\aresults\"^\n" +
+	"\x16ListDeadLettersRequest\x12\x1c\n" +
+	"\tprocessor\x18\x01 \x01(\tR\tprocessor\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"a\n" +
+	"\x17ListDeadLettersResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.file.ProcessingResultR\aresults\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"7\n" +
+	"\x17RetryDeadLettersRequest\x12\x1c\n" +
+	"\tprocessor\x18\x01 \x01(\tR\tprocessor\"0\n" +
+	"\x18RetryDeadLettersResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"\xe2\x01\n" +
+	"\x0fQuarantinedFile\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x18\n" +
+	"\ascanner\x18\x05 \x01(\tR\ascanner\x12\x1c\n" +
+	"\tsignature\x18\x06 \x01(\tR\tsignature\x12'\n" +
+	"\x0fdisabled_shares\x18\a \x01(\x03R\x0edisabledShares\x12\x14\n" +
+	"\x05ctime\x18\b \x01(\x03R\x05ctime\"^\n" +
+	"\x1bListQuarantinedFilesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"a\n" +
+	"\x1cListQuarantinedFilesResponse\x12+\n" +
+	"\x05files\x18\x01 \x03(\v2\x15.file.QuarantinedFileR\x05files\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"8\n" +
+	"\x1dReleaseQuarantinedFileRequest\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\"4\n" +
+	"\x1eReleaseQuarantinedFileResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xf4\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12\x1b\n" +
+	"\tfolder_id\x18\x04 \x01(\x03R\bfolderId\x12\x18\n" +
+	"\aenabled\x18\x05 \x01(\bR\aenabled\x12\x1a\n" +
+	"\bfailures\x18\x06 \x01(\x05R\bfailures\x12'\n" +
+	"\x0fdisabled_reason\x18\a \x01(\tR\x0edisabledReason\x12\x14\n" +
+	"\x05ctime\x18\b \x01(\x03R\x05ctime\x12\x14\n" +
+	"\x05utime\x18\t \x01(\x03R\x05utime\"\x7f\n" +
+	"\x14CreateWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12\x1b\n" +
+	"\tfolder_id\x18\x04 \x01(\x03R\bfolderId\"X\n" +
+	"\x15CreateWebhookResponse\x12'\n" +
+	"\awebhook\x18\x01 \x01(\v2\r.file.WebhookR\awebhook\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\".\n" +
+	"\x13ListWebhooksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"A\n" +
+	"\x14ListWebhooksResponse\x12)\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\r.file.WebhookR\bwebhooks\"\xb4\x01\n" +
+	"\x14UpdateWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x04 \x03(\tR\n" +
+	"eventTypes\x12\x1b\n" +
+	"\tfolder_id\x18\x05 \x01(\x03R\bfolderId\x12#\n" +
+	"\rrotate_secret\x18\x06 \x01(\bR\frotateSecret\"X\n" +
+	"\x15UpdateWebhookResponse\x12'\n" +
+	"\awebhook\x18\x01 \x01(\v2\r.file.WebhookR\awebhook\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"?\n" +
+	"\x14DeleteWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"\x17\n" +
+	"\x15DeleteWebhookResponse\"\xba\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x03R\twebhookId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x123\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1b.file.WebhookDeliveryStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12#\n" +
+	"\rresponse_code\x18\x06 \x01(\x05R\fresponseCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x12\x18\n" +
+	"\apayload\x18\b \x01(\tR\apayload\x12\x14\n" +
+	"\x05ctime\x18\t \x01(\x03R\x05ctime\x12\x14\n" +
+	"\x05utime\x18\n" +
+	" \x01(\x03R\x05utime\"~\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x03R\twebhookId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\"l\n" +
+	"\x1dListWebhookDeliveriesResponse\x125\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x15.file.WebhookDeliveryR\n" +
+	"deliveries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"S\n" +
+	"\x17RedeliverWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\vdelivery_id\x18\x02 \x01(\x03R\n" +
+	"deliveryId\"M\n" +
+	"\x18RedeliverWebhookResponse\x121\n" +
+	"\bdelivery\x18\x01 \x01(\v2\x15.file.WebhookDeliveryR\bdelivery\"1\n" +
+	"\vAuditTarget\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x81\x03\n" +
+	"\bAuditLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\x19\n" +
+	"\bactor_id\x18\x05 \x01(\x05R\aactorId\x12\x19\n" +
+	"\bowner_id\x18\x06 \x01(\x05R\aownerId\x12\x1b\n" +
+	"\tdevice_id\x18\a \x01(\tR\bdeviceId\x12\x0e\n" +
+	"\x02ip\x18\b \x01(\tR\x02ip\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\t \x01(\tR\tuserAgent\x12+\n" +
+	"\atargets\x18\n" +
+	" \x03(\v2\x11.file.AuditTargetR\atargets\x12\x16\n" +
+	"\x06before\x18\v \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\f \x01(\tR\x05after\x12\x12\n" +
+	"\x04code\x18\r \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\x0e \x01(\tR\x05error\x12\x14\n" +
+	"\x05ctime\x18\x0f \x01(\x03R\x05ctime\"\xbb\x01\n" +
+	"\x0eAuditLogFilter\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\x05R\aactorId\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x03 \x01(\x03R\aendTime\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1f\n" +
+	"\vtarget_type\x18\x05 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x06 \x01(\tR\btargetId\"\x85\x01\n" +
+	"\x14ListAuditLogsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12,\n" +
+	"\x06filter\x18\x02 \x01(\v2\x14.file.AuditLogFilterR\x06filter\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\"Q\n" +
+	"\x15ListAuditLogsResponse\x12\"\n" +
+	"\x04logs\x18\x01 \x03(\v2\x0e.file.AuditLogR\x04logs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\x90\x01\n" +
+	"\x16ExportAuditLogsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12,\n" +
+	"\x06filter\x18\x02 \x01(\v2\x14.file.AuditLogFilterR\x06filter\x12/\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x17.file.AuditExportFormatR\x06format\"-\n" +
+	"\x17ExportAuditLogsResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"m\n" +
+	"\x15QueryAuditLogsRequest\x12,\n" +
+	"\x06filter\x18\x01 \x01(\v2\x14.file.AuditLogFilterR\x06filter\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"u\n" +
+	"\x14DumpAuditLogsRequest\x12,\n" +
+	"\x06filter\x18\x01 \x01(\v2\x14.file.AuditLogFilterR\x06filter\x12/\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x17.file.AuditExportFormatR\x06format*F\n" +
 	"\vPreviewType\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\t\n" +
 	"\x05IMAGE\x10\x01\x12\a\n" +
@@ -3228,7 +14120,56 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\n" +
 	"\x06DELETE\x10\x01\x12\n" +
 	"\n" +
-	"\x06UPDATE\x10\x022\xa7\v\n" +
+	"\x06UPDATE\x10\x02*{\n" +
+	"\x12NameConflictPolicy\x12\x16\n" +
+	"\x12NAME_CONFLICT_FAIL\x10\x00\x12\x18\n" +
+	"\x14NAME_CONFLICT_RENAME\x10\x01\x12\x16\n" +
+	"\x12NAME_CONFLICT_SKIP\x10\x02\x12\x1b\n" +
+	"\x17NAME_CONFLICT_OVERWRITE\x10\x03*4\n" +
+	"\tBatchMode\x12\x15\n" +
+	"\x11BATCH_BEST_EFFORT\x10\x00\x12\x10\n" +
+	"\fBATCH_ATOMIC\x10\x01*;\n" +
+	"\rBatchItemType\x12\x13\n" +
+	"\x0fBATCH_ITEM_FILE\x10\x00\x12\x15\n" +
+	"\x11BATCH_ITEM_FOLDER\x10\x01*\xe9\x01\n" +
+	"\x0fBatchItemStatus\x12\x11\n" +
+	"\rBATCH_ITEM_OK\x10\x00\x12\x18\n" +
+	"\x14BATCH_ITEM_NOT_FOUND\x10\x01\x12\x17\n" +
+	"\x13BATCH_ITEM_CONFLICT\x10\x02\x12\x16\n" +
+	"\x12BATCH_ITEM_INVALID\x10\x03\x12\x17\n" +
+	"\x13BATCH_ITEM_NO_SPACE\x10\x04\x12\x15\n" +
+	"\x11BATCH_ITEM_FAILED\x10\x05\x12\x16\n" +
+	"\x12BATCH_ITEM_SKIPPED\x10\x06\x12\x16\n" +
+	"\x12BATCH_ITEM_ABORTED\x10\a\x12\x18\n" +
+	"\x14BATCH_ITEM_FORBIDDEN\x10\b*r\n" +
+	"\aAclRole\x12\x11\n" +
+	"\rACL_ROLE_NONE\x10\x00\x12\x13\n" +
+	"\x0fACL_ROLE_VIEWER\x10\x01\x12\x16\n" +
+	"\x12ACL_ROLE_COMMENTER\x10\x02\x12\x13\n" +
+	"\x0fACL_ROLE_EDITOR\x10\x03\x12\x12\n" +
+	"\x0eACL_ROLE_OWNER\x10\x04*z\n" +
+	"\fScrubProblem\x12\x16\n" +
+	"\x12SCRUB_PROBLEM_NONE\x10\x00\x12\x19\n" +
+	"\x15SCRUB_PROBLEM_CORRUPT\x10\x01\x12\x19\n" +
+	"\x15SCRUB_PROBLEM_MISSING\x10\x02\x12\x1c\n" +
+	"\x18SCRUB_PROBLEM_UNREADABLE\x10\x03*\xc4\x01\n" +
+	"\x10ProcessingStatus\x12\x1d\n" +
+	"\x19PROCESSING_STATUS_UNKNOWN\x10\x00\x12\x1d\n" +
+	"\x19PROCESSING_STATUS_PENDING\x10\x01\x12\x1d\n" +
+	"\x19PROCESSING_STATUS_RUNNING\x10\x02\x12\x1a\n" +
+	"\x16PROCESSING_STATUS_DONE\x10\x03\x12\x1b\n" +
+	"\x17PROCESSING_STATUS_RETRY\x10\x04\x12\x1a\n" +
+	"\x16PROCESSING_STATUS_DEAD\x10\x05*\xf4\x01\n" +
+	"\x15WebhookDeliveryStatus\x12#\n" +
+	"\x1fWEBHOOK_DELIVERY_STATUS_UNKNOWN\x10\x00\x12#\n" +
+	"\x1fWEBHOOK_DELIVERY_STATUS_PENDING\x10\x01\x12#\n" +
+	"\x1fWEBHOOK_DELIVERY_STATUS_RUNNING\x10\x02\x12%\n" +
+	"!WEBHOOK_DELIVERY_STATUS_SUCCEEDED\x10\x03\x12!\n" +
+	"\x1dWEBHOOK_DELIVERY_STATUS_RETRY\x10\x04\x12\"\n" +
+	"\x1eWEBHOOK_DELIVERY_STATUS_FAILED\x10\x05*O\n" +
+	"\x11AuditExportFormat\x12\x1b\n" +
+	"\x17AUDIT_EXPORT_FORMAT_CSV\x10\x00\x12\x1d\n" +
+	"\x19AUDIT_EXPORT_FORMAT_JSONL\x10\x012\xf15\n" +
 	"\vFileService\x123\n" +
 	"\x06Upload\x12\x13.file.UploadRequest\x1a\x14.file.UploadResponse\x12N\n" +
 	"\x0fCreateFileStore\x12\x1c.file.CreateFileStoreRequest\x1a\x1d.file.CreateFileStoreResponse\x12E\n" +
@@ -3254,7 +14195,82 @@ const file_idl_cloudstorage_file_proto_rawDesc = "" +
 	"\rSaveToMyDrive\x12\x1a.file.SaveToMyDriveRequest\x1a\x1b.file.SaveToMyDriveResponse\x12Q\n" +
 	"\x10GetUserFileStore\x12\x1d.file.GetUserFileStoreRequest\x1a\x1e.file.GetUserFileStoreResponse\x12?\n" +
 	"\n" +
-	"UpdateFile\x12\x17.file.UpdateFileRequest\x1a\x18.file.UpdateFileResponseB\aZ\x05/fileb\x06proto3"
+	"UpdateFile\x12\x17.file.UpdateFileRequest\x1a\x18.file.UpdateFileResponse\x12B\n" +
+	"\vGetFileMeta\x12\x18.file.GetFileMetaRequest\x1a\x19.file.GetFileMetaResponse\x12B\n" +
+	"\vSetFileMeta\x12\x18.file.SetFileMetaRequest\x1a\x19.file.SetFileMetaResponse\x12K\n" +
+	"\x0eDeleteFileMeta\x12\x1b.file.DeleteFileMetaRequest\x1a\x1c.file.DeleteFileMetaResponse\x129\n" +
+	"\bCopyFile\x12\x15.file.CopyFileRequest\x1a\x16.file.CopyFileResponse\x12?\n" +
+	"\n" +
+	"CopyFolder\x12\x17.file.CopyFolderRequest\x1a\x18.file.CopyFolderResponse\x123\n" +
+	"\x06GetJob\x12\x13.file.GetJobRequest\x1a\x14.file.GetJobResponse\x12F\n" +
+	"\tBatchMove\x12\x1b.file.BatchOperationRequest\x1a\x1c.file.BatchOperationResponse\x12F\n" +
+	"\tBatchCopy\x12\x1b.file.BatchOperationRequest\x1a\x1c.file.BatchOperationResponse\x12H\n" +
+	"\vBatchDelete\x12\x1b.file.BatchOperationRequest\x1a\x1c.file.BatchOperationResponse\x12I\n" +
+	"\fBatchRestore\x12\x1b.file.BatchOperationRequest\x1a\x1c.file.BatchOperationResponse\x12H\n" +
+	"\vBatchRename\x12\x1b.file.BatchOperationRequest\x1a\x1c.file.BatchOperationResponse\x12B\n" +
+	"\vResolvePath\x12\x18.file.ResolvePathRequest\x1a\x19.file.ResolvePathResponse\x12;\n" +
+	"\bListPath\x12\x15.file.ListPathRequest\x1a\x18.file.ListFolderResponse\x12?\n" +
+	"\n" +
+	"DeletePath\x12\x17.file.DeletePathRequest\x1a\x18.file.DeletePathResponse\x12Q\n" +
+	"\x10EnsureFolderPath\x12\x1d.file.EnsureFolderPathRequest\x1a\x1e.file.EnsureFolderPathResponse\x12H\n" +
+	"\rGetFolderTree\x12\x1a.file.GetFolderTreeRequest\x1a\x1b.file.GetFolderTreeResponse\x12B\n" +
+	"\vUploadChunk\x12\x18.file.UploadChunkRequest\x1a\x19.file.UploadChunkResponse\x12N\n" +
+	"\x0fListUploadParts\x12\x1c.file.ListUploadPartsRequest\x1a\x1d.file.ListUploadPartsResponse\x12B\n" +
+	"\vAbortUpload\x12\x18.file.AbortUploadRequest\x1a\x19.file.AbortUploadResponse\x12N\n" +
+	"\x0fListShareFolder\x12\x1c.file.ListShareFolderRequest\x1a\x1d.file.ListShareFolderResponse\x12K\n" +
+	"\x0eListShareFiles\x12\x1b.file.ListShareFilesRequest\x1a\x1c.file.ListShareFilesResponse\x12=\n" +
+	"\fGetShareFile\x12\x16.file.ShareFileRequest\x1a\x15.file.GetFileResponse\x12A\n" +
+	"\x10PreviewShareFile\x12\x16.file.ShareFileRequest\x1a\x15.file.PreviewResponse\x12K\n" +
+	"\x11DownloadShareFile\x12\x16.file.ShareFileRequest\x1a\x1c.file.DownloadStreamResponse0\x01\x12?\n" +
+	"\n" +
+	"ListShares\x12\x17.file.ListSharesRequest\x1a\x18.file.ListSharesResponse\x12E\n" +
+	"\fRevokeShares\x12\x19.file.RevokeSharesRequest\x1a\x1a.file.RevokeSharesResponse\x12B\n" +
+	"\vUpdateShare\x12\x18.file.UpdateShareRequest\x1a\x19.file.UpdateShareResponse\x12T\n" +
+	"\x11GetShareAccessLog\x12\x1e.file.GetShareAccessLogRequest\x1a\x1f.file.GetShareAccessLogResponse\x12H\n" +
+	"\rShareWithUser\x12\x1a.file.ShareWithUserRequest\x1a\x1b.file.ShareWithUserResponse\x12N\n" +
+	"\x0fRevokeUserShare\x12\x1c.file.RevokeUserShareRequest\x1a\x1d.file.RevokeUserShareResponse\x12T\n" +
+	"\x11ListCollaborators\x12\x1e.file.ListCollaboratorsRequest\x1a\x1f.file.ListCollaboratorsResponse\x12Q\n" +
+	"\x10ListSharedWithMe\x12\x1d.file.ListSharedWithMeRequest\x1a\x1e.file.ListSharedWithMeResponse\x12E\n" +
+	"\fGetTeamSpace\x12\x19.file.GetTeamSpaceRequest\x1a\x1a.file.GetTeamSpaceResponse\x12T\n" +
+	"\x11ListSpaceActivity\x12\x1e.file.ListSpaceActivityRequest\x1a\x1f.file.ListSpaceActivityResponse\x12T\n" +
+	"\x11CreateFileRequest\x12\x1e.file.CreateFileRequestRequest\x1a\x1f.file.CreateFileRequestResponse\x12Q\n" +
+	"\x10ListFileRequests\x12\x1d.file.ListFileRequestsRequest\x1a\x1e.file.ListFileRequestsResponse\x12T\n" +
+	"\x11CloseFileRequests\x12\x1e.file.CloseFileRequestsRequest\x1a\x1f.file.CloseFileRequestsResponse\x12c\n" +
+	"\x16ListFileRequestUploads\x12#.file.ListFileRequestUploadsRequest\x1a$.file.ListFileRequestUploadsResponse\x12]\n" +
+	"\x14GetPublicFileRequest\x12!.file.GetPublicFileRequestRequest\x1a\".file.GetPublicFileRequestResponse\x12T\n" +
+	"\x11SubmitFileRequest\x12\x1e.file.SubmitFileRequestRequest\x1a\x1f.file.SubmitFileRequestResponse\x12B\n" +
+	"\vCreateVault\x12\x18.file.CreateVaultRequest\x1a\x19.file.CreateVaultResponse\x12E\n" +
+	"\fSetPublicKey\x12\x19.file.SetPublicKeyRequest\x1a\x1a.file.SetPublicKeyResponse\x12H\n" +
+	"\rGetPublicKeys\x12\x1a.file.GetPublicKeysRequest\x1a\x1b.file.GetPublicKeysResponse\x12Z\n" +
+	"\x13PutVaultKeyEnvelope\x12 .file.PutVaultKeyEnvelopeRequest\x1a!.file.PutVaultKeyEnvelopeResponse\x12Z\n" +
+	"\x13GetVaultKeyEnvelope\x12 .file.GetVaultKeyEnvelopeRequest\x1a!.file.GetVaultKeyEnvelopeResponse\x12c\n" +
+	"\x16RevokeVaultKeyEnvelope\x12#.file.RevokeVaultKeyEnvelopeRequest\x1a$.file.RevokeVaultKeyEnvelopeResponse\x12H\n" +
+	"\rCreateWebhook\x12\x1a.file.CreateWebhookRequest\x1a\x1b.file.CreateWebhookResponse\x12E\n" +
+	"\fListWebhooks\x12\x19.file.ListWebhooksRequest\x1a\x1a.file.ListWebhooksResponse\x12H\n" +
+	"\rUpdateWebhook\x12\x1a.file.UpdateWebhookRequest\x1a\x1b.file.UpdateWebhookResponse\x12H\n" +
+	"\rDeleteWebhook\x12\x1a.file.DeleteWebhookRequest\x1a\x1b.file.DeleteWebhookResponse\x12`\n" +
+	"\x15ListWebhookDeliveries\x12\".file.ListWebhookDeliveriesRequest\x1a#.file.ListWebhookDeliveriesResponse\x12Q\n" +
+	"\x10RedeliverWebhook\x12\x1d.file.RedeliverWebhookRequest\x1a\x1e.file.RedeliverWebhookResponse\x12H\n" +
+	"\rListAuditLogs\x12\x1a.file.ListAuditLogsRequest\x1a\x1b.file.ListAuditLogsResponse\x12P\n" +
+	"\x0fExportAuditLogs\x12\x1c.file.ExportAuditLogsRequest\x1a\x1d.file.ExportAuditLogsResponse0\x01\x12K\n" +
+	"\x0eReconcileQuota\x12\x1b.file.ReconcileQuotaRequest\x1a\x1c.file.ReconcileQuotaResponse\x129\n" +
+	"\bSavePlan\x12\x15.file.SavePlanRequest\x1a\x16.file.SavePlanResponse\x12<\n" +
+	"\tListPlans\x12\x16.file.ListPlansRequest\x1a\x17.file.ListPlansResponse\x12?\n" +
+	"\n" +
+	"AssignPlan\x12\x17.file.AssignPlanRequest\x1a\x18.file.AssignPlanResponse\x12H\n" +
+	"\rGrantCapacity\x12\x1a.file.GrantCapacityRequest\x1a\x1b.file.GrantCapacityResponse\x12W\n" +
+	"\x12ListUsersNearQuota\x12\x1f.file.ListUsersNearQuotaRequest\x1a .file.ListUsersNearQuotaResponse\x12N\n" +
+	"\x0fCreateTeamSpace\x12\x1c.file.CreateTeamSpaceRequest\x1a\x1d.file.CreateTeamSpaceResponse\x12K\n" +
+	"\x0eSetSpaceMember\x12\x1b.file.SetSpaceMemberRequest\x1a\x1c.file.SetSpaceMemberResponse\x12E\n" +
+	"\fScrubStorage\x12\x19.file.ScrubStorageRequest\x1a\x1a.file.ScrubStorageResponse\x12T\n" +
+	"\x11ListScrubFindings\x12\x1e.file.ListScrubFindingsRequest\x1a\x1f.file.ListScrubFindingsResponse\x12H\n" +
+	"\rReprocessFile\x12\x1a.file.ReprocessFileRequest\x1a\x1b.file.ReprocessFileResponse\x12N\n" +
+	"\x0fListDeadLetters\x12\x1c.file.ListDeadLettersRequest\x1a\x1d.file.ListDeadLettersResponse\x12Q\n" +
+	"\x10RetryDeadLetters\x12\x1d.file.RetryDeadLettersRequest\x1a\x1e.file.RetryDeadLettersResponse\x12]\n" +
+	"\x14ListQuarantinedFiles\x12!.file.ListQuarantinedFilesRequest\x1a\".file.ListQuarantinedFilesResponse\x12c\n" +
+	"\x16ReleaseQuarantinedFile\x12#.file.ReleaseQuarantinedFileRequest\x1a$.file.ReleaseQuarantinedFileResponse\x12J\n" +
+	"\x0eQueryAuditLogs\x12\x1b.file.QueryAuditLogsRequest\x1a\x1b.file.ListAuditLogsResponse\x12L\n" +
+	"\rDumpAuditLogs\x12\x1a.file.DumpAuditLogsRequest\x1a\x1d.file.ExportAuditLogsResponse0\x01B\aZ\x05/fileb\x06proto3"
 
 var (
 	file_idl_cloudstorage_file_proto_rawDescOnce sync.Once
@@ -3268,125 +14284,527 @@ func file_idl_cloudstorage_file_proto_rawDescGZIP() []byte {
 	return file_idl_cloudstorage_file_proto_rawDescData
 }
 
-var file_idl_cloudstorage_file_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_idl_cloudstorage_file_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_idl_cloudstorage_file_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_idl_cloudstorage_file_proto_msgTypes = make([]protoimpl.MessageInfo, 205)
 var file_idl_cloudstorage_file_proto_goTypes = []any{
-	(PreviewType)(0),                 // 0: file.PreviewType
-	(ChangeOperation)(0),             // 1: file.ChangeOperation
-	(*FileMetaData)(nil),             // 2: file.FileMetaData
-	(*File)(nil),                     // 3: file.File
-	(*Folder)(nil),                   // 4: file.Folder
-	(*FileStore)(nil),                // 5: file.FileStore
-	(*UploadRequest)(nil),            // 6: file.UploadRequest
-	(*UploadResponse)(nil),           // 7: file.UploadResponse
-	(*CreateFileStoreRequest)(nil),   // 8: file.CreateFileStoreRequest
-	(*CreateFileStoreResponse)(nil),  // 9: file.CreateFileStoreResponse
-	(*CreateFolderRequest)(nil),      // 10: file.CreateFolderRequest
-	(*CreateFolderResponse)(nil),     // 11: file.CreateFolderResponse
-	(*ListFolderRequest)(nil),        // 12: file.ListFolderRequest
-	(*ListFolderResponse)(nil),       // 13: file.ListFolderResponse
-	(*GetFileRequest)(nil),           // 14: file.GetFileRequest
-	(*GetFileResponse)(nil),          // 15: file.GetFileResponse
-	(*DownloadRequest)(nil),          // 16: file.DownloadRequest
-	(*DownloadResponse)(nil),         // 17: file.DownloadResponse
-	(*DownloadStreamResponse)(nil),   // 18: file.DownloadStreamResponse
-	(*MoveFolderRequest)(nil),        // 19: file.MoveFolderRequest
-	(*MoveFolderResponse)(nil),       // 20: file.MoveFolderResponse
-	(*MoveFileRequest)(nil),          // 21: file.MoveFileRequest
-	(*MoveFileResponse)(nil),         // 22: file.MoveFileResponse
-	(*DeleteFileRequest)(nil),        // 23: file.DeleteFileRequest
-	(*DeleteFileResponse)(nil),       // 24: file.DeleteFileResponse
-	(*DeleteFolderRequest)(nil),      // 25: file.DeleteFolderRequest
-	(*DeleteFolderResponse)(nil),     // 26: file.DeleteFolderResponse
-	(*SearchRequest)(nil),            // 27: file.SearchRequest
-	(*SearchResponse)(nil),           // 28: file.SearchResponse
-	(*PreviewRequest)(nil),           // 29: file.PreviewRequest
-	(*PreviewResponse)(nil),          // 30: file.PreviewResponse
-	(*PartInfo)(nil),                 // 31: file.PartInfo
-	(*DownloadTaskRequest)(nil),      // 32: file.DownloadTaskRequest
-	(*FileDownloadInfo)(nil),         // 33: file.FileDownloadInfo
-	(*DownloadTaskResponse)(nil),     // 34: file.DownloadTaskResponse
-	(*GetDownloadTaskRequest)(nil),   // 35: file.GetDownloadTaskRequest
-	(*GetDownloadTaskResponse)(nil),  // 36: file.GetDownloadTaskResponse
-	(*FileProgress)(nil),             // 37: file.FileProgress
-	(*ResumeDownloadRequest)(nil),    // 38: file.ResumeDownloadRequest
-	(*ResumeDownloadResponse)(nil),   // 39: file.ResumeDownloadResponse
-	(*UploadChunkRequest)(nil),       // 40: file.UploadChunkRequest
-	(*UploadChunkResponse)(nil),      // 41: file.UploadChunkResponse
-	(*CreateShareLinkRequest)(nil),   // 42: file.CreateShareLinkRequest
-	(*CreateShareLinkResponse)(nil),  // 43: file.CreateShareLinkResponse
-	(*SaveToMyDriveRequest)(nil),     // 44: file.SaveToMyDriveRequest
-	(*SaveToMyDriveResponse)(nil),    // 45: file.SaveToMyDriveResponse
-	(*GetUserFileStoreRequest)(nil),  // 46: file.GetUserFileStoreRequest
-	(*GetUserFileStoreResponse)(nil), // 47: file.GetUserFileStoreResponse
-	(*UpdateFileRequest)(nil),        // 48: file.UpdateFileRequest
-	(*FileChange)(nil),               // 49: file.FileChange
-	(*UpdateFileResponse)(nil),       // 50: file.UpdateFileResponse
+	(PreviewType)(0),                       // 0: file.PreviewType
+	(ChangeOperation)(0),                   // 1: file.ChangeOperation
+	(NameConflictPolicy)(0),                // 2: file.NameConflictPolicy
+	(BatchMode)(0),                         // 3: file.BatchMode
+	(BatchItemType)(0),                     // 4: file.BatchItemType
+	(BatchItemStatus)(0),                   // 5: file.BatchItemStatus
+	(AclRole)(0),                           // 6: file.AclRole
+	(ScrubProblem)(0),                      // 7: file.ScrubProblem
+	(ProcessingStatus)(0),                  // 8: file.ProcessingStatus
+	(WebhookDeliveryStatus)(0),             // 9: file.WebhookDeliveryStatus
+	(AuditExportFormat)(0),                 // 10: file.AuditExportFormat
+	(*FileMetaData)(nil),                   // 11: file.FileMetaData
+	(*MetaValue)(nil),                      // 12: file.MetaValue
+	(*File)(nil),                           // 13: file.File
+	(*Folder)(nil),                         // 14: file.Folder
+	(*FolderNode)(nil),                     // 15: file.FolderNode
+	(*FileStore)(nil),                      // 16: file.FileStore
+	(*StoragePlan)(nil),                    // 17: file.StoragePlan
+	(*UploadRequest)(nil),                  // 18: file.UploadRequest
+	(*UploadResponse)(nil),                 // 19: file.UploadResponse
+	(*CreateFileStoreRequest)(nil),         // 20: file.CreateFileStoreRequest
+	(*CreateFileStoreResponse)(nil),        // 21: file.CreateFileStoreResponse
+	(*CreateFolderRequest)(nil),            // 22: file.CreateFolderRequest
+	(*CreateFolderResponse)(nil),           // 23: file.CreateFolderResponse
+	(*ListFolderRequest)(nil),              // 24: file.ListFolderRequest
+	(*ListFolderResponse)(nil),             // 25: file.ListFolderResponse
+	(*GetFileRequest)(nil),                 // 26: file.GetFileRequest
+	(*GetFileResponse)(nil),                // 27: file.GetFileResponse
+	(*DownloadRequest)(nil),                // 28: file.DownloadRequest
+	(*DownloadResponse)(nil),               // 29: file.DownloadResponse
+	(*DownloadStreamResponse)(nil),         // 30: file.DownloadStreamResponse
+	(*MoveFolderRequest)(nil),              // 31: file.MoveFolderRequest
+	(*MoveFolderResponse)(nil),             // 32: file.MoveFolderResponse
+	(*MoveFileRequest)(nil),                // 33: file.MoveFileRequest
+	(*MoveFileResponse)(nil),               // 34: file.MoveFileResponse
+	(*DeleteFileRequest)(nil),              // 35: file.DeleteFileRequest
+	(*DeleteFileResponse)(nil),             // 36: file.DeleteFileResponse
+	(*DeleteFolderRequest)(nil),            // 37: file.DeleteFolderRequest
+	(*DeleteFolderResponse)(nil),           // 38: file.DeleteFolderResponse
+	(*SearchRequest)(nil),                  // 39: file.SearchRequest
+	(*SearchResponse)(nil),                 // 40: file.SearchResponse
+	(*PreviewRequest)(nil),                 // 41: file.PreviewRequest
+	(*PreviewResponse)(nil),                // 42: file.PreviewResponse
+	(*PartInfo)(nil),                       // 43: file.PartInfo
+	(*DownloadTaskRequest)(nil),            // 44: file.DownloadTaskRequest
+	(*FileDownloadInfo)(nil),               // 45: file.FileDownloadInfo
+	(*DownloadTaskResponse)(nil),           // 46: file.DownloadTaskResponse
+	(*GetDownloadTaskRequest)(nil),         // 47: file.GetDownloadTaskRequest
+	(*GetDownloadTaskResponse)(nil),        // 48: file.GetDownloadTaskResponse
+	(*FileProgress)(nil),                   // 49: file.FileProgress
+	(*ResumeDownloadRequest)(nil),          // 50: file.ResumeDownloadRequest
+	(*ResumeDownloadResponse)(nil),         // 51: file.ResumeDownloadResponse
+	(*UploadChunkRequest)(nil),             // 52: file.UploadChunkRequest
+	(*UploadChunkResponse)(nil),            // 53: file.UploadChunkResponse
+	(*CreateShareLinkRequest)(nil),         // 54: file.CreateShareLinkRequest
+	(*CreateShareLinkResponse)(nil),        // 55: file.CreateShareLinkResponse
+	(*SaveToMyDriveRequest)(nil),           // 56: file.SaveToMyDriveRequest
+	(*SaveToMyDriveResponse)(nil),          // 57: file.SaveToMyDriveResponse
+	(*ShareInfo)(nil),                      // 58: file.ShareInfo
+	(*ListShareFolderRequest)(nil),         // 59: file.ListShareFolderRequest
+	(*ListShareFolderResponse)(nil),        // 60: file.ListShareFolderResponse
+	(*ListShareFilesRequest)(nil),          // 61: file.ListShareFilesRequest
+	(*ShareEntry)(nil),                     // 62: file.ShareEntry
+	(*ListShareFilesResponse)(nil),         // 63: file.ListShareFilesResponse
+	(*ShareFileRequest)(nil),               // 64: file.ShareFileRequest
+	(*ShareSummary)(nil),                   // 65: file.ShareSummary
+	(*ListSharesRequest)(nil),              // 66: file.ListSharesRequest
+	(*ListSharesResponse)(nil),             // 67: file.ListSharesResponse
+	(*RevokeSharesRequest)(nil),            // 68: file.RevokeSharesRequest
+	(*RevokeSharesResponse)(nil),           // 69: file.RevokeSharesResponse
+	(*UpdateShareRequest)(nil),             // 70: file.UpdateShareRequest
+	(*UpdateShareResponse)(nil),            // 71: file.UpdateShareResponse
+	(*ShareAccessLog)(nil),                 // 72: file.ShareAccessLog
+	(*GetShareAccessLogRequest)(nil),       // 73: file.GetShareAccessLogRequest
+	(*GetShareAccessLogResponse)(nil),      // 74: file.GetShareAccessLogResponse
+	(*GetUserFileStoreRequest)(nil),        // 75: file.GetUserFileStoreRequest
+	(*GetUserFileStoreResponse)(nil),       // 76: file.GetUserFileStoreResponse
+	(*UpdateFileRequest)(nil),              // 77: file.UpdateFileRequest
+	(*FileChange)(nil),                     // 78: file.FileChange
+	(*UpdateFileResponse)(nil),             // 79: file.UpdateFileResponse
+	(*GetFileMetaRequest)(nil),             // 80: file.GetFileMetaRequest
+	(*GetFileMetaResponse)(nil),            // 81: file.GetFileMetaResponse
+	(*SetFileMetaRequest)(nil),             // 82: file.SetFileMetaRequest
+	(*SetFileMetaResponse)(nil),            // 83: file.SetFileMetaResponse
+	(*DeleteFileMetaRequest)(nil),          // 84: file.DeleteFileMetaRequest
+	(*DeleteFileMetaResponse)(nil),         // 85: file.DeleteFileMetaResponse
+	(*CopyFileRequest)(nil),                // 86: file.CopyFileRequest
+	(*CopyFileResponse)(nil),               // 87: file.CopyFileResponse
+	(*CopyFolderRequest)(nil),              // 88: file.CopyFolderRequest
+	(*CopyFolderResponse)(nil),             // 89: file.CopyFolderResponse
+	(*GetJobRequest)(nil),                  // 90: file.GetJobRequest
+	(*GetJobResponse)(nil),                 // 91: file.GetJobResponse
+	(*BatchItem)(nil),                      // 92: file.BatchItem
+	(*BatchItemResult)(nil),                // 93: file.BatchItemResult
+	(*BatchOperationRequest)(nil),          // 94: file.BatchOperationRequest
+	(*BatchOperationResponse)(nil),         // 95: file.BatchOperationResponse
+	(*ResolvePathRequest)(nil),             // 96: file.ResolvePathRequest
+	(*ResolvePathResponse)(nil),            // 97: file.ResolvePathResponse
+	(*ListPathRequest)(nil),                // 98: file.ListPathRequest
+	(*DeletePathRequest)(nil),              // 99: file.DeletePathRequest
+	(*DeletePathResponse)(nil),             // 100: file.DeletePathResponse
+	(*EnsureFolderPathRequest)(nil),        // 101: file.EnsureFolderPathRequest
+	(*EnsureFolderPathResponse)(nil),       // 102: file.EnsureFolderPathResponse
+	(*GetFolderTreeRequest)(nil),           // 103: file.GetFolderTreeRequest
+	(*GetFolderTreeResponse)(nil),          // 104: file.GetFolderTreeResponse
+	(*AbortUploadRequest)(nil),             // 105: file.AbortUploadRequest
+	(*AbortUploadResponse)(nil),            // 106: file.AbortUploadResponse
+	(*ListUploadPartsRequest)(nil),         // 107: file.ListUploadPartsRequest
+	(*ListUploadPartsResponse)(nil),        // 108: file.ListUploadPartsResponse
+	(*ReconcileQuotaRequest)(nil),          // 109: file.ReconcileQuotaRequest
+	(*QuotaUsage)(nil),                     // 110: file.QuotaUsage
+	(*ReconcileQuotaResponse)(nil),         // 111: file.ReconcileQuotaResponse
+	(*SavePlanRequest)(nil),                // 112: file.SavePlanRequest
+	(*SavePlanResponse)(nil),               // 113: file.SavePlanResponse
+	(*ListPlansRequest)(nil),               // 114: file.ListPlansRequest
+	(*ListPlansResponse)(nil),              // 115: file.ListPlansResponse
+	(*AssignPlanRequest)(nil),              // 116: file.AssignPlanRequest
+	(*AssignPlanResponse)(nil),             // 117: file.AssignPlanResponse
+	(*GrantCapacityRequest)(nil),           // 118: file.GrantCapacityRequest
+	(*GrantCapacityResponse)(nil),          // 119: file.GrantCapacityResponse
+	(*ListUsersNearQuotaRequest)(nil),      // 120: file.ListUsersNearQuotaRequest
+	(*ListUsersNearQuotaResponse)(nil),     // 121: file.ListUsersNearQuotaResponse
+	(*Collaborator)(nil),                   // 122: file.Collaborator
+	(*ShareWithUserRequest)(nil),           // 123: file.ShareWithUserRequest
+	(*ShareWithUserResponse)(nil),          // 124: file.ShareWithUserResponse
+	(*RevokeUserShareRequest)(nil),         // 125: file.RevokeUserShareRequest
+	(*RevokeUserShareResponse)(nil),        // 126: file.RevokeUserShareResponse
+	(*ListCollaboratorsRequest)(nil),       // 127: file.ListCollaboratorsRequest
+	(*ListCollaboratorsResponse)(nil),      // 128: file.ListCollaboratorsResponse
+	(*SharedItem)(nil),                     // 129: file.SharedItem
+	(*ListSharedWithMeRequest)(nil),        // 130: file.ListSharedWithMeRequest
+	(*ListSharedWithMeResponse)(nil),       // 131: file.ListSharedWithMeResponse
+	(*TeamSpace)(nil),                      // 132: file.TeamSpace
+	(*SpaceActivity)(nil),                  // 133: file.SpaceActivity
+	(*CreateTeamSpaceRequest)(nil),         // 134: file.CreateTeamSpaceRequest
+	(*CreateTeamSpaceResponse)(nil),        // 135: file.CreateTeamSpaceResponse
+	(*SetSpaceMemberRequest)(nil),          // 136: file.SetSpaceMemberRequest
+	(*SetSpaceMemberResponse)(nil),         // 137: file.SetSpaceMemberResponse
+	(*GetTeamSpaceRequest)(nil),            // 138: file.GetTeamSpaceRequest
+	(*GetTeamSpaceResponse)(nil),           // 139: file.GetTeamSpaceResponse
+	(*ListSpaceActivityRequest)(nil),       // 140: file.ListSpaceActivityRequest
+	(*ListSpaceActivityResponse)(nil),      // 141: file.ListSpaceActivityResponse
+	(*FileRequestInfo)(nil),                // 142: file.FileRequestInfo
+	(*PublicFileRequest)(nil),              // 143: file.PublicFileRequest
+	(*FileRequestUpload)(nil),              // 144: file.FileRequestUpload
+	(*CreateFileRequestRequest)(nil),       // 145: file.CreateFileRequestRequest
+	(*CreateFileRequestResponse)(nil),      // 146: file.CreateFileRequestResponse
+	(*ListFileRequestsRequest)(nil),        // 147: file.ListFileRequestsRequest
+	(*ListFileRequestsResponse)(nil),       // 148: file.ListFileRequestsResponse
+	(*CloseFileRequestsRequest)(nil),       // 149: file.CloseFileRequestsRequest
+	(*CloseFileRequestsResponse)(nil),      // 150: file.CloseFileRequestsResponse
+	(*ListFileRequestUploadsRequest)(nil),  // 151: file.ListFileRequestUploadsRequest
+	(*ListFileRequestUploadsResponse)(nil), // 152: file.ListFileRequestUploadsResponse
+	(*GetPublicFileRequestRequest)(nil),    // 153: file.GetPublicFileRequestRequest
+	(*GetPublicFileRequestResponse)(nil),   // 154: file.GetPublicFileRequestResponse
+	(*SubmitFileRequestRequest)(nil),       // 155: file.SubmitFileRequestRequest
+	(*SubmitFileRequestResponse)(nil),      // 156: file.SubmitFileRequestResponse
+	(*CreateVaultRequest)(nil),             // 157: file.CreateVaultRequest
+	(*CreateVaultResponse)(nil),            // 158: file.CreateVaultResponse
+	(*SetPublicKeyRequest)(nil),            // 159: file.SetPublicKeyRequest
+	(*SetPublicKeyResponse)(nil),           // 160: file.SetPublicKeyResponse
+	(*UserPublicKey)(nil),                  // 161: file.UserPublicKey
+	(*GetPublicKeysRequest)(nil),           // 162: file.GetPublicKeysRequest
+	(*GetPublicKeysResponse)(nil),          // 163: file.GetPublicKeysResponse
+	(*PutVaultKeyEnvelopeRequest)(nil),     // 164: file.PutVaultKeyEnvelopeRequest
+	(*PutVaultKeyEnvelopeResponse)(nil),    // 165: file.PutVaultKeyEnvelopeResponse
+	(*GetVaultKeyEnvelopeRequest)(nil),     // 166: file.GetVaultKeyEnvelopeRequest
+	(*GetVaultKeyEnvelopeResponse)(nil),    // 167: file.GetVaultKeyEnvelopeResponse
+	(*RevokeVaultKeyEnvelopeRequest)(nil),  // 168: file.RevokeVaultKeyEnvelopeRequest
+	(*RevokeVaultKeyEnvelopeResponse)(nil), // 169: file.RevokeVaultKeyEnvelopeResponse
+	(*ScrubFinding)(nil),                   // 170: file.ScrubFinding
+	(*ScrubStorageRequest)(nil),            // 171: file.ScrubStorageRequest
+	(*ScrubStorageResponse)(nil),           // 172: file.ScrubStorageResponse
+	(*ListScrubFindingsRequest)(nil),       // 173: file.ListScrubFindingsRequest
+	(*ListScrubFindingsResponse)(nil),      // 174: file.ListScrubFindingsResponse
+	(*ProcessingResult)(nil),               // 175: file.ProcessingResult
+	(*ReprocessFileRequest)(nil),           // 176: file.ReprocessFileRequest
+	(*ReprocessFileResponse)(nil),          // 177: file.ReprocessFileResponse
+	(*ListDeadLettersRequest)(nil),         // 178: file.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),        // 179: file.ListDeadLettersResponse
+	(*RetryDeadLettersRequest)(nil),        // 180: file.RetryDeadLettersRequest
+	(*RetryDeadLettersResponse)(nil),       // 181: file.RetryDeadLettersResponse
+	(*QuarantinedFile)(nil),                // 182: file.QuarantinedFile
+	(*ListQuarantinedFilesRequest)(nil),    // 183: file.ListQuarantinedFilesRequest
+	(*ListQuarantinedFilesResponse)(nil),   // 184: file.ListQuarantinedFilesResponse
+	(*ReleaseQuarantinedFileRequest)(nil),  // 185: file.ReleaseQuarantinedFileRequest
+	(*ReleaseQuarantinedFileResponse)(nil), // 186: file.ReleaseQuarantinedFileResponse
+	(*Webhook)(nil),                        // 187: file.Webhook
+	(*CreateWebhookRequest)(nil),           // 188: file.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),          // 189: file.CreateWebhookResponse
+	(*ListWebhooksRequest)(nil),            // 190: file.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),           // 191: file.ListWebhooksResponse
+	(*UpdateWebhookRequest)(nil),           // 192: file.UpdateWebhookRequest
+	(*UpdateWebhookResponse)(nil),          // 193: file.UpdateWebhookResponse
+	(*DeleteWebhookRequest)(nil),           // 194: file.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),          // 195: file.DeleteWebhookResponse
+	(*WebhookDelivery)(nil),                // 196: file.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),   // 197: file.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),  // 198: file.ListWebhookDeliveriesResponse
+	(*RedeliverWebhookRequest)(nil),        // 199: file.RedeliverWebhookRequest
+	(*RedeliverWebhookResponse)(nil),       // 200: file.RedeliverWebhookResponse
+	(*AuditTarget)(nil),                    // 201: file.AuditTarget
+	(*AuditLog)(nil),                       // 202: file.AuditLog
+	(*AuditLogFilter)(nil),                 // 203: file.AuditLogFilter
+	(*ListAuditLogsRequest)(nil),           // 204: file.ListAuditLogsRequest
+	(*ListAuditLogsResponse)(nil),          // 205: file.ListAuditLogsResponse
+	(*ExportAuditLogsRequest)(nil),         // 206: file.ExportAuditLogsRequest
+	(*ExportAuditLogsResponse)(nil),        // 207: file.ExportAuditLogsResponse
+	(*QueryAuditLogsRequest)(nil),          // 208: file.QueryAuditLogsRequest
+	(*DumpAuditLogsRequest)(nil),           // 209: file.DumpAuditLogsRequest
+	nil,                                    // 210: file.FileMetaData.MetadataEntry
+	nil,                                    // 211: file.File.MetadataEntry
+	nil,                                    // 212: file.GetFileMetaResponse.MetadataEntry
+	nil,                                    // 213: file.SetFileMetaRequest.MetadataEntry
+	nil,                                    // 214: file.SetFileMetaResponse.MetadataEntry
+	nil,                                    // 215: file.ProcessingResult.OutputsEntry
 }
 var file_idl_cloudstorage_file_proto_depIdxs = []int32{
-	2,  // 0: file.UploadRequest.metadata:type_name -> file.FileMetaData
-	4,  // 1: file.CreateFolderResponse.folder:type_name -> file.Folder
-	4,  // 2: file.ListFolderResponse.folders:type_name -> file.Folder
-	3,  // 3: file.ListFolderResponse.files:type_name -> file.File
-	3,  // 4: file.GetFileResponse.file:type_name -> file.File
-	3,  // 5: file.SearchResponse.files:type_name -> file.File
-	4,  // 6: file.SearchResponse.folders:type_name -> file.Folder
-	0,  // 7: file.PreviewResponse.type:type_name -> file.PreviewType
-	33, // 8: file.DownloadTaskRequest.files:type_name -> file.FileDownloadInfo
-	37, // 9: file.GetDownloadTaskResponse.files:type_name -> file.FileProgress
-	31, // 10: file.UploadChunkRequest.parts:type_name -> file.PartInfo
-	5,  // 11: file.GetUserFileStoreResponse.file_store:type_name -> file.FileStore
-	49, // 12: file.UpdateFileRequest.changes:type_name -> file.FileChange
-	1,  // 13: file.FileChange.operation:type_name -> file.ChangeOperation
-	3,  // 14: file.UpdateFileResponse.file:type_name -> file.File
-	49, // 15: file.UpdateFileResponse.needed_changes:type_name -> file.FileChange
-	6,  // 16: file.FileService.Upload:input_type -> file.UploadRequest
-	8,  // 17: file.FileService.CreateFileStore:input_type -> file.CreateFileStoreRequest
-	10, // 18: file.FileService.CreateFolder:input_type -> file.CreateFolderRequest
-	12, // 19: file.FileService.ListFolder:input_type -> file.ListFolderRequest
-	14, // 20: file.FileService.GetFile:input_type -> file.GetFileRequest
-	16, // 21: file.FileService.Download:input_type -> file.DownloadRequest
-	16, // 22: file.FileService.DownloadStream:input_type -> file.DownloadRequest
-	19, // 23: file.FileService.MoveFolder:input_type -> file.MoveFolderRequest
-	21, // 24: file.FileService.MoveFile:input_type -> file.MoveFileRequest
-	23, // 25: file.FileService.DeleteFile:input_type -> file.DeleteFileRequest
-	25, // 26: file.FileService.DeleteFolder:input_type -> file.DeleteFolderRequest
-	27, // 27: file.FileService.Search:input_type -> file.SearchRequest
-	29, // 28: file.FileService.Preview:input_type -> file.PreviewRequest
-	32, // 29: file.FileService.DownloadTask:input_type -> file.DownloadTaskRequest
-	35, // 30: file.FileService.GetDownloadTask:input_type -> file.GetDownloadTaskRequest
-	38, // 31: file.FileService.ResumeDownload:input_type -> file.ResumeDownloadRequest
-	40, // 32: file.FileService.UploadChunkStream:input_type -> file.UploadChunkRequest
-	42, // 33: file.FileService.CreateShareLink:input_type -> file.CreateShareLinkRequest
-	44, // 34: file.FileService.SaveToMyDrive:input_type -> file.SaveToMyDriveRequest
-	46, // 35: file.FileService.GetUserFileStore:input_type -> file.GetUserFileStoreRequest
-	48, // 36: file.FileService.UpdateFile:input_type -> file.UpdateFileRequest
-	7,  // 37: file.FileService.Upload:output_type -> file.UploadResponse
-	9,  // 38: file.FileService.CreateFileStore:output_type -> file.CreateFileStoreResponse
-	11, // 39: file.FileService.CreateFolder:output_type -> file.CreateFolderResponse
-	13, // 40: file.FileService.ListFolder:output_type -> file.ListFolderResponse
-	15, // 41: file.FileService.GetFile:output_type -> file.GetFileResponse
-	17, // 42: file.FileService.Download:output_type -> file.DownloadResponse
-	18, // 43: file.FileService.DownloadStream:output_type -> file.DownloadStreamResponse
-	20, // 44: file.FileService.MoveFolder:output_type -> file.MoveFolderResponse
-	22, // 45: file.FileService.MoveFile:output_type -> file.MoveFileResponse
-	24, // 46: file.FileService.DeleteFile:output_type -> file.DeleteFileResponse
-	26, // 47: file.FileService.DeleteFolder:output_type -> file.DeleteFolderResponse
-	28, // 48: file.FileService.Search:output_type -> file.SearchResponse
-	30, // 49: file.FileService.Preview:output_type -> file.PreviewResponse
-	34, // 50: file.FileService.DownloadTask:output_type -> file.DownloadTaskResponse
-	36, // 51: file.FileService.GetDownloadTask:output_type -> file.GetDownloadTaskResponse
-	39, // 52: file.FileService.ResumeDownload:output_type -> file.ResumeDownloadResponse
-	41, // 53: file.FileService.UploadChunkStream:output_type -> file.UploadChunkResponse
-	43, // 54: file.FileService.CreateShareLink:output_type -> file.CreateShareLinkResponse
-	45, // 55: file.FileService.SaveToMyDrive:output_type -> file.SaveToMyDriveResponse
-	47, // 56: file.FileService.GetUserFileStore:output_type -> file.GetUserFileStoreResponse
-	50, // 57: file.FileService.UpdateFile:output_type -> file.UpdateFileResponse
-	37, // [37:58] is the sub-list for method output_type
-	16, // [16:37] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	210, // 0: file.FileMetaData.metadata:type_name -> file.FileMetaData.MetadataEntry
+	2,   // 1: file.FileMetaData.conflict_policy:type_name -> file.NameConflictPolicy
+	211, // 2: file.File.metadata:type_name -> file.File.MetadataEntry
+	14,  // 3: file.FolderNode.folder:type_name -> file.Folder
+	15,  // 4: file.FolderNode.children:type_name -> file.FolderNode
+	11,  // 5: file.UploadRequest.metadata:type_name -> file.FileMetaData
+	2,   // 6: file.CreateFolderRequest.conflict_policy:type_name -> file.NameConflictPolicy
+	14,  // 7: file.CreateFolderResponse.folder:type_name -> file.Folder
+	14,  // 8: file.ListFolderResponse.folders:type_name -> file.Folder
+	13,  // 9: file.ListFolderResponse.files:type_name -> file.File
+	13,  // 10: file.GetFileResponse.file:type_name -> file.File
+	175, // 11: file.GetFileResponse.processing:type_name -> file.ProcessingResult
+	2,   // 12: file.MoveFolderRequest.conflict_policy:type_name -> file.NameConflictPolicy
+	14,  // 13: file.MoveFolderResponse.folder:type_name -> file.Folder
+	2,   // 14: file.MoveFileRequest.conflict_policy:type_name -> file.NameConflictPolicy
+	13,  // 15: file.SearchResponse.files:type_name -> file.File
+	14,  // 16: file.SearchResponse.folders:type_name -> file.Folder
+	0,   // 17: file.PreviewResponse.type:type_name -> file.PreviewType
+	45,  // 18: file.DownloadTaskRequest.files:type_name -> file.FileDownloadInfo
+	49,  // 19: file.GetDownloadTaskResponse.files:type_name -> file.FileProgress
+	43,  // 20: file.UploadChunkRequest.parts:type_name -> file.PartInfo
+	2,   // 21: file.UploadChunkRequest.conflict_policy:type_name -> file.NameConflictPolicy
+	2,   // 22: file.SaveToMyDriveRequest.conflict_policy:type_name -> file.NameConflictPolicy
+	13,  // 23: file.SaveToMyDriveResponse.files:type_name -> file.File
+	14,  // 24: file.SaveToMyDriveResponse.folders:type_name -> file.Folder
+	58,  // 25: file.ListShareFolderResponse.share:type_name -> file.ShareInfo
+	13,  // 26: file.ListShareFolderResponse.files:type_name -> file.File
+	14,  // 27: file.ListShareFolderResponse.folders:type_name -> file.Folder
+	13,  // 28: file.ShareEntry.file:type_name -> file.File
+	58,  // 29: file.ListShareFilesResponse.share:type_name -> file.ShareInfo
+	62,  // 30: file.ListShareFilesResponse.entries:type_name -> file.ShareEntry
+	65,  // 31: file.ListSharesResponse.shares:type_name -> file.ShareSummary
+	65,  // 32: file.UpdateShareResponse.share:type_name -> file.ShareSummary
+	72,  // 33: file.GetShareAccessLogResponse.logs:type_name -> file.ShareAccessLog
+	16,  // 34: file.GetUserFileStoreResponse.file_store:type_name -> file.FileStore
+	17,  // 35: file.GetUserFileStoreResponse.plan:type_name -> file.StoragePlan
+	78,  // 36: file.UpdateFileRequest.changes:type_name -> file.FileChange
+	1,   // 37: file.FileChange.operation:type_name -> file.ChangeOperation
+	13,  // 38: file.UpdateFileResponse.file:type_name -> file.File
+	78,  // 39: file.UpdateFileResponse.needed_changes:type_name -> file.FileChange
+	212, // 40: file.GetFileMetaResponse.metadata:type_name -> file.GetFileMetaResponse.MetadataEntry
+	213, // 41: file.SetFileMetaRequest.metadata:type_name -> file.SetFileMetaRequest.MetadataEntry
+	214, // 42: file.SetFileMetaResponse.metadata:type_name -> file.SetFileMetaResponse.MetadataEntry
+	2,   // 43: file.CopyFileRequest.conflict_policy:type_name -> file.NameConflictPolicy
+	13,  // 44: file.CopyFileResponse.file:type_name -> file.File
+	2,   // 45: file.CopyFolderRequest.conflict_policy:type_name -> file.NameConflictPolicy
+	14,  // 46: file.CopyFolderResponse.folder:type_name -> file.Folder
+	4,   // 47: file.BatchItem.type:type_name -> file.BatchItemType
+	4,   // 48: file.BatchItemResult.type:type_name -> file.BatchItemType
+	5,   // 49: file.BatchItemResult.status:type_name -> file.BatchItemStatus
+	92,  // 50: file.BatchOperationRequest.items:type_name -> file.BatchItem
+	3,   // 51: file.BatchOperationRequest.mode:type_name -> file.BatchMode
+	2,   // 52: file.BatchOperationRequest.conflict_policy:type_name -> file.NameConflictPolicy
+	93,  // 53: file.BatchOperationResponse.results:type_name -> file.BatchItemResult
+	13,  // 54: file.ResolvePathResponse.file:type_name -> file.File
+	14,  // 55: file.ResolvePathResponse.folder:type_name -> file.Folder
+	14,  // 56: file.EnsureFolderPathResponse.folder:type_name -> file.Folder
+	15,  // 57: file.GetFolderTreeResponse.root:type_name -> file.FolderNode
+	110, // 58: file.ReconcileQuotaResponse.usage:type_name -> file.QuotaUsage
+	17,  // 59: file.SavePlanRequest.plan:type_name -> file.StoragePlan
+	17,  // 60: file.SavePlanResponse.plan:type_name -> file.StoragePlan
+	17,  // 61: file.ListPlansResponse.plans:type_name -> file.StoragePlan
+	16,  // 62: file.AssignPlanResponse.file_store:type_name -> file.FileStore
+	16,  // 63: file.GrantCapacityResponse.file_store:type_name -> file.FileStore
+	16,  // 64: file.ListUsersNearQuotaResponse.file_stores:type_name -> file.FileStore
+	6,   // 65: file.Collaborator.role:type_name -> file.AclRole
+	6,   // 66: file.ShareWithUserRequest.role:type_name -> file.AclRole
+	122, // 67: file.ShareWithUserResponse.collaborator:type_name -> file.Collaborator
+	122, // 68: file.ListCollaboratorsResponse.collaborators:type_name -> file.Collaborator
+	6,   // 69: file.ListCollaboratorsResponse.my_role:type_name -> file.AclRole
+	14,  // 70: file.SharedItem.folder:type_name -> file.Folder
+	13,  // 71: file.SharedItem.file:type_name -> file.File
+	6,   // 72: file.SharedItem.role:type_name -> file.AclRole
+	129, // 73: file.ListSharedWithMeResponse.items:type_name -> file.SharedItem
+	16,  // 74: file.TeamSpace.file_store:type_name -> file.FileStore
+	132, // 75: file.CreateTeamSpaceResponse.space:type_name -> file.TeamSpace
+	132, // 76: file.GetTeamSpaceResponse.space:type_name -> file.TeamSpace
+	133, // 77: file.ListSpaceActivityResponse.activities:type_name -> file.SpaceActivity
+	142, // 78: file.CreateFileRequestResponse.request:type_name -> file.FileRequestInfo
+	142, // 79: file.ListFileRequestsResponse.requests:type_name -> file.FileRequestInfo
+	144, // 80: file.ListFileRequestUploadsResponse.uploads:type_name -> file.FileRequestUpload
+	143, // 81: file.GetPublicFileRequestResponse.request:type_name -> file.PublicFileRequest
+	14,  // 82: file.CreateVaultResponse.folder:type_name -> file.Folder
+	161, // 83: file.GetPublicKeysResponse.keys:type_name -> file.UserPublicKey
+	7,   // 84: file.ScrubFinding.problem:type_name -> file.ScrubProblem
+	170, // 85: file.ScrubStorageResponse.finding:type_name -> file.ScrubFinding
+	170, // 86: file.ListScrubFindingsResponse.findings:type_name -> file.ScrubFinding
+	8,   // 87: file.ProcessingResult.status:type_name -> file.ProcessingStatus
+	215, // 88: file.ProcessingResult.outputs:type_name -> file.ProcessingResult.OutputsEntry
+	175, // 89: file.ReprocessFileResponse.results:type_name -> file.ProcessingResult
+	175, // 90: file.ListDeadLettersResponse.results:type_name -> file.ProcessingResult
+	182, // 91: file.ListQuarantinedFilesResponse.files:type_name -> file.QuarantinedFile
+	187, // 92: file.CreateWebhookResponse.webhook:type_name -> file.Webhook
+	187, // 93: file.ListWebhooksResponse.webhooks:type_name -> file.Webhook
+	187, // 94: file.UpdateWebhookResponse.webhook:type_name -> file.Webhook
+	9,   // 95: file.WebhookDelivery.status:type_name -> file.WebhookDeliveryStatus
+	196, // 96: file.ListWebhookDeliveriesResponse.deliveries:type_name -> file.WebhookDelivery
+	196, // 97: file.RedeliverWebhookResponse.delivery:type_name -> file.WebhookDelivery
+	201, // 98: file.AuditLog.targets:type_name -> file.AuditTarget
+	203, // 99: file.ListAuditLogsRequest.filter:type_name -> file.AuditLogFilter
+	202, // 100: file.ListAuditLogsResponse.logs:type_name -> file.AuditLog
+	203, // 101: file.ExportAuditLogsRequest.filter:type_name -> file.AuditLogFilter
+	10,  // 102: file.ExportAuditLogsRequest.format:type_name -> file.AuditExportFormat
+	203, // 103: file.QueryAuditLogsRequest.filter:type_name -> file.AuditLogFilter
+	203, // 104: file.DumpAuditLogsRequest.filter:type_name -> file.AuditLogFilter
+	10,  // 105: file.DumpAuditLogsRequest.format:type_name -> file.AuditExportFormat
+	12,  // 106: file.FileMetaData.MetadataEntry.value:type_name -> file.MetaValue
+	12,  // 107: file.File.MetadataEntry.value:type_name -> file.MetaValue
+	12,  // 108: file.GetFileMetaResponse.MetadataEntry.value:type_name -> file.MetaValue
+	12,  // 109: file.SetFileMetaRequest.MetadataEntry.value:type_name -> file.MetaValue
+	12,  // 110: file.SetFileMetaResponse.MetadataEntry.value:type_name -> file.MetaValue
+	18,  // 111: file.FileService.Upload:input_type -> file.UploadRequest
+	20,  // 112: file.FileService.CreateFileStore:input_type -> file.CreateFileStoreRequest
+	22,  // 113: file.FileService.CreateFolder:input_type -> file.CreateFolderRequest
+	24,  // 114: file.FileService.ListFolder:input_type -> file.ListFolderRequest
+	26,  // 115: file.FileService.GetFile:input_type -> file.GetFileRequest
+	28,  // 116: file.FileService.Download:input_type -> file.DownloadRequest
+	28,  // 117: file.FileService.DownloadStream:input_type -> file.DownloadRequest
+	31,  // 118: file.FileService.MoveFolder:input_type -> file.MoveFolderRequest
+	33,  // 119: file.FileService.MoveFile:input_type -> file.MoveFileRequest
+	35,  // 120: file.FileService.DeleteFile:input_type -> file.DeleteFileRequest
+	37,  // 121: file.FileService.DeleteFolder:input_type -> file.DeleteFolderRequest
+	39,  // 122: file.FileService.Search:input_type -> file.SearchRequest
+	41,  // 123: file.FileService.Preview:input_type -> file.PreviewRequest
+	44,  // 124: file.FileService.DownloadTask:input_type -> file.DownloadTaskRequest
+	47,  // 125: file.FileService.GetDownloadTask:input_type -> file.GetDownloadTaskRequest
+	50,  // 126: file.FileService.ResumeDownload:input_type -> file.ResumeDownloadRequest
+	52,  // 127: file.FileService.UploadChunkStream:input_type -> file.UploadChunkRequest
+	54,  // 128: file.FileService.CreateShareLink:input_type -> file.CreateShareLinkRequest
+	56,  // 129: file.FileService.SaveToMyDrive:input_type -> file.SaveToMyDriveRequest
+	75,  // 130: file.FileService.GetUserFileStore:input_type -> file.GetUserFileStoreRequest
+	77,  // 131: file.FileService.UpdateFile:input_type -> file.UpdateFileRequest
+	80,  // 132: file.FileService.GetFileMeta:input_type -> file.GetFileMetaRequest
+	82,  // 133: file.FileService.SetFileMeta:input_type -> file.SetFileMetaRequest
+	84,  // 134: file.FileService.DeleteFileMeta:input_type -> file.DeleteFileMetaRequest
+	86,  // 135: file.FileService.CopyFile:input_type -> file.CopyFileRequest
+	88,  // 136: file.FileService.CopyFolder:input_type -> file.CopyFolderRequest
+	90,  // 137: file.FileService.GetJob:input_type -> file.GetJobRequest
+	94,  // 138: file.FileService.BatchMove:input_type -> file.BatchOperationRequest
+	94,  // 139: file.FileService.BatchCopy:input_type -> file.BatchOperationRequest
+	94,  // 140: file.FileService.BatchDelete:input_type -> file.BatchOperationRequest
+	94,  // 141: file.FileService.BatchRestore:input_type -> file.BatchOperationRequest
+	94,  // 142: file.FileService.BatchRename:input_type -> file.BatchOperationRequest
+	96,  // 143: file.FileService.ResolvePath:input_type -> file.ResolvePathRequest
+	98,  // 144: file.FileService.ListPath:input_type -> file.ListPathRequest
+	99,  // 145: file.FileService.DeletePath:input_type -> file.DeletePathRequest
+	101, // 146: file.FileService.EnsureFolderPath:input_type -> file.EnsureFolderPathRequest
+	103, // 147: file.FileService.GetFolderTree:input_type -> file.GetFolderTreeRequest
+	52,  // 148: file.FileService.UploadChunk:input_type -> file.UploadChunkRequest
+	107, // 149: file.FileService.ListUploadParts:input_type -> file.ListUploadPartsRequest
+	105, // 150: file.FileService.AbortUpload:input_type -> file.AbortUploadRequest
+	59,  // 151: file.FileService.ListShareFolder:input_type -> file.ListShareFolderRequest
+	61,  // 152: file.FileService.ListShareFiles:input_type -> file.ListShareFilesRequest
+	64,  // 153: file.FileService.GetShareFile:input_type -> file.ShareFileRequest
+	64,  // 154: file.FileService.PreviewShareFile:input_type -> file.ShareFileRequest
+	64,  // 155: file.FileService.DownloadShareFile:input_type -> file.ShareFileRequest
+	66,  // 156: file.FileService.ListShares:input_type -> file.ListSharesRequest
+	68,  // 157: file.FileService.RevokeShares:input_type -> file.RevokeSharesRequest
+	70,  // 158: file.FileService.UpdateShare:input_type -> file.UpdateShareRequest
+	73,  // 159: file.FileService.GetShareAccessLog:input_type -> file.GetShareAccessLogRequest
+	123, // 160: file.FileService.ShareWithUser:input_type -> file.ShareWithUserRequest
+	125, // 161: file.FileService.RevokeUserShare:input_type -> file.RevokeUserShareRequest
+	127, // 162: file.FileService.ListCollaborators:input_type -> file.ListCollaboratorsRequest
+	130, // 163: file.FileService.ListSharedWithMe:input_type -> file.ListSharedWithMeRequest
+	138, // 164: file.FileService.GetTeamSpace:input_type -> file.GetTeamSpaceRequest
+	140, // 165: file.FileService.ListSpaceActivity:input_type -> file.ListSpaceActivityRequest
+	145, // 166: file.FileService.CreateFileRequest:input_type -> file.CreateFileRequestRequest
+	147, // 167: file.FileService.ListFileRequests:input_type -> file.ListFileRequestsRequest
+	149, // 168: file.FileService.CloseFileRequests:input_type -> file.CloseFileRequestsRequest
+	151, // 169: file.FileService.ListFileRequestUploads:input_type -> file.ListFileRequestUploadsRequest
+	153, // 170: file.FileService.GetPublicFileRequest:input_type -> file.GetPublicFileRequestRequest
+	155, // 171: file.FileService.SubmitFileRequest:input_type -> file.SubmitFileRequestRequest
+	157, // 172: file.FileService.CreateVault:input_type -> file.CreateVaultRequest
+	159, // 173: file.FileService.SetPublicKey:input_type -> file.SetPublicKeyRequest
+	162, // 174: file.FileService.GetPublicKeys:input_type -> file.GetPublicKeysRequest
+	164, // 175: file.FileService.PutVaultKeyEnvelope:input_type -> file.PutVaultKeyEnvelopeRequest
+	166, // 176: file.FileService.GetVaultKeyEnvelope:input_type -> file.GetVaultKeyEnvelopeRequest
+	168, // 177: file.FileService.RevokeVaultKeyEnvelope:input_type -> file.RevokeVaultKeyEnvelopeRequest
+	188, // 178: file.FileService.CreateWebhook:input_type -> file.CreateWebhookRequest
+	190, // 179: file.FileService.ListWebhooks:input_type -> file.ListWebhooksRequest
+	192, // 180: file.FileService.UpdateWebhook:input_type -> file.UpdateWebhookRequest
+	194, // 181: file.FileService.DeleteWebhook:input_type -> file.DeleteWebhookRequest
+	197, // 182: file.FileService.ListWebhookDeliveries:input_type -> file.ListWebhookDeliveriesRequest
+	199, // 183: file.FileService.RedeliverWebhook:input_type -> file.RedeliverWebhookRequest
+	204, // 184: file.FileService.ListAuditLogs:input_type -> file.ListAuditLogsRequest
+	206, // 185: file.FileService.ExportAuditLogs:input_type -> file.ExportAuditLogsRequest
+	109, // 186: file.FileService.ReconcileQuota:input_type -> file.ReconcileQuotaRequest
+	112, // 187: file.FileService.SavePlan:input_type -> file.SavePlanRequest
+	114, // 188: file.FileService.ListPlans:input_type -> file.ListPlansRequest
+	116, // 189: file.FileService.AssignPlan:input_type -> file.AssignPlanRequest
+	118, // 190: file.FileService.GrantCapacity:input_type -> file.GrantCapacityRequest
+	120, // 191: file.FileService.ListUsersNearQuota:input_type -> file.ListUsersNearQuotaRequest
+	134, // 192: file.FileService.CreateTeamSpace:input_type -> file.CreateTeamSpaceRequest
+	136, // 193: file.FileService.SetSpaceMember:input_type -> file.SetSpaceMemberRequest
+	171, // 194: file.FileService.ScrubStorage:input_type -> file.ScrubStorageRequest
+	173, // 195: file.FileService.ListScrubFindings:input_type -> file.ListScrubFindingsRequest
+	176, // 196: file.FileService.ReprocessFile:input_type -> file.ReprocessFileRequest
+	178, // 197: file.FileService.ListDeadLetters:input_type -> file.ListDeadLettersRequest
+	180, // 198: file.FileService.RetryDeadLetters:input_type -> file.RetryDeadLettersRequest
+	183, // 199: file.FileService.ListQuarantinedFiles:input_type -> file.ListQuarantinedFilesRequest
+	185, // 200: file.FileService.ReleaseQuarantinedFile:input_type -> file.ReleaseQuarantinedFileRequest
+	208, // 201: file.FileService.QueryAuditLogs:input_type -> file.QueryAuditLogsRequest
+	209, // 202: file.FileService.DumpAuditLogs:input_type -> file.DumpAuditLogsRequest
+	19,  // 203: file.FileService.Upload:output_type -> file.UploadResponse
+	21,  // 204: file.FileService.CreateFileStore:output_type -> file.CreateFileStoreResponse
+	23,  // 205: file.FileService.CreateFolder:output_type -> file.CreateFolderResponse
+	25,  // 206: file.FileService.ListFolder:output_type -> file.ListFolderResponse
+	27,  // 207: file.FileService.GetFile:output_type -> file.GetFileResponse
+	29,  // 208: file.FileService.Download:output_type -> file.DownloadResponse
+	30,  // 209: file.FileService.DownloadStream:output_type -> file.DownloadStreamResponse
+	32,  // 210: file.FileService.MoveFolder:output_type -> file.MoveFolderResponse
+	34,  // 211: file.FileService.MoveFile:output_type -> file.MoveFileResponse
+	36,  // 212: file.FileService.DeleteFile:output_type -> file.DeleteFileResponse
+	38,  // 213: file.FileService.DeleteFolder:output_type -> file.DeleteFolderResponse
+	40,  // 214: file.FileService.Search:output_type -> file.SearchResponse
+	42,  // 215: file.FileService.Preview:output_type -> file.PreviewResponse
+	46,  // 216: file.FileService.DownloadTask:output_type -> file.DownloadTaskResponse
+	48,  // 217: file.FileService.GetDownloadTask:output_type -> file.GetDownloadTaskResponse
+	51,  // 218: file.FileService.ResumeDownload:output_type -> file.ResumeDownloadResponse
+	53,  // 219: file.FileService.UploadChunkStream:output_type -> file.UploadChunkResponse
+	55,  // 220: file.FileService.CreateShareLink:output_type -> file.CreateShareLinkResponse
+	57,  // 221: file.FileService.SaveToMyDrive:output_type -> file.SaveToMyDriveResponse
+	76,  // 222: file.FileService.GetUserFileStore:output_type -> file.GetUserFileStoreResponse
+	79,  // 223: file.FileService.UpdateFile:output_type -> file.UpdateFileResponse
+	81,  // 224: file.FileService.GetFileMeta:output_type -> file.GetFileMetaResponse
+	83,  // 225: file.FileService.SetFileMeta:output_type -> file.SetFileMetaResponse
+	85,  // 226: file.FileService.DeleteFileMeta:output_type -> file.DeleteFileMetaResponse
+	87,  // 227: file.FileService.CopyFile:output_type -> file.CopyFileResponse
+	89,  // 228: file.FileService.CopyFolder:output_type -> file.CopyFolderResponse
+	91,  // 229: file.FileService.GetJob:output_type -> file.GetJobResponse
+	95,  // 230: file.FileService.BatchMove:output_type -> file.BatchOperationResponse
+	95,  // 231: file.FileService.BatchCopy:output_type -> file.BatchOperationResponse
+	95,  // 232: file.FileService.BatchDelete:output_type -> file.BatchOperationResponse
+	95,  // 233: file.FileService.BatchRestore:output_type -> file.BatchOperationResponse
+	95,  // 234: file.FileService.BatchRename:output_type -> file.BatchOperationResponse
+	97,  // 235: file.FileService.ResolvePath:output_type -> file.ResolvePathResponse
+	25,  // 236: file.FileService.ListPath:output_type -> file.ListFolderResponse
+	100, // 237: file.FileService.DeletePath:output_type -> file.DeletePathResponse
+	102, // 238: file.FileService.EnsureFolderPath:output_type -> file.EnsureFolderPathResponse
+	104, // 239: file.FileService.GetFolderTree:output_type -> file.GetFolderTreeResponse
+	53,  // 240: file.FileService.UploadChunk:output_type -> file.UploadChunkResponse
+	108, // 241: file.FileService.ListUploadParts:output_type -> file.ListUploadPartsResponse
+	106, // 242: file.FileService.AbortUpload:output_type -> file.AbortUploadResponse
+	60,  // 243: file.FileService.ListShareFolder:output_type -> file.ListShareFolderResponse
+	63,  // 244: file.FileService.ListShareFiles:output_type -> file.ListShareFilesResponse
+	27,  // 245: file.FileService.GetShareFile:output_type -> file.GetFileResponse
+	42,  // 246: file.FileService.PreviewShareFile:output_type -> file.PreviewResponse
+	30,  // 247: file.FileService.DownloadShareFile:output_type -> file.DownloadStreamResponse
+	67,  // 248: file.FileService.ListShares:output_type -> file.ListSharesResponse
+	69,  // 249: file.FileService.RevokeShares:output_type -> file.RevokeSharesResponse
+	71,  // 250: file.FileService.UpdateShare:output_type -> file.UpdateShareResponse
+	74,  // 251: file.FileService.GetShareAccessLog:output_type -> file.GetShareAccessLogResponse
+	124, // 252: file.FileService.ShareWithUser:output_type -> file.ShareWithUserResponse
+	126, // 253: file.FileService.RevokeUserShare:output_type -> file.RevokeUserShareResponse
+	128, // 254: file.FileService.ListCollaborators:output_type -> file.ListCollaboratorsResponse
+	131, // 255: file.FileService.ListSharedWithMe:output_type -> file.ListSharedWithMeResponse
+	139, // 256: file.FileService.GetTeamSpace:output_type -> file.GetTeamSpaceResponse
+	141, // 257: file.FileService.ListSpaceActivity:output_type -> file.ListSpaceActivityResponse
+	146, // 258: file.FileService.CreateFileRequest:output_type -> file.CreateFileRequestResponse
+	148, // 259: file.FileService.ListFileRequests:output_type -> file.ListFileRequestsResponse
+	150, // 260: file.FileService.CloseFileRequests:output_type -> file.CloseFileRequestsResponse
+	152, // 261: file.FileService.ListFileRequestUploads:output_type -> file.ListFileRequestUploadsResponse
+	154, // 262: file.FileService.GetPublicFileRequest:output_type -> file.GetPublicFileRequestResponse
+	156, // 263: file.FileService.SubmitFileRequest:output_type -> file.SubmitFileRequestResponse
+	158, // 264: file.FileService.CreateVault:output_type -> file.CreateVaultResponse
+	160, // 265: file.FileService.SetPublicKey:output_type -> file.SetPublicKeyResponse
+	163, // 266: file.FileService.GetPublicKeys:output_type -> file.GetPublicKeysResponse
+	165, // 267: file.FileService.PutVaultKeyEnvelope:output_type -> file.PutVaultKeyEnvelopeResponse
+	167, // 268: file.FileService.GetVaultKeyEnvelope:output_type -> file.GetVaultKeyEnvelopeResponse
+	169, // 269: file.FileService.RevokeVaultKeyEnvelope:output_type -> file.RevokeVaultKeyEnvelopeResponse
+	189, // 270: file.FileService.CreateWebhook:output_type -> file.CreateWebhookResponse
+	191, // 271: file.FileService.ListWebhooks:output_type -> file.ListWebhooksResponse
+	193, // 272: file.FileService.UpdateWebhook:output_type -> file.UpdateWebhookResponse
+	195, // 273: file.FileService.DeleteWebhook:output_type -> file.DeleteWebhookResponse
+	198, // 274: file.FileService.ListWebhookDeliveries:output_type -> file.ListWebhookDeliveriesResponse
+	200, // 275: file.FileService.RedeliverWebhook:output_type -> file.RedeliverWebhookResponse
+	205, // 276: file.FileService.ListAuditLogs:output_type -> file.ListAuditLogsResponse
+	207, // 277: file.FileService.ExportAuditLogs:output_type -> file.ExportAuditLogsResponse
+	111, // 278: file.FileService.ReconcileQuota:output_type -> file.ReconcileQuotaResponse
+	113, // 279: file.FileService.SavePlan:output_type -> file.SavePlanResponse
+	115, // 280: file.FileService.ListPlans:output_type -> file.ListPlansResponse
+	117, // 281: file.FileService.AssignPlan:output_type -> file.AssignPlanResponse
+	119, // 282: file.FileService.GrantCapacity:output_type -> file.GrantCapacityResponse
+	121, // 283: file.FileService.ListUsersNearQuota:output_type -> file.ListUsersNearQuotaResponse
+	135, // 284: file.FileService.CreateTeamSpace:output_type -> file.CreateTeamSpaceResponse
+	137, // 285: file.FileService.SetSpaceMember:output_type -> file.SetSpaceMemberResponse
+	172, // 286: file.FileService.ScrubStorage:output_type -> file.ScrubStorageResponse
+	174, // 287: file.FileService.ListScrubFindings:output_type -> file.ListScrubFindingsResponse
+	177, // 288: file.FileService.ReprocessFile:output_type -> file.ReprocessFileResponse
+	179, // 289: file.FileService.ListDeadLetters:output_type -> file.ListDeadLettersResponse
+	181, // 290: file.FileService.RetryDeadLetters:output_type -> file.RetryDeadLettersResponse
+	184, // 291: file.FileService.ListQuarantinedFiles:output_type -> file.ListQuarantinedFilesResponse
+	186, // 292: file.FileService.ReleaseQuarantinedFile:output_type -> file.ReleaseQuarantinedFileResponse
+	205, // 293: file.FileService.QueryAuditLogs:output_type -> file.ListAuditLogsResponse
+	207, // 294: file.FileService.DumpAuditLogs:output_type -> file.ExportAuditLogsResponse
+	203, // [203:295] is the sub-list for method output_type
+	111, // [111:203] is the sub-list for method input_type
+	111, // [111:111] is the sub-list for extension type_name
+	111, // [111:111] is the sub-list for extension extendee
+	0,   // [0:111] is the sub-list for field type_name
 }
 
 func init() { file_idl_cloudstorage_file_proto_init() }
@@ -3394,13 +14812,19 @@ func file_idl_cloudstorage_file_proto_init() {
 	if File_idl_cloudstorage_file_proto != nil {
 		return
 	}
+	file_idl_cloudstorage_file_proto_msgTypes[1].OneofWrappers = []any{
+		(*MetaValue_StringValue)(nil),
+		(*MetaValue_NumberValue)(nil),
+		(*MetaValue_DateValue)(nil),
+		(*MetaValue_BoolValue)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_idl_cloudstorage_file_proto_rawDesc), len(file_idl_cloudstorage_file_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   49,
+			NumEnums:      11,
+			NumMessages:   205,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
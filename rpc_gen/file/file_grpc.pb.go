@@ -19,27 +19,98 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FileService_Upload_FullMethodName            = "/file.FileService/Upload"
-	FileService_CreateFileStore_FullMethodName   = "/file.FileService/CreateFileStore"
-	FileService_CreateFolder_FullMethodName      = "/file.FileService/CreateFolder"
-	FileService_ListFolder_FullMethodName        = "/file.FileService/ListFolder"
-	FileService_GetFile_FullMethodName           = "/file.FileService/GetFile"
-	FileService_Download_FullMethodName          = "/file.FileService/Download"
-	FileService_DownloadStream_FullMethodName    = "/file.FileService/DownloadStream"
-	FileService_MoveFolder_FullMethodName        = "/file.FileService/MoveFolder"
-	FileService_MoveFile_FullMethodName          = "/file.FileService/MoveFile"
-	FileService_DeleteFile_FullMethodName        = "/file.FileService/DeleteFile"
-	FileService_DeleteFolder_FullMethodName      = "/file.FileService/DeleteFolder"
-	FileService_Search_FullMethodName            = "/file.FileService/Search"
-	FileService_Preview_FullMethodName           = "/file.FileService/Preview"
-	FileService_DownloadTask_FullMethodName      = "/file.FileService/DownloadTask"
-	FileService_GetDownloadTask_FullMethodName   = "/file.FileService/GetDownloadTask"
-	FileService_ResumeDownload_FullMethodName    = "/file.FileService/ResumeDownload"
-	FileService_UploadChunkStream_FullMethodName = "/file.FileService/UploadChunkStream"
-	FileService_CreateShareLink_FullMethodName   = "/file.FileService/CreateShareLink"
-	FileService_SaveToMyDrive_FullMethodName     = "/file.FileService/SaveToMyDrive"
-	FileService_GetUserFileStore_FullMethodName  = "/file.FileService/GetUserFileStore"
-	FileService_UpdateFile_FullMethodName        = "/file.FileService/UpdateFile"
+	FileService_Upload_FullMethodName                 = "/file.FileService/Upload"
+	FileService_CreateFileStore_FullMethodName        = "/file.FileService/CreateFileStore"
+	FileService_CreateFolder_FullMethodName           = "/file.FileService/CreateFolder"
+	FileService_ListFolder_FullMethodName             = "/file.FileService/ListFolder"
+	FileService_GetFile_FullMethodName                = "/file.FileService/GetFile"
+	FileService_Download_FullMethodName               = "/file.FileService/Download"
+	FileService_DownloadStream_FullMethodName         = "/file.FileService/DownloadStream"
+	FileService_MoveFolder_FullMethodName             = "/file.FileService/MoveFolder"
+	FileService_MoveFile_FullMethodName               = "/file.FileService/MoveFile"
+	FileService_DeleteFile_FullMethodName             = "/file.FileService/DeleteFile"
+	FileService_DeleteFolder_FullMethodName           = "/file.FileService/DeleteFolder"
+	FileService_Search_FullMethodName                 = "/file.FileService/Search"
+	FileService_Preview_FullMethodName                = "/file.FileService/Preview"
+	FileService_DownloadTask_FullMethodName           = "/file.FileService/DownloadTask"
+	FileService_GetDownloadTask_FullMethodName        = "/file.FileService/GetDownloadTask"
+	FileService_ResumeDownload_FullMethodName         = "/file.FileService/ResumeDownload"
+	FileService_UploadChunkStream_FullMethodName      = "/file.FileService/UploadChunkStream"
+	FileService_CreateShareLink_FullMethodName        = "/file.FileService/CreateShareLink"
+	FileService_SaveToMyDrive_FullMethodName          = "/file.FileService/SaveToMyDrive"
+	FileService_GetUserFileStore_FullMethodName       = "/file.FileService/GetUserFileStore"
+	FileService_UpdateFile_FullMethodName             = "/file.FileService/UpdateFile"
+	FileService_GetFileMeta_FullMethodName            = "/file.FileService/GetFileMeta"
+	FileService_SetFileMeta_FullMethodName            = "/file.FileService/SetFileMeta"
+	FileService_DeleteFileMeta_FullMethodName         = "/file.FileService/DeleteFileMeta"
+	FileService_CopyFile_FullMethodName               = "/file.FileService/CopyFile"
+	FileService_CopyFolder_FullMethodName             = "/file.FileService/CopyFolder"
+	FileService_GetJob_FullMethodName                 = "/file.FileService/GetJob"
+	FileService_BatchMove_FullMethodName              = "/file.FileService/BatchMove"
+	FileService_BatchCopy_FullMethodName              = "/file.FileService/BatchCopy"
+	FileService_BatchDelete_FullMethodName            = "/file.FileService/BatchDelete"
+	FileService_BatchRestore_FullMethodName           = "/file.FileService/BatchRestore"
+	FileService_BatchRename_FullMethodName            = "/file.FileService/BatchRename"
+	FileService_ResolvePath_FullMethodName            = "/file.FileService/ResolvePath"
+	FileService_ListPath_FullMethodName               = "/file.FileService/ListPath"
+	FileService_DeletePath_FullMethodName             = "/file.FileService/DeletePath"
+	FileService_EnsureFolderPath_FullMethodName       = "/file.FileService/EnsureFolderPath"
+	FileService_GetFolderTree_FullMethodName          = "/file.FileService/GetFolderTree"
+	FileService_UploadChunk_FullMethodName            = "/file.FileService/UploadChunk"
+	FileService_ListUploadParts_FullMethodName        = "/file.FileService/ListUploadParts"
+	FileService_AbortUpload_FullMethodName            = "/file.FileService/AbortUpload"
+	FileService_ListShareFolder_FullMethodName        = "/file.FileService/ListShareFolder"
+	FileService_ListShareFiles_FullMethodName         = "/file.FileService/ListShareFiles"
+	FileService_GetShareFile_FullMethodName           = "/file.FileService/GetShareFile"
+	FileService_PreviewShareFile_FullMethodName       = "/file.FileService/PreviewShareFile"
+	FileService_DownloadShareFile_FullMethodName      = "/file.FileService/DownloadShareFile"
+	FileService_ListShares_FullMethodName             = "/file.FileService/ListShares"
+	FileService_RevokeShares_FullMethodName           = "/file.FileService/RevokeShares"
+	FileService_UpdateShare_FullMethodName            = "/file.FileService/UpdateShare"
+	FileService_GetShareAccessLog_FullMethodName      = "/file.FileService/GetShareAccessLog"
+	FileService_ShareWithUser_FullMethodName          = "/file.FileService/ShareWithUser"
+	FileService_RevokeUserShare_FullMethodName        = "/file.FileService/RevokeUserShare"
+	FileService_ListCollaborators_FullMethodName      = "/file.FileService/ListCollaborators"
+	FileService_ListSharedWithMe_FullMethodName       = "/file.FileService/ListSharedWithMe"
+	FileService_GetTeamSpace_FullMethodName           = "/file.FileService/GetTeamSpace"
+	FileService_ListSpaceActivity_FullMethodName      = "/file.FileService/ListSpaceActivity"
+	FileService_CreateFileRequest_FullMethodName      = "/file.FileService/CreateFileRequest"
+	FileService_ListFileRequests_FullMethodName       = "/file.FileService/ListFileRequests"
+	FileService_CloseFileRequests_FullMethodName      = "/file.FileService/CloseFileRequests"
+	FileService_ListFileRequestUploads_FullMethodName = "/file.FileService/ListFileRequestUploads"
+	FileService_GetPublicFileRequest_FullMethodName   = "/file.FileService/GetPublicFileRequest"
+	FileService_SubmitFileRequest_FullMethodName      = "/file.FileService/SubmitFileRequest"
+	FileService_CreateVault_FullMethodName            = "/file.FileService/CreateVault"
+	FileService_SetPublicKey_FullMethodName           = "/file.FileService/SetPublicKey"
+	FileService_GetPublicKeys_FullMethodName          = "/file.FileService/GetPublicKeys"
+	FileService_PutVaultKeyEnvelope_FullMethodName    = "/file.FileService/PutVaultKeyEnvelope"
+	FileService_GetVaultKeyEnvelope_FullMethodName    = "/file.FileService/GetVaultKeyEnvelope"
+	FileService_RevokeVaultKeyEnvelope_FullMethodName = "/file.FileService/RevokeVaultKeyEnvelope"
+	FileService_CreateWebhook_FullMethodName          = "/file.FileService/CreateWebhook"
+	FileService_ListWebhooks_FullMethodName           = "/file.FileService/ListWebhooks"
+	FileService_UpdateWebhook_FullMethodName          = "/file.FileService/UpdateWebhook"
+	FileService_DeleteWebhook_FullMethodName          = "/file.FileService/DeleteWebhook"
+	FileService_ListWebhookDeliveries_FullMethodName  = "/file.FileService/ListWebhookDeliveries"
+	FileService_RedeliverWebhook_FullMethodName       = "/file.FileService/RedeliverWebhook"
+	FileService_ListAuditLogs_FullMethodName          = "/file.FileService/ListAuditLogs"
+	FileService_ExportAuditLogs_FullMethodName        = "/file.FileService/ExportAuditLogs"
+	FileService_ReconcileQuota_FullMethodName         = "/file.FileService/ReconcileQuota"
+	FileService_SavePlan_FullMethodName               = "/file.FileService/SavePlan"
+	FileService_ListPlans_FullMethodName              = "/file.FileService/ListPlans"
+	FileService_AssignPlan_FullMethodName             = "/file.FileService/AssignPlan"
+	FileService_GrantCapacity_FullMethodName          = "/file.FileService/GrantCapacity"
+	FileService_ListUsersNearQuota_FullMethodName     = "/file.FileService/ListUsersNearQuota"
+	FileService_CreateTeamSpace_FullMethodName        = "/file.FileService/CreateTeamSpace"
+	FileService_SetSpaceMember_FullMethodName         = "/file.FileService/SetSpaceMember"
+	FileService_ScrubStorage_FullMethodName           = "/file.FileService/ScrubStorage"
+	FileService_ListScrubFindings_FullMethodName      = "/file.FileService/ListScrubFindings"
+	FileService_ReprocessFile_FullMethodName          = "/file.FileService/ReprocessFile"
+	FileService_ListDeadLetters_FullMethodName        = "/file.FileService/ListDeadLetters"
+	FileService_RetryDeadLetters_FullMethodName       = "/file.FileService/RetryDeadLetters"
+	FileService_ListQuarantinedFiles_FullMethodName   = "/file.FileService/ListQuarantinedFiles"
+	FileService_ReleaseQuarantinedFile_FullMethodName = "/file.FileService/ReleaseQuarantinedFile"
+	FileService_QueryAuditLogs_FullMethodName         = "/file.FileService/QueryAuditLogs"
+	FileService_DumpAuditLogs_FullMethodName          = "/file.FileService/DumpAuditLogs"
 )
 
 // FileServiceClient is the client API for FileService service.
@@ -67,6 +138,78 @@ type FileServiceClient interface {
 	SaveToMyDrive(ctx context.Context, in *SaveToMyDriveRequest, opts ...grpc.CallOption) (*SaveToMyDriveResponse, error)
 	GetUserFileStore(ctx context.Context, in *GetUserFileStoreRequest, opts ...grpc.CallOption) (*GetUserFileStoreResponse, error)
 	UpdateFile(ctx context.Context, in *UpdateFileRequest, opts ...grpc.CallOption) (*UpdateFileResponse, error)
+	GetFileMeta(ctx context.Context, in *GetFileMetaRequest, opts ...grpc.CallOption) (*GetFileMetaResponse, error)
+	SetFileMeta(ctx context.Context, in *SetFileMetaRequest, opts ...grpc.CallOption) (*SetFileMetaResponse, error)
+	DeleteFileMeta(ctx context.Context, in *DeleteFileMetaRequest, opts ...grpc.CallOption) (*DeleteFileMetaResponse, error)
+	CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*CopyFileResponse, error)
+	CopyFolder(ctx context.Context, in *CopyFolderRequest, opts ...grpc.CallOption) (*CopyFolderResponse, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
+	BatchMove(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error)
+	BatchCopy(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error)
+	BatchDelete(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error)
+	BatchRestore(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error)
+	BatchRename(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error)
+	ResolvePath(ctx context.Context, in *ResolvePathRequest, opts ...grpc.CallOption) (*ResolvePathResponse, error)
+	ListPath(ctx context.Context, in *ListPathRequest, opts ...grpc.CallOption) (*ListFolderResponse, error)
+	DeletePath(ctx context.Context, in *DeletePathRequest, opts ...grpc.CallOption) (*DeletePathResponse, error)
+	EnsureFolderPath(ctx context.Context, in *EnsureFolderPathRequest, opts ...grpc.CallOption) (*EnsureFolderPathResponse, error)
+	GetFolderTree(ctx context.Context, in *GetFolderTreeRequest, opts ...grpc.CallOption) (*GetFolderTreeResponse, error)
+	UploadChunk(ctx context.Context, in *UploadChunkRequest, opts ...grpc.CallOption) (*UploadChunkResponse, error)
+	ListUploadParts(ctx context.Context, in *ListUploadPartsRequest, opts ...grpc.CallOption) (*ListUploadPartsResponse, error)
+	AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*AbortUploadResponse, error)
+	ListShareFolder(ctx context.Context, in *ListShareFolderRequest, opts ...grpc.CallOption) (*ListShareFolderResponse, error)
+	ListShareFiles(ctx context.Context, in *ListShareFilesRequest, opts ...grpc.CallOption) (*ListShareFilesResponse, error)
+	GetShareFile(ctx context.Context, in *ShareFileRequest, opts ...grpc.CallOption) (*GetFileResponse, error)
+	PreviewShareFile(ctx context.Context, in *ShareFileRequest, opts ...grpc.CallOption) (*PreviewResponse, error)
+	DownloadShareFile(ctx context.Context, in *ShareFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadStreamResponse], error)
+	ListShares(ctx context.Context, in *ListSharesRequest, opts ...grpc.CallOption) (*ListSharesResponse, error)
+	RevokeShares(ctx context.Context, in *RevokeSharesRequest, opts ...grpc.CallOption) (*RevokeSharesResponse, error)
+	UpdateShare(ctx context.Context, in *UpdateShareRequest, opts ...grpc.CallOption) (*UpdateShareResponse, error)
+	GetShareAccessLog(ctx context.Context, in *GetShareAccessLogRequest, opts ...grpc.CallOption) (*GetShareAccessLogResponse, error)
+	ShareWithUser(ctx context.Context, in *ShareWithUserRequest, opts ...grpc.CallOption) (*ShareWithUserResponse, error)
+	RevokeUserShare(ctx context.Context, in *RevokeUserShareRequest, opts ...grpc.CallOption) (*RevokeUserShareResponse, error)
+	ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error)
+	ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error)
+	GetTeamSpace(ctx context.Context, in *GetTeamSpaceRequest, opts ...grpc.CallOption) (*GetTeamSpaceResponse, error)
+	ListSpaceActivity(ctx context.Context, in *ListSpaceActivityRequest, opts ...grpc.CallOption) (*ListSpaceActivityResponse, error)
+	CreateFileRequest(ctx context.Context, in *CreateFileRequestRequest, opts ...grpc.CallOption) (*CreateFileRequestResponse, error)
+	ListFileRequests(ctx context.Context, in *ListFileRequestsRequest, opts ...grpc.CallOption) (*ListFileRequestsResponse, error)
+	CloseFileRequests(ctx context.Context, in *CloseFileRequestsRequest, opts ...grpc.CallOption) (*CloseFileRequestsResponse, error)
+	ListFileRequestUploads(ctx context.Context, in *ListFileRequestUploadsRequest, opts ...grpc.CallOption) (*ListFileRequestUploadsResponse, error)
+	GetPublicFileRequest(ctx context.Context, in *GetPublicFileRequestRequest, opts ...grpc.CallOption) (*GetPublicFileRequestResponse, error)
+	SubmitFileRequest(ctx context.Context, in *SubmitFileRequestRequest, opts ...grpc.CallOption) (*SubmitFileRequestResponse, error)
+	CreateVault(ctx context.Context, in *CreateVaultRequest, opts ...grpc.CallOption) (*CreateVaultResponse, error)
+	SetPublicKey(ctx context.Context, in *SetPublicKeyRequest, opts ...grpc.CallOption) (*SetPublicKeyResponse, error)
+	GetPublicKeys(ctx context.Context, in *GetPublicKeysRequest, opts ...grpc.CallOption) (*GetPublicKeysResponse, error)
+	PutVaultKeyEnvelope(ctx context.Context, in *PutVaultKeyEnvelopeRequest, opts ...grpc.CallOption) (*PutVaultKeyEnvelopeResponse, error)
+	GetVaultKeyEnvelope(ctx context.Context, in *GetVaultKeyEnvelopeRequest, opts ...grpc.CallOption) (*GetVaultKeyEnvelopeResponse, error)
+	RevokeVaultKeyEnvelope(ctx context.Context, in *RevokeVaultKeyEnvelopeRequest, opts ...grpc.CallOption) (*RevokeVaultKeyEnvelopeResponse, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*UpdateWebhookResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*RedeliverWebhookResponse, error)
+	ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error)
+	ExportAuditLogs(ctx context.Context, in *ExportAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAuditLogsResponse], error)
+	// 以下为管理接口, 不经网关暴露; 除 CreateTeamSpace、SetSpaceMember 由用户服务调用外, 须在元数据 x-admin-token 中携带管理令牌
+	ReconcileQuota(ctx context.Context, in *ReconcileQuotaRequest, opts ...grpc.CallOption) (*ReconcileQuotaResponse, error)
+	SavePlan(ctx context.Context, in *SavePlanRequest, opts ...grpc.CallOption) (*SavePlanResponse, error)
+	ListPlans(ctx context.Context, in *ListPlansRequest, opts ...grpc.CallOption) (*ListPlansResponse, error)
+	AssignPlan(ctx context.Context, in *AssignPlanRequest, opts ...grpc.CallOption) (*AssignPlanResponse, error)
+	GrantCapacity(ctx context.Context, in *GrantCapacityRequest, opts ...grpc.CallOption) (*GrantCapacityResponse, error)
+	ListUsersNearQuota(ctx context.Context, in *ListUsersNearQuotaRequest, opts ...grpc.CallOption) (*ListUsersNearQuotaResponse, error)
+	CreateTeamSpace(ctx context.Context, in *CreateTeamSpaceRequest, opts ...grpc.CallOption) (*CreateTeamSpaceResponse, error)
+	SetSpaceMember(ctx context.Context, in *SetSpaceMemberRequest, opts ...grpc.CallOption) (*SetSpaceMemberResponse, error)
+	ScrubStorage(ctx context.Context, in *ScrubStorageRequest, opts ...grpc.CallOption) (*ScrubStorageResponse, error)
+	ListScrubFindings(ctx context.Context, in *ListScrubFindingsRequest, opts ...grpc.CallOption) (*ListScrubFindingsResponse, error)
+	ReprocessFile(ctx context.Context, in *ReprocessFileRequest, opts ...grpc.CallOption) (*ReprocessFileResponse, error)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	RetryDeadLetters(ctx context.Context, in *RetryDeadLettersRequest, opts ...grpc.CallOption) (*RetryDeadLettersResponse, error)
+	ListQuarantinedFiles(ctx context.Context, in *ListQuarantinedFilesRequest, opts ...grpc.CallOption) (*ListQuarantinedFilesResponse, error)
+	ReleaseQuarantinedFile(ctx context.Context, in *ReleaseQuarantinedFileRequest, opts ...grpc.CallOption) (*ReleaseQuarantinedFileResponse, error)
+	QueryAuditLogs(ctx context.Context, in *QueryAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error)
+	DumpAuditLogs(ctx context.Context, in *DumpAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAuditLogsResponse], error)
 }
 
 type fileServiceClient struct {
@@ -299,6 +442,743 @@ func (c *fileServiceClient) UpdateFile(ctx context.Context, in *UpdateFileReques
 	return out, nil
 }
 
+func (c *fileServiceClient) GetFileMeta(ctx context.Context, in *GetFileMetaRequest, opts ...grpc.CallOption) (*GetFileMetaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFileMetaResponse)
+	err := c.cc.Invoke(ctx, FileService_GetFileMeta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) SetFileMeta(ctx context.Context, in *SetFileMetaRequest, opts ...grpc.CallOption) (*SetFileMetaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetFileMetaResponse)
+	err := c.cc.Invoke(ctx, FileService_SetFileMeta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) DeleteFileMeta(ctx context.Context, in *DeleteFileMetaRequest, opts ...grpc.CallOption) (*DeleteFileMetaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFileMetaResponse)
+	err := c.cc.Invoke(ctx, FileService_DeleteFileMeta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*CopyFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CopyFileResponse)
+	err := c.cc.Invoke(ctx, FileService_CopyFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CopyFolder(ctx context.Context, in *CopyFolderRequest, opts ...grpc.CallOption) (*CopyFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CopyFolderResponse)
+	err := c.cc.Invoke(ctx, FileService_CopyFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJobResponse)
+	err := c.cc.Invoke(ctx, FileService_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) BatchMove(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchOperationResponse)
+	err := c.cc.Invoke(ctx, FileService_BatchMove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) BatchCopy(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchOperationResponse)
+	err := c.cc.Invoke(ctx, FileService_BatchCopy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) BatchDelete(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchOperationResponse)
+	err := c.cc.Invoke(ctx, FileService_BatchDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) BatchRestore(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchOperationResponse)
+	err := c.cc.Invoke(ctx, FileService_BatchRestore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) BatchRename(ctx context.Context, in *BatchOperationRequest, opts ...grpc.CallOption) (*BatchOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchOperationResponse)
+	err := c.cc.Invoke(ctx, FileService_BatchRename_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ResolvePath(ctx context.Context, in *ResolvePathRequest, opts ...grpc.CallOption) (*ResolvePathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolvePathResponse)
+	err := c.cc.Invoke(ctx, FileService_ResolvePath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListPath(ctx context.Context, in *ListPathRequest, opts ...grpc.CallOption) (*ListFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFolderResponse)
+	err := c.cc.Invoke(ctx, FileService_ListPath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) DeletePath(ctx context.Context, in *DeletePathRequest, opts ...grpc.CallOption) (*DeletePathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePathResponse)
+	err := c.cc.Invoke(ctx, FileService_DeletePath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) EnsureFolderPath(ctx context.Context, in *EnsureFolderPathRequest, opts ...grpc.CallOption) (*EnsureFolderPathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnsureFolderPathResponse)
+	err := c.cc.Invoke(ctx, FileService_EnsureFolderPath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetFolderTree(ctx context.Context, in *GetFolderTreeRequest, opts ...grpc.CallOption) (*GetFolderTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFolderTreeResponse)
+	err := c.cc.Invoke(ctx, FileService_GetFolderTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) UploadChunk(ctx context.Context, in *UploadChunkRequest, opts ...grpc.CallOption) (*UploadChunkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadChunkResponse)
+	err := c.cc.Invoke(ctx, FileService_UploadChunk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListUploadParts(ctx context.Context, in *ListUploadPartsRequest, opts ...grpc.CallOption) (*ListUploadPartsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUploadPartsResponse)
+	err := c.cc.Invoke(ctx, FileService_ListUploadParts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*AbortUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortUploadResponse)
+	err := c.cc.Invoke(ctx, FileService_AbortUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListShareFolder(ctx context.Context, in *ListShareFolderRequest, opts ...grpc.CallOption) (*ListShareFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShareFolderResponse)
+	err := c.cc.Invoke(ctx, FileService_ListShareFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListShareFiles(ctx context.Context, in *ListShareFilesRequest, opts ...grpc.CallOption) (*ListShareFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShareFilesResponse)
+	err := c.cc.Invoke(ctx, FileService_ListShareFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetShareFile(ctx context.Context, in *ShareFileRequest, opts ...grpc.CallOption) (*GetFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFileResponse)
+	err := c.cc.Invoke(ctx, FileService_GetShareFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) PreviewShareFile(ctx context.Context, in *ShareFileRequest, opts ...grpc.CallOption) (*PreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewResponse)
+	err := c.cc.Invoke(ctx, FileService_PreviewShareFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) DownloadShareFile(ctx context.Context, in *ShareFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[2], FileService_DownloadShareFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ShareFileRequest, DownloadStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_DownloadShareFileClient = grpc.ServerStreamingClient[DownloadStreamResponse]
+
+func (c *fileServiceClient) ListShares(ctx context.Context, in *ListSharesRequest, opts ...grpc.CallOption) (*ListSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharesResponse)
+	err := c.cc.Invoke(ctx, FileService_ListShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) RevokeShares(ctx context.Context, in *RevokeSharesRequest, opts ...grpc.CallOption) (*RevokeSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeSharesResponse)
+	err := c.cc.Invoke(ctx, FileService_RevokeShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) UpdateShare(ctx context.Context, in *UpdateShareRequest, opts ...grpc.CallOption) (*UpdateShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateShareResponse)
+	err := c.cc.Invoke(ctx, FileService_UpdateShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetShareAccessLog(ctx context.Context, in *GetShareAccessLogRequest, opts ...grpc.CallOption) (*GetShareAccessLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShareAccessLogResponse)
+	err := c.cc.Invoke(ctx, FileService_GetShareAccessLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ShareWithUser(ctx context.Context, in *ShareWithUserRequest, opts ...grpc.CallOption) (*ShareWithUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareWithUserResponse)
+	err := c.cc.Invoke(ctx, FileService_ShareWithUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) RevokeUserShare(ctx context.Context, in *RevokeUserShareRequest, opts ...grpc.CallOption) (*RevokeUserShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeUserShareResponse)
+	err := c.cc.Invoke(ctx, FileService_RevokeUserShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, FileService_ListCollaborators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListSharedWithMeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharedWithMeResponse)
+	err := c.cc.Invoke(ctx, FileService_ListSharedWithMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetTeamSpace(ctx context.Context, in *GetTeamSpaceRequest, opts ...grpc.CallOption) (*GetTeamSpaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTeamSpaceResponse)
+	err := c.cc.Invoke(ctx, FileService_GetTeamSpace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListSpaceActivity(ctx context.Context, in *ListSpaceActivityRequest, opts ...grpc.CallOption) (*ListSpaceActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSpaceActivityResponse)
+	err := c.cc.Invoke(ctx, FileService_ListSpaceActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CreateFileRequest(ctx context.Context, in *CreateFileRequestRequest, opts ...grpc.CallOption) (*CreateFileRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFileRequestResponse)
+	err := c.cc.Invoke(ctx, FileService_CreateFileRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListFileRequests(ctx context.Context, in *ListFileRequestsRequest, opts ...grpc.CallOption) (*ListFileRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFileRequestsResponse)
+	err := c.cc.Invoke(ctx, FileService_ListFileRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CloseFileRequests(ctx context.Context, in *CloseFileRequestsRequest, opts ...grpc.CallOption) (*CloseFileRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseFileRequestsResponse)
+	err := c.cc.Invoke(ctx, FileService_CloseFileRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListFileRequestUploads(ctx context.Context, in *ListFileRequestUploadsRequest, opts ...grpc.CallOption) (*ListFileRequestUploadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFileRequestUploadsResponse)
+	err := c.cc.Invoke(ctx, FileService_ListFileRequestUploads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetPublicFileRequest(ctx context.Context, in *GetPublicFileRequestRequest, opts ...grpc.CallOption) (*GetPublicFileRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicFileRequestResponse)
+	err := c.cc.Invoke(ctx, FileService_GetPublicFileRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) SubmitFileRequest(ctx context.Context, in *SubmitFileRequestRequest, opts ...grpc.CallOption) (*SubmitFileRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitFileRequestResponse)
+	err := c.cc.Invoke(ctx, FileService_SubmitFileRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CreateVault(ctx context.Context, in *CreateVaultRequest, opts ...grpc.CallOption) (*CreateVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVaultResponse)
+	err := c.cc.Invoke(ctx, FileService_CreateVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) SetPublicKey(ctx context.Context, in *SetPublicKeyRequest, opts ...grpc.CallOption) (*SetPublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, FileService_SetPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetPublicKeys(ctx context.Context, in *GetPublicKeysRequest, opts ...grpc.CallOption) (*GetPublicKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicKeysResponse)
+	err := c.cc.Invoke(ctx, FileService_GetPublicKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) PutVaultKeyEnvelope(ctx context.Context, in *PutVaultKeyEnvelopeRequest, opts ...grpc.CallOption) (*PutVaultKeyEnvelopeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutVaultKeyEnvelopeResponse)
+	err := c.cc.Invoke(ctx, FileService_PutVaultKeyEnvelope_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetVaultKeyEnvelope(ctx context.Context, in *GetVaultKeyEnvelopeRequest, opts ...grpc.CallOption) (*GetVaultKeyEnvelopeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVaultKeyEnvelopeResponse)
+	err := c.cc.Invoke(ctx, FileService_GetVaultKeyEnvelope_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) RevokeVaultKeyEnvelope(ctx context.Context, in *RevokeVaultKeyEnvelopeRequest, opts ...grpc.CallOption) (*RevokeVaultKeyEnvelopeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeVaultKeyEnvelopeResponse)
+	err := c.cc.Invoke(ctx, FileService_RevokeVaultKeyEnvelope_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, FileService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, FileService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*UpdateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWebhookResponse)
+	err := c.cc.Invoke(ctx, FileService_UpdateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, FileService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, FileService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*RedeliverWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeliverWebhookResponse)
+	err := c.cc.Invoke(ctx, FileService_RedeliverWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditLogsResponse)
+	err := c.cc.Invoke(ctx, FileService_ListAuditLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ExportAuditLogs(ctx context.Context, in *ExportAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAuditLogsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[3], FileService_ExportAuditLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAuditLogsRequest, ExportAuditLogsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_ExportAuditLogsClient = grpc.ServerStreamingClient[ExportAuditLogsResponse]
+
+func (c *fileServiceClient) ReconcileQuota(ctx context.Context, in *ReconcileQuotaRequest, opts ...grpc.CallOption) (*ReconcileQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileQuotaResponse)
+	err := c.cc.Invoke(ctx, FileService_ReconcileQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) SavePlan(ctx context.Context, in *SavePlanRequest, opts ...grpc.CallOption) (*SavePlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavePlanResponse)
+	err := c.cc.Invoke(ctx, FileService_SavePlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListPlans(ctx context.Context, in *ListPlansRequest, opts ...grpc.CallOption) (*ListPlansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPlansResponse)
+	err := c.cc.Invoke(ctx, FileService_ListPlans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) AssignPlan(ctx context.Context, in *AssignPlanRequest, opts ...grpc.CallOption) (*AssignPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignPlanResponse)
+	err := c.cc.Invoke(ctx, FileService_AssignPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GrantCapacity(ctx context.Context, in *GrantCapacityRequest, opts ...grpc.CallOption) (*GrantCapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantCapacityResponse)
+	err := c.cc.Invoke(ctx, FileService_GrantCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListUsersNearQuota(ctx context.Context, in *ListUsersNearQuotaRequest, opts ...grpc.CallOption) (*ListUsersNearQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersNearQuotaResponse)
+	err := c.cc.Invoke(ctx, FileService_ListUsersNearQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) CreateTeamSpace(ctx context.Context, in *CreateTeamSpaceRequest, opts ...grpc.CallOption) (*CreateTeamSpaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTeamSpaceResponse)
+	err := c.cc.Invoke(ctx, FileService_CreateTeamSpace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) SetSpaceMember(ctx context.Context, in *SetSpaceMemberRequest, opts ...grpc.CallOption) (*SetSpaceMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetSpaceMemberResponse)
+	err := c.cc.Invoke(ctx, FileService_SetSpaceMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ScrubStorage(ctx context.Context, in *ScrubStorageRequest, opts ...grpc.CallOption) (*ScrubStorageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScrubStorageResponse)
+	err := c.cc.Invoke(ctx, FileService_ScrubStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListScrubFindings(ctx context.Context, in *ListScrubFindingsRequest, opts ...grpc.CallOption) (*ListScrubFindingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScrubFindingsResponse)
+	err := c.cc.Invoke(ctx, FileService_ListScrubFindings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ReprocessFile(ctx context.Context, in *ReprocessFileRequest, opts ...grpc.CallOption) (*ReprocessFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReprocessFileResponse)
+	err := c.cc.Invoke(ctx, FileService_ReprocessFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, FileService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) RetryDeadLetters(ctx context.Context, in *RetryDeadLettersRequest, opts ...grpc.CallOption) (*RetryDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryDeadLettersResponse)
+	err := c.cc.Invoke(ctx, FileService_RetryDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ListQuarantinedFiles(ctx context.Context, in *ListQuarantinedFilesRequest, opts ...grpc.CallOption) (*ListQuarantinedFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuarantinedFilesResponse)
+	err := c.cc.Invoke(ctx, FileService_ListQuarantinedFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) ReleaseQuarantinedFile(ctx context.Context, in *ReleaseQuarantinedFileRequest, opts ...grpc.CallOption) (*ReleaseQuarantinedFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseQuarantinedFileResponse)
+	err := c.cc.Invoke(ctx, FileService_ReleaseQuarantinedFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) QueryAuditLogs(ctx context.Context, in *QueryAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditLogsResponse)
+	err := c.cc.Invoke(ctx, FileService_QueryAuditLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) DumpAuditLogs(ctx context.Context, in *DumpAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAuditLogsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[4], FileService_DumpAuditLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DumpAuditLogsRequest, ExportAuditLogsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_DumpAuditLogsClient = grpc.ServerStreamingClient[ExportAuditLogsResponse]
+
 // FileServiceServer is the server API for FileService service.
 // All implementations must embed UnimplementedFileServiceServer
 // for forward compatibility.
@@ -324,460 +1204,2002 @@ type FileServiceServer interface {
 	SaveToMyDrive(context.Context, *SaveToMyDriveRequest) (*SaveToMyDriveResponse, error)
 	GetUserFileStore(context.Context, *GetUserFileStoreRequest) (*GetUserFileStoreResponse, error)
 	UpdateFile(context.Context, *UpdateFileRequest) (*UpdateFileResponse, error)
+	GetFileMeta(context.Context, *GetFileMetaRequest) (*GetFileMetaResponse, error)
+	SetFileMeta(context.Context, *SetFileMetaRequest) (*SetFileMetaResponse, error)
+	DeleteFileMeta(context.Context, *DeleteFileMetaRequest) (*DeleteFileMetaResponse, error)
+	CopyFile(context.Context, *CopyFileRequest) (*CopyFileResponse, error)
+	CopyFolder(context.Context, *CopyFolderRequest) (*CopyFolderResponse, error)
+	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
+	BatchMove(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error)
+	BatchCopy(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error)
+	BatchDelete(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error)
+	BatchRestore(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error)
+	BatchRename(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error)
+	ResolvePath(context.Context, *ResolvePathRequest) (*ResolvePathResponse, error)
+	ListPath(context.Context, *ListPathRequest) (*ListFolderResponse, error)
+	DeletePath(context.Context, *DeletePathRequest) (*DeletePathResponse, error)
+	EnsureFolderPath(context.Context, *EnsureFolderPathRequest) (*EnsureFolderPathResponse, error)
+	GetFolderTree(context.Context, *GetFolderTreeRequest) (*GetFolderTreeResponse, error)
+	UploadChunk(context.Context, *UploadChunkRequest) (*UploadChunkResponse, error)
+	ListUploadParts(context.Context, *ListUploadPartsRequest) (*ListUploadPartsResponse, error)
+	AbortUpload(context.Context, *AbortUploadRequest) (*AbortUploadResponse, error)
+	ListShareFolder(context.Context, *ListShareFolderRequest) (*ListShareFolderResponse, error)
+	ListShareFiles(context.Context, *ListShareFilesRequest) (*ListShareFilesResponse, error)
+	GetShareFile(context.Context, *ShareFileRequest) (*GetFileResponse, error)
+	PreviewShareFile(context.Context, *ShareFileRequest) (*PreviewResponse, error)
+	DownloadShareFile(*ShareFileRequest, grpc.ServerStreamingServer[DownloadStreamResponse]) error
+	ListShares(context.Context, *ListSharesRequest) (*ListSharesResponse, error)
+	RevokeShares(context.Context, *RevokeSharesRequest) (*RevokeSharesResponse, error)
+	UpdateShare(context.Context, *UpdateShareRequest) (*UpdateShareResponse, error)
+	GetShareAccessLog(context.Context, *GetShareAccessLogRequest) (*GetShareAccessLogResponse, error)
+	ShareWithUser(context.Context, *ShareWithUserRequest) (*ShareWithUserResponse, error)
+	RevokeUserShare(context.Context, *RevokeUserShareRequest) (*RevokeUserShareResponse, error)
+	ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error)
+	ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error)
+	GetTeamSpace(context.Context, *GetTeamSpaceRequest) (*GetTeamSpaceResponse, error)
+	ListSpaceActivity(context.Context, *ListSpaceActivityRequest) (*ListSpaceActivityResponse, error)
+	CreateFileRequest(context.Context, *CreateFileRequestRequest) (*CreateFileRequestResponse, error)
+	ListFileRequests(context.Context, *ListFileRequestsRequest) (*ListFileRequestsResponse, error)
+	CloseFileRequests(context.Context, *CloseFileRequestsRequest) (*CloseFileRequestsResponse, error)
+	ListFileRequestUploads(context.Context, *ListFileRequestUploadsRequest) (*ListFileRequestUploadsResponse, error)
+	GetPublicFileRequest(context.Context, *GetPublicFileRequestRequest) (*GetPublicFileRequestResponse, error)
+	SubmitFileRequest(context.Context, *SubmitFileRequestRequest) (*SubmitFileRequestResponse, error)
+	CreateVault(context.Context, *CreateVaultRequest) (*CreateVaultResponse, error)
+	SetPublicKey(context.Context, *SetPublicKeyRequest) (*SetPublicKeyResponse, error)
+	GetPublicKeys(context.Context, *GetPublicKeysRequest) (*GetPublicKeysResponse, error)
+	PutVaultKeyEnvelope(context.Context, *PutVaultKeyEnvelopeRequest) (*PutVaultKeyEnvelopeResponse, error)
+	GetVaultKeyEnvelope(context.Context, *GetVaultKeyEnvelopeRequest) (*GetVaultKeyEnvelopeResponse, error)
+	RevokeVaultKeyEnvelope(context.Context, *RevokeVaultKeyEnvelopeRequest) (*RevokeVaultKeyEnvelopeResponse, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*UpdateWebhookResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*RedeliverWebhookResponse, error)
+	ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error)
+	ExportAuditLogs(*ExportAuditLogsRequest, grpc.ServerStreamingServer[ExportAuditLogsResponse]) error
+	// 以下为管理接口, 不经网关暴露; 除 CreateTeamSpace、SetSpaceMember 由用户服务调用外, 须在元数据 x-admin-token 中携带管理令牌
+	ReconcileQuota(context.Context, *ReconcileQuotaRequest) (*ReconcileQuotaResponse, error)
+	SavePlan(context.Context, *SavePlanRequest) (*SavePlanResponse, error)
+	ListPlans(context.Context, *ListPlansRequest) (*ListPlansResponse, error)
+	AssignPlan(context.Context, *AssignPlanRequest) (*AssignPlanResponse, error)
+	GrantCapacity(context.Context, *GrantCapacityRequest) (*GrantCapacityResponse, error)
+	ListUsersNearQuota(context.Context, *ListUsersNearQuotaRequest) (*ListUsersNearQuotaResponse, error)
+	CreateTeamSpace(context.Context, *CreateTeamSpaceRequest) (*CreateTeamSpaceResponse, error)
+	SetSpaceMember(context.Context, *SetSpaceMemberRequest) (*SetSpaceMemberResponse, error)
+	ScrubStorage(context.Context, *ScrubStorageRequest) (*ScrubStorageResponse, error)
+	ListScrubFindings(context.Context, *ListScrubFindingsRequest) (*ListScrubFindingsResponse, error)
+	ReprocessFile(context.Context, *ReprocessFileRequest) (*ReprocessFileResponse, error)
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	RetryDeadLetters(context.Context, *RetryDeadLettersRequest) (*RetryDeadLettersResponse, error)
+	ListQuarantinedFiles(context.Context, *ListQuarantinedFilesRequest) (*ListQuarantinedFilesResponse, error)
+	ReleaseQuarantinedFile(context.Context, *ReleaseQuarantinedFileRequest) (*ReleaseQuarantinedFileResponse, error)
+	QueryAuditLogs(context.Context, *QueryAuditLogsRequest) (*ListAuditLogsResponse, error)
+	DumpAuditLogs(*DumpAuditLogsRequest, grpc.ServerStreamingServer[ExportAuditLogsResponse]) error
+	mustEmbedUnimplementedFileServiceServer()
+}
+
+// UnimplementedFileServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFileServiceServer struct{}
+
+func (UnimplementedFileServiceServer) Upload(context.Context, *UploadRequest) (*UploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Upload not implemented")
+}
+func (UnimplementedFileServiceServer) CreateFileStore(context.Context, *CreateFileStoreRequest) (*CreateFileStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFileStore not implemented")
+}
+func (UnimplementedFileServiceServer) CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedFileServiceServer) ListFolder(context.Context, *ListFolderRequest) (*ListFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFolder not implemented")
+}
+func (UnimplementedFileServiceServer) GetFile(context.Context, *GetFileRequest) (*GetFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFile not implemented")
+}
+func (UnimplementedFileServiceServer) Download(context.Context, *DownloadRequest) (*DownloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Download not implemented")
+}
+func (UnimplementedFileServiceServer) DownloadStream(*DownloadRequest, grpc.ServerStreamingServer[DownloadStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadStream not implemented")
+}
+func (UnimplementedFileServiceServer) MoveFolder(context.Context, *MoveFolderRequest) (*MoveFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveFolder not implemented")
+}
+func (UnimplementedFileServiceServer) MoveFile(context.Context, *MoveFileRequest) (*MoveFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveFile not implemented")
+}
+func (UnimplementedFileServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (UnimplementedFileServiceServer) DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFolder not implemented")
+}
+func (UnimplementedFileServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedFileServiceServer) Preview(context.Context, *PreviewRequest) (*PreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Preview not implemented")
+}
+func (UnimplementedFileServiceServer) DownloadTask(context.Context, *DownloadTaskRequest) (*DownloadTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DownloadTask not implemented")
+}
+func (UnimplementedFileServiceServer) GetDownloadTask(context.Context, *GetDownloadTaskRequest) (*GetDownloadTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDownloadTask not implemented")
+}
+func (UnimplementedFileServiceServer) ResumeDownload(context.Context, *ResumeDownloadRequest) (*ResumeDownloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeDownload not implemented")
+}
+func (UnimplementedFileServiceServer) UploadChunkStream(grpc.ClientStreamingServer[UploadChunkRequest, UploadChunkResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadChunkStream not implemented")
+}
+func (UnimplementedFileServiceServer) CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShareLink not implemented")
+}
+func (UnimplementedFileServiceServer) SaveToMyDrive(context.Context, *SaveToMyDriveRequest) (*SaveToMyDriveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveToMyDrive not implemented")
+}
+func (UnimplementedFileServiceServer) GetUserFileStore(context.Context, *GetUserFileStoreRequest) (*GetUserFileStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserFileStore not implemented")
+}
+func (UnimplementedFileServiceServer) UpdateFile(context.Context, *UpdateFileRequest) (*UpdateFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFile not implemented")
+}
+func (UnimplementedFileServiceServer) GetFileMeta(context.Context, *GetFileMetaRequest) (*GetFileMetaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileMeta not implemented")
+}
+func (UnimplementedFileServiceServer) SetFileMeta(context.Context, *SetFileMetaRequest) (*SetFileMetaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFileMeta not implemented")
+}
+func (UnimplementedFileServiceServer) DeleteFileMeta(context.Context, *DeleteFileMetaRequest) (*DeleteFileMetaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFileMeta not implemented")
+}
+func (UnimplementedFileServiceServer) CopyFile(context.Context, *CopyFileRequest) (*CopyFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyFile not implemented")
+}
+func (UnimplementedFileServiceServer) CopyFolder(context.Context, *CopyFolderRequest) (*CopyFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyFolder not implemented")
+}
+func (UnimplementedFileServiceServer) GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedFileServiceServer) BatchMove(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchMove not implemented")
+}
+func (UnimplementedFileServiceServer) BatchCopy(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCopy not implemented")
+}
+func (UnimplementedFileServiceServer) BatchDelete(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDelete not implemented")
+}
+func (UnimplementedFileServiceServer) BatchRestore(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRestore not implemented")
+}
+func (UnimplementedFileServiceServer) BatchRename(context.Context, *BatchOperationRequest) (*BatchOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRename not implemented")
+}
+func (UnimplementedFileServiceServer) ResolvePath(context.Context, *ResolvePathRequest) (*ResolvePathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolvePath not implemented")
+}
+func (UnimplementedFileServiceServer) ListPath(context.Context, *ListPathRequest) (*ListFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPath not implemented")
+}
+func (UnimplementedFileServiceServer) DeletePath(context.Context, *DeletePathRequest) (*DeletePathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePath not implemented")
+}
+func (UnimplementedFileServiceServer) EnsureFolderPath(context.Context, *EnsureFolderPathRequest) (*EnsureFolderPathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnsureFolderPath not implemented")
+}
+func (UnimplementedFileServiceServer) GetFolderTree(context.Context, *GetFolderTreeRequest) (*GetFolderTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFolderTree not implemented")
+}
+func (UnimplementedFileServiceServer) UploadChunk(context.Context, *UploadChunkRequest) (*UploadChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadChunk not implemented")
+}
+func (UnimplementedFileServiceServer) ListUploadParts(context.Context, *ListUploadPartsRequest) (*ListUploadPartsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUploadParts not implemented")
+}
+func (UnimplementedFileServiceServer) AbortUpload(context.Context, *AbortUploadRequest) (*AbortUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortUpload not implemented")
+}
+func (UnimplementedFileServiceServer) ListShareFolder(context.Context, *ListShareFolderRequest) (*ListShareFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShareFolder not implemented")
+}
+func (UnimplementedFileServiceServer) ListShareFiles(context.Context, *ListShareFilesRequest) (*ListShareFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShareFiles not implemented")
+}
+func (UnimplementedFileServiceServer) GetShareFile(context.Context, *ShareFileRequest) (*GetFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShareFile not implemented")
+}
+func (UnimplementedFileServiceServer) PreviewShareFile(context.Context, *ShareFileRequest) (*PreviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewShareFile not implemented")
+}
+func (UnimplementedFileServiceServer) DownloadShareFile(*ShareFileRequest, grpc.ServerStreamingServer[DownloadStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadShareFile not implemented")
+}
+func (UnimplementedFileServiceServer) ListShares(context.Context, *ListSharesRequest) (*ListSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShares not implemented")
+}
+func (UnimplementedFileServiceServer) RevokeShares(context.Context, *RevokeSharesRequest) (*RevokeSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShares not implemented")
+}
+func (UnimplementedFileServiceServer) UpdateShare(context.Context, *UpdateShareRequest) (*UpdateShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShare not implemented")
+}
+func (UnimplementedFileServiceServer) GetShareAccessLog(context.Context, *GetShareAccessLogRequest) (*GetShareAccessLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShareAccessLog not implemented")
+}
+func (UnimplementedFileServiceServer) ShareWithUser(context.Context, *ShareWithUserRequest) (*ShareWithUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareWithUser not implemented")
+}
+func (UnimplementedFileServiceServer) RevokeUserShare(context.Context, *RevokeUserShareRequest) (*RevokeUserShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserShare not implemented")
+}
+func (UnimplementedFileServiceServer) ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollaborators not implemented")
+}
+func (UnimplementedFileServiceServer) ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListSharedWithMeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedWithMe not implemented")
+}
+func (UnimplementedFileServiceServer) GetTeamSpace(context.Context, *GetTeamSpaceRequest) (*GetTeamSpaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamSpace not implemented")
+}
+func (UnimplementedFileServiceServer) ListSpaceActivity(context.Context, *ListSpaceActivityRequest) (*ListSpaceActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSpaceActivity not implemented")
+}
+func (UnimplementedFileServiceServer) CreateFileRequest(context.Context, *CreateFileRequestRequest) (*CreateFileRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFileRequest not implemented")
+}
+func (UnimplementedFileServiceServer) ListFileRequests(context.Context, *ListFileRequestsRequest) (*ListFileRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFileRequests not implemented")
+}
+func (UnimplementedFileServiceServer) CloseFileRequests(context.Context, *CloseFileRequestsRequest) (*CloseFileRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseFileRequests not implemented")
+}
+func (UnimplementedFileServiceServer) ListFileRequestUploads(context.Context, *ListFileRequestUploadsRequest) (*ListFileRequestUploadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFileRequestUploads not implemented")
+}
+func (UnimplementedFileServiceServer) GetPublicFileRequest(context.Context, *GetPublicFileRequestRequest) (*GetPublicFileRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicFileRequest not implemented")
+}
+func (UnimplementedFileServiceServer) SubmitFileRequest(context.Context, *SubmitFileRequestRequest) (*SubmitFileRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitFileRequest not implemented")
+}
+func (UnimplementedFileServiceServer) CreateVault(context.Context, *CreateVaultRequest) (*CreateVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVault not implemented")
+}
+func (UnimplementedFileServiceServer) SetPublicKey(context.Context, *SetPublicKeyRequest) (*SetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPublicKey not implemented")
+}
+func (UnimplementedFileServiceServer) GetPublicKeys(context.Context, *GetPublicKeysRequest) (*GetPublicKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKeys not implemented")
+}
+func (UnimplementedFileServiceServer) PutVaultKeyEnvelope(context.Context, *PutVaultKeyEnvelopeRequest) (*PutVaultKeyEnvelopeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutVaultKeyEnvelope not implemented")
+}
+func (UnimplementedFileServiceServer) GetVaultKeyEnvelope(context.Context, *GetVaultKeyEnvelopeRequest) (*GetVaultKeyEnvelopeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVaultKeyEnvelope not implemented")
+}
+func (UnimplementedFileServiceServer) RevokeVaultKeyEnvelope(context.Context, *RevokeVaultKeyEnvelopeRequest) (*RevokeVaultKeyEnvelopeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeVaultKeyEnvelope not implemented")
+}
+func (UnimplementedFileServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedFileServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedFileServiceServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*UpdateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedFileServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedFileServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedFileServiceServer) RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*RedeliverWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
+func (UnimplementedFileServiceServer) ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditLogs not implemented")
+}
+func (UnimplementedFileServiceServer) ExportAuditLogs(*ExportAuditLogsRequest, grpc.ServerStreamingServer[ExportAuditLogsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditLogs not implemented")
+}
+func (UnimplementedFileServiceServer) ReconcileQuota(context.Context, *ReconcileQuotaRequest) (*ReconcileQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileQuota not implemented")
+}
+func (UnimplementedFileServiceServer) SavePlan(context.Context, *SavePlanRequest) (*SavePlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SavePlan not implemented")
+}
+func (UnimplementedFileServiceServer) ListPlans(context.Context, *ListPlansRequest) (*ListPlansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlans not implemented")
+}
+func (UnimplementedFileServiceServer) AssignPlan(context.Context, *AssignPlanRequest) (*AssignPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignPlan not implemented")
+}
+func (UnimplementedFileServiceServer) GrantCapacity(context.Context, *GrantCapacityRequest) (*GrantCapacityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantCapacity not implemented")
+}
+func (UnimplementedFileServiceServer) ListUsersNearQuota(context.Context, *ListUsersNearQuotaRequest) (*ListUsersNearQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsersNearQuota not implemented")
+}
+func (UnimplementedFileServiceServer) CreateTeamSpace(context.Context, *CreateTeamSpaceRequest) (*CreateTeamSpaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTeamSpace not implemented")
+}
+func (UnimplementedFileServiceServer) SetSpaceMember(context.Context, *SetSpaceMemberRequest) (*SetSpaceMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSpaceMember not implemented")
+}
+func (UnimplementedFileServiceServer) ScrubStorage(context.Context, *ScrubStorageRequest) (*ScrubStorageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScrubStorage not implemented")
+}
+func (UnimplementedFileServiceServer) ListScrubFindings(context.Context, *ListScrubFindingsRequest) (*ListScrubFindingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScrubFindings not implemented")
+}
+func (UnimplementedFileServiceServer) ReprocessFile(context.Context, *ReprocessFileRequest) (*ReprocessFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReprocessFile not implemented")
+}
+func (UnimplementedFileServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedFileServiceServer) RetryDeadLetters(context.Context, *RetryDeadLettersRequest) (*RetryDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryDeadLetters not implemented")
+}
+func (UnimplementedFileServiceServer) ListQuarantinedFiles(context.Context, *ListQuarantinedFilesRequest) (*ListQuarantinedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQuarantinedFiles not implemented")
+}
+func (UnimplementedFileServiceServer) ReleaseQuarantinedFile(context.Context, *ReleaseQuarantinedFileRequest) (*ReleaseQuarantinedFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseQuarantinedFile not implemented")
+}
+func (UnimplementedFileServiceServer) QueryAuditLogs(context.Context, *QueryAuditLogsRequest) (*ListAuditLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLogs not implemented")
+}
+func (UnimplementedFileServiceServer) DumpAuditLogs(*DumpAuditLogsRequest, grpc.ServerStreamingServer[ExportAuditLogsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DumpAuditLogs not implemented")
+}
+func (UnimplementedFileServiceServer) mustEmbedUnimplementedFileServiceServer() {}
+func (UnimplementedFileServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeFileServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FileServiceServer will
+// result in compilation errors.
+type UnsafeFileServiceServer interface {
 	mustEmbedUnimplementedFileServiceServer()
 }
 
-// UnimplementedFileServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedFileServiceServer struct{}
+func RegisterFileServiceServer(s grpc.ServiceRegistrar, srv FileServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFileServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FileService_ServiceDesc, srv)
+}
+
+func _FileService_Upload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).Upload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_Upload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).Upload(ctx, req.(*UploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CreateFileStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFileStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateFileStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateFileStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateFileStore(ctx, req.(*CreateFileStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateFolder(ctx, req.(*CreateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ListFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListFolder(ctx, req.(*ListFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetFile(ctx, req.(*GetFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_Download_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).Download(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_Download_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).Download(ctx, req.(*DownloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DownloadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).DownloadStream(m, &grpc.GenericServerStream[DownloadRequest, DownloadStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_DownloadStreamServer = grpc.ServerStreamingServer[DownloadStreamResponse]
+
+func _FileService_MoveFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).MoveFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_MoveFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).MoveFolder(ctx, req.(*MoveFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_MoveFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).MoveFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_MoveFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).MoveFile(ctx, req.(*MoveFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DeleteFile(ctx, req.(*DeleteFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).DeleteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DeleteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DeleteFolder(ctx, req.(*DeleteFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_Preview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).Preview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_Preview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).Preview(ctx, req.(*PreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DownloadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).DownloadTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DownloadTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DownloadTask(ctx, req.(*DownloadTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetDownloadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDownloadTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetDownloadTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetDownloadTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetDownloadTask(ctx, req.(*GetDownloadTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ResumeDownload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeDownloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ResumeDownload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ResumeDownload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ResumeDownload(ctx, req.(*ResumeDownloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_UploadChunkStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileServiceServer).UploadChunkStream(&grpc.GenericServerStream[UploadChunkRequest, UploadChunkResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_UploadChunkStreamServer = grpc.ClientStreamingServer[UploadChunkRequest, UploadChunkResponse]
+
+func _FileService_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateShareLink(ctx, req.(*CreateShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_SaveToMyDrive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveToMyDriveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).SaveToMyDrive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_SaveToMyDrive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).SaveToMyDrive(ctx, req.(*SaveToMyDriveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetUserFileStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserFileStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetUserFileStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetUserFileStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetUserFileStore(ctx, req.(*GetUserFileStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_UpdateFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).UpdateFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_UpdateFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).UpdateFile(ctx, req.(*UpdateFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetFileMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetFileMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetFileMeta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetFileMeta(ctx, req.(*GetFileMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_SetFileMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFileMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).SetFileMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_SetFileMeta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).SetFileMeta(ctx, req.(*SetFileMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DeleteFileMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFileMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).DeleteFileMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DeleteFileMeta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DeleteFileMeta(ctx, req.(*DeleteFileMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CopyFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CopyFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CopyFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CopyFile(ctx, req.(*CopyFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_CopyFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CopyFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CopyFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CopyFolder(ctx, req.(*CopyFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_BatchMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).BatchMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_BatchMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).BatchMove(ctx, req.(*BatchOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_BatchCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).BatchCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_BatchCopy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).BatchCopy(ctx, req.(*BatchOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).BatchDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_BatchDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).BatchDelete(ctx, req.(*BatchOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_BatchRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).BatchRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_BatchRestore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).BatchRestore(ctx, req.(*BatchOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_BatchRename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).BatchRename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_BatchRename_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).BatchRename(ctx, req.(*BatchOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ResolvePath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolvePathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ResolvePath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ResolvePath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ResolvePath(ctx, req.(*ResolvePathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ListPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListPath(ctx, req.(*ListPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DeletePath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).DeletePath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DeletePath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DeletePath(ctx, req.(*DeletePathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_EnsureFolderPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnsureFolderPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).EnsureFolderPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_EnsureFolderPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).EnsureFolderPath(ctx, req.(*EnsureFolderPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetFolderTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFolderTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetFolderTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetFolderTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetFolderTree(ctx, req.(*GetFolderTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedFileServiceServer) Upload(context.Context, *UploadRequest) (*UploadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Upload not implemented")
+func _FileService_UploadChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).UploadChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_UploadChunk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).UploadChunk(ctx, req.(*UploadChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) CreateFileStore(context.Context, *CreateFileStoreRequest) (*CreateFileStoreResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateFileStore not implemented")
+
+func _FileService_ListUploadParts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUploadPartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListUploadParts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListUploadParts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListUploadParts(ctx, req.(*ListUploadPartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+
+func _FileService_AbortUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).AbortUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_AbortUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).AbortUpload(ctx, req.(*AbortUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) ListFolder(context.Context, *ListFolderRequest) (*ListFolderResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListFolder not implemented")
+
+func _FileService_ListShareFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShareFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListShareFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListShareFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListShareFolder(ctx, req.(*ListShareFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) GetFile(context.Context, *GetFileRequest) (*GetFileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetFile not implemented")
+
+func _FileService_ListShareFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShareFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListShareFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListShareFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListShareFiles(ctx, req.(*ListShareFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) Download(context.Context, *DownloadRequest) (*DownloadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Download not implemented")
+
+func _FileService_GetShareFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetShareFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetShareFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetShareFile(ctx, req.(*ShareFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) DownloadStream(*DownloadRequest, grpc.ServerStreamingServer[DownloadStreamResponse]) error {
-	return status.Errorf(codes.Unimplemented, "method DownloadStream not implemented")
+
+func _FileService_PreviewShareFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).PreviewShareFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_PreviewShareFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).PreviewShareFile(ctx, req.(*ShareFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_DownloadShareFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ShareFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).DownloadShareFile(m, &grpc.GenericServerStream[ShareFileRequest, DownloadStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_DownloadShareFileServer = grpc.ServerStreamingServer[DownloadStreamResponse]
+
+func _FileService_ListShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListShares(ctx, req.(*ListSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_RevokeShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).RevokeShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_RevokeShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).RevokeShares(ctx, req.(*RevokeSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_UpdateShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).UpdateShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_UpdateShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).UpdateShare(ctx, req.(*UpdateShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetShareAccessLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShareAccessLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetShareAccessLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetShareAccessLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetShareAccessLog(ctx, req.(*GetShareAccessLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ShareWithUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareWithUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ShareWithUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ShareWithUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ShareWithUser(ctx, req.(*ShareWithUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_RevokeUserShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUserShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).RevokeUserShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_RevokeUserShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).RevokeUserShare(ctx, req.(*RevokeUserShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ListCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListCollaborators(ctx, req.(*ListCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ListSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedWithMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListSharedWithMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListSharedWithMe(ctx, req.(*ListSharedWithMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetTeamSpace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamSpaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetTeamSpace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetTeamSpace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetTeamSpace(ctx, req.(*GetTeamSpaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ListSpaceActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSpaceActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListSpaceActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListSpaceActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListSpaceActivity(ctx, req.(*ListSpaceActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) MoveFolder(context.Context, *MoveFolderRequest) (*MoveFolderResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MoveFolder not implemented")
+
+func _FileService_CreateFileRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFileRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateFileRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateFileRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateFileRequest(ctx, req.(*CreateFileRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) MoveFile(context.Context, *MoveFileRequest) (*MoveFileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MoveFile not implemented")
+
+func _FileService_ListFileRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFileRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListFileRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListFileRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListFileRequests(ctx, req.(*ListFileRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+
+func _FileService_CloseFileRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseFileRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CloseFileRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CloseFileRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CloseFileRequests(ctx, req.(*CloseFileRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteFolder not implemented")
+
+func _FileService_ListFileRequestUploads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFileRequestUploadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListFileRequestUploads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListFileRequestUploads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListFileRequestUploads(ctx, req.(*ListFileRequestUploadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+
+func _FileService_GetPublicFileRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicFileRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetPublicFileRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetPublicFileRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetPublicFileRequest(ctx, req.(*GetPublicFileRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) Preview(context.Context, *PreviewRequest) (*PreviewResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Preview not implemented")
+
+func _FileService_SubmitFileRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitFileRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).SubmitFileRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_SubmitFileRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).SubmitFileRequest(ctx, req.(*SubmitFileRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) DownloadTask(context.Context, *DownloadTaskRequest) (*DownloadTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DownloadTask not implemented")
+
+func _FileService_CreateVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateVault(ctx, req.(*CreateVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) GetDownloadTask(context.Context, *GetDownloadTaskRequest) (*GetDownloadTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDownloadTask not implemented")
+
+func _FileService_SetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).SetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_SetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).SetPublicKey(ctx, req.(*SetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) ResumeDownload(context.Context, *ResumeDownloadRequest) (*ResumeDownloadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ResumeDownload not implemented")
+
+func _FileService_GetPublicKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetPublicKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetPublicKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetPublicKeys(ctx, req.(*GetPublicKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) UploadChunkStream(grpc.ClientStreamingServer[UploadChunkRequest, UploadChunkResponse]) error {
-	return status.Errorf(codes.Unimplemented, "method UploadChunkStream not implemented")
+
+func _FileService_PutVaultKeyEnvelope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutVaultKeyEnvelopeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).PutVaultKeyEnvelope(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_PutVaultKeyEnvelope_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).PutVaultKeyEnvelope(ctx, req.(*PutVaultKeyEnvelopeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) CreateShareLink(context.Context, *CreateShareLinkRequest) (*CreateShareLinkResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateShareLink not implemented")
+
+func _FileService_GetVaultKeyEnvelope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVaultKeyEnvelopeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetVaultKeyEnvelope(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetVaultKeyEnvelope_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetVaultKeyEnvelope(ctx, req.(*GetVaultKeyEnvelopeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) SaveToMyDrive(context.Context, *SaveToMyDriveRequest) (*SaveToMyDriveResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SaveToMyDrive not implemented")
+
+func _FileService_RevokeVaultKeyEnvelope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeVaultKeyEnvelopeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).RevokeVaultKeyEnvelope(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_RevokeVaultKeyEnvelope_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).RevokeVaultKeyEnvelope(ctx, req.(*RevokeVaultKeyEnvelopeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) GetUserFileStore(context.Context, *GetUserFileStoreRequest) (*GetUserFileStoreResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserFileStore not implemented")
+
+func _FileService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) UpdateFile(context.Context, *UpdateFileRequest) (*UpdateFileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateFile not implemented")
+
+func _FileService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedFileServiceServer) mustEmbedUnimplementedFileServiceServer() {}
-func (UnimplementedFileServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeFileServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to FileServiceServer will
-// result in compilation errors.
-type UnsafeFileServiceServer interface {
-	mustEmbedUnimplementedFileServiceServer()
+func _FileService_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_UpdateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterFileServiceServer(s grpc.ServiceRegistrar, srv FileServiceServer) {
-	// If the following call pancis, it indicates UnimplementedFileServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _FileService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&FileService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(FileServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_Upload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UploadRequest)
+func _FileService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).Upload(ctx, in)
+		return srv.(FileServiceServer).ListWebhookDeliveries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_Upload_FullMethodName,
+		FullMethod: FileService_ListWebhookDeliveries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).Upload(ctx, req.(*UploadRequest))
+		return srv.(FileServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_CreateFileStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateFileStoreRequest)
+func _FileService_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).CreateFileStore(ctx, in)
+		return srv.(FileServiceServer).RedeliverWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_CreateFileStore_FullMethodName,
+		FullMethod: FileService_RedeliverWebhook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).CreateFileStore(ctx, req.(*CreateFileStoreRequest))
+		return srv.(FileServiceServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateFolderRequest)
+func _FileService_ListAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).CreateFolder(ctx, in)
+		return srv.(FileServiceServer).ListAuditLogs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_CreateFolder_FullMethodName,
+		FullMethod: FileService_ListAuditLogs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).CreateFolder(ctx, req.(*CreateFolderRequest))
+		return srv.(FileServiceServer).ListAuditLogs(ctx, req.(*ListAuditLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_ExportAuditLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAuditLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(FileServiceServer).ExportAuditLogs(m, &grpc.GenericServerStream[ExportAuditLogsRequest, ExportAuditLogsResponse]{ServerStream: stream})
 }
 
-func _FileService_ListFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListFolderRequest)
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_ExportAuditLogsServer = grpc.ServerStreamingServer[ExportAuditLogsResponse]
+
+func _FileService_ReconcileQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileQuotaRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).ListFolder(ctx, in)
+		return srv.(FileServiceServer).ReconcileQuota(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_ListFolder_FullMethodName,
+		FullMethod: FileService_ReconcileQuota_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).ListFolder(ctx, req.(*ListFolderRequest))
+		return srv.(FileServiceServer).ReconcileQuota(ctx, req.(*ReconcileQuotaRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_GetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFileRequest)
+func _FileService_SavePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavePlanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).GetFile(ctx, in)
+		return srv.(FileServiceServer).SavePlan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_GetFile_FullMethodName,
+		FullMethod: FileService_SavePlan_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).GetFile(ctx, req.(*GetFileRequest))
+		return srv.(FileServiceServer).SavePlan(ctx, req.(*SavePlanRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_Download_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DownloadRequest)
+func _FileService_ListPlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlansRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).Download(ctx, in)
+		return srv.(FileServiceServer).ListPlans(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_Download_FullMethodName,
+		FullMethod: FileService_ListPlans_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).Download(ctx, req.(*DownloadRequest))
+		return srv.(FileServiceServer).ListPlans(ctx, req.(*ListPlansRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_DownloadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(DownloadRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(FileServiceServer).DownloadStream(m, &grpc.GenericServerStream[DownloadRequest, DownloadStreamResponse]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type FileService_DownloadStreamServer = grpc.ServerStreamingServer[DownloadStreamResponse]
-
-func _FileService_MoveFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MoveFolderRequest)
+func _FileService_AssignPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignPlanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).MoveFolder(ctx, in)
+		return srv.(FileServiceServer).AssignPlan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_MoveFolder_FullMethodName,
+		FullMethod: FileService_AssignPlan_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).MoveFolder(ctx, req.(*MoveFolderRequest))
+		return srv.(FileServiceServer).AssignPlan(ctx, req.(*AssignPlanRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_MoveFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MoveFileRequest)
+func _FileService_GrantCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantCapacityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).MoveFile(ctx, in)
+		return srv.(FileServiceServer).GrantCapacity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_MoveFile_FullMethodName,
+		FullMethod: FileService_GrantCapacity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).MoveFile(ctx, req.(*MoveFileRequest))
+		return srv.(FileServiceServer).GrantCapacity(ctx, req.(*GrantCapacityRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteFileRequest)
+func _FileService_ListUsersNearQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersNearQuotaRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).DeleteFile(ctx, in)
+		return srv.(FileServiceServer).ListUsersNearQuota(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_DeleteFile_FullMethodName,
+		FullMethod: FileService_ListUsersNearQuota_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).DeleteFile(ctx, req.(*DeleteFileRequest))
+		return srv.(FileServiceServer).ListUsersNearQuota(ctx, req.(*ListUsersNearQuotaRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteFolderRequest)
+func _FileService_CreateTeamSpace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTeamSpaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).DeleteFolder(ctx, in)
+		return srv.(FileServiceServer).CreateTeamSpace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_DeleteFolder_FullMethodName,
+		FullMethod: FileService_CreateTeamSpace_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).DeleteFolder(ctx, req.(*DeleteFolderRequest))
+		return srv.(FileServiceServer).CreateTeamSpace(ctx, req.(*CreateTeamSpaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchRequest)
+func _FileService_SetSpaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSpaceMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).Search(ctx, in)
+		return srv.(FileServiceServer).SetSpaceMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_Search_FullMethodName,
+		FullMethod: FileService_SetSpaceMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).Search(ctx, req.(*SearchRequest))
+		return srv.(FileServiceServer).SetSpaceMember(ctx, req.(*SetSpaceMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_Preview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PreviewRequest)
+func _FileService_ScrubStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScrubStorageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).Preview(ctx, in)
+		return srv.(FileServiceServer).ScrubStorage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_Preview_FullMethodName,
+		FullMethod: FileService_ScrubStorage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).Preview(ctx, req.(*PreviewRequest))
+		return srv.(FileServiceServer).ScrubStorage(ctx, req.(*ScrubStorageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_DownloadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DownloadTaskRequest)
+func _FileService_ListScrubFindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScrubFindingsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).DownloadTask(ctx, in)
+		return srv.(FileServiceServer).ListScrubFindings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_DownloadTask_FullMethodName,
+		FullMethod: FileService_ListScrubFindings_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).DownloadTask(ctx, req.(*DownloadTaskRequest))
+		return srv.(FileServiceServer).ListScrubFindings(ctx, req.(*ListScrubFindingsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_GetDownloadTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDownloadTaskRequest)
+func _FileService_ReprocessFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReprocessFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).GetDownloadTask(ctx, in)
+		return srv.(FileServiceServer).ReprocessFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_GetDownloadTask_FullMethodName,
+		FullMethod: FileService_ReprocessFile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).GetDownloadTask(ctx, req.(*GetDownloadTaskRequest))
+		return srv.(FileServiceServer).ReprocessFile(ctx, req.(*ReprocessFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_ResumeDownload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ResumeDownloadRequest)
+func _FileService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).ResumeDownload(ctx, in)
+		return srv.(FileServiceServer).ListDeadLetters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_ResumeDownload_FullMethodName,
+		FullMethod: FileService_ListDeadLetters_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).ResumeDownload(ctx, req.(*ResumeDownloadRequest))
+		return srv.(FileServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_UploadChunkStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(FileServiceServer).UploadChunkStream(&grpc.GenericServerStream[UploadChunkRequest, UploadChunkResponse]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type FileService_UploadChunkStreamServer = grpc.ClientStreamingServer[UploadChunkRequest, UploadChunkResponse]
-
-func _FileService_CreateShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateShareLinkRequest)
+func _FileService_RetryDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryDeadLettersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).CreateShareLink(ctx, in)
+		return srv.(FileServiceServer).RetryDeadLetters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_CreateShareLink_FullMethodName,
+		FullMethod: FileService_RetryDeadLetters_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).CreateShareLink(ctx, req.(*CreateShareLinkRequest))
+		return srv.(FileServiceServer).RetryDeadLetters(ctx, req.(*RetryDeadLettersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_SaveToMyDrive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SaveToMyDriveRequest)
+func _FileService_ListQuarantinedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuarantinedFilesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).SaveToMyDrive(ctx, in)
+		return srv.(FileServiceServer).ListQuarantinedFiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_SaveToMyDrive_FullMethodName,
+		FullMethod: FileService_ListQuarantinedFiles_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).SaveToMyDrive(ctx, req.(*SaveToMyDriveRequest))
+		return srv.(FileServiceServer).ListQuarantinedFiles(ctx, req.(*ListQuarantinedFilesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_GetUserFileStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserFileStoreRequest)
+func _FileService_ReleaseQuarantinedFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseQuarantinedFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).GetUserFileStore(ctx, in)
+		return srv.(FileServiceServer).ReleaseQuarantinedFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_GetUserFileStore_FullMethodName,
+		FullMethod: FileService_ReleaseQuarantinedFile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).GetUserFileStore(ctx, req.(*GetUserFileStoreRequest))
+		return srv.(FileServiceServer).ReleaseQuarantinedFile(ctx, req.(*ReleaseQuarantinedFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _FileService_UpdateFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateFileRequest)
+func _FileService_QueryAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FileServiceServer).UpdateFile(ctx, in)
+		return srv.(FileServiceServer).QueryAuditLogs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: FileService_UpdateFile_FullMethodName,
+		FullMethod: FileService_QueryAuditLogs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileServiceServer).UpdateFile(ctx, req.(*UpdateFileRequest))
+		return srv.(FileServiceServer).QueryAuditLogs(ctx, req.(*QueryAuditLogsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileService_DumpAuditLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DumpAuditLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileServiceServer).DumpAuditLogs(m, &grpc.GenericServerStream[DumpAuditLogsRequest, ExportAuditLogsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FileService_DumpAuditLogsServer = grpc.ServerStreamingServer[ExportAuditLogsResponse]
+
 // FileService_ServiceDesc is the grpc.ServiceDesc for FileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -861,6 +3283,278 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateFile",
 			Handler:    _FileService_UpdateFile_Handler,
 		},
+		{
+			MethodName: "GetFileMeta",
+			Handler:    _FileService_GetFileMeta_Handler,
+		},
+		{
+			MethodName: "SetFileMeta",
+			Handler:    _FileService_SetFileMeta_Handler,
+		},
+		{
+			MethodName: "DeleteFileMeta",
+			Handler:    _FileService_DeleteFileMeta_Handler,
+		},
+		{
+			MethodName: "CopyFile",
+			Handler:    _FileService_CopyFile_Handler,
+		},
+		{
+			MethodName: "CopyFolder",
+			Handler:    _FileService_CopyFolder_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _FileService_GetJob_Handler,
+		},
+		{
+			MethodName: "BatchMove",
+			Handler:    _FileService_BatchMove_Handler,
+		},
+		{
+			MethodName: "BatchCopy",
+			Handler:    _FileService_BatchCopy_Handler,
+		},
+		{
+			MethodName: "BatchDelete",
+			Handler:    _FileService_BatchDelete_Handler,
+		},
+		{
+			MethodName: "BatchRestore",
+			Handler:    _FileService_BatchRestore_Handler,
+		},
+		{
+			MethodName: "BatchRename",
+			Handler:    _FileService_BatchRename_Handler,
+		},
+		{
+			MethodName: "ResolvePath",
+			Handler:    _FileService_ResolvePath_Handler,
+		},
+		{
+			MethodName: "ListPath",
+			Handler:    _FileService_ListPath_Handler,
+		},
+		{
+			MethodName: "DeletePath",
+			Handler:    _FileService_DeletePath_Handler,
+		},
+		{
+			MethodName: "EnsureFolderPath",
+			Handler:    _FileService_EnsureFolderPath_Handler,
+		},
+		{
+			MethodName: "GetFolderTree",
+			Handler:    _FileService_GetFolderTree_Handler,
+		},
+		{
+			MethodName: "UploadChunk",
+			Handler:    _FileService_UploadChunk_Handler,
+		},
+		{
+			MethodName: "ListUploadParts",
+			Handler:    _FileService_ListUploadParts_Handler,
+		},
+		{
+			MethodName: "AbortUpload",
+			Handler:    _FileService_AbortUpload_Handler,
+		},
+		{
+			MethodName: "ListShareFolder",
+			Handler:    _FileService_ListShareFolder_Handler,
+		},
+		{
+			MethodName: "ListShareFiles",
+			Handler:    _FileService_ListShareFiles_Handler,
+		},
+		{
+			MethodName: "GetShareFile",
+			Handler:    _FileService_GetShareFile_Handler,
+		},
+		{
+			MethodName: "PreviewShareFile",
+			Handler:    _FileService_PreviewShareFile_Handler,
+		},
+		{
+			MethodName: "ListShares",
+			Handler:    _FileService_ListShares_Handler,
+		},
+		{
+			MethodName: "RevokeShares",
+			Handler:    _FileService_RevokeShares_Handler,
+		},
+		{
+			MethodName: "UpdateShare",
+			Handler:    _FileService_UpdateShare_Handler,
+		},
+		{
+			MethodName: "GetShareAccessLog",
+			Handler:    _FileService_GetShareAccessLog_Handler,
+		},
+		{
+			MethodName: "ShareWithUser",
+			Handler:    _FileService_ShareWithUser_Handler,
+		},
+		{
+			MethodName: "RevokeUserShare",
+			Handler:    _FileService_RevokeUserShare_Handler,
+		},
+		{
+			MethodName: "ListCollaborators",
+			Handler:    _FileService_ListCollaborators_Handler,
+		},
+		{
+			MethodName: "ListSharedWithMe",
+			Handler:    _FileService_ListSharedWithMe_Handler,
+		},
+		{
+			MethodName: "GetTeamSpace",
+			Handler:    _FileService_GetTeamSpace_Handler,
+		},
+		{
+			MethodName: "ListSpaceActivity",
+			Handler:    _FileService_ListSpaceActivity_Handler,
+		},
+		{
+			MethodName: "CreateFileRequest",
+			Handler:    _FileService_CreateFileRequest_Handler,
+		},
+		{
+			MethodName: "ListFileRequests",
+			Handler:    _FileService_ListFileRequests_Handler,
+		},
+		{
+			MethodName: "CloseFileRequests",
+			Handler:    _FileService_CloseFileRequests_Handler,
+		},
+		{
+			MethodName: "ListFileRequestUploads",
+			Handler:    _FileService_ListFileRequestUploads_Handler,
+		},
+		{
+			MethodName: "GetPublicFileRequest",
+			Handler:    _FileService_GetPublicFileRequest_Handler,
+		},
+		{
+			MethodName: "SubmitFileRequest",
+			Handler:    _FileService_SubmitFileRequest_Handler,
+		},
+		{
+			MethodName: "CreateVault",
+			Handler:    _FileService_CreateVault_Handler,
+		},
+		{
+			MethodName: "SetPublicKey",
+			Handler:    _FileService_SetPublicKey_Handler,
+		},
+		{
+			MethodName: "GetPublicKeys",
+			Handler:    _FileService_GetPublicKeys_Handler,
+		},
+		{
+			MethodName: "PutVaultKeyEnvelope",
+			Handler:    _FileService_PutVaultKeyEnvelope_Handler,
+		},
+		{
+			MethodName: "GetVaultKeyEnvelope",
+			Handler:    _FileService_GetVaultKeyEnvelope_Handler,
+		},
+		{
+			MethodName: "RevokeVaultKeyEnvelope",
+			Handler:    _FileService_RevokeVaultKeyEnvelope_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _FileService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _FileService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _FileService_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _FileService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _FileService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _FileService_RedeliverWebhook_Handler,
+		},
+		{
+			MethodName: "ListAuditLogs",
+			Handler:    _FileService_ListAuditLogs_Handler,
+		},
+		{
+			MethodName: "ReconcileQuota",
+			Handler:    _FileService_ReconcileQuota_Handler,
+		},
+		{
+			MethodName: "SavePlan",
+			Handler:    _FileService_SavePlan_Handler,
+		},
+		{
+			MethodName: "ListPlans",
+			Handler:    _FileService_ListPlans_Handler,
+		},
+		{
+			MethodName: "AssignPlan",
+			Handler:    _FileService_AssignPlan_Handler,
+		},
+		{
+			MethodName: "GrantCapacity",
+			Handler:    _FileService_GrantCapacity_Handler,
+		},
+		{
+			MethodName: "ListUsersNearQuota",
+			Handler:    _FileService_ListUsersNearQuota_Handler,
+		},
+		{
+			MethodName: "CreateTeamSpace",
+			Handler:    _FileService_CreateTeamSpace_Handler,
+		},
+		{
+			MethodName: "SetSpaceMember",
+			Handler:    _FileService_SetSpaceMember_Handler,
+		},
+		{
+			MethodName: "ScrubStorage",
+			Handler:    _FileService_ScrubStorage_Handler,
+		},
+		{
+			MethodName: "ListScrubFindings",
+			Handler:    _FileService_ListScrubFindings_Handler,
+		},
+		{
+			MethodName: "ReprocessFile",
+			Handler:    _FileService_ReprocessFile_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _FileService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "RetryDeadLetters",
+			Handler:    _FileService_RetryDeadLetters_Handler,
+		},
+		{
+			MethodName: "ListQuarantinedFiles",
+			Handler:    _FileService_ListQuarantinedFiles_Handler,
+		},
+		{
+			MethodName: "ReleaseQuarantinedFile",
+			Handler:    _FileService_ReleaseQuarantinedFile_Handler,
+		},
+		{
+			MethodName: "QueryAuditLogs",
+			Handler:    _FileService_QueryAuditLogs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -873,6 +3567,21 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _FileService_UploadChunkStream_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DownloadShareFile",
+			Handler:       _FileService_DownloadShareFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportAuditLogs",
+			Handler:       _FileService_ExportAuditLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DumpAuditLogs",
+			Handler:       _FileService_DumpAuditLogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "idl/cloudstorage/file.proto",
 }
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.3
-// 	protoc        v5.29.3
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
 // source: idl/cloudstorage/user.proto
 
 package user
@@ -12,6 +12,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -473,109 +474,1281 @@ func (*UpdateInfoResponse) Descriptor() ([]byte, []int) {
 	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{8}
 }
 
-var File_idl_cloudstorage_user_proto protoreflect.FileDescriptor
+// 按用户名或手机号查找用户, 用于邀请协作者, 两者都提供时优先用户名
+type FindUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindUserRequest) Reset() {
+	*x = FindUserRequest{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindUserRequest) ProtoMessage() {}
+
+func (x *FindUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindUserRequest.ProtoReflect.Descriptor instead.
+func (*FindUserRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FindUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FindUserRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+type FindUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"` // 不返回手机号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindUserResponse) Reset() {
+	*x = FindUserResponse{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindUserResponse) ProtoMessage() {}
+
+func (x *FindUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindUserResponse.ProtoReflect.Descriptor instead.
+func (*FindUserResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FindUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OwnerId       int32                  `protobuf:"varint,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	RootFolderId  int64                  `protobuf:"varint,4,opt,name=root_folder_id,json=rootFolderId,proto3" json:"root_folder_id,omitempty"` // 团队空间的根文件夹
+	Role          int32                  `protobuf:"varint,5,opt,name=role,proto3" json:"role,omitempty"`                                       // 当前用户的角色: 1-成员 2-管理员 3-所有者
+	Ctime         int64                  `protobuf:"varint,6,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Team) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Team) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Team) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *Team) GetRootFolderId() int64 {
+	if x != nil {
+		return x.RootFolderId
+	}
+	return 0
+}
+
+func (x *Team) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+func (x *Team) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+type TeamMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          int32                  `protobuf:"varint,3,opt,name=role,proto3" json:"role,omitempty"`
+	Ctime         int64                  `protobuf:"varint,4,opt,name=ctime,proto3" json:"ctime,omitempty"` // 加入时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TeamMember) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TeamMember) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TeamMember) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+func (x *TeamMember) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+type TeamAudit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId       int32                  `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	TargetUserId  int32                  `protobuf:"varint,4,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	Detail        string                 `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	Ctime         int64                  `protobuf:"varint,6,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamAudit) Reset() {
+	*x = TeamAudit{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamAudit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamAudit) ProtoMessage() {}
+
+func (x *TeamAudit) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamAudit.ProtoReflect.Descriptor instead.
+func (*TeamAudit) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TeamAudit) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TeamAudit) GetActorId() int32 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *TeamAudit) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TeamAudit) GetTargetUserId() int32 {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return 0
+}
+
+func (x *TeamAudit) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *TeamAudit) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+// 创建团队及其团队空间, 创建者成为所有者
+type CreateTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamRequest) Reset() {
+	*x = CreateTeamRequest{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamRequest) ProtoMessage() {}
+
+func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateTeamRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateTeamRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamResponse) Reset() {
+	*x = CreateTeamResponse{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamResponse) ProtoMessage() {}
+
+func (x *CreateTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamResponse.ProtoReflect.Descriptor instead.
+func (*CreateTeamResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type ListTeamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTeamsRequest) Reset() {
+	*x = ListTeamsRequest{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTeamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTeamsRequest) ProtoMessage() {}
+
+func (x *ListTeamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTeamsRequest.ProtoReflect.Descriptor instead.
+func (*ListTeamsRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTeamsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListTeamsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []*Team                `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTeamsResponse) Reset() {
+	*x = ListTeamsResponse{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTeamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTeamsResponse) ProtoMessage() {}
+
+func (x *ListTeamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTeamsResponse.ProtoReflect.Descriptor instead.
+func (*ListTeamsResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListTeamsResponse) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type ListTeamMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamId        int32                  `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTeamMembersRequest) Reset() {
+	*x = ListTeamMembersRequest{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTeamMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTeamMembersRequest) ProtoMessage() {}
+
+func (x *ListTeamMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTeamMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListTeamMembersRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListTeamMembersRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListTeamMembersRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+type ListTeamMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*TeamMember          `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTeamMembersResponse) Reset() {
+	*x = ListTeamMembersResponse{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTeamMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTeamMembersResponse) ProtoMessage() {}
+
+func (x *ListTeamMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTeamMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListTeamMembersResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListTeamMembersResponse) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// 按用户名或手机号添加成员, 需要管理员以上角色, 只能授予低于自己的角色
+type AddTeamMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamId        int32                  `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Role          int32                  `protobuf:"varint,5,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMemberRequest) Reset() {
+	*x = AddTeamMemberRequest{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamMemberRequest) ProtoMessage() {}
+
+func (x *AddTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AddTeamMemberRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AddTeamMemberRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *AddTeamMemberRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddTeamMemberRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *AddTeamMemberRequest) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+type AddTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *TeamMember            `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMemberResponse) Reset() {
+	*x = AddTeamMemberResponse{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamMemberResponse) ProtoMessage() {}
+
+func (x *AddTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AddTeamMemberResponse) GetMember() *TeamMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+// 修改成员角色, 所有者将其他成员设为所有者时转让团队, 原所有者成为管理员
+type UpdateTeamMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamId        int32                  `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	MemberId      int32                  `protobuf:"varint,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Role          int32                  `protobuf:"varint,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTeamMemberRequest) Reset() {
+	*x = UpdateTeamMemberRequest{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTeamMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamMemberRequest) ProtoMessage() {}
+
+func (x *UpdateTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateTeamMemberRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateTeamMemberRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *UpdateTeamMemberRequest) GetMemberId() int32 {
+	if x != nil {
+		return x.MemberId
+	}
+	return 0
+}
+
+func (x *UpdateTeamMemberRequest) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+type UpdateTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTeamMemberResponse) Reset() {
+	*x = UpdateTeamMemberResponse{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTeamMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamMemberResponse) ProtoMessage() {}
+
+func (x *UpdateTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{23}
+}
+
+// 移除成员, member_id 为自己时表示退出团队, 所有者须先转让团队
+type RemoveTeamMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamId        int32                  `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	MemberId      int32                  `protobuf:"varint,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTeamMemberRequest) Reset() {
+	*x = RemoveTeamMemberRequest{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTeamMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTeamMemberRequest) ProtoMessage() {}
+
+func (x *RemoveTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RemoveTeamMemberRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RemoveTeamMemberRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *RemoveTeamMemberRequest) GetMemberId() int32 {
+	if x != nil {
+		return x.MemberId
+	}
+	return 0
+}
+
+type RemoveTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTeamMemberResponse) Reset() {
+	*x = RemoveTeamMemberResponse{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTeamMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTeamMemberResponse) ProtoMessage() {}
+
+func (x *RemoveTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{25}
+}
+
+type GetTeamAuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TeamId        int32                  `protobuf:"varint,2,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamAuditRequest) Reset() {
+	*x = GetTeamAuditRequest{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamAuditRequest) ProtoMessage() {}
+
+func (x *GetTeamAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamAuditRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamAuditRequest) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetTeamAuditRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetTeamAuditRequest) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *GetTeamAuditRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetTeamAuditRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetTeamAuditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*TeamAudit           `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamAuditResponse) Reset() {
+	*x = GetTeamAuditResponse{}
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamAuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamAuditResponse) ProtoMessage() {}
+
+func (x *GetTeamAuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_cloudstorage_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamAuditResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamAuditResponse) Descriptor() ([]byte, []int) {
+	return file_idl_cloudstorage_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetTeamAuditResponse) GetLogs() []*TeamAudit {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
 
-var file_idl_cloudstorage_user_proto_rawDesc = []byte{
-	0x0a, 0x1b, 0x69, 0x64, 0x6c, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x73, 0x74, 0x6f, 0x72, 0x61,
-	0x67, 0x65, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x75,
-	0x73, 0x65, 0x72, 0x1a, 0x1b, 0x69, 0x64, 0x6c, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x73, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0x58, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f,
-	0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x22, 0x27, 0x0a, 0x0f, 0x53, 0x65,
-	0x6e, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68,
-	0x6f, 0x6e, 0x65, 0x22, 0x24, 0x0a, 0x10, 0x53, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x22, 0x4f, 0x0a, 0x11, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
-	0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70,
-	0x68, 0x6f, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x22, 0x2a, 0x0a, 0x12, 0x56, 0x65,
-	0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x2d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
-	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x65, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x04,
-	0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x75, 0x73, 0x65,
-	0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x0a,
-	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x6f, 0x72,
-	0x65, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x22, 0x58, 0x0a, 0x11,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x8e, 0x02, 0x0a,
-	0x0b, 0x55, 0x73, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x08,
-	0x53, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e,
-	0x53, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x56, 0x65, 0x72, 0x69, 0x66,
-	0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
-	0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x64, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x55,
-	0x73, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47,
-	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x19, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x2e, 0x75, 0x73, 0x65,
-	0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x07, 0x5a,
-	0x05, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *GetTeamAuditResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
 }
 
+var File_idl_cloudstorage_user_proto protoreflect.FileDescriptor
+
+const file_idl_cloudstorage_user_proto_rawDesc = "" +
+	"\n" +
+	"\x1bidl/cloudstorage/user.proto\x12\x04user\x1a\x1bidl/cloudstorage/file.proto\"X\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06avatar\x18\x04 \x01(\tR\x06avatar\"'\n" +
+	"\x0fSendCodeRequest\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\"$\n" +
+	"\x10SendCodeResponse\x12\x10\n" +
+	"\x03biz\x18\x01 \x01(\tR\x03biz\"O\n" +
+	"\x11VerifyCodeRequest\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x10\n" +
+	"\x03biz\x18\x03 \x01(\tR\x03biz\"*\n" +
+	"\x12VerifyCodeResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"-\n" +
+	"\x12GetUserInfoRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"e\n" +
+	"\x13GetUserInfoResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\x12.\n" +
+	"\n" +
+	"file_store\x18\x02 \x01(\v2\x0f.file.FileStoreR\tfileStore\"X\n" +
+	"\x11UpdateInfoRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06avatar\x18\x03 \x01(\tR\x06avatar\"\x14\n" +
+	"\x12UpdateInfoResponse\";\n" +
+	"\x0fFindUserRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\"2\n" +
+	"\x10FindUserResponse\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"\x95\x01\n" +
+	"\x04Team\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\x05R\aownerId\x12$\n" +
+	"\x0eroot_folder_id\x18\x04 \x01(\x03R\frootFolderId\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\x05R\x04role\x12\x14\n" +
+	"\x05ctime\x18\x06 \x01(\x03R\x05ctime\"c\n" +
+	"\n" +
+	"TeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\x05R\x04role\x12\x14\n" +
+	"\x05ctime\x18\x04 \x01(\x03R\x05ctime\"\xa2\x01\n" +
+	"\tTeamAudit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\x05R\aactorId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12$\n" +
+	"\x0etarget_user_id\x18\x04 \x01(\x05R\ftargetUserId\x12\x16\n" +
+	"\x06detail\x18\x05 \x01(\tR\x06detail\x12\x14\n" +
+	"\x05ctime\x18\x06 \x01(\x03R\x05ctime\"@\n" +
+	"\x11CreateTeamRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"4\n" +
+	"\x12CreateTeamResponse\x12\x1e\n" +
+	"\x04team\x18\x01 \x01(\v2\n" +
+	".user.TeamR\x04team\"+\n" +
+	"\x10ListTeamsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"5\n" +
+	"\x11ListTeamsResponse\x12 \n" +
+	"\x05teams\x18\x01 \x03(\v2\n" +
+	".user.TeamR\x05teams\"J\n" +
+	"\x16ListTeamMembersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x05R\x06teamId\"E\n" +
+	"\x17ListTeamMembersResponse\x12*\n" +
+	"\amembers\x18\x01 \x03(\v2\x10.user.TeamMemberR\amembers\"\x86\x01\n" +
+	"\x14AddTeamMemberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x05R\x06teamId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\x05R\x04role\"A\n" +
+	"\x15AddTeamMemberResponse\x12(\n" +
+	"\x06member\x18\x01 \x01(\v2\x10.user.TeamMemberR\x06member\"|\n" +
+	"\x17UpdateTeamMemberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x05R\x06teamId\x12\x1b\n" +
+	"\tmember_id\x18\x03 \x01(\x05R\bmemberId\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\x05R\x04role\"\x1a\n" +
+	"\x18UpdateTeamMemberResponse\"h\n" +
+	"\x17RemoveTeamMemberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x05R\x06teamId\x12\x1b\n" +
+	"\tmember_id\x18\x03 \x01(\x05R\bmemberId\"\x1a\n" +
+	"\x18RemoveTeamMemberResponse\"o\n" +
+	"\x13GetTeamAuditRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\ateam_id\x18\x02 \x01(\x05R\x06teamId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\"Q\n" +
+	"\x14GetTeamAuditResponse\x12#\n" +
+	"\x04logs\x18\x01 \x03(\v2\x0f.user.TeamAuditR\x04logs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total2\x85\t\n" +
+	"\vUserService\x129\n" +
+	"\bSendCode\x12\x15.user.SendCodeRequest\x1a\x16.user.SendCodeResponse\x12?\n" +
+	"\n" +
+	"VerifyCode\x12\x17.user.VerifyCodeRequest\x1a\x18.user.VerifyCodeResponse\x12B\n" +
+	"\vGetUserInfo\x12\x18.user.GetUserInfoRequest\x1a\x19.user.GetUserInfoResponse\x12?\n" +
+	"\n" +
+	"UpdateInfo\x12\x17.user.UpdateInfoRequest\x1a\x18.user.UpdateInfoResponse\x129\n" +
+	"\bFindUser\x12\x15.user.FindUserRequest\x1a\x16.user.FindUserResponse\x12?\n" +
+	"\n" +
+	"CreateTeam\x12\x17.user.CreateTeamRequest\x1a\x18.user.CreateTeamResponse\x12<\n" +
+	"\tListTeams\x12\x16.user.ListTeamsRequest\x1a\x17.user.ListTeamsResponse\x12N\n" +
+	"\x0fListTeamMembers\x12\x1c.user.ListTeamMembersRequest\x1a\x1d.user.ListTeamMembersResponse\x12H\n" +
+	"\rAddTeamMember\x12\x1a.user.AddTeamMemberRequest\x1a\x1b.user.AddTeamMemberResponse\x12Q\n" +
+	"\x10UpdateTeamMember\x12\x1d.user.UpdateTeamMemberRequest\x1a\x1e.user.UpdateTeamMemberResponse\x12Q\n" +
+	"\x10RemoveTeamMember\x12\x1d.user.RemoveTeamMemberRequest\x1a\x1e.user.RemoveTeamMemberResponse\x12E\n" +
+	"\fGetTeamAudit\x12\x19.user.GetTeamAuditRequest\x1a\x1a.user.GetTeamAuditResponse\x12H\n" +
+	"\rListAuditLogs\x12\x1a.file.ListAuditLogsRequest\x1a\x1b.file.ListAuditLogsResponse\x12P\n" +
+	"\x0fExportAuditLogs\x12\x1c.file.ExportAuditLogsRequest\x1a\x1d.file.ExportAuditLogsResponse0\x01\x12J\n" +
+	"\x0eQueryAuditLogs\x12\x1b.file.QueryAuditLogsRequest\x1a\x1b.file.ListAuditLogsResponse\x12L\n" +
+	"\rDumpAuditLogs\x12\x1a.file.DumpAuditLogsRequest\x1a\x1d.file.ExportAuditLogsResponse0\x01B\aZ\x05/userb\x06proto3"
+
 var (
 	file_idl_cloudstorage_user_proto_rawDescOnce sync.Once
-	file_idl_cloudstorage_user_proto_rawDescData = file_idl_cloudstorage_user_proto_rawDesc
+	file_idl_cloudstorage_user_proto_rawDescData []byte
 )
 
 func file_idl_cloudstorage_user_proto_rawDescGZIP() []byte {
 	file_idl_cloudstorage_user_proto_rawDescOnce.Do(func() {
-		file_idl_cloudstorage_user_proto_rawDescData = protoimpl.X.CompressGZIP(file_idl_cloudstorage_user_proto_rawDescData)
+		file_idl_cloudstorage_user_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_idl_cloudstorage_user_proto_rawDesc), len(file_idl_cloudstorage_user_proto_rawDesc)))
 	})
 	return file_idl_cloudstorage_user_proto_rawDescData
 }
 
-var file_idl_cloudstorage_user_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_idl_cloudstorage_user_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_idl_cloudstorage_user_proto_goTypes = []any{
-	(*User)(nil),                // 0: user.User
-	(*SendCodeRequest)(nil),     // 1: user.SendCodeRequest
-	(*SendCodeResponse)(nil),    // 2: user.SendCodeResponse
-	(*VerifyCodeRequest)(nil),   // 3: user.VerifyCodeRequest
-	(*VerifyCodeResponse)(nil),  // 4: user.VerifyCodeResponse
-	(*GetUserInfoRequest)(nil),  // 5: user.GetUserInfoRequest
-	(*GetUserInfoResponse)(nil), // 6: user.GetUserInfoResponse
-	(*UpdateInfoRequest)(nil),   // 7: user.UpdateInfoRequest
-	(*UpdateInfoResponse)(nil),  // 8: user.UpdateInfoResponse
-	(*file.FileStore)(nil),      // 9: file.FileStore
+	(*User)(nil),                         // 0: user.User
+	(*SendCodeRequest)(nil),              // 1: user.SendCodeRequest
+	(*SendCodeResponse)(nil),             // 2: user.SendCodeResponse
+	(*VerifyCodeRequest)(nil),            // 3: user.VerifyCodeRequest
+	(*VerifyCodeResponse)(nil),           // 4: user.VerifyCodeResponse
+	(*GetUserInfoRequest)(nil),           // 5: user.GetUserInfoRequest
+	(*GetUserInfoResponse)(nil),          // 6: user.GetUserInfoResponse
+	(*UpdateInfoRequest)(nil),            // 7: user.UpdateInfoRequest
+	(*UpdateInfoResponse)(nil),           // 8: user.UpdateInfoResponse
+	(*FindUserRequest)(nil),              // 9: user.FindUserRequest
+	(*FindUserResponse)(nil),             // 10: user.FindUserResponse
+	(*Team)(nil),                         // 11: user.Team
+	(*TeamMember)(nil),                   // 12: user.TeamMember
+	(*TeamAudit)(nil),                    // 13: user.TeamAudit
+	(*CreateTeamRequest)(nil),            // 14: user.CreateTeamRequest
+	(*CreateTeamResponse)(nil),           // 15: user.CreateTeamResponse
+	(*ListTeamsRequest)(nil),             // 16: user.ListTeamsRequest
+	(*ListTeamsResponse)(nil),            // 17: user.ListTeamsResponse
+	(*ListTeamMembersRequest)(nil),       // 18: user.ListTeamMembersRequest
+	(*ListTeamMembersResponse)(nil),      // 19: user.ListTeamMembersResponse
+	(*AddTeamMemberRequest)(nil),         // 20: user.AddTeamMemberRequest
+	(*AddTeamMemberResponse)(nil),        // 21: user.AddTeamMemberResponse
+	(*UpdateTeamMemberRequest)(nil),      // 22: user.UpdateTeamMemberRequest
+	(*UpdateTeamMemberResponse)(nil),     // 23: user.UpdateTeamMemberResponse
+	(*RemoveTeamMemberRequest)(nil),      // 24: user.RemoveTeamMemberRequest
+	(*RemoveTeamMemberResponse)(nil),     // 25: user.RemoveTeamMemberResponse
+	(*GetTeamAuditRequest)(nil),          // 26: user.GetTeamAuditRequest
+	(*GetTeamAuditResponse)(nil),         // 27: user.GetTeamAuditResponse
+	(*file.FileStore)(nil),               // 28: file.FileStore
+	(*file.ListAuditLogsRequest)(nil),    // 29: file.ListAuditLogsRequest
+	(*file.ExportAuditLogsRequest)(nil),  // 30: file.ExportAuditLogsRequest
+	(*file.QueryAuditLogsRequest)(nil),   // 31: file.QueryAuditLogsRequest
+	(*file.DumpAuditLogsRequest)(nil),    // 32: file.DumpAuditLogsRequest
+	(*file.ListAuditLogsResponse)(nil),   // 33: file.ListAuditLogsResponse
+	(*file.ExportAuditLogsResponse)(nil), // 34: file.ExportAuditLogsResponse
 }
 var file_idl_cloudstorage_user_proto_depIdxs = []int32{
-	0, // 0: user.GetUserInfoResponse.user:type_name -> user.User
-	9, // 1: user.GetUserInfoResponse.file_store:type_name -> file.FileStore
-	1, // 2: user.UserService.SendCode:input_type -> user.SendCodeRequest
-	3, // 3: user.UserService.VerifyCode:input_type -> user.VerifyCodeRequest
-	5, // 4: user.UserService.GetUserInfo:input_type -> user.GetUserInfoRequest
-	7, // 5: user.UserService.UpdateInfo:input_type -> user.UpdateInfoRequest
-	2, // 6: user.UserService.SendCode:output_type -> user.SendCodeResponse
-	4, // 7: user.UserService.VerifyCode:output_type -> user.VerifyCodeResponse
-	6, // 8: user.UserService.GetUserInfo:output_type -> user.GetUserInfoResponse
-	8, // 9: user.UserService.UpdateInfo:output_type -> user.UpdateInfoResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: user.GetUserInfoResponse.user:type_name -> user.User
+	28, // 1: user.GetUserInfoResponse.file_store:type_name -> file.FileStore
+	0,  // 2: user.FindUserResponse.user:type_name -> user.User
+	11, // 3: user.CreateTeamResponse.team:type_name -> user.Team
+	11, // 4: user.ListTeamsResponse.teams:type_name -> user.Team
+	12, // 5: user.ListTeamMembersResponse.members:type_name -> user.TeamMember
+	12, // 6: user.AddTeamMemberResponse.member:type_name -> user.TeamMember
+	13, // 7: user.GetTeamAuditResponse.logs:type_name -> user.TeamAudit
+	1,  // 8: user.UserService.SendCode:input_type -> user.SendCodeRequest
+	3,  // 9: user.UserService.VerifyCode:input_type -> user.VerifyCodeRequest
+	5,  // 10: user.UserService.GetUserInfo:input_type -> user.GetUserInfoRequest
+	7,  // 11: user.UserService.UpdateInfo:input_type -> user.UpdateInfoRequest
+	9,  // 12: user.UserService.FindUser:input_type -> user.FindUserRequest
+	14, // 13: user.UserService.CreateTeam:input_type -> user.CreateTeamRequest
+	16, // 14: user.UserService.ListTeams:input_type -> user.ListTeamsRequest
+	18, // 15: user.UserService.ListTeamMembers:input_type -> user.ListTeamMembersRequest
+	20, // 16: user.UserService.AddTeamMember:input_type -> user.AddTeamMemberRequest
+	22, // 17: user.UserService.UpdateTeamMember:input_type -> user.UpdateTeamMemberRequest
+	24, // 18: user.UserService.RemoveTeamMember:input_type -> user.RemoveTeamMemberRequest
+	26, // 19: user.UserService.GetTeamAudit:input_type -> user.GetTeamAuditRequest
+	29, // 20: user.UserService.ListAuditLogs:input_type -> file.ListAuditLogsRequest
+	30, // 21: user.UserService.ExportAuditLogs:input_type -> file.ExportAuditLogsRequest
+	31, // 22: user.UserService.QueryAuditLogs:input_type -> file.QueryAuditLogsRequest
+	32, // 23: user.UserService.DumpAuditLogs:input_type -> file.DumpAuditLogsRequest
+	2,  // 24: user.UserService.SendCode:output_type -> user.SendCodeResponse
+	4,  // 25: user.UserService.VerifyCode:output_type -> user.VerifyCodeResponse
+	6,  // 26: user.UserService.GetUserInfo:output_type -> user.GetUserInfoResponse
+	8,  // 27: user.UserService.UpdateInfo:output_type -> user.UpdateInfoResponse
+	10, // 28: user.UserService.FindUser:output_type -> user.FindUserResponse
+	15, // 29: user.UserService.CreateTeam:output_type -> user.CreateTeamResponse
+	17, // 30: user.UserService.ListTeams:output_type -> user.ListTeamsResponse
+	19, // 31: user.UserService.ListTeamMembers:output_type -> user.ListTeamMembersResponse
+	21, // 32: user.UserService.AddTeamMember:output_type -> user.AddTeamMemberResponse
+	23, // 33: user.UserService.UpdateTeamMember:output_type -> user.UpdateTeamMemberResponse
+	25, // 34: user.UserService.RemoveTeamMember:output_type -> user.RemoveTeamMemberResponse
+	27, // 35: user.UserService.GetTeamAudit:output_type -> user.GetTeamAuditResponse
+	33, // 36: user.UserService.ListAuditLogs:output_type -> file.ListAuditLogsResponse
+	34, // 37: user.UserService.ExportAuditLogs:output_type -> file.ExportAuditLogsResponse
+	33, // 38: user.UserService.QueryAuditLogs:output_type -> file.ListAuditLogsResponse
+	34, // 39: user.UserService.DumpAuditLogs:output_type -> file.ExportAuditLogsResponse
+	24, // [24:40] is the sub-list for method output_type
+	8,  // [8:24] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_idl_cloudstorage_user_proto_init() }
@@ -587,9 +1760,9 @@ func file_idl_cloudstorage_user_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_idl_cloudstorage_user_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_idl_cloudstorage_user_proto_rawDesc), len(file_idl_cloudstorage_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -598,7 +1771,6 @@ func file_idl_cloudstorage_user_proto_init() {
 		MessageInfos:      file_idl_cloudstorage_user_proto_msgTypes,
 	}.Build()
 	File_idl_cloudstorage_user_proto = out.File
-	file_idl_cloudstorage_user_proto_rawDesc = nil
 	file_idl_cloudstorage_user_proto_goTypes = nil
 	file_idl_cloudstorage_user_proto_depIdxs = nil
 }
@@ -1,13 +1,14 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.29.3
+// - protoc             v6.30.2
 // source: idl/cloudstorage/user.proto
 
 package user
 
 import (
 	context "context"
+	file "github.com/crazyfrankie/cloudstorage/rpc_gen/file"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -19,10 +20,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_SendCode_FullMethodName    = "/user.UserService/SendCode"
-	UserService_VerifyCode_FullMethodName  = "/user.UserService/VerifyCode"
-	UserService_GetUserInfo_FullMethodName = "/user.UserService/GetUserInfo"
-	UserService_UpdateInfo_FullMethodName  = "/user.UserService/UpdateInfo"
+	UserService_SendCode_FullMethodName         = "/user.UserService/SendCode"
+	UserService_VerifyCode_FullMethodName       = "/user.UserService/VerifyCode"
+	UserService_GetUserInfo_FullMethodName      = "/user.UserService/GetUserInfo"
+	UserService_UpdateInfo_FullMethodName       = "/user.UserService/UpdateInfo"
+	UserService_FindUser_FullMethodName         = "/user.UserService/FindUser"
+	UserService_CreateTeam_FullMethodName       = "/user.UserService/CreateTeam"
+	UserService_ListTeams_FullMethodName        = "/user.UserService/ListTeams"
+	UserService_ListTeamMembers_FullMethodName  = "/user.UserService/ListTeamMembers"
+	UserService_AddTeamMember_FullMethodName    = "/user.UserService/AddTeamMember"
+	UserService_UpdateTeamMember_FullMethodName = "/user.UserService/UpdateTeamMember"
+	UserService_RemoveTeamMember_FullMethodName = "/user.UserService/RemoveTeamMember"
+	UserService_GetTeamAudit_FullMethodName     = "/user.UserService/GetTeamAudit"
+	UserService_ListAuditLogs_FullMethodName    = "/user.UserService/ListAuditLogs"
+	UserService_ExportAuditLogs_FullMethodName  = "/user.UserService/ExportAuditLogs"
+	UserService_QueryAuditLogs_FullMethodName   = "/user.UserService/QueryAuditLogs"
+	UserService_DumpAuditLogs_FullMethodName    = "/user.UserService/DumpAuditLogs"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -33,6 +46,19 @@ type UserServiceClient interface {
 	VerifyCode(ctx context.Context, in *VerifyCodeRequest, opts ...grpc.CallOption) (*VerifyCodeResponse, error)
 	GetUserInfo(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*GetUserInfoResponse, error)
 	UpdateInfo(ctx context.Context, in *UpdateInfoRequest, opts ...grpc.CallOption) (*UpdateInfoResponse, error)
+	FindUser(ctx context.Context, in *FindUserRequest, opts ...grpc.CallOption) (*FindUserResponse, error)
+	CreateTeam(ctx context.Context, in *CreateTeamRequest, opts ...grpc.CallOption) (*CreateTeamResponse, error)
+	ListTeams(ctx context.Context, in *ListTeamsRequest, opts ...grpc.CallOption) (*ListTeamsResponse, error)
+	ListTeamMembers(ctx context.Context, in *ListTeamMembersRequest, opts ...grpc.CallOption) (*ListTeamMembersResponse, error)
+	AddTeamMember(ctx context.Context, in *AddTeamMemberRequest, opts ...grpc.CallOption) (*AddTeamMemberResponse, error)
+	UpdateTeamMember(ctx context.Context, in *UpdateTeamMemberRequest, opts ...grpc.CallOption) (*UpdateTeamMemberResponse, error)
+	RemoveTeamMember(ctx context.Context, in *RemoveTeamMemberRequest, opts ...grpc.CallOption) (*RemoveTeamMemberResponse, error)
+	GetTeamAudit(ctx context.Context, in *GetTeamAuditRequest, opts ...grpc.CallOption) (*GetTeamAuditResponse, error)
+	ListAuditLogs(ctx context.Context, in *file.ListAuditLogsRequest, opts ...grpc.CallOption) (*file.ListAuditLogsResponse, error)
+	ExportAuditLogs(ctx context.Context, in *file.ExportAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[file.ExportAuditLogsResponse], error)
+	// 以下为管理接口, 不经网关暴露
+	QueryAuditLogs(ctx context.Context, in *file.QueryAuditLogsRequest, opts ...grpc.CallOption) (*file.ListAuditLogsResponse, error)
+	DumpAuditLogs(ctx context.Context, in *file.DumpAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[file.ExportAuditLogsResponse], error)
 }
 
 type userServiceClient struct {
@@ -83,6 +109,144 @@ func (c *userServiceClient) UpdateInfo(ctx context.Context, in *UpdateInfoReques
 	return out, nil
 }
 
+func (c *userServiceClient) FindUser(ctx context.Context, in *FindUserRequest, opts ...grpc.CallOption) (*FindUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindUserResponse)
+	err := c.cc.Invoke(ctx, UserService_FindUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateTeam(ctx context.Context, in *CreateTeamRequest, opts ...grpc.CallOption) (*CreateTeamResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTeamResponse)
+	err := c.cc.Invoke(ctx, UserService_CreateTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListTeams(ctx context.Context, in *ListTeamsRequest, opts ...grpc.CallOption) (*ListTeamsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTeamsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListTeams_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListTeamMembers(ctx context.Context, in *ListTeamMembersRequest, opts ...grpc.CallOption) (*ListTeamMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTeamMembersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListTeamMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AddTeamMember(ctx context.Context, in *AddTeamMemberRequest, opts ...grpc.CallOption) (*AddTeamMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTeamMemberResponse)
+	err := c.cc.Invoke(ctx, UserService_AddTeamMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateTeamMember(ctx context.Context, in *UpdateTeamMemberRequest, opts ...grpc.CallOption) (*UpdateTeamMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTeamMemberResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateTeamMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemoveTeamMember(ctx context.Context, in *RemoveTeamMemberRequest, opts ...grpc.CallOption) (*RemoveTeamMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTeamMemberResponse)
+	err := c.cc.Invoke(ctx, UserService_RemoveTeamMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetTeamAudit(ctx context.Context, in *GetTeamAuditRequest, opts ...grpc.CallOption) (*GetTeamAuditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTeamAuditResponse)
+	err := c.cc.Invoke(ctx, UserService_GetTeamAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListAuditLogs(ctx context.Context, in *file.ListAuditLogsRequest, opts ...grpc.CallOption) (*file.ListAuditLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(file.ListAuditLogsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListAuditLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExportAuditLogs(ctx context.Context, in *file.ExportAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[file.ExportAuditLogsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_ExportAuditLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[file.ExportAuditLogsRequest, file.ExportAuditLogsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportAuditLogsClient = grpc.ServerStreamingClient[file.ExportAuditLogsResponse]
+
+func (c *userServiceClient) QueryAuditLogs(ctx context.Context, in *file.QueryAuditLogsRequest, opts ...grpc.CallOption) (*file.ListAuditLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(file.ListAuditLogsResponse)
+	err := c.cc.Invoke(ctx, UserService_QueryAuditLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DumpAuditLogs(ctx context.Context, in *file.DumpAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[file.ExportAuditLogsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[1], UserService_DumpAuditLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[file.DumpAuditLogsRequest, file.ExportAuditLogsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_DumpAuditLogsClient = grpc.ServerStreamingClient[file.ExportAuditLogsResponse]
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -91,6 +255,19 @@ type UserServiceServer interface {
 	VerifyCode(context.Context, *VerifyCodeRequest) (*VerifyCodeResponse, error)
 	GetUserInfo(context.Context, *GetUserInfoRequest) (*GetUserInfoResponse, error)
 	UpdateInfo(context.Context, *UpdateInfoRequest) (*UpdateInfoResponse, error)
+	FindUser(context.Context, *FindUserRequest) (*FindUserResponse, error)
+	CreateTeam(context.Context, *CreateTeamRequest) (*CreateTeamResponse, error)
+	ListTeams(context.Context, *ListTeamsRequest) (*ListTeamsResponse, error)
+	ListTeamMembers(context.Context, *ListTeamMembersRequest) (*ListTeamMembersResponse, error)
+	AddTeamMember(context.Context, *AddTeamMemberRequest) (*AddTeamMemberResponse, error)
+	UpdateTeamMember(context.Context, *UpdateTeamMemberRequest) (*UpdateTeamMemberResponse, error)
+	RemoveTeamMember(context.Context, *RemoveTeamMemberRequest) (*RemoveTeamMemberResponse, error)
+	GetTeamAudit(context.Context, *GetTeamAuditRequest) (*GetTeamAuditResponse, error)
+	ListAuditLogs(context.Context, *file.ListAuditLogsRequest) (*file.ListAuditLogsResponse, error)
+	ExportAuditLogs(*file.ExportAuditLogsRequest, grpc.ServerStreamingServer[file.ExportAuditLogsResponse]) error
+	// 以下为管理接口, 不经网关暴露
+	QueryAuditLogs(context.Context, *file.QueryAuditLogsRequest) (*file.ListAuditLogsResponse, error)
+	DumpAuditLogs(*file.DumpAuditLogsRequest, grpc.ServerStreamingServer[file.ExportAuditLogsResponse]) error
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -113,6 +290,42 @@ func (UnimplementedUserServiceServer) GetUserInfo(context.Context, *GetUserInfoR
 func (UnimplementedUserServiceServer) UpdateInfo(context.Context, *UpdateInfoRequest) (*UpdateInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateInfo not implemented")
 }
+func (UnimplementedUserServiceServer) FindUser(context.Context, *FindUserRequest) (*FindUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindUser not implemented")
+}
+func (UnimplementedUserServiceServer) CreateTeam(context.Context, *CreateTeamRequest) (*CreateTeamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTeam not implemented")
+}
+func (UnimplementedUserServiceServer) ListTeams(context.Context, *ListTeamsRequest) (*ListTeamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTeams not implemented")
+}
+func (UnimplementedUserServiceServer) ListTeamMembers(context.Context, *ListTeamMembersRequest) (*ListTeamMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTeamMembers not implemented")
+}
+func (UnimplementedUserServiceServer) AddTeamMember(context.Context, *AddTeamMemberRequest) (*AddTeamMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTeamMember not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateTeamMember(context.Context, *UpdateTeamMemberRequest) (*UpdateTeamMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTeamMember not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveTeamMember(context.Context, *RemoveTeamMemberRequest) (*RemoveTeamMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTeamMember not implemented")
+}
+func (UnimplementedUserServiceServer) GetTeamAudit(context.Context, *GetTeamAuditRequest) (*GetTeamAuditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeamAudit not implemented")
+}
+func (UnimplementedUserServiceServer) ListAuditLogs(context.Context, *file.ListAuditLogsRequest) (*file.ListAuditLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditLogs not implemented")
+}
+func (UnimplementedUserServiceServer) ExportAuditLogs(*file.ExportAuditLogsRequest, grpc.ServerStreamingServer[file.ExportAuditLogsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditLogs not implemented")
+}
+func (UnimplementedUserServiceServer) QueryAuditLogs(context.Context, *file.QueryAuditLogsRequest) (*file.ListAuditLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLogs not implemented")
+}
+func (UnimplementedUserServiceServer) DumpAuditLogs(*file.DumpAuditLogsRequest, grpc.ServerStreamingServer[file.ExportAuditLogsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DumpAuditLogs not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -206,6 +419,208 @@ func _UserService_UpdateInfo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_FindUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).FindUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_FindUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).FindUser(ctx, req.(*FindUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateTeam(ctx, req.(*CreateTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListTeams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTeamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListTeams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListTeams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListTeams(ctx, req.(*ListTeamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListTeamMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTeamMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListTeamMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListTeamMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListTeamMembers(ctx, req.(*ListTeamMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AddTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTeamMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddTeamMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddTeamMember(ctx, req.(*AddTeamMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTeamMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateTeamMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateTeamMember(ctx, req.(*UpdateTeamMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTeamMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveTeamMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveTeamMember(ctx, req.(*RemoveTeamMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetTeamAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTeamAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetTeamAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetTeamAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetTeamAudit(ctx, req.(*GetTeamAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(file.ListAuditLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListAuditLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListAuditLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListAuditLogs(ctx, req.(*file.ListAuditLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExportAuditLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(file.ExportAuditLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ExportAuditLogs(m, &grpc.GenericServerStream[file.ExportAuditLogsRequest, file.ExportAuditLogsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportAuditLogsServer = grpc.ServerStreamingServer[file.ExportAuditLogsResponse]
+
+func _UserService_QueryAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(file.QueryAuditLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).QueryAuditLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_QueryAuditLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).QueryAuditLogs(ctx, req.(*file.QueryAuditLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DumpAuditLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(file.DumpAuditLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).DumpAuditLogs(m, &grpc.GenericServerStream[file.DumpAuditLogsRequest, file.ExportAuditLogsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_DumpAuditLogsServer = grpc.ServerStreamingServer[file.ExportAuditLogsResponse]
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +644,58 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateInfo",
 			Handler:    _UserService_UpdateInfo_Handler,
 		},
+		{
+			MethodName: "FindUser",
+			Handler:    _UserService_FindUser_Handler,
+		},
+		{
+			MethodName: "CreateTeam",
+			Handler:    _UserService_CreateTeam_Handler,
+		},
+		{
+			MethodName: "ListTeams",
+			Handler:    _UserService_ListTeams_Handler,
+		},
+		{
+			MethodName: "ListTeamMembers",
+			Handler:    _UserService_ListTeamMembers_Handler,
+		},
+		{
+			MethodName: "AddTeamMember",
+			Handler:    _UserService_AddTeamMember_Handler,
+		},
+		{
+			MethodName: "UpdateTeamMember",
+			Handler:    _UserService_UpdateTeamMember_Handler,
+		},
+		{
+			MethodName: "RemoveTeamMember",
+			Handler:    _UserService_RemoveTeamMember_Handler,
+		},
+		{
+			MethodName: "GetTeamAudit",
+			Handler:    _UserService_GetTeamAudit_Handler,
+		},
+		{
+			MethodName: "ListAuditLogs",
+			Handler:    _UserService_ListAuditLogs_Handler,
+		},
+		{
+			MethodName: "QueryAuditLogs",
+			Handler:    _UserService_QueryAuditLogs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAuditLogs",
+			Handler:       _UserService_ExportAuditLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DumpAuditLogs",
+			Handler:       _UserService_DumpAuditLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "idl/cloudstorage/user.proto",
 }